@@ -18,13 +18,23 @@ import (
 	"github.com/taubyte/tau/pkg/raft"
 	"github.com/taubyte/tau/pkg/sensors"
 	commonSpecs "github.com/taubyte/tau/pkg/specs/common"
+	"github.com/taubyte/tau/pkg/tracing"
 	"github.com/taubyte/tau/services/common/httpsvc"
 	slices "github.com/taubyte/tau/utils/slices/string"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func Start(ctx context.Context, serviceConfig config.Config) error {
 	setLogLevel()
 
+	if endpoint := serviceConfig.TracingEndpoint(); endpoint != "" {
+		shutdown, err := tracing.Setup(ctx, endpoint, attribute.String("tau.shape", serviceConfig.Shape()))
+		if err != nil {
+			return fmt.Errorf("setting up tracing failed with: %w", err)
+		}
+		defer shutdown(context.Background())
+	}
+
 	ctx, ctx_cancel := context.WithCancel(ctx)
 	sigkill := make(chan os.Signal, 1)
 	signal.Notify(sigkill, os.Interrupt, syscall.SIGTERM)
@@ -100,3 +100,21 @@ type RawRepoDataOuter struct {
 	Provider      string      `json:"provider"`
 	URL           string      `json:"url"`
 }
+
+// StorageCredentials is the S3 key pair returned for the storages of a project
+type StorageCredentials struct {
+	AccessKeyId     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+}
+
+// StorageCredentialsReturn is the data returned from the server when getting
+// storage credentials
+type StorageCredentialsReturn struct {
+	Credentials *StorageCredentials `json:"credentials"`
+}
+
+// StorageAccessKeysReturn is the data returned from the server when listing
+// storage credentials
+type StorageAccessKeysReturn struct {
+	AccessKeys []string `json:"access_keys"`
+}
@@ -0,0 +1,39 @@
+package client
+
+import "fmt"
+
+// IssueStorageCredentials creates a new S3 key pair for the storages of a
+// project. The secret is only returned here.
+func (c *Client) IssueStorageCredentials(projectId string) (*StorageCredentials, error) {
+	var data StorageCredentialsReturn
+
+	if err := c.http.Post("/project/"+projectId+"/storage/credentials", nil, &data); err != nil {
+		return nil, fmt.Errorf("issuing storage credentials of project `%s` failed with: %w", projectId, err)
+	}
+
+	if data.Credentials == nil {
+		return nil, fmt.Errorf("no storage credentials returned for project `%s`", projectId)
+	}
+
+	return data.Credentials, nil
+}
+
+// StorageAccessKeys returns the access keys issued for the storages of a project
+func (c *Client) StorageAccessKeys(projectId string) ([]string, error) {
+	var data StorageAccessKeysReturn
+
+	if err := c.http.Get("/project/"+projectId+"/storage/credentials", &data); err != nil {
+		return nil, fmt.Errorf("listing storage credentials of project `%s` failed with: %w", projectId, err)
+	}
+
+	return data.AccessKeys, nil
+}
+
+// RevokeStorageCredentials deletes an S3 key pair of a project
+func (c *Client) RevokeStorageCredentials(projectId, accessKey string) error {
+	if err := c.http.Delete("/project/"+projectId+"/storage/credentials/"+accessKey, nil, nil); err != nil {
+		return fmt.Errorf("revoking storage credentials `%s` of project `%s` failed with: %w", accessKey, projectId, err)
+	}
+
+	return nil
+}
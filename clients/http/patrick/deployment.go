@@ -0,0 +1,113 @@
+package patrick
+
+import (
+	"fmt"
+	"net/url"
+
+	patrickIface "github.com/taubyte/tau/core/services/patrick"
+)
+
+// Deployments returns the commits deployed on a branch of a project, newest
+// first. The default branch is used if branch is empty.
+func (c *Client) Deployments(projectId, branch string) (deployments []*patrickIface.Deployment, err error) {
+	receive := &struct {
+		Deployments []*patrickIface.Deployment
+	}{}
+	path := "/deployments/" + projectId
+	if len(branch) > 0 {
+		path += "?branch=" + url.QueryEscape(branch)
+	}
+
+	if err = c.http.Get(path, &receive); err != nil {
+		err = fmt.Errorf("failed getting deployments of project `%s` with: %w", projectId, err)
+		return
+	}
+
+	return receive.Deployments, nil
+}
+
+// Rollback points the current commit of a project's branch back to an earlier
+// deployment, the one matching to or the one before the current if empty.
+func (c *Client) Rollback(projectId, branch, to string) (deployment *patrickIface.Deployment, err error) {
+	receive := &struct {
+		Deployment patrickIface.Deployment
+	}{}
+	body := map[string]string{
+		"branch": branch,
+		"to":     to,
+	}
+
+	if err = c.http.Post("/rollback/"+projectId, body, &receive); err != nil {
+		err = fmt.Errorf("failed rolling back project `%s` with: %w", projectId, err)
+		return
+	}
+
+	return &receive.Deployment, nil
+}
+
+// Canary returns the traffic policy of a project's branch.
+func (c *Client) Canary(projectId, branch string) (canary *patrickIface.Canary, err error) {
+	receive := &struct {
+		Canary patrickIface.Canary
+	}{}
+	path := "/canary/" + projectId
+	if len(branch) > 0 {
+		path += "?branch=" + url.QueryEscape(branch)
+	}
+
+	if err = c.http.Get(path, &receive); err != nil {
+		err = fmt.Errorf("failed getting canary of project `%s` with: %w", projectId, err)
+		return
+	}
+
+	return &receive.Canary, nil
+}
+
+// StartCanary sends weight percent of the traffic of a project's branch to
+// the deployed commit matching canary, or to the commit deployed next if
+// empty. On a branch with a canary already, it changes its weight.
+func (c *Client) StartCanary(projectId, branch, canary string, weight int) (policy *patrickIface.Canary, err error) {
+	receive := &struct {
+		Canary patrickIface.Canary
+	}{}
+	body := map[string]interface{}{
+		"branch": branch,
+		"canary": canary,
+		"weight": weight,
+	}
+
+	if err = c.http.Post("/canary/"+projectId, body, &receive); err != nil {
+		err = fmt.Errorf("failed starting canary of project `%s` with: %w", projectId, err)
+		return
+	}
+
+	return &receive.Canary, nil
+}
+
+// PromoteCanary serves the canary commit of a project's branch to all of its
+// traffic.
+func (c *Client) PromoteCanary(projectId, branch string) (*patrickIface.Deployment, error) {
+	return c.endCanary(projectId, branch, "promote")
+}
+
+// AbortCanary serves the stable commit of a project's branch to all of its
+// traffic.
+func (c *Client) AbortCanary(projectId, branch string) (*patrickIface.Deployment, error) {
+	return c.endCanary(projectId, branch, "abort")
+}
+
+func (c *Client) endCanary(projectId, branch, action string) (deployment *patrickIface.Deployment, err error) {
+	receive := &struct {
+		Deployment patrickIface.Deployment
+	}{}
+	body := map[string]string{
+		"branch": branch,
+	}
+
+	if err = c.http.Post("/canary/"+projectId+"/"+action, body, &receive); err != nil {
+		err = fmt.Errorf("failed to %s canary of project `%s` with: %w", action, projectId, err)
+		return
+	}
+
+	return &receive.Deployment, nil
+}
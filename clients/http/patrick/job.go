@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
 	patrickIface "github.com/taubyte/tau/core/services/patrick"
 )
@@ -86,3 +87,51 @@ func (c *Client) Retry(jid string) (response interface{}, err error) {
 
 	return
 }
+
+// Runs returns the recorded runs of a scheduled function, newest first.
+func (c *Client) Runs(projectId, functionId string) (runs []*patrickIface.ScheduledRun, err error) {
+	receive := &struct {
+		Runs []*patrickIface.ScheduledRun
+	}{}
+	url := "/schedules/" + projectId + "/" + functionId
+	if err = c.http.Get(url, &receive); err != nil {
+		err = fmt.Errorf("failed getting runs of function `%s` with: %w", functionId, err)
+		return
+	}
+
+	return receive.Runs, nil
+}
+
+// FunctionLogs returns, oldest first, the logs of the calls of a function
+// started at or after since, a unix nano time, or the latest ones if since is
+// zero.
+func (c *Client) FunctionLogs(projectId, functionId string, since int64) (logs []*patrickIface.FunctionLog, err error) {
+	receive := &struct {
+		Logs []*patrickIface.FunctionLog
+	}{}
+	url := "/logs/functions/" + projectId + "/" + functionId
+	if since > 0 {
+		url += "?since=" + strconv.FormatInt(since, 10)
+	}
+
+	if err = c.http.Get(url, &receive); err != nil {
+		err = fmt.Errorf("failed getting logs of function `%s` with: %w", functionId, err)
+		return
+	}
+
+	return receive.Logs, nil
+}
+
+// FollowLog returns the output of a running job from offset, waiting a few
+// seconds for some. Done is set once the whole log was returned, or when the
+// job is over and its log stored.
+func (c *Client) FollowLog(jid string, offset int64) (chunk *patrickIface.JobLogChunk, err error) {
+	chunk = new(patrickIface.JobLogChunk)
+	url := "/logs/follow/" + jid + "?offset=" + strconv.FormatInt(offset, 10)
+	if err = c.http.Get(url, chunk); err != nil {
+		err = fmt.Errorf("failed following log of job `%s` with: %w", jid, err)
+		return nil, err
+	}
+
+	return chunk, nil
+}
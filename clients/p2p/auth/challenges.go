@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"fmt"
+
+	iface "github.com/taubyte/tau/core/services/auth"
+	"github.com/taubyte/tau/p2p/streams/command"
+	"github.com/taubyte/tau/utils/maps"
+)
+
+func (c *Client) Challenges() iface.Challenges {
+	return (*Challenges)(c)
+}
+
+func (h *Challenges) Set(fqdn, value string) error {
+	if _, err := h.client.Send("acme", command.Body{"action": "challenge-set", "fqdn": fqdn, "value": value}, h.peers...); err != nil {
+		return fmt.Errorf("setting challenge of `%s` failed with: %w", fqdn, err)
+	}
+
+	return nil
+}
+
+func (h *Challenges) Get(fqdn string) ([]string, error) {
+	resp, err := h.client.Send("acme", command.Body{"action": "challenge-get", "fqdn": fqdn}, h.peers...)
+	if err != nil {
+		return nil, fmt.Errorf("getting challenges of `%s` failed with: %w", fqdn, err)
+	}
+
+	if _, ok := resp["values"]; !ok {
+		return nil, nil
+	}
+
+	return maps.StringArray(resp, "values")
+}
+
+func (h *Challenges) Delete(fqdn, value string) error {
+	if _, err := h.client.Send("acme", command.Body{"action": "challenge-delete", "fqdn": fqdn, "value": value}, h.peers...); err != nil {
+		return fmt.Errorf("deleting challenge of `%s` failed with: %w", fqdn, err)
+	}
+
+	return nil
+}
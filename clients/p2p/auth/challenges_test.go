@@ -0,0 +1,58 @@
+//go:build dreaming
+
+package auth_test
+
+import (
+	"testing"
+
+	commonIface "github.com/taubyte/tau/core/common"
+	"github.com/taubyte/tau/dream"
+	"gotest.tools/v3/assert"
+
+	_ "github.com/taubyte/tau/clients/p2p/accounts/dream"
+	_ "github.com/taubyte/tau/clients/p2p/auth/dream"
+	_ "github.com/taubyte/tau/services/accounts/dream"
+	_ "github.com/taubyte/tau/services/auth/dream"
+)
+
+func TestChallenges_Dreaming(t *testing.T) {
+	m, err := dream.New(t.Context())
+	assert.NilError(t, err)
+	defer m.Close()
+
+	u, err := m.New(dream.UniverseConfig{Name: t.Name()})
+	assert.NilError(t, err)
+
+	err = u.StartWithConfig(&dream.Config{
+		Services: map[string]commonIface.ServiceConfig{
+			"auth": {},
+		},
+		Simples: map[string]dream.SimpleConfig{
+			"client": {
+				Clients: dream.SimpleConfigClients{
+					Auth: &commonIface.ClientConfig{},
+				}.Compat(),
+			},
+		},
+	})
+	assert.NilError(t, err)
+
+	simple, err := u.Simple("client")
+	assert.NilError(t, err)
+
+	auth, err := simple.Auth()
+	assert.NilError(t, err)
+
+	fqdn := "_acme-challenge.g.tau.link"
+	assert.NilError(t, auth.Challenges().Set(fqdn, "token"))
+
+	values, err := auth.Challenges().Get(fqdn)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, values, []string{"token"})
+
+	assert.NilError(t, auth.Challenges().Delete(fqdn, "token"))
+
+	values, err = auth.Challenges().Get(fqdn)
+	assert.NilError(t, err)
+	assert.Equal(t, len(values), 0)
+}
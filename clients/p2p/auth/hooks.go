@@ -41,9 +41,41 @@ func (h *Hooks) New(obj map[string]interface{}) (iface.Hook, error) {
 			GithubId: github_id,
 			Secret:   secret,
 		}, nil
+	case "gitlab", "gitea", "bitbucket":
+		repository, err := maps.String(obj, "repository")
+		if err != nil {
+			return nil, errors.New("Creating hook: " + err.Error())
+		}
+
+		secret, err := maps.String(obj, "secret")
+		if err != nil {
+			return nil, errors.New("Creating hook: " + err.Error())
+		}
+
+		repositoryId, err := maps.Int(obj, "repository_id")
+		if err != nil {
+			return nil, errors.New("Creating hook: " + err.Error())
+		}
+
+		return &iface.GitHook{
+			Id:           id,
+			Provider:     provider,
+			Repository:   repository,
+			RepositoryId: repositoryId,
+			Secret:       secret,
+		}, nil
 	default:
+		return nil, fmt.Errorf("Creating hook: unknown provider `%s`", provider)
+	}
+}
+
+func (h *Hooks) Register(provider, repository string) (iface.Hook, error) {
+	response, err := h.client.Send("hooks", command.Body{"action": "register", "provider": provider, "repository": repository}, h.peers...)
+	if err != nil {
 		return nil, err
 	}
+
+	return h.New(response)
 }
 
 func (h *Hooks) Get(hook_id string) (iface.Hook, error) {
@@ -28,6 +28,22 @@ func (r *Repositories) Github() iface.GithubRepositories {
 	return (*GithubRepositories)(r)
 }
 
+func (r *Repositories) Git(provider string) iface.GitRepositories {
+	return &GitRepositories{client: (*Client)(r), provider: provider}
+}
+
+func (r *GitRepositories) Get(id int) (iface.GithubRepository, error) {
+	logger.Debugf("Getting %s Repository `%d`", r.provider, id)
+	defer logger.Debugf("Getting %s Repository `%d` done", r.provider, id)
+
+	response, err := r.client.client.Send("repositories", command.Body{"action": "get", "provider": r.provider, "id": id}, r.client.peers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*GithubRepositories)(r.client).New(response)
+}
+
 func (r *GithubRepositories) New(obj map[string]interface{}) (iface.GithubRepository, error) {
 	var repo GithubRepository
 	var err error
@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/taubyte/tau/p2p/streams/command"
+	"github.com/taubyte/tau/utils/maps"
+)
+
+// StorageCredentials returns the project and secret of an S3 access key.
+// Auth only answers substrate nodes.
+func (c *Client) StorageCredentials(accessKey string) (projectId, secretKey string, err error) {
+	resp, err := c.client.Send("storage", command.Body{"action": "credentials", "access": accessKey}, c.peers...)
+	if err != nil {
+		return "", "", fmt.Errorf("getting storage credentials of `%s` failed with: %w", accessKey, err)
+	}
+
+	if projectId, err = maps.String(resp, "project"); err != nil {
+		return "", "", err
+	}
+
+	if secretKey, err = maps.String(resp, "secret"); err != nil {
+		return "", "", err
+	}
+
+	return projectId, secretKey, nil
+}
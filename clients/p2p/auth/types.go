@@ -17,6 +17,8 @@ type Client struct {
 
 type Stats Client
 
+type Challenges Client
+
 type Hooks Client
 
 type Projects Client
@@ -24,6 +26,11 @@ type Projects Client
 type Repositories Client
 type GithubRepositories Repositories
 
+type GitRepositories struct {
+	client   *Client
+	provider string
+}
+
 type RepositoryCommon struct {
 	project string
 	Name    string
@@ -49,6 +49,8 @@ type remoteKV struct {
 
 var _ coreKvdb.KVDB = (*remoteKV)(nil)
 var _ hoarderIface.NxKVDB = (*remoteKV)(nil)
+var _ hoarderIface.CasKVDB = (*remoteKV)(nil)
+var _ hoarderIface.ScanKVDB = (*remoteKV)(nil)
 
 func (r *remoteKV) instanceBody() command.Body {
 	return command.Body{
@@ -250,6 +252,26 @@ func (r *remoteKV) PutNx(ctx context.Context, key string, v []byte) (bool, error
 	return existed, nil
 }
 
+// CompareAndSwap writes the key only if its value on the serving replica hashes
+// to expected, or if it is absent when expected is empty. Returns swapped=false
+// when nothing was written.
+func (r *remoteKV) CompareAndSwap(ctx context.Context, key string, expected, v []byte) (bool, error) {
+	resp, err := r.do(ctx, command.Body{
+		hoarderSpecs.BodyKVOp:     hoarderSpecs.KVCas,
+		hoarderSpecs.BodyKey:      key,
+		hoarderSpecs.BodyExpected: expected,
+		hoarderSpecs.BodyValue:    v,
+	})
+	if err != nil {
+		return false, err
+	}
+	if maps.TryString(resp, hoarderSpecs.BodyCode) == hoarderSpecs.CodeOverCapacity {
+		return false, errors.New("no space left for value")
+	}
+	swapped, _ := maps.Bool(resp, hoarderSpecs.BodySwapped)
+	return swapped, nil
+}
+
 func (r *remoteKV) Delete(ctx context.Context, key string) error {
 	_, err := r.do(ctx, command.Body{hoarderSpecs.BodyKVOp: hoarderSpecs.KVDelete, hoarderSpecs.BodyKey: key})
 	return err
@@ -263,6 +285,20 @@ func (r *remoteKV) List(ctx context.Context, prefix string) ([]string, error) {
 	return maps.StringArray(resp, hoarderSpecs.BodyKeys)
 }
 
+// Scan lists at most limit keys with the prefix, in order, after the given one.
+func (r *remoteKV) Scan(ctx context.Context, prefix, after string, limit int) ([]string, error) {
+	resp, err := r.do(ctx, command.Body{
+		hoarderSpecs.BodyKVOp:   hoarderSpecs.KVList,
+		hoarderSpecs.BodyPrefix: prefix,
+		hoarderSpecs.BodyCursor: after,
+		hoarderSpecs.BodyLimit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return maps.StringArray(resp, hoarderSpecs.BodyKeys)
+}
+
 func (r *remoteKV) ListRegEx(ctx context.Context, prefix string, regexs ...string) ([]string, error) {
 	resp, err := r.do(ctx, command.Body{
 		hoarderSpecs.BodyKVOp:   hoarderSpecs.KVListRegex,
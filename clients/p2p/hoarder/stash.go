@@ -68,3 +68,26 @@ func (c *Client) Stash(cid string, data io.Reader, opts ...hoarderIface.StashOpt
 	}
 	return nil
 }
+
+// Release drops owner's reference to a stashed CID; the hoarder unstashes it
+// once no owner references it. Only WithoutFanout applies, marking a
+// hoarder→hoarder release so the receiver only drops its own copy.
+func (c *Client) Release(cid, owner string, opts ...hoarderIface.StashOption) (bool, error) {
+	cfg := &hoarderIface.StashConfig{Fanout: true}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	resp, err := c.Send(hoarderSpecs.HoarderCommand, command.Body{
+		hoarderSpecs.BodyAction: hoarderSpecs.ActionRelease,
+		hoarderSpecs.BodyCid:    cid,
+		hoarderSpecs.BodyOwner:  owner,
+		hoarderSpecs.BodyFanout: cfg.Fanout,
+	}, c.peers...)
+	if err != nil {
+		return false, fmt.Errorf("releasing %s failed with: %w", cid, err)
+	}
+
+	released, _ := resp[hoarderSpecs.BodyReleased].(bool)
+	return released, nil
+}
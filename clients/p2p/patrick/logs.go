@@ -0,0 +1,22 @@
+package patrick
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	iface "github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/p2p/streams/command"
+)
+
+func (c *Client) PushFunctionLogs(logs []*iface.FunctionLog) error {
+	data, err := cbor.Marshal(logs)
+	if err != nil {
+		return fmt.Errorf("marshalling %d logs failed with: %w", len(logs), err)
+	}
+
+	if _, err = c.Send("logs", command.Body{"action": "push", "logs": data}, c.peers...); err != nil {
+		return fmt.Errorf("pushing %d logs failed with: %w", len(logs), err)
+	}
+
+	return nil
+}
@@ -111,3 +111,7 @@ func (s *Starfish) Timeout(jid string) error {
 func (s *Starfish) Cancel(jid string, cid_log map[string]string) (interface{}, error) {
 	return nil, fmt.Errorf("not implemented")
 }
+
+func (s *Starfish) PushFunctionLogs(logs []*patrick.FunctionLog) error {
+	return fmt.Errorf("not implemented")
+}
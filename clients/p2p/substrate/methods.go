@@ -1,14 +1,19 @@
 package substrate
 
 import (
+	"time"
+
 	"github.com/taubyte/tau/p2p/streams/client"
 )
 
-func (c *Client) ProxyHTTP(host, path, method string, ops ...client.Option[client.Request]) (<-chan *client.Response, error) {
+// ProxyHTTP asks substrate nodes whether they serve a request. trafficKey is
+// the key the request is assigned a commit by, see spec.TrafficPolicy.
+func (c *Client) ProxyHTTP(host, path, method, trafficKey string, ops ...client.Option[client.Request]) (<-chan *client.Response, error) {
 	body := map[string]interface{}{
-		BodyHost:   host,
-		BodyPath:   path,
-		BodyMethod: method,
+		BodyHost:       host,
+		BodyPath:       path,
+		BodyMethod:     method,
+		BodyTrafficKey: trafficKey,
 	}
 
 	mainOptions := append(c.defaultOptions(), client.Body(body))
@@ -16,6 +21,21 @@ func (c *Client) ProxyHTTP(host, path, method string, ops ...client.Option[clien
 	return c.client.New(CommandHTTP, append(mainOptions, ops...)...).Do()
 }
 
+// RunSchedule asks a substrate node to call a scheduled function for the tick
+// scheduled at the given time, missed counting the ticks it stands in for.
+func (c *Client) RunSchedule(projectId, applicationId, functionId string, scheduled time.Time, missed int, ops ...client.Option[client.Request]) (<-chan *client.Response, error) {
+	body := map[string]interface{}{
+		BodyProject:     projectId,
+		BodyApplication: applicationId,
+		BodyFunction:    functionId,
+		BodyScheduled:   scheduled.UnixNano(),
+		BodyMissed:      missed,
+	}
+
+	mainOptions := append(c.defaultOptions(), client.Body(body))
+	return c.client.New(CommandSchedule, append(mainOptions, ops...)...).Do()
+}
+
 func (c *Client) defaultOptions() []client.Option[client.Request] {
 	options := make([]client.Option[client.Request], 0, 10)
 	params := c.defaults
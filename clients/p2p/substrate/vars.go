@@ -8,9 +8,29 @@ var (
 )
 
 const (
-	CommandHTTP = "proxy-http"
+	CommandHTTP     = "proxy-http"
+	CommandSchedule = "schedule"
 
 	BodyHost   = "host"
 	BodyPath   = "path"
 	BodyMethod = "method"
+
+	// BodyTrafficKey assigns the request a commit, when its project splits
+	// traffic between two.
+	BodyTrafficKey = "traffic"
+
+	BodyProject     = "project"
+	BodyApplication = "application"
+	BodyFunction    = "function"
+	BodyScheduled   = "scheduled"
+	BodyMissed      = "missed"
+
+	ResponseStarted  = "started"
+	ResponseFinished = "finished"
+
+	ResponseProject  = "project"
+	ResponseBranch   = "branch"
+	ResponseResource = "resource"
+	ResponseCommit   = "commit"
+	ResponseRoute    = "route"
 )
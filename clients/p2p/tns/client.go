@@ -11,10 +11,13 @@ import (
 	"github.com/taubyte/tau/p2p/streams/client"
 	"github.com/taubyte/tau/p2p/streams/command"
 	"github.com/taubyte/tau/utils/mapstructure"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	spec "github.com/taubyte/tau/pkg/specs/common"
 	"github.com/taubyte/tau/pkg/specs/extract"
 	"github.com/taubyte/tau/pkg/specs/methods"
+	"github.com/taubyte/tau/pkg/tracing"
 	"github.com/taubyte/tau/utils/maps"
 
 	srvCommon "github.com/taubyte/tau/services/common"
@@ -26,6 +29,7 @@ func New(ctx context.Context, node peer.Node) (tns.Client, error) {
 		err error
 	)
 
+	c.ctx = context.Background()
 	c.cache = newCache(node)
 	c.node = node
 	c.client, err = client.New(node, srvCommon.TnsProtocol)
@@ -45,7 +49,7 @@ func (c *Client) Close() {
 
 /****** LIST *******/
 func (c *Client) List(depth int) ([][]string, error) {
-	response, err := c.client.Send("list", command.Body{"depth": depth}, c.peers...)
+	response, err := c.client.SendContext(c.ctx, "list", command.Body{"depth": depth}, c.peers...)
 	if err != nil {
 		logger.Error(err)
 		return nil, err
@@ -85,11 +89,14 @@ func (c *Client) List(depth int) ([][]string, error) {
 
 /****** FETCH *******/
 // Fetch a key, does not watch nor cache value
-func (c *Client) Fetch(path tns.Path) (tns.Object, error) {
-	var err error
+func (c *Client) Fetch(path tns.Path) (_ tns.Object, err error) {
+	ctx, span := tracing.Child(c.ctx, "tns.fetch", trace.WithAttributes(attribute.String("tns.path", path.String())))
+	defer func() { tracing.End(span, err) }()
+
 	object := c.cache.get(path)
+	span.SetAttributes(attribute.Bool("tns.cached", object != nil))
 	if object == nil {
-		object, err = c.fetch(path.Slice())
+		object, err = c.fetch(ctx, path.Slice())
 		if err != nil {
 			return nil, err
 		}
@@ -105,11 +112,14 @@ func (c *Client) Fetch(path tns.Path) (tns.Object, error) {
 
 /****** FETCH *******/
 // Fetch a key, does not watch nor cache value
-func (c *Client) Lookup(query tns.Query) (interface{}, error) {
+func (c *Client) Lookup(query tns.Query) (_ interface{}, err error) {
 	logger.Debugf("Fetching prefixes %v/%v", query, query)
 	defer logger.Debugf("Fetching prefixes %v/%v DONE", query, query)
 
-	return c.lookup(query)
+	ctx, span := tracing.Child(c.ctx, "tns.lookup", trace.WithAttributes(attribute.StringSlice("tns.prefix", query.Prefix)))
+	defer func() { tracing.End(span, err) }()
+
+	return c.lookup(ctx, query)
 }
 
 /****** PUSH *******/
@@ -118,7 +128,7 @@ func (c *Client) Push(path []string, data interface{}) error {
 	logger.Debugf("Pushing object at %s", path)
 	defer logger.Debugf("Pushing keys %s DONE", path)
 
-	response, err := c.client.Send("push", command.Body{
+	response, err := c.client.SendContext(c.ctx, "push", command.Body{
 		"path": path,
 		"data": data,
 	}, c.peers...)
@@ -143,8 +153,8 @@ func (c *Client) Push(path []string, data interface{}) error {
 
 /****** COMMON *******/
 
-func (c *Client) fetch(path []string) (interface{}, error) {
-	response, err := c.client.Send("fetch", command.Body{"path": path}, c.peers...)
+func (c *Client) fetch(ctx context.Context, path []string) (interface{}, error) {
+	response, err := c.client.SendContext(ctx, "fetch", command.Body{"path": path}, c.peers...)
 	if err != nil {
 		logger.Error("fetch failed with:", err)
 		return nil, err
@@ -158,8 +168,8 @@ func (c *Client) fetch(path []string) (interface{}, error) {
 	return obj, nil
 }
 
-func (c *Client) lookup(query tns.Query) ([]string, error) {
-	response, err := c.client.Send("lookup", command.Body{"prefix": query.Prefix, "regex": query.RegEx}, c.peers...)
+func (c *Client) lookup(ctx context.Context, query tns.Query) ([]string, error) {
+	response, err := c.client.SendContext(ctx, "lookup", command.Body{"prefix": query.Prefix, "regex": query.RegEx}, c.peers...)
 	if err != nil {
 		logger.Error("lookup failed with:", err)
 		return nil, err
@@ -1,15 +1,28 @@
 package tns
 
 import (
+	"context"
+
 	peerCore "github.com/libp2p/go-libp2p/core/peer"
 	tns "github.com/taubyte/tau/core/services/tns"
 )
 
 func (c *Client) Peers(pids ...peerCore.ID) tns.Client {
 	return &Client{
+		ctx:    c.ctx,
 		client: c.client,
 		node:   c.node,
 		peers:  pids,
 		cache:  c.cache,
 	}
 }
+
+func (c *Client) WithContext(ctx context.Context) tns.Client {
+	return &Client{
+		ctx:    ctx,
+		client: c.client,
+		node:   c.node,
+		peers:  c.peers,
+		cache:  c.cache,
+	}
+}
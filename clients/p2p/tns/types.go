@@ -13,6 +13,7 @@ import (
 )
 
 type Client struct {
+	ctx    context.Context
 	node   peer.Node
 	client *client.Client
 	peers  []peerCore.ID
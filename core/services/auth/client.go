@@ -21,6 +21,9 @@ type Client interface {
 	Repositories() Repositories
 	Secrets() Secrets
 	Stats() Stats // TODO: rename State
+	Challenges() Challenges
+	// StorageCredentials returns the project and secret of an S3 access key.
+	StorageCredentials(accessKey string) (projectId, secretKey string, err error)
 	Peers(...peerCore.ID) Client
 	Close()
 }
@@ -34,6 +37,14 @@ type Secrets interface {
 	PublicKeys(ctx context.Context, opts ...PublicKeyOption) ([]DistributedKey, error)
 }
 
+// Challenges holds the values answering ACME DNS-01 challenges, each expiring
+// a few minutes after it's set.
+type Challenges interface {
+	Set(fqdn, value string) error
+	Get(fqdn string) ([]string, error)
+	Delete(fqdn, value string) error
+}
+
 type DomainRegistration struct {
 	Token string `json:"token"`
 	Entry string `json:"entry"`
@@ -47,12 +58,16 @@ type Stats interface {
 type Hook interface {
 	Github() (*GithubHook, error)
 	Bitbucket() (*BitbucketHook, error)
+	Git() (*GitHook, error)
 }
 
 type Hooks interface {
 	Get(hook_id string) (Hook, error)
 	New(obj map[string]interface{}) (Hook, error)
 	List() ([]string, error)
+	// Register creates a push hook for a provider that is not wired through
+	// a repository registration flow (gitlab, gitea, bitbucket).
+	Register(provider, repository string) (Hook, error)
 }
 
 type Projects interface {
@@ -74,6 +89,13 @@ type Project struct {
 }
 type Repositories interface {
 	Github() GithubRepositories
+	// Git returns the repositories of a provider: github, gitlab, gitea or
+	// bitbucket. Repository ids are only unique per provider.
+	Git(provider string) GitRepositories
+}
+
+type GitRepositories interface {
+	Get(id int) (GithubRepository, error)
 }
 
 type GithubRepositories interface {
@@ -97,6 +119,16 @@ type GithubHook struct {
 	Secret   string
 }
 
+// GitHook is the provider-neutral view of a push hook.
+type GitHook struct {
+	Id         string
+	Provider   string
+	Repository string
+	// RepositoryId is the numeric id jobs of the repository are keyed by.
+	RepositoryId int
+	Secret       string
+}
+
 type GithubRepository interface {
 	Repository
 	PrivateKey() string
@@ -110,3 +142,26 @@ func (h *GithubHook) Github() (*GithubHook, error) {
 func (h *GithubHook) Bitbucket() (*BitbucketHook, error) {
 	return nil, errors.New("not a Bitbucket hook")
 }
+
+func (h *GithubHook) Git() (*GitHook, error) {
+	return &GitHook{
+		Id:       h.Id,
+		Provider: "github",
+		Secret:   h.Secret,
+	}, nil
+}
+
+func (h *GitHook) Github() (*GithubHook, error) {
+	return nil, errors.New("not a Github hook")
+}
+
+func (h *GitHook) Bitbucket() (*BitbucketHook, error) {
+	if h.Provider != "bitbucket" {
+		return nil, errors.New("not a Bitbucket hook")
+	}
+	return &BitbucketHook{Id: h.Id}, nil
+}
+
+func (h *GitHook) Git() (*GitHook, error) {
+	return h, nil
+}
@@ -27,6 +27,10 @@ type Client interface {
 	// the current fleet-clamped stash replica target — the check a byte holder
 	// runs before dropping its local copy.
 	StashStatus(cids ...string) (claims map[string]int, target int, err error)
+	// Release drops owner's reference to a stashed CID. Once no owner references
+	// it, hoarders unstash it and drop the bytes; released reports whether that
+	// happened.
+	Release(cid, owner string, opts ...StashOption) (released bool, err error)
 	Peers(...peerCore.ID) Client
 	Close()
 }
@@ -46,6 +50,21 @@ type NxKVDB interface {
 	PutNx(ctx context.Context, key string, value []byte) (existed bool, err error)
 }
 
+// CasKVDB is a KVDB handle that also supports compare-and-swap. expected is the
+// SHA-256 of the value the key must hold on the serving replica, empty when it
+// must be absent; swapped is false when nothing was written.
+type CasKVDB interface {
+	kvdb.KVDB
+	CompareAndSwap(ctx context.Context, key string, expected, value []byte) (swapped bool, err error)
+}
+
+// ScanKVDB is a KVDB handle that lists keys a page at a time. Scan returns, in
+// order, at most limit keys with the prefix that sort after the given one.
+type ScanKVDB interface {
+	kvdb.KVDB
+	Scan(ctx context.Context, prefix, after string, limit int) ([]string, error)
+}
+
 // StashConfig carries push options. Target is the desired replica count; Owner
 // is the storage instance hash the blocks belong to; Fanout is whether the
 // receiving hoarder re-pushes to co-claimants (false for hoarder→hoarder
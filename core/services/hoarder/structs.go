@@ -2,12 +2,14 @@ package hoarder
 
 // ResourceKind identifies what a placement/registry entry is for. Global is a
 // project-wide database hosted by hoarders without per-resource TNS validation.
+// Messaging is the durable log of a persistent messaging channel.
 type ResourceKind int
 
 const (
 	Database ResourceKind = iota
 	Storage
 	Global
+	Messaging
 )
 
 // Auction carries a placement request: the resource kind plus the identity/
@@ -15,6 +15,9 @@ type Client interface {
 	Timeout(jid string) error
 	Cancel(jid string, cid_log map[string]string) (interface{}, error)
 	DatabaseStats() (kvdb.Stats, error)
+	// PushFunctionLogs hands the logs of function calls to patrick. Only
+	// substrate nodes may push them.
+	PushFunctionLogs(logs []*FunctionLog) error
 	Peers(...peerCore.ID) Client
 	Close()
 }
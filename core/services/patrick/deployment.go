@@ -0,0 +1,23 @@
+package patrick
+
+// Deployment is a commit of a project's config deployed on a branch. Assets
+// maps the ids of the resources built from code to the asset cids they used
+// while the commit was live, so rolling back to it restores them too.
+type Deployment struct {
+	Commit  string            `json:"commit"`
+	Branch  string            `json:"branch"`
+	Time    int64             `json:"time"` // unix nano
+	Assets  map[string]string `json:"assets,omitempty"`
+	Current bool              `json:"current,omitempty"`
+}
+
+// Canary is the traffic policy of a project's branch: Weight percent of the
+// clients are served Canary, the others Stable. Inactive policies serve the
+// current commit to everyone.
+type Canary struct {
+	Branch string `json:"branch"`
+	Stable string `json:"stable,omitempty"`
+	Canary string `json:"canary,omitempty"`
+	Weight int    `json:"weight"`
+	Active bool   `json:"active"`
+}
@@ -6,10 +6,12 @@ import (
 	"github.com/taubyte/tau/utils/id"
 )
 
-// PushEventJobID returns a stable job id for a GitHub push webhook payload.
+// PushEventJobID returns a stable job id for a push webhook payload.
 // It uses repository id, ref, after, and repository.pushed_at. When pushed_at
 // is missing (zero) or ref/after are empty, it falls back to a random id for
-// legacy payloads and backward compatibility.
+// legacy payloads and backward compatibility. GitHub keys keep their original
+// shape so ids of already stored jobs do not change; other providers are
+// namespaced by their provider name.
 func PushEventJobID(meta *Meta) string {
 	if meta == nil {
 		return id.Generate(0)
@@ -17,6 +19,10 @@ func PushEventJobID(meta *Meta) string {
 	if meta.Repository.PushedAt == 0 || meta.After == "" || meta.Ref == "" {
 		return id.Generate(meta.Repository.ID)
 	}
-	key := fmt.Sprintf("v1:github:%d:%s:%s:%d", meta.Repository.ID, meta.Ref, meta.After, meta.Repository.PushedAt)
+	provider := meta.Repository.Provider
+	if provider == "" {
+		provider = "github"
+	}
+	key := fmt.Sprintf("v1:%s:%d:%s:%s:%d", provider, meta.Repository.ID, meta.Ref, meta.After, meta.Repository.PushedAt)
 	return id.GenerateDeterministic(key)
 }
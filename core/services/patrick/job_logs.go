@@ -0,0 +1,17 @@
+package patrick
+
+// JobLogsTopic is where the monkey running a job publishes its log as it is
+// written.
+func JobLogsTopic(jobId string) string {
+	return "/monkey/v1/logs/" + jobId
+}
+
+// JobLogChunk is a piece of the log of a running job, starting at Offset.
+// Done marks the end of the log, Offset being its length. A chunk with Replay
+// set and no data asks the monkey to publish the log again from Offset.
+type JobLogChunk struct {
+	Offset int64  `cbor:"1,keyasint"`
+	Data   []byte `cbor:"2,keyasint"`
+	Done   bool   `cbor:"3,keyasint"`
+	Replay bool   `cbor:"4,keyasint"`
+}
@@ -0,0 +1,20 @@
+package patrick
+
+// FunctionLog records one invocation of a function: its output and how the
+// call went. Times are unix nano.
+type FunctionLog struct {
+	ProjectId   string `cbor:"1,keyasint"`
+	Application string `cbor:"2,keyasint"`
+	FunctionId  string `cbor:"3,keyasint"`
+	Branch      string `cbor:"4,keyasint"`
+	Commit      string `cbor:"5,keyasint"`
+	Node        string `cbor:"6,keyasint"`
+	Started     int64  `cbor:"7,keyasint"`
+	Duration    int64  `cbor:"8,keyasint"`
+	Memory      uint64 `cbor:"9,keyasint"` // bytes of linear memory after the call
+	Error       string `cbor:"10,keyasint"`
+	Stdout      string `cbor:"11,keyasint"`
+	Stderr      string `cbor:"12,keyasint"`
+	// Truncated is set when the output went over what a node keeps of a call.
+	Truncated bool `cbor:"13,keyasint"`
+}
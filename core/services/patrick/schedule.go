@@ -0,0 +1,41 @@
+package patrick
+
+// RunStatus is the outcome of one tick of a scheduled function.
+type RunStatus string
+
+const (
+	RunRunning   RunStatus = "running"
+	RunSucceeded RunStatus = "succeeded"
+	RunFailed    RunStatus = "failed"
+	// RunMissed marks a tick too late to fire when the scheduler caught up.
+	RunMissed RunStatus = "missed"
+)
+
+// ScheduledRun records one tick of a scheduled function. Times are unix nano.
+type ScheduledRun struct {
+	ProjectId   string    `cbor:"1,keyasint"`
+	Application string    `cbor:"2,keyasint"`
+	FunctionId  string    `cbor:"3,keyasint"`
+	Scheduled   int64     `cbor:"4,keyasint"`
+	Started     int64     `cbor:"5,keyasint"`
+	Finished    int64     `cbor:"6,keyasint"`
+	Node        string    `cbor:"7,keyasint"`
+	Status      RunStatus `cbor:"8,keyasint"`
+	Missed      int       `cbor:"9,keyasint"` // earlier ticks coalesced into this one
+	Error       string    `cbor:"10,keyasint"`
+}
+
+func (s RunStatus) Unicode() string {
+	switch s {
+	case RunRunning:
+		return "►"
+	case RunSucceeded:
+		return "✔"
+	case RunFailed:
+		return "×"
+	case RunMissed:
+		return "■"
+	default:
+		return "？"
+	}
+}
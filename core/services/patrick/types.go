@@ -2,6 +2,7 @@ package patrick
 
 import (
 	"encoding/json"
+	"strings"
 	"sync"
 
 	"github.com/fxamacker/cbor/v2"
@@ -68,6 +69,15 @@ func (r *Repository) normalize() {
 	}
 }
 
+// GitProvider returns the provider the repository is registered under in auth.
+// Jobs queued before providers were recorded are GitHub's.
+func (r *Repository) GitProvider() string {
+	if r.Provider == "" {
+		return "github"
+	}
+	return strings.ToLower(r.Provider)
+}
+
 type DelayConfig struct {
 	Time int `cbor:"1,keyasint"` // Inject delay in second
 }
@@ -7,7 +7,28 @@ type KV interface {
 	Put(ctx context.Context, key string, v []byte) error
 	Delete(ctx context.Context, key string) error
 	List(ctx context.Context, prefix string) ([]string, error)
+	// Scan lists, in order, at most limit keys with the prefix that sort after
+	// the given one, every key when limit is not positive.
+	Scan(ctx context.Context, prefix, after string, limit int) ([]string, error)
+	// Batch groups writes applied together on Commit.
+	Batch(ctx context.Context) (Batch, error)
+	// PutNx stores v only if key is absent, returning whether it existed.
+	PutNx(ctx context.Context, key string, v []byte) (existed bool, err error)
+	// CompareAndSwap stores v only if the SHA-256 of the value of key is
+	// expected, or if key is absent when expected is empty.
+	CompareAndSwap(ctx context.Context, key string, expected, v []byte) (swapped bool, err error)
+	// Increment adds delta to the integer stored at key, absent counting as
+	// zero, and returns the result.
+	Increment(ctx context.Context, key string, delta int64) (int64, error)
 	Close()
 	UpdateSize(size uint64)
+	// UpdateKey sets the key values are encrypted with at rest, none when empty.
+	UpdateKey(key string) error
 	Size(ctx context.Context) (uint64, error)
 }
+
+type Batch interface {
+	Put(key string, v []byte) error
+	Delete(key string) error
+	Commit() error
+}
@@ -0,0 +1,34 @@
+package events
+
+import (
+	"time"
+
+	"github.com/taubyte/tau/core/services/substrate"
+	"github.com/taubyte/tau/core/services/substrate/components"
+	"github.com/taubyte/tau/core/services/substrate/events"
+)
+
+type MatchDefinition struct {
+	Project     string
+	Application string
+	Function    string
+}
+
+func (m *MatchDefinition) String() string {
+	return m.Project + m.Application + m.Function
+}
+
+func (m *MatchDefinition) CachePrefix() string {
+	return m.Project
+}
+
+type Service interface {
+	components.ServiceComponent
+	substrate.EventService
+}
+
+type Serviceable interface {
+	components.FunctionServiceable
+	Handle(ev *events.Event) (time.Time, error)
+	Name() string
+}
@@ -13,6 +13,30 @@ type Service interface {
 	Subscribe(projectId, appId, resource, channel string) error
 	Publish(ctx context.Context, projectId, appId, resource, channel string, data []byte) error
 	WebSocketURL(projectId, appId, channel string) (string, error)
+	// Read returns, oldest first, at most limit messages logged on a persistent
+	// channel from the given offset on.
+	Read(ctx context.Context, projectId, appId, channel string, offset uint64, limit int) ([]*Entry, error)
+	// Offset returns the offset a subscriber of a persistent channel reads next.
+	Offset(ctx context.Context, projectId, appId, channel, subscriber string) (uint64, error)
+	// Commit records the offset a subscriber of a persistent channel reads next.
+	Commit(ctx context.Context, projectId, appId, channel, subscriber string, offset uint64) error
+}
+
+// Entry is a message logged on a persistent channel.
+type Entry struct {
+	Offset uint64
+	Time   time.Time
+	Source string
+	Data   []byte
+}
+
+// Log is the durable log of a persistent channel.
+type Log interface {
+	Append(ctx context.Context, source string, data []byte) (offset uint64, err error)
+	Read(ctx context.Context, offset uint64, limit int) ([]*Entry, error)
+	Offset(ctx context.Context, subscriber string) (uint64, error)
+	Commit(ctx context.Context, subscriber string, offset uint64) error
+	Config() *structureSpec.Messaging
 }
 
 type Messaging interface {
@@ -49,4 +73,6 @@ type ServiceWithLookup interface {
 	Service
 	Lookup(matcher MatchDefinition) (picks []Serviceable, err error)
 	Context() context.Context
+	// Log returns the log of the matcher's channel, nil when it isn't persistent.
+	Log(matcher MatchDefinition) (Log, error)
 }
@@ -0,0 +1,41 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/taubyte/tau/core/services/substrate/components"
+)
+
+// Tick is one activation of a scheduled function, as fired by the scheduler.
+type Tick struct {
+	// Scheduled is the activation time the cron expression produced.
+	Scheduled time.Time `json:"scheduled"`
+	// Missed counts earlier activations coalesced into this one.
+	Missed int `json:"missed,omitempty"`
+}
+
+type MatchDefinition struct {
+	Project     string
+	Application string
+	Function    string
+}
+
+func (m *MatchDefinition) String() string {
+	return m.Project + m.Application + m.Function
+}
+
+func (m *MatchDefinition) CachePrefix() string {
+	return m.Project
+}
+
+type Service interface {
+	components.ServiceComponent
+	Run(ctx context.Context, matcher *MatchDefinition, tick Tick) error
+}
+
+type Serviceable interface {
+	components.FunctionServiceable
+	Handle(tick Tick) (time.Time, error)
+	Name() string
+}
@@ -47,6 +47,8 @@ type Storage interface {
 	Id() string
 	Kvdb() kvdb.KVDB
 	ContextConfig() Context
-	UpdateCapacity(size uint64)
+	// UpdateConfig applies a new config of the storage: its capacity and
+	// lifecycle rules.
+	UpdateConfig(config *structureSpec.Storage)
 	Config() *structureSpec.Storage
 }
@@ -64,3 +64,11 @@ type MatchDefinition interface {
 	String() string
 	CachePrefix() string
 }
+
+// PinnedMatchDefinition is implemented by match definitions that can be bound
+// to a commit of a branch, served instead of the current one. An empty commit
+// means the definition isn't pinned.
+type PinnedMatchDefinition interface {
+	MatchDefinition
+	Pinned() (branch, commit string)
+}
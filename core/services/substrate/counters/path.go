@@ -11,6 +11,7 @@ type Path interface {
 	Failed() path
 	Join(toJoin string) path
 	Memory() path
+	Rejected() path
 	SmartOp(smartOpId string) path
 	String() string
 	Success() path
@@ -54,6 +55,10 @@ func (c path) Memory() path {
 	return join(c, memory)
 }
 
+func (c path) Rejected() path {
+	return join(c, rejected)
+}
+
 func (c path) Execution() path {
 	return join(c, execution)
 }
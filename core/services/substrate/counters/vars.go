@@ -7,8 +7,9 @@ var (
 )
 
 const (
-	time   = "t"
-	memory = "m"
+	time     = "t"
+	memory   = "m"
+	rejected = "r"
 
 	failed  = "f"
 	success = "s"
@@ -0,0 +1,19 @@
+package events
+
+import "context"
+
+type changeKey struct{}
+
+// WithChange marks ctx as the context of a function run for a change. The
+// changes made under it trigger no function, so functions can't trigger each
+// other in a loop.
+func WithChange(ctx context.Context) context.Context {
+	return context.WithValue(ctx, changeKey{}, true)
+}
+
+// FromChange reports whether ctx is the context of a function run for a
+// change.
+func FromChange(ctx context.Context) bool {
+	marked, _ := ctx.Value(changeKey{}).(bool)
+	return marked
+}
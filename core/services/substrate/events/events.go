@@ -0,0 +1,41 @@
+package events
+
+import "time"
+
+// Kind is what happened to a storage file or a database key.
+type Kind string
+
+const (
+	Put        Kind = "put"
+	Delete     Kind = "delete"
+	NewVersion Kind = "new-version"
+)
+
+// Kinds lists every kind of change, in the order triggers document them.
+var Kinds = []Kind{Put, Delete, NewVersion}
+
+// Resource is the kind of resource a change happened on, and the trigger type
+// of the functions it invokes.
+type Resource string
+
+const (
+	Storage  Resource = "storage"
+	Database Resource = "database"
+)
+
+// Event is a change of a storage file or a database key, as functions receive
+// it.
+type Event struct {
+	Resource Resource `json:"resource"`
+	// Name is the name of the storage or database.
+	Name string `json:"name"`
+	Kind Kind   `json:"kind"`
+	// Key is the path of the file or the database key.
+	Key string `json:"key"`
+	// Version is the file version, 0 for database keys and deletions of every
+	// version.
+	Version int `json:"version,omitempty"`
+	// Size is the size of the value put.
+	Size int       `json:"size,omitempty"`
+	Time time.Time `json:"time"`
+}
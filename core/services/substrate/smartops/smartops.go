@@ -2,6 +2,7 @@ package smartops
 
 import (
 	"context"
+	"fmt"
 )
 
 type EventCaller interface {
@@ -28,3 +29,13 @@ type SmartOpsCache interface {
 type Util interface {
 	GPU() bool
 }
+
+// BlockedError is returned by a guarded resource when one of its smartops
+// exits with a non-zero code.
+type BlockedError struct {
+	Code uint32
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("blocked by smartops with code %d", e.Code)
+}
@@ -3,9 +3,12 @@ package substrate
 import (
 	"context"
 	"io"
+	"time"
 
 	services "github.com/taubyte/tau/core/services"
+	"github.com/taubyte/tau/core/services/patrick"
 	"github.com/taubyte/tau/core/services/substrate/counters"
+	"github.com/taubyte/tau/core/services/substrate/events"
 	"github.com/taubyte/tau/core/services/substrate/smartops"
 	"github.com/taubyte/tau/core/services/tns"
 	"github.com/taubyte/tau/core/vm"
@@ -22,6 +25,10 @@ type Service interface {
 	Counter() CounterService
 	// SmartOps returns the smartops service attached to the Substrate
 	SmartOps() SmartOpsService
+	// Logs returns the function log service attached to the Substrate
+	Logs() LogService
+	// Events returns the storage and database event service attached to the Substrate
+	Events() EventService
 	Orbitals() []vm.Plugin
 
 	Dev() bool
@@ -30,7 +37,8 @@ type Service interface {
 }
 
 type ProxyClient interface {
-	ProxyHTTP(host string, path string, method string, ops ...client.Option[client.Request]) (<-chan *client.Response, error)
+	ProxyHTTP(host string, path string, method string, trafficKey string, ops ...client.Option[client.Request]) (<-chan *client.Response, error)
+	RunSchedule(projectId, applicationId, functionId string, scheduled time.Time, missed int, ops ...client.Option[client.Request]) (<-chan *client.Response, error)
 	io.Closer
 }
 
@@ -44,3 +52,15 @@ type CounterService interface {
 	Push(...*counters.WrappedMetric)
 	Implemented() bool
 }
+
+type LogService interface {
+	Service
+	Push(*patrick.FunctionLog)
+}
+
+type EventService interface {
+	Service
+	// Emit dispatches a change, made under ctx, to the functions it triggers,
+	// without waiting for them.
+	Emit(ctx context.Context, projectId, applicationId string, ev *events.Event)
+}
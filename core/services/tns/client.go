@@ -1,6 +1,8 @@
 package tns
 
 import (
+	"context"
+
 	peerCore "github.com/libp2p/go-libp2p/core/peer"
 	"github.com/taubyte/tau/core/kvdb"
 	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
@@ -45,6 +47,10 @@ type Client interface {
 	Stats() Stats
 
 	Peers(...peerCore.ID) Client
+
+	// WithContext returns a client whose calls are part of the trace of ctx,
+	// if any, and cancelled with it.
+	WithContext(ctx context.Context) Client
 }
 
 type Stats interface {
@@ -13,6 +13,9 @@ import (
 
 	"github.com/taubyte/tau/p2p/peer"
 	cr "github.com/taubyte/tau/p2p/streams/command/response"
+	"github.com/taubyte/tau/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/libp2p/go-libp2p/core/discovery"
 	"github.com/libp2p/go-libp2p/core/network"
@@ -52,6 +55,7 @@ type peerRequest struct {
 }
 
 type Request struct {
+	ctx        context.Context
 	client     *Client
 	to         []peerCore.ID
 	cmd        string
@@ -91,6 +95,15 @@ func Body(body command.Body) Option[Request] {
 	}
 }
 
+// Context makes the request part of the trace of ctx, if any: each command
+// sent is a span whose context the peer receives.
+func Context(ctx context.Context) Option[Request] {
+	return func(s *Request) error {
+		s.ctx = ctx
+		return nil
+	}
+}
+
 func Threshold(threshold int) Option[Request] {
 	return func(s *Request) error {
 		s.threshold = threshold
@@ -180,6 +193,7 @@ func New(node peer.Node, path string, opts ...Option[Client]) (*Client, error) {
 
 func (c *Client) New(cmd string, opts ...Option[Request]) *Request {
 	r := &Request{
+		ctx:       context.Background(),
 		client:    c,
 		cmd:       cmd,
 		to:        make([]peerCore.ID, 0),
@@ -434,10 +448,10 @@ func (r *Request) Do() (<-chan *Response, error) {
 		if len(strms) == 0 {
 			return nil, fmt.Errorf("no streams could be opened for command %q", r.cmd)
 		}
-		return r.client.send(r.cmd, r.body, strms, r.threshold, r.cmdTimeout)
+		return r.client.send(r.ctx, r.cmd, r.body, strms, r.threshold, r.cmdTimeout)
 	}
 
-	return r.client.send(r.cmd, r.body, nil, r.threshold, r.cmdTimeout)
+	return r.client.send(r.ctx, r.cmd, r.body, nil, r.threshold, r.cmdTimeout)
 }
 
 func (r *Response) CloseRead() {
@@ -452,8 +466,31 @@ func (r *Response) Close() {
 	r.ReadWriter.(network.Stream).Reset()
 }
 
-func (c *Client) sendTo(strm stream, deadline time.Time, cmdName string, body command.Body) *Response {
+// sendTo sends the command to strm as a span of the trace of ctx, if any.
+func (c *Client) sendTo(ctx context.Context, strm stream, deadline time.Time, cmdName string, body command.Body) *Response {
+	ctx, span := tracing.Child(ctx, "p2p.command",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("p2p.protocol", c.path),
+			attribute.String("p2p.command", cmdName),
+			attribute.String("p2p.peer", strm.ID.String()),
+		),
+	)
+
 	cmd := command.New(cmdName, body)
+	if span.SpanContext().IsValid() {
+		cmd.Trace = make(map[string]string)
+		tracing.Inject(ctx, cmd.Trace)
+	}
+
+	res := c.exchange(strm, deadline, cmd)
+	tracing.End(span, res.err)
+
+	return res
+}
+
+func (c *Client) exchange(strm stream, deadline time.Time, cmd *command.Command) *Response {
+	cmdName := cmd.Command
 
 	if err := strm.SetWriteDeadline(deadline); err != nil {
 		if !strings.Contains(err.Error(), "deadline not supported") {
@@ -511,7 +548,7 @@ func (c *Client) sendTo(strm stream, deadline time.Time, cmdName string, body co
 	}
 }
 
-func (c *Client) send(cmdName string, body command.Body, streams []stream, threshold int, timeout time.Duration) (<-chan *Response, error) {
+func (c *Client) send(traceCtx context.Context, cmdName string, body command.Body, streams []stream, threshold int, timeout time.Duration) (<-chan *Response, error) {
 	select {
 	case <-c.ctx.Done():
 		return nil, errors.New("client context ended")
@@ -599,7 +636,7 @@ func (c *Client) send(cmdName string, body command.Body, streams []stream, thres
 						pid:        _strm.ID,
 						err:        ctx.Err(),
 					}
-				case responses <- c.sendTo(_strm, cmdDD, cmdName, body):
+				case responses <- c.sendTo(traceCtx, _strm, cmdDD, cmdName, body):
 				}
 			}(strm)
 		}
@@ -628,9 +665,15 @@ func (c *Client) syncSend(cmd string, opts ...Option[Request]) (cr.Response, err
 }
 
 func (c *Client) Send(cmd string, body command.Body, peers ...peerCore.ID) (cr.Response, error) {
+	return c.SendContext(context.Background(), cmd, body, peers...)
+}
+
+// SendContext is Send as part of the trace of ctx, if any, and cancelled with
+// it.
+func (c *Client) SendContext(ctx context.Context, cmd string, body command.Body, peers ...peerCore.ID) (cr.Response, error) {
 	if len(peers) > 0 {
-		return c.syncSend(cmd, Body(body), To(peers...), Threshold(len(peers)))
+		return c.syncSend(cmd, Context(ctx), Body(body), To(peers...), Threshold(len(peers)))
 	} else {
-		return c.syncSend(cmd, Body(body))
+		return c.syncSend(cmd, Context(ctx), Body(body))
 	}
 }
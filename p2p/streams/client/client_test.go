@@ -24,6 +24,7 @@ import (
 	peercore "github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // toInt converts various numeric types to int
@@ -657,6 +658,94 @@ func TestClientSend_Sync(t *testing.T) {
 	}
 }
 
+func TestClientSend_Trace(t *testing.T) {
+	logging.SetLogLevel("*", "error")
+
+	ctx := t.Context()
+
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	var n int
+	for n < 25565 || n > 40000 {
+		n = rnd.Intn(100000)
+	}
+
+	p1, err := peer.New(
+		ctx,
+		nil,
+		keypair.NewRaw(),
+		nil,
+		[]string{fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", n)},
+		nil,
+		true,
+		false,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p1.Close()
+
+	svr, err := peerService.New(p1, "trace-test", "/trace/1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Stop()
+
+	err = svr.Define("trace", func(ctx context.Context, _ streams.Connection, _ command.Body) (cr.Response, error) {
+		return cr.Response{"trace": trace.SpanContextFromContext(ctx).TraceID().String()}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svr.Start()
+
+	p2, err := peer.New(
+		ctx,
+		nil,
+		keypair.NewRaw(),
+		nil,
+		[]string{fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", n+1)},
+		nil,
+		true,
+		false,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p2.Close()
+
+	err = p2.Peer().Connect(ctx, peercore.AddrInfo{ID: p1.ID(), Addrs: p1.Peer().Addrs()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := New(p2, "/trace/1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	traceId := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	traced := trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceId,
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	resp, err := c.syncSend("trace", Context(traced), To(p1.ID()))
+	require.NoError(t, err)
+	assert.Equal(t, traceId.String(), resp["trace"])
+
+	resp, err = c.SendContext(traced, "trace", command.Body{}, p1.ID())
+	require.NoError(t, err)
+	assert.Equal(t, traceId.String(), resp["trace"])
+
+	// Requests made outside of a trace don't start one.
+	resp, err = c.Send("trace", command.Body{}, p1.ID())
+	require.NoError(t, err)
+	assert.Equal(t, trace.TraceID{}.String(), resp["trace"])
+}
+
 func TestRequestTimeout(t *testing.T) {
 	logging.SetLogLevel("*", "error")
 
@@ -183,3 +183,23 @@ func TestCommand_BodyWithComplexTypes(t *testing.T) {
 
 	assert.Equal(t, cmd.Command, decoded.Command)
 }
+
+func TestCommand_EncodeDecode_Trace(t *testing.T) {
+	cmd := New("traced", Body{"key": "value"})
+	cmd.Trace = map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+
+	var buf bytes.Buffer
+	require.NoError(t, cmd.Encode(&buf))
+
+	decoded, err := Decode(nil, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, cmd.Trace, decoded.Trace)
+
+	// Commands of peers not tracing carry no context.
+	buf.Reset()
+	require.NoError(t, New("untraced", Body{}).Encode(&buf))
+
+	decoded, err = Decode(nil, &buf)
+	require.NoError(t, err)
+	assert.Empty(t, decoded.Trace)
+}
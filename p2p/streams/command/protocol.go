@@ -16,5 +16,7 @@ type Command struct {
 	conn streams.Connection
 
 	Command string `cbor:"16,keyasint"`
-	Body    Body   `cbor:"64,keyasint"`
+	// Trace carries the W3C trace context of the caller, if any.
+	Trace map[string]string `cbor:"32,keyasint,omitempty"`
+	Body  Body              `cbor:"64,keyasint"`
 }
@@ -10,6 +10,9 @@ import (
 	"github.com/taubyte/tau/p2p/streams/command"
 	ce "github.com/taubyte/tau/p2p/streams/command/error"
 	cr "github.com/taubyte/tau/p2p/streams/command/response"
+	"github.com/taubyte/tau/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type CommandHandler func(context.Context, streams.Connection, command.Body) (cr.Response, error)
@@ -45,7 +48,7 @@ func (r *Router) AddStatic(command string, handler CommandHandler, stream Stream
 	return nil
 }
 
-func (r *Router) handle(cmd *command.Command) (cr.Response, StreamHandler, error) {
+func (r *Router) handle(ctx context.Context, cmd *command.Command) (cr.Response, StreamHandler, error) {
 	if cmd == nil {
 		return nil, nil, fmt.Errorf("router: received nil command")
 	}
@@ -56,7 +59,7 @@ func (r *Router) handle(cmd *command.Command) (cr.Response, StreamHandler, error
 	}
 
 	if _handlers, ok := r.staticRoutes[cmd.Command]; ok {
-		ret, err := _handlers.std(r.svr.Context(), conn, cmd.Body)
+		ret, err := _handlers.std(ctx, conn, cmd.Body)
 		if err != nil {
 			return ret, _handlers.stream, fmt.Errorf("router: executing command %q failed: %w", cmd.Command, err)
 		}
@@ -75,7 +78,14 @@ func (r *Router) Handle(s streams.Stream) {
 		return
 	}
 
-	creturn, upgrade, err := r.handle(c)
+	// Handles the command as part of the trace of the caller, if any.
+	ctx, span := tracing.Child(tracing.Extract(r.svr.Context(), c.Trace), "p2p.command.handle",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("p2p.command", c.Command)),
+	)
+
+	creturn, upgrade, err := r.handle(ctx, c)
+	tracing.End(span, err)
 	if err != nil {
 		ce.Encode(s, err)
 		return
@@ -88,6 +98,6 @@ func (r *Router) Handle(s streams.Stream) {
 	}
 
 	if upgrade != nil {
-		upgrade(r.svr.Context(), s)
+		upgrade(ctx, s)
 	}
 }
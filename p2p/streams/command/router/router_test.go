@@ -88,7 +88,7 @@ func TestHandle_NilCommand(t *testing.T) {
 	svr := &streams.StreamManger{}
 	r := New(svr)
 
-	resp, upgrade, err := r.handle(nil)
+	resp, upgrade, err := r.handle(context.Background(), nil)
 	assert.Nil(t, resp)
 	assert.Nil(t, upgrade)
 	assert.Error(t, err)
@@ -103,7 +103,7 @@ func TestHandle_CommandWithNoConnection(t *testing.T) {
 	cmd := command.New("unknownCmd", command.Body{})
 
 	// handle will fail at Connection() call since conn is nil
-	_, _, err := r.handle(cmd)
+	_, _, err := r.handle(context.Background(), cmd)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no connection found")
 }
@@ -72,6 +72,9 @@ func (o *output) Compress(method builders.CompressionMethod) (io.ReadSeekCloser,
 		if noFiles {
 			return nil, fmt.Errorf("website build produced no output: output directory is empty")
 		}
+		if err = precompress(o.outDir); err != nil {
+			return nil, fmt.Errorf("precompressing website files failed with: %w", err)
+		}
 		zippedFile, err = bundle.Zip(bundle.ZipDir, o.outDir, o.wd.Website().BuildZip())
 	default:
 		return nil, fmt.Errorf("compression method `%d` not supported", method)
@@ -0,0 +1,71 @@
+package builder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/andybalholm/brotli"
+	"github.com/taubyte/tau/pkg/specs/builders/website"
+)
+
+var precompressors = map[string]func(io.Writer) io.WriteCloser{
+	website.BrotliSuffix: func(w io.Writer) io.WriteCloser {
+		return brotli.NewWriterLevel(w, brotli.BestCompression)
+	},
+	website.GzipSuffix: func(w io.Writer) io.WriteCloser {
+		gz, _ := gzip.NewWriterLevel(w, gzip.BestCompression)
+		return gz
+	},
+}
+
+// precompress writes the brotli and gzip variants of the compressible files of
+// a website next to them, so they are served without compressing each request.
+// Variants the build already has are kept, and those no smaller than their
+// file are dropped.
+func precompress(dir string) error {
+	return filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !website.Compressible(name) {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil || info.Size() < website.MinCompressSize {
+			return err
+		}
+
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+
+		for suffix, compressor := range precompressors {
+			if _, err = os.Stat(name + suffix); err == nil {
+				continue
+			}
+
+			var buf bytes.Buffer
+			w := compressor(&buf)
+			if _, err = w.Write(data); err != nil {
+				return err
+			}
+
+			if err = w.Close(); err != nil {
+				return err
+			}
+
+			if buf.Len() >= len(data) {
+				continue
+			}
+
+			if err = os.WriteFile(name+suffix, buf.Bytes(), info.Mode().Perm()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+package builder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"gotest.tools/v3/assert"
+)
+
+func TestPrecompress(t *testing.T) {
+	dir := t.TempDir()
+	page := strings.Repeat("<p>hello</p>\n", 200)
+
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "assets"), 0755))
+	for name, data := range map[string]string{
+		"index.html":        page,
+		"small.js":          "console.log(1)",
+		"assets/logo.png":   page,
+		"assets/app.css":    page,
+		"assets/app.css.br": "custom",
+	} {
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0644))
+	}
+
+	assert.NilError(t, precompress(dir))
+
+	data, err := os.ReadFile(filepath.Join(dir, "index.html.br"))
+	assert.NilError(t, err)
+	decoded, err := io.ReadAll(brotli.NewReader(bytes.NewReader(data)))
+	assert.NilError(t, err)
+	assert.Equal(t, string(decoded), page)
+
+	data, err = os.ReadFile(filepath.Join(dir, "index.html.gz"))
+	assert.NilError(t, err)
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	assert.NilError(t, err)
+	decoded, err = io.ReadAll(gz)
+	assert.NilError(t, err)
+	assert.Equal(t, string(decoded), page)
+
+	// Variants of the build are kept.
+	data, err = os.ReadFile(filepath.Join(dir, "assets/app.css.br"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "custom")
+	_, err = os.Stat(filepath.Join(dir, "assets/app.css.gz"))
+	assert.NilError(t, err)
+
+	for _, name := range []string{"small.js.br", "small.js.gz", "assets/logo.png.br", "assets/logo.png.gz"} {
+		_, err = os.Stat(filepath.Join(dir, name))
+		assert.Assert(t, os.IsNotExist(err), name)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"crypto"
 	"crypto/x509"
 	"errors"
+	"fmt"
+	"net/url"
+	"path"
 	"regexp"
 	"sync"
 
@@ -44,6 +47,14 @@ type Config interface {
 	ServicesDomainMatch(s string) bool
 	AliasDomainsMatch(dom string) bool
 	GeneratedDomainMatch(s string) bool
+	// PreviewBranches are the glob patterns of branches deployed as previews.
+	PreviewBranches() []string
+	// BuildWorkers bounds concurrent builds in a monkey code job; 0 = default.
+	BuildWorkers() int
+	// ProjectBuilds bounds the running jobs of a project; 0 = unbounded.
+	ProjectBuilds() int
+	// TracingEndpoint is the OTLP collector spans are exported to; "" = off.
+	TracingEndpoint() string
 	// Hosts: custom domain -> service bindings (domains.hosts).
 	Hosts() map[string]string
 	ServiceForHost(host string) (string, bool)
@@ -222,6 +233,14 @@ func WithNetworkFqdn(fqdn string) Option {
 	}
 }
 
+// WithGeneratedDomain sets the generated domain.
+func WithGeneratedDomain(domain string) Option {
+	return func(c *config) error {
+		c.generatedDomain = domain
+		return nil
+	}
+}
+
 // WithGeneratedDomainRegExp sets the generated domain regex.
 func WithGeneratedDomainRegExp(r *regexp.Regexp) Option {
 	return func(c *config) error {
@@ -230,6 +249,73 @@ func WithGeneratedDomainRegExp(r *regexp.Regexp) Option {
 	}
 }
 
+// WithPreviewBranches sets the preview branch patterns. Validates each glob.
+func WithPreviewBranches(patterns []string) Option {
+	return func(c *config) error {
+		if err := validatePreviewBranches(patterns); err != nil {
+			return err
+		}
+		c.previewBranches = patterns
+		return nil
+	}
+}
+
+// WithBuildWorkers sets the number of concurrent builds per code job.
+func WithBuildWorkers(n int) Option {
+	return func(c *config) error {
+		if n < 0 {
+			return fmt.Errorf("build workers must not be negative, got %d", n)
+		}
+		c.buildWorkers = n
+		return nil
+	}
+}
+
+// WithProjectBuilds sets the number of jobs of a project run at once.
+func WithProjectBuilds(n int) Option {
+	return func(c *config) error {
+		if n < 0 {
+			return fmt.Errorf("project builds must not be negative, got %d", n)
+		}
+		c.projectBuilds = n
+		return nil
+	}
+}
+
+// WithTracingEndpoint sets the OTLP collector URL. Validates it is http(s).
+func WithTracingEndpoint(endpoint string) Option {
+	return func(c *config) error {
+		if err := validateTracingEndpoint(endpoint); err != nil {
+			return err
+		}
+		c.tracingEndpoint = endpoint
+		return nil
+	}
+}
+
+func validateTracingEndpoint(endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid tracing endpoint `%s`: %w", endpoint, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid tracing endpoint `%s`: expected a http(s) URL", endpoint)
+	}
+	return nil
+}
+
+func validatePreviewBranches(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid preview branch pattern `%s`: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
 // WithServicesDomainRegExp sets the services domain regex.
 func WithServicesDomainRegExp(r *regexp.Regexp) Option {
 	return func(c *config) error {
@@ -254,6 +340,14 @@ func WithHosts(h map[string]string) Option {
 	}
 }
 
+// WithServices sets the services the node runs.
+func WithServices(services []string) Option {
+	return func(c *config) error {
+		c.services = services
+		return nil
+	}
+}
+
 // WithPeers sets the bootstrap peers.
 func WithPeers(peers []string) Option {
 	return func(c *config) error {
@@ -320,6 +414,10 @@ type config struct {
 	generatedDomain string
 	aliasDomains    []string
 	hosts           map[string]string
+	previewBranches []string
+	buildWorkers    int
+	projectBuilds   int
+	tracingEndpoint string
 
 	routeHostsMu    sync.Mutex
 	routeHostsCache map[string][]string
@@ -368,6 +466,10 @@ func (c *config) Location() *seerIface.Location { return c.location }
 func (c *config) NetworkFqdn() string           { return c.networkFqdn }
 func (c *config) GeneratedDomain() string       { return c.generatedDomain }
 func (c *config) AliasDomains() []string        { return c.aliasDomains }
+func (c *config) PreviewBranches() []string     { return c.previewBranches }
+func (c *config) BuildWorkers() int             { return c.buildWorkers }
+func (c *config) ProjectBuilds() int            { return c.projectBuilds }
+func (c *config) TracingEndpoint() string       { return c.tracingEndpoint }
 
 func (c *config) Hosts() map[string]string { return c.hosts }
 
@@ -73,6 +73,26 @@ func WithSource(src *Source, opts SourceOptions) Option {
 		c.accounts = src.Accounts
 		c.enterprise = src.Enterprise
 
+		if err = validatePreviewBranches(src.Previews.Branches); err != nil {
+			return err
+		}
+		c.previewBranches = src.Previews.Branches
+
+		if src.Builds.Workers < 0 {
+			return fmt.Errorf("builds.workers must not be negative, got %d", src.Builds.Workers)
+		}
+		c.buildWorkers = src.Builds.Workers
+
+		if src.Builds.PerProject < 0 {
+			return fmt.Errorf("builds.per-project must not be negative, got %d", src.Builds.PerProject)
+		}
+		c.projectBuilds = src.Builds.PerProject
+
+		if err = validateTracingEndpoint(src.Tracing.Endpoint); err != nil {
+			return err
+		}
+		c.tracingEndpoint = src.Tracing.Endpoint
+
 		if c.swarmKey, err = loadSwarmKey(swarmPath); err != nil {
 			return err
 		}
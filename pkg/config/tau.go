@@ -11,6 +11,8 @@ type Ports struct {
 	Main int `yaml:"main"`
 	Lite int `yaml:"lite,omitempty"`
 	Ipfs int `yaml:"ipfs,omitempty"`
+	// Mqtt is the substrate MQTT broker port; zero leaves the broker off.
+	Mqtt int `yaml:"mqtt,omitempty"`
 }
 
 func (p Ports) ToMap() map[string]int {
@@ -18,6 +20,7 @@ func (p Ports) ToMap() map[string]int {
 		"main": p.Main,
 		"lite": p.Lite,
 		"ipfs": p.Ipfs,
+		"mqtt": p.Mqtt,
 	}
 }
 
@@ -42,9 +45,36 @@ type Source struct {
 	// `//go:build ee` code decodes each service's entry into its own typed
 	// config (via EnterpriseConfig).
 	Enterprise map[string]yaml.Node `yaml:"enterprise,omitempty"`
+	// Previews lists the non-default branches patrick and monkey build and
+	// substrate serves on <branch>--<generated fqdn> preview domains.
+	Previews Previews `yaml:"previews,omitempty"`
+	// Builds tunes how patrick schedules and monkey runs builds.
+	Builds Builds `yaml:"builds,omitempty"`
+	// Tracing exports the spans of the node to an OTLP collector.
+	Tracing Tracing `yaml:"tracing,omitempty"`
 	Plugins
 }
 
+type Tracing struct {
+	// Endpoint is the http(s) URL of the OTLP/HTTP collector, e.g.
+	// "http://collector:4318". Empty leaves tracing off.
+	Endpoint string `yaml:"endpoint,omitempty"`
+}
+
+type Builds struct {
+	// Workers bounds the functions and libraries a code job builds at once.
+	// Zero uses the monkey default.
+	Workers int `yaml:"workers,omitempty"`
+	// PerProject bounds the jobs of a project patrick hands out to monkeys at
+	// once. Zero leaves it unbounded.
+	PerProject int `yaml:"per-project,omitempty"`
+}
+
+type Previews struct {
+	// Branches are path.Match glob patterns, e.g. "feature/*".
+	Branches []string `yaml:"branches,omitempty"`
+}
+
 type BundleOrigin struct {
 	Shape     string    `yaml:"shape"`
 	Host      string    `yaml:"host"`
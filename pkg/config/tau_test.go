@@ -60,3 +60,72 @@ func TestNode_Validate_KeepsNonEmptyCluster(t *testing.T) {
 		t.Errorf("Cluster() after New = %q, want build", cfg.Cluster())
 	}
 }
+
+func TestSource_Previews_YAMLUnmarshal(t *testing.T) {
+	data := []byte("previews:\n  branches: [\"feature/*\", dev]")
+	var src Source
+	if err := yaml.Unmarshal(data, &src); err != nil {
+		t.Fatal(err)
+	}
+	if len(src.Previews.Branches) != 2 || src.Previews.Branches[0] != "feature/*" {
+		t.Errorf("Source.Previews.Branches = %v", src.Previews.Branches)
+	}
+}
+
+func TestWithPreviewBranches(t *testing.T) {
+	cfg, err := New(WithPrivateKey(make([]byte, 32)), WithPreviewBranches([]string{"feature/*"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.PreviewBranches(); len(got) != 1 || got[0] != "feature/*" {
+		t.Errorf("PreviewBranches() = %v", got)
+	}
+
+	if _, err = New(WithPreviewBranches([]string{"feature/["})); err == nil {
+		t.Error("expected malformed pattern to be rejected")
+	}
+}
+
+func TestWithBuildWorkers(t *testing.T) {
+	cfg, err := New(WithPrivateKey(make([]byte, 32)), WithBuildWorkers(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.BuildWorkers(); got != 8 {
+		t.Errorf("BuildWorkers() = %d, want 8", got)
+	}
+
+	if _, err = New(WithBuildWorkers(-1)); err == nil {
+		t.Error("expected negative workers to be rejected")
+	}
+}
+
+func TestWithProjectBuilds(t *testing.T) {
+	cfg, err := New(WithPrivateKey(make([]byte, 32)), WithProjectBuilds(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.ProjectBuilds(); got != 2 {
+		t.Errorf("ProjectBuilds() = %d, want 2", got)
+	}
+
+	if _, err = New(WithProjectBuilds(-1)); err == nil {
+		t.Error("expected negative project builds to be rejected")
+	}
+}
+
+func TestWithTracingEndpoint(t *testing.T) {
+	cfg, err := New(WithPrivateKey(make([]byte, 32)), WithTracingEndpoint("http://collector:4318"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.TracingEndpoint(); got != "http://collector:4318" {
+		t.Errorf("TracingEndpoint() = %q", got)
+	}
+
+	for _, endpoint := range []string{"collector:4318", "grpc://collector:4317", "http://"} {
+		if _, err = New(WithTracingEndpoint(endpoint)); err == nil {
+			t.Errorf("expected endpoint %q to be rejected", endpoint)
+		}
+	}
+}
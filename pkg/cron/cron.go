@@ -0,0 +1,90 @@
+// Package cron parses standard five-field cron expressions and computes their
+// activation times.
+//
+// A schedule is "minute hour day-of-month month day-of-week". Fields accept
+// `*`, values, ranges (`1-5`), lists (`1,3,5`) and steps (`*/15`, `0-30/10`);
+// months and weekdays also accept three-letter names and Sunday may be 0 or 7.
+// The descriptors @yearly (@annually), @monthly, @weekly, @daily (@midnight)
+// and @hourly stand for their usual expressions. As in Vixie cron, when both
+// day fields are restricted a time matches if either does.
+package cron
+
+import (
+	"time"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny record a `*` day field, which doesn't restrict days.
+	domAny, dowAny bool
+}
+
+// searchLimit bounds Next for expressions that never match, like Feb 30.
+const searchLimit = 5
+
+// Next returns the first activation strictly after t, in t's location, or the
+// zero time if there is none within five years.
+//
+// Times skipped by a daylight saving jump never activate; an hour repeated by
+// one activates once.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	after := wall(t)
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.Year() + searchLimit
+
+	for t.Year() <= limit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			continue
+		}
+
+		// A wall clock at or before t's own was already passed before the
+		// clocks went back.
+		if s.minute&(1<<uint(t.Minute())) == 0 || !wall(t).After(after) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// forward returns next, a midnight past t, moved out of a daylight saving gap
+// that time.Date may resolve to before t.
+func forward(t, next time.Time) time.Time {
+	for !next.After(t) {
+		next = next.Add(time.Hour)
+	}
+	return next
+}
+
+// wall is t's wall clock, comparable across daylight saving changes.
+func wall(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+
+	return dom || dow
+}
@@ -0,0 +1,88 @@
+package cron
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"gotest.tools/v3/assert"
+)
+
+func mustParse(t *testing.T, expr string) *Schedule {
+	t.Helper()
+	s, err := Parse(expr)
+	assert.NilError(t, err)
+	return s
+}
+
+func TestNext(t *testing.T) {
+	from := time.Date(2026, time.March, 14, 10, 17, 42, 0, time.UTC) // a Saturday
+
+	for _, tc := range []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2026, time.March, 14, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, time.March, 14, 10, 30, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2026, time.March, 15, 3, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, time.March, 14, 11, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 9 * * mon-fri", time.Date(2026, time.March, 16, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 12 1,15 * *", time.Date(2026, time.March, 15, 12, 0, 0, 0, time.UTC)},
+		{"5/20 10 * * *", time.Date(2026, time.March, 14, 10, 25, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either one matches.
+		{"0 0 20 * sun", time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		// A starred day field makes both match.
+		{"0 0 */10 * sun", time.Date(2026, time.May, 31, 0, 0, 0, 0, time.UTC)},
+	} {
+		assert.Equal(t, mustParse(t, tc.expr).Next(from), tc.next, tc.expr)
+	}
+
+	assert.Assert(t, mustParse(t, "0 0 30 feb *").Next(from).IsZero())
+}
+
+func TestNextDaylightSaving(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NilError(t, err)
+
+	// 02:30 doesn't exist on March 8th.
+	next := mustParse(t, "30 2 * * *").Next(time.Date(2026, time.March, 7, 12, 0, 0, 0, ny))
+	assert.Equal(t, next, time.Date(2026, time.March, 9, 2, 30, 0, 0, ny))
+
+	// 01:30 happens twice on November 1st, and runs once.
+	first := mustParse(t, "30 1 * * *").Next(time.Date(2026, time.October, 31, 12, 0, 0, 0, ny))
+	_, offset := first.Zone()
+	assert.Equal(t, offset, -4*3600)
+	assert.Equal(t, mustParse(t, "30 1 * * *").Next(first), time.Date(2026, time.November, 2, 1, 30, 0, 0, ny))
+
+	next = mustParse(t, "*/30 * * * *").Next(first)
+	assert.Equal(t, next.Sub(first), 90*time.Minute)
+	assert.Equal(t, next.Hour(), 2)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"* * * foo *",
+		"@fortnightly",
+	} {
+		_, err := Parse(expr)
+		assert.Assert(t, err != nil, expr)
+	}
+
+	_, err := Parse("* * * JAN-mar SUN,sat")
+	assert.NilError(t, err)
+}
@@ -0,0 +1,157 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type field struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	minutes     = field{name: "minute", min: 0, max: 59}
+	hours       = field{name: "hour", min: 0, max: 23}
+	daysOfMonth = field{name: "day of month", min: 1, max: 31}
+	months      = field{name: "month", min: 1, max: 12, names: []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	daysOfWeek  = field{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a five-field cron expression or descriptor.
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@") {
+		_expr, ok := descriptors[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf("unknown descriptor `%s`", expr)
+		}
+		expr = _expr
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in `%s`, got %d", expr, len(fields))
+	}
+
+	var (
+		s   Schedule
+		err error
+	)
+
+	if s.minute, _, err = minutes.parse(fields[0]); err != nil {
+		return nil, err
+	}
+
+	if s.hour, _, err = hours.parse(fields[1]); err != nil {
+		return nil, err
+	}
+
+	if s.dom, s.domAny, err = daysOfMonth.parse(fields[2]); err != nil {
+		return nil, err
+	}
+
+	if s.month, _, err = months.parse(fields[3]); err != nil {
+		return nil, err
+	}
+
+	if s.dow, s.dowAny, err = daysOfWeek.parse(fields[4]); err != nil {
+		return nil, err
+	}
+
+	// 7 is Sunday too.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	return &s, nil
+}
+
+// parse returns the bits set by a comma separated list, and whether it starts
+// with `*`, which Vixie cron takes to leave days unrestricted.
+func (f field) parse(expr string) (bits uint64, star bool, err error) {
+	for _, item := range strings.Split(expr, ",") {
+		_bits, err := f.parseItem(item)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %s `%s`: %w", f.name, expr, err)
+		}
+		bits |= _bits
+	}
+
+	return bits, strings.HasPrefix(expr, "*"), nil
+}
+
+func (f field) parseItem(item string) (uint64, error) {
+	rng, _step, stepped := strings.Cut(item, "/")
+
+	step := 1
+	if stepped {
+		var err error
+		if step, err = strconv.Atoi(_step); err != nil || step < 1 {
+			return 0, fmt.Errorf("bad step `%s`", _step)
+		}
+	}
+
+	var low, high int
+	if rng == "*" {
+		low, high = f.min, f.max
+	} else {
+		_low, _high, isRange := strings.Cut(rng, "-")
+
+		var err error
+		if low, err = f.value(_low); err != nil {
+			return 0, err
+		}
+
+		high = low
+		if isRange {
+			if high, err = f.value(_high); err != nil {
+				return 0, err
+			}
+			if high < low {
+				return 0, fmt.Errorf("range `%s` ends before it starts", rng)
+			}
+		} else if stepped {
+			// `5/15` runs from 5 to the end, stepping 15.
+			high = f.max
+		}
+	}
+
+	var bits uint64
+	for v := low; v <= high; v += step {
+		bits |= 1 << uint(v)
+	}
+
+	return bits, nil
+}
+
+func (f field) value(s string) (int, error) {
+	for i, name := range f.names {
+		if name != "" && strings.EqualFold(s, name) {
+			return i, nil
+		}
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("bad value `%s`", s)
+	}
+
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%d is out of range [%d-%d]", v, f.min, f.max)
+	}
+
+	return v, nil
+}
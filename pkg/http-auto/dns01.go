@@ -0,0 +1,335 @@
+package auto
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	mathRand "math/rand/v2"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	domainSpecs "github.com/taubyte/tau/pkg/specs/domain"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// Same name autocert keeps its account key under, so both share the account.
+const accountKeyName = "acme_account+key"
+
+// wildcards returns the domains to keep a wildcard certificate for: the
+// configured ones and those delegating their challenges to seer.
+func (s *Service) wildcards() []string {
+	domains := make([]string, 0)
+	if s.dns01.Wildcards != nil {
+		domains = append(domains, s.dns01.Wildcards()...)
+	}
+
+	s.dns01Lock.Lock()
+	for domain := range s.delegated {
+		domains = append(domains, domain)
+	}
+	s.dns01Lock.Unlock()
+
+	return domains
+}
+
+func (s *Service) keepWildcards() {
+	select {
+	case <-s.Context().Done():
+		return
+	case <-time.After(mathRand.N(RenewJitter + 1)):
+	}
+
+	ticker := time.NewTicker(RenewCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, domain := range s.wildcards() {
+			ctx, ctxC := context.WithTimeout(s.Context(), IssueTimeout)
+			if err := s.ensureWildcard(ctx, domain); err != nil {
+				logger.Errorf("obtaining wildcard certificate of %s failed with: %s", domain, err)
+			}
+			ctxC()
+		}
+
+		select {
+		case <-s.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// delegatedWildcard obtains, in the background, the wildcard certificate of
+// the parent of a registered host when its domain delegates its challenges to
+// seer. Handshakes meanwhile get the certificate the store already holds.
+func (s *Service) delegatedWildcard(host string) {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	_, domain, ok := strings.Cut(host, ".")
+	if !ok || !strings.Contains(domain, ".") || s.dns01.Zone == "" {
+		return
+	}
+
+	if (s.autoTrustDomain != nil && s.autoTrustDomain(host)) || (s.skipDomainProof != nil && s.skipDomainProof(host)) {
+		return
+	}
+
+	// Challenge names never are hosts, so they can't clash in the caches.
+	key := domainSpecs.ChallengeName(domain)
+	if item := s.positiveCache.Get(key); item != nil && item.Value() {
+		return
+	}
+	if item := s.negativeCache.Get(key); item != nil && item.Value() {
+		return
+	}
+
+	s.dns01Lock.Lock()
+	defer s.dns01Lock.Unlock()
+
+	if s.delegating[domain] {
+		return
+	}
+	s.delegating[domain] = true
+
+	go func() {
+		s.delegateWildcard(host, domain, key)
+
+		s.dns01Lock.Lock()
+		delete(s.delegating, domain)
+		s.dns01Lock.Unlock()
+	}()
+}
+
+func (s *Service) delegateWildcard(host, domain, key string) {
+	ctx, ctxC := context.WithTimeout(s.Context(), IssueTimeout)
+	defer ctxC()
+
+	target, err := net.DefaultResolver.LookupCNAME(ctx, key)
+	if err != nil || strings.TrimSuffix(strings.ToLower(target), ".") != domainSpecs.DelegatedChallengeName(domain, s.dns01.Zone) {
+		s.negativeCache.Set(key, true, NegativeTTL)
+		return
+	}
+
+	if err = s.validateFQDN(host); err != nil {
+		s.negativeCache.Set(key, true, NegativeTTL)
+		return
+	}
+
+	if err = s.ensureWildcard(ctx, domain); err != nil {
+		logger.Errorf("obtaining wildcard certificate of %s failed with: %s", domain, err)
+		s.negativeCache.Set(key, true, NegativeTTL)
+		return
+	}
+
+	s.dns01Lock.Lock()
+	s.delegated[domain] = true
+	s.dns01Lock.Unlock()
+
+	s.positiveCache.Set(key, true, PositiveTTL)
+}
+
+// ensureWildcard obtains a wildcard certificate for domain unless the store
+// holds one that isn't due for renewal.
+func (s *Service) ensureWildcard(ctx context.Context, domain string) error {
+	name := "*." + domain
+	if data, err := s.certStore.Get(ctx, name); err == nil && wildcardValid(data, domain, time.Now()) {
+		return nil
+	}
+
+	s.issueLock.Lock()
+	defer s.issueLock.Unlock()
+
+	// Obtained while waiting for the lock.
+	if data, err := s.certStore.Get(ctx, name); err == nil && wildcardValid(data, domain, time.Now()) {
+		return nil
+	}
+
+	data, err := s.issueWildcard(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	if err = s.certStore.Put(ctx, name, data); err != nil {
+		return fmt.Errorf("storing certificate of %s failed with: %w", name, err)
+	}
+
+	logger.Infof("obtained wildcard certificate of %s", domain)
+
+	return nil
+}
+
+// wildcardValid tells whether data holds a certificate covering all the
+// subdomains of domain and not expiring within RenewBefore.
+func wildcardValid(data []byte, domain string, now time.Time) bool {
+	for {
+		var block *pem.Block
+		if block, data = pem.Decode(data); block == nil {
+			return false
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		leaf, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return false
+		}
+
+		return slices.Contains(leaf.DNSNames, "*."+domain) && leaf.NotAfter.Sub(now) > RenewBefore
+	}
+}
+
+// issueWildcard obtains a certificate for *.domain, answering its DNS-01
+// challenges through the challenge store seer serves. It returns the key and
+// chain PEM encoded, the way autocert caches certificates.
+func (s *Service) issueWildcard(ctx context.Context, domain string) ([]byte, error) {
+	client, err := s.acmeAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := "*." + domain
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(name))
+	if err != nil {
+		return nil, fmt.Errorf("ordering certificate of %s failed with: %w", name, err)
+	}
+
+	for _, url := range order.AuthzURLs {
+		authz, err := client.GetAuthorization(ctx, url)
+		if err != nil {
+			return nil, fmt.Errorf("getting authorization of %s failed with: %w", name, err)
+		}
+
+		if authz.Status == acme.StatusValid {
+			continue
+		}
+
+		var challenge *acme.Challenge
+		for _, c := range authz.Challenges {
+			if c.Type == "dns-01" {
+				challenge = c
+				break
+			}
+		}
+
+		if challenge == nil {
+			return nil, fmt.Errorf("no dns-01 challenge offered for %s", name)
+		}
+
+		value, err := client.DNS01ChallengeRecord(challenge.Token)
+		if err != nil {
+			return nil, fmt.Errorf("computing challenge of %s failed with: %w", name, err)
+		}
+
+		challengeName := domainSpecs.ChallengeName(authz.Identifier.Value)
+		if err = s.authClient.Challenges().Set(challengeName, value); err != nil {
+			return nil, err
+		}
+		defer s.authClient.Challenges().Delete(challengeName, value)
+
+		if _, err = client.Accept(ctx, challenge); err != nil {
+			return nil, fmt.Errorf("accepting challenge of %s failed with: %w", name, err)
+		}
+
+		if _, err = client.WaitAuthorization(ctx, url); err != nil {
+			return nil, fmt.Errorf("authorizing %s failed with: %w", name, err)
+		}
+	}
+
+	if order, err = client.WaitOrder(ctx, order.URI); err != nil {
+		return nil, fmt.Errorf("waiting for order of %s failed with: %w", name, err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating key of %s failed with: %w", name, err)
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{name}}, key)
+	if err != nil {
+		return nil, fmt.Errorf("creating certificate request of %s failed with: %w", name, err)
+	}
+
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, fmt.Errorf("finalizing order of %s failed with: %w", name, err)
+	}
+
+	return encodeCertificate(key, chain)
+}
+
+func encodeCertificate(key crypto.Signer, chain [][]byte) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling key failed with: %w", err)
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	for _, cert := range chain {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})...)
+	}
+
+	return data, nil
+}
+
+// acmeAccount returns the client of the ACME account, registering it once.
+// Without a configured key, the account key autocert uses is shared.
+func (s *Service) acmeAccount(ctx context.Context) (*acme.Client, error) {
+	if s.dns01Client != nil {
+		return s.dns01Client, nil
+	}
+
+	var key crypto.Signer
+	if s.acme != nil && s.acme.Key != nil {
+		key = s.acme.Key
+	} else {
+		var err error
+		if key, err = s.accountKey(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	client := s.newACMEClient(key)
+	if _, err := client.Register(ctx, &acme.Account{}, autocert.AcceptTOS); err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return nil, fmt.Errorf("registering acme account failed with: %w", err)
+	}
+
+	s.dns01Client = client
+
+	return client, nil
+}
+
+func (s *Service) accountKey(ctx context.Context) (crypto.Signer, error) {
+	if data, err := s.certStore.Get(ctx, accountKeyName); err == nil {
+		if block, _ := pem.Decode(data); block != nil {
+			if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+				return key, nil
+			}
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating account key failed with: %w", err)
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling account key failed with: %w", err)
+	}
+
+	if err = s.certStore.Put(ctx, accountKeyName, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})); err != nil {
+		return nil, fmt.Errorf("storing account key failed with: %w", err)
+	}
+
+	return key, nil
+}
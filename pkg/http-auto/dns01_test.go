@@ -0,0 +1,52 @@
+package auto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func selfSigned(t *testing.T, notAfter time.Time, names ...string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		DNSNames:     names,
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	assert.NilError(t, err)
+
+	data, err := encodeCertificate(key, [][]byte{der})
+	assert.NilError(t, err)
+
+	return data
+}
+
+func TestEncodeCertificate(t *testing.T) {
+	data := selfSigned(t, time.Now().Add(time.Hour), "*.g.tau.link")
+
+	// Loads like the certificates autocert caches.
+	cert, err := tls.X509KeyPair(data, data)
+	assert.NilError(t, err)
+	assert.Equal(t, len(cert.Certificate), 1)
+}
+
+func TestWildcardValid(t *testing.T) {
+	now := time.Now()
+
+	assert.Assert(t, wildcardValid(selfSigned(t, now.Add(60*24*time.Hour), "*.g.tau.link"), "g.tau.link", now))
+	assert.Assert(t, !wildcardValid(selfSigned(t, now.Add(10*24*time.Hour), "*.g.tau.link"), "g.tau.link", now))
+	assert.Assert(t, !wildcardValid(selfSigned(t, now.Add(60*24*time.Hour), "*.tau.link"), "g.tau.link", now))
+	assert.Assert(t, !wildcardValid([]byte("garbage"), "g.tau.link", now))
+}
@@ -2,6 +2,7 @@ package auto
 
 import (
 	"context"
+	"crypto"
 	"crypto/tls"
 	"errors"
 	"fmt"
@@ -43,6 +44,8 @@ func (s *Service) SetOption(opt interface{}) error {
 		s.acmeCARoots = opt.Roots
 	case options.OptionACMECASkipVerify:
 		s.acmeCASkipVerify = opt.Skip
+	case autoOptions.OptionDNS01:
+		s.dns01 = &opt
 	}
 	// default: we ignore option we do not know so other modules can process them
 	return nil
@@ -104,6 +107,8 @@ func newAuto(ctx context.Context, node peer.Node, opts ...options.Option) (*Serv
 		return nil, err
 	}
 
+	s.delegated = make(map[string]bool)
+	s.delegating = make(map[string]bool)
 	s.positiveCache = ttlcache.New(ttlcache.WithTTL[string, bool](PositiveTTL))
 	s.negativeCache = ttlcache.New(ttlcache.WithTTL[string, bool](NegativeTTL))
 
@@ -173,24 +178,20 @@ func (s *Service) Start() {
 	}
 
 	if s.acme != nil {
-		m.Client = &acme.Client{
-			DirectoryURL: s.acme.DirectoryURL,
-			Key:          s.acme.Key,
-		}
-		if s.acmeCASkipVerify || s.acmeCARoots != nil {
-			m.Client.HTTPClient = &http.Client{
-				Transport: &http.Transport{
-					TLSClientConfig: &tls.Config{
-						InsecureSkipVerify: s.acmeCASkipVerify,
-						RootCAs:            s.acmeCARoots,
-					},
-				},
-			}
+		m.Client = s.newACMEClient(s.acme.Key)
+	}
+
+	getCertificate := m.GetCertificate
+	if s.dns01 != nil {
+		go s.keepWildcards()
+		getCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			s.delegatedWildcard(hello.ServerName)
+			return m.GetCertificate(hello)
 		}
 	}
 
 	cfg := &tls.Config{
-		GetCertificate: m.GetCertificate,
+		GetCertificate: getCertificate,
 		NextProtos: []string{
 			"http/1.1", acme.ALPNProto,
 		},
@@ -218,6 +219,28 @@ func (s *Service) Start() {
 	s.WatchContextDone()
 }
 
+// newACMEClient returns a client of the configured ACME directory, Let's
+// Encrypt by default, signing with key.
+func (s *Service) newACMEClient(key crypto.Signer) *acme.Client {
+	client := &acme.Client{Key: key}
+	if s.acme != nil {
+		client.DirectoryURL = s.acme.DirectoryURL
+	}
+
+	if s.acmeCASkipVerify || s.acmeCARoots != nil {
+		client.HTTPClient = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: s.acmeCASkipVerify,
+					RootCAs:            s.acmeCARoots,
+				},
+			},
+		}
+	}
+
+	return client
+}
+
 func (s *Service) GetListenAddress() (*url.URL, error) {
 	return url.Parse("https://" + s.ListenAddress)
 }
@@ -51,3 +51,18 @@ func ClientNode(node peer.Node) options.Option {
 		return s.SetOption(OptionClientNode{Node: node})
 	}
 }
+
+// OptionDNS01 obtains wildcard certificates through ACME DNS-01 challenges,
+// published by seer. Zone is the zone seer serves, which domains hosted
+// elsewhere delegate their challenge name to; Wildcards returns the domains
+// to keep a wildcard certificate for.
+type OptionDNS01 struct {
+	Zone      string
+	Wildcards func() []string
+}
+
+func DNS01(zone string, wildcards func() []string) options.Option {
+	return func(s options.Configurable) error {
+		return s.SetOption(OptionDNS01{Zone: zone, Wildcards: wildcards})
+	}
+}
@@ -38,3 +38,19 @@ func TestCustomDomainChecker(t *testing.T) {
 	}
 	t.Errorf("Option CustomDomainChecker not set correctly")
 }
+
+func TestDNS01(t *testing.T) {
+	mc := newMockConfigurable()
+
+	err := options.Parse(mc, []options.Option{DNS01("tau.link", func() []string { return []string{"g.tau.link"} })})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, o := range mc.values {
+		if _o, ok := o.(OptionDNS01); ok && _o.Zone == "tau.link" && _o.Wildcards()[0] == "g.tau.link" {
+			return
+		}
+	}
+	t.Errorf("Option DNS01 not set correctly")
+}
@@ -2,14 +2,17 @@ package auto
 
 import (
 	"crypto/x509"
+	"sync"
 	"time"
 
 	"github.com/jellydator/ttlcache/v3"
 	auth "github.com/taubyte/tau/core/services/auth"
 	ifaceTns "github.com/taubyte/tau/core/services/tns"
 	"github.com/taubyte/tau/p2p/peer"
+	autoOptions "github.com/taubyte/tau/pkg/http-auto/options"
 	basicHttp "github.com/taubyte/tau/pkg/http/basic"
 	"github.com/taubyte/tau/pkg/http/options"
+	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
 )
 
@@ -31,6 +34,13 @@ type Service struct {
 	acmeCARoots      *x509.CertPool
 	acmeCASkipVerify bool
 
+	dns01       *autoOptions.OptionDNS01
+	dns01Client *acme.Client
+	issueLock   sync.Mutex
+	dns01Lock   sync.Mutex
+	delegated   map[string]bool
+	delegating  map[string]bool
+
 	positiveCache *ttlcache.Cache[string, bool]
 	negativeCache *ttlcache.Cache[string, bool]
 }
@@ -38,4 +48,13 @@ type Service struct {
 var (
 	PositiveTTL = 1 * time.Hour
 	NegativeTTL = 1 * time.Minute
+
+	// RenewCheckInterval is how often wildcard certificates are checked.
+	RenewCheckInterval = 12 * time.Hour
+	// RenewBefore is how long before expiry a wildcard certificate is renewed.
+	RenewBefore = 30 * 24 * time.Hour
+	// RenewJitter spreads the first check of the nodes sharing certificates.
+	RenewJitter = 1 * time.Minute
+	// IssueTimeout bounds obtaining a certificate through DNS-01.
+	IssueTimeout = 5 * time.Minute
 )
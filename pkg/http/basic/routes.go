@@ -57,11 +57,16 @@ func (s *Service) Raw(def *service.RawRouteDefinition) *mux.Route {
 }
 
 func (s *Service) LowLevel(def *service.LowLevelDefinition) *mux.Route {
+	var route *mux.Route
 	if len(def.PathPrefix) > 0 {
-		return s.Router.PathPrefix(def.Path).HandlerFunc(def.Handler)
+		route = s.Router.PathPrefix(def.Path).HandlerFunc(def.Handler)
+	} else {
+		route = s.Router.Path(def.Path).HandlerFunc(def.Handler)
 	}
 
-	return s.Router.Path(def.Path).HandlerFunc(def.Handler)
+	applyHostMatch(route, def.Hosts)
+
+	return route
 }
 
 func (s *Service) LowLevelHandler(def *service.LowLevelHandlerDefinition) *mux.Route {
@@ -43,6 +43,7 @@ type RawRouteDefinition struct {
 }
 
 type LowLevelDefinition struct {
+	Hosts      []string // scope to these hosts (see RouteDefinition.Hosts)
 	Path       string
 	PathPrefix string
 	Handler    func(w http.ResponseWriter, r *http.Request)
@@ -7,28 +7,51 @@ import (
 	ds "github.com/ipfs/go-datastore"
 	query "github.com/ipfs/go-datastore/query"
 	"github.com/taubyte/tau/core/kvdb"
+	"github.com/taubyte/tau/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func (kvd *kvDatabase) Get(ctx context.Context, key string) ([]byte, error) {
+// span starts a span of op on key, when ctx is part of a trace.
+func (kvd *kvDatabase) span(ctx context.Context, op, key string) (context.Context, trace.Span) {
+	return tracing.Child(ctx, "kvdb."+op, trace.WithAttributes(
+		attribute.String("kvdb.database", kvd.path),
+		attribute.String("kvdb.key", key),
+	))
+}
+
+func (kvd *kvDatabase) Get(ctx context.Context, key string) (v []byte, err error) {
+	ctx, span := kvd.span(ctx, "get", key)
+	defer func() { tracing.End(span, err) }()
+
 	k := ds.NewKey(key)
 	return kvd.datastore.Get(ctx, k)
 }
 
-func (kvd *kvDatabase) Put(ctx context.Context, key string, v []byte) error {
+func (kvd *kvDatabase) Put(ctx context.Context, key string, v []byte) (err error) {
 	if key == "" {
 		return errors.New("key cannot be empty")
 	}
 
+	ctx, span := kvd.span(ctx, "put", key)
+	defer func() { tracing.End(span, err) }()
+
 	k := ds.NewKey(key)
 	return kvd.datastore.Put(ctx, k, v)
 }
 
-func (kvd *kvDatabase) Delete(ctx context.Context, key string) error {
+func (kvd *kvDatabase) Delete(ctx context.Context, key string) (err error) {
+	ctx, span := kvd.span(ctx, "delete", key)
+	defer func() { tracing.End(span, err) }()
+
 	k := ds.NewKey(key)
 	return kvd.datastore.Delete(ctx, k)
 }
 
-func (kvd *kvDatabase) List(ctx context.Context, prefix string) ([]string, error) {
+func (kvd *kvDatabase) List(ctx context.Context, prefix string) (_ []string, err error) {
+	ctx, span := kvd.span(ctx, "list", prefix)
+	defer func() { tracing.End(span, err) }()
+
 	result, err := kvd.list(ctx, prefix)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,125 @@
+package kvdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/pebble/v2"
+	ds "github.com/ipfs/go-datastore"
+	query "github.com/ipfs/go-datastore/query"
+	pds "github.com/ipfs/go-ds-pebble"
+	"github.com/taubyte/tau/core/kvdb"
+	"github.com/taubyte/tau/pkg/tracing"
+)
+
+// Scanner is a KVDB that lists keys a page at a time.
+type Scanner interface {
+	Scan(ctx context.Context, prefix, after string, limit int) ([]string, error)
+}
+
+// Scan returns, in scan order, at most limit keys with the prefix that sort
+// after the given one, all of them when limit is not positive. It seeks to
+// after in stores that are Scanners and lists the others.
+func Scan(ctx context.Context, kv kvdb.KVDB, prefix, after string, limit int) ([]string, error) {
+	if scanner, ok := kv.(Scanner); ok && limit > 0 {
+		return scanner.Scan(ctx, prefix, after, limit)
+	}
+
+	keys, err := kv.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return PageKeys(keys, after, limit), nil
+}
+
+// PageKeys sorts keys in scan order and returns at most limit of them after
+// the given one, all of them when limit is not positive.
+func PageKeys(keys []string, after string, limit int) []string {
+	sort.Slice(keys, func(i, j int) bool { return scanKey(keys[i]) < scanKey(keys[j]) })
+
+	after = scanKey(after)
+	keys = keys[sort.Search(len(keys), func(i int) bool { return scanKey(keys[i]) > after }):]
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	return keys
+}
+
+// scanKey is what keys sort by: the key of their value in the set, so pages
+// listed from the store and sorted in memory come in the same order. Keys
+// sort as paths, with or without a leading slash.
+func scanKey(key string) string {
+	if key == "" {
+		return ""
+	}
+
+	return ds.NewKey(key).String() + "/" + valueSuffix
+}
+
+func (kvd *kvDatabase) Scan(ctx context.Context, prefix, after string, limit int) (_ []string, err error) {
+	ctx, span := kvd.span(ctx, "scan", prefix)
+	defer func() { tracing.End(span, err) }()
+
+	return kvd.datastore.set.scan(ctx, prefix, after, limit)
+}
+
+// scan returns at most limit keys of elements with the prefix that sort after
+// the given one. On pebble it seeks to after instead of listing the prefix.
+func (s *set) scan(ctx context.Context, prefix, after string, limit int) ([]string, error) {
+	store, ok := s.store.(*pds.Datastore)
+	if !ok {
+		results, err := s.Elements(ctx, query.Query{Prefix: prefix, KeysOnly: true})
+		if err != nil {
+			return nil, err
+		}
+
+		entries, err := results.Rest()
+		if err != nil {
+			return nil, err
+		}
+
+		keys := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			keys = append(keys, entry.Key)
+		}
+
+		return PageKeys(keys, after, limit), nil
+	}
+
+	keysPrefix := s.keyPrefix(keysNs).String()
+	lower := s.keyPrefix(keysNs).Child(ds.NewKey(prefix)).String() + "/"
+	upper := []byte(lower)
+	upper[len(upper)-1]++
+
+	iter, err := store.DB.NewIter(&pebble.IterOptions{LowerBound: []byte(lower), UpperBound: upper})
+	if err != nil {
+		return nil, fmt.Errorf("opening iterator failed with: %w", err)
+	}
+	defer iter.Close()
+
+	valid := iter.First()
+	if after != "" {
+		valid = iter.SeekGE([]byte(s.valueKey(ds.NewKey(after).String()).String() + "\x00"))
+	}
+
+	vSuffix := "/" + valueSuffix
+	keys := make([]string, 0, max(limit, 0))
+	for ; valid && (limit <= 0 || len(keys) < limit); valid = iter.Next() {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		key := string(iter.Key())
+		if !strings.HasSuffix(key, vSuffix) {
+			continue
+		}
+
+		keys = append(keys, strings.TrimSuffix(strings.TrimPrefix(key, keysPrefix), vSuffix))
+	}
+
+	return keys, iter.Error()
+}
@@ -0,0 +1,75 @@
+package kvdb
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	pebbleds "github.com/ipfs/go-ds-pebble"
+)
+
+func TestScan(t *testing.T) {
+	pebble, err := pebbleds.NewDatastore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, store := range map[string]ds.Datastore{
+		"map":    dssync.MutexWrap(ds.NewMapDatastore()),
+		"pebble": pebble,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			d := newTestDatastore(t, store)
+
+			for _, key := range []string{"/m/3", "/m/1", "/m/1/x", "/m/2", "/m/4", "/n/1", "/m0"} {
+				if err := d.Put(ctx, ds.NewKey(key), []byte(key)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := d.Delete(ctx, ds.NewKey("/m/4")); err != nil {
+				t.Fatal(err)
+			}
+
+			var all []string
+			for after := ""; ; {
+				page, err := d.set.scan(ctx, "m", after, 2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(page) == 0 {
+					break
+				}
+				all = append(all, page...)
+				after = page[len(page)-1]
+			}
+
+			if want := []string{"/m/1", "/m/1/x", "/m/2", "/m/3"}; !slices.Equal(all, want) {
+				t.Fatalf("scanned %v, want %v", all, want)
+			}
+
+			if page, _ := d.set.scan(ctx, "/m/", "m/2", 0); !slices.Equal(page, []string{"/m/3"}) {
+				t.Fatalf("page after m/2 is %v", page)
+			}
+		})
+	}
+}
+
+func TestPageKeys(t *testing.T) {
+	keys := []string{"/c", "/a", "/d", "/b"}
+
+	if got := PageKeys(slices.Clone(keys), "", 2); !slices.Equal(got, []string{"/a", "/b"}) {
+		t.Fatalf("first page is %v", got)
+	}
+	if got := PageKeys(slices.Clone(keys), "b", 2); !slices.Equal(got, []string{"/c", "/d"}) {
+		t.Fatalf("page after b is %v", got)
+	}
+	if got := PageKeys(slices.Clone(keys), "/d", 2); len(got) != 0 {
+		t.Fatalf("page after the last key is %v", got)
+	}
+	if got := PageKeys(slices.Clone(keys), "", 0); len(got) != 4 {
+		t.Fatalf("unlimited page is %v", got)
+	}
+}
@@ -45,6 +45,26 @@ func (g getter) Type() string {
 	return basic.Get[string](g, "certificate", "type")
 }
 
+func (g getter) Records() []string {
+	return basic.Get[[]string](g, "records")
+}
+
+func (g getter) RateLimit() int {
+	return basic.Get[int](g, "limits", "rate")
+}
+
+func (g getter) RateBurst() int {
+	return basic.Get[int](g, "limits", "burst")
+}
+
+func (g getter) RateKey() string {
+	return basic.Get[string](g, "limits", "key")
+}
+
+func (g getter) Concurrency() int {
+	return basic.Get[int](g, "limits", "concurrency")
+}
+
 func (g getter) SmartOps() []string {
 	return basic.Get[[]string](g, "smartops")
 }
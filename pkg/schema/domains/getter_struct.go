@@ -12,6 +12,11 @@ func (g getter) Struct() (dom *structureSpec.Domain, err error) {
 		Tags:        g.Tags(),
 		Fqdn:        g.FQDN(),
 		CertType:    g.Type(),
+		Records:     g.Records(),
+		RateLimit:   g.RateLimit(),
+		RateBurst:   g.RateBurst(),
+		RateKey:     g.RateKey(),
+		Concurrency: g.Concurrency(),
 	}
 
 	if dom.CertType == "inline" {
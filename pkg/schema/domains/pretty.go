@@ -4,7 +4,7 @@ import "github.com/taubyte/tau/pkg/schema/pretty"
 
 func (d *domain) Prettify(pretty.Prettier) map[string]interface{} {
 	getter := d.Get()
-	return map[string]interface {
+	prettied := map[string]interface {
 	}{
 		"Id":             getter.Id(),
 		"Name":           getter.Name(),
@@ -18,4 +18,20 @@ func (d *domain) Prettify(pretty.Prettier) map[string]interface{} {
 		// "Cert":           getter.Cert(),
 		// "Key":            getter.Key(),
 	}
+
+	if records := getter.Records(); len(records) > 0 {
+		prettied["Records"] = records
+	}
+
+	if rate := getter.RateLimit(); rate > 0 {
+		prettied["RateLimit"] = rate
+		prettied["RateBurst"] = getter.RateBurst()
+		prettied["RateKey"] = getter.RateKey()
+	}
+
+	if concurrency := getter.Concurrency(); concurrency > 0 {
+		prettied["Concurrency"] = concurrency
+	}
+
+	return prettied
 }
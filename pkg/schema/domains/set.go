@@ -33,6 +33,26 @@ func Type(value string) basic.Op {
 	return basic.SetChild("certificate", "type", value)
 }
 
+func Records(value []string) basic.Op {
+	return basic.Set("records", value)
+}
+
+func RateLimit(value int) basic.Op {
+	return basic.SetChild("limits", "rate", value)
+}
+
+func RateBurst(value int) basic.Op {
+	return basic.SetChild("limits", "burst", value)
+}
+
+func RateKey(value string) basic.Op {
+	return basic.SetChild("limits", "key", value)
+}
+
+func Concurrency(value int) basic.Op {
+	return basic.SetChild("limits", "concurrency", value)
+}
+
 func SmartOps(value []string) basic.Op {
 	return basic.Set("smartops", value)
 }
@@ -51,6 +51,18 @@ func (d *domain) SetWithStruct(sync bool, domain *structureSpec.Domain) error {
 			}
 			return nil
 		}},
+		{"Records", true, func() error {
+			ops = append(ops, Records(domain.Records))
+			return nil
+		}},
+		{"RateLimit", true, func() error {
+			ops = append(ops, RateLimit(domain.RateLimit), RateBurst(domain.RateBurst), RateKey(domain.RateKey))
+			return nil
+		}},
+		{"Concurrency", true, func() error {
+			ops = append(ops, Concurrency(domain.Concurrency))
+			return nil
+		}},
 		{"SmartOps", true, func() error {
 			ops = append(ops, SmartOps(domain.SmartOps))
 			return nil
@@ -52,6 +52,60 @@ func TestStruct(t *testing.T) {
 	})
 }
 
+func TestStructRecords(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	dom, err := project.Domain("test_domain1", "")
+	assert.NilError(t, err)
+
+	records := []string{"@ MX 10 mail", "@ TXT v=spf1 -all"}
+	err = dom.SetWithStruct(true, &structureSpec.Domain{
+		Id:      "domain1ID",
+		Fqdn:    "hal.computers.com",
+		Records: records,
+	})
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, dom.Get().Records(), records)
+
+	_struct, err := dom.Get().Struct()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, _struct.Records, records)
+
+	assert.DeepEqual(t, dom.Prettify(nil)["Records"], records)
+}
+
+func TestStructLimits(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	dom, err := project.Domain("test_domain1", "")
+	assert.NilError(t, err)
+
+	err = dom.SetWithStruct(true, &structureSpec.Domain{
+		Id:          "domain1ID",
+		Fqdn:        "hal.computers.com",
+		RateLimit:   100,
+		RateKey:     "X-Forwarded-User",
+		Concurrency: 50,
+	})
+	assert.NilError(t, err)
+
+	getter := dom.Get()
+	assert.Equal(t, getter.RateLimit(), 100)
+	assert.Equal(t, getter.RateBurst(), 0)
+	assert.Equal(t, getter.RateKey(), "X-Forwarded-User")
+	assert.Equal(t, getter.Concurrency(), 50)
+
+	_struct, err := getter.Struct()
+	assert.NilError(t, err)
+	assert.Equal(t, _struct.RateLimit, 100)
+	assert.Equal(t, _struct.Concurrency, 50)
+
+	assert.Equal(t, dom.Prettify(nil)["Concurrency"], 50)
+}
+
 func TestStructError(t *testing.T) {
 	project, err := internal.NewProjectEmpty()
 	assert.NilError(t, err)
@@ -26,4 +26,9 @@ type Getter interface {
 	Type() string
 	Cert() string
 	Key() string
+	Records() []string
+	RateLimit() int
+	RateBurst() int
+	RateKey() string
+	Concurrency() int
 }
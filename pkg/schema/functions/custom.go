@@ -0,0 +1,22 @@
+package functions
+
+import (
+	"github.com/taubyte/tau/pkg/schema/basic"
+	seer "github.com/taubyte/tau/pkg/yaseer"
+)
+
+// Setters tcc-gen doesn't derive: it only emits them for paths at most two
+// levels deep.
+
+// RateLimit sets the requests per second and the burst each client may make,
+// clients being told apart by the header key, or their IP when it is empty.
+func RateLimit(rate, burst int, key string) basic.Op {
+	return func(c basic.ConfigIface) []*seer.Query {
+		limit := c.Config().Get("execution").Get("rate-limit")
+		return []*seer.Query{
+			limit.Get("rate").Set(rate),
+			limit.Get("burst").Set(burst),
+			limit.Get("key").Set(key),
+		}
+	}
+}
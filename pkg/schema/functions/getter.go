@@ -78,6 +78,26 @@ func (g getter) Paths() []string {
 	return basic.Get[[]string](g, "trigger", "paths")
 }
 
+func (g getter) Cron() string {
+	return basic.Get[string](g, "trigger", "cron")
+}
+
+func (g getter) Timezone() string {
+	return basic.Get[string](g, "trigger", "timezone")
+}
+
+func (g getter) Resource() string {
+	return basic.Get[string](g, "trigger", "resource")
+}
+
+func (g getter) Prefix() string {
+	return basic.Get[string](g, "trigger", "prefix")
+}
+
+func (g getter) Events() []string {
+	return basic.Get[[]string](g, "trigger", "events")
+}
+
 func (g getter) Source() string {
 	return basic.Get[string](g, "source")
 }
@@ -90,6 +110,22 @@ func (g getter) Memory() string {
 	return basic.Get[string](g, "execution", "memory")
 }
 
+func (g getter) RateLimit() int {
+	return basic.Get[int](g, "execution", "rate-limit", "rate")
+}
+
+func (g getter) RateBurst() int {
+	return basic.Get[int](g, "execution", "rate-limit", "burst")
+}
+
+func (g getter) RateKey() string {
+	return basic.Get[string](g, "execution", "rate-limit", "key")
+}
+
+func (g getter) Concurrency() int {
+	return basic.Get[int](g, "execution", "concurrency")
+}
+
 func (g getter) Call() string {
 	return basic.Get[string](g, "execution", "call")
 }
@@ -25,6 +25,10 @@ func (g getter) Struct() (fun *structureSpec.Function, err error) {
 		Type:        _type,
 		Timeout:     timeout,
 		Memory:      memory,
+		RateLimit:   g.RateLimit(),
+		RateBurst:   g.RateBurst(),
+		RateKey:     g.RateKey(),
+		Concurrency: g.Concurrency(),
 		Call:        g.Call(),
 		Source:      g.Source(),
 		SmartOps:    g.SmartOps(),
@@ -43,6 +47,13 @@ func (g getter) Struct() (fun *structureSpec.Function, err error) {
 	case "pubsub":
 		fun.Channel = g.Channel()
 		fun.Local = g.Local()
+	case "schedule":
+		fun.Cron = g.Cron()
+		fun.Timezone = g.Timezone()
+	case "storage", "database":
+		fun.Resource = g.Resource()
+		fun.Prefix = g.Prefix()
+		fun.Events = g.Events()
 	}
 
 	return
@@ -23,6 +23,16 @@ func (f *function) Prettify(p pretty.Prettier) map[string]interface{} {
 		"Call":        getter.Call(),
 	}
 
+	if rate := getter.RateLimit(); rate > 0 {
+		obj["RateLimit"] = rate
+		obj["RateBurst"] = getter.RateBurst()
+		obj["RateKey"] = getter.RateKey()
+	}
+
+	if concurrency := getter.Concurrency(); concurrency > 0 {
+		obj["Concurrency"] = concurrency
+	}
+
 	switch _type {
 	case "http", "https":
 		obj["Method"] = getter.Method()
@@ -32,6 +42,13 @@ func (f *function) Prettify(p pretty.Prettier) map[string]interface{} {
 		obj["Command"] = getter.Command()
 		obj["Local"] = getter.Local()
 		obj["Protocol"] = getter.Protocol()
+	case "schedule":
+		obj["Cron"] = getter.Cron()
+		obj["Timezone"] = getter.Timezone()
+	case "storage", "database":
+		obj["Resource"] = getter.Resource()
+		obj["Prefix"] = getter.Prefix()
+		obj["Events"] = getter.Events()
 	default:
 		obj["Channel"] = getter.Channel()
 		obj["Local"] = getter.Local()
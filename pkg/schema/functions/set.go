@@ -54,6 +54,26 @@ func Paths(value []string) basic.Op {
 	return basic.SetChild("trigger", "paths", value)
 }
 
+func Cron(value string) basic.Op {
+	return basic.SetChild("trigger", "cron", value)
+}
+
+func Timezone(value string) basic.Op {
+	return basic.SetChild("trigger", "timezone", value)
+}
+
+func Resource(value string) basic.Op {
+	return basic.SetChild("trigger", "resource", value)
+}
+
+func Prefix(value string) basic.Op {
+	return basic.SetChild("trigger", "prefix", value)
+}
+
+func Events(value []string) basic.Op {
+	return basic.SetChild("trigger", "events", value)
+}
+
 func Source(value string) basic.Op {
 	return basic.Set("source", value)
 }
@@ -66,6 +86,10 @@ func Memory(value string) basic.Op {
 	return basic.SetChild("execution", "memory", value)
 }
 
+func Concurrency(value int) basic.Op {
+	return basic.SetChild("execution", "concurrency", value)
+}
+
 func Call(value string) basic.Op {
 	return basic.SetChild("execution", "call", value)
 }
@@ -57,7 +57,7 @@ func (f *function) SetWithStruct(sync bool, function *structureSpec.Function) er
 		}},
 		{"Method", true, func() error {
 			switch function.Type {
-			case "pubsub", "p2p":
+			case "pubsub", "p2p", "schedule", "storage", "database":
 			default:
 				ops = append(ops, Method(function.Method))
 			}
@@ -65,12 +65,47 @@ func (f *function) SetWithStruct(sync bool, function *structureSpec.Function) er
 		}},
 		{"Paths", true, func() error {
 			switch function.Type {
-			case "pubsub", "p2p":
+			case "pubsub", "p2p", "schedule", "storage", "database":
 			default:
 				ops = append(ops, Paths(function.Paths))
 			}
 			return nil
 		}},
+		{"Cron", true, func() error {
+			switch function.Type {
+			case "schedule":
+				ops = append(ops, Cron(function.Cron))
+			}
+			return nil
+		}},
+		{"Timezone", false, func() error {
+			switch function.Type {
+			case "schedule":
+				ops = append(ops, Timezone(function.Timezone))
+			}
+			return nil
+		}},
+		{"Resource", true, func() error {
+			switch function.Type {
+			case "storage", "database":
+				ops = append(ops, Resource(function.Resource))
+			}
+			return nil
+		}},
+		{"Prefix", true, func() error {
+			switch function.Type {
+			case "storage", "database":
+				ops = append(ops, Prefix(function.Prefix))
+			}
+			return nil
+		}},
+		{"Events", true, func() error {
+			switch function.Type {
+			case "storage", "database":
+				ops = append(ops, Events(function.Events))
+			}
+			return nil
+		}},
 		{"Timeout", true, func() error {
 			ops = append(ops, Timeout(common.TimeToString(function.Timeout)))
 			return nil
@@ -79,6 +114,14 @@ func (f *function) SetWithStruct(sync bool, function *structureSpec.Function) er
 			ops = append(ops, Memory(common.UnitsToString(function.Memory)))
 			return nil
 		}},
+		{"RateLimit", true, func() error {
+			ops = append(ops, RateLimit(function.RateLimit, function.RateBurst, function.RateKey))
+			return nil
+		}},
+		{"Concurrency", true, func() error {
+			ops = append(ops, Concurrency(function.Concurrency))
+			return nil
+		}},
 		{"Call", true, func() error {
 			ops = append(ops, Call(function.Call))
 			return nil
@@ -88,6 +88,123 @@ func TestStructP2P(t *testing.T) {
 	assertFunction3_p2p(t, fun.Get())
 }
 
+func TestStructSchedule(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	fun, err := project.Function("test_function4", "")
+	assert.NilError(t, err)
+
+	err = fun.SetWithStruct(true, &structureSpec.Function{
+		Id:       "function4ID",
+		Name:     "test_function4",
+		Type:     "schedule",
+		Cron:     "*/5 * * * *",
+		Timezone: "Europe/Paris",
+		Source:   ".",
+		Timeout:  uint64(10 * time.Second),
+		Memory:   uint64(16 * units.MB),
+		Call:     "tick",
+	})
+	assert.NilError(t, err)
+
+	_struct, err := fun.Get().Struct()
+	assert.NilError(t, err)
+
+	eql(t, [][]any{
+		{_struct.Type, "schedule"},
+		{_struct.Cron, "*/5 * * * *"},
+		{_struct.Timezone, "Europe/Paris"},
+		{_struct.Method, ""},
+		{len(_struct.Paths), 0},
+		{_struct.Channel, ""},
+		{_struct.Call, "tick"},
+	})
+}
+
+func TestStructStorageEvents(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	fun, err := project.Function("test_function5", "")
+	assert.NilError(t, err)
+
+	err = fun.SetWithStruct(true, &structureSpec.Function{
+		Id:       "function5ID",
+		Name:     "test_function5",
+		Type:     "storage",
+		Resource: "images",
+		Prefix:   "uploads/",
+		Events:   []string{"put", "new-version"},
+		Source:   ".",
+		Timeout:  uint64(10 * time.Second),
+		Memory:   uint64(16 * units.MB),
+		Call:     "resize",
+	})
+	assert.NilError(t, err)
+
+	_struct, err := fun.Get().Struct()
+	assert.NilError(t, err)
+
+	eql(t, [][]any{
+		{_struct.Type, "storage"},
+		{_struct.Resource, "images"},
+		{_struct.Prefix, "uploads/"},
+		{len(_struct.Events), 2},
+		{_struct.Events[1], "new-version"},
+		{_struct.Method, ""},
+		{len(_struct.Paths), 0},
+		{_struct.Cron, ""},
+		{_struct.Call, "resize"},
+	})
+}
+
+func TestStructLimits(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	fun, err := project.Function("test_function6", "")
+	assert.NilError(t, err)
+
+	err = fun.SetWithStruct(true, &structureSpec.Function{
+		Id:          "function6ID",
+		Name:        "test_function6",
+		Type:        "https",
+		Domains:     []string{"test_domain1"},
+		Method:      "post",
+		Paths:       []string{"/upload"},
+		Source:      ".",
+		Timeout:     uint64(10 * time.Second),
+		Memory:      uint64(16 * units.MB),
+		RateLimit:   20,
+		RateBurst:   40,
+		RateKey:     "X-Api-Key",
+		Concurrency: 8,
+		Call:        "upload",
+	})
+	assert.NilError(t, err)
+
+	getter := fun.Get()
+	eql(t, [][]any{
+		{getter.RateLimit(), 20},
+		{getter.RateBurst(), 40},
+		{getter.RateKey(), "X-Api-Key"},
+		{getter.Concurrency(), 8},
+	})
+
+	_struct, err := getter.Struct()
+	assert.NilError(t, err)
+
+	eql(t, [][]any{
+		{_struct.RateLimit, 20},
+		{_struct.RateBurst, 40},
+		{_struct.RateKey, "X-Api-Key"},
+		{_struct.Concurrency, 8},
+	})
+
+	assert.Equal(t, fun.Prettify(nil)["RateKey"], "X-Api-Key")
+}
+
 func TestStructError(t *testing.T) {
 	project, err := internal.NewProjectEmpty()
 	assert.NilError(t, err)
@@ -27,10 +27,19 @@ type Getter interface {
 	Local() bool
 	Command() string
 	Channel() string
+	Cron() string
+	Timezone() string
+	Resource() string
+	Prefix() string
+	Events() []string
 	Source() string
 	Domains() []string
 	Timeout() string
 	Memory() string
+	RateLimit() int
+	RateBurst() int
+	RateKey() string
+	Concurrency() int
 	Call() string
 	Protocol() string
 }
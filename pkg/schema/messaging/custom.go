@@ -28,3 +28,18 @@ func Bridges(mqtt bool, websocket bool) basic.Op {
 		}
 	}
 }
+
+// Persistence turns the durable log of the channel on or off, with its
+// retention and delivery rules.
+func Persistence(enable bool, retention, size string, retries int, deadLetter string) basic.Op {
+	return func(c basic.ConfigIface) []*seer.Query {
+		persistence := c.Config().Get("persistence")
+		return []*seer.Query{
+			persistence.Get("enable").Set(enable),
+			persistence.Get("retention").Set(retention),
+			persistence.Get("size").Set(size),
+			persistence.Get("retries").Set(retries),
+			persistence.Get("dead-letter").Set(deadLetter),
+		}
+	}
+}
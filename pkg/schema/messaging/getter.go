@@ -53,6 +53,26 @@ func (g getter) WebSocket() bool {
 	return basic.Get[bool](g, "bridges", "websocket", "enable")
 }
 
+func (g getter) Persistent() bool {
+	return basic.Get[bool](g, "persistence", "enable")
+}
+
+func (g getter) Retention() string {
+	return basic.Get[string](g, "persistence", "retention")
+}
+
+func (g getter) RetentionSize() string {
+	return basic.Get[string](g, "persistence", "size")
+}
+
+func (g getter) Retries() int {
+	return basic.Get[int](g, "persistence", "retries")
+}
+
+func (g getter) DeadLetter() string {
+	return basic.Get[string](g, "persistence", "dead-letter")
+}
+
 func (g getter) SmartOps() []string {
 	return basic.Get[[]string](g, "smartops")
 }
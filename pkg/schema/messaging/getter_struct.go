@@ -1,6 +1,7 @@
 package messaging
 
 import (
+	"github.com/taubyte/tau/pkg/schema/common"
 	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
 )
 
@@ -18,5 +19,18 @@ func (g getter) Struct() (msg *structureSpec.Messaging, err error) {
 		SmartOps:    g.SmartOps(),
 	}
 
+	if msg.Persistent = g.Persistent(); msg.Persistent {
+		if msg.Retention, err = common.StringToTime(g.Retention()); err != nil {
+			return nil, err
+		}
+
+		if msg.RetentionSize, err = common.StringToUnits(g.RetentionSize()); err != nil {
+			return nil, err
+		}
+
+		msg.Retries = g.Retries()
+		msg.DeadLetter = g.DeadLetter()
+	}
+
 	return
 }
@@ -4,7 +4,7 @@ import "github.com/taubyte/tau/pkg/schema/pretty"
 
 func (m *messaging) Prettify(pretty.Prettier) map[string]interface{} {
 	getter := m.Get()
-	return map[string]interface {
+	obj := map[string]interface {
 	}{
 		"Id":           getter.Id(),
 		"Name":         getter.Name(),
@@ -16,4 +16,14 @@ func (m *messaging) Prettify(pretty.Prettier) map[string]interface{} {
 		"MQTT":         getter.MQTT(),
 		"WebSocket":    getter.WebSocket(),
 	}
+
+	if getter.Persistent() {
+		obj["Persistent"] = true
+		obj["Retention"] = getter.Retention()
+		obj["RetentionSize"] = getter.RetentionSize()
+		obj["Retries"] = getter.Retries()
+		obj["DeadLetter"] = getter.DeadLetter()
+	}
+
+	return obj
 }
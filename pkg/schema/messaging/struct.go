@@ -40,6 +40,17 @@ func (m *messaging) SetWithStruct(sync bool, messaging *structureSpec.Messaging)
 
 	ops = append(ops, Channel(messaging.Regex, messaging.Match))
 	ops = append(ops, Bridges(messaging.MQTT, messaging.WebSocket))
+	if messaging.Persistent {
+		var retention, size string
+		if messaging.Retention > 0 {
+			retention = common.TimeToString(messaging.Retention)
+		}
+		if messaging.RetentionSize > 0 {
+			size = common.UnitsToString(messaging.RetentionSize)
+		}
+
+		ops = append(ops, Persistence(true, retention, size, messaging.Retries, messaging.DeadLetter))
+	}
 
 	return m.Set(sync, ops...)
 }
@@ -2,6 +2,7 @@ package messaging_test
 
 import (
 	"testing"
+	"time"
 
 	internal "github.com/taubyte/tau/pkg/schema/internal/test"
 	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
@@ -60,3 +61,35 @@ func TestStructError(t *testing.T) {
 	err = msg.SetWithStruct(true, nil)
 	assert.ErrorContains(t, err, "nil pointer")
 }
+
+func TestStructPersistence(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	msg, err := project.Messaging("test_messaging1", "")
+	assert.NilError(t, err)
+
+	err = msg.SetWithStruct(true, &structureSpec.Messaging{
+		Id:            "messaging1ID",
+		Match:         "orders",
+		Persistent:    true,
+		Retention:     uint64(24 * time.Hour),
+		RetentionSize: 64 * 1000 * 1000,
+		Retries:       3,
+		DeadLetter:    "orders-dead",
+	})
+	assert.NilError(t, err)
+
+	getter := msg.Get()
+	assert.Equal(t, getter.Persistent(), true)
+	assert.Equal(t, getter.Retention(), "24h")
+	assert.Equal(t, getter.Retries(), 3)
+	assert.Equal(t, getter.DeadLetter(), "orders-dead")
+
+	_struct, err := getter.Struct()
+	assert.NilError(t, err)
+	assert.Equal(t, _struct.Retention, uint64(24*time.Hour))
+	assert.Equal(t, _struct.RetentionSize, uint64(64*1000*1000))
+	assert.Equal(t, _struct.Retries, 3)
+	assert.Equal(t, _struct.DeadLetter, "orders-dead")
+}
@@ -26,4 +26,9 @@ type Getter interface {
 	ChannelMatch() string
 	MQTT() bool
 	WebSocket() bool
+	Persistent() bool
+	Retention() string
+	RetentionSize() string
+	Retries() int
+	DeadLetter() string
 }
@@ -38,6 +38,20 @@ func Object(versioning bool, size string) basic.Op {
 	}
 }
 
+// KeepVersions and ExpireVersions set the version retention rules of an object
+// storage.
+func KeepVersions(keep int) basic.Op {
+	return func(c basic.ConfigIface) []*seer.Query {
+		return []*seer.Query{c.Config().Get("object").Get("versions").Get("keep").Set(keep)}
+	}
+}
+
+func ExpireVersions(expire string) basic.Op {
+	return func(c basic.ConfigIface) []*seer.Query {
+		return []*seer.Query{c.Config().Get("object").Get("versions").Get("expire").Set(expire)}
+	}
+}
+
 func Streaming(ttl string, size string) basic.Op {
 	return func(c basic.ConfigIface) []*seer.Query {
 		streaming := c.Config().Get("streaming")
@@ -54,6 +54,14 @@ func (g getter) Versioning() bool {
 	return basic.Get[bool](g, "object", "versioning")
 }
 
+func (g getter) KeepVersions() int {
+	return basic.Get[int](g, "object", "versions", "keep")
+}
+
+func (g getter) ExpireVersions() string {
+	return basic.Get[string](g, "object", "versions", "expire")
+}
+
 func (g getter) TTL() string {
 	return basic.Get[string](g, "streaming", "ttl")
 }
@@ -29,6 +29,11 @@ func (g getter) Struct() (stg *structureSpec.Storage, err error) {
 	switch _type {
 	case "object":
 		stg.Versioning = g.Versioning()
+		stg.KeepVersions = g.KeepVersions()
+		stg.ExpireVersions, err = common.StringToTime(g.ExpireVersions())
+		if err != nil {
+			return nil, err
+		}
 	case "streaming":
 		stg.Ttl, err = common.StringToTime(g.TTL())
 		if err != nil {
@@ -22,6 +22,12 @@ func (s *storage) Prettify(pretty.Prettier) map[string]interface{} {
 	case "object":
 		obj["Public"] = getter.Public()
 		obj["Versioning"] = getter.Versioning()
+		if keep := getter.KeepVersions(); keep > 0 {
+			obj["KeepVersions"] = keep
+		}
+		if expire := getter.ExpireVersions(); expire != "" {
+			obj["ExpireVersions"] = expire
+		}
 	case "streaming":
 		obj["TTL"] = getter.TTL()
 	}
@@ -47,6 +47,18 @@ func (s *storage) SetWithStruct(sync bool, storage *structureSpec.Storage) error
 			}
 			return nil
 		}},
+		{"KeepVersions", true, func() error {
+			if storage.Type == "object" {
+				ops = append(ops, KeepVersions(storage.KeepVersions))
+			}
+			return nil
+		}},
+		{"ExpireVersions", true, func() error {
+			if storage.Type == "object" {
+				ops = append(ops, ExpireVersions(common.TimeToString(storage.ExpireVersions)))
+			}
+			return nil
+		}},
 		{"SmartOps", true, func() error {
 			ops = append(ops, SmartOps(storage.SmartOps))
 			return nil
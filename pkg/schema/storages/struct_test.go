@@ -87,3 +87,31 @@ func TestStructError(t *testing.T) {
 	err = stg.SetWithStruct(true, &structureSpec.Storage{})
 	assert.ErrorContains(t, err, "failed with: Storage type `` not allowed")
 }
+
+func TestStructObjectRetention(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	stg, err := project.Storage("test_storage2", "test_app1")
+	assert.NilError(t, err)
+
+	err = stg.SetWithStruct(true, &structureSpec.Storage{
+		Id:             "storage2ID",
+		Match:          "users",
+		Versioning:     true,
+		KeepVersions:   5,
+		ExpireVersions: uint64(720 * time.Hour),
+		Type:           "object",
+		Size:           uint64(50 * units.GB),
+	})
+	assert.NilError(t, err)
+
+	getter := stg.Get()
+	assert.Equal(t, getter.KeepVersions(), 5)
+	assert.Equal(t, getter.ExpireVersions(), "720h")
+
+	_struct, err := getter.Struct()
+	assert.NilError(t, err)
+	assert.Equal(t, _struct.KeepVersions, 5)
+	assert.Equal(t, _struct.ExpireVersions, uint64(720*time.Hour))
+}
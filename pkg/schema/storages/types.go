@@ -28,6 +28,8 @@ type Getter interface {
 	// if bucket type Object
 	Public() bool
 	Versioning() bool
+	KeepVersions() int
+	ExpireVersions() string
 
 	// if bucket type Streaming
 	TTL() string
@@ -49,6 +49,10 @@ func (g getter) Branch() string {
 	return basic.Get[string](g, "source", "branch")
 }
 
+func (g getter) SPA() bool {
+	return basic.Get[bool](g, "spa")
+}
+
 func (g getter) SmartOps() []string {
 	return basic.Get[[]string](g, "smartops")
 }
@@ -20,5 +20,10 @@ func (g getter) Struct() (web *structureSpec.Website, err error) {
 		SmartOps:    g.SmartOps(),
 	}
 
+	// Websites are single page applications unless set otherwise.
+	spa := true
+	g.Config().Get("spa").Value(&spa)
+	web.SPA = &spa
+
 	return
 }
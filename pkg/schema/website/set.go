@@ -29,6 +29,10 @@ func Branch(value string) basic.Op {
 	return basic.SetChild("source", "branch", value)
 }
 
+func SPA(value bool) basic.Op {
+	return basic.Set("spa", value)
+}
+
 func SmartOps(value []string) basic.Op {
 	return basic.Set("smartops", value)
 }
@@ -35,6 +35,10 @@ func (w *website) SetWithStruct(sync bool, website *structureSpec.Website) error
 			ops = append(ops, Branch(website.Branch))
 			return nil
 		}},
+		{"SPA", true, func() error {
+			ops = append(ops, SPA(*website.SPA))
+			return nil
+		}},
 		{"Provider", true, func() error {
 			switch website.Provider {
 			case "github":
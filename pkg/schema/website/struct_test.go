@@ -73,3 +73,31 @@ func TestStructError(t *testing.T) {
 	})
 	assert.ErrorContains(t, err, "Git provider `unsupported` not supported")
 }
+
+func TestStructSPA(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	web, err := project.Website("test_website1", "")
+	assert.NilError(t, err)
+
+	err = web.Set(true)
+	assert.NilError(t, err)
+
+	_struct, err := web.Get().Struct()
+	assert.NilError(t, err)
+	assert.Equal(t, *_struct.SPA, true)
+
+	spa := false
+	err = web.SetWithStruct(true, &structureSpec.Website{
+		Id:       "website1ID",
+		Provider: "github",
+		SPA:      &spa,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, web.Get().SPA(), false)
+
+	_struct, err = web.Get().Struct()
+	assert.NilError(t, err)
+	assert.Equal(t, *_struct.SPA, false)
+}
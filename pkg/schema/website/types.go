@@ -24,5 +24,6 @@ type Getter interface {
 	Domains() []string
 	Paths() []string
 	Branch() string
+	SPA() bool
 	Git() (provider, id, fullname string)
 }
@@ -2,6 +2,7 @@ package website
 
 import (
 	"path"
+	"strings"
 
 	ci "github.com/taubyte/tau/pkg/containers"
 	"github.com/taubyte/tau/pkg/specs/builders"
@@ -14,3 +15,8 @@ func (d Dir) BuildZip() string {
 func (d Dir) SetWorkDir() ci.ContainerOption {
 	return ci.WorkDir("/" + builders.Source)
 }
+
+// Compressible tells whether a file is worth compressing, from its extension.
+func Compressible(name string) bool {
+	return compressibleExtensions[strings.ToLower(path.Ext(name))]
+}
@@ -1,3 +1,30 @@
 package website
 
 const ZipFile = "build.zip"
+
+// Precompressed variants of a file sit next to it, named with these suffixes.
+const (
+	BrotliSuffix = ".br"
+	GzipSuffix   = ".gz"
+)
+
+// MinCompressSize is the size under which files aren't worth compressing.
+const MinCompressSize = 1024
+
+var compressibleExtensions = map[string]bool{
+	".css":         true,
+	".csv":         true,
+	".htm":         true,
+	".html":        true,
+	".ico":         true,
+	".js":          true,
+	".json":        true,
+	".map":         true,
+	".md":          true,
+	".mjs":         true,
+	".svg":         true,
+	".txt":         true,
+	".wasm":        true,
+	".webmanifest": true,
+	".xml":         true,
+}
@@ -4,6 +4,21 @@ func Current(projectId, branch string) *TnsPath {
 	return NewTnsPath(([]string{ProjectPathVariable.String(), projectId, BranchPathVariable.String(), branch, CurrentCommitPathVariable.String()}))
 }
 
+// Deployments is where the commits deployed on a project's branch are recorded.
+func Deployments(projectId, branch string) *TnsPath {
+	return NewTnsPath([]string{ProjectPathVariable.String(), projectId, BranchPathVariable.String(), branch, DeploymentsPathVariable.String()})
+}
+
+// Deployment is the record of a commit deployed on a project's branch.
+func Deployment(projectId, branch, commit string) *TnsPath {
+	return NewTnsPath(append(Deployments(projectId, branch).Slice(), commit))
+}
+
+// Traffic is where the traffic policy of a project's branch is kept.
+func Traffic(projectId, branch string) *TnsPath {
+	return NewTnsPath([]string{ProjectPathVariable.String(), projectId, BranchPathVariable.String(), branch, TrafficPathVariable.String()})
+}
+
 func (_path *TnsPath) Versioning() *VersioningPath {
 	return &VersioningPath{_path}
 }
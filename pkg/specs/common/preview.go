@@ -0,0 +1,92 @@
+package common
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+// PreviewSeparator splits the branch label from the generated host in a
+// preview domain: <branch>--<generated fqdn>.
+const PreviewSeparator = "--"
+
+// PreviewPathVariable holds the branch a preview label was derived from.
+const PreviewPathVariable PathVariable = "previews"
+
+// maxPreviewLabel leaves room in the 63 byte DNS label for the separator and
+// the generated prefix.
+const maxPreviewLabel = 40
+
+// IsDefaultBranch reports whether branch is one of DefaultBranches.
+func IsDefaultBranch(branch string) bool {
+	return slices.Contains(DefaultBranches, branch)
+}
+
+// MatchBranch reports whether branch matches one of the glob patterns.
+func MatchBranch(patterns []string, branch string) bool {
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, branch); err == nil && ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PreviewLabel turns a branch name into a DNS safe label.
+func PreviewLabel(branch string) string {
+	label := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '-'
+		}
+	}, branch)
+
+	for strings.Contains(label, PreviewSeparator) {
+		label = strings.ReplaceAll(label, PreviewSeparator, "-")
+	}
+
+	if len(label) > maxPreviewLabel {
+		label = label[:maxPreviewLabel]
+	}
+
+	return strings.Trim(label, "-")
+}
+
+// PreviewHost returns the host serving branch for the generated host.
+func PreviewHost(branch, host string) string {
+	return PreviewLabel(branch) + PreviewSeparator + host
+}
+
+// SplitPreviewHost splits a preview host into its branch label and the
+// generated host it previews.
+func SplitPreviewHost(host string) (label, base string, ok bool) {
+	label, base, ok = strings.Cut(host, PreviewSeparator)
+	if !ok || label == "" || base == "" || strings.Contains(label, ".") {
+		return "", "", false
+	}
+
+	return label, base, true
+}
+
+// Preview is the tns path mapping a preview label of a project to its branch.
+func Preview(projectId, label string) *TnsPath {
+	return NewTnsPath([]string{ProjectPathVariable.String(), projectId, PreviewPathVariable.String(), label})
+}
+
+// PreviewBranch returns the branch of a fetched Preview path, empty when the
+// label isn't registered:
+//
+//	branch := PreviewBranch(tnsClient.Fetch(Preview(projectId, label)))
+func PreviewBranch(obj interface{ Interface() interface{} }, err error) string {
+	if err != nil || obj == nil {
+		return ""
+	}
+
+	branch, _ := obj.Interface().(string)
+	return branch
+}
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMatchBranch(t *testing.T) {
+	patterns := []string{"feature/*", "dev"}
+	for branch, want := range map[string]bool{
+		"dev":           true,
+		"feature/login": true,
+		"feature":       false,
+		"fix/login":     false,
+	} {
+		if got := MatchBranch(patterns, branch); got != want {
+			t.Fatalf("MatchBranch(%q) = %v, want %v", branch, got, want)
+		}
+	}
+}
+
+func TestPreviewHost(t *testing.T) {
+	host := PreviewHost("Feature/New--Login", "abcd1234.g.tau.link")
+	if host != "feature-new-login--abcd1234.g.tau.link" {
+		t.Fatalf("unexpected preview host %q", host)
+	}
+
+	label, base, ok := SplitPreviewHost(host)
+	if !ok || label != "feature-new-login" || base != "abcd1234.g.tau.link" {
+		t.Fatalf("unexpected split %q %q %v", label, base, ok)
+	}
+
+	if _, _, ok = SplitPreviewHost("abcd1234.g.tau.link"); ok {
+		t.Fatal("plain host should not be a preview host")
+	}
+
+	if _, _, ok = SplitPreviewHost("a.b--c.tau.link"); ok {
+		t.Fatal("separator outside the first label should not be a preview host")
+	}
+}
+
+type previewObject struct{ value interface{} }
+
+func (o previewObject) Interface() interface{} { return o.value }
+
+func TestPreviewBranch(t *testing.T) {
+	if branch := PreviewBranch(previewObject{"feature/login"}, nil); branch != "feature/login" {
+		t.Fatalf("unexpected branch %q", branch)
+	}
+
+	for _, obj := range []previewObject{{nil}, {""}, {42}} {
+		if branch := PreviewBranch(obj, nil); branch != "" {
+			t.Fatalf("unexpected branch %q for %v", branch, obj.value)
+		}
+	}
+
+	if branch := PreviewBranch(previewObject{"feature/login"}, errors.New("not found")); branch != "" {
+		t.Fatalf("unexpected branch %q on error", branch)
+	}
+
+	if branch := PreviewBranch(nil, nil); branch != "" {
+		t.Fatalf("unexpected branch %q without object", branch)
+	}
+}
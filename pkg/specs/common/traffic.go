@@ -0,0 +1,132 @@
+package common
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+
+	"github.com/taubyte/tau/utils/mapstructure"
+)
+
+const (
+	// TrafficHeader carries the key a request is assigned a commit by. It
+	// takes precedence over TrafficCookie.
+	TrafficHeader = "X-Tau-Traffic-Key"
+
+	// TrafficCookie keeps the key of a client, so it sticks to its commit.
+	TrafficCookie = "tau-traffic"
+)
+
+// TrafficPolicy splits the traffic of a project's branch between two deployed
+// commits: Weight percent of the keys go to Canary, the others to Stable. An
+// empty Canary is the branch's current commit. Without a Stable, the policy is
+// inactive and the current commit gets everything.
+type TrafficPolicy struct {
+	Stable string `json:"stable"`
+	Canary string `json:"canary,omitempty"`
+	Weight int    `json:"weight"`
+}
+
+// DecodeTrafficPolicy reads a policy as stored in tns.
+func DecodeTrafficPolicy(value interface{}) (*TrafficPolicy, error) {
+	policy := new(TrafficPolicy)
+	if value == nil {
+		return policy, nil
+	}
+
+	if err := mapstructure.Decode(value, policy); err != nil {
+		return nil, fmt.Errorf("decoding traffic policy failed with: %w", err)
+	}
+
+	return policy, nil
+}
+
+// Active reports whether the policy splits traffic.
+func (p *TrafficPolicy) Active() bool {
+	return p != nil && p.Stable != ""
+}
+
+// Validate checks the policy can be pushed.
+func (p *TrafficPolicy) Validate() error {
+	if p.Stable == "" {
+		return errors.New("traffic policy has no stable commit")
+	}
+
+	if p.Weight < 0 || p.Weight > 100 {
+		return fmt.Errorf("traffic weight %d is not between 0 and 100", p.Weight)
+	}
+
+	return nil
+}
+
+// CanaryOf returns the commit the canary share goes to, given the current one.
+func (p *TrafficPolicy) CanaryOf(current string) string {
+	if p.Canary != "" {
+		return p.Canary
+	}
+
+	return current
+}
+
+// Pick returns the commit serving key. Keys are spread evenly over 100
+// buckets per canary, the lower Weight of them going to it: raising the weight
+// keeps the keys already on the canary there.
+func (p *TrafficPolicy) Pick(key, current string) string {
+	canary := p.CanaryOf(current)
+	if !p.Active() || canary == p.Stable {
+		return canary
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(canary + "/" + key))
+	if int(h.Sum32()%100) < p.Weight {
+		return canary
+	}
+
+	return p.Stable
+}
+
+// Map returns the policy as pushed to tns. Every field is set, so a push
+// replaces the previous policy.
+func (p *TrafficPolicy) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"stable": p.Stable,
+		"canary": p.Canary,
+		"weight": p.Weight,
+	}
+}
+
+// TrafficKey returns the key of a request, from its header or cookie, or an
+// empty string if it has none.
+func TrafficKey(r *http.Request) string {
+	if key := r.Header.Get(TrafficHeader); key != "" {
+		return key
+	}
+
+	if cookie, err := r.Cookie(TrafficCookie); err == nil {
+		return cookie.Value
+	}
+
+	return ""
+}
+
+// NewTrafficKey returns a random key for a client that has none yet.
+func NewTrafficKey() string {
+	key := make([]byte, 16)
+	rand.Read(key)
+	return hex.EncodeToString(key)
+}
+
+// TrafficKeyCookie returns the cookie keeping key on the client.
+func TrafficKeyCookie(key string) *http.Cookie {
+	return &http.Cookie{
+		Name:     TrafficCookie,
+		Value:    key,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -0,0 +1,87 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestTrafficPick(t *testing.T) {
+	policy := &TrafficPolicy{Stable: "c1", Weight: 10}
+
+	canaried := 0
+	for i := range 10000 {
+		key := fmt.Sprintf("key-%d", i)
+		commit := policy.Pick(key, "c2")
+		if commit != policy.Pick(key, "c2") {
+			t.Fatalf("key %q is not sticky", key)
+		}
+
+		if commit == "c2" {
+			canaried++
+		} else if commit != "c1" {
+			t.Fatalf("unexpected commit %q", commit)
+		}
+	}
+
+	if canaried < 800 || canaried > 1200 {
+		t.Fatalf("%d keys of 10000 went to the canary, want about 1000", canaried)
+	}
+
+	// Raising the weight keeps the keys already on the canary.
+	raised := &TrafficPolicy{Stable: "c1", Weight: 50}
+	for i := range 1000 {
+		key := fmt.Sprintf("key-%d", i)
+		if policy.Pick(key, "c2") == "c2" && raised.Pick(key, "c2") != "c2" {
+			t.Fatalf("key %q left the canary", key)
+		}
+	}
+
+	if commit := (&TrafficPolicy{Stable: "c1", Canary: "c3", Weight: 100}).Pick("key", "c2"); commit != "c3" {
+		t.Fatalf("full weight picked %q, want the canary", commit)
+	}
+
+	if commit := (&TrafficPolicy{Weight: 100}).Pick("key", "c2"); commit != "c2" {
+		t.Fatalf("inactive policy picked %q, want the current commit", commit)
+	}
+}
+
+func TestTrafficPolicyDecode(t *testing.T) {
+	policy, err := DecodeTrafficPolicy(map[string]interface{}{"stable": "c1", "canary": "", "weight": uint64(5)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !policy.Active() || policy.Stable != "c1" || policy.Weight != 5 {
+		t.Fatalf("unexpected policy %+v", policy)
+	}
+
+	if policy, err = DecodeTrafficPolicy(nil); err != nil || policy.Active() {
+		t.Fatalf("missing policy should be inactive, got %+v %v", policy, err)
+	}
+
+	if err = (&TrafficPolicy{Stable: "c1", Weight: 101}).Validate(); err == nil {
+		t.Fatal("weight above 100 should not validate")
+	}
+}
+
+func TestTrafficKey(t *testing.T) {
+	r, _ := http.NewRequest("GET", "http://example.com", nil)
+	if key := TrafficKey(r); key != "" {
+		t.Fatalf("unexpected key %q", key)
+	}
+
+	r.AddCookie(TrafficKeyCookie("from-cookie"))
+	if key := TrafficKey(r); key != "from-cookie" {
+		t.Fatalf("unexpected key %q", key)
+	}
+
+	r.Header.Set(TrafficHeader, "from-header")
+	if key := TrafficKey(r); key != "from-header" {
+		t.Fatalf("unexpected key %q", key)
+	}
+
+	if NewTrafficKey() == NewTrafficKey() {
+		t.Fatal("new keys should differ")
+	}
+}
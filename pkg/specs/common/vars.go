@@ -11,6 +11,8 @@ const (
 	BranchPathVariable        PathVariable = "branches"
 	CommitPathVariable        PathVariable = "commit"
 	CurrentCommitPathVariable PathVariable = "current"
+	DeploymentsPathVariable   PathVariable = "deployments"
+	TrafficPathVariable       PathVariable = "traffic"
 )
 
 // TODO remove this and iterate, default branch should be gathered from a given repository
@@ -0,0 +1,37 @@
+package domainSpec
+
+import "strings"
+
+// ChallengeLabel starts the names answering ACME DNS-01 challenges.
+const ChallengeLabel = "_acme-challenge"
+
+// ChallengeName is the name holding the DNS-01 challenges of domain, a
+// wildcard domain sharing the one of its base domain.
+func ChallengeName(domain string) string {
+	return ChallengeLabel + "." + strings.TrimPrefix(strings.TrimSuffix(strings.ToLower(domain), "."), "*.")
+}
+
+// DelegatedChallengeName is the name under zone, a zone seer serves, that a
+// domain hosted elsewhere points its challenge name to with a CNAME.
+func DelegatedChallengeName(domain, zone string) string {
+	return ChallengeName(domain) + "." + strings.ToLower(zone)
+}
+
+// ChallengeNames returns the challenge names name answers for: itself, and the
+// one delegating to it when name is under zone. It returns nothing if name
+// isn't a challenge name.
+func ChallengeNames(name, zone string) []string {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	if !strings.HasPrefix(name, ChallengeLabel+".") {
+		return nil
+	}
+
+	names := []string{name}
+	if suffix := "." + strings.ToLower(zone); len(zone) > 0 && strings.HasSuffix(name, suffix) {
+		if delegating := strings.TrimSuffix(name, suffix); strings.Contains(strings.TrimPrefix(delegating, ChallengeLabel+"."), ".") {
+			names = append(names, delegating)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,25 @@
+package domainSpec
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestChallengeName(t *testing.T) {
+	assert.Equal(t, ChallengeName("*.g.tau.link"), "_acme-challenge.g.tau.link")
+	assert.Equal(t, ChallengeName("Shop.Example.com."), "_acme-challenge.shop.example.com")
+	assert.Equal(t, DelegatedChallengeName("*.shop.com", "tau.link"), "_acme-challenge.shop.com.tau.link")
+}
+
+func TestChallengeNames(t *testing.T) {
+	assert.DeepEqual(t, ChallengeNames("_acme-challenge.shop.com.tau.link.", "tau.link"), []string{
+		"_acme-challenge.shop.com.tau.link",
+		"_acme-challenge.shop.com",
+	})
+
+	// A challenge of the zone itself delegates nothing.
+	assert.DeepEqual(t, ChallengeNames("_acme-challenge.g.tau.link", "tau.link"), []string{"_acme-challenge.g.tau.link"})
+	assert.DeepEqual(t, ChallengeNames("_acme-challenge.shop.com", ""), []string{"_acme-challenge.shop.com"})
+	assert.Assert(t, ChallengeNames("www.shop.com", "tau.link") == nil)
+}
@@ -0,0 +1,190 @@
+package domainSpec
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/taubyte/tau/pkg/specs/common"
+)
+
+// RecordsPathVariable holds the records served for a name.
+const RecordsPathVariable common.PathVariable = "records"
+
+// DefaultRecordTTL is the TTL, in seconds, of a record that sets none.
+const DefaultRecordTTL = 300
+
+// Record is a DNS record of a domain, written like a zone file line without
+// the class: `<name> [ttl] <type> <data>`, with type one of A, AAAA, CNAME,
+// MX, TXT, SRV or CAA. The name is relative to the domain, `@` being the
+// domain itself, so a domain only serves names under it.
+type Record struct {
+	Name string
+	TTL  uint32
+	Type string
+	Data string
+}
+
+// ParseRecord parses and checks a record.
+func ParseRecord(record string) (*Record, error) {
+	name, rest := cutField(record)
+	r := &Record{Name: strings.ToLower(name), TTL: DefaultRecordTTL}
+	if err := checkRecordName(r.Name); err != nil {
+		return nil, fmt.Errorf("record `%s`: %w", record, err)
+	}
+
+	field, rest := cutField(rest)
+	if ttl, err := strconv.ParseUint(field, 10, 32); err == nil {
+		r.TTL = uint32(ttl)
+		field, rest = cutField(rest)
+	}
+
+	r.Type = strings.ToUpper(field)
+	// TXT data keeps its spacing.
+	r.Data = rest
+
+	if len(r.Type) == 0 || len(r.Data) == 0 {
+		return nil, fmt.Errorf("record `%s` should be `<name> [ttl] <type> <data>`", record)
+	}
+
+	fields := strings.Fields(r.Data)
+	if err := r.checkData(fields); err != nil {
+		return nil, fmt.Errorf("record `%s`: %w", record, err)
+	}
+
+	return r, nil
+}
+
+// cutField splits the first field off s.
+func cutField(s string) (field, rest string) {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i:])
+	}
+
+	return s, ""
+}
+
+func checkRecordName(name string) error {
+	if name == "@" {
+		return nil
+	}
+
+	if strings.HasSuffix(name, ".") {
+		return errors.New("name must be relative to the domain")
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return fmt.Errorf("invalid name `%s`", name)
+		}
+
+		for _, c := range label {
+			if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' && c != '_' {
+				return fmt.Errorf("invalid name `%s`", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Record) checkData(data []string) error {
+	arity := map[string]int{"A": 1, "AAAA": 1, "CNAME": 1, "MX": 2, "SRV": 4, "CAA": 3}
+	if n, ok := arity[r.Type]; ok && len(data) != n {
+		return fmt.Errorf("%s takes %d values, got %d", r.Type, n, len(data))
+	}
+
+	switch r.Type {
+	case "A", "AAAA":
+		addr, err := netip.ParseAddr(data[0])
+		if err != nil || addr.Is4() != (r.Type == "A") {
+			return fmt.Errorf("invalid %s address `%s`", r.Type, data[0])
+		}
+	case "MX":
+		return checkUints(data[:1], 16)
+	case "SRV":
+		return checkUints(data[:3], 16)
+	case "CAA":
+		return checkUints(data[:1], 8)
+	case "CNAME", "TXT":
+	default:
+		return fmt.Errorf("unsupported type `%s`", r.Type)
+	}
+
+	return nil
+}
+
+func checkUints(values []string, bits int) error {
+	for _, v := range values {
+		if _, err := strconv.ParseUint(v, 10, bits); err != nil {
+			return fmt.Errorf("invalid number `%s`", v)
+		}
+	}
+
+	return nil
+}
+
+// Owner returns the name the record is served for.
+func (r *Record) Owner(fqdn string) string {
+	if r.Name == "@" {
+		return fqdn
+	}
+
+	return r.Name + "." + fqdn
+}
+
+// String returns the record as an absolute zone file line: names relative to
+// the domain are completed, and TXT data is quoted when it isn't.
+func (r *Record) String(fqdn string) string {
+	data := r.Data
+	switch r.Type {
+	case "CNAME":
+		data = absolute(data, fqdn)
+	case "MX", "SRV":
+		fields := strings.Fields(data)
+		fields[len(fields)-1] = absolute(fields[len(fields)-1], fqdn)
+		data = strings.Join(fields, " ")
+	case "TXT":
+		if !strings.HasPrefix(data, `"`) {
+			data = strconv.Quote(data)
+		}
+	}
+
+	return fmt.Sprintf("%s. %d IN %s %s", r.Owner(fqdn), r.TTL, r.Type, data)
+}
+
+func absolute(name, fqdn string) string {
+	switch {
+	case name == "@":
+		return fqdn + "."
+	case strings.HasSuffix(name, "."):
+		return name
+	default:
+		return name + "." + fqdn + "."
+	}
+}
+
+// RecordsPath is where the records served for a name are published, by
+// project then by the domain they belong to.
+func (t *tnsHelper) RecordsPath(name string) (*common.TnsPath, error) {
+	p, err := t.BasicPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.NewTnsPath(append(p.Slice(), RecordsPathVariable.String())), nil
+}
+
+// ProjectRecordsPath is where a project publishes the records its domain fqdn
+// serves for a name.
+func (t *tnsHelper) ProjectRecordsPath(name, project, fqdn string) (*common.TnsPath, error) {
+	p, err := t.RecordsPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.NewTnsPath(append(p.Slice(), project, fqdn)), nil
+}
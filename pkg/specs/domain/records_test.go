@@ -0,0 +1,56 @@
+package domainSpec
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseRecord(t *testing.T) {
+	for _, tc := range []struct {
+		record string
+		line   string
+	}{
+		{"@ MX 10 mail", "example.com. 300 IN MX 10 mail.example.com."},
+		{"@ 3600 mx 10 mx.provider.net.", "example.com. 3600 IN MX 10 mx.provider.net."},
+		{"www CNAME @", "www.example.com. 300 IN CNAME example.com."},
+		{"@ TXT v=spf1 include:_spf.provider.net ~all", `example.com. 300 IN TXT "v=spf1 include:_spf.provider.net ~all"`},
+		{`s1._domainkey TXT "v=DKIM1; k=rsa"`, `s1._domainkey.example.com. 300 IN TXT "v=DKIM1; k=rsa"`},
+		{"_sip._tcp SRV 10 5 5060 sip", "_sip._tcp.example.com. 300 IN SRV 10 5 5060 sip.example.com."},
+		{"api AAAA 2001:db8::1", "api.example.com. 300 IN AAAA 2001:db8::1"},
+		{"@ CAA 0 issue letsencrypt.org", "example.com. 300 IN CAA 0 issue letsencrypt.org"},
+	} {
+		r, err := ParseRecord(tc.record)
+		assert.NilError(t, err, tc.record)
+		assert.Equal(t, r.String("example.com"), tc.line)
+	}
+}
+
+func TestParseRecordErrors(t *testing.T) {
+	for _, record := range []string{
+		"",
+		"@ MX",
+		"@ 300",
+		"@ MX mail",
+		"@ MX 10 mail extra",
+		"@ A 2001:db8::1",
+		"@ AAAA 10.0.0.1",
+		"@ NS ns1",
+		"example.org. A 10.0.0.1",
+		"bad..name A 10.0.0.1",
+		"_sip._tcp SRV 10 5 sip",
+	} {
+		_, err := ParseRecord(record)
+		assert.Assert(t, err != nil, record)
+	}
+}
+
+func TestRecordsPath(t *testing.T) {
+	p, err := Tns().RecordsPath("www.example.com")
+	assert.NilError(t, err)
+	assert.Equal(t, p.String(), "domains/com/example/www/records")
+
+	p, err = Tns().ProjectRecordsPath("www.example.com", "project", "example.com")
+	assert.NilError(t, err)
+	assert.Equal(t, p.String(), "domains/com/example/www/records/project/example.com")
+}
@@ -11,11 +11,13 @@ package hoarder
 //	/hoarder/claims/<hash>/<peerID>       cbor{Since}
 //	/hoarder/stash/meta/<cid>             cbor{Target,OwnerHash?}
 //	/hoarder/stash/claims/<cid>/<peerID>  cbor{Since}
+//	/hoarder/stash/owners/<cid>/<owner>   cbor{Since}
 const (
 	MetaPrefix        = "/hoarder/meta/"
 	ClaimsPrefix      = "/hoarder/claims/"
 	StashMetaPrefix   = "/hoarder/stash/meta/"
 	StashClaimsPrefix = "/hoarder/stash/claims/"
+	StashOwnersPrefix = "/hoarder/stash/owners/"
 )
 
 // MetaKey is the durable placement record for a resource instance.
@@ -47,3 +49,13 @@ func StashClaimsPathOf(cid string) string {
 func StashClaimKey(cid, peerID string) string {
 	return StashClaimsPathOf(cid) + peerID
 }
+
+// StashOwnersPathOf is the prefix holding every owner referencing a stashed CID.
+func StashOwnersPathOf(cid string) string {
+	return StashOwnersPrefix + cid + "/"
+}
+
+// StashOwnerKey names one storage instance's reference to a stashed CID.
+func StashOwnerKey(cid, owner string) string {
+	return StashOwnersPathOf(cid) + owner
+}
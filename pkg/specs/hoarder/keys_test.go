@@ -8,12 +8,14 @@ func TestKeyHelpers(t *testing.T) {
 	const cid = "bafyCid"
 
 	cases := map[string]string{
-		MetaKey(hash):           "/hoarder/meta/QmHash",
-		ClaimsPathOf(hash):      "/hoarder/claims/QmHash/",
-		ClaimKey(hash, pid):     "/hoarder/claims/QmHash/12D3KooWpeer",
-		StashMetaKey(cid):       "/hoarder/stash/meta/bafyCid",
-		StashClaimsPathOf(cid):  "/hoarder/stash/claims/bafyCid/",
-		StashClaimKey(cid, pid): "/hoarder/stash/claims/bafyCid/12D3KooWpeer",
+		MetaKey(hash):            "/hoarder/meta/QmHash",
+		ClaimsPathOf(hash):       "/hoarder/claims/QmHash/",
+		ClaimKey(hash, pid):      "/hoarder/claims/QmHash/12D3KooWpeer",
+		StashMetaKey(cid):        "/hoarder/stash/meta/bafyCid",
+		StashClaimsPathOf(cid):   "/hoarder/stash/claims/bafyCid/",
+		StashClaimKey(cid, pid):  "/hoarder/stash/claims/bafyCid/12D3KooWpeer",
+		StashOwnersPathOf(cid):   "/hoarder/stash/owners/bafyCid/",
+		StashOwnerKey(cid, hash): "/hoarder/stash/owners/bafyCid/QmHash",
 	}
 	for got, want := range cases {
 		if got != want {
@@ -41,8 +41,15 @@ const (
 	// ActionStashStatus reports the live stash claim count per CID — lets a byte
 	// holder confirm a CID is replicated before dropping its local copy.
 	ActionStashStatus = "stashStatus"
+	// ActionRelease drops an owner's reference to a stashed CID; once no owner
+	// references it, the placement and every claim are removed and holders drop
+	// the bytes. The response carries BodyReleased=true when that happened.
+	ActionRelease = "release"
 )
 
+// BodyReleased is set true on a release response when the CID was unstashed.
+const BodyReleased = "released"
+
 // KVDBCommand is the remote data-plane route. Body carries {kind, project,
 // application, match, branch, kvop, key/value/prefix/ops/limit/cursor} and the
 // handler operates on the loaded instance kvdb (first-touch if unplaced).
@@ -86,11 +93,20 @@ const (
 	// writes on the serving replica. The response carries BodyExisted=true when
 	// the key was already present and nothing was written.
 	KVPutNx = "putnx"
+	// KVCas writes the key only if its current value hashes (SHA-256) to
+	// BodyExpected, or if it is absent when BodyExpected is empty. The response
+	// carries BodySwapped=true when the value was written.
+	KVCas = "cas"
 )
 
 // BodyExisted is set true on a putnx response when the key already existed.
 const BodyExisted = "existed"
 
+const (
+	BodyExpected = "expected"
+	BodySwapped  = "swapped"
+)
+
 // Typed result codes carried in the response's BodyCode field for control-flow
 // signals the client routes on (never as free text). A response with no BodyCode
 // is a success.
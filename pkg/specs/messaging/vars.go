@@ -3,3 +3,12 @@ package messagingSpec
 import "github.com/taubyte/tau/pkg/specs/common"
 
 const PathVariable common.PathVariable = "messaging"
+
+// LogPrefix namespaces the match a persistent channel's log is hoarded under,
+// so it never shares an instance with a database or storage of the same name.
+const LogPrefix = "log:"
+
+// LogMatch is the match a persistent channel's log is hoarded under.
+func LogMatch(channel string) string {
+	return LogPrefix + channel
+}
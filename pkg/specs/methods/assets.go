@@ -23,3 +23,28 @@ func GetTNSAssetPath(projectId, resourceId, branch string) (*common.TnsPath, err
 	hashValue := multihash.Hash(projectId + resourceId + branch)
 	return common.NewTnsPath([]string{"assets", hashValue}), nil
 }
+
+// GetTNSProjectBuildCachePath returns where the build cache of a project is
+// recorded, keyed by build cache key.
+func GetTNSProjectBuildCachePath(projectId string) (*common.TnsPath, error) {
+	if len(projectId) < 1 {
+		return nil, errors.New("project Id is empty")
+	}
+
+	return common.NewTnsPath([]string{"builds", "cache", projectId}), nil
+}
+
+// GetTNSBuildCachePath returns where the asset CID built from a given build
+// cache key is recorded for a project.
+func GetTNSBuildCachePath(projectId, key string) (*common.TnsPath, error) {
+	cachePath, err := GetTNSProjectBuildCachePath(projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(key) < 1 {
+		return nil, errors.New("build cache key is empty")
+	}
+
+	return common.NewTnsPath(append(cachePath.Slice(), key)), nil
+}
@@ -0,0 +1,26 @@
+package storageSpec
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/base64"
+	"fmt"
+)
+
+var accessKeyEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewCredentials returns a random S3 key pair. Auth stores every pair it
+// issues under its project, so each one can be revoked on its own.
+func NewCredentials() (accessKey, secretKey string, err error) {
+	id := make([]byte, 10)
+	if _, err = rand.Read(id); err != nil {
+		return "", "", fmt.Errorf("generating access key failed with: %w", err)
+	}
+
+	secret := make([]byte, 30)
+	if _, err = rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("generating secret key failed with: %w", err)
+	}
+
+	return AccessKeyPrefix + accessKeyEncoding.EncodeToString(id), base64.RawURLEncoding.EncodeToString(secret), nil
+}
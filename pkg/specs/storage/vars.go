@@ -4,3 +4,6 @@ import "github.com/taubyte/tau/pkg/specs/common"
 
 const PathVariable common.PathVariable = "storages"
 const FilePath common.PathVariable = "file"
+
+// AccessKeyPrefix starts every S3 access key issued by auth.
+const AccessKeyPrefix = "TAU"
@@ -20,6 +20,11 @@ type Domain struct {
 	CertFile    string `mapstructure:"cert-file"`
 	KeyFile     string `mapstructure:"key-file"`
 	CertType    string `mapstructure:"cert-type"`
+	Records     []string
+	RateLimit   int    `mapstructure:"rateLimit"`
+	RateBurst   int    `mapstructure:"rateBurst"`
+	RateKey     string `mapstructure:"rateKey"`
+	Concurrency int
 	SmartOps    []string
 
 	Indexer
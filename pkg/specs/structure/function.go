@@ -24,9 +24,18 @@ type Function struct {
 	Method      string
 	Domains     []string
 	Paths       []string
+	Cron        string
+	Timezone    string
+	Resource    string
+	Prefix      string
+	Events      []string
 	Source      string
 	Timeout     uint64
 	Memory      uint64
+	RateLimit   int    `mapstructure:"rateLimit"`
+	RateBurst   int    `mapstructure:"rateBurst"`
+	RateKey     string `mapstructure:"rateKey"`
+	Concurrency int
 	Call        string
 	Secure      bool
 	SmartOps    []string
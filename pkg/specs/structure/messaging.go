@@ -12,16 +12,21 @@ import (
 )
 
 type Messaging struct {
-	Id          string
-	Name        string
-	Description string
-	Tags        []string
-	Local       bool
-	Match       string
-	Regex       bool
-	MQTT        bool
-	WebSocket   bool `mapstructure:"webSocket"`
-	SmartOps    []string
+	Id            string
+	Name          string
+	Description   string
+	Tags          []string
+	Local         bool
+	Match         string
+	Regex         bool
+	MQTT          bool
+	WebSocket     bool `mapstructure:"webSocket"`
+	Persistent    bool
+	Retention     uint64
+	RetentionSize uint64 `mapstructure:"retentionSize"`
+	Retries       int
+	DeadLetter    string `mapstructure:"deadLetter"`
+	SmartOps      []string
 
 	Basic
 	Wasm
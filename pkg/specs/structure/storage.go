@@ -12,18 +12,20 @@ import (
 )
 
 type Storage struct {
-	Id          string
-	Name        string
-	Description string
-	Tags        []string
-	Type        string
-	Match       string
-	Regex       bool `mapstructure:"useRegex"`
-	Public      bool
-	Versioning  bool
-	Ttl         uint64
-	Size        uint64
-	SmartOps    []string
+	Id             string
+	Name           string
+	Description    string
+	Tags           []string
+	Type           string
+	Match          string
+	Regex          bool `mapstructure:"useRegex"`
+	Public         bool
+	Versioning     bool
+	KeepVersions   int
+	ExpireVersions uint64
+	Ttl            uint64
+	Size           uint64
+	SmartOps       []string
 
 	Basic
 	Indexer
@@ -19,6 +19,7 @@ type Website struct {
 	Domains     []string
 	Paths       []string
 	Branch      string
+	SPA         *bool
 	Provider    string
 	RepoID      string `mapstructure:"repository-id"`
 	RepoName    string `mapstructure:"repository-name"`
@@ -315,6 +315,7 @@ func (d *sporedrive) writeConfig(h remoteHost, shape string, w io.Writer) error
 			Main: int(sc.Ports().Get("main")),
 			Lite: int(sc.Ports().Get("lite")),
 			Ipfs: int(sc.Ports().Get("ipfs")),
+			Mqtt: int(sc.Ports().Get("mqtt")),
 		},
 		Location: &seer.Location{
 			Latitude:  lat,
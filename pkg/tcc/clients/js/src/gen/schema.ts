@@ -7,7 +7,7 @@ import type { AsyncFs } from "../fs.js";
 
 export type DatabaseNetwork = "all" | "subnet" | "host";
 export type DomainCertType = "inline" | "auto";
-export type FunctionType = "http" | "https" | "pubsub" | "p2p";
+export type FunctionType = "http" | "https" | "pubsub" | "p2p" | "schedule" | "storage" | "database";
 export type FunctionMethod = "GET" | "HEAD" | "POST" | "PUT" | "DELETE" | "CONNECT" | "OPTIONS" | "TRACE" | "PATCH";
 export type StorageNetwork = "all" | "subnet" | "host";
 
@@ -304,6 +304,56 @@ export class DomainConfig {
   unsetCertType(): Promise<void> {
     return this.s.binding.delete(this.s.handle, this.res, ["certificate", "type"]);
   }
+
+  async records(): Promise<string[] | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["records"])) as string[] | undefined;
+  }
+  setRecords(v: string[]): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["records"], v);
+  }
+  unsetRecords(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["records"]);
+  }
+
+  async rateLimit(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["limits", "rate"])) as number | undefined;
+  }
+  setRateLimit(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["limits", "rate"], v);
+  }
+  unsetRateLimit(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["limits", "rate"]);
+  }
+
+  async rateBurst(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["limits", "burst"])) as number | undefined;
+  }
+  setRateBurst(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["limits", "burst"], v);
+  }
+  unsetRateBurst(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["limits", "burst"]);
+  }
+
+  async rateKey(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["limits", "key"])) as string | undefined;
+  }
+  setRateKey(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["limits", "key"], v);
+  }
+  unsetRateKey(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["limits", "key"]);
+  }
+
+  async concurrency(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["limits", "concurrency"])) as number | undefined;
+  }
+  setConcurrency(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["limits", "concurrency"], v);
+  }
+  unsetConcurrency(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["limits", "concurrency"]);
+  }
 }
 
 /** Typed accessors for a function's config. */
@@ -448,6 +498,56 @@ export class FunctionConfig {
     return this.s.binding.delete(this.s.handle, this.res, ["trigger", "paths"]);
   }
 
+  async cron(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["trigger", "cron"])) as string | undefined;
+  }
+  setCron(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["trigger", "cron"], v);
+  }
+  unsetCron(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["trigger", "cron"]);
+  }
+
+  async timezone(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["trigger", "timezone"])) as string | undefined;
+  }
+  setTimezone(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["trigger", "timezone"], v);
+  }
+  unsetTimezone(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["trigger", "timezone"]);
+  }
+
+  async resource(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["trigger", "resource"])) as string | undefined;
+  }
+  setResource(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["trigger", "resource"], v);
+  }
+  unsetResource(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["trigger", "resource"]);
+  }
+
+  async prefix(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["trigger", "prefix"])) as string | undefined;
+  }
+  setPrefix(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["trigger", "prefix"], v);
+  }
+  unsetPrefix(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["trigger", "prefix"]);
+  }
+
+  async events(): Promise<string[] | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["trigger", "events"])) as string[] | undefined;
+  }
+  setEvents(v: string[]): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["trigger", "events"], v);
+  }
+  unsetEvents(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["trigger", "events"]);
+  }
+
   async source(): Promise<string | undefined> {
     return (await this.s.binding.get(this.s.handle, this.res, ["source"])) as string | undefined;
   }
@@ -478,6 +578,46 @@ export class FunctionConfig {
     return this.s.binding.delete(this.s.handle, this.res, ["execution", "memory"]);
   }
 
+  async rateLimit(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["execution", "rate-limit", "rate"])) as number | undefined;
+  }
+  setRateLimit(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["execution", "rate-limit", "rate"], v);
+  }
+  unsetRateLimit(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["execution", "rate-limit", "rate"]);
+  }
+
+  async rateBurst(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["execution", "rate-limit", "burst"])) as number | undefined;
+  }
+  setRateBurst(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["execution", "rate-limit", "burst"], v);
+  }
+  unsetRateBurst(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["execution", "rate-limit", "burst"]);
+  }
+
+  async rateKey(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["execution", "rate-limit", "key"])) as string | undefined;
+  }
+  setRateKey(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["execution", "rate-limit", "key"], v);
+  }
+  unsetRateKey(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["execution", "rate-limit", "key"]);
+  }
+
+  async concurrency(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["execution", "concurrency"])) as number | undefined;
+  }
+  setConcurrency(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["execution", "concurrency"], v);
+  }
+  unsetConcurrency(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["execution", "concurrency"]);
+  }
+
   async call(): Promise<string | undefined> {
     return (await this.s.binding.get(this.s.handle, this.res, ["execution", "call"])) as string | undefined;
   }
@@ -699,6 +839,56 @@ export class MessagingConfig {
   unsetWebSocket(): Promise<void> {
     return this.s.binding.delete(this.s.handle, this.res, ["bridges", "websocket", "enable"]);
   }
+
+  async persistent(): Promise<boolean | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["persistence", "enable"])) as boolean | undefined;
+  }
+  setPersistent(v: boolean): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["persistence", "enable"], v);
+  }
+  unsetPersistent(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["persistence", "enable"]);
+  }
+
+  async retention(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["persistence", "retention"])) as string | undefined;
+  }
+  setRetention(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["persistence", "retention"], v);
+  }
+  unsetRetention(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["persistence", "retention"]);
+  }
+
+  async retentionSize(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["persistence", "size"])) as string | undefined;
+  }
+  setRetentionSize(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["persistence", "size"], v);
+  }
+  unsetRetentionSize(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["persistence", "size"]);
+  }
+
+  async retries(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["persistence", "retries"])) as number | undefined;
+  }
+  setRetries(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["persistence", "retries"], v);
+  }
+  unsetRetries(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["persistence", "retries"]);
+  }
+
+  async deadLetter(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["persistence", "dead-letter"])) as string | undefined;
+  }
+  setDeadLetter(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["persistence", "dead-letter"], v);
+  }
+  unsetDeadLetter(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["persistence", "dead-letter"]);
+  }
 }
 
 /** Typed accessors for a service's config. */
@@ -974,6 +1164,26 @@ export class StorageConfig {
     return this.s.binding.delete(this.s.handle, this.res, ["object", "versioning"]);
   }
 
+  async keepVersions(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["object", "versions", "keep"])) as number | undefined;
+  }
+  setKeepVersions(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["object", "versions", "keep"], v);
+  }
+  unsetKeepVersions(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["object", "versions", "keep"]);
+  }
+
+  async expireVersions(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["object", "versions", "expire"])) as string | undefined;
+  }
+  setExpireVersions(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["object", "versions", "expire"], v);
+  }
+  unsetExpireVersions(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["object", "versions", "expire"]);
+  }
+
   async ttl(): Promise<string | undefined> {
     return (await this.s.binding.get(this.s.handle, this.res, ["streaming", "ttl"])) as string | undefined;
   }
@@ -1076,6 +1286,16 @@ export class WebsiteConfig {
     return this.s.binding.delete(this.s.handle, this.res, ["source", "branch"]);
   }
 
+  async spa(): Promise<boolean | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["spa"])) as boolean | undefined;
+  }
+  setSpa(v: boolean): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["spa"], v);
+  }
+  unsetSpa(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["spa"]);
+  }
+
   async repoID(): Promise<string | undefined> {
     return (await this.s.binding.get(this.s.handle, this.res, ["source", "github", "id"])) as string | undefined;
   }
@@ -1123,6 +1343,11 @@ export interface Domain {
   "cert-file"?: string;
   "key-file"?: string;
   "cert-type"?: DomainCertType;
+  records?: string[];
+  rateLimit?: number;
+  rateBurst?: number;
+  rateKey?: string;
+  concurrency?: number;
   smartops?: string[];
 }
 
@@ -1140,9 +1365,18 @@ export interface Function {
   method?: FunctionMethod;
   domains?: string[];
   paths?: string[];
+  cron?: string;
+  timezone?: string;
+  resource?: string;
+  prefix?: string;
+  events?: string[];
   source?: string;
   timeout?: number;
   memory?: number;
+  rateLimit?: number;
+  rateBurst?: number;
+  rateKey?: string;
+  concurrency?: number;
   call?: string;
   secure?: boolean;
   smartops?: string[];
@@ -1173,6 +1407,11 @@ export interface Messaging {
   regex?: boolean;
   mqtt?: boolean;
   webSocket?: boolean;
+  persistent?: boolean;
+  retention?: number;
+  retentionSize?: number;
+  retries?: number;
+  deadLetter?: string;
   smartops?: string[];
 }
 
@@ -1210,6 +1449,8 @@ export interface Storage {
   useRegex?: boolean;
   public?: boolean;
   versioning?: boolean;
+  keepversions?: number;
+  expireversions?: number;
   ttl?: number;
   size?: number;
   smartops?: string[];
@@ -1224,6 +1465,7 @@ export interface Website {
   domains?: string[];
   paths?: string[];
   branch?: string;
+  spa?: boolean;
   provider?: string;
   "repository-id"?: string;
   "repository-name"?: string;
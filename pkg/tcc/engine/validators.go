@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/ipfs/go-cid"
+	"github.com/taubyte/tau/pkg/cron"
+	domainSpec "github.com/taubyte/tau/pkg/specs/domain"
 )
 
 // NextValidation represents a validation that needs to be performed externally.
@@ -175,6 +177,46 @@ func IsHttpMethod() Option {
 	}
 }
 
+// IsCron validates a five-field cron expression or @descriptor.
+func IsCron() Option {
+	return func(a *Attribute) {
+		Validator(func(s string) error {
+			_, err := cron.Parse(s)
+			return err
+		})(a)
+		Annotate("format", "cron")(a)
+	}
+}
+
+// EachInSet validates that every element of a string slice attribute is one
+// of elms.
+func EachInSet(elms ...string) Option {
+	return func(a *Attribute) {
+		Validator(func(values []string) error {
+			for _, v := range values {
+				if !slices.Contains(elms, v) {
+					return fmt.Errorf("invalid value %q", v)
+				}
+			}
+			return nil
+		})(a)
+	}
+}
+
+// IsDnsRecords validates each record of a domain's records list.
+func IsDnsRecords() Option {
+	return func(a *Attribute) {
+		Validator(func(records []string) error {
+			for _, record := range records {
+				if _, err := domainSpec.ParseRecord(record); err != nil {
+					return err
+				}
+			}
+			return nil
+		})(a)
+	}
+}
+
 // MinInt returns an Option that validates an Int attribute is >= min.
 func MinInt(min int) Option {
 	return func(a *Attribute) {
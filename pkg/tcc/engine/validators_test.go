@@ -161,6 +161,34 @@ func TestIsHttpMethod(t *testing.T) {
 	}
 }
 
+func TestIsCron(t *testing.T) {
+	attr := &Attribute{}
+	option := IsCron()
+	option(attr)
+
+	tests := []struct {
+		val     string
+		isValid bool
+	}{
+		{"*/5 * * * *", true},
+		{"0 3 * * mon-fri", true},
+		{"@daily", true},
+		{"", false},
+		{"* * *", false},
+		{"61 * * * *", false},
+	}
+
+	for _, test := range tests {
+		err := attr.Validator(test.val)
+		if test.isValid && err != nil {
+			t.Errorf("Expected cron '%s' to be valid, but got error: %v", test.val, err)
+		}
+		if !test.isValid && err == nil {
+			t.Errorf("Expected cron '%s' to be invalid, but got no error", test.val)
+		}
+	}
+}
+
 func TestMinInt(t *testing.T) {
 	attr := &Attribute{}
 	option := MinInt(1)
@@ -227,3 +255,55 @@ func TestIsDomainName(t *testing.T) {
 		}
 	}
 }
+
+func TestIsDnsRecords(t *testing.T) {
+	attr := &Attribute{}
+	option := IsDnsRecords()
+	option(attr)
+
+	tests := []struct {
+		val     []string
+		isValid bool
+	}{
+		{[]string{}, true},
+		{[]string{"@ MX 10 mail", "www CNAME @"}, true},
+		{[]string{"@ MX 10 mail", "@ NS ns1"}, false},
+		{[]string{"example.org. A 10.0.0.1"}, false},
+	}
+
+	for _, test := range tests {
+		err := attr.Validator(test.val)
+		if test.isValid && err != nil {
+			t.Errorf("Expected records %v to be valid, but got error: %v", test.val, err)
+		}
+		if !test.isValid && err == nil {
+			t.Errorf("Expected records %v to be invalid, but got no error", test.val)
+		}
+	}
+}
+
+func TestEachInSet(t *testing.T) {
+	attr := &Attribute{}
+	option := EachInSet("put", "delete")
+	option(attr)
+
+	tests := []struct {
+		val     []string
+		isValid bool
+	}{
+		{[]string{}, true},
+		{[]string{"put"}, true},
+		{[]string{"delete", "put"}, true},
+		{[]string{"put", "move"}, false},
+	}
+
+	for _, test := range tests {
+		err := attr.Validator(test.val)
+		if test.isValid && err != nil {
+			t.Errorf("Expected values %v to be valid, but got error: %v", test.val, err)
+		}
+		if !test.isValid && err == nil {
+			t.Errorf("Expected values %v to be invalid, but got no error", test.val)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"regexp"
 	"testing"
+	"time"
 
 	schema "github.com/taubyte/tau/pkg/tcc/taubyte/v1/schema"
 
@@ -52,6 +53,65 @@ func TestCompile(t *testing.T) {
 	newObj := obj.Flat()["object"].(map[string]interface{})
 	oldObj := oldCompiler.Object()
 
+	// the frozen compiler predates schedule triggers: it drops cron/timezone and
+	// adds a local flag, so check that function on its own
+	scheduledId := "QmSVtxcHjmAoZhfYqpV5P6GzyG7E6wGLdAbX6hj1k3GeRk"
+	scheduled := newObj["functions"].(map[string]any)[scheduledId].(map[string]any)
+	assert.Equal(t, scheduled["type"], "schedule")
+	assert.Equal(t, scheduled["cron"], "30 2 * * *")
+	assert.Equal(t, scheduled["timezone"], "Europe/Paris")
+	delete(newObj["functions"].(map[string]any), scheduledId)
+	delete(oldObj["functions"].(map[string]any), scheduledId)
+
+	// nor storage triggers
+	triggeredId := "QmaBz1pwTgwpj8t9ta1Ag5k1Q6R53tjaEBJFS7ZfJVducX"
+	triggered := newObj["functions"].(map[string]any)[triggeredId].(map[string]any)
+	assert.Equal(t, triggered["type"], "storage")
+	assert.Equal(t, triggered["resource"], "test_storage1")
+	assert.Equal(t, triggered["prefix"], "uploads/")
+	assert.DeepEqual(t, triggered["events"], []string{"put", "new-version"})
+	delete(newObj["functions"].(map[string]any), triggeredId)
+	delete(oldObj["functions"].(map[string]any), triggeredId)
+
+	// nor does it know about domain records
+	domain := newObj["domains"].(map[string]any)["QmUcVJtgGZYkqFr2J9t2jV2fJJWZBvD7FJ6RyXzJY2kAj1"].(map[string]any)
+	assert.Equal(t, len(domain["records"].([]string)), 3)
+	delete(domain, "records")
+
+	// nor about rate limits
+	limited := newObj["functions"].(map[string]any)["QmNf1SAZuyM9vLPeWiYx9qh3AWJKCjJvF9d1f5ZPZCZxXh"].(map[string]any)
+	for key, value := range map[string]any{"rateLimit": 10, "rateBurst": 20, "rateKey": "X-Api-Key", "concurrency": 16} {
+		assert.Equal(t, limited[key], value, key)
+		delete(limited, key)
+	}
+	for key, value := range map[string]any{"rateLimit": 100, "concurrency": 64} {
+		assert.Equal(t, domain[key], value, key)
+		delete(domain, key)
+	}
+
+	// nor about persistent messaging
+	for _, channel := range newObj["messaging"].(map[string]any) {
+		channel := channel.(map[string]any)
+		for key, value := range map[string]any{"persistent": true, "retention": int64(24 * time.Hour), "retentionSize": int64(64e6), "retries": 3, "deadLetter": "test_messaging_dead"} {
+			assert.Equal(t, channel[key], value, key)
+			delete(channel, key)
+		}
+	}
+
+	// nor about single page applications, on by default
+	websites := []any{newObj["websites"]}
+	for _, app := range newObj["applications"].(map[string]any) {
+		websites = append(websites, app.(map[string]any)["websites"])
+	}
+	for _, group := range websites {
+		group, _ := group.(map[string]any)
+		for _, website := range group {
+			website := website.(map[string]any)
+			assert.Equal(t, website["spa"], true)
+			delete(website, "spa")
+		}
+	}
+
 	assert.Assert(t, cmp.Equal(newObj, oldObj), cmp.Diff(oldObj, newObj))
 
 	indexes := obj.Flat()["indexes"].(map[string]interface{})
@@ -60,6 +120,16 @@ func TestCompile(t *testing.T) {
 	// delete it to make the deep equal works
 	delete(indexes, "p2p/pubsub/QmUgRE95oaisf5cK1DNaKizPQS7mqtd3zZ68wuUEKfoWoB")
 
+	halRecords := "domains/com/computers/hal/records/QmTz6X9hTn18fpKxrnbE3BvmkZHy3r1mRyHzfXK3gVZLxR/hal.computers.com"
+	mailRecords := "domains/com/computers/hal/mail/records/QmTz6X9hTn18fpKxrnbE3BvmkZHy3r1mRyHzfXK3gVZLxR/hal.computers.com"
+	assert.DeepEqual(t, indexes[halRecords], []string{
+		"hal.computers.com. 300 IN MX 10 mail.hal.computers.com.",
+		`hal.computers.com. 3600 IN TXT "v=spf1 include:_spf.computers.com -all"`,
+	})
+	assert.DeepEqual(t, indexes[mailRecords], []string{"mail.hal.computers.com. 300 IN A 10.0.0.25"})
+	delete(indexes, halRecords)
+	delete(indexes, mailRecords)
+
 	assert.Assert(t, cmp.Equal(indexes, oldCompiler.Indexes()), cmp.Diff(oldCompiler.Indexes(), indexes))
 
 	// Verify validations are returned
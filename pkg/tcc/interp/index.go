@@ -6,6 +6,7 @@ import (
 
 	"github.com/taubyte/tau/core/common/repositorytype"
 	"github.com/taubyte/tau/pkg/specs/common"
+	domainSpec "github.com/taubyte/tau/pkg/specs/domain"
 	"github.com/taubyte/tau/pkg/specs/methods"
 	"github.com/taubyte/tau/pkg/tcc/engine"
 	"github.com/taubyte/tau/pkg/tcc/interp/utils"
@@ -87,6 +88,20 @@ func IndexPlaceholder(keyField string) engine.NodeOption {
 	return engine.GroupAnnotate("indexPlaceholder", keyField)
 }
 
+// recordsIndex is the declared footprint of an IndexRecords annotation.
+type recordsIndex struct {
+	keyField, recordsField string
+}
+
+// IndexRecords declares the DNS records a domain publishes: on a default branch,
+// the driver parses each record in recordsField against the fqdn at keyField and
+// appends its absolute zone line at the records path of the name it's served
+// for, under the project and the fqdn, so records of different projects are
+// never merged. Other branches publish none, as indexes aren't branch scoped.
+func IndexRecords(keyField, recordsField string) engine.NodeOption {
+	return engine.GroupAnnotate("indexRecords", recordsIndex{keyField, recordsField})
+}
+
 // IndexByName declares the mechanical "keyed by Name" index link most resources
 // share: the driver appends the resource's IndexValue to cap(Name).Links(), where
 // cap is one of the resource's Addressing capabilities (wasm -> WasmModulePath,
@@ -207,6 +222,38 @@ func indexPlaceholder(ic *IndexCtx, index object.Object[object.Refrence], groupK
 	return nil
 }
 
+func indexRecords(ic *IndexCtx, index object.Object[object.Refrence], ri recordsIndex) error {
+	if !common.IsDefaultBranch(ic.Branch) {
+		return nil
+	}
+
+	records, ok := ic.Obj.Get(ri.recordsField).([]string)
+	if !ok {
+		return nil
+	}
+
+	fqdn, err := ic.Obj.GetString(ri.keyField)
+	if err != nil {
+		return fmt.Errorf("domain %s is not a string: %w", ri.keyField, err)
+	}
+
+	for _, _record := range records {
+		record, err := domainSpec.ParseRecord(_record)
+		if err != nil {
+			return err
+		}
+
+		p, err := domainSpec.Tns().ProjectRecordsPath(record.Owner(fqdn), ic.Project, fqdn)
+		if err != nil {
+			return fmt.Errorf("getting records path failed with %w", err)
+		}
+
+		appendLink(index, p.String(), record.String(fqdn))
+	}
+
+	return nil
+}
+
 // indexOrder is the fixed V1 index order, taken verbatim from pass4/pipe.go. It is
 // NOT the DSL declaration order: link buckets shared across scopes accumulate in
 // this order, so it is load-bearing for parity and must not be derived from the
@@ -330,6 +377,7 @@ func (gi *groupIndexer) Process(ct transform.Context[object.Refrence], config ob
 	_, hasName := gi.iter.Meta["indexName"].(bool)
 	scopeCap, _ := gi.iter.Meta["indexByScope"].(engine.Capability)
 	placeholderField, hasPlaceholder := gi.iter.Meta["indexPlaceholder"].(string)
+	records, hasRecords := gi.iter.Meta["indexRecords"].(recordsIndex)
 
 	lookup := makeLookup(config, configRoot)
 
@@ -393,6 +441,12 @@ func (gi *groupIndexer) Process(ct transform.Context[object.Refrence], config ob
 			}
 		}
 
+		if hasRecords {
+			if err := indexRecords(ic, index, records); err != nil {
+				return nil, fmt.Errorf("index records for %s %s failed with %w", gi.groupKey, id, err)
+			}
+		}
+
 		if err := emitAttrValidations(ct, instObj, gi.iter.Attributes, projectId, appId); err != nil {
 			return nil, err
 		}
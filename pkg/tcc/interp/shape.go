@@ -68,6 +68,7 @@ var indexMetaKeys = []string{
 	"indexName",
 	"indexByScope",
 	"indexPlaceholder",
+	"indexRecords",
 }
 
 // UsesIndexing reports whether any group iterator declares an index footprint. It
@@ -72,20 +72,28 @@ var TaubyteRessources = []*Node{
 				String("certificate-data", Path("certificate", "cert"), Field("CertFile"), Tag("cert-file"), InSection("tls"), ShowWhen("certificate-type", "inline"), Doc("Certificate", "PEM-encoded TLS certificate for the domain (inline certificate-type).")),
 				String("certificate-key", Path("certificate", "key"), Field("KeyFile"), Tag("key-file"), InSection("tls"), ShowWhen("certificate-type", "inline"), Doc("Certificate Key", "PEM-encoded private key for the certificate (inline certificate-type).")),
 				String("certificate-type", Path("certificate", "type"), InSet("inline", "auto"), Default(""), Field("CertType"), Tag("cert-type"), InSection("tls"), Doc("Certificate Type", "How the TLS certificate is provisioned: inline (supplied here) or auto (managed).")),
+				StringSlice("records", IsDnsRecords(), InSection("dns"), Doc("Records", "DNS records served for the domain, as `<name> [ttl] <type> <data>` with name relative to the domain (`@` for the domain itself). Types: A, AAAA, CNAME, MX, TXT, SRV, CAA.")),
+				Int("rate-limit", Path("limits", "rate"), Field("RateLimit"), Tag("rateLimit"), Accessor("RateLimit"), InSection("limits"), Doc("Rate Limit", "Requests per second each client may make to the domain; unlimited when 0.")),
+				Int("rate-burst", Path("limits", "burst"), Field("RateBurst"), Tag("rateBurst"), Accessor("RateBurst"), InSection("limits"), Doc("Burst", "Requests a client may make at once before the rate limit applies; the rate when 0.")),
+				String("rate-key", Path("limits", "key"), Field("RateKey"), Tag("rateKey"), Accessor("RateKey"), InSection("limits"), Doc("Client Header", "Request header telling clients apart for the rate limit; the client IP when empty.")),
+				Int("max-concurrency", Path("limits", "concurrency"), Field("Concurrency"), Tag("concurrency"), InSection("limits"), Doc("Max Concurrency", "Requests to the domain a node serves at once, the extra ones being rejected; unlimited when 0.")),
 			),
 			GroupDoc("A DNS domain and its TLS configuration, referenced by functions and websites."),
 			secIdentity,
 			Section("tls", "TLS", "Certificate configuration."),
+			Section("dns", "DNS", "Records served by the domain's name servers."),
+			Section("limits", "Limits", "Request rate and concurrency limits."),
 			// domain's BasicPath is bespoke (fqdn-reversed), so it's not tagged here.
 			Addressing(HasIndex),
 			Embeds("Indexer"),
 			Resource("domains", "Domain", "Domain", "domain"),
 			interp.IndexPlaceholder("fqdn"),
+			interp.IndexRecords("fqdn", "records"),
 		)),
 	DefineGroup("functions",
 		DefineIter(
 			TaubyteAttributes(
-				String("type", Path("trigger", "type"), InSet("http", "https", "pubsub", "p2p"), DerivedBool("Secure", map[string]bool{"http": false, "https": true}, map[bool]string{false: "http", true: "https"}), InSection("trigger"), Doc("Trigger Type", "Trigger that invokes the function: http, https, pubsub, or p2p.")),
+				String("type", Path("trigger", "type"), InSet("http", "https", "pubsub", "p2p", "schedule", "storage", "database"), DerivedBool("Secure", map[string]bool{"http": false, "https": true}, map[bool]string{false: "http", true: "https"}), InSection("trigger"), Doc("Trigger Type", "Trigger that invokes the function: http, https, pubsub, p2p, schedule, storage, or database.")),
 				Bool("local", Path("trigger", "local"), InSection("trigger"), Doc("Local", "Restrict the trigger to the local node / project scope.")),
 				String("pubsub-channel", Path("trigger", "channel"), Tag("channel"), InSection("pubsub"), Doc("PubSub Channel", "PubSub channel the function subscribes to (pubsub trigger).")),
 				String("p2p-protocol", Path("trigger", "protocol"), Compat("trigger", "service"), Tag("service"), OnlyWhen("type", "p2p"), Default(""), InSection("p2p"), Doc("P2P Protocol", "libp2p protocol the function serves (p2p trigger).")),
@@ -94,17 +102,28 @@ var TaubyteRessources = []*Node{
 				StringSlice("http-methods", Path("trigger", "methods"), Tag("methods"), NoAccessors(), NoStructField()), // TO IMPLEMENT
 				StringSlice("http-domains", Path("trigger", "domains"), Compat("domains"), Tag("domains"), Ref("domains"), InSection("http"), Doc("Domains", "Domains that route to this function. Each must name a defined domain.")),
 				StringSlice("http-paths", Path("trigger", "paths"), Tag("paths"), InSection("http"), Doc("Paths", "URL path patterns that route to this function (http/https trigger).")),
+				String("schedule-cron", Path("trigger", "cron"), IsCron(), Tag("cron"), InSection("schedule"), Doc("Cron", "Cron expression the function runs on, five fields or a descriptor like @daily (schedule trigger).")),
+				String("schedule-timezone", Path("trigger", "timezone"), Tag("timezone"), InSection("schedule"), Doc("Timezone", "IANA timezone the cron expression is read in, e.g. \"Europe/Paris\"; UTC when empty (schedule trigger).")),
+				String("event-resource", Path("trigger", "resource"), Tag("resource"), InSection("events"), Doc("Resource", "Name of the storage or database whose changes invoke the function (storage/database trigger).")),
+				String("event-prefix", Path("trigger", "prefix"), Tag("prefix"), InSection("events"), Doc("Prefix", "Only changes of file paths or keys starting with this prefix invoke the function; all when empty.")),
+				StringSlice("event-kinds", Path("trigger", "events"), EachInSet("put", "delete", "new-version"), Tag("events"), InSection("events"), Doc("Events", "Kinds of change that invoke the function: put, delete, new-version; all when empty.")),
 				String("source", Ref("libraries", Prefix("libraries/")), sourceShape, InSection("code"), Doc("Source", "Code source: \".\" for inline code, or \"libraries/<name>\" to build from a defined library.")),
 				Duration("timeout", Path("execution", "timeout"), InSection("limits"), Doc("Timeout", "Maximum execution time, as a human string (e.g. \"30s\").")),
 				Bytes("memory", Path("execution", "memory"), InSection("limits"), Doc("Memory", "Maximum memory the function may use, as a human string (e.g. \"32MB\").")),
+				Int("rate-limit", Path("execution", "rate-limit", "rate"), Field("RateLimit"), Tag("rateLimit"), Accessor("RateLimit"), InSection("limits"), Doc("Rate Limit", "Requests per second each client may make to the function; unlimited when 0 (http/https trigger).")),
+				Int("rate-burst", Path("execution", "rate-limit", "burst"), Field("RateBurst"), Tag("rateBurst"), Accessor("RateBurst"), InSection("limits"), Doc("Burst", "Requests a client may make at once before the rate limit applies; the rate when 0.")),
+				String("rate-key", Path("execution", "rate-limit", "key"), Field("RateKey"), Tag("rateKey"), Accessor("RateKey"), InSection("limits"), Doc("Client Header", "Request header telling clients apart for the rate limit; the client IP when empty.")),
+				Int("max-concurrency", Path("execution", "concurrency"), Field("Concurrency"), Tag("concurrency"), InSection("limits"), Doc("Max Concurrency", "Calls of the function a node runs at once, the extra ones being rejected; unlimited when 0 (http/https trigger).")),
 				String("call", Path("execution", "call"), InSection("code"), Doc("Entrypoint", "Exported entrypoint symbol invoked in the WASM module.")),
 			),
-			GroupDoc("A serverless function triggered over HTTP(S), PubSub, or p2p."),
+			GroupDoc("A serverless function triggered over HTTP(S), PubSub, p2p, on a schedule, or by storage and database changes."),
 			secIdentity,
 			Section("trigger", "Trigger", "How the function is invoked."),
 			SectionWhen("http", "HTTP", "HTTP(S) routing.", "type", "http", "https"),
 			SectionWhen("pubsub", "PubSub", "PubSub subscription.", "type", "pubsub"),
 			SectionWhen("p2p", "P2P", "libp2p protocol handling.", "type", "p2p"),
+			SectionWhen("schedule", "Schedule", "When the function runs.", "type", "schedule"),
+			SectionWhen("events", "Events", "Storage or database changes the function runs on.", "type", "storage", "database"),
 			Section("code", "Code", "The function's code source and entrypoint."),
 			Section("limits", "Limits", "Runtime resource limits."),
 			Addressing(HasBasicPath, HasIndex, HasHttp, HasWasmModule, HasServices),
@@ -140,11 +159,17 @@ var TaubyteRessources = []*Node{
 				Bool("regex", Path("channel", "regex"), NoSetter(), InSection("channel"), Doc("Regex", "Treat match as a regular expression.")),
 				Bool("mqtt", Path("bridges", "mqtt", "enable"), Accessor("MQTT"), NoSetter(), InSection("bridges"), Doc("MQTT", "Expose this channel over the MQTT bridge.")),
 				Bool("websocket", Path("bridges", "websocket", "enable"), Tag("webSocket"), Accessor("WebSocket"), NoSetter(), InSection("bridges"), Doc("WebSocket", "Expose this channel over the WebSocket bridge.")),
+				Bool("persistent", Path("persistence", "enable"), Accessor("Persistent"), NoSetter(), InSection("persistence"), Doc("Persistent", "Append published messages to a durable log subscribers replay from their offset.")),
+				Duration("retention", Path("persistence", "retention"), Field("Retention"), Accessor("Retention"), NoSetter(), InSection("persistence"), Doc("Retention", "How long logged messages are kept, as a human string (e.g. \"24h\"); forever when empty.")),
+				Bytes("retention-size", Path("persistence", "size"), Field("RetentionSize"), Tag("retentionSize"), Accessor("RetentionSize"), NoSetter(), InSection("persistence"), Doc("Retention Size", "How much message data the log keeps before dropping the oldest, as a human string (e.g. \"64MB\"); unbounded when empty.")),
+				Int("retries", Path("persistence", "retries"), Field("Retries"), Accessor("Retries"), NoSetter(), InSection("persistence"), Doc("Retries", "How many times delivering a message to a function is retried before it is dead-lettered.")),
+				String("dead-letter", Path("persistence", "dead-letter"), Field("DeadLetter"), Tag("deadLetter"), Accessor("DeadLetter"), NoSetter(), InSection("persistence"), Doc("Dead Letter", "Channel messages are published to once their delivery retries are exhausted; dropped when empty.")),
 			),
 			GroupDoc("A PubSub messaging channel, optionally bridged to MQTT/WebSocket."),
 			secIdentity,
 			Section("channel", "Channel", "Channel matching."),
 			Section("bridges", "Bridges", "External protocol bridges (MQTT / WebSocket)."),
+			Section("persistence", "Persistence", "Durable message log, retention, and delivery retries."),
 			Addressing(HasBasicPath, HasIndex, HasWebSocket, HasEmptyPath),
 			// messaging embeds Wasm beyond its capability flags — load-bearing in
 			// the dream inject path (services/tns/mocks casts to structureSpec.Wasm).
@@ -191,6 +216,8 @@ var TaubyteRessources = []*Node{
 				Bool("useRegex", Path("regex"), Compat("useRegex"), InSection("storage"), Doc("Use Regex", "Treat match as a regular expression instead of a literal prefix.")),
 				String("network-access", Path("access", "network"), InSet("all", "subnet", "host"), Default("all"), EnumBool("Public", []string{"all"}, []string{"all", "subnet", "host"}, [2]string{"all", "subnet"}), NoAccessors(), InSection("access"), Doc("Network Access", "Which peers may reach this storage: all, subnet (project peers only), or host (local node only).")),
 				Bool("versioning", Path("object", "versioning"), NoSetter(), InSection("storage"), Doc("Versioning", "Keep historical versions of objects (object storage).")),
+				Int("keep-versions", Path("object", "versions", "keep"), Field("KeepVersions"), Accessor("KeepVersions"), NoSetter(), InSection("storage"), Doc("Keep Versions", "How many of the latest versions of an object are kept, all of them when 0 (object storage with versioning).")),
+				Duration("expire-versions", Path("object", "versions", "expire"), Field("ExpireVersions"), Accessor("ExpireVersions"), NoSetter(), InSection("storage"), Doc("Expire Versions", "How long a version is kept once a newer one replaces it, as a human string (e.g. \"720h\"); forever when empty (object storage with versioning).")),
 				Duration("ttl", Path("streaming", "ttl"), Field("Ttl"), Accessor("TTL"), NoSetter(), InSection("storage"), Doc("Time-To-Live", "Time-to-live for streamed entries, as a human string (e.g. \"1h\") (streaming storage).")),
 				Bytes("size", Path(Either("object", "streaming"), "size"), InSection("storage"), Doc("Size", "Maximum storage size, as a human string (e.g. \"1GB\").")),
 			),
@@ -209,6 +236,7 @@ var TaubyteRessources = []*Node{
 				StringSlice("domains", Path("domains"), Ref("domains"), InSection("serving"), Doc("Domains", "Domains that serve this website. Each must name a defined domain.")),
 				StringSlice("paths", Path("paths"), Compat("source", "paths"), InSection("serving"), Doc("Paths", "URL path patterns served by this website.")), // TODO: add validation
 				String("branch", Path("source", "branch"), InSection("source"), Doc("Branch", "Git branch to build the website from.")),                         // TODO: deprecate
+				Bool("spa", Default(true), Accessor("SPA"), InSection("serving"), Doc("Single Page Application", "Serve the index for paths with no file instead of the build's 404.html.")),
 				String("git-provider", Path("source", Either("github")), Key(), Field("Provider"), Tag("provider"), InSection("source"), Doc("Provider", "Source-control provider hosting the repository (the key selects the provider block).")),
 				String("github-id", Path("source", "github", "id"), Field("RepoID"), Tag("repository-id"), NoAccessors(), InSection("source"), Doc("Repository ID", "GitHub repository numeric id.")),
 				String("github-fullname", Path("source", "github", "fullname"), Field("RepoName"), Tag("repository-name"), NoAccessors(), InSection("source"), Doc("Repository", "GitHub repository full name (owner/repo).")),
@@ -31,9 +31,13 @@ var (
 	Embeds           = engine.Embeds
 	EmitValidation   = engine.EmitValidation
 	EnumBool         = engine.EnumBool
+	EachInSet        = engine.EachInSet
 	Field            = engine.Field
 	GroupDoc         = engine.GroupDoc
+	Int              = engine.Int
 	IsCID            = engine.IsCID
+	IsCron           = engine.IsCron
+	IsDnsRecords     = engine.IsDnsRecords
 	IsEmail          = engine.IsEmail
 	IsFqdn           = engine.IsFqdn
 	IsHttpMethod     = engine.IsHttpMethod
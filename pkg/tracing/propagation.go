@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Inject writes the W3C trace context of ctx to carrier.
+func Inject(ctx context.Context, carrier map[string]string) {
+	propagator.Inject(ctx, propagation.MapCarrier(carrier))
+}
+
+// Extract returns ctx with the remote span of the W3C trace context in
+// carrier, if any.
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return propagator.Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// InjectHeader sets the traceparent and tracestate headers of ctx.
+func InjectHeader(ctx context.Context, header http.Header) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// ExtractHeader returns ctx with the remote span of the traceparent header,
+// if any.
+func ExtractHeader(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// WithSpan returns ctx with the span of from, so work bound to ctx is traced
+// as part of from without being cancelled with it.
+func WithSpan(ctx, from context.Context) context.Context {
+	return trace.ContextWithSpan(ctx, trace.SpanFromContext(from))
+}
@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Setup exports the spans of the node over OTLP/HTTP to the collector at
+// endpoint, a http(s) URL that defaults to the /v1/traces path. Until it is
+// called spans are not recorded, but trace contexts still go through. The
+// returned function flushes and stops the exporter.
+func Setup(ctx context.Context, endpoint string, attrs ...attribute.KeyValue) (func(context.Context) error, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parsing tracing endpoint `%s` failed with: %w", endpoint, err)
+	}
+
+	if u.Path == "" {
+		u.Path = DefaultPath
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(u.String()))
+	if err != nil {
+		return nil, fmt.Errorf("creating otlp exporter failed with: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(append([]attribute.KeyValue{ServiceName}, attrs...)...)),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span named name, child of the one in ctx if any.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, opts...)
+}
+
+// Child starts a span named name only when ctx is part of a trace, so calls
+// made outside of one don't each start their own.
+func Child(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	return tracer.Start(ctx, name, opts...)
+}
+
+// End records err, if any, on span then ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gotest.tools/v3/assert"
+)
+
+func record(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return exporter
+}
+
+func TestPropagation(t *testing.T) {
+	exporter := record(t)
+
+	ctx, span := Start(context.Background(), "gateway")
+
+	carrier := make(map[string]string)
+	Inject(ctx, carrier)
+	assert.Assert(t, carrier["traceparent"] != "")
+
+	header := make(http.Header)
+	InjectHeader(ctx, header)
+	assert.Equal(t, header.Get("traceparent"), carrier["traceparent"])
+
+	for _, remote := range []context.Context{
+		Extract(context.Background(), carrier),
+		ExtractHeader(context.Background(), header),
+	} {
+		_, child := Child(remote, "substrate")
+		End(child, errors.New("failed"))
+	}
+	End(span, nil)
+
+	spans := exporter.GetSpans()
+	assert.Equal(t, len(spans), 3)
+	for _, child := range spans[:2] {
+		assert.Equal(t, child.Parent.SpanID(), span.SpanContext().SpanID())
+		assert.Equal(t, child.SpanContext.TraceID(), span.SpanContext().TraceID())
+		assert.Equal(t, child.Status.Code, codes.Error)
+	}
+}
+
+func TestChild(t *testing.T) {
+	exporter := record(t)
+
+	ctx, span := Child(context.Background(), "orphan")
+	assert.Assert(t, !span.SpanContext().IsValid())
+	assert.Assert(t, !trace.SpanContextFromContext(ctx).IsValid())
+	End(span, nil)
+
+	carrier := make(map[string]string)
+	Inject(ctx, carrier)
+	assert.Equal(t, len(carrier), 0)
+	assert.Equal(t, len(exporter.GetSpans()), 0)
+}
+
+func TestWithSpan(t *testing.T) {
+	record(t)
+
+	from, span := Start(context.Background(), "request")
+	defer span.End()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	traced := WithSpan(ctx, from)
+	assert.Assert(t, trace.SpanContextFromContext(traced).Equal(span.SpanContext()))
+
+	cancel()
+	assert.Assert(t, traced.Err() != nil)
+	assert.NilError(t, from.Err())
+}
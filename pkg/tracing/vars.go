@@ -0,0 +1,18 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+var (
+	// DefaultPath is where spans are posted when the endpoint has no path.
+	DefaultPath = "/v1/traces"
+
+	// ServiceName is the service.name of the spans tau exports.
+	ServiceName = attribute.String("service.name", "tau")
+
+	tracer     = otel.Tracer("github.com/taubyte/tau")
+	propagator = propagation.TraceContext{}
+)
@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+
+	"github.com/taubyte/go-sdk/errno"
+	common "github.com/taubyte/tau/core/vm"
+)
+
+// databaseBatch deletes then puts the given keys, all applied together.
+func (f *Factory) databaseBatch(ctx context.Context, module common.Module,
+	databaseId,
+	putKeysPtr, putKeysLen,
+	putValuesPtr, putValuesLen,
+	deleteKeysPtr, deleteKeysLen uint32,
+) uint32 {
+
+	database, err := f.getDatabase(databaseId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	putKeys, err := f.ReadStringSlice(module, putKeysPtr, putKeysLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	putValues, err := f.ReadBytesSlice(module, putValuesPtr, putValuesLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	if len(putKeys) != len(putValues) {
+		return uint32(errno.ErrorSizeMismatch)
+	}
+
+	deleteKeys, err := f.ReadStringSlice(module, deleteKeysPtr, deleteKeysLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	batch, err0 := database.KV().Batch(ctx)
+	if err0 != nil {
+		return uint32(errno.ErrorDatabasePutFailed)
+	}
+
+	for _, key := range deleteKeys {
+		if batch.Delete(key) != nil {
+			return uint32(errno.ErrorDatabaseDeleteFailed)
+		}
+	}
+
+	for i, key := range putKeys {
+		if batch.Put(key, putValues[i]) != nil {
+			return uint32(errno.ErrorDatabasePutFailed)
+		}
+	}
+
+	if batch.Commit() != nil {
+		return uint32(errno.ErrorDatabasePutFailed)
+	}
+
+	return 0
+}
+
+func (f *Factory) databasePutNx(ctx context.Context, module common.Module,
+	databaseId,
+	keyPtr, keyLen,
+	bufPtr, bufSize,
+	existedPtr uint32,
+) uint32 {
+
+	database, err := f.getDatabase(databaseId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	key, err := f.ReadString(module, keyPtr, keyLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	data, err := f.ReadBytes(module, bufPtr, bufSize)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	existed, err0 := database.KV().PutNx(ctx, key, data)
+	if err0 != nil {
+		return uint32(errno.ErrorDatabasePutFailed)
+	}
+
+	return uint32(f.WriteBool(module, existedPtr, existed))
+}
+
+// databaseCompareAndSwap puts the value only if the SHA-256 of the current one
+// is the given hash, or if the key is absent when the hash is empty.
+func (f *Factory) databaseCompareAndSwap(ctx context.Context, module common.Module,
+	databaseId,
+	keyPtr, keyLen,
+	hashPtr, hashLen,
+	bufPtr, bufSize,
+	swappedPtr uint32,
+) uint32 {
+
+	database, err := f.getDatabase(databaseId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	key, err := f.ReadString(module, keyPtr, keyLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	hash, err := f.ReadBytes(module, hashPtr, hashLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	data, err := f.ReadBytes(module, bufPtr, bufSize)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	swapped, err0 := database.KV().CompareAndSwap(ctx, key, hash, data)
+	if err0 != nil {
+		return uint32(errno.ErrorDatabasePutFailed)
+	}
+
+	return uint32(f.WriteBool(module, swappedPtr, swapped))
+}
+
+func (f *Factory) databaseIncrement(ctx context.Context, module common.Module,
+	databaseId,
+	keyPtr, keyLen uint32,
+	delta int64,
+	valuePtr uint32,
+) uint32 {
+
+	database, err := f.getDatabase(databaseId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	key, err := f.ReadString(module, keyPtr, keyLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	value, err0 := database.KV().Increment(ctx, key, delta)
+	if err0 != nil {
+		return uint32(errno.ErrorDatabasePutFailed)
+	}
+
+	return uint32(f.WriteUint64Le(module, valuePtr, uint64(value)))
+}
+
+func (f *Factory) scan(ctx context.Context, module common.Module,
+	databaseId,
+	prefixPtr, prefixLen,
+	afterPtr, afterLen,
+	limit uint32,
+) ([]string, errno.Error) {
+
+	database, err := f.getDatabase(databaseId)
+	if err != 0 {
+		return nil, err
+	}
+
+	prefix, err := f.ReadString(module, prefixPtr, prefixLen)
+	if err != 0 {
+		return nil, err
+	}
+
+	after, err := f.ReadString(module, afterPtr, afterLen)
+	if err != 0 {
+		return nil, err
+	}
+
+	keys, err0 := database.KV().Scan(ctx, prefix, after, int(limit))
+	if err0 != nil {
+		return nil, errno.ErrorDatabaseListFailed
+	}
+
+	return keys, 0
+}
+
+// databaseScan lists, in order, at most limit keys with the prefix after the
+// given one; the last key listed is the cursor of the next page.
+func (f *Factory) databaseScan(ctx context.Context, module common.Module,
+	databaseId,
+	prefixPtr, prefixLen,
+	afterPtr, afterLen,
+	limit,
+	dataPtr uint32,
+) uint32 {
+
+	keys, err := f.scan(ctx, module, databaseId, prefixPtr, prefixLen, afterPtr, afterLen, limit)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteStringSlice(module, dataPtr, keys))
+}
+
+func (f *Factory) databaseScanSize(ctx context.Context, module common.Module,
+	databaseId,
+	prefixPtr, prefixLen,
+	afterPtr, afterLen,
+	limit,
+	sizePtr uint32,
+) uint32 {
+
+	keys, err := f.scan(ctx, module, databaseId, prefixPtr, prefixLen, afterPtr, afterLen, limit)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteStringSliceSize(module, sizePtr, keys))
+}
@@ -13,4 +13,10 @@ func (f *Factory) RegisterHostFunctions(b wazy.HostModuleBuilder) {
 	wazy.HostFunc4(b.NewFunctionBuilder(), f.databaseList).Export("databaseList")
 	wazy.HostFunc4(b.NewFunctionBuilder(), f.databaseListSize).Export("databaseListSize")
 	wazy.HostFunc3(b.NewFunctionBuilder(), f.newDatabase).Export("newDatabase")
+	wazy.HostFunc7(b.NewFunctionBuilder(), f.databaseBatch).Export("databaseBatch")
+	wazy.HostFunc6(b.NewFunctionBuilder(), f.databasePutNx).Export("databasePutNx")
+	wazy.HostFunc8(b.NewFunctionBuilder(), f.databaseCompareAndSwap).Export("databaseCompareAndSwap")
+	wazy.HostFunc5(b.NewFunctionBuilder(), f.databaseIncrement).Export("databaseIncrement")
+	wazy.HostFunc7(b.NewFunctionBuilder(), f.databaseScan).Export("databaseScan")
+	wazy.HostFunc7(b.NewFunctionBuilder(), f.databaseScanSize).Export("databaseScanSize")
 }
@@ -0,0 +1,120 @@
+package event
+
+import (
+	"context"
+
+	"github.com/taubyte/go-sdk/common"
+	"github.com/taubyte/go-sdk/errno"
+	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	"github.com/taubyte/tau/core/services/substrate/events"
+	vmCommon "github.com/taubyte/tau/core/vm"
+)
+
+// CreateChangeEvent creates the event of a storage or database change. Guests
+// see a pubsub event carrying msg, and read the change through the
+// getChangeEvent host functions.
+func (f *Factory) CreateChangeEvent(msg pubsubIface.Message, change *events.Event) *Event {
+	e := &Event{
+		Id:     f.generateEventId(),
+		Type:   common.EventTypePubsub,
+		pubsub: msg,
+		change: change,
+	}
+
+	f.eventsLock.Lock()
+	defer f.eventsLock.Unlock()
+	f.events[e.Id] = e
+	return e
+}
+
+func (f *Factory) getChange(eventId uint32) (*events.Event, errno.Error) {
+	e, err := f.getEvent(eventId)
+	if err != 0 {
+		return nil, err
+	}
+
+	if e.change == nil {
+		return nil, errno.ErrorNilAddress
+	}
+
+	return e.change, 0
+}
+
+func (f *Factory) changeStringSize(module vmCommon.Module, eventId, sizePtr uint32, field func(*events.Event) string) uint32 {
+	change, err := f.getChange(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteStringSize(module, sizePtr, field(change)))
+}
+
+func (f *Factory) changeString(module vmCommon.Module, eventId, bufPtr uint32, field func(*events.Event) string) uint32 {
+	change, err := f.getChange(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteString(module, bufPtr, field(change)))
+}
+
+func (f *Factory) changeUint64(module vmCommon.Module, eventId, ptr uint32, field func(*events.Event) uint64) uint32 {
+	change, err := f.getChange(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteUint64Le(module, ptr, field(change)))
+}
+
+func changeResource(change *events.Event) string { return string(change.Resource) }
+func changeName(change *events.Event) string     { return change.Name }
+func changeKind(change *events.Event) string     { return string(change.Kind) }
+func changeKey(change *events.Event) string      { return change.Key }
+
+func (f *Factory) getChangeEventResourceSize(ctx context.Context, module vmCommon.Module, eventId, sizePtr uint32) uint32 {
+	return f.changeStringSize(module, eventId, sizePtr, changeResource)
+}
+
+func (f *Factory) getChangeEventResource(ctx context.Context, module vmCommon.Module, eventId, bufPtr uint32) uint32 {
+	return f.changeString(module, eventId, bufPtr, changeResource)
+}
+
+func (f *Factory) getChangeEventNameSize(ctx context.Context, module vmCommon.Module, eventId, sizePtr uint32) uint32 {
+	return f.changeStringSize(module, eventId, sizePtr, changeName)
+}
+
+func (f *Factory) getChangeEventName(ctx context.Context, module vmCommon.Module, eventId, bufPtr uint32) uint32 {
+	return f.changeString(module, eventId, bufPtr, changeName)
+}
+
+func (f *Factory) getChangeEventKindSize(ctx context.Context, module vmCommon.Module, eventId, sizePtr uint32) uint32 {
+	return f.changeStringSize(module, eventId, sizePtr, changeKind)
+}
+
+func (f *Factory) getChangeEventKind(ctx context.Context, module vmCommon.Module, eventId, bufPtr uint32) uint32 {
+	return f.changeString(module, eventId, bufPtr, changeKind)
+}
+
+func (f *Factory) getChangeEventKeySize(ctx context.Context, module vmCommon.Module, eventId, sizePtr uint32) uint32 {
+	return f.changeStringSize(module, eventId, sizePtr, changeKey)
+}
+
+func (f *Factory) getChangeEventKey(ctx context.Context, module vmCommon.Module, eventId, bufPtr uint32) uint32 {
+	return f.changeString(module, eventId, bufPtr, changeKey)
+}
+
+// getChangeEventVersion writes the file version, 0 for database keys.
+func (f *Factory) getChangeEventVersion(ctx context.Context, module vmCommon.Module, eventId, versionPtr uint32) uint32 {
+	return f.changeUint64(module, eventId, versionPtr, func(change *events.Event) uint64 { return uint64(change.Version) })
+}
+
+// getChangeEventValueSize writes the size of the value put, 0 for deletions.
+func (f *Factory) getChangeEventValueSize(ctx context.Context, module vmCommon.Module, eventId, sizePtr uint32) uint32 {
+	return f.changeUint64(module, eventId, sizePtr, func(change *events.Event) uint64 { return uint64(change.Size) })
+}
+
+// getChangeEventTime writes when the change happened, in unix nanoseconds.
+func (f *Factory) getChangeEventTime(ctx context.Context, module vmCommon.Module, eventId, timePtr uint32) uint32 {
+	return f.changeUint64(module, eventId, timePtr, func(change *events.Event) uint64 { return uint64(change.Time.UnixNano()) })
+}
@@ -42,4 +42,15 @@ func (f *Factory) RegisterHostFunctions(b wazy.HostModuleBuilder) {
 	wazy.HostFunc2(b.NewFunctionBuilder(), f.getHttpEventRequestQueryKeysSize).Export("getHttpEventRequestQueryKeysSize")
 	wazy.HostFunc2(b.NewFunctionBuilder(), f.getHttpEventRequestQueryKeys).Export("getHttpEventRequestQueryKeys")
 	wazy.HostFunc4(b.NewFunctionBuilder(), f.eventHttpRedirect).Export("eventHttpRedirect")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getChangeEventResourceSize).Export("getChangeEventResourceSize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getChangeEventResource).Export("getChangeEventResource")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getChangeEventNameSize).Export("getChangeEventNameSize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getChangeEventName).Export("getChangeEventName")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getChangeEventKindSize).Export("getChangeEventKindSize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getChangeEventKind).Export("getChangeEventKind")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getChangeEventKeySize).Export("getChangeEventKeySize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getChangeEventKey).Export("getChangeEventKey")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getChangeEventVersion).Export("getChangeEventVersion")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getChangeEventValueSize).Export("getChangeEventValueSize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getChangeEventTime).Export("getChangeEventTime")
 }
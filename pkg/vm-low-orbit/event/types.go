@@ -7,6 +7,7 @@ import (
 
 	"github.com/taubyte/go-sdk/common"
 	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	"github.com/taubyte/tau/core/services/substrate/events"
 	"github.com/taubyte/tau/core/vm"
 	"github.com/taubyte/tau/pkg/vm-low-orbit/helpers"
 )
@@ -28,6 +29,7 @@ type Event struct {
 	http   *httpEventAttributes
 	pubsub pubsubIface.Message
 	p2p    *P2PData
+	change *events.Event
 }
 
 type httpEventAttributes struct {
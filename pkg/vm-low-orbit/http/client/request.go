@@ -7,6 +7,9 @@ import (
 
 	"github.com/taubyte/go-sdk/errno"
 	common "github.com/taubyte/tau/core/vm"
+	"github.com/taubyte/tau/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func (client *Client) getRequest(reqId uint32) (*Request, errno.Error) {
@@ -91,8 +94,19 @@ func (f *Factory) doHttpRequest(ctx context.Context, module common.Module,
 		return uint32(err)
 	}
 
-	var _err error
+	// The request is a span of the call of the function, its context sent
+	// along so the server can continue the trace.
+	ctx, span := tracing.Child(ctx, "wasm.http.client",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.method", req.Method),
+			attribute.String("http.host", req.URL.Host),
+		),
+	)
+	tracing.InjectHeader(ctx, req.Header)
+
 	resp, _err := client.Do(req.Request)
+	tracing.End(span, _err)
 	if _err != nil {
 		return uint32(errno.ErrorHttpRequestFailed)
 	}
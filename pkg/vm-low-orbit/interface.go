@@ -10,6 +10,7 @@ import (
 	res "github.com/taubyte/tau/p2p/streams/command/response"
 
 	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	"github.com/taubyte/tau/core/services/substrate/events"
 	"github.com/taubyte/tau/core/vm"
 	"github.com/taubyte/tau/pkg/vm-low-orbit/event"
 )
@@ -24,6 +25,7 @@ type eventApi interface {
 	CreateHttpEvent(w http.ResponseWriter, r *http.Request) *event.Event
 	CreatePubsubEvent(msg pubsubIface.Message) *event.Event
 	CreateP2PEvent(cmd *command.Command, response res.Response) *event.Event
+	CreateChangeEvent(msg pubsubIface.Message, change *events.Event) *event.Event
 }
 
 var With = func(pi vm.PluginInstance) (Instance, error) {
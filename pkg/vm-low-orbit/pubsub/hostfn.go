@@ -9,4 +9,8 @@ func (f *Factory) RegisterHostFunctions(b wazy.HostModuleBuilder) {
 	wazy.HostFunc2(b.NewFunctionBuilder(), f.setSubscriptionChannel).Export("setSubscriptionChannel")
 	wazy.HostFunc3(b.NewFunctionBuilder(), f.getWebSocketURLSize).Export("getWebSocketURLSize")
 	wazy.HostFunc3(b.NewFunctionBuilder(), f.getWebSocketURL).Export("getWebSocketURL")
+	wazy.HostFunc5(b.NewFunctionBuilder(), f.readChannelSize).Export("readChannelSize")
+	wazy.HostFunc6(b.NewFunctionBuilder(), f.readChannel).Export("readChannel")
+	wazy.HostFunc5(b.NewFunctionBuilder(), f.getChannelOffset).Export("getChannelOffset")
+	wazy.HostFunc5(b.NewFunctionBuilder(), f.commitChannelOffset).Export("commitChannelOffset")
 }
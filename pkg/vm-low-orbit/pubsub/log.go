@@ -0,0 +1,137 @@
+package pubsub
+
+import (
+	"context"
+
+	"github.com/taubyte/go-sdk/errno"
+	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	common "github.com/taubyte/tau/core/vm"
+)
+
+func (f *Factory) readLog(ctx context.Context, module common.Module,
+	channelPtr, channelLen uint32,
+	offset uint64,
+	limit uint32,
+) ([]*pubsubIface.Entry, errno.Error) {
+	channel, err := f.ReadString(module, channelPtr, channelLen)
+	if err != 0 {
+		return nil, err
+	}
+
+	_ctx := f.parent.Context()
+	entries, err0 := f.pubsubNode.Read(ctx, _ctx.Project(), _ctx.Application(), channel, offset, int(limit))
+	if err0 != nil {
+		return nil, errno.ErrorChannelNotFound
+	}
+
+	return entries, 0
+}
+
+func entriesData(entries []*pubsubIface.Entry) [][]byte {
+	data := make([][]byte, len(entries))
+	for i, entry := range entries {
+		data[i] = entry.Data
+	}
+
+	return data
+}
+
+func (f *Factory) readChannelSize(ctx context.Context, module common.Module,
+	channelPtr, channelLen uint32,
+	offset uint64,
+	limit,
+	sizePtr uint32,
+) uint32 {
+	entries, err := f.readLog(ctx, module, channelPtr, channelLen, offset, limit)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteBytesSliceSize(module, sizePtr, entriesData(entries)))
+}
+
+// readChannel writes, oldest first, at most limit messages logged on a
+// persistent channel from the given offset on, and the offset to read next.
+func (f *Factory) readChannel(ctx context.Context, module common.Module,
+	channelPtr, channelLen uint32,
+	offset uint64,
+	limit,
+	dataPtr,
+	nextPtr uint32,
+) uint32 {
+	entries, err := f.readLog(ctx, module, channelPtr, channelLen, offset, limit)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	if len(entries) > 0 {
+		offset = entries[len(entries)-1].Offset + 1
+	}
+
+	if err = f.WriteBytesSlice(module, dataPtr, entriesData(entries)); err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteUint64Le(module, nextPtr, offset))
+}
+
+// subscriber reads the subscriber name, the calling function when empty.
+func (f *Factory) subscriber(module common.Module, subscriberPtr, subscriberLen uint32) (string, errno.Error) {
+	subscriber, err := f.ReadString(module, subscriberPtr, subscriberLen)
+	if err != 0 {
+		return "", err
+	}
+
+	if subscriber == "" {
+		subscriber = f.parent.Context().Resource()
+	}
+
+	return subscriber, 0
+}
+
+func (f *Factory) getChannelOffset(ctx context.Context, module common.Module,
+	channelPtr, channelLen,
+	subscriberPtr, subscriberLen,
+	offsetPtr uint32,
+) uint32 {
+	channel, err := f.ReadString(module, channelPtr, channelLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	subscriber, err := f.subscriber(module, subscriberPtr, subscriberLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	_ctx := f.parent.Context()
+	offset, err0 := f.pubsubNode.Offset(ctx, _ctx.Project(), _ctx.Application(), channel, subscriber)
+	if err0 != nil {
+		return uint32(errno.ErrorChannelNotFound)
+	}
+
+	return uint32(f.WriteUint64Le(module, offsetPtr, offset))
+}
+
+func (f *Factory) commitChannelOffset(ctx context.Context, module common.Module,
+	channelPtr, channelLen,
+	subscriberPtr, subscriberLen uint32,
+	offset uint64,
+) uint32 {
+	channel, err := f.ReadString(module, channelPtr, channelLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	subscriber, err := f.subscriber(module, subscriberPtr, subscriberLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	_ctx := f.parent.Context()
+	if err0 := f.pubsubNode.Commit(ctx, _ctx.Project(), _ctx.Application(), channel, subscriber, offset); err0 != nil {
+		return uint32(errno.ErrorChannelNotFound)
+	}
+
+	return 0
+}
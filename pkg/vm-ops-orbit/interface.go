@@ -15,6 +15,7 @@ type Instance interface {
 
 type resourceApi interface {
 	CreateSmartOp(caller smartops.EventCaller) *common.Resource
+	ReleaseSmartOp(resourceId uint32)
 }
 
 var With = func(pi vm.PluginInstance) (Instance, error) {
@@ -16,3 +16,11 @@ func (f *Factory) CreateSmartOp(caller smartops.EventCaller) *common.Resource {
 	f.resources[r.Id] = r
 	return r
 }
+
+// ReleaseSmartOp forgets the resource created by CreateSmartOp once the call
+// it was created for is done.
+func (f *Factory) ReleaseSmartOp(resourceId uint32) {
+	f.resourceLock.Lock()
+	defer f.resourceLock.Unlock()
+	delete(f.resources, resourceId)
+}
@@ -0,0 +1,28 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/taubyte/go-sdk/errno"
+	"github.com/taubyte/tau/pkg/vm-ops-orbit/common"
+	"gotest.tools/v3/assert"
+)
+
+func TestReleaseSmartOp(t *testing.T) {
+	f := &Factory{resources: make(map[uint32]*common.Resource)}
+
+	first := f.CreateSmartOp(nil)
+	second := f.CreateSmartOp(nil)
+	assert.Assert(t, first.Id != second.Id)
+
+	f.ReleaseSmartOp(first.Id)
+	_, err := f.GetResource(first.Id)
+	assert.Equal(t, err, errno.SmartOpErrorResourceNotFound)
+
+	resource, err := f.GetResource(second.Id)
+	assert.Equal(t, err, errno.Error(0))
+	assert.Equal(t, resource, second)
+
+	f.ReleaseSmartOp(second.Id)
+	assert.Equal(t, len(f.resources), 0)
+}
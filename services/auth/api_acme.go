@@ -226,6 +226,29 @@ func (srv *AuthService) acmeServiceHandler(ctx context.Context, st streams.Conne
 			return nil, err
 		}
 		return nil, nil
+	case "challenge-set", "challenge-delete":
+		fqdn, err := maps.String(body, "fqdn")
+		if err != nil {
+			return nil, err
+		}
+		value, err := maps.String(body, "value")
+		if err != nil {
+			return nil, err
+		}
+		if action == "challenge-set" {
+			return nil, srv.setACMEChallenge(ctx, fqdn, value)
+		}
+		return nil, srv.deleteACMEChallenge(ctx, fqdn, value)
+	case "challenge-get":
+		fqdn, err := maps.String(body, "fqdn")
+		if err != nil {
+			return nil, err
+		}
+		values, err := srv.getACMEChallenges(ctx, fqdn)
+		if err != nil {
+			return nil, err
+		}
+		return cr.Response{"values": values}, nil
 	default:
 		return nil, errors.New("Acme action `" + action + "` not recognized")
 	}
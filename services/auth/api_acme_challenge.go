@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChallengeTTL is how long a DNS-01 challenge value is served once set.
+var ChallengeTTL = 10 * time.Minute
+
+// Challenge keys: /acme/challenge/<base64 fqdn>/<base64 value> = unix nano expiry
+func challengePrefix(fqdn string) string {
+	return "/acme/challenge/" + base64.StdEncoding.EncodeToString([]byte(strings.ToLower(fqdn))) + "/"
+}
+
+func (srv *AuthService) setACMEChallenge(ctx context.Context, fqdn, value string) error {
+	logger.Debugf("Set acme challenge for `%s`", fqdn)
+
+	key := challengePrefix(fqdn) + base64.StdEncoding.EncodeToString([]byte(value))
+	expiry := strconv.FormatInt(time.Now().Add(ChallengeTTL).UnixNano(), 10)
+	if err := srv.db.Put(ctx, key, []byte(expiry)); err != nil {
+		return fmt.Errorf("setting challenge of `%s` failed with: %w", fqdn, err)
+	}
+
+	return nil
+}
+
+// getACMEChallenges returns the challenge values of fqdn still valid, dropping
+// the expired ones.
+func (srv *AuthService) getACMEChallenges(ctx context.Context, fqdn string) ([]string, error) {
+	prefix := challengePrefix(fqdn)
+	keys, err := srv.db.List(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing challenges of `%s` failed with: %w", fqdn, err)
+	}
+
+	now := time.Now()
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		data, err := srv.db.Get(ctx, key)
+		if err != nil {
+			continue
+		}
+
+		if nano, err := strconv.ParseInt(string(data), 10, 64); err != nil || now.After(time.Unix(0, nano)) {
+			srv.db.Delete(ctx, key)
+			continue
+		}
+
+		value, err := base64.StdEncoding.DecodeString(key[strings.LastIndex(key, "/")+1:])
+		if err != nil {
+			continue
+		}
+
+		values = append(values, string(value))
+	}
+
+	return values, nil
+}
+
+func (srv *AuthService) deleteACMEChallenge(ctx context.Context, fqdn, value string) error {
+	logger.Debugf("Del acme challenge for `%s`", fqdn)
+
+	if err := srv.db.Delete(ctx, challengePrefix(fqdn)+base64.StdEncoding.EncodeToString([]byte(value))); err != nil {
+		return fmt.Errorf("deleting challenge of `%s` failed with: %w", fqdn, err)
+	}
+
+	return nil
+}
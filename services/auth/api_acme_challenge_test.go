@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/p2p/streams/command"
+	"gotest.tools/v3/assert"
+)
+
+func TestACMEChallenges(t *testing.T) {
+	ctx := context.Background()
+	svc, err := New(ctx, newTestConfig(t, 12391))
+	assert.NilError(t, err)
+	defer svc.Close()
+
+	fqdn := "_acme-challenge.g.tau.link"
+	for _, value := range []string{"wildcard", "apex"} {
+		_, err = svc.acmeServiceHandler(ctx, nil, command.Body{"action": "challenge-set", "fqdn": fqdn, "value": value})
+		assert.NilError(t, err)
+	}
+
+	resp, err := svc.acmeServiceHandler(ctx, nil, command.Body{"action": "challenge-get", "fqdn": fqdn})
+	assert.NilError(t, err)
+	assert.Equal(t, len(resp["values"].([]string)), 2)
+
+	_, err = svc.acmeServiceHandler(ctx, nil, command.Body{"action": "challenge-delete", "fqdn": fqdn, "value": "apex"})
+	assert.NilError(t, err)
+
+	values, err := svc.getACMEChallenges(ctx, fqdn)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, values, []string{"wildcard"})
+
+	values, err = svc.getACMEChallenges(ctx, "_acme-challenge.other.com")
+	assert.NilError(t, err)
+	assert.Equal(t, len(values), 0)
+
+	defer func(ttl time.Duration) { ChallengeTTL = ttl }(ChallengeTTL)
+	ChallengeTTL = -time.Second
+	assert.NilError(t, svc.setACMEChallenge(ctx, fqdn, "expired"))
+
+	values, err = svc.getACMEChallenges(ctx, fqdn)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, values, []string{"wildcard"})
+}
@@ -424,7 +424,7 @@ func TestP2PRepositoryHandlers(t *testing.T) {
 			params map[string]interface{}
 			expect string
 		}{
-			{"UnsupportedProvider", map[string]interface{}{"provider": "bitbucket", "id": "12345"}, "unsupported provider"},
+			{"UnsupportedProvider", map[string]interface{}{"provider": "svn", "id": "12345"}, "unsupported provider"},
 			{"MissingProvider", map[string]interface{}{"id": "12345"}, "missing provider"},
 			{"MissingID", map[string]interface{}{"provider": "github"}, "missing ID"},
 		}
@@ -565,7 +565,7 @@ func TestRepositoryOperations(t *testing.T) {
 			nil,
 			{"provider": "github"},
 			{"id": "12345"},
-			{"provider": "bitbucket", "id": "12345"},
+			{"provider": "svn", "id": "12345"},
 		}
 
 		for _, invalidInput := range invalidInputs {
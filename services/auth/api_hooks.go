@@ -4,15 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 
 	"github.com/ipfs/go-cid"
 	"github.com/taubyte/tau/p2p/streams"
 	"github.com/taubyte/tau/p2p/streams/command"
 	cr "github.com/taubyte/tau/p2p/streams/command/response"
+	cu "github.com/taubyte/tau/services/auth/crypto"
 	"github.com/taubyte/tau/services/auth/hooks"
 	"github.com/taubyte/tau/services/auth/projects"
 	"github.com/taubyte/tau/services/auth/repositories"
 	"github.com/taubyte/tau/services/common"
+	"github.com/taubyte/tau/utils/id"
 	"github.com/taubyte/tau/utils/maps"
 )
 
@@ -37,6 +41,62 @@ func (srv *AuthService) listHooks(ctx context.Context) (cr.Response, error) {
 	return cr.Response{"hooks": ids}, nil
 }
 
+// registerGitHook creates a push hook for a provider that has no repository
+// registration flow of its own. The returned secret must be configured on the
+// provider side together with the webhook URL.
+func (srv *AuthService) registerGitHook(ctx context.Context, body command.Body) (cr.Response, error) {
+	provider, err := maps.String(body, "provider")
+	if err != nil {
+		return nil, fmt.Errorf("missing provider parameter: %w", err)
+	}
+
+	if !slices.Contains(hooks.GitProviders, provider) {
+		return nil, fmt.Errorf("provider `%s` is not supported", provider)
+	}
+
+	repository, err := maps.String(body, "repository")
+	if err != nil {
+		return nil, fmt.Errorf("missing repository parameter: %w", err)
+	}
+
+	// Jobs are keyed by a numeric repository id: Bitbucket repositories are
+	// given one for their UUID, the others go by their own.
+	var repositoryId int
+	if provider == "bitbucket" {
+		repositoryId, err = srv.bitbucketRepositoryId(ctx, repository)
+	} else {
+		repositoryId, err = strconv.Atoi(repository)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolving id of %s repository `%s` failed with: %w", provider, repository, err)
+	}
+
+	secret, err := cu.GenerateSecretString()
+	if err != nil {
+		return nil, fmt.Errorf("generating hook secret failed with %w", err)
+	}
+
+	hook, err := hooks.New(srv.KV(), hooks.Data{
+		"id":            id.Generate(provider, repository),
+		"provider":      provider,
+		"repository":    repository,
+		"repository_id": repositoryId,
+		"secret":        secret,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("hooks new failed with %w", err)
+	}
+
+	if err = hook.Register(ctx); err != nil {
+		return nil, fmt.Errorf("hooks register failed with %w", err)
+	}
+
+	resp := cr.Response(hook.Serialize())
+	resp["url"] = srv.webHookUrl + "/" + provider + "/" + hook.ID()
+
+	return resp, nil
+}
+
 func (srv *AuthService) apiHookServiceHandler(ctx context.Context, st streams.Connection, body command.Body) (cr.Response, error) {
 	action, err := maps.String(body, "action")
 	if err != nil {
@@ -52,14 +112,16 @@ func (srv *AuthService) apiHookServiceHandler(ctx context.Context, st streams.Co
 		return srv.getRepositoryHookByID(ctx, hook_id)
 	case "list":
 		return srv.listHooks(ctx)
+	case "register":
+		return srv.registerGitHook(ctx, body)
 	default:
 		return nil, errors.New("Hook action `" + action + "` not reconized.")
 	}
 }
 
 /******* REPOS ********/
-func (srv *AuthService) getGithubRepositoryByID(ctx context.Context, id int) (cr.Response, error) {
-	repo, err := repositories.Fetch(ctx, srv.db, fmt.Sprintf("%d", id))
+func (srv *AuthService) getRepositoryByID(ctx context.Context, provider string, id int) (cr.Response, error) {
+	repo, err := repositories.FetchOn(ctx, srv.db, provider, id)
 	if err != nil {
 		return nil, err
 	}
@@ -90,16 +152,14 @@ func (srv *AuthService) apiGitRepositoryServiceHandler(ctx context.Context, st s
 		if err != nil {
 			return nil, err
 		}
-		switch provider {
-		case "github":
-			hook_id, err := maps.Int(body, "id")
-			if err != nil {
-				return nil, err
-			}
-			return srv.getGithubRepositoryByID(ctx, hook_id)
-		default:
+		if !slices.Contains(repositories.GitProviders, provider) {
 			return nil, errors.New("Repository provider `" + provider + "` not supported.")
 		}
+		repo_id, err := maps.Int(body, "id")
+		if err != nil {
+			return nil, err
+		}
+		return srv.getRepositoryByID(ctx, provider, repo_id)
 	case "list":
 		return srv.listRepo(ctx)
 	case "register":
@@ -150,6 +210,15 @@ func (srv *AuthService) createProjectStream(ctx context.Context, body command.Bo
 		return nil, fmt.Errorf("missing code repository ID parameter: %w", err)
 	}
 
+	provider, err := maps.String(body, "provider")
+	if err != nil {
+		provider = "github"
+	}
+
+	if !slices.Contains(repositories.GitProviders, provider) {
+		return nil, fmt.Errorf("provider `%s` is not supported", provider)
+	}
+
 	// Generate a new project ID
 	projectID := common.GetNewProjectID()
 
@@ -157,7 +226,7 @@ func (srv *AuthService) createProjectStream(ctx context.Context, body command.Bo
 	project, err := projects.New(srv.KV(), projects.Data{
 		"id":       projectID,
 		"name":     name,
-		"provider": "github",
+		"provider": provider,
 		"config":   configRepoID,
 		"code":     codeRepoID,
 	})
@@ -171,12 +240,12 @@ func (srv *AuthService) createProjectStream(ctx context.Context, body command.Bo
 	}
 
 	// Link repositories to project
-	repo_key := fmt.Sprintf("/repositories/github/%s", configRepoID)
+	repo_key := fmt.Sprintf("/repositories/%s/%s", provider, configRepoID)
 	if err = srv.db.Put(ctx, repo_key+"/project", []byte(projectID)); err != nil {
 		return nil, err
 	}
 
-	repo_key = fmt.Sprintf("/repositories/github/%s", codeRepoID)
+	repo_key = fmt.Sprintf("/repositories/%s/%s", provider, codeRepoID)
 	if err = srv.db.Put(ctx, repo_key+"/project", []byte(projectID)); err != nil {
 		return nil, err
 	}
@@ -216,7 +285,7 @@ func (srv *AuthService) registerRepositoryStream(ctx context.Context, body comma
 		return nil, fmt.Errorf("missing provider parameter: %w", err)
 	}
 
-	if provider != "github" {
+	if !slices.Contains(repositories.GitProviders, provider) {
 		return nil, fmt.Errorf("provider `%s` is not supported", provider)
 	}
 
@@ -228,14 +297,24 @@ func (srv *AuthService) registerRepositoryStream(ctx context.Context, body comma
 	// For p2p streams (internal service communication), we can register repositories
 	// without external GitHub API calls since services trust each other
 	// Use the main function with nil client to skip GitHub API verification
-	response, err := srv.registerGitHubRepository(ctx, nil, repoID)
+	var response *RepositoryRegistrationResponse
+	if provider == "github" {
+		response, err = srv.registerGitHubRepository(ctx, nil, repoID)
+	} else {
+		response, err = srv.registerGitRepository(ctx, provider, repoID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("repository registration failed: %w", err)
 	}
 
-	return cr.Response{
+	resp := cr.Response{
 		"key": response.Key,
-	}, nil
+	}
+	if response.DeployKey != "" {
+		resp["deploy_key"] = response.DeployKey
+	}
+
+	return resp, nil
 }
 
 // unregisterRepositoryStream handles repository unregistration over p2p streams
@@ -245,7 +324,7 @@ func (srv *AuthService) unregisterRepositoryStream(ctx context.Context, body com
 		return nil, fmt.Errorf("missing provider parameter: %w", err)
 	}
 
-	if provider != "github" {
+	if !slices.Contains(repositories.GitProviders, provider) {
 		return nil, fmt.Errorf("provider `%s` is not supported", provider)
 	}
 
@@ -257,7 +336,11 @@ func (srv *AuthService) unregisterRepositoryStream(ctx context.Context, body com
 	// For p2p streams (internal service communication), we can unregister repositories
 	// without external GitHub API calls
 	// Use the main function with nil client to skip GitHub API verification
-	err = srv.unregisterGitHubRepository(ctx, nil, repoID)
+	if provider == "github" {
+		err = srv.unregisterGitHubRepository(ctx, nil, repoID)
+	} else {
+		err = srv.unregisterGitRepository(ctx, provider, repoID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("repository unregistration failed: %w", err)
 	}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+
+	"github.com/taubyte/tau/services/auth/repositories"
+)
+
+// registerGitRepository registers a repository of a provider other than
+// GitHub. Auth can't install the deploy key there, so its public half is
+// returned to be added to the repository by hand.
+func (srv *AuthService) registerGitRepository(ctx context.Context, provider, repoID string) (*RepositoryRegistrationResponse, error) {
+	_repo_id, err := strconv.Atoi(repoID)
+	if err != nil {
+		return nil, fmt.Errorf("parse repoId failed with %s", err)
+	}
+
+	if repositories.ExistOn(ctx, srv.db, provider, repoID) {
+		return nil, fmt.Errorf("%s repository `%s` is already registered", provider, repoID)
+	}
+
+	_, kpub, kpriv, err := generateKey()
+	if err != nil {
+		return nil, fmt.Errorf("generate key failed with %s", err)
+	}
+
+	repo, err := repositories.New(srv.KV(), repositories.Data{
+		"id":       _repo_id,
+		"provider": provider,
+		"key":      kpriv,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("new repository failed with %s", err)
+	}
+
+	if err = repo.Register(ctx); err != nil {
+		return nil, err
+	}
+
+	return &RepositoryRegistrationResponse{
+		Key:       fmt.Sprintf("/repositories/%s/%s/key", provider, repoID),
+		DeployKey: kpub,
+	}, nil
+}
+
+// unregisterGitRepository drops a repository of a provider other than GitHub
+// and its hooks.
+func (srv *AuthService) unregisterGitRepository(ctx context.Context, provider, repoID string) error {
+	_repo_id, err := strconv.Atoi(repoID)
+	if err != nil {
+		return err
+	}
+
+	repo, err := repositories.FetchOn(ctx, srv.db, provider, _repo_id)
+	if err != nil {
+		return fmt.Errorf("repository `%s` not registered! err = %w", repoID, err)
+	}
+
+	for _, hook := range repo.Hooks(ctx) {
+		hook.Delete(ctx)
+	}
+
+	return repo.Delete(ctx)
+}
+
+// bitbucketRepositoryId returns the numeric id a Bitbucket repository is
+// known by, allocating one on first use. Bitbucket Cloud only exposes UUIDs;
+// the id starts from a hash of the UUID and moves on past ids taken by other
+// repositories, so two UUIDs never share one.
+func (srv *AuthService) bitbucketRepositoryId(ctx context.Context, uuid string) (int, error) {
+	uuidKey := "/bitbucket/uuids/" + uuid
+	if v, err := srv.db.Get(ctx, uuidKey); err == nil && len(v) > 0 {
+		return strconv.Atoi(string(v))
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(uuid))
+	id := int(h.Sum32() & math.MaxInt32)
+
+	for range 1024 {
+		if id == 0 {
+			id = 1
+		}
+
+		idKey := fmt.Sprintf("/bitbucket/ids/%d", id)
+		taken, err := srv.db.Get(ctx, idKey)
+		if err != nil || len(taken) == 0 {
+			if err = srv.db.Put(ctx, idKey, []byte(uuid)); err != nil {
+				return 0, fmt.Errorf("reserving bitbucket repository id failed with: %w", err)
+			}
+
+			if err = srv.db.Put(ctx, uuidKey, []byte(strconv.Itoa(id))); err != nil {
+				return 0, fmt.Errorf("recording bitbucket repository id failed with: %w", err)
+			}
+
+			return id, nil
+		}
+
+		if string(taken) == uuid {
+			return id, nil
+		}
+
+		id = (id + 1) & math.MaxInt32
+	}
+
+	return 0, fmt.Errorf("no free repository id for bitbucket repository `%s`", uuid)
+}
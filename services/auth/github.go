@@ -22,6 +22,9 @@ import (
 
 type RepositoryRegistrationResponse struct {
 	Key string `json:"key"`
+	// DeployKey is the public key to install on repositories of providers
+	// auth can't install it on.
+	DeployKey string `json:"deploy_key,omitempty"`
 }
 
 type ProjectResponse struct {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"strconv"
 	"time"
 
 	http "github.com/taubyte/tau/pkg/http"
@@ -126,7 +127,12 @@ func (srv *AuthService) getGitHubUserRepositoryHTTPHandler(ctx http.Context) (in
 		return nil, fmt.Errorf("repository %s not found", repoId)
 	}
 
-	repo, err := repositories.Fetch(requestCtx, srv.db, repoId)
+	_repoId, err := strconv.Atoi(repoId)
+	if err != nil {
+		return nil, fmt.Errorf("parsing repository ID failed with %w", err)
+	}
+
+	repo, err := repositories.FetchOn(requestCtx, srv.db, provider, _repoId)
 	if err != nil {
 		return nil, fmt.Errorf("fetching repository %s failed with %w", repoId, err)
 	}
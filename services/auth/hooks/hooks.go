@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 
 	"github.com/ipfs/go-log/v2"
@@ -151,6 +152,130 @@ func (h *GithubHook) Register(ctx context.Context) error {
 	return nil
 }
 
+// GitHook is a push hook for providers that authenticate deliveries with a
+// shared secret only (gitlab, gitea/forgejo, bitbucket).
+type GitHook struct {
+	HookCommon
+	Secret     string
+	Repository string
+	// RepositoryId is the numeric id jobs and deploy keys of the repository
+	// are keyed by.
+	RepositoryId int
+}
+
+// GitProviders lists the providers backed by GitHook.
+var GitProviders = []string{"gitlab", "gitea", "bitbucket"}
+
+func isGitProvider(provider string) bool {
+	return slices.Contains(GitProviders, provider)
+}
+
+func (h *GitHook) Serialize() Data {
+	return Data{
+		"id":            h.Id,
+		"provider":      h.Provider,
+		"secret":        h.Secret,
+		"repository":    h.Repository,
+		"repository_id": h.RepositoryId,
+	}
+}
+
+func (h *GitHook) repositoryPath() string {
+	return fmt.Sprintf("/repositories/%s/%d/hooks/%s", h.Provider, h.RepositoryId, h.Id)
+}
+
+func (h *GitHook) ProviderID() string {
+	return h.Repository
+}
+
+func (h *GitHook) Delete(ctx context.Context) error {
+	err := h.HookCommon.Delete(ctx)
+	if err != nil {
+		return err
+	}
+
+	batch, err := h.KV.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	root := "/hooks/" + h.Id + "/" + h.Provider
+
+	err = batch.Delete(root + "/secret")
+	if err != nil {
+		return fmt.Errorf("failed to batch delete hook secret: %w", err)
+	}
+
+	err = batch.Delete(root + "/repository")
+	if err != nil {
+		return fmt.Errorf("failed to batch delete hook repository: %w", err)
+	}
+
+	err = batch.Delete(root + "/repository_id")
+	if err != nil {
+		return fmt.Errorf("failed to batch delete hook repository id: %w", err)
+	}
+
+	err = batch.Delete(h.repositoryPath())
+	if err != nil {
+		return fmt.Errorf("failed to batch delete repository hook reference: %w", err)
+	}
+
+	err = batch.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit hook deletion batch: %w", err)
+	}
+
+	return nil
+}
+
+func (h *GitHook) Register(ctx context.Context) error {
+	err := h.HookCommon.Register(ctx)
+	if err != nil {
+		return err
+	}
+
+	batch, err := h.KV.Batch(ctx)
+	if err != nil {
+		h.Delete(ctx)
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	root := "/hooks/" + h.Id + "/" + h.Provider
+
+	err = batch.Put(h.repositoryPath(), nil)
+	if err != nil {
+		h.Delete(ctx)
+		return fmt.Errorf("failed to batch repository hook reference: %w", err)
+	}
+
+	err = batch.Put(root+"/secret", []byte(h.Secret))
+	if err != nil {
+		h.Delete(ctx)
+		return fmt.Errorf("failed to batch hook secret: %w", err)
+	}
+
+	err = batch.Put(root+"/repository", []byte(h.Repository))
+	if err != nil {
+		h.Delete(ctx)
+		return fmt.Errorf("failed to batch hook repository: %w", err)
+	}
+
+	err = batch.Put(root+"/repository_id", network.UInt64ToBytes(uint64(h.RepositoryId)))
+	if err != nil {
+		h.Delete(ctx)
+		return fmt.Errorf("failed to batch hook repository id: %w", err)
+	}
+
+	err = batch.Commit()
+	if err != nil {
+		h.Delete(ctx)
+		return fmt.Errorf("failed to commit hook registration batch: %w", err)
+	}
+
+	return nil
+}
+
 func Exist(ctx context.Context, kv kvdb.KVDB, id string) bool {
 	ret, err := kv.Get(ctx, "/hooks/"+id+"/provider")
 	if err != nil || ret == nil {
@@ -200,7 +325,32 @@ func Fetch(ctx context.Context, kv kvdb.KVDB, hook_id string) (Hook, error) {
 
 		data["repository"] = int(repository)
 	default:
-		return nil, errors.New("unknown/unsupported git provider " + provider)
+		if !isGitProvider(provider) {
+			return nil, errors.New("unknown/unsupported git provider " + provider)
+		}
+
+		_secret, err := kv.Get(ctx, "/hooks/"+hook_id+"/"+provider+"/secret")
+		if err != nil {
+			return nil, err
+		}
+		data["secret"] = string(_secret)
+
+		_repository, err := kv.Get(ctx, "/hooks/"+hook_id+"/"+provider+"/repository")
+		if err != nil {
+			return nil, err
+		}
+		data["repository"] = string(_repository)
+
+		_repositoryId, err := kv.Get(ctx, "/hooks/"+hook_id+"/"+provider+"/repository_id")
+		if err != nil {
+			return nil, err
+		}
+		repositoryId, err := network.BytesToUInt64(_repositoryId)
+		if err != nil {
+			return nil, errors.New("Repository ID for Hook `" + hook_id + "` is not an `int`")
+		}
+
+		data["repository_id"] = int(repositoryId)
 	}
 
 	return New(kv, data)
@@ -243,6 +393,32 @@ func New(kv kvdb.KVDB, data Data) (Hook, error) {
 			Repository: repository,
 		}, nil
 	default:
-		return nil, fmt.Errorf("unknown hook type `%s` ", provider)
+		if !isGitProvider(provider) {
+			return nil, fmt.Errorf("unknown hook type `%s` ", provider)
+		}
+
+		repository, err := maps.String(data, "repository")
+		if err != nil {
+			return nil, err
+		}
+		repositoryId, err := maps.Int(data, "repository_id")
+		if err != nil {
+			return nil, err
+		}
+		secret, err := maps.String(data, "secret")
+		if err != nil {
+			return nil, err
+		}
+
+		return &GitHook{
+			HookCommon: HookCommon{
+				KV:       kv,
+				Id:       id,
+				Provider: provider,
+			},
+			Secret:       secret,
+			Repository:   repository,
+			RepositoryId: repositoryId,
+		}, nil
 	}
 }
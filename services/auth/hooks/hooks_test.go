@@ -325,3 +325,45 @@ func TestNew_WithInvalidProvider(t *testing.T) {
 	assert.Assert(t, err != nil)
 	assert.Assert(t, err.Error() != "")
 }
+
+func TestGitHook_RegisterFetchDelete(t *testing.T) {
+	mockKV := mock.New()
+	defer mockKV.Close()
+
+	ctx := context.Background()
+	db, err := mockKV.New(nil, "test", 5)
+	assert.NilError(t, err)
+	defer db.Close()
+
+	for _, provider := range GitProviders {
+		hook, err := New(db, Data{
+			"id":            "hook-" + provider,
+			"provider":      provider,
+			"secret":        "s3cr3t",
+			"repository":    "group/project",
+			"repository_id": 42,
+		})
+		assert.NilError(t, err)
+
+		err = hook.Register(ctx)
+		assert.NilError(t, err)
+
+		fetched, err := Fetch(ctx, db, "hook-"+provider)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, fetched.Serialize(), Data{
+			"id":            "hook-" + provider,
+			"provider":      provider,
+			"secret":        "s3cr3t",
+			"repository":    "group/project",
+			"repository_id": 42,
+		})
+		assert.Equal(t, fetched.ProviderID(), "group/project")
+
+		_, err = db.Get(ctx, "/repositories/"+provider+"/42/hooks/hook-"+provider)
+		assert.NilError(t, err)
+
+		err = fetched.Delete(ctx)
+		assert.NilError(t, err)
+		assert.Assert(t, !Exist(ctx, db, "hook-"+provider))
+	}
+}
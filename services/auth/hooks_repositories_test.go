@@ -2,6 +2,9 @@ package auth
 
 import (
 	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
 	"testing"
 
 	"github.com/taubyte/tau/p2p/streams/command"
@@ -111,8 +114,8 @@ func TestRepositoryHookErrorHandling(t *testing.T) {
 	_, err = svc.getRepositoryHookByID(ctx, "non-existent-hook")
 	assert.Assert(t, err != nil, "Expected error for non-existent hook")
 
-	// Test getGithubRepositoryByID with non-existent repo
-	_, err = svc.getGithubRepositoryByID(ctx, 999)
+	// Test getRepositoryByID with non-existent repo
+	_, err = svc.getRepositoryByID(ctx, "github", 999)
 	assert.Assert(t, err != nil, "Expected error for non-existent repository")
 
 	// Test apiHookServiceHandler with get action
@@ -140,8 +143,8 @@ func TestRepositoryHookErrorHandlingForNonExistentEntities(t *testing.T) {
 	_, err = svc.getRepositoryHookByID(ctx, "non-existent-hook")
 	assert.Assert(t, err != nil, "Expected error for non-existent hook")
 
-	// Test getGithubRepositoryByID with non-existent repo
-	_, err = svc.getGithubRepositoryByID(ctx, 999)
+	// Test getRepositoryByID with non-existent repo
+	_, err = svc.getRepositoryByID(ctx, "github", 999)
 	assert.Assert(t, err != nil, "Expected error for non-existent repository")
 }
 
@@ -249,3 +252,58 @@ func TestApiGitRepositoryServiceHandlerInputValidation(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Assert(t, listResp != nil)
 }
+
+// Test repositories of other providers are kept apart from GitHub ones
+func TestGitRepositoriesByProvider(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestConfig(t, 12392)
+	svc, err := New(ctx, cfg)
+	assert.NilError(t, err)
+	defer svc.Close()
+
+	resp, err := svc.apiGitRepositoryServiceHandler(ctx, nil, command.Body{"action": "register", "provider": "gitlab", "id": "4242"})
+	assert.NilError(t, err)
+	assert.Assert(t, resp["deploy_key"] != nil)
+
+	repo, err := svc.apiGitRepositoryServiceHandler(ctx, nil, command.Body{"action": "get", "provider": "gitlab", "id": 4242})
+	assert.NilError(t, err)
+	assert.Equal(t, repo["provider"], "gitlab")
+
+	// The same id on GitHub is another repository.
+	_, err = svc.apiGitRepositoryServiceHandler(ctx, nil, command.Body{"action": "get", "provider": "github", "id": 4242})
+	assert.Assert(t, err != nil)
+
+	_, err = svc.apiGitRepositoryServiceHandler(ctx, nil, command.Body{"action": "unregister", "provider": "gitlab", "id": "4242"})
+	assert.NilError(t, err)
+
+	_, err = svc.apiGitRepositoryServiceHandler(ctx, nil, command.Body{"action": "get", "provider": "gitlab", "id": 4242})
+	assert.Assert(t, err != nil)
+}
+
+// Test Bitbucket UUIDs get distinct, stable repository ids
+func TestBitbucketRepositoryId(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestConfig(t, 12393)
+	svc, err := New(ctx, cfg)
+	assert.NilError(t, err)
+	defer svc.Close()
+
+	first, err := svc.bitbucketRepositoryId(ctx, "{first}")
+	assert.NilError(t, err)
+	assert.Assert(t, first > 0)
+
+	again, err := svc.bitbucketRepositoryId(ctx, "{first}")
+	assert.NilError(t, err)
+	assert.Equal(t, again, first)
+
+	// Take the id the next UUID hashes to, as a colliding UUID would.
+	h := fnv.New32a()
+	h.Write([]byte("{second}"))
+	hashed := int(h.Sum32() & math.MaxInt32)
+	assert.NilError(t, svc.db.Put(ctx, fmt.Sprintf("/bitbucket/ids/%d", hashed), []byte("{other}")))
+
+	second, err := svc.bitbucketRepositoryId(ctx, "{second}")
+	assert.NilError(t, err)
+	assert.Assert(t, second != hashed)
+	assert.Assert(t, second != first)
+}
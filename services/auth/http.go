@@ -3,4 +3,5 @@ package auth
 func (srv *AuthService) setupHTTPRoutes() {
 	srv.setupGitHubHTTPRoutes()
 	srv.setupDomainsHTTPRoutes()
+	srv.setupStorageHTTPRoutes()
 }
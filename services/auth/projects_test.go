@@ -146,9 +146,9 @@ func TestProjectAndRepositoryAPIOperationsWithSubTests(t *testing.T) {
 		assert.Assert(t, err != nil, "Expected error for invalid action")
 	})
 
-	// Test getGithubRepositoryByID with non-existent repo
-	t.Run("getGithubRepositoryByID", func(t *testing.T) {
-		_, err := svc.getGithubRepositoryByID(ctx, 999)
+	// Test getRepositoryByID with non-existent repo
+	t.Run("getRepositoryByID", func(t *testing.T) {
+		_, err := svc.getRepositoryByID(ctx, "github", 999)
 		assert.Assert(t, err != nil, "Expected error for non-existent repository")
 	})
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 
 	"github.com/ipfs/go-log/v2"
@@ -13,11 +14,12 @@ import (
 )
 
 var (
-	GitProviders = []string{"github"}
+	// GitProviders is the order Fetch tries providers in for a bare id.
+	GitProviders = []string{"github", "gitlab", "gitea", "bitbucket"}
 	logger       = log.Logger("tau.auth.service.api.repositories")
 )
 
-func (r *githubRepository) Serialize() Data {
+func (r *gitRepository) Serialize() Data {
 	return Data{
 		"id":       r.id,
 		"provider": r.provider,
@@ -26,7 +28,7 @@ func (r *githubRepository) Serialize() Data {
 	}
 }
 
-func (r *githubRepository) Delete(ctx context.Context) (err error) {
+func (r *gitRepository) Delete(ctx context.Context) (err error) {
 	for _, h := range r.Hooks(ctx) {
 		err = h.Delete(ctx)
 		if err != nil {
@@ -39,7 +41,7 @@ func (r *githubRepository) Delete(ctx context.Context) (err error) {
 		return fmt.Errorf("failed to create batch: %w", err)
 	}
 
-	repo_key := fmt.Sprintf("/repositories/github/%d/key", r.id)
+	repo_key := fmt.Sprintf("/repositories/%s/%d/key", r.provider, r.id)
 	err = batch.Delete(repo_key)
 	if err != nil {
 		return fmt.Errorf("failed to batch delete repository key: %w", err)
@@ -53,13 +55,13 @@ func (r *githubRepository) Delete(ctx context.Context) (err error) {
 	return nil
 }
 
-func (r *githubRepository) Register(ctx context.Context) (err error) {
+func (r *gitRepository) Register(ctx context.Context) (err error) {
 	batch, err := r.kv.Batch(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create batch: %w", err)
 	}
 
-	repo_key := fmt.Sprintf("/repositories/github/%d/key", r.id)
+	repo_key := fmt.Sprintf("/repositories/%s/%d/key", r.provider, r.id)
 	err = batch.Put(repo_key, []byte(r.key))
 	if err != nil {
 		return fmt.Errorf("failed to batch repository key: %w", err)
@@ -74,8 +76,8 @@ func (r *githubRepository) Register(ctx context.Context) (err error) {
 	return nil
 }
 
-func (r *githubRepository) Hooks(ctx context.Context) []hooks.Hook {
-	keys, err := r.kv.List(ctx, fmt.Sprintf("/repositories/github/%d/hooks/", r.id))
+func (r *gitRepository) Hooks(ctx context.Context) []hooks.Hook {
+	keys, err := r.kv.List(ctx, fmt.Sprintf("/repositories/%s/%d/hooks/", r.provider, r.id))
 	if err != nil {
 		return nil
 	}
@@ -125,8 +127,8 @@ func Provider(ctx context.Context, kv kvdb.KVDB, id string) (string, error) {
 	return "", fmt.Errorf("Repository with ID = `%s` does not exist! error: %w", id, err)
 }
 
-func fetchGithub(ctx context.Context, kv kvdb.KVDB, id int) (Repository, error) {
-	repo_key := fmt.Sprintf("/repositories/github/%d", id)
+func fetch(ctx context.Context, kv kvdb.KVDB, provider string, id int) (Repository, error) {
+	repo_key := fmt.Sprintf("/repositories/%s/%d", provider, id)
 
 	key, err := kv.Get(ctx, repo_key+"/key")
 	if err != nil {
@@ -136,30 +138,39 @@ func fetchGithub(ctx context.Context, kv kvdb.KVDB, id int) (Repository, error)
 	projectId, _ := kv.Get(ctx, repo_key+"/project")
 	return New(kv, Data{
 		"id":       id,
-		"provider": "github",
+		"provider": provider,
 		"project":  string(projectId),
 		"key":      string(key),
 	})
+}
+
+// FetchOn fetches the repository with the given id on provider. Ids are only
+// unique per provider, so lookups for a known provider must use it.
+func FetchOn(ctx context.Context, kv kvdb.KVDB, provider string, id int) (Repository, error) {
+	if !slices.Contains(GitProviders, provider) {
+		return nil, errors.New("unknown/unsupported git provider " + provider)
+	}
+
+	repo, err := fetch(ctx, kv, provider, id)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s repository %d failed with: %w", provider, id, err)
+	}
 
+	return repo, nil
 }
 
+// Fetch fetches a repository by id alone, from the first provider of
+// GitProviders that has it.
 func Fetch(ctx context.Context, kv kvdb.KVDB, id string) (Repository, error) {
 	provider, err := Provider(ctx, kv, id)
 	if err != nil {
 		return nil, err
 	}
 
-	switch provider {
-	case "github":
-		_id, err := strconv.Atoi(id)
-		if err != nil {
-			return nil, errors.New("Github repository id must be an int. Parsing returned: " + err.Error())
-		}
-		repo, err := fetchGithub(ctx, kv, _id)
-		if err != nil {
-			return nil, errors.New("Failed fetching github with: " + err.Error())
-		}
-		return repo, nil
+	_id, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errors.New("repository id must be an int. Parsing returned: " + err.Error())
 	}
-	return nil, errors.New("unknown/unsupported git provider " + provider)
+
+	return FetchOn(ctx, kv, provider, _id)
 }
@@ -24,16 +24,16 @@ type repositoryCommon struct {
 	project  string
 }
 
-type githubRepository struct {
+type gitRepository struct {
 	repositoryCommon
 	id  int
 	key string
 }
 
-func (r *githubRepository) ID() int {
+func (r *gitRepository) ID() int {
 	return r.id
 }
 
-func (r *githubRepository) Provider() string {
+func (r *gitRepository) Provider() string {
 	return r.provider
 }
@@ -8,8 +8,10 @@ import (
 
 	"github.com/ipfs/go-log/v2"
 	accountsClientPkg "github.com/taubyte/tau/clients/p2p/accounts"
+	seerApi "github.com/taubyte/tau/clients/p2p/seer"
 	tnsApi "github.com/taubyte/tau/clients/p2p/tns"
 	accountsIface "github.com/taubyte/tau/core/services/accounts"
+	seerIface "github.com/taubyte/tau/core/services/seer"
 	streams "github.com/taubyte/tau/p2p/streams/service"
 	tauConfig "github.com/taubyte/tau/pkg/config"
 	"github.com/taubyte/tau/pkg/kvdb"
@@ -70,6 +72,11 @@ func New(ctx context.Context, cfg tauConfig.Config) (*AuthService, error) {
 	if srv.tnsClient, err = tnsApi.New(srv.ctx, clientNode); err != nil {
 		return nil, err
 	}
+	seerClient, err := seerApi.New(srv.ctx, clientNode, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating seer client: %w", err)
+	}
+	srv.substratePeers = servicesCommon.NewServicePeers(seerClient.Usage(), seerIface.ServiceTypeSubstrate)
 	srv.config = cfg
 	if srv.stream, err = streams.New(srv.node, servicesCommon.Auth, servicesCommon.AuthProtocol); err != nil {
 		return nil, err
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/taubyte/tau/p2p/streams"
+	"github.com/taubyte/tau/p2p/streams/command"
+	cr "github.com/taubyte/tau/p2p/streams/command/response"
+	storageSpec "github.com/taubyte/tau/pkg/specs/storage"
+	"github.com/taubyte/tau/utils/maps"
+)
+
+// Storage credential keys:
+//
+//	/storage/credentials/<access key>/project = project id
+//	/storage/credentials/<access key>/secret = secret key
+//	/storage/projects/<project id>/<access key> = nil
+func storageCredentialsKey(accessKey, field string) string {
+	return "/storage/credentials/" + accessKey + "/" + field
+}
+
+func storageProjectPrefix(projectId string) string {
+	return "/storage/projects/" + projectId + "/"
+}
+
+// issueStorageCredentials stores a new key pair for a project, which S3 and
+// MQTT clients authenticate with.
+func (srv *AuthService) issueStorageCredentials(ctx context.Context, projectId string) (*StorageCredentials, error) {
+	accessKey, secretKey, err := storageSpec.NewCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := srv.db.Batch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating batch failed with: %w", err)
+	}
+
+	if err = batch.Put(storageCredentialsKey(accessKey, "project"), []byte(projectId)); err != nil {
+		return nil, err
+	}
+
+	if err = batch.Put(storageCredentialsKey(accessKey, "secret"), []byte(secretKey)); err != nil {
+		return nil, err
+	}
+
+	if err = batch.Put(storageProjectPrefix(projectId)+accessKey, nil); err != nil {
+		return nil, err
+	}
+
+	if err = batch.Commit(); err != nil {
+		return nil, fmt.Errorf("storing storage credentials of project `%s` failed with: %w", projectId, err)
+	}
+
+	return &StorageCredentials{AccessKeyId: accessKey, SecretAccessKey: secretKey}, nil
+}
+
+// listStorageCredentials returns the access keys issued for a project.
+func (srv *AuthService) listStorageCredentials(ctx context.Context, projectId string) ([]string, error) {
+	prefix := storageProjectPrefix(projectId)
+	keys, err := srv.db.List(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing storage credentials of project `%s` failed with: %w", projectId, err)
+	}
+
+	accessKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		accessKeys = append(accessKeys, strings.TrimPrefix(key, prefix))
+	}
+
+	return accessKeys, nil
+}
+
+// revokeStorageCredentials deletes a key pair issued for a project.
+func (srv *AuthService) revokeStorageCredentials(ctx context.Context, projectId, accessKey string) error {
+	owner, err := srv.db.Get(ctx, storageCredentialsKey(accessKey, "project"))
+	if err != nil || string(owner) != projectId {
+		return fmt.Errorf("access key `%s` not found in project `%s`", accessKey, projectId)
+	}
+
+	batch, err := srv.db.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("creating batch failed with: %w", err)
+	}
+
+	for _, key := range []string{storageCredentialsKey(accessKey, "project"), storageCredentialsKey(accessKey, "secret"), storageProjectPrefix(projectId) + accessKey} {
+		if err = batch.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	if err = batch.Commit(); err != nil {
+		return fmt.Errorf("revoking access key `%s` failed with: %w", accessKey, err)
+	}
+
+	return nil
+}
+
+// lookupStorageCredentials returns the project and secret of an access key.
+func (srv *AuthService) lookupStorageCredentials(ctx context.Context, accessKey string) (projectId, secretKey string, err error) {
+	project, err := srv.db.Get(ctx, storageCredentialsKey(accessKey, "project"))
+	if err != nil {
+		return "", "", fmt.Errorf("access key `%s` not found", accessKey)
+	}
+
+	secret, err := srv.db.Get(ctx, storageCredentialsKey(accessKey, "secret"))
+	if err != nil {
+		return "", "", fmt.Errorf("access key `%s` not found", accessKey)
+	}
+
+	return string(project), string(secret), nil
+}
+
+// storageServiceHandler hands secrets out to substrate nodes only, which
+// check S3 signatures with them.
+func (srv *AuthService) storageServiceHandler(ctx context.Context, conn streams.Connection, body command.Body) (cr.Response, error) {
+	if !srv.substratePeers.Has(conn.RemotePeer()) {
+		return nil, errors.New("storage credentials are only served to substrate nodes")
+	}
+
+	action, err := maps.String(body, "action")
+	if err != nil {
+		return nil, err
+	}
+
+	switch action {
+	case "credentials":
+		accessKey, err := maps.String(body, "access")
+		if err != nil {
+			return nil, err
+		}
+
+		projectId, secretKey, err := srv.lookupStorageCredentials(ctx, accessKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return cr.Response{"project": projectId, "secret": secretKey}, nil
+	default:
+		return nil, fmt.Errorf("storage action `%s` not recognized", action)
+	}
+}
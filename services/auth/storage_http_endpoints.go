@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	http "github.com/taubyte/tau/pkg/http"
+	"github.com/taubyte/tau/services/auth/projects"
+	protocolCommon "github.com/taubyte/tau/services/common"
+	"github.com/taubyte/tau/utils/maps"
+)
+
+type StorageCredentialsResponse struct {
+	Credentials StorageCredentials `json:"credentials"`
+}
+
+type StorageCredentials struct {
+	AccessKeyId     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key,omitempty"`
+}
+
+type StorageCredentialsListResponse struct {
+	AccessKeys []string `json:"access_keys"`
+}
+
+// storageProject returns the project of the request once the user is known to
+// reach its config repository.
+func (srv *AuthService) storageProject(ctx http.Context) (string, error) {
+	client, err := getGithubClientFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	projectId, err := maps.String(ctx.Variables(), "project")
+	if err != nil {
+		return "", err
+	}
+
+	return projectId, srv.checkStorageAccess(ctx.Request().Context(), client, projectId)
+}
+
+func (srv *AuthService) checkStorageAccess(ctx context.Context, client GitHubClient, projectId string) error {
+	project, err := projects.Fetch(ctx, srv.KV(), projectId)
+	if err != nil {
+		return fmt.Errorf("retrieving project error: %w", err)
+	}
+
+	if err = client.GetByID(project.Config()); err != nil {
+		return fmt.Errorf("accessing config repository of project `%s` failed with: %w", projectId, err)
+	}
+
+	return nil
+}
+
+func (srv *AuthService) issueStorageCredentialsHTTPHandler(ctx http.Context) (interface{}, error) {
+	projectId, err := srv.storageProject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := srv.issueStorageCredentials(ctx.Request().Context(), projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StorageCredentialsResponse{Credentials: *credentials}, nil
+}
+
+func (srv *AuthService) listStorageCredentialsHTTPHandler(ctx http.Context) (interface{}, error) {
+	projectId, err := srv.storageProject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accessKeys, err := srv.listStorageCredentials(ctx.Request().Context(), projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StorageCredentialsListResponse{AccessKeys: accessKeys}, nil
+}
+
+func (srv *AuthService) revokeStorageCredentialsHTTPHandler(ctx http.Context) (interface{}, error) {
+	projectId, err := srv.storageProject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accessKey, err := maps.String(ctx.Variables(), "access")
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, srv.revokeStorageCredentials(ctx.Request().Context(), projectId, accessKey)
+}
+
+func (srv *AuthService) setupStorageHTTPRoutes() {
+	hosts := srv.config.RouteHosts(protocolCommon.Auth)
+
+	srv.http.POST(&http.RouteDefinition{
+		Hosts: hosts,
+		Path:  "/project/{project}/storage/credentials",
+		Vars: http.Variables{
+			Required: []string{"project"},
+		},
+		Scope: []string{"projects/write"},
+		Auth: http.RouteAuthHandler{
+			Validator: srv.GitHubTokenHTTPAuth,
+			GC:        srv.GitHubTokenHTTPAuthCleanup,
+		},
+		Handler: srv.issueStorageCredentialsHTTPHandler,
+	})
+
+	srv.http.GET(&http.RouteDefinition{
+		Hosts: hosts,
+		Path:  "/project/{project}/storage/credentials",
+		Vars: http.Variables{
+			Required: []string{"project"},
+		},
+		Scope: []string{"projects/read"},
+		Auth: http.RouteAuthHandler{
+			Validator: srv.GitHubTokenHTTPAuth,
+			GC:        srv.GitHubTokenHTTPAuthCleanup,
+		},
+		Handler: srv.listStorageCredentialsHTTPHandler,
+	})
+
+	srv.http.DELETE(&http.RouteDefinition{
+		Hosts: hosts,
+		Path:  "/project/{project}/storage/credentials/{access}",
+		Vars: http.Variables{
+			Required: []string{"project", "access"},
+		},
+		Scope: []string{"projects/write"},
+		Auth: http.RouteAuthHandler{
+			Validator: srv.GitHubTokenHTTPAuth,
+			GC:        srv.GitHubTokenHTTPAuthCleanup,
+		},
+		Handler: srv.revokeStorageCredentialsHTTPHandler,
+	})
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/taubyte/tau/services/auth/projects"
+	"gotest.tools/v3/assert"
+)
+
+func TestStorageCredentials(t *testing.T) {
+	svc, cleanup := createTestServiceWithKeys(t, 12389)
+	defer cleanup()
+
+	project, err := projects.New(svc.KV(), projects.Data{
+		"id":     "QmStorageProject",
+		"name":   "storage",
+		"config": "123",
+		"code":   "456",
+	})
+	assert.NilError(t, err)
+	assert.NilError(t, project.Register())
+
+	mockCtx := &mockHTTPContextWithClient{
+		variables: map[string]interface{}{
+			"project":      "QmStorageProject",
+			"GithubClient": &mockGitHubClient{},
+		},
+	}
+
+	response, err := svc.issueStorageCredentialsHTTPHandler(mockCtx)
+	assert.NilError(t, err)
+	credentials := response.(*StorageCredentialsResponse).Credentials
+
+	projectId, secretKey, err := svc.lookupStorageCredentials(context.Background(), credentials.AccessKeyId)
+	assert.NilError(t, err)
+	assert.Equal(t, projectId, "QmStorageProject")
+	assert.Equal(t, secretKey, credentials.SecretAccessKey)
+
+	// Each key pair is issued on its own.
+	response, err = svc.issueStorageCredentialsHTTPHandler(mockCtx)
+	assert.NilError(t, err)
+	other := response.(*StorageCredentialsResponse).Credentials
+	assert.Assert(t, other.AccessKeyId != credentials.AccessKeyId)
+	assert.Assert(t, other.SecretAccessKey != credentials.SecretAccessKey)
+
+	response, err = svc.listStorageCredentialsHTTPHandler(mockCtx)
+	assert.NilError(t, err)
+	assert.Equal(t, len(response.(*StorageCredentialsListResponse).AccessKeys), 2)
+
+	// Keys are revoked one at a time, and only from their project.
+	assert.ErrorContains(t, svc.revokeStorageCredentials(context.Background(), "QmOtherProject", credentials.AccessKeyId), "not found")
+
+	mockCtx.variables["access"] = credentials.AccessKeyId
+	_, err = svc.revokeStorageCredentialsHTTPHandler(mockCtx)
+	assert.NilError(t, err)
+
+	_, _, err = svc.lookupStorageCredentials(context.Background(), credentials.AccessKeyId)
+	assert.ErrorContains(t, err, "not found")
+
+	_, _, err = svc.lookupStorageCredentials(context.Background(), other.AccessKeyId)
+	assert.NilError(t, err)
+
+	response, err = svc.listStorageCredentialsHTTPHandler(mockCtx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, response.(*StorageCredentialsListResponse).AccessKeys, []string{other.AccessKeyId})
+
+	err = svc.checkStorageAccess(context.Background(), &mockGitHubClient{}, "QmMissingProject")
+	assert.ErrorContains(t, err, "retrieving project")
+}
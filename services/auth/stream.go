@@ -31,6 +31,7 @@ func (srv *AuthService) setupStreamRoutes() {
 	srv.stream.Define("repositories", srv.apiGitRepositoryServiceHandler)
 	srv.stream.Define("projects", srv.apiProjectsServiceHandler)
 	srv.stream.Define("domain", srv.ApiDomainServiceHandler)
+	srv.stream.Define("storage", srv.storageServiceHandler)
 
 	attachSecretsServiceStreams(srv.secretsService, srv.stream)
 }
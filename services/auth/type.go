@@ -13,6 +13,7 @@ import (
 	iface "github.com/taubyte/tau/core/services/auth"
 	"github.com/taubyte/tau/core/services/tns"
 	tauConfig "github.com/taubyte/tau/pkg/config"
+	servicesCommon "github.com/taubyte/tau/services/common"
 )
 
 var _ iface.Service = &AuthService{}
@@ -26,6 +27,9 @@ type AuthService struct {
 	tnsClient tns.Client
 	dbFactory kv.Factory
 
+	// substratePeers are the nodes storage credentials are served to.
+	substratePeers *servicesCommon.ServicePeers
+
 	config     tauConfig.Config
 	devMode    bool
 	webHookUrl string
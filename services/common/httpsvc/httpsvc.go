@@ -2,6 +2,7 @@ package httpsvc
 
 import (
 	"context"
+	"slices"
 	"strings"
 
 	"github.com/taubyte/tau/p2p/peer"
@@ -12,6 +13,7 @@ import (
 	basicHttp "github.com/taubyte/tau/pkg/http/basic"
 	basicHttpSecure "github.com/taubyte/tau/pkg/http/basic/secure"
 	"github.com/taubyte/tau/pkg/http/options"
+	commonSpecs "github.com/taubyte/tau/pkg/specs/common"
 )
 
 // New wires the standard tau HTTP listener: autocert HTTPS in production,
@@ -36,7 +38,9 @@ func devHTTP(ctx context.Context, enableHTTPS bool, ops ...options.Option) (serv
 // AutoOptsFromConfig translates a tau config into the option set
 // pkg/http-auto expects: listen addr, client-node, the two domain predicates
 // (auto-trust for service+alias domains, skip-proof for generated subdomains),
-// custom ACME directory + CA trust knobs, and debug.
+// DNS-01 wildcards through seer, custom ACME directory + CA trust knobs, and
+// debug. Only nodes running auth order wildcards: the others find them in the
+// certificate store auth keeps.
 func AutoOptsFromConfig(cfg config.Config) []options.Option {
 	ops := []options.Option{
 		options.Listen(cfg.HttpListen()),
@@ -44,6 +48,9 @@ func AutoOptsFromConfig(cfg config.Config) []options.Option {
 		autoOpts.AutoTrustDomain(autoTrustFromConfig(cfg)),
 		autoOpts.SkipDomainProof(cfg.GeneratedDomainMatch),
 	}
+	if slices.Contains(cfg.Services(), commonSpecs.Auth) {
+		ops = append(ops, autoOpts.DNS01(cfg.NetworkFqdn(), wildcardsFromConfig(cfg)))
+	}
 	if cfg.CustomAcme() {
 		ops = append(ops, options.ACMEWithKey(cfg.AcmeUrl(), cfg.AcmeKey()))
 	}
@@ -75,3 +82,14 @@ func autoTrustFromConfig(cfg config.Config) func(string) bool {
 		return cfg.ServicesDomainMatch(host)
 	}
 }
+
+// wildcardsFromConfig keeps a wildcard certificate for the generated domain,
+// so generated subdomains don't each need one.
+func wildcardsFromConfig(cfg config.Config) func() []string {
+	return func() []string {
+		if generated := strings.Trim(cfg.GeneratedDomain(), "."); len(generated) > 0 {
+			return []string{generated}
+		}
+		return nil
+	}
+}
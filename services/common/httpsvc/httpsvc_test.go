@@ -17,6 +17,7 @@ type optSink struct {
 	clientNodeSet    bool
 	autoTrustSet     bool
 	skipProofSet     bool
+	dns01            *autoOpts.OptionDNS01
 	debugSet         bool
 	acmeURL          string
 	acmeCASkipVerify bool
@@ -33,6 +34,8 @@ func (s *optSink) SetOption(o any) error {
 		s.autoTrustSet = true
 	case autoOpts.OptionSkipDomainProof:
 		s.skipProofSet = true
+	case autoOpts.OptionDNS01:
+		s.dns01 = &v
 	case options.OptionDebug:
 		s.debugSet = true
 	case options.OptionACME:
@@ -77,6 +80,21 @@ func TestAutoOptsFromConfig(t *testing.T) {
 	assert.Assert(t, sink.skipProofSet)
 	assert.Assert(t, sink.debugSet)
 	assert.Equal(t, sink.acmeURL, "https://acme.example/directory")
+	assert.Assert(t, sink.dns01 == nil)
+}
+
+func TestWildcardsFromConfig(t *testing.T) {
+	cfg := testCfg(t,
+		config.WithServices([]string{"auth"}),
+		config.WithNetworkFqdn("net.example.com"),
+		config.WithGeneratedDomain("g.example.com"),
+	)
+	sink := &optSink{}
+	for _, op := range AutoOptsFromConfig(cfg) {
+		assert.NilError(t, op(sink))
+	}
+	assert.Equal(t, sink.dns01.Zone, "net.example.com")
+	assert.DeepEqual(t, sink.dns01.Wildcards(), []string{"g.example.com"})
 }
 
 func TestAutoTrustFromConfig(t *testing.T) {
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taubyte/tau/core/services/seer"
+)
+
+// ServicePeersRefresh is how long the peers of a service are kept before seer
+// is asked for them again.
+var ServicePeersRefresh = 10 * time.Second
+
+// ServicePeers tells whether a peer announced running a service to seer, so
+// stream and pubsub handlers can take messages from that service only.
+type ServicePeers struct {
+	usage   seer.Usage
+	service seer.ServiceType
+
+	lock    sync.Mutex
+	ids     map[string]struct{}
+	fetched time.Time
+}
+
+func NewServicePeers(usage seer.Usage, service seer.ServiceType) *ServicePeers {
+	return &ServicePeers{usage: usage, service: service}
+}
+
+// Has reports whether pid runs the service. Failing to reach seer keeps the
+// peers known so far.
+func (p *ServicePeers) Has(pid peer.ID) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if time.Since(p.fetched) >= ServicePeersRefresh {
+		p.fetched = time.Now()
+		if ids, err := p.usage.ListServiceId(string(p.service)); err == nil {
+			p.ids = make(map[string]struct{}, len(ids))
+			for _, id := range ids {
+				p.ids[id] = struct{}{}
+			}
+		}
+	}
+
+	_, ok := p.ids[pid.String()]
+	return ok
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"maps"
 	"os"
 
 	"github.com/libp2p/go-libp2p/core/crypto"
@@ -10,6 +11,12 @@ import (
 	"github.com/taubyte/tau/pkg/config"
 )
 
+// Meta keys of the addresses a node announces for its services.
+const (
+	IPMetaKey  = "IP"
+	IP6MetaKey = "IP6"
+)
+
 // StartSeerBeacon announces the given service types from this node through a
 // single seer client and one usage/geo beacon goroutine set. A node running
 // several services (a shape) beacons once for all of them, instead of opening a
@@ -29,13 +36,8 @@ func StartSeerBeacon(cfg config.Config, sc seer.Client, serviceTypes []seer.Serv
 	if len(announce) == 0 {
 		return fmt.Errorf("p2p announce is empty")
 	}
-	ma, err := multiaddr.NewMultiaddr(announce[0])
-	if err != nil {
-		return err
-	}
-
-	// Report Ip address as well
-	addr, err := ma.ValueForProtocol(multiaddr.P_IP4)
+	// Report Ip addresses as well
+	meta, err := announcedAddrs(announce)
 	if err != nil {
 		return err
 	}
@@ -44,6 +46,7 @@ func StartSeerBeacon(cfg config.Config, sc seer.Client, serviceTypes []seer.Serv
 	if cluster == "" {
 		cluster = "main"
 	}
+	meta["cluster"] = cluster
 
 	hostname, err := os.Hostname()
 	if err != nil {
@@ -71,9 +74,39 @@ func StartSeerBeacon(cfg config.Config, sc seer.Client, serviceTypes []seer.Serv
 
 	// Announce every service type this node runs under one usage beacon.
 	for _, serviceType := range serviceTypes {
-		sc.Usage().AddService(serviceType, map[string]string{"IP": addr, "cluster": cluster})
+		sc.Usage().AddService(serviceType, maps.Clone(meta))
 	}
 	sc.Usage().Beacon(hostname, nodeId, clientNodeId, signature).Start()
 
 	return nil
 }
+
+// announcedAddrs returns the meta reporting the first IPv4 and IPv6 addresses
+// of the announced multiaddrs, under IPMetaKey and IP6MetaKey.
+func announcedAddrs(announce []string) (map[string]string, error) {
+	meta := make(map[string]string, 3)
+	for _, addr := range announce {
+		ma, err := multiaddr.NewMultiaddr(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := meta[IPMetaKey]; !ok {
+			if ip, err := ma.ValueForProtocol(multiaddr.P_IP4); err == nil {
+				meta[IPMetaKey] = ip
+			}
+		}
+
+		if _, ok := meta[IP6MetaKey]; !ok {
+			if ip, err := ma.ValueForProtocol(multiaddr.P_IP6); err == nil {
+				meta[IP6MetaKey] = ip
+			}
+		}
+	}
+
+	if len(meta) == 0 {
+		return nil, fmt.Errorf("no ip address in p2p announce %v", announce)
+	}
+
+	return meta, nil
+}
@@ -3,16 +3,24 @@ package gateway
 import (
 	"errors"
 	"fmt"
+	"net"
 	"sort"
 
 	goHttp "net/http"
 
+	peerCore "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taubyte/tau/clients/p2p/substrate"
 	"github.com/taubyte/tau/p2p/streams/client"
 	tunnel "github.com/taubyte/tau/p2p/streams/tunnels/http"
 	http "github.com/taubyte/tau/pkg/http"
+	spec "github.com/taubyte/tau/pkg/specs/common"
 	functionSpec "github.com/taubyte/tau/pkg/specs/function"
 	websiteSpec "github.com/taubyte/tau/pkg/specs/website"
+	"github.com/taubyte/tau/pkg/tracing"
 	"github.com/taubyte/tau/services/substrate/components/metrics"
+	"github.com/taubyte/tau/utils/maps"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func (g *Gateway) attach() {
@@ -36,10 +44,105 @@ func (wr wrappedResponse) Decode(data interface{}) (err error) {
 	}
 }
 
-func (g *Gateway) handleHttp(w goHttp.ResponseWriter, r *goHttp.Request) error {
-	resCh, err := g.substrateClient.ProxyHTTP(r.Host, r.URL.Path, r.Method)
+func (g *Gateway) handleHttp(w goHttp.ResponseWriter, r *goHttp.Request) (err error) {
+	ctx, span := tracing.Start(tracing.ExtractHeader(r.Context(), r.Header), "gateway.http",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("http.host", r.Host),
+			attribute.String("http.method", r.Method),
+			attribute.String("http.path", r.URL.Path),
+		),
+	)
+	defer func() { tracing.End(span, err) }()
+	r = r.WithContext(ctx)
+
+	body := wrapBody(r)
+
+	// Substrates asked about the request and the one serving it must assign it
+	// the same commit, when its project splits traffic.
+	if spec.TrafficKey(r) == "" {
+		r.Header.Set(spec.TrafficHeader, spec.NewTrafficKey())
+	}
+
+	// Substrates rate limit clients by the last address added here.
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		r.Header.Add("X-Forwarded-For", host)
+	}
+
+	if key, peers := g.routes.get(r.Host, r.Method, r.URL.Path); len(peers) > 0 {
+		if done, err := g.handleCached(w, r, peers, body); done {
+			return err
+		}
+
+		g.routes.forget(r.Host, key)
+	}
+
+	return g.handleFanOut(w, r, body)
+}
+
+// handleCached tries the cached candidates of a route in turn. It reports
+// whether the request was handled; if not, every candidate failed before
+// taking the request and the caller may fan out.
+func (g *Gateway) handleCached(w goHttp.ResponseWriter, r *goHttp.Request, peers []peerCore.ID, body *replayBody) (bool, error) {
+	for _, pid := range g.health.order(peers) {
+		pick, err := g.dial(r, pid)
+		if err != nil {
+			g.health.fail(pid)
+			logger.Debug(err)
+			continue
+		}
+
+		started, err := g.tunnel(w, r, pick)
+		pick.Close()
+		if started || !body.rewind() {
+			return true, err
+		}
+	}
+
+	return false, nil
+}
+
+// dial asks a single substrate to serve the request.
+func (g *Gateway) dial(r *goHttp.Request, pid peerCore.ID) (*client.Response, error) {
+	resCh, err := g.substrateClient.ProxyHTTP(r.Host, r.URL.Path, r.Method, spec.TrafficKey(r), client.To(pid), client.Threshold(1), client.Context(r.Context()))
+	if err != nil {
+		return nil, fmt.Errorf("substrate client proxyHttp failed with: %w", err)
+	}
+
+	response := <-resCh
+	if response == nil {
+		return nil, fmt.Errorf("node `%s` did not respond", pid)
+	}
+
+	if err = response.Error(); err == nil && !matched(response) {
+		err = errors.New("no substrate match found")
+	}
+
+	if err != nil {
+		response.Close()
+		return nil, fmt.Errorf("response from node `%s` failed with: %w", pid, err)
+	}
+
+	return response, nil
+}
+
+func matched(response *client.Response) bool {
+	for _, variable := range []string{websiteSpec.PathVariable.String(), functionSpec.PathVariable.String()} {
+		if _, err := response.Get(variable); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *Gateway) handleFanOut(w goHttp.ResponseWriter, r *goHttp.Request, body *replayBody) error {
+	ctx, span := tracing.Start(r.Context(), "gateway.fanout")
+	resCh, err := g.substrateClient.ProxyHTTP(r.Host, r.URL.Path, r.Method, spec.TrafficKey(r), client.Context(ctx))
 	if err != nil {
-		return fmt.Errorf("substrate client proxyHttp failed with: %w", err)
+		err = fmt.Errorf("substrate client proxyHttp failed with: %w", err)
+		tracing.End(span, err)
+		return err
 	}
 
 	websiteMatches := make([]wrappedResponse, 0)
@@ -68,6 +171,8 @@ func (g *Gateway) handleHttp(w goHttp.ResponseWriter, r *goHttp.Request) error {
 
 		discard = append(discard, response)
 	}
+	span.SetAttributes(attribute.Int("gateway.matches", len(websiteMatches)+len(funcMatches)))
+	span.End()
 	defer func() {
 		for _, res := range discard {
 			res.Close()
@@ -83,20 +188,77 @@ func (g *Gateway) handleHttp(w goHttp.ResponseWriter, r *goHttp.Request) error {
 		return errors.New("no substrate match found")
 	}
 
-	var pick *client.Response
+	matches, website := funcMatches, false
 	if len(websiteMatches) > len(funcMatches) {
-		sort.Slice(websiteMatches, func(i, j int) bool { return websiteMatches[j].metrics.Less(websiteMatches[i].metrics) })
-		pick = websiteMatches[0].Response
-	} else {
-		sort.Slice(funcMatches, func(i, j int) bool { return funcMatches[j].metrics.Less(funcMatches[i].metrics) })
-		pick = funcMatches[0].Response
+		matches, website = websiteMatches, true
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[j].metrics.Less(matches[i].metrics) })
+
+	peers := make([]peerCore.ID, 0, len(matches))
+	responses := make(map[peerCore.ID]*client.Response, len(matches))
+	for _, match := range matches {
+		peers = append(peers, match.PID())
+		responses[match.PID()] = match.Response
+	}
+
+	best := matches[0].Response
+	project, _ := maps.String(best.Response, substrate.ResponseProject)
+	branch, _ := maps.String(best.Response, substrate.ResponseBranch)
+	route, _ := maps.String(best.Response, substrate.ResponseRoute)
+	if len(project) > 0 && len(branch) > 0 && len(route) > 0 {
+		g.routes.put(r.Host, r.Method, route, website, project, branch, peers)
+	}
+
+	var lastErr error
+	order := g.health.order(peers)
+	for i, pid := range order {
+		// The last candidate's body won't be replayed.
+		if i == len(order)-1 {
+			body.drop()
+		}
+
+		started, err := g.tunnel(w, r, responses[pid])
+		if started || !body.rewind() {
+			return err
+		}
+
+		lastErr = err
+	}
+
+	return lastErr
+}
+
+// tunnel proxies the request to a substrate that accepted it. It reports
+// whether a response was started: if not, the request may go to another.
+func (g *Gateway) tunnel(w goHttp.ResponseWriter, r *goHttp.Request, pick *client.Response) (bool, error) {
+	w.Header().Set(ProxyHeader, pick.PID().String())
+
+	// The substrate serves the request as part of this span.
+	ctx, span := tracing.Start(r.Context(), "gateway.tunnel",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("p2p.peer", pick.PID().String())),
+	)
+	tracing.InjectHeader(ctx, r.Header)
+
+	sw := &startedWriter{ResponseWriter: w}
+	err := tunnel.Frontend(sw, r, pick)
+	if err == nil && !sw.started {
+		err = errors.New("tunnel closed before a response")
 	}
+	tracing.End(span, err)
 
-	w.Header().Add(ProxyHeader, pick.PID().String())
+	if err != nil {
+		g.health.fail(pick.PID())
+		err = fmt.Errorf("tunneling Frontend to `%s` failed with: %w", pick.PID(), err)
+		if !sw.started {
+			logger.Debug(err)
+		}
 
-	if err := tunnel.Frontend(w, r, pick); err != nil {
-		return fmt.Errorf("tunneling Frontend failed with: %w", err)
+		return sw.started, err
 	}
 
-	return nil
+	g.health.succeed(pick.PID())
+
+	return true, nil
 }
@@ -0,0 +1,76 @@
+package gateway
+
+import (
+	"sync"
+	"time"
+
+	peerCore "github.com/libp2p/go-libp2p/core/peer"
+)
+
+type peerState struct {
+	failures int
+	ejected  time.Time
+}
+
+// peerHealth ejects substrates after EjectThreshold failures in a row. An
+// ejected peer is tried last until EjectDuration is over, then a single
+// failure ejects it again; a success clears it.
+type peerHealth struct {
+	lock  sync.Mutex
+	peers map[peerCore.ID]*peerState
+}
+
+func newPeerHealth() *peerHealth {
+	return &peerHealth{peers: make(map[peerCore.ID]*peerState)}
+}
+
+func (h *peerHealth) fail(pid peerCore.ID) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	state, ok := h.peers[pid]
+	if !ok {
+		state = new(peerState)
+		h.peers[pid] = state
+	}
+
+	state.failures++
+	if state.failures >= EjectThreshold {
+		state.ejected = time.Now().Add(EjectDuration)
+		logger.Debugf("ejected `%s` after %d failures", pid, state.failures)
+	}
+}
+
+func (h *peerHealth) succeed(pid peerCore.ID) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.peers, pid)
+}
+
+func (h *peerHealth) ejected(pid peerCore.ID, now time.Time) bool {
+	state, ok := h.peers[pid]
+	return ok && now.Before(state.ejected)
+}
+
+// order moves ejected peers behind the others, keeping the order otherwise.
+func (h *peerHealth) order(peers []peerCore.ID) []peerCore.ID {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	now := time.Now()
+	ordered := make([]peerCore.ID, 0, len(peers))
+	for _, pid := range peers {
+		if !h.ejected(pid, now) {
+			ordered = append(ordered, pid)
+		}
+	}
+
+	for _, pid := range peers {
+		if h.ejected(pid, now) {
+			ordered = append(ordered, pid)
+		}
+	}
+
+	return ordered
+}
@@ -0,0 +1,46 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	peerCore "github.com/libp2p/go-libp2p/core/peer"
+	"gotest.tools/v3/assert"
+)
+
+func TestPeerHealth(t *testing.T) {
+	h := newPeerHealth()
+	peers := []peerCore.ID{"a", "b", "c"}
+
+	for range EjectThreshold - 1 {
+		h.fail("a")
+	}
+	assert.DeepEqual(t, h.order(peers), peers)
+
+	h.fail("a")
+	assert.DeepEqual(t, h.order(peers), []peerCore.ID{"b", "c", "a"})
+
+	h.succeed("a")
+	assert.DeepEqual(t, h.order(peers), peers)
+}
+
+func TestPeerHealthProbation(t *testing.T) {
+	duration := EjectDuration
+	EjectDuration = 10 * time.Millisecond
+	defer func() { EjectDuration = duration }()
+
+	h := newPeerHealth()
+	peers := []peerCore.ID{"a", "b"}
+
+	for range EjectThreshold {
+		h.fail("a")
+	}
+	assert.DeepEqual(t, h.order(peers), []peerCore.ID{"b", "a"})
+
+	time.Sleep(20 * time.Millisecond)
+	assert.DeepEqual(t, h.order(peers), peers)
+
+	// Failing again once back is enough to be ejected.
+	h.fail("a")
+	assert.DeepEqual(t, h.order(peers), []peerCore.ID{"b", "a"})
+}
@@ -50,6 +50,12 @@ func New(ctx context.Context, cfg tauConfig.Config) (gateway iface.Service, err
 		return nil, fmt.Errorf("new streams client failed with: %w", err)
 	}
 
+	if g.routes, err = newRouteCache(ctx, g.node); err != nil {
+		return nil, fmt.Errorf("new route cache failed with: %w", err)
+	}
+
+	g.health = newPeerHealth()
+
 	g.attach()
 	return g, nil
 }
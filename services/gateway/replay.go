@@ -0,0 +1,98 @@
+package gateway
+
+import (
+	"bytes"
+	"io"
+	goHttp "net/http"
+)
+
+// replayBody records what substrates read of a request body, up to
+// RetryBodyLimit, so a request a substrate failed before answering can be sent
+// to another one. Nothing is read ahead: bodies are only kept while another
+// attempt may follow.
+type replayBody struct {
+	io.ReadCloser
+	sent     []byte
+	replay   *bytes.Reader
+	overflow bool
+}
+
+// wrapBody puts a replayBody in front of the request body, if there is one.
+func wrapBody(r *goHttp.Request) *replayBody {
+	if r.Body == nil || r.Body == goHttp.NoBody {
+		return nil
+	}
+
+	b := &replayBody{ReadCloser: r.Body}
+	r.Body = b
+
+	return b
+}
+
+func (b *replayBody) Read(p []byte) (int, error) {
+	if b.replay != nil && b.replay.Len() > 0 {
+		return b.replay.Read(p)
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	if !b.overflow {
+		if int64(len(b.sent)+n) > RetryBodyLimit {
+			b.drop()
+		} else {
+			b.sent = append(b.sent, p[:n]...)
+		}
+	}
+
+	return n, err
+}
+
+// Close leaves the client's body open for the next attempt; the server closes
+// it once the request is done.
+func (b *replayBody) Close() error {
+	return nil
+}
+
+// drop stops recording, as no other attempt will follow. A rewound body still
+// replays what was read before.
+func (b *replayBody) drop() {
+	if b != nil {
+		b.overflow = true
+		b.sent = nil
+	}
+}
+
+// rewind restarts the body from its first byte. It reports whether it could:
+// not once more than RetryBodyLimit was read, or recording was dropped.
+func (b *replayBody) rewind() bool {
+	if b == nil {
+		return true
+	}
+
+	if b.overflow {
+		return false
+	}
+
+	b.replay = bytes.NewReader(b.sent)
+	return true
+}
+
+// startedWriter records whether a response was started, past which a request
+// can't be retried.
+type startedWriter struct {
+	goHttp.ResponseWriter
+	started bool
+}
+
+func (w *startedWriter) WriteHeader(code int) {
+	w.started = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *startedWriter) Write(p []byte) (int, error) {
+	w.started = true
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *startedWriter) Unwrap() goHttp.ResponseWriter {
+	return w.ResponseWriter
+}
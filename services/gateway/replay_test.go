@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestReplayBody(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader("hello"))
+	body := wrapBody(r)
+
+	for range 2 {
+		data, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		assert.Equal(t, string(data), "hello")
+		assert.NilError(t, r.Body.Close())
+		assert.Assert(t, body.rewind())
+	}
+}
+
+func TestReplayBodyPartlyRead(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader("hello world"))
+	body := wrapBody(r)
+
+	buf := make([]byte, 5)
+	_, err := io.ReadFull(r.Body, buf)
+	assert.NilError(t, err)
+	assert.Assert(t, body.rewind())
+
+	// The last attempt still gets what the failed one read.
+	body.drop()
+	data, err := io.ReadAll(r.Body)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "hello world")
+	assert.Assert(t, !body.rewind())
+}
+
+func TestReplayBodyTooLarge(t *testing.T) {
+	limit := RetryBodyLimit
+	RetryBodyLimit = 4
+	defer func() { RetryBodyLimit = limit }()
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader("hello world"))
+	body := wrapBody(r)
+
+	data, err := io.ReadAll(r.Body)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "hello world")
+	assert.Assert(t, !body.rewind())
+}
+
+func TestReplayBodyNone(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	body := wrapBody(r)
+
+	assert.Assert(t, body == nil)
+	assert.Assert(t, body.rewind())
+}
+
+func TestStartedWriter(t *testing.T) {
+	w := &startedWriter{ResponseWriter: httptest.NewRecorder()}
+	w.Header().Set("X", "y")
+	assert.Assert(t, !w.started)
+
+	w.WriteHeader(200)
+	assert.Assert(t, w.started)
+}
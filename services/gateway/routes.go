@@ -0,0 +1,290 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	peerCore "github.com/libp2p/go-libp2p/core/peer"
+	tnsCommon "github.com/taubyte/tau/clients/p2p/tns/common"
+	"github.com/taubyte/tau/p2p/peer"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+)
+
+// assetsTopic is where every asset update, a code deploy of any project, is
+// announced: asset keys are too short to get a topic of their own.
+var assetsTopic = tnsCommon.GetChannelFor("assets", "")
+
+type route struct {
+	host   string
+	key    string
+	method string
+	// path is the function path or the website path prefix served.
+	path   string
+	prefix bool
+	// peers is ordered best first.
+	peers   []peerCore.ID
+	topic   string
+	expires time.Time
+}
+
+// matches reports whether the route serves path.
+func (r *route) matches(path string) bool {
+	if !r.prefix || path == r.path {
+		return path == r.path
+	}
+
+	return strings.HasPrefix(path, strings.TrimSuffix(r.path, "/")+"/")
+}
+
+// routeCache remembers, for up to RouteCacheSize routes, the substrates that
+// can serve a function or website of a host for RouteCacheTTL, so requests
+// skip the fan out. Routes of a project are dropped when tns announces a
+// deploy of it.
+type routeCache struct {
+	ctx  context.Context
+	node peer.Node
+
+	lock sync.Mutex
+	// hosts maps a host to its routes by routeKey.
+	hosts  map[string]map[string]*route
+	size   int
+	topics map[string]context.CancelFunc
+}
+
+func newRouteCache(ctx context.Context, node peer.Node) (*routeCache, error) {
+	c := &routeCache{
+		ctx:    ctx,
+		node:   node,
+		hosts:  make(map[string]map[string]*route),
+		topics: make(map[string]context.CancelFunc),
+	}
+
+	if err := node.PubSubSubscribeContext(ctx, assetsTopic, func(*pubsub.Message) {
+		c.flush()
+	}, func(err error) {
+		logger.Errorf("watching `%s` failed with: %s", assetsTopic, err)
+	}); err != nil {
+		return nil, err
+	}
+
+	go c.sweep()
+
+	return c, nil
+}
+
+func routeKey(method, path string, prefix bool) string {
+	if prefix {
+		return method + " " + path + "*"
+	}
+
+	return method + " " + path
+}
+
+// get returns the key and candidates of the route serving a request, or no
+// candidates if none is cached. A function path is preferred to a website
+// prefix, and a longer prefix to a shorter one.
+func (c *routeCache) get(host, method, path string) (string, []peerCore.ID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var best *route
+	if r, ok := c.hosts[host][routeKey(method, path, false)]; ok {
+		best = r
+	} else {
+		for _, r := range c.hosts[host] {
+			if r.prefix && r.method == method && r.matches(path) && (best == nil || len(r.path) > len(best.path)) {
+				best = r
+			}
+		}
+	}
+
+	if best == nil {
+		return "", nil
+	}
+
+	if time.Now().After(best.expires) {
+		c.drop(best)
+		return "", nil
+	}
+
+	return best.key, best.peers
+}
+
+// put caches the candidates of a route served by the given project branch.
+// When the cache is full, the route closest to expiring makes room.
+func (c *routeCache) put(host, method, path string, prefix bool, project, branch string, peers []peerCore.ID) {
+	if len(peers) == 0 || RouteCacheSize < 1 {
+		return
+	}
+
+	topic := tnsCommon.GetChannelFor(spec.Current(project, branch).Slice()...)
+	key := routeKey(method, path, prefix)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	routes, ok := c.hosts[host]
+	if !ok {
+		routes = make(map[string]*route)
+		c.hosts[host] = routes
+	}
+
+	if _, ok := routes[key]; !ok {
+		if c.size >= RouteCacheSize {
+			c.evict()
+		}
+		c.size++
+	}
+
+	r := &route{
+		host:    host,
+		key:     key,
+		method:  method,
+		path:    path,
+		prefix:  prefix,
+		peers:   peers,
+		topic:   topic,
+		expires: time.Now().Add(RouteCacheTTL),
+	}
+	routes[key] = r
+
+	if _, ok := c.topics[topic]; ok {
+		return
+	}
+
+	ctx, ctxC := context.WithCancel(c.ctx)
+	if err := c.node.PubSubSubscribeContext(ctx, topic, func(*pubsub.Message) {
+		c.invalidate(topic)
+	}, func(err error) {
+		logger.Errorf("watching `%s` failed with: %s", topic, err)
+	}); err != nil {
+		ctxC()
+		// Without a way to learn about deploys the route can't be trusted.
+		c.drop(r)
+		logger.Errorf("subscribing to `%s` failed with: %s", topic, err)
+		return
+	}
+
+	c.topics[topic] = ctxC
+}
+
+// forget drops a route whose candidates all failed.
+func (c *routeCache) forget(host, key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if r, ok := c.hosts[host][key]; ok {
+		c.drop(r)
+	}
+}
+
+// evict drops the route closest to expiring. Callers hold the lock.
+func (c *routeCache) evict() {
+	var oldest *route
+	c.each(func(r *route) {
+		if oldest == nil || r.expires.Before(oldest.expires) {
+			oldest = r
+		}
+	})
+
+	if oldest != nil {
+		c.drop(oldest)
+	}
+}
+
+// each calls fn on every route. Callers hold the lock; fn may drop the route
+// it is given.
+func (c *routeCache) each(fn func(*route)) {
+	for _, routes := range c.hosts {
+		for _, r := range routes {
+			fn(r)
+		}
+	}
+}
+
+// invalidate drops the routes of a deployed project branch.
+func (c *routeCache) invalidate(topic string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.each(func(r *route) {
+		if r.topic == topic {
+			c.remove(r)
+		}
+	})
+
+	if ctxC, ok := c.topics[topic]; ok {
+		ctxC()
+		delete(c.topics, topic)
+	}
+}
+
+func (c *routeCache) flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for topic, ctxC := range c.topics {
+		ctxC()
+		delete(c.topics, topic)
+	}
+
+	clear(c.hosts)
+	c.size = 0
+}
+
+// remove deletes a route. Callers hold the lock.
+func (c *routeCache) remove(r *route) {
+	routes, ok := c.hosts[r.host]
+	if !ok || routes[r.key] != r {
+		return
+	}
+
+	delete(routes, r.key)
+	if len(routes) == 0 {
+		delete(c.hosts, r.host)
+	}
+	c.size--
+}
+
+// drop deletes a route, and the subscription no other route needs. Callers
+// hold the lock.
+func (c *routeCache) drop(r *route) {
+	c.remove(r)
+
+	needed := false
+	c.each(func(other *route) {
+		needed = needed || other.topic == r.topic
+	})
+	if needed {
+		return
+	}
+
+	if ctxC, ok := c.topics[r.topic]; ok {
+		ctxC()
+		delete(c.topics, r.topic)
+	}
+}
+
+// sweep drops expired routes that are no longer requested.
+func (c *routeCache) sweep() {
+	ticker := time.NewTicker(RouteCacheTTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case now := <-ticker.C:
+			c.lock.Lock()
+			c.each(func(r *route) {
+				if now.After(r.expires) {
+					c.drop(r)
+				}
+			})
+			c.lock.Unlock()
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	peerCore "github.com/libp2p/go-libp2p/core/peer"
+	tnsCommon "github.com/taubyte/tau/clients/p2p/tns/common"
+	"github.com/taubyte/tau/p2p/peer"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	"gotest.tools/v3/assert"
+)
+
+func newTestRouteCache(t *testing.T) (*routeCache, peer.Node) {
+	ctx, ctxC := context.WithCancel(context.Background())
+	t.Cleanup(ctxC)
+
+	node := peer.Mock(ctx)
+	t.Cleanup(func() { node.Close() })
+
+	c, err := newRouteCache(ctx, node)
+	assert.NilError(t, err)
+
+	return c, node
+}
+
+func TestRouteCache(t *testing.T) {
+	c, _ := newTestRouteCache(t)
+	peers := []peerCore.ID{"a", "b"}
+
+	_, got := c.get("hal.computers.com", "GET", "/ping")
+	assert.Assert(t, got == nil)
+
+	c.put("hal.computers.com", "GET", "/ping", false, "project", "main", peers)
+	key, got := c.get("hal.computers.com", "GET", "/ping")
+	assert.DeepEqual(t, got, peers)
+	assert.Equal(t, len(c.topics), 1)
+
+	_, got = c.get("hal.computers.com", "POST", "/ping")
+	assert.Assert(t, got == nil)
+	_, got = c.get("hal.computers.com", "GET", "/ping/more")
+	assert.Assert(t, got == nil)
+
+	c.forget("hal.computers.com", key)
+	_, got = c.get("hal.computers.com", "GET", "/ping")
+	assert.Assert(t, got == nil)
+	assert.Equal(t, len(c.topics), 0)
+	assert.Equal(t, c.size, 0)
+}
+
+func TestRouteCacheWebsitePrefix(t *testing.T) {
+	c, _ := newTestRouteCache(t)
+
+	c.put("hal.computers.com", "GET", "/", true, "project", "main", []peerCore.ID{"root"})
+	c.put("hal.computers.com", "GET", "/docs", true, "project", "main", []peerCore.ID{"docs"})
+	c.put("hal.computers.com", "GET", "/docs/api", false, "project", "main", []peerCore.ID{"api"})
+
+	for path, want := range map[string]peerCore.ID{
+		"/":            "root",
+		"/index.html":  "root",
+		"/docs":        "docs",
+		"/docs/a.html": "docs",
+		"/docsx":       "root",
+		"/docs/api":    "api",
+	} {
+		_, got := c.get("hal.computers.com", "GET", path)
+		assert.DeepEqual(t, got, []peerCore.ID{want})
+	}
+
+	_, got := c.get("other.computers.com", "GET", "/")
+	assert.Assert(t, got == nil)
+	assert.Equal(t, c.size, 3)
+}
+
+func TestRouteCacheSize(t *testing.T) {
+	size := RouteCacheSize
+	RouteCacheSize = 2
+	defer func() { RouteCacheSize = size }()
+
+	c, _ := newTestRouteCache(t)
+
+	c.put("hal.computers.com", "GET", "/a", false, "project", "main", []peerCore.ID{"a"})
+	c.put("hal.computers.com", "GET", "/b", false, "project", "main", []peerCore.ID{"b"})
+	c.put("hal.computers.com", "GET", "/b", false, "project", "main", []peerCore.ID{"b"})
+	assert.Equal(t, c.size, 2)
+
+	c.put("other.computers.com", "GET", "/c", false, "other", "main", []peerCore.ID{"c"})
+	assert.Equal(t, c.size, 2)
+
+	_, got := c.get("hal.computers.com", "GET", "/a")
+	assert.Assert(t, got == nil)
+	_, got = c.get("hal.computers.com", "GET", "/b")
+	assert.Assert(t, got != nil)
+	_, got = c.get("other.computers.com", "GET", "/c")
+	assert.Assert(t, got != nil)
+}
+
+func TestRouteCacheExpires(t *testing.T) {
+	ttl := RouteCacheTTL
+	RouteCacheTTL = 10 * time.Millisecond
+	defer func() { RouteCacheTTL = ttl }()
+
+	c, _ := newTestRouteCache(t)
+
+	c.put("hal.computers.com", "GET", "/ping", false, "project", "main", []peerCore.ID{"a"})
+
+	time.Sleep(20 * time.Millisecond)
+	_, got := c.get("hal.computers.com", "GET", "/ping")
+	assert.Assert(t, got == nil)
+	assert.Equal(t, len(c.topics), 0)
+}
+
+func TestRouteCacheInvalidatedOnDeploy(t *testing.T) {
+	c, node := newTestRouteCache(t)
+
+	c.put("hal.computers.com", "GET", "/ping", false, "project", "main", []peerCore.ID{"a"})
+	c.put("other.computers.com", "GET", "/ping", false, "other", "main", []peerCore.ID{"a"})
+	cached := func(host string) bool {
+		_, got := c.get(host, "GET", "/ping")
+		return got != nil
+	}
+
+	topic := tnsCommon.GetChannelFor(spec.Current("project", "main").Slice()...)
+	publishUntil(t, node, topic, func() bool { return !cached("hal.computers.com") })
+	assert.Assert(t, cached("other.computers.com"))
+
+	// A code deploy of any project drops every route.
+	publishUntil(t, node, assetsTopic, func() bool { return !cached("other.computers.com") })
+}
+
+// publishUntil publishes on a topic until done, as the subscription may not be
+// ready for the first message.
+func publishUntil(t *testing.T, node peer.Node, topic string, done func() bool) {
+	for range 100 {
+		assert.NilError(t, node.PubSubPublish(context.Background(), topic, nil))
+		time.Sleep(20 * time.Millisecond)
+		if done() {
+			return
+		}
+	}
+
+	t.Fatalf("no update on `%s`", topic)
+}
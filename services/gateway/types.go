@@ -16,6 +16,8 @@ type Gateway struct {
 	http http.Service
 
 	substrateClient substrate.ProxyClient
+	routes          *routeCache
+	health          *peerHealth
 
 	cluster string
 	dev     bool
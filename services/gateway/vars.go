@@ -6,4 +6,16 @@ var (
 	ChannelTimeout time.Duration = 100 * time.Millisecond
 	ProxyHeader                  = "X-Substrate-Peer"
 	MaxScore                     = 50
+
+	// RouteCacheTTL is how long the substrates found for a route are reused.
+	RouteCacheTTL = 10 * time.Second
+	// RouteCacheSize is the most routes the gateway remembers.
+	RouteCacheSize = 4096
+	// RetryBodyLimit is the largest request body buffered to retry a request
+	// on another substrate.
+	RetryBodyLimit int64 = 1 << 20
+	// EjectThreshold is the number of failures in a row that ejects a substrate.
+	EjectThreshold = 3
+	// EjectDuration is how long an ejected substrate is tried last.
+	EjectDuration = 30 * time.Second
 )
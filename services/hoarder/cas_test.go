@@ -0,0 +1,65 @@
+package hoarder
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/taubyte/tau/p2p/streams/command"
+	hoarderSpecs "github.com/taubyte/tau/pkg/specs/hoarder"
+	"github.com/taubyte/tau/utils/maps"
+)
+
+func casBody(key string, expected, value []byte) command.Body {
+	return command.Body{
+		hoarderSpecs.BodyKVOp:     hoarderSpecs.KVCas,
+		hoarderSpecs.BodyKey:      key,
+		hoarderSpecs.BodyExpected: expected,
+		hoarderSpecs.BodyValue:    value,
+	}
+}
+
+func TestKVCas_Semantics(t *testing.T) {
+	srv := newTestService(t)
+	ctx := t.Context()
+	hash := "cas-test-instance"
+	handle, err := srv.load(hash)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	swap := func(expected []byte, value string) bool {
+		t.Helper()
+		resp, err := srv.kvCas(ctx, handle, hash, casBody("k", expected, []byte(value)))
+		if err != nil {
+			t.Fatalf("cas failed: %v", err)
+		}
+		swapped, _ := maps.Bool(resp, hoarderSpecs.BodySwapped)
+		return swapped
+	}
+
+	// Nothing expected: only written while absent.
+	if !swap(nil, "first") {
+		t.Fatal("cas of an absent key not written")
+	}
+	if swap(nil, "again") {
+		t.Fatal("cas expecting absence overwrote a present key")
+	}
+
+	stale := sha256.Sum256([]byte("other"))
+	if swap(stale[:], "stale") {
+		t.Fatal("cas with a stale hash was written")
+	}
+
+	current := sha256.Sum256([]byte("first"))
+	if !swap(current[:], "second") {
+		t.Fatal("cas with the current hash not written")
+	}
+
+	got, err := srv.kvGet(ctx, handle, command.Body{hoarderSpecs.BodyKey: "k"})
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if v, _ := maps.ByteArray(got, hoarderSpecs.BodyValue); string(v) != "second" {
+		t.Fatalf("unexpected value %q", v)
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 
 	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
 	spec "github.com/taubyte/tau/pkg/specs/common"
+	messagingSpec "github.com/taubyte/tau/pkg/specs/messaging"
 )
 
 // errNoConfigMatch marks the one DEFINITIVE validateConfig outcome: TNS answered
@@ -81,6 +83,22 @@ func (srv *Service) validateConfig(auction *hoarderIface.Auction) error {
 			}
 		}
 		return fmt.Errorf("no storage config matches `%s`: %w", auction.Meta.Match, errNoConfigMatch)
+	case hoarderIface.Messaging:
+		channel, ok := strings.CutPrefix(auction.Meta.Match, messagingSpec.LogPrefix)
+		if !ok {
+			return fmt.Errorf("`%s` is not a channel log: %w", auction.Meta.Match, errNoConfigMatch)
+		}
+		configs, _, _, err := srv.tnsClient.Messaging().All(auction.Meta.ProjectId, auction.Meta.ApplicationId, branches...).List()
+		if err != nil {
+			return fmt.Errorf("listing messaging for %s failed with: %w", auction.Meta.ProjectId, err)
+		}
+		for id, c := range configs {
+			if c.Persistent && checkMatch(c.Regex, channel, c.Match, c.Name) == nil {
+				auction.Meta.ConfigId = id
+				return nil
+			}
+		}
+		return fmt.Errorf("no persistent messaging config matches `%s`: %w", channel, errNoConfigMatch)
 	}
 	return fmt.Errorf("invalid resource kind %d", auction.MetaType)
 }
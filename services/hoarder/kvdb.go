@@ -1,7 +1,9 @@
 package hoarder
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/taubyte/tau/p2p/streams"
 	"github.com/taubyte/tau/p2p/streams/command"
 	cr "github.com/taubyte/tau/p2p/streams/command/response"
+	kvdbPkg "github.com/taubyte/tau/pkg/kvdb"
 	hoarderSpecs "github.com/taubyte/tau/pkg/specs/hoarder"
 	"github.com/taubyte/tau/utils/maps"
 )
@@ -41,6 +44,8 @@ func (srv *Service) kvdbHandler(ctx context.Context, conn streams.Connection, bo
 		resp, err = srv.kvPut(ctx, handle, hash, body)
 	case hoarderSpecs.KVPutNx:
 		resp, err = srv.kvPutNx(ctx, handle, hash, body)
+	case hoarderSpecs.KVCas:
+		resp, err = srv.kvCas(ctx, handle, hash, body)
 	case hoarderSpecs.KVDelete:
 		resp, err = srv.kvDelete(ctx, handle, hash, body)
 	case hoarderSpecs.KVList:
@@ -253,6 +258,62 @@ func (srv *Service) kvPutNx(ctx context.Context, handle kvdb.KVDB, hash string,
 	return cr.Response{}, nil
 }
 
+// kvCas writes the key only if its current value hashes to the expected one,
+// or if it is absent when none is expected, atomically against concurrent
+// writes on this node. Response carries swapped=true when the value was
+// written.
+func (srv *Service) kvCas(ctx context.Context, handle kvdb.KVDB, hash string, body command.Body) (cr.Response, error) {
+	key, err := maps.String(body, hoarderSpecs.BodyKey)
+	if err != nil {
+		return nil, err
+	}
+	value, err := maps.ByteArray(body, hoarderSpecs.BodyValue)
+	if err != nil {
+		return nil, fmt.Errorf("missing value: %w", err)
+	}
+	expected, _ := maps.ByteArray(body, hoarderSpecs.BodyExpected)
+	if err := srv.admitWrite(maps.TryString(body, hoarderSpecs.BodyProject), len(value)); err != nil {
+		return cr.Response{hoarderSpecs.BodyCode: hoarderSpecs.CodeOverCapacity}, nil
+	}
+	enc, err := srv.cipherEncrypt(value)
+	if err != nil {
+		return nil, fmt.Errorf("encrypting value failed with: %w", err)
+	}
+
+	mu := srv.writeLock(hash)
+	mu.Lock()
+	matches := len(expected) == 0
+	if cur, gerr := handle.Get(ctx, key); gerr == nil && cur != nil {
+		plain, err := srv.cipherDecrypt(cur)
+		if err != nil {
+			mu.Unlock()
+			return nil, fmt.Errorf("decrypting value failed with: %w", err)
+		}
+		sum := sha256.Sum256(plain)
+		matches = bytes.Equal(sum[:], expected)
+	}
+	if !matches {
+		mu.Unlock()
+		return cr.Response{hoarderSpecs.BodySwapped: false}, nil
+	}
+	err = handle.Put(ctx, key, enc)
+	mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("cas failed with: %w", err)
+	}
+
+	// Replicated as a plain put: the co-owner must end up with the value this
+	// node compared and wrote, whatever it holds now.
+	repl := command.Body{}
+	for k, v := range body {
+		repl[k] = v
+	}
+	repl[hoarderSpecs.BodyKVOp] = hoarderSpecs.KVPut
+	delete(repl, hoarderSpecs.BodyExpected)
+	srv.replicateWrite(ctx, hash, repl)
+	return cr.Response{hoarderSpecs.BodySwapped: true}, nil
+}
+
 func (srv *Service) kvDelete(ctx context.Context, handle kvdb.KVDB, hash string, body command.Body) (cr.Response, error) {
 	key, err := maps.String(body, hoarderSpecs.BodyKey)
 	if err != nil {
@@ -371,9 +432,20 @@ func (srv *Service) replicateWrite(ctx context.Context, hash string, body comman
 	logger.Warnf("write to %s acked under-replicated: %s", hash, reason)
 }
 
+// kvList lists the keys with a prefix. With a limit, keys are returned in
+// order, at most limit of them, starting after the cursor key.
 func (srv *Service) kvList(ctx context.Context, handle kvdb.KVDB, body command.Body) (cr.Response, error) {
 	prefix := maps.TryString(body, hoarderSpecs.BodyPrefix)
-	keys, err := handle.List(ctx, prefix)
+
+	var (
+		keys []string
+		err  error
+	)
+	if limit, _ := maps.Int(body, hoarderSpecs.BodyLimit); limit > 0 {
+		keys, err = kvdbPkg.Scan(ctx, handle, prefix, maps.TryString(body, hoarderSpecs.BodyCursor), limit)
+	} else {
+		keys, err = handle.List(ctx, prefix)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("list failed with: %w", err)
 	}
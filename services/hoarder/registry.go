@@ -185,6 +185,28 @@ func (srv *Service) listStashClaims(ctx context.Context, cid string) ([]string,
 	return lastSegmentEach(keys), nil
 }
 
+// addStashOwner records that a storage instance references the stashed cid.
+// No-op if already recorded.
+func (srv *Service) addStashOwner(ctx context.Context, cid, owner string) error {
+	key := hoarderSpecs.StashOwnerKey(cid, owner)
+	if cur, _ := srv.db.Get(ctx, key); cur != nil {
+		return nil
+	}
+	b, err := cbor.Marshal(&Claim{Since: time.Now().Unix()})
+	if err != nil {
+		return fmt.Errorf("marshal stash owner failed with: %w", err)
+	}
+	return srv.db.Put(ctx, key, b)
+}
+
+func (srv *Service) listStashOwners(ctx context.Context, cid string) ([]string, error) {
+	keys, err := srv.db.List(ctx, hoarderSpecs.StashOwnersPathOf(cid))
+	if err != nil {
+		return nil, err
+	}
+	return lastSegmentEach(keys), nil
+}
+
 // listStashCids returns every CID with a stash placement record.
 func (srv *Service) listStashCids(ctx context.Context) ([]string, error) {
 	keys, err := srv.db.List(ctx, hoarderSpecs.StashMetaPrefix)
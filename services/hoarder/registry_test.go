@@ -9,6 +9,7 @@ import (
 
 	peerCore "github.com/libp2p/go-libp2p/core/peer"
 	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
+	seerIface "github.com/taubyte/tau/core/services/seer"
 	"github.com/taubyte/tau/p2p/peer"
 	streamClient "github.com/taubyte/tau/p2p/streams/client"
 	"github.com/taubyte/tau/p2p/streams/command"
@@ -36,7 +37,9 @@ func newTestService(t *testing.T) *Service {
 		ldr:       newLoader(),
 		members:   make(map[string]*member),
 		// A fixed 32-byte key so the suite is build-tag agnostic across cipher seams.
-		atRestKey: bytes.Repeat([]byte{0x2a}, 32),
+		atRestKey:      bytes.Repeat([]byte{0x2a}, 32),
+		substratePeers: protocolCommon.NewServicePeers(testUsage{}, seerIface.ServiceTypeSubstrate),
+		hoarderPeers:   protocolCommon.NewServicePeers(testUsage{}, seerIface.ServiceTypeHoarder),
 	}
 }
 
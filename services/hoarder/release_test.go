@@ -0,0 +1,187 @@
+package hoarder
+
+import (
+	"bytes"
+	"testing"
+
+	peerCore "github.com/libp2p/go-libp2p/core/peer"
+	seerIface "github.com/taubyte/tau/core/services/seer"
+	"github.com/taubyte/tau/p2p/streams"
+	"github.com/taubyte/tau/p2p/streams/command"
+	"github.com/taubyte/tau/p2p/streams/command/response"
+	hoarderSpecs "github.com/taubyte/tau/pkg/specs/hoarder"
+	protocolCommon "github.com/taubyte/tau/services/common"
+)
+
+// testUsage lists the peers seer knows per service type.
+type testUsage struct {
+	seerIface.Usage
+	ids map[seerIface.ServiceType][]string
+}
+
+func (u testUsage) ListServiceId(name string) ([]string, error) {
+	return u.ids[seerIface.ServiceType(name)], nil
+}
+
+type testConn struct {
+	streams.Connection
+	pid peerCore.ID
+}
+
+func (c testConn) RemotePeer() peerCore.ID {
+	return c.pid
+}
+
+const (
+	testSubstratePeer = peerCore.ID("substrate-peer")
+	testHoarderPeer   = peerCore.ID("hoarder-peer")
+)
+
+// withPeers makes testSubstratePeer and testHoarderPeer known to seer.
+func withPeers(srv *Service) {
+	usage := testUsage{ids: map[seerIface.ServiceType][]string{
+		seerIface.ServiceTypeSubstrate: {testSubstratePeer.String()},
+		seerIface.ServiceTypeHoarder:   {testHoarderPeer.String()},
+	}}
+	srv.substratePeers = protocolCommon.NewServicePeers(usage, seerIface.ServiceTypeSubstrate)
+	srv.hoarderPeers = protocolCommon.NewServicePeers(usage, seerIface.ServiceTypeHoarder)
+}
+
+func releaseBody(cid, owner string) command.Body {
+	return command.Body{
+		hoarderSpecs.BodyAction: hoarderSpecs.ActionRelease,
+		hoarderSpecs.BodyCid:    cid,
+		hoarderSpecs.BodyOwner:  owner,
+		hoarderSpecs.BodyFanout: true,
+	}
+}
+
+func TestRelease_LastOwnerUnstashes(t *testing.T) {
+	srv := newTestService(t)
+	withPeers(srv)
+	ctx := t.Context()
+	from := testConn{pid: testSubstratePeer}
+
+	data := []byte("released payload")
+	gotCid, err := srv.node.AddFileForCid(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cid := gotCid.String()
+
+	var buf bytes.Buffer
+	h := command.New(hoarderSpecs.StashHeader, command.Body{
+		hoarderSpecs.BodyCid:    cid,
+		hoarderSpecs.BodyTarget: 1,
+		hoarderSpecs.BodyOwner:  "ownerA",
+		hoarderSpecs.BodyFanout: false,
+	})
+	if err := h.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	buf.Write(data)
+	srv.stashReceive(ctx, &buf)
+	if _, err := response.Decode(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second storage instance stashing the same bytes shares the CID.
+	if err := srv.addStashOwner(ctx, cid, "ownerB"); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := srv.ServiceHandler(ctx, from, releaseBody(cid, "ownerA"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp[hoarderSpecs.BodyReleased] != false {
+		t.Fatalf("release with another owner left = %v, want released=false", resp)
+	}
+	if _, ok := srv.stashClaimSince(ctx, cid, srv.node.ID().String()); !ok {
+		t.Fatal("claim must survive while an owner references the cid")
+	}
+
+	resp, err = srv.ServiceHandler(ctx, from, releaseBody(cid, "ownerB"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp[hoarderSpecs.BodyReleased] != true {
+		t.Fatalf("release of the last owner = %v, want released=true", resp)
+	}
+	if cids, _ := srv.listStashCids(ctx); len(cids) != 0 {
+		t.Fatalf("stash meta must be gone, got %v", cids)
+	}
+	if claims, _ := srv.listStashClaims(ctx, cid); len(claims) != 0 {
+		t.Fatalf("stash claims must be gone, got %v", claims)
+	}
+}
+
+func TestRelease_LegacyOwnerRefused(t *testing.T) {
+	srv := newTestService(t)
+	ctx := t.Context()
+	self := testConn{pid: srv.node.ID()}
+	cid := "bafyLegacy"
+
+	// Stashed before owners were tracked: only the meta names the owner.
+	if err := srv.putStashMeta(ctx, cid, &StashMeta{Target: 1, OwnerHash: "ownerA"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.addStashClaim(ctx, cid, srv.node.ID().String()); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := srv.releaseHandler(ctx, self, releaseBody(cid, "ownerB"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp[hoarderSpecs.BodyReleased] != false {
+		t.Fatalf("release by a foreign owner = %v, want released=false", resp)
+	}
+
+	resp, err = srv.releaseHandler(ctx, self, releaseBody(cid, "ownerA"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp[hoarderSpecs.BodyReleased] != true {
+		t.Fatalf("release by the recorded owner = %v, want released=true", resp)
+	}
+	if _, err := srv.getStashMeta(ctx, cid); err == nil {
+		t.Fatal("stash meta must be gone")
+	}
+}
+
+func TestRelease_Peers(t *testing.T) {
+	srv := newTestService(t)
+	withPeers(srv)
+	ctx := t.Context()
+	cid := "bafyPeers"
+
+	if err := srv.addStashClaim(ctx, cid, srv.node.ID().String()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Owner releases come from substrate nodes, fan-out from hoarders.
+	if _, err := srv.releaseHandler(ctx, testConn{pid: testHoarderPeer}, releaseBody(cid, "ownerA")); err == nil {
+		t.Fatal("owner release from a hoarder must be refused")
+	}
+
+	fanout := releaseBody(cid, "ownerA")
+	fanout[hoarderSpecs.BodyFanout] = false
+	if _, err := srv.releaseHandler(ctx, testConn{pid: testSubstratePeer}, fanout); err == nil {
+		t.Fatal("fan-out release from a substrate node must be refused")
+	}
+	if _, ok := srv.stashClaimSince(ctx, cid, srv.node.ID().String()); !ok {
+		t.Fatal("a refused release must keep the claim")
+	}
+
+	resp, err := srv.releaseHandler(ctx, testConn{pid: testHoarderPeer}, fanout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp[hoarderSpecs.BodyReleased] != true {
+		t.Fatalf("fan-out release from a hoarder = %v, want released=true", resp)
+	}
+	if _, ok := srv.stashClaimSince(ctx, cid, srv.node.ID().String()); ok {
+		t.Fatal("fan-out release must drop the claim")
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"path"
 
 	hoarderClient "github.com/taubyte/tau/clients/p2p/hoarder"
+	seerApi "github.com/taubyte/tau/clients/p2p/seer"
 	tnsApi "github.com/taubyte/tau/clients/p2p/tns"
 	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
+	seerIface "github.com/taubyte/tau/core/services/seer"
 	streamClient "github.com/taubyte/tau/p2p/streams/client"
 	streams "github.com/taubyte/tau/p2p/streams/service"
 	tauConfig "github.com/taubyte/tau/pkg/config"
@@ -70,6 +72,13 @@ func New(ctx context.Context, cfg tauConfig.Config) (service hoarderIface.Servic
 		return nil, fmt.Errorf("creating hoarder kvdb replication client failed with: %w", err)
 	}
 
+	seerClient, err := seerApi.New(ctx, clientNode, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating seer client failed with: %w", err)
+	}
+	s.substratePeers = protocolCommon.NewServicePeers(seerClient.Usage(), seerIface.ServiceTypeSubstrate)
+	s.hoarderPeers = protocolCommon.NewServicePeers(seerClient.Usage(), seerIface.ServiceTypeHoarder)
+
 	// Recover what this node already holds, then start membership + reconcile.
 	// They share a cancelable context so Close stops them before tearing down the
 	// state they read.
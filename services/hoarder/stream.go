@@ -49,6 +49,8 @@ func (srv *Service) ServiceHandler(ctx context.Context, conn streams.Connection,
 		return srv.metasHandler(ctx, body)
 	case hoarderSpecs.ActionStashStatus:
 		return srv.stashStatusHandler(ctx, body)
+	case hoarderSpecs.ActionRelease:
+		return srv.releaseHandler(ctx, conn, body)
 	}
 
 	return nil, fmt.Errorf("action %s unknown", action)
@@ -199,6 +201,14 @@ func (srv *Service) stashReceive(ctx context.Context, rw io.ReadWriter) {
 	}
 
 	self := srv.node.ID().String()
+	// A stash recorded before owners were tracked only names its owner in the
+	// meta, which is about to be overwritten — keep that reference.
+	if prev, err := srv.getStashMeta(ctx, cidStr); err == nil && prev.OwnerHash != "" {
+		if err := srv.addStashOwner(ctx, cidStr, prev.OwnerHash); err != nil {
+			stashErr(rw, fmt.Sprintf("recording stash owner failed: %s", err))
+			return
+		}
+	}
 	if err := srv.putStashMeta(ctx, cidStr, &StashMeta{Target: target, OwnerHash: owner}); err != nil {
 		stashErr(rw, fmt.Sprintf("writing stash meta failed: %s", err))
 		return
@@ -207,6 +217,12 @@ func (srv *Service) stashReceive(ctx context.Context, rw io.ReadWriter) {
 		stashErr(rw, fmt.Sprintf("claiming stash failed: %s", err))
 		return
 	}
+	if owner != "" {
+		if err := srv.addStashOwner(ctx, cidStr, owner); err != nil {
+			stashErr(rw, fmt.Sprintf("recording stash owner failed: %s", err))
+			return
+		}
+	}
 
 	if fanout {
 		srv.fanoutStash(ctx, cidStr, target, owner)
@@ -260,6 +276,98 @@ func (srv *Service) fanoutStash(ctx context.Context, cid string, target int, own
 	}
 }
 
+// releaseHandler drops an owner's reference to a stashed CID. The first hoarder
+// reached (fanout set) removes the owner and, once no owner is left, the stash
+// placement and every claim, then tells the other claimants to drop their copy.
+// Every hoarder reached drops its own bytes and claim once the CID is unstashed.
+// Owners are storage instances, so only substrate nodes (or this node) may
+// release for them; the fan-out release is only taken from other hoarders.
+func (srv *Service) releaseHandler(ctx context.Context, conn streams.Connection, body command.Body) (cr.Response, error) {
+	cid, err := maps.String(body, hoarderSpecs.BodyCid)
+	if err != nil {
+		return nil, fmt.Errorf("missing cid: %w", err)
+	}
+	owner := maps.TryString(body, hoarderSpecs.BodyOwner)
+	fanout, _ := maps.Bool(body, hoarderSpecs.BodyFanout)
+
+	from := conn.RemotePeer()
+	if fanout {
+		if from != srv.node.ID() && !srv.substratePeers.Has(from) {
+			return nil, fmt.Errorf("release of %s refused: %s is not a substrate node", cid, from)
+		}
+
+		released, err := srv.unstash(ctx, cid, owner)
+		if err != nil || !released {
+			return cr.Response{hoarderSpecs.BodyReleased: false}, err
+		}
+	} else if !srv.hoarderPeers.Has(from) {
+		return nil, fmt.Errorf("release of %s refused: %s is not a hoarder", cid, from)
+	}
+
+	srv.node.DeleteFile(cid) //nolint:errcheck // may not hold the bytes
+	if err := srv.db.Delete(ctx, hoarderSpecs.StashClaimKey(cid, srv.node.ID().String())); err != nil {
+		return nil, fmt.Errorf("dropping stash claim failed with: %w", err)
+	}
+
+	return cr.Response{hoarderSpecs.BodyReleased: true}, nil
+}
+
+// unstash removes owner's reference to cid and, when it was the last one, the
+// stash placement and claims, notifying the other claimants. Stashes recorded
+// before owners were tracked only name their owner in the meta, so an owner
+// with no reference of its own is refused there.
+func (srv *Service) unstash(ctx context.Context, cid, owner string) (bool, error) {
+	var tracked bool
+	if owner != "" {
+		key := hoarderSpecs.StashOwnerKey(cid, owner)
+		if cur, _ := srv.db.Get(ctx, key); cur != nil {
+			tracked = true
+		}
+		if err := srv.db.Delete(ctx, key); err != nil {
+			return false, fmt.Errorf("dropping stash owner failed with: %w", err)
+		}
+	}
+
+	owners, err := srv.listStashOwners(ctx, cid)
+	if err != nil {
+		return false, fmt.Errorf("listing stash owners failed with: %w", err)
+	}
+	if len(owners) > 0 {
+		return false, nil
+	}
+
+	if meta, err := srv.getStashMeta(ctx, cid); err == nil && !tracked && meta.OwnerHash != "" && meta.OwnerHash != owner {
+		return false, nil
+	}
+
+	claims, err := srv.listStashClaims(ctx, cid)
+	if err != nil {
+		return false, fmt.Errorf("listing stash claims failed with: %w", err)
+	}
+	if err := srv.db.Delete(ctx, hoarderSpecs.StashMetaKey(cid)); err != nil {
+		return false, fmt.Errorf("dropping stash meta failed with: %w", err)
+	}
+
+	self := srv.node.ID().String()
+	for _, pidStr := range claims {
+		if pidStr == self {
+			continue
+		}
+		if err := srv.db.Delete(ctx, hoarderSpecs.StashClaimKey(cid, pidStr)); err != nil {
+			logger.Errorf("release: dropping claim of %s on %s failed with: %s", pidStr, cid, err)
+		}
+		pid, err := peerCore.Decode(pidStr)
+		if err != nil || srv.stashClient == nil {
+			continue
+		}
+		if _, err := srv.stashClient.Peers(pid).Release(cid, owner, hoarderIface.WithoutFanout()); err != nil {
+			logger.Errorf("release of %s on %s failed with: %s", cid, pidStr, err)
+		}
+	}
+
+	return true, nil
+}
+
 // stashClaimedByMe lists the stashed CIDs this node holds a claim on.
 func (srv *Service) stashClaimedByMe(ctx context.Context) ([]string, error) {
 	cids, err := srv.listStashCids(ctx)
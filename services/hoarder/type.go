@@ -10,6 +10,7 @@ import (
 	"github.com/taubyte/tau/p2p/peer"
 	streamClient "github.com/taubyte/tau/p2p/streams/client"
 	streams "github.com/taubyte/tau/p2p/streams/service"
+	protocolCommon "github.com/taubyte/tau/services/common"
 )
 
 // stashClient re-pushes bytes to co-claimants during fan-out (an internal
@@ -28,6 +29,11 @@ type Service struct {
 	stashClient hoarderIface.Client
 	kvStream    *streamClient.Client
 
+	// substratePeers and hoarderPeers tell who may release stashed CIDs: owners
+	// live on substrate nodes, fan-out comes from other hoarders.
+	substratePeers *protocolCommon.ServicePeers
+	hoarderPeers   *protocolCommon.ServicePeers
+
 	// membership (heartbeat controller — see membership.go)
 	membersLock sync.RWMutex
 	members     map[string]*member
@@ -6,11 +6,12 @@ import (
 
 	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
 	ifaceTns "github.com/taubyte/tau/core/services/tns"
+	messagingSpec "github.com/taubyte/tau/pkg/specs/messaging"
 	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
 )
 
 // fakeTns embeds tns.Client and overrides only the two typed accessors
-// validateConfig reaches (Database/Storage). Everything else panics if touched,
+// validateConfig reaches (Database/Storage/Messaging). Everything else panics if touched,
 // which is exactly what we want in a unit test. A non-nil listErr makes List()
 // fail, standing in for a TNS transport outage (as opposed to a successful list
 // that simply contains no match).
@@ -18,6 +19,7 @@ type fakeTns struct {
 	ifaceTns.Client
 	dbs      map[string]*structureSpec.Database
 	storages map[string]*structureSpec.Storage
+	channels map[string]*structureSpec.Messaging
 	listErr  error
 }
 
@@ -27,6 +29,9 @@ func (f *fakeTns) Database() ifaceTns.StructureIface[*structureSpec.Database] {
 func (f *fakeTns) Storage() ifaceTns.StructureIface[*structureSpec.Storage] {
 	return fakeStruct[*structureSpec.Storage]{list: f.storages, err: f.listErr}
 }
+func (f *fakeTns) Messaging() ifaceTns.StructureIface[*structureSpec.Messaging] {
+	return fakeStruct[*structureSpec.Messaging]{list: f.channels, err: f.listErr}
+}
 
 type fakeStruct[T structureSpec.Structure] struct {
 	ifaceTns.StructureIface[T]
@@ -84,6 +89,33 @@ func TestValidateConfig_DatabaseAndStorage(t *testing.T) {
 	}
 }
 
+func TestValidateConfig_Messaging(t *testing.T) {
+	srv := newTestService(t)
+	srv.tnsClient = &fakeTns{channels: map[string]*structureSpec.Messaging{
+		"msg-1": {Name: "orders", Match: "orders", Persistent: true},
+		"msg-2": {Name: "chat", Match: "chat"},
+	}}
+
+	auc := &hoarderIface.Auction{MetaType: hoarderIface.Messaging, Meta: meta(messagingSpec.LogMatch("orders"))}
+	if err := srv.validateConfig(auc); err != nil {
+		t.Fatalf("messaging validate: %v", err)
+	}
+	if auc.Meta.ConfigId != "msg-1" {
+		t.Fatalf("messaging ConfigId = %q, want msg-1", auc.Meta.ConfigId)
+	}
+
+	// Only persistent channels keep a log.
+	err := srv.validateConfig(&hoarderIface.Auction{MetaType: hoarderIface.Messaging, Meta: meta(messagingSpec.LogMatch("chat"))})
+	if !errors.Is(err, errNoConfigMatch) {
+		t.Fatalf("non-persistent channel must not match, got %v", err)
+	}
+
+	// A bare channel name is not a log match.
+	if err := srv.validateConfig(&hoarderIface.Auction{MetaType: hoarderIface.Messaging, Meta: meta("orders")}); err == nil {
+		t.Fatal("expected error for a match without the log prefix")
+	}
+}
+
 // TestValidateConfig_NoMatchIsSentinel pins the wire that carries the definitive
 // signal: a successful list with no match must wrap errNoConfigMatch, while a
 // listing failure (outage) must NOT — otherwise configDeleted cannot tell a real
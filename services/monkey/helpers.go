@@ -52,12 +52,13 @@ var (
 
 func (m *Monkey) tryGetGitRepo(
 	ac auth.Client,
+	provider string,
 	repoID int,
 ) (gitRepo auth.GithubRepository, err error) {
 	for i := 0; i < GetGitRepoMaxRetries; i++ {
-		gitRepo, err = ac.Repositories().Github().Get(repoID)
+		gitRepo, err = ac.Repositories().Git(provider).Get(repoID)
 		if err != nil {
-			return gitRepo, fmt.Errorf("fetching repository %d from auth failed with %w", repoID, err)
+			return gitRepo, fmt.Errorf("fetching %s repository %d from auth failed with %w", provider, repoID, err)
 		}
 
 		deployKey := gitRepo.PrivateKey()
@@ -36,7 +36,7 @@ func (m *Monkey) RunJob() (err error) {
 	var p *auth.Project
 	repoType := repositorytype.UnknownRepository
 
-	gitRepo, err := m.tryGetGitRepo(ac, repo.ID)
+	gitRepo, err := m.tryGetGitRepo(ac, repo.GitProvider(), repo.ID)
 	if err != nil {
 		return fmt.Errorf("run job failed during fetching with %w", err)
 	}
@@ -99,6 +99,7 @@ func (m *Monkey) RunJob() (err error) {
 		GeneratedDomainRegExp: m.generatedDomainRegExp,
 		Accounts:              m.Service.accountsClient,
 		NetworkFqdn:           m.Service.config.NetworkFqdn(),
+		BuildWorkers:          m.Service.config.BuildWorkers(),
 	}
 
 	c.Context(m.ctx)
@@ -106,9 +107,10 @@ func (m *Monkey) RunJob() (err error) {
 	if repoType == repositorytype.CodeRepository {
 		c.ConfigRepoId = p.Git.Config.Id()
 
-		configRepo, err := ac.Repositories().Github().Get(p.Git.Config.Id())
+		// Both repositories of a project are on the same provider.
+		configRepo, err := ac.Repositories().Git(m.Job.Meta.Repository.GitProvider()).Get(p.Git.Config.Id())
 		if err != nil {
-			return fmt.Errorf("auth github get failed with: %w", err)
+			return fmt.Errorf("auth %s get failed with: %w", m.Job.Meta.Repository.GitProvider(), err)
 		}
 		c.ConfigPrivateKey = configRepo.PrivateKey()
 	}
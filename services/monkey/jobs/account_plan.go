@@ -45,8 +45,8 @@ func (c Context) checkAccountPlan(p projectSchema.Project) error {
 }
 
 // gitProviderIdentity reads (provider, external_id) from the patrick Job's
-// repository metadata. Defaults provider to "github" — the only wired one
-// today; extend Job.Meta.Repository.Provider when others land.
+// repository metadata. Defaults provider to "github" for jobs stored before
+// patrick recorded the provider.
 func (c Context) gitProviderIdentity() (provider, externalID string) {
 	if c.Job == nil {
 		return "", ""
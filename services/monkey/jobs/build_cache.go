@@ -0,0 +1,246 @@
+package jobs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/taubyte/tau/pkg/specs/builders"
+	specs "github.com/taubyte/tau/pkg/specs/builders/common"
+	"github.com/taubyte/tau/pkg/specs/methods"
+	"github.com/taubyte/tau/utils/mapstructure"
+	"gopkg.in/yaml.v3"
+)
+
+// buildCacheVersion is mixed into every key; bump it when the build output
+// format changes so stale cache entries stop matching.
+const buildCacheVersion = "v1"
+
+// buildCacheKey content-addresses a build: the hash covers the source tree
+// under sourcePath, the builder image, the raw `config.yaml` and the assets
+// of the libraries the source can link against, as returned by
+// libraryAssets. Two sources with the same key produce the same WASM asset.
+func buildCacheKey(sourcePath string, libraries []string) (string, error) {
+	wd, err := specs.Wd(sourcePath)
+	if err != nil {
+		return "", specs.DefaultWDError(err)
+	}
+
+	configData, err := os.ReadFile(wd.ConfigFile())
+	if err != nil {
+		return "", fmt.Errorf("reading config file failed with: %w", err)
+	}
+
+	var config builders.Config
+	if err = yaml.Unmarshal(configData, &config); err != nil {
+		return "", fmt.Errorf("decoding config failed with: %w", err)
+	}
+
+	treeHash, err := hashTree(sourcePath)
+	if err != nil {
+		return "", fmt.Errorf("hashing source tree `%s` failed with: %w", sourcePath, err)
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00", buildCacheVersion, config.HandleDepreciatedEnvironment().Image)
+	h.Write(configData)
+	h.Write([]byte{0})
+	h.Write(treeHash)
+	for _, library := range libraries {
+		fmt.Fprintf(h, "\x00%s", library)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// libraryAssets returns a sorted `<id>=<asset cid>` entry for each library
+// in ids, as published on the job's branch. A library that was never built
+// has an empty cid, so building it later changes the entry.
+func (c Context) libraryAssets(ids []string) ([]string, error) {
+	assets := make([]string, 0, len(ids))
+	for _, id := range ids {
+		assetPath, err := methods.GetTNSAssetPath(c.ProjectID, id, c.Job.Meta.Repository.Branch)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, err := c.Tns.Fetch(assetPath)
+		if err != nil {
+			return nil, fmt.Errorf("fetching asset of library `%s` failed with: %w", id, err)
+		}
+
+		cid, _ := obj.Interface().(string)
+		assets = append(assets, id+"="+cid)
+	}
+	sort.Strings(assets)
+
+	return assets, nil
+}
+
+// hashTree hashes the relative path, mode and content of every file under
+// root in lexical order. Symlinks contribute their target, not what it
+// points to; `.git` directories are skipped.
+func hashTree(root string) ([]byte, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		files = append(files, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, p := range files {
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return nil, err
+		}
+
+		info, err := os.Lstat(p)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(h, "%s\x00%o\x00", filepath.ToSlash(rel), info.Mode())
+
+		if info.Mode()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(p)
+			if err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(h, "%s\x00", target)
+			continue
+		}
+
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		if err = hashFile(h, p); err != nil {
+			return nil, err
+		}
+		h.Write([]byte{0})
+	}
+
+	return h.Sum(nil), nil
+}
+
+func hashFile(w io.Writer, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// buildCacheEntry is what the build cache records under a key.
+type buildCacheEntry struct {
+	Cid string `mapstructure:"cid"`
+	// Used is when the asset was last built or reused, in unix seconds.
+	Used int64 `mapstructure:"used"`
+}
+
+func (e buildCacheEntry) value() map[string]interface{} {
+	return map[string]interface{}{"cid": e.Cid, "used": e.Used}
+}
+
+// cachedBuild returns the asset CID previously built for key, if any.
+func (c Context) cachedBuild(key string) (string, bool) {
+	cachePath, err := methods.GetTNSBuildCachePath(c.ProjectID, key)
+	if err != nil {
+		return "", false
+	}
+
+	obj, err := c.Tns.Fetch(cachePath)
+	if err != nil {
+		return "", false
+	}
+
+	var entry buildCacheEntry
+	if mapstructure.Decode(obj.Interface(), &entry) != nil {
+		return "", false
+	}
+
+	return entry.Cid, len(entry.Cid) > 0
+}
+
+// cacheBuild records cid as the asset built for key, as of now.
+func (c Context) cacheBuild(key, cid string) error {
+	cachePath, err := methods.GetTNSBuildCachePath(c.ProjectID, key)
+	if err != nil {
+		return err
+	}
+
+	return c.Tns.Push(cachePath.Slice(), buildCacheEntry{Cid: cid, Used: time.Now().Unix()}.value())
+}
+
+// pruneBuildCache drops the builds of the project neither built nor reused
+// for BuildCacheRetention, then the least recently used ones past
+// MaxBuildCacheEntries. A build another job caches while the project's cache
+// is rewritten may be dropped too, which only costs it a rebuild.
+func (c Context) pruneBuildCache() error {
+	cachePath, err := methods.GetTNSProjectBuildCachePath(c.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	obj, err := c.Tns.Fetch(cachePath)
+	if err != nil {
+		return fmt.Errorf("fetching build cache failed with: %w", err)
+	}
+
+	var cached map[string]interface{}
+	if err = mapstructure.Decode(obj.Interface(), &cached); err != nil || len(cached) == 0 {
+		return nil
+	}
+
+	type keyedEntry struct {
+		key string
+		buildCacheEntry
+	}
+
+	cutoff := time.Now().Add(-BuildCacheRetention).Unix()
+	entries := make([]keyedEntry, 0, len(cached))
+	for key, value := range cached {
+		var entry buildCacheEntry
+		if mapstructure.Decode(value, &entry) != nil || len(entry.Cid) == 0 || entry.Used < cutoff {
+			continue
+		}
+		entries = append(entries, keyedEntry{key: key, buildCacheEntry: entry})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Used > entries[j].Used })
+	if len(entries) > MaxBuildCacheEntries {
+		entries = entries[:MaxBuildCacheEntries]
+	}
+
+	if len(entries) == len(cached) {
+		return nil
+	}
+
+	kept := make(map[string]interface{}, len(entries))
+	for _, entry := range entries {
+		kept[entry.key] = entry.value()
+	}
+
+	return c.Tns.Push(cachePath.Slice(), kept)
+}
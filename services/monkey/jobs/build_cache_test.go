@@ -0,0 +1,236 @@
+package jobs
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/core/services/tns"
+	"github.com/taubyte/tau/pkg/specs/methods"
+	"gotest.tools/v3/assert"
+)
+
+type fakeTnsObject struct {
+	tns.Object
+	value interface{}
+}
+
+func (o fakeTnsObject) Interface() interface{} { return o.value }
+
+// fakeTns keeps values as a tree of maps: like tns, a fetch returns the
+// subtree under a path and a push replaces it.
+type fakeTns struct {
+	tns.Client
+	lock sync.Mutex
+	root map[string]interface{}
+}
+
+func newFakeTns() *fakeTns {
+	return &fakeTns{root: make(map[string]interface{})}
+}
+
+func (f *fakeTns) Fetch(p tns.Path) (tns.Object, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return fakeTnsObject{value: f.get(p.Slice())}, nil
+}
+
+func (f *fakeTns) get(p []string) interface{} {
+	var value interface{} = f.root
+	for _, part := range p {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[part]
+	}
+	return value
+}
+
+func (f *fakeTns) Push(p []string, value interface{}) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	m := f.root
+	for _, part := range p[:len(p)-1] {
+		next, ok := m[part].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			m[part] = next
+		}
+		m = next
+	}
+	m[p[len(p)-1]] = value
+
+	return nil
+}
+
+func writeOpSource(t *testing.T, root, name, source, image string) string {
+	t.Helper()
+	dir := path.Join(root, "functions", name)
+	assert.NilError(t, os.MkdirAll(path.Join(dir, ".taubyte"), 0755))
+	assert.NilError(t, os.WriteFile(path.Join(dir, ".taubyte", "config.yaml"), []byte("version: 1.0\nenvironment:\n  image: "+image+"\n"), 0644))
+	assert.NilError(t, os.WriteFile(path.Join(dir, "lib.go"), []byte(source), 0644))
+	return dir
+}
+
+func TestBuildCacheKey(t *testing.T) {
+	root := t.TempDir()
+
+	key, err := buildCacheKey(writeOpSource(t, root, "a", "package lib", "taubyte/go-wasi:latest"), nil)
+	assert.NilError(t, err)
+
+	same, err := buildCacheKey(writeOpSource(t, root, "b", "package lib", "taubyte/go-wasi:latest"), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, key, same)
+
+	changedSource, err := buildCacheKey(writeOpSource(t, root, "c", "package lib2", "taubyte/go-wasi:latest"), nil)
+	assert.NilError(t, err)
+	assert.Assert(t, key != changedSource)
+
+	changedImage, err := buildCacheKey(writeOpSource(t, root, "d", "package lib", "taubyte/go-wasi:v2"), nil)
+	assert.NilError(t, err)
+	assert.Assert(t, key != changedImage)
+
+	withLibrary, err := buildCacheKey(writeOpSource(t, root, "e", "package lib", "taubyte/go-wasi:latest"), []string{"lib=cid1"})
+	assert.NilError(t, err)
+	assert.Assert(t, key != withLibrary)
+
+	changedLibrary, err := buildCacheKey(writeOpSource(t, root, "f", "package lib", "taubyte/go-wasi:latest"), []string{"lib=cid2"})
+	assert.NilError(t, err)
+	assert.Assert(t, withLibrary != changedLibrary)
+
+	_, err = buildCacheKey(t.TempDir(), nil)
+	assert.ErrorContains(t, err, "taubyte")
+}
+
+func TestHandleOps_ReusesCachedBuilds(t *testing.T) {
+	root := t.TempDir()
+	fake := newFakeTns()
+
+	logFile, err := os.CreateTemp("", "monkey-code-*.log")
+	assert.NilError(t, err)
+	t.Cleanup(func() { _ = os.Remove(logFile.Name()); logFile.Close() })
+
+	c := code{Context{
+		ctx:          context.Background(),
+		Tns:          fake,
+		ProjectID:    "project",
+		LogFile:      logFile,
+		gitDir:       root,
+		BuildWorkers: 2,
+		Job: &patrick.Job{
+			AssetCid: make(map[string]string),
+			Meta:     patrick.Meta{Repository: patrick.Repository{Branch: "main"}},
+		},
+	}}
+
+	var ops []Op
+	for _, name := range []string{"f1", "f2", "f3"} {
+		op := Op{id: "id-" + name, name: name, pathVariable: "functions", libraries: []string{"lib-id"}}
+		libraries, err := c.libraryAssets(op.libraries)
+		assert.NilError(t, err)
+		key, err := buildCacheKey(writeOpSource(t, root, name, "package "+name, "taubyte/go-wasi:latest"), libraries)
+		assert.NilError(t, err)
+		assert.NilError(t, c.cacheBuild(key, "cid-"+name))
+		ops = append(ops, op)
+	}
+
+	assert.NilError(t, c.handleOps(ops))
+
+	for _, op := range ops {
+		assert.Equal(t, c.Job.AssetCid[op.id], "cid-"+op.name)
+
+		assetPath, err := methods.GetTNSAssetPath("project", op.id, "main")
+		assert.NilError(t, err)
+		assert.Equal(t, fake.get(assetPath.Slice()), "cid-"+op.name)
+	}
+
+	logs, err := os.ReadFile(logFile.Name())
+	assert.NilError(t, err)
+	assert.Equal(t, strings.Count(string(logs), "Reusing cached build"), 3)
+}
+
+func TestLibraryAssets(t *testing.T) {
+	fake := newFakeTns()
+	c := Context{
+		Tns:       fake,
+		ProjectID: "project",
+		Job:       &patrick.Job{Meta: patrick.Meta{Repository: patrick.Repository{Branch: "main"}}},
+	}
+
+	assets, err := c.libraryAssets([]string{"lib2", "lib1"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, assets, []string{"lib1=", "lib2="})
+
+	assetPath, err := methods.GetTNSAssetPath("project", "lib2", "main")
+	assert.NilError(t, err)
+	assert.NilError(t, fake.Push(assetPath.Slice(), "cid2"))
+
+	assets, err = c.libraryAssets([]string{"lib2", "lib1"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, assets, []string{"lib1=", "lib2=cid2"})
+}
+
+func TestPruneBuildCache(t *testing.T) {
+	defer func(max int) { MaxBuildCacheEntries = max }(MaxBuildCacheEntries)
+	MaxBuildCacheEntries = 2
+
+	fake := newFakeTns()
+	c := Context{Tns: fake, ProjectID: "project"}
+
+	cachePath, err := methods.GetTNSProjectBuildCachePath("project")
+	assert.NilError(t, err)
+
+	now := time.Now()
+	assert.NilError(t, fake.Push(cachePath.Slice(), map[string]interface{}{
+		"expired": buildCacheEntry{Cid: "cid-expired", Used: now.Add(-BuildCacheRetention - time.Hour).Unix()}.value(),
+		"oldest":  buildCacheEntry{Cid: "cid-oldest", Used: now.Add(-2 * time.Hour).Unix()}.value(),
+		"older":   buildCacheEntry{Cid: "cid-older", Used: now.Add(-time.Hour).Unix()}.value(),
+		"legacy":  "cid-legacy",
+	}))
+	assert.NilError(t, c.cacheBuild("newest", "cid-newest"))
+
+	assert.NilError(t, c.pruneBuildCache())
+
+	for key, kept := range map[string]bool{"expired": false, "oldest": false, "legacy": false, "older": true, "newest": true} {
+		_, ok := c.cachedBuild(key)
+		assert.Equal(t, ok, kept, key)
+	}
+}
+
+func TestHandleOpLoggedCopyError(t *testing.T) {
+	root := t.TempDir()
+	writeOpSource(t, root, "f1", "package f1", "taubyte/go-wasi:latest")
+
+	logFile, err := os.CreateTemp("", "monkey-code-*.log")
+	assert.NilError(t, err)
+	logFile.Close()
+	t.Cleanup(func() { _ = os.Remove(logFile.Name()) })
+
+	c := code{Context{
+		ctx:       context.Background(),
+		Tns:       newFakeTns(),
+		ProjectID: "project",
+		LogFile:   logFile,
+		gitDir:    root,
+		Job: &patrick.Job{
+			AssetCid: make(map[string]string),
+			Meta:     patrick.Meta{Repository: patrick.Repository{Branch: "main"}},
+		},
+	}}
+
+	op := Op{id: "id-f1", name: "f1", pathVariable: "functions"}
+	key, err := buildCacheKey(path.Join(root, "functions", "f1"), nil)
+	assert.NilError(t, err)
+	assert.NilError(t, c.cacheBuild(key, "cid-f1"))
+
+	// The build is reused, but the job log is closed.
+	var mu sync.Mutex
+	assert.ErrorContains(t, c.handleOpLogged(op, &mu), "copying build log of f1")
+}
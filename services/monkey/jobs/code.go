@@ -4,13 +4,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"path"
+	"sync"
 	"time"
 
 	"github.com/taubyte/tau/core/builders"
 	build "github.com/taubyte/tau/pkg/builder"
 	"github.com/taubyte/tau/pkg/git"
 	projectSchema "github.com/taubyte/tau/pkg/schema/project"
+	spec "github.com/taubyte/tau/pkg/specs/common"
 )
 
 func (c code) handle() error {
@@ -40,34 +43,139 @@ func (c code) handle() error {
 	return nil
 }
 
+// handleOps builds ops on up to BuildWorkers concurrent workers. Each op
+// logs to its own file, which is appended to the job log once the op is
+// done so the output of parallel builds doesn't interleave. After the first
+// failure no new op is started and that error is returned. The project's
+// build cache is pruned once the ops are done.
 func (c code) handleOps(ops []Op) error {
 	if len(ops) == 0 {
 		return nil
 	}
 
-	for _, op := range ops {
-		op.err = c.handleOp(op)
-		if op.err != nil {
-			fmt.Fprintf(c.LogFile, "Error building %s: %s\n", op.name, op.err.Error())
-			return op.err
+	workers := c.BuildWorkers
+	if workers < 1 {
+		workers = DefaultBuildWorkers
+	}
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, workers)
+		wg       sync.WaitGroup
+	)
+	for i := range ops {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed || c.ctx.Err() != nil {
+			break
 		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(op *Op) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			op.err = c.handleOpLogged(*op, &mu)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if op.err != nil {
+				fmt.Fprintf(c.LogFile, "Error building %s: %s\n", op.name, op.err.Error())
+				if firstErr == nil {
+					firstErr = op.err
+				}
+			}
+		}(&ops[i])
+	}
+	wg.Wait()
+
+	if err := c.pruneBuildCache(); err != nil {
+		logger.Warnf("pruning build cache of %s failed with: %s", c.ProjectID, err)
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return c.ctx.Err()
+}
+
+// handleOpLogged runs handleOp against a private log file and copies it into
+// the job log, under logLock, when done.
+func (c code) handleOpLogged(op Op, logLock *sync.Mutex) error {
+	opLog, err := os.CreateTemp("", "build-"+op.id+"-*.log")
+	if err != nil {
+		return fmt.Errorf("creating build log for %s failed with: %w", op.name, err)
+	}
+	defer os.Remove(opLog.Name())
+	defer opLog.Close()
+
+	opCode := c
+	opCode.LogFile = opLog
+	buildErr := opCode.handleOp(op)
+
+	logLock.Lock()
+	defer logLock.Unlock()
+
+	if _, err = opLog.Seek(0, io.SeekStart); err == nil {
+		_, err = io.Copy(c.LogFile, opLog)
+	}
+
+	if buildErr != nil {
+		return buildErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("copying build log of %s failed with: %w", op.name, err)
 	}
 
 	return nil
 }
 
+// handleOp reuses the asset of an identical earlier build when the build
+// cache has one, otherwise builds op and records the result in the cache.
 func (c code) handleOp(op Op) error {
+	sourcePath := path.Join(c.gitDir, op.application, op.pathVariable, op.name)
+	libraries, err := c.libraryAssets(op.libraries)
+	var key string
+	if err == nil {
+		key, err = buildCacheKey(sourcePath, libraries)
+	}
+	if err != nil {
+		fmt.Fprintf(c.LogFile, "Build cache disabled for %s: %s\n", op.name, err)
+	} else if cid, ok := c.cachedBuild(key); ok {
+		fmt.Fprintf(c.LogFile, "Reusing cached build of %s: %s\n", op.name, cid)
+		if err := c.cacheBuild(key, cid); err != nil {
+			logger.Warnf("refreshing cached build of %s failed with: %s", op.name, err)
+		}
+		return c.publishAsset(op.id, cid)
+	}
+
 	moduleReader, err := c.HandleOp(op)
 	if err != nil {
 		return err
 	}
 	defer moduleReader.Close()
 
-	if err := c.handleCompressedBuild(op.id, moduleReader); err != nil {
-		return fmt.Errorf("handling build details failed with: %s", err)
+	cid, err := c.StashBuildFile(moduleReader)
+	if err != nil {
+		return fmt.Errorf("stashing build of %s failed with: %w", op.name, err)
+	}
+
+	if err = c.publishAsset(op.id, cid); err != nil {
+		return fmt.Errorf("publishing asset of %s failed with: %w", op.name, err)
+	}
+
+	if len(key) > 0 {
+		if err := c.cacheBuild(key, cid); err != nil {
+			logger.Warnf("caching build of %s failed with: %s", op.name, err)
+		}
 	}
 
-	return err
+	return nil
 }
 
 func (c Context) HandleOp(op Op) (io.ReadSeekCloser, error) {
@@ -103,52 +211,73 @@ func (c *code) checkConfig() error {
 			return fmt.Errorf("failed fetch config ssh url with: %s", err)
 		}
 
+		// A preview branch usually only exists in the code repo; fall back to
+		// the config repo's default branches when it has no matching branch.
+		branches := []string{c.Job.Meta.Repository.Branch}
+		if !spec.IsDefaultBranch(c.Job.Meta.Repository.Branch) {
+			branches = append(branches, spec.DefaultBranches...)
+		}
+
+		var configRepo *git.Repository
+		for _, branch := range branches {
+			if configRepo, err = c.cloneConfigRepo(url, branch); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("getting git repo from url `%s` failed with: %s", url, err)
+		}
+
+		c.ConfigRepoRoot = configRepo.Root()
+
 		json.NewEncoder(c.LogFile).Encode(struct {
 			Op        string `json:"op"`
-			Url       string `json:"url"`
-			Branch    string `json:"branch"`
+			Status    string `json:"status"`
 			Timestamp int64  `json:"timestamp"`
 		}{
 			Op:        "git-clone",
-			Url:       url,
-			Branch:    c.Job.Meta.Repository.Branch,
+			Status:    "success",
 			Timestamp: time.Now().UnixNano(),
 		})
-		configRepo, err := git.New(
-			c.ctx,
-			git.URL(url),
-			git.SSHKey(c.ConfigPrivateKey),
-			git.Temporary(),
-			git.Branch(c.Job.Meta.Repository.Branch),
-			git.Output(c.LogFile),
-		)
-		if err != nil {
-			json.NewEncoder(c.LogFile).Encode(struct {
-				Op        string `json:"op"`
-				Status    string `json:"status"`
-				Timestamp int64  `json:"timestamp"`
-				Error     string `json:"error"`
-			}{
-				Op:        "git-clone",
-				Status:    "error",
-				Error:     err.Error(),
-				Timestamp: time.Now().UnixNano(),
-			})
-			return fmt.Errorf("getting git repo from url `%s` failed with: %s", url, err)
-		}
+	}
 
-		c.ConfigRepoRoot = configRepo.Root()
+	return nil
+}
 
+func (c *code) cloneConfigRepo(url, branch string) (*git.Repository, error) {
+	json.NewEncoder(c.LogFile).Encode(struct {
+		Op        string `json:"op"`
+		Url       string `json:"url"`
+		Branch    string `json:"branch"`
+		Timestamp int64  `json:"timestamp"`
+	}{
+		Op:        "git-clone",
+		Url:       url,
+		Branch:    branch,
+		Timestamp: time.Now().UnixNano(),
+	})
+	configRepo, err := git.New(
+		c.ctx,
+		git.URL(url),
+		git.SSHKey(c.ConfigPrivateKey),
+		git.Temporary(),
+		git.Branch(branch),
+		git.Output(c.LogFile),
+	)
+	if err != nil {
 		json.NewEncoder(c.LogFile).Encode(struct {
 			Op        string `json:"op"`
 			Status    string `json:"status"`
 			Timestamp int64  `json:"timestamp"`
+			Error     string `json:"error"`
 		}{
 			Op:        "git-clone",
-			Status:    "success",
+			Status:    "error",
+			Error:     err.Error(),
 			Timestamp: time.Now().UnixNano(),
 		})
+		return nil, err
 	}
 
-	return nil
+	return configRepo, nil
 }
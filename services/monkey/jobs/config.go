@@ -6,6 +6,7 @@ import (
 
 	_ "github.com/taubyte/tau/pkg/builder"
 	projectSchema "github.com/taubyte/tau/pkg/schema/project"
+	spec "github.com/taubyte/tau/pkg/specs/common"
 	tccCompiler "github.com/taubyte/tau/pkg/tcc/taubyte/v1/schema"
 	tcc "github.com/taubyte/tau/utils/tcc"
 )
@@ -84,5 +85,19 @@ func (c config) handle() error {
 		return fmt.Errorf("publishing compiled config failed with: %s", err.Error())
 	}
 
+	// Preview branches get a stable label so substrate can map
+	// `<label>--<host>` back to the branch. Branches whose names map to the
+	// same label can't both have one.
+	if branch := c.Job.Meta.Repository.Branch; !spec.IsDefaultBranch(branch) {
+		label := spec.PreviewLabel(branch)
+		if owner := spec.PreviewBranch(c.Tns.Fetch(spec.Preview(c.ProjectID, label))); owner != "" && owner != branch {
+			return fmt.Errorf("preview `%s` of branch `%s` is already registered for branch `%s`", label, branch, owner)
+		}
+
+		if err = c.Tns.Push(spec.Preview(c.ProjectID, label).Slice(), branch); err != nil {
+			return fmt.Errorf("publishing preview for branch `%s` failed with: %w", branch, err)
+		}
+	}
+
 	return nil
 }
@@ -14,6 +14,7 @@ import (
 	specs "github.com/taubyte/tau/pkg/specs/common"
 	"github.com/taubyte/tau/pkg/specs/methods"
 	"github.com/taubyte/tau/utils/maps"
+	tcc "github.com/taubyte/tau/utils/tcc"
 )
 
 func (c Context) storeLogFile(file *os.File) (string, error) {
@@ -34,7 +35,8 @@ func (c Context) storeLogFile(file *os.File) (string, error) {
 }
 
 func (c Context) fetchConfigSshUrl() (sshString string, err error) {
-	tnsPath := specs.NewTnsPath([]string{"resolve", "repo", "github", strconv.Itoa(c.ConfigRepoId)})
+	provider, _ := c.gitProviderIdentity()
+	tnsPath := specs.NewTnsPath([]string{"resolve", "repo", provider, strconv.Itoa(c.ConfigRepoId)})
 	tnsObj, err := c.Tns.Fetch(tnsPath)
 	// TODO: This should return
 	if err != nil {
@@ -90,6 +92,11 @@ func (c Context) handleCompressedBuild(id string, rsk io.ReadSeekCloser) error {
 		return fmt.Errorf("stashing build failed with: %s", err)
 	}
 
+	return c.publishAsset(id, cid)
+}
+
+// publishAsset points the resource's TNS asset path at cid.
+func (c Context) publishAsset(id, cid string) error {
 	c.Job.SetCid(id, cid)
 
 	assetKey, err := methods.GetTNSAssetPath(c.ProjectID, id, c.Job.Meta.Repository.Branch)
@@ -102,8 +109,16 @@ func (c Context) handleCompressedBuild(id string, rsk io.ReadSeekCloser) error {
 			break
 		}
 	}
+	if err != nil {
+		return err
+	}
+
+	// Deployments are keyed by config commit, the head commit is the code's.
+	if err = tcc.PublishBuiltAsset(c.Tns, c.ProjectID, c.Job.Meta.Repository.Branch, id, cid); err != nil {
+		logger.Errorf("recording asset of `%s` for job `%s` failed with: %s", id, c.Job.Id, err)
+	}
 
-	return err
+	return nil
 }
 
 func (c Context) handleLog() error {
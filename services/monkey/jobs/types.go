@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"time"
 
 	"github.com/taubyte/tau/core/common/repositorytype"
 	"github.com/taubyte/tau/core/services/accounts"
@@ -47,14 +48,33 @@ type Context struct {
 	// NetworkFqdn is the cloud FQDN this monkey is compiling for. Empty in
 	// dream/local; checkAccountPlan skips when empty.
 	NetworkFqdn string
+
+	// BuildWorkers bounds how many functions and libraries a code job builds
+	// at once. Zero uses DefaultBuildWorkers.
+	BuildWorkers int
 }
 
+// DefaultBuildWorkers is the build concurrency of code jobs when none is
+// configured.
+const DefaultBuildWorkers = 4
+
+var (
+	// BuildCacheRetention is how long a cached build neither built nor
+	// reused is kept.
+	BuildCacheRetention = 30 * 24 * time.Hour
+
+	// MaxBuildCacheEntries caps the builds cached per project.
+	MaxBuildCacheEntries = 512
+)
+
 type Op struct {
 	id           string
 	name         string
 	application  string
 	pathVariable string
-	err          error
+	// ids of the libraries the op can link against
+	libraries []string
+	err       error
 }
 
 type code struct{ Context }
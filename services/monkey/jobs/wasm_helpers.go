@@ -17,7 +17,13 @@ func buildTodoFromConfig(projectIface projectLib.Project) ([]Op, error) {
 	getFunctions := projectIface.Get().Functions
 	getSmartOps := projectIface.Get().SmartOps
 
+	globalLibraries, err := todos.libraryIds("")
+	if err != nil {
+		return nil, err
+	}
+
 	// Get Global Functions
+	todos.libraries = globalLibraries
 	_, functions := getFunctions("")
 	for _, f := range functions {
 		if err := todos.addFunc(f, ""); err != nil {
@@ -35,6 +41,12 @@ func buildTodoFromConfig(projectIface projectLib.Project) ([]Op, error) {
 
 	// Get App Functions and SmartOps
 	for _, app := range apps {
+		appLibraries, err := todos.libraryIds(app)
+		if err != nil {
+			return nil, err
+		}
+		todos.libraries = append(appLibraries, globalLibraries...)
+
 		functions, _ = getFunctions(app)
 		for _, f := range functions {
 			if err := todos.addFunc(f, app); err != nil {
@@ -60,8 +72,9 @@ func (t *todo) addFunc(name, app string) error {
 	}
 
 	if function.Get().Source() == "." {
-
-		t.ops = append(t.ops, ToOp(function))
+		op := ToOp(function)
+		op.libraries = t.libraries
+		t.ops = append(t.ops, op)
 	}
 
 	return nil
@@ -74,7 +87,9 @@ func (t *todo) addSmart(name, app string) error {
 	}
 
 	if smart.Get().Source() == "." {
-		t.ops = append(t.ops, ToOp(smart))
+		op := ToOp(smart)
+		op.libraries = t.libraries
+		t.ops = append(t.ops, op)
 	}
 
 	return nil
@@ -103,4 +118,27 @@ func ToOp(value interface{}) (op Op) {
 type todo struct {
 	ops          []Op
 	projectIface projectLib.Project
+	// libraries the ops being added can link against
+	libraries []string
+}
+
+// libraryIds returns the ids of the libraries defined in application, or of
+// the global ones when application is empty.
+func (t *todo) libraryIds(application string) ([]string, error) {
+	local, global := t.projectIface.Get().Libraries(application)
+	names := global
+	if application != "" {
+		names = local
+	}
+
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		library, err := t.projectIface.Library(name, application)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, library.Get().Id())
+	}
+
+	return ids, nil
 }
@@ -0,0 +1,165 @@
+package monkey
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/p2p/peer"
+)
+
+var (
+	// LogStreamInterval is how often the log of a job is checked for output to
+	// publish.
+	LogStreamInterval = 500 * time.Millisecond
+	// LogChunkSize bounds the output published in a message.
+	LogChunkSize = 64 << 10
+)
+
+// logStreamer publishes the log of a job on its topic as it is written, so
+// patrick can serve it before the job is done.
+type logStreamer struct {
+	ctx  context.Context
+	ctxC context.CancelFunc
+	// pubCtx outlives ctx so the end of the log is published after a cancel.
+	pubCtx context.Context
+
+	node  peer.Node
+	topic string
+	file  io.ReaderAt
+
+	lock sync.Mutex
+	// offset is where the output published so far ends.
+	offset int64
+
+	stopped chan struct{}
+}
+
+func newLogStreamer(ctx context.Context, node peer.Node, jid string, file io.ReaderAt) *logStreamer {
+	s := &logStreamer{
+		pubCtx:  node.Context(),
+		node:    node,
+		topic:   patrick.JobLogsTopic(jid),
+		file:    file,
+		stopped: make(chan struct{}),
+	}
+
+	s.ctx, s.ctxC = context.WithCancel(ctx)
+
+	if err := node.PubSubSubscribeContext(s.ctx, s.topic, s.handleReplay, func(err error) {
+		if s.ctx.Err() == nil {
+			logger.Errorf("log subscription of `%s` ended with: %s", jid, err)
+		}
+	}); err != nil {
+		logger.Errorf("subscribing to log of `%s` failed with: %s", jid, err)
+	}
+
+	go s.loop()
+
+	return s
+}
+
+func (m *Monkey) streamLogs() *logStreamer {
+	return newLogStreamer(m.ctx, m.Service.node, m.Id, m.logFile)
+}
+
+func (s *logStreamer) loop() {
+	defer close(s.stopped)
+
+	ticker := time.NewTicker(LogStreamInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.flush()
+		}
+	}
+}
+
+// handleReplay publishes again the output a follower missed.
+func (s *logStreamer) handleReplay(msg *pubsub.Message) {
+	var chunk patrick.JobLogChunk
+	if err := cbor.Unmarshal(msg.Data, &chunk); err != nil || !chunk.Replay {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if chunk.Offset < 0 || chunk.Offset >= s.offset {
+		return
+	}
+
+	if _, err := s.send(chunk.Offset, s.offset); err != nil {
+		logger.Errorf("replaying log on `%s` failed with: %s", s.topic, err)
+	}
+}
+
+func (s *logStreamer) flush() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var err error
+	if s.offset, err = s.send(s.offset, -1); err != nil {
+		logger.Errorf("publishing log on `%s` failed with: %s", s.topic, err)
+	}
+}
+
+// send publishes the log from offset up to limit, or to its end when limit is
+// negative, returning where it stopped.
+func (s *logStreamer) send(offset, limit int64) (int64, error) {
+	buf := make([]byte, LogChunkSize)
+	for limit < 0 || offset < limit {
+		size := int64(len(buf))
+		if limit >= 0 {
+			size = min(size, limit-offset)
+		}
+
+		n, err := s.file.ReadAt(buf[:size], offset)
+		if n > 0 {
+			if perr := s.publish(&patrick.JobLogChunk{Offset: offset, Data: buf[:n]}); perr != nil {
+				return offset, perr
+			}
+			offset += int64(n)
+		}
+
+		if err == io.EOF || n == 0 {
+			break
+		} else if err != nil {
+			return offset, err
+		}
+	}
+
+	return offset, nil
+}
+
+func (s *logStreamer) publish(chunk *patrick.JobLogChunk) error {
+	data, err := cbor.Marshal(chunk)
+	if err != nil {
+		return err
+	}
+
+	return s.node.PubSubPublish(s.pubCtx, s.topic, data)
+}
+
+// close publishes what is left of the log, then its end.
+func (s *logStreamer) close() {
+	s.ctxC()
+	<-s.stopped
+
+	s.flush()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.publish(&patrick.JobLogChunk{Offset: s.offset, Done: true}); err != nil {
+		logger.Errorf("publishing end of log on `%s` failed with: %s", s.topic, err)
+	}
+}
@@ -0,0 +1,81 @@
+package monkey
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/p2p/peer"
+	"gotest.tools/v3/assert"
+)
+
+func TestLogStreamer(t *testing.T) {
+	ctx, ctxC := context.WithCancel(context.Background())
+	defer ctxC()
+
+	node := peer.Mock(ctx)
+	defer node.Close()
+
+	interval, chunkSize := LogStreamInterval, LogChunkSize
+	LogStreamInterval, LogChunkSize = 10*time.Millisecond, 4
+	defer func() { LogStreamInterval, LogChunkSize = interval, chunkSize }()
+
+	chunks := make(chan *patrick.JobLogChunk, 64)
+	assert.NilError(t, node.PubSubSubscribeContext(ctx, patrick.JobLogsTopic("job"), func(msg *pubsub.Message) {
+		chunk := new(patrick.JobLogChunk)
+		assert.NilError(t, cbor.Unmarshal(msg.Data, chunk))
+		if !chunk.Replay {
+			chunks <- chunk
+		}
+	}, func(error) {}))
+
+	next := func() *patrick.JobLogChunk {
+		select {
+		case chunk := <-chunks:
+			return chunk
+		case <-time.After(5 * time.Second):
+			t.Fatal("no log published")
+			return nil
+		}
+	}
+
+	file, err := os.CreateTemp(t.TempDir(), "log")
+	assert.NilError(t, err)
+	defer file.Close()
+
+	s := newLogStreamer(ctx, node, "job", file)
+
+	_, err = file.WriteString("hello")
+	assert.NilError(t, err)
+
+	chunk := next()
+	assert.Equal(t, chunk.Offset, int64(0))
+	assert.Equal(t, string(chunk.Data), "hell")
+	chunk = next()
+	assert.Equal(t, chunk.Offset, int64(4))
+	assert.Equal(t, string(chunk.Data), "o")
+
+	// A follower that missed some asks for it again.
+	data, err := cbor.Marshal(&patrick.JobLogChunk{Offset: 3, Replay: true})
+	assert.NilError(t, err)
+	assert.NilError(t, node.PubSubPublish(ctx, patrick.JobLogsTopic("job"), data))
+
+	chunk = next()
+	assert.Equal(t, chunk.Offset, int64(3))
+	assert.Equal(t, string(chunk.Data), "lo")
+
+	_, err = file.WriteString("!")
+	assert.NilError(t, err)
+	s.close()
+
+	// Whatever the ticker got to before the end.
+	for chunk = next(); !chunk.Done; chunk = next() {
+		assert.Equal(t, chunk.Offset, int64(5))
+		assert.Equal(t, string(chunk.Data), "!")
+	}
+	assert.Equal(t, chunk.Offset, int64(6))
+}
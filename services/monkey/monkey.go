@@ -20,12 +20,16 @@ func (m *Monkey) Run() {
 		}
 	}()
 
+	logs := m.streamLogs()
+
 	errs := make(chan error, 1024)
 
 	m.run(errs)
 	close(errs)
 
 	runErr := m.appendErrors(m.logFile, errs)
+	logs.close()
+
 	cid, err := m.storeLogs(m.logFile)
 	if err != nil {
 		logger.Errorf("Writing cid of job `%s` failed: %s", m.Id, err.Error())
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,11 +32,17 @@ func (srv *PatrickService) setupStreamRoutes() {
 	})
 	srv.stream.Define("patrick", srv.requestServiceHandler)
 	srv.stream.Define("stats", srv.statsServiceHandler)
+	srv.stream.Define("logs", srv.functionLogsServiceHandler)
 }
 
 func (srv *PatrickService) setupHTTPRoutes() {
 	srv.setupGithubRoutes()
+	srv.setupGitHookRoutes()
 	srv.setupJobRoutes()
+	srv.setupScheduleRoutes()
+	srv.setupDeploymentRoutes()
+	srv.setupFunctionLogRoutes()
+	srv.setupJobLogRoutes()
 }
 
 func (p *PatrickService) statsServiceHandler(ctx context.Context, conn streams.Connection, body command.Body) (cr.Response, error) {
@@ -245,6 +252,8 @@ func (p *PatrickService) timeoutHandler(ctx context.Context, jid string, cid_log
 				return fmt.Errorf("failed delete in timeoutHandler with %w", err)
 			}
 
+			p.finishJob(jid)
+
 			return nil
 		}
 
@@ -265,7 +274,7 @@ func (p *PatrickService) timeoutHandler(ctx context.Context, jid string, cid_log
 			return err
 		}
 
-		if err = p.jobQueue.Push(jid, nil, 5*time.Second); err != nil {
+		if err = p.jobQueue.Push(jid, p.queuedJob(ctx, jid), 5*time.Second); err != nil {
 			return fmt.Errorf("failed to push job %s back onto queue: %w", jid, err)
 		}
 
@@ -343,6 +352,9 @@ func (p *PatrickService) updateStatus(ctx context.Context, pid peer.ID, jid stri
 		return fmt.Errorf("failed delete in updateStatus with %w", err)
 	}
 
+	// Failed jobs with attempts left are pushed again by ReannounceJobs.
+	p.finishJob(jid)
+
 	return nil
 }
 
@@ -531,7 +543,7 @@ func (srv *PatrickService) retryJob(ctx http.Context) (iface interface{}, err er
 			return nil, fmt.Errorf("failed putting job %s with %w", job.Id, err)
 		}
 
-		if err = srv.jobQueue.Push(job.Id, nil, 5*time.Second); err != nil {
+		if err = srv.jobQueue.Push(job.Id, srv.retriedJob(requestCtx, job.Id), 5*time.Second); err != nil {
 			return nil, fmt.Errorf("failed to push retried job %s onto queue: %w", job.Id, err)
 		}
 
@@ -646,6 +658,33 @@ func (srv *PatrickService) setupGithubRoutes() {
 	})
 }
 
+func (srv *PatrickService) setupGitHookRoutes() {
+	hosts := srv.config.RouteHosts(servicesCommon.Patrick)
+	for _, provider := range []struct {
+		name    string
+		headers []string
+		parse   pushParser
+	}{
+		{"gitlab", []string{"X-Gitlab-Token", "X-Gitlab-Event"}, parseGitlabPush},
+		// Forgejo may send its own headers only; the parser checks for either.
+		{"gitea", nil, parseGiteaPush},
+		{"bitbucket", []string{"X-Hub-Signature", "X-Event-Key"}, parseBitbucketPush},
+	} {
+		srv.http.POST(&http.RouteDefinition{
+			Hosts: hosts,
+			Path:  "/" + provider.name + "/{hook}",
+			Vars: http.Variables{
+				Required: append([]string{"hook"}, provider.headers...),
+			},
+			Scope: []string{"hook/push"},
+			Auth: http.RouteAuthHandler{
+				Validator: srv.checkHookAndExtractSecret(provider.name),
+			},
+			Handler: srv.pushHookHandler(provider.name, provider.parse),
+		})
+	}
+}
+
 func (srv *PatrickService) setupJobRoutes() {
 	hosts := srv.config.RouteHosts(servicesCommon.Patrick)
 	srv.http.GET(&http.RouteDefinition{
@@ -726,6 +765,235 @@ func (srv *PatrickService) setupJobRoutes() {
 
 }
 
+func (srv *PatrickService) setupScheduleRoutes() {
+	srv.http.GET(&http.RouteDefinition{
+		Hosts: srv.config.RouteHosts(servicesCommon.Patrick),
+		Path:  "/schedules/{projectId}/{functionId}",
+		Vars: http.Variables{
+			Required: []string{"projectId", "functionId"},
+		},
+		Auth: http.RouteAuthHandler{
+			Validator: srv.GitHubTokenHTTPAuth,
+			GC:        srv.GitHubTokenHTTPAuthCleanup,
+		},
+		Handler: srv.scheduledRunsHandler,
+	})
+}
+
+func (srv *PatrickService) scheduledRunsHandler(ctx http.Context) (interface{}, error) {
+	projectId, err := maps.String(ctx.Variables(), "projectId")
+	if err != nil {
+		return nil, err
+	}
+
+	functionId, err := maps.String(ctx.Variables(), "functionId")
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := scheduledRuns(srv.raftCluster, functionId)
+	if err != nil {
+		return nil, fmt.Errorf("getting runs of `%s` failed with: %w", functionId, err)
+	}
+
+	projectRuns := make([]*commonIface.ScheduledRun, 0, len(runs))
+	for _, run := range runs {
+		if run.ProjectId == projectId {
+			projectRuns = append(projectRuns, run)
+		}
+	}
+
+	return map[string]interface{}{"runs": projectRuns}, nil
+}
+
+func (srv *PatrickService) setupDeploymentRoutes() {
+	hosts := srv.config.RouteHosts(servicesCommon.Patrick)
+	srv.http.GET(&http.RouteDefinition{
+		Hosts: hosts,
+		Path:  "/deployments/{projectId}",
+		Vars: http.Variables{
+			Required: []string{"projectId"},
+			Optional: []string{"branch"},
+		},
+		Auth: http.RouteAuthHandler{
+			Validator: srv.GitHubTokenHTTPAuth,
+			GC:        srv.GitHubTokenHTTPAuthCleanup,
+		},
+		Handler: srv.deploymentsHandler,
+	})
+
+	srv.http.POST(&http.RouteDefinition{
+		Hosts: hosts,
+		Path:  "/rollback/{projectId}",
+		Vars: http.Variables{
+			Required: []string{"projectId"},
+			Optional: []string{"branch", "to"},
+		},
+		Auth: http.RouteAuthHandler{
+			Validator: srv.GitHubTokenHTTPAuth,
+			GC:        srv.GitHubTokenHTTPAuthCleanup,
+		},
+		Handler: srv.rollbackHandler,
+	})
+
+	srv.http.GET(&http.RouteDefinition{
+		Hosts: hosts,
+		Path:  "/canary/{projectId}",
+		Vars: http.Variables{
+			Required: []string{"projectId"},
+			Optional: []string{"branch"},
+		},
+		Auth: http.RouteAuthHandler{
+			Validator: srv.GitHubTokenHTTPAuth,
+			GC:        srv.GitHubTokenHTTPAuthCleanup,
+		},
+		Handler: srv.canaryHandler,
+	})
+
+	srv.http.POST(&http.RouteDefinition{
+		Hosts: hosts,
+		Path:  "/canary/{projectId}",
+		Vars: http.Variables{
+			Required: []string{"projectId", "weight"},
+			Optional: []string{"branch", "canary"},
+		},
+		Auth: http.RouteAuthHandler{
+			Validator: srv.GitHubTokenHTTPAuth,
+			GC:        srv.GitHubTokenHTTPAuthCleanup,
+		},
+		Handler: srv.startCanaryHandler,
+	})
+
+	for action, promote := range map[string]bool{"promote": true, "abort": false} {
+		srv.http.POST(&http.RouteDefinition{
+			Hosts: hosts,
+			Path:  "/canary/{projectId}/" + action,
+			Vars: http.Variables{
+				Required: []string{"projectId"},
+				Optional: []string{"branch"},
+			},
+			Auth: http.RouteAuthHandler{
+				Validator: srv.GitHubTokenHTTPAuth,
+				GC:        srv.GitHubTokenHTTPAuthCleanup,
+			},
+			Handler: srv.endCanaryHandler(promote),
+		})
+	}
+}
+
+func (srv *PatrickService) deploymentsHandler(ctx http.Context) (interface{}, error) {
+	projectId, err := maps.String(ctx.Variables(), "projectId")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = srv.projectAccess(ctx, projectId); err != nil {
+		return nil, err
+	}
+
+	branch, _ := maps.String(ctx.Variables(), "branch")
+	if branch, err = srv.branchOf(projectId, branch); err != nil {
+		return nil, err
+	}
+
+	deployments, err := srv.deployments(projectId, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"deployments": deployments}, nil
+}
+
+func (srv *PatrickService) rollbackHandler(ctx http.Context) (interface{}, error) {
+	projectId, err := maps.String(ctx.Variables(), "projectId")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = srv.projectAccess(ctx, projectId); err != nil {
+		return nil, err
+	}
+
+	branch, _ := maps.String(ctx.Variables(), "branch")
+	to, _ := maps.String(ctx.Variables(), "to")
+
+	deployment, err := srv.rollback(ctx.Request().Context(), projectId, branch, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"deployment": deployment}, nil
+}
+
+func (srv *PatrickService) canaryHandler(ctx http.Context) (interface{}, error) {
+	projectId, err := maps.String(ctx.Variables(), "projectId")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = srv.projectAccess(ctx, projectId); err != nil {
+		return nil, err
+	}
+
+	branch, _ := maps.String(ctx.Variables(), "branch")
+
+	canary, err := srv.canary(projectId, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"canary": canary}, nil
+}
+
+func (srv *PatrickService) startCanaryHandler(ctx http.Context) (interface{}, error) {
+	projectId, err := maps.String(ctx.Variables(), "projectId")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = srv.projectAccess(ctx, projectId); err != nil {
+		return nil, err
+	}
+
+	// Numbers come decoded from json.
+	weight, ok := ctx.Variables()["weight"].(float64)
+	if !ok {
+		return nil, errors.New("`weight` needs to be a number")
+	}
+
+	branch, _ := maps.String(ctx.Variables(), "branch")
+	commit, _ := maps.String(ctx.Variables(), "canary")
+
+	canary, err := srv.startCanary(projectId, branch, commit, int(weight))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"canary": canary}, nil
+}
+
+func (srv *PatrickService) endCanaryHandler(promote bool) func(ctx http.Context) (interface{}, error) {
+	return func(ctx http.Context) (interface{}, error) {
+		projectId, err := maps.String(ctx.Variables(), "projectId")
+		if err != nil {
+			return nil, err
+		}
+
+		if err = srv.projectAccess(ctx, projectId); err != nil {
+			return nil, err
+		}
+
+		branch, _ := maps.String(ctx.Variables(), "branch")
+
+		deployment, err := srv.endCanary(ctx.Request().Context(), projectId, branch, promote)
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{"deployment": deployment}, nil
+	}
+}
+
 func (srv *PatrickService) GitHubTokenHTTPAuth(ctx http.Context) (interface{}, error) {
 	auth := httpAuth.GetAuthorization(ctx)
 	if auth != nil && (auth.Type == "oauth" || auth.Type == "github") {
@@ -743,6 +1011,32 @@ func (srv *PatrickService) GitHubTokenHTTPAuth(ctx http.Context) (interface{}, e
 	return nil, errors.New("valid Github token required")
 }
 
+// projectAccess checks the GitHub token of the request reaches the config
+// repository of projectId, as auth requires of the project's owners.
+func (srv *PatrickService) projectAccess(ctx http.Context, projectId string) error {
+	client, ok := ctx.Variables()["GithubClient"].(authService.GitHubClient)
+	if !ok {
+		return errors.New("valid Github token required")
+	}
+
+	project := srv.authClient.Projects().Get(projectId)
+	if project == nil || project.Git.Config == nil {
+		return fmt.Errorf("project `%s` not found", projectId)
+	}
+
+	// A GitHub token only vouches for GitHub repositories; an id of another
+	// provider may well name an unrelated GitHub repository.
+	if project.Provider != "" && project.Provider != "github" {
+		return fmt.Errorf("project `%s` is not hosted on github", projectId)
+	}
+
+	if err := client.GetByID(strconv.Itoa(project.Git.Config.Id())); err != nil {
+		return fmt.Errorf("accessing project `%s` failed with: %w", projectId, err)
+	}
+
+	return nil
+}
+
 func (srv *PatrickService) GitHubTokenHTTPAuthCleanup(ctx http.Context) (interface{}, error) {
 	done, k := ctx.Variables()["GithubClientDone"]
 	if k && done != nil {
@@ -88,6 +88,23 @@ func TestSetupGithubRoutesInProduction(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
+func TestSetupGitHookRoutes(t *testing.T) {
+	mockHTTP := &mockHTTPService{}
+
+	mockHTTP.On("POST", "/gitlab/{hook}").Return()
+	mockHTTP.On("POST", "/gitea/{hook}").Return()
+	mockHTTP.On("POST", "/bitbucket/{hook}").Return()
+
+	srv := &PatrickService{
+		http:   mockHTTP,
+		config: testConfig(t),
+	}
+
+	srv.setupGitHookRoutes()
+
+	mockHTTP.AssertExpectations(t)
+}
+
 func TestSetupJobRoutes(t *testing.T) {
 	mockHTTP := &mockHTTPService{}
 
@@ -108,6 +125,51 @@ func TestSetupJobRoutes(t *testing.T) {
 	mockHTTP.AssertExpectations(t)
 }
 
+func TestSetupScheduleRoutes(t *testing.T) {
+	mockHTTP := &mockHTTPService{}
+
+	mockHTTP.On("GET", "/schedules/{projectId}/{functionId}").Return()
+
+	srv := &PatrickService{
+		http:   mockHTTP,
+		config: testConfig(t),
+	}
+
+	srv.setupScheduleRoutes()
+
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestSetupFunctionLogRoutes(t *testing.T) {
+	mockHTTP := &mockHTTPService{}
+
+	mockHTTP.On("GET", "/logs/functions/{projectId}/{functionId}").Return()
+
+	srv := &PatrickService{
+		http:   mockHTTP,
+		config: testConfig(t),
+	}
+
+	srv.setupFunctionLogRoutes()
+
+	mockHTTP.AssertExpectations(t)
+}
+
+func TestSetupJobLogRoutes(t *testing.T) {
+	mockHTTP := &mockHTTPService{}
+
+	mockHTTP.On("GET", "/logs/follow/{jid}").Return()
+
+	srv := &PatrickService{
+		http:   mockHTTP,
+		config: testConfig(t),
+	}
+
+	srv.setupJobLogRoutes()
+
+	mockHTTP.AssertExpectations(t)
+}
+
 func TestSetupJobRoutesInProduction(t *testing.T) {
 	mockHTTP := &mockHTTPService{}
 
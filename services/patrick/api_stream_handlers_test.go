@@ -72,7 +72,7 @@ type pushCall struct {
 	data []byte
 }
 
-// mockJobQueue implements raft.Queue for unit tests with call recording.
+// mockJobQueue implements jobQueue for unit tests with call recording.
 type mockJobQueue struct {
 	popID     string
 	popData   []byte
@@ -80,6 +80,9 @@ type mockJobQueue struct {
 	pushErr   error
 	pushCalls []pushCall
 	popCalls  int
+	doneCalls []string
+	// superseded is returned by Supersede.
+	superseded []string
 }
 
 func (m *mockJobQueue) Push(id string, data []byte, _ time.Duration) error {
@@ -93,8 +96,20 @@ func (m *mockJobQueue) Pop(_ time.Duration) (string, []byte, error) {
 func (m *mockJobQueue) Peek() (string, []byte, bool) { return "", nil, false }
 func (m *mockJobQueue) Len() int                     { return 0 }
 func (m *mockJobQueue) Close() error                 { return nil }
+func (m *mockJobQueue) Running() []string            { return nil }
 
-var _ raft.Queue = (*mockJobQueue)(nil)
+func (m *mockJobQueue) Done(id string, _ time.Duration) error {
+	m.doneCalls = append(m.doneCalls, id)
+	return nil
+}
+
+func (m *mockJobQueue) Supersede(string, time.Duration) ([]string, error) {
+	superseded := m.superseded
+	m.superseded = nil
+	return superseded, nil
+}
+
+var _ jobQueue = (*mockJobQueue)(nil)
 
 func createTestService() *PatrickService {
 	mockFactory := mock.New()
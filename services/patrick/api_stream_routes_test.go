@@ -40,6 +40,7 @@ func TestSetupStreamRoutes(t *testing.T) {
 	mockStream.On("Define", "ping", mock.AnythingOfType("router.CommandHandler")).Return(nil)
 	mockStream.On("Define", "patrick", mock.AnythingOfType("router.CommandHandler")).Return(nil)
 	mockStream.On("Define", "stats", mock.AnythingOfType("router.CommandHandler")).Return(nil)
+	mockStream.On("Define", "logs", mock.AnythingOfType("router.CommandHandler")).Return(nil)
 
 	srv := &PatrickService{}
 	srv.stream = mockStream
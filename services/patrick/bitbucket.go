@@ -0,0 +1,98 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	goHttp "net/http"
+	"strings"
+
+	authIface "github.com/taubyte/tau/core/services/auth"
+	iface "github.com/taubyte/tau/core/services/patrick"
+)
+
+// Bitbucket Cloud webhook handlers
+
+type bitbucketPushPayload struct {
+	Repository struct {
+		UUID     string `json:"uuid"`
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Push struct {
+		Changes []struct {
+			Old *bitbucketRef `json:"old"`
+			New *bitbucketRef `json:"new"`
+		} `json:"changes"`
+	} `json:"push"`
+}
+
+type bitbucketRef struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Target struct {
+		Hash string `json:"hash"`
+		Date string `json:"date"`
+	} `json:"target"`
+}
+
+// parseBitbucketPush authenticates a Bitbucket Cloud delivery through the
+// `sha256=` HMAC in X-Hub-Signature. Only the first branch change of a push
+// is handled; tag pushes are not push events for us. Bitbucket Cloud has no
+// numeric repository ids: jobs take the one auth gave the hook's repository.
+func parseBitbucketPush(header goHttp.Header, body []byte, hook *authIface.GitHook) (*iface.Meta, error) {
+	signature, ok := strings.CutPrefix(header.Get("X-Hub-Signature"), "sha256=")
+	if !ok || !validHMACSHA256(body, hook.Secret, signature) {
+		return nil, errors.New("invalid X-Hub-Signature")
+	}
+
+	if header.Get("X-Event-Key") != "repo:push" {
+		return nil, errNotPushEvent
+	}
+
+	var payload bitbucketPushPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decoding push payload failed with %w", err)
+	}
+
+	if hook.RepositoryId == 0 {
+		return nil, errors.New("hook has no repository id")
+	}
+
+	if hook.Repository != payload.Repository.UUID {
+		return nil, fmt.Errorf("delivery for repository `%s` on a hook of repository `%s`", payload.Repository.UUID, hook.Repository)
+	}
+
+	sshURL := fmt.Sprintf("git@bitbucket.org:%s.git", payload.Repository.FullName)
+	for _, change := range payload.Push.Changes {
+		meta := &iface.Meta{
+			Repository: iface.Repository{
+				ID:     hook.RepositoryId,
+				SSHURL: sshURL,
+				URI:    sshURL,
+			},
+		}
+
+		switch {
+		case change.New != nil && change.New.Type == "branch":
+			meta.Ref = "refs/heads/" + change.New.Name
+			meta.After = change.New.Target.Hash
+			meta.HeadCommit = iface.HeadCommit{ID: change.New.Target.Hash}
+			meta.Repository.PushedAt = commitTimestamp(change.New.Target.Date)
+			if change.Old != nil {
+				meta.Before = change.Old.Target.Hash
+			}
+		case change.New == nil && change.Old != nil && change.Old.Type == "branch":
+			// Bitbucket reports deletions without a new ref; map them onto the
+			// all-zero after commit the other providers send.
+			meta.Ref = "refs/heads/" + change.Old.Name
+			meta.Before = change.Old.Target.Hash
+			meta.After = strings.Repeat("0", len(change.Old.Target.Hash))
+		default:
+			continue
+		}
+
+		return meta, nil
+	}
+
+	return nil, errNotPushEvent
+}
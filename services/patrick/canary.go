@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	commonIface "github.com/taubyte/tau/core/services/patrick"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	"github.com/taubyte/tau/pkg/specs/methods"
+	tcc "github.com/taubyte/tau/utils/tcc"
+)
+
+// trafficPolicy returns the traffic policy of a branch of a project, an
+// inactive one if it has none.
+func (srv *PatrickService) trafficPolicy(projectId, branch string) (*spec.TrafficPolicy, error) {
+	obj, err := srv.tnsClient.Fetch(spec.Traffic(projectId, branch))
+	if err != nil {
+		return new(spec.TrafficPolicy), nil
+	}
+
+	return spec.DecodeTrafficPolicy(obj.Interface())
+}
+
+func (srv *PatrickService) pushTrafficPolicy(projectId, branch string, policy *spec.TrafficPolicy) error {
+	if err := srv.tnsClient.Push(spec.Traffic(projectId, branch).Slice(), policy.Map()); err != nil {
+		return fmt.Errorf("pushing traffic policy of project `%s` on branch `%s` failed with: %w", projectId, branch, err)
+	}
+
+	return nil
+}
+
+func canaryOf(branch, current string, policy *spec.TrafficPolicy) *commonIface.Canary {
+	canary := &commonIface.Canary{Branch: branch, Active: policy.Active()}
+	if canary.Active {
+		canary.Stable = policy.Stable
+		canary.Canary = policy.CanaryOf(current)
+		canary.Weight = policy.Weight
+	}
+
+	return canary
+}
+
+// canary returns the traffic policy of a branch of a project.
+func (srv *PatrickService) canary(projectId, branch string) (*commonIface.Canary, error) {
+	branch, err := srv.branchOf(projectId, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := srv.trafficPolicy(projectId, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	current, _ := tcc.CurrentCommit(srv.tnsClient, projectId, branch)
+
+	return canaryOf(branch, current, policy), nil
+}
+
+// startCanary sends weight percent of a branch's traffic to the deployed
+// commit matching canary, the rest staying on the current commit. Without a
+// canary, the commit deployed next is the canary. On a branch with a canary
+// already, it changes its weight and, if given, its commit.
+func (srv *PatrickService) startCanary(projectId, branch, canary string, weight int) (*commonIface.Canary, error) {
+	branch, err := srv.branchOf(projectId, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := tcc.CurrentCommit(srv.tnsClient, projectId, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == "" {
+		return nil, fmt.Errorf("nothing is deployed on branch `%s` of project `%s`", branch, projectId)
+	}
+
+	policy, err := srv.trafficPolicy(projectId, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.Active() {
+		policy = &spec.TrafficPolicy{Stable: current}
+	}
+
+	if canary != "" {
+		deployments, err := srv.deployments(projectId, branch)
+		if err != nil {
+			return nil, err
+		}
+
+		deployment, err := target(deployments, canary)
+		if err != nil {
+			return nil, fmt.Errorf("starting canary of project `%s` on branch `%s` failed with: %w", projectId, branch, err)
+		}
+
+		if deployment.Commit == policy.Stable {
+			return nil, fmt.Errorf("commit `%s` is the stable one", deployment.Commit)
+		}
+
+		if _, err = srv.tnsClient.Fetch(methods.ProjectPrefix(projectId, branch, deployment.Commit)); err != nil {
+			return nil, fmt.Errorf("config of commit `%s` not found: %w", deployment.Commit, err)
+		}
+
+		policy.Canary = deployment.Commit
+	}
+
+	policy.Weight = weight
+	if err = policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err = srv.pushTrafficPolicy(projectId, branch, policy); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Sending %d%% of project `%s` on branch `%s` to commit `%s`", weight, projectId, branch, policy.CanaryOf(current))
+
+	return canaryOf(branch, current, policy), nil
+}
+
+// endCanary serves the canary, when promoted, or the stable commit to all of
+// a branch's traffic and drops its policy.
+func (srv *PatrickService) endCanary(ctx context.Context, projectId, branch string, promote bool) (*commonIface.Deployment, error) {
+	branch, err := srv.branchOf(projectId, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := srv.trafficPolicy(projectId, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.Active() {
+		return nil, fmt.Errorf("project `%s` has no canary on branch `%s`", projectId, branch)
+	}
+
+	current, err := tcc.CurrentCommit(srv.tnsClient, projectId, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := policy.Stable
+	if promote {
+		keep = policy.CanaryOf(current)
+	}
+
+	if keep == "" {
+		return nil, errors.New("canary commit is unknown")
+	}
+
+	deployment, err := srv.rollback(ctx, projectId, branch, keep)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = srv.pushTrafficPolicy(projectId, branch, new(spec.TrafficPolicy)); err != nil {
+		return nil, err
+	}
+
+	return deployment, nil
+}
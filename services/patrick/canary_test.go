@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	"github.com/taubyte/tau/pkg/specs/methods"
+	"gotest.tools/v3/assert"
+)
+
+func TestCanaryAbort(t *testing.T) {
+	srv, tnsClient := newDeploymentsService(nil)
+
+	canary, err := srv.startCanary("project", "", "", 10)
+	assert.NilError(t, err)
+	assert.Assert(t, canary.Active)
+	assert.Equal(t, canary.Stable, "c3")
+	assert.Equal(t, canary.Weight, 10)
+
+	// The commit deployed next is the canary.
+	tnsClient.values[spec.Current("project", "main").String()] = "c4"
+	canary, err = srv.canary("project", "main")
+	assert.NilError(t, err)
+	assert.Equal(t, canary.Stable, "c3")
+	assert.Equal(t, canary.Canary, "c4")
+
+	// Changing the weight keeps the stable commit.
+	canary, err = srv.startCanary("project", "main", "", 25)
+	assert.NilError(t, err)
+	assert.Equal(t, canary.Stable, "c3")
+	assert.Equal(t, canary.Weight, 25)
+
+	deployment, err := srv.endCanary(context.Background(), "project", "main", false)
+	assert.NilError(t, err)
+	assert.Equal(t, deployment.Commit, "c3")
+
+	canary, err = srv.canary("project", "main")
+	assert.NilError(t, err)
+	assert.Assert(t, !canary.Active)
+
+	_, err = srv.endCanary(context.Background(), "project", "main", false)
+	assert.ErrorContains(t, err, "no canary")
+}
+
+func TestCanaryPromote(t *testing.T) {
+	srv, tnsClient := newDeploymentsService(nil)
+
+	canary, err := srv.startCanary("project", "main", "c1", 50)
+	assert.NilError(t, err)
+	assert.Equal(t, canary.Stable, "c3")
+	assert.Equal(t, canary.Canary, "c1")
+
+	deployment, err := srv.endCanary(context.Background(), "project", "main", true)
+	assert.NilError(t, err)
+	assert.Equal(t, deployment.Commit, "c1")
+
+	assetPath, err := methods.GetTNSAssetPath("project", "fn", "main")
+	assert.NilError(t, err)
+	assert.Equal(t, tnsClient.values[assetPath.String()], "cid1")
+}
+
+func TestCanaryInvalid(t *testing.T) {
+	srv, _ := newDeploymentsService(nil)
+
+	_, err := srv.startCanary("project", "main", "", 101)
+	assert.ErrorContains(t, err, "between 0 and 100")
+
+	_, err = srv.startCanary("project", "main", "c3", 10)
+	assert.ErrorContains(t, err, "stable")
+
+	_, err = srv.startCanary("project", "main", "c9", 10)
+	assert.ErrorContains(t, err, "never deployed")
+}
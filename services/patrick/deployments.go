@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	commonIface "github.com/taubyte/tau/core/services/patrick"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	"github.com/taubyte/tau/pkg/specs/methods"
+	"github.com/taubyte/tau/utils/mapstructure"
+	tcc "github.com/taubyte/tau/utils/tcc"
+)
+
+// assetCheckTimeout bounds how long a rollback waits for each asset to resolve.
+var assetCheckTimeout = 30 * time.Second
+
+// branchOf returns the branch given, or the default branch a commit of the
+// project is deployed on.
+func (srv *PatrickService) branchOf(projectId, branch string) (string, error) {
+	if branch != "" {
+		return branch, nil
+	}
+
+	_, branch, err := srv.tnsClient.Simple().Commit(projectId, spec.DefaultBranches...)
+	if err != nil {
+		return "", fmt.Errorf("finding deployed branch of project `%s` failed with: %w", projectId, err)
+	}
+
+	return branch, nil
+}
+
+// deployments lists, newest first, the commits deployed on a branch of a
+// project, flagging the one currently served.
+func (srv *PatrickService) deployments(projectId, branch string) ([]*commonIface.Deployment, error) {
+	obj, err := srv.tnsClient.Fetch(spec.Deployments(projectId, branch))
+	if err != nil {
+		return nil, fmt.Errorf("fetching deployments of project `%s` on branch `%s` failed with: %w", projectId, branch, err)
+	}
+
+	var records map[string]*commonIface.Deployment
+	if err = mapstructure.Decode(obj.Interface(), &records); err != nil {
+		return nil, fmt.Errorf("decoding deployments of project `%s` failed with: %w", projectId, err)
+	}
+
+	current, _ := tcc.CurrentCommit(srv.tnsClient, projectId, branch)
+
+	list := make([]*commonIface.Deployment, 0, len(records))
+	for commit, deployment := range records {
+		if deployment == nil {
+			continue
+		}
+
+		deployment.Commit = commit
+		deployment.Branch = branch
+		deployment.Current = commit == current
+		list = append(list, deployment)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Time > list[j].Time })
+
+	return list, nil
+}
+
+// target picks the deployment to roll back to: the one whose commit starts
+// with to, or the one deployed before the current one when to is empty.
+func target(deployments []*commonIface.Deployment, to string) (*commonIface.Deployment, error) {
+	if to == "" {
+		for i, deployment := range deployments {
+			if deployment.Current {
+				if i+1 == len(deployments) {
+					return nil, errors.New("no deployment before the current one")
+				}
+
+				return deployments[i+1], nil
+			}
+		}
+
+		return nil, errors.New("current commit was never recorded as deployed")
+	}
+
+	var match *commonIface.Deployment
+	for _, deployment := range deployments {
+		if strings.HasPrefix(deployment.Commit, to) {
+			if match != nil {
+				return nil, fmt.Errorf("commit `%s` is ambiguous", to)
+			}
+			match = deployment
+		}
+	}
+
+	if match == nil {
+		return nil, fmt.Errorf("commit `%s` was never deployed", to)
+	}
+
+	return match, nil
+}
+
+// rollback points a branch of a project back at an earlier deployed commit,
+// restoring the assets its resources used. It fails, leaving the branch as
+// it is, if the commit's config or any of its assets can't be found anymore.
+func (srv *PatrickService) rollback(ctx context.Context, projectId, branch, to string) (*commonIface.Deployment, error) {
+	branch, err := srv.branchOf(projectId, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments, err := srv.deployments(projectId, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := target(deployments, to)
+	if err != nil {
+		return nil, fmt.Errorf("rolling back project `%s` on branch `%s` failed with: %w", projectId, branch, err)
+	}
+
+	if deployment.Current {
+		return deployment, nil
+	}
+
+	if _, err = srv.tnsClient.Fetch(methods.ProjectPrefix(projectId, branch, deployment.Commit)); err != nil {
+		return nil, fmt.Errorf("config of commit `%s` not found: %w", deployment.Commit, err)
+	}
+
+	for id, cid := range deployment.Assets {
+		if err = srv.checkAsset(ctx, cid); err != nil {
+			return nil, fmt.Errorf("asset `%s` of `%s` in commit `%s` not found: %w", cid, id, deployment.Commit, err)
+		}
+	}
+
+	for id, cid := range deployment.Assets {
+		assetPath, err := methods.GetTNSAssetPath(projectId, id, branch)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = srv.tnsClient.Push(assetPath.Slice(), cid); err != nil {
+			return nil, fmt.Errorf("restoring asset of `%s` failed with: %w", id, err)
+		}
+	}
+
+	if err = tcc.PublishCurrent(srv.tnsClient, projectId, branch, deployment.Commit); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Rolled back project `%s` on branch `%s` to commit `%s`", projectId, branch, deployment.Commit)
+
+	deployment.Current = true
+
+	return deployment, nil
+}
+
+// checkAsset makes sure the asset cid can still be fetched.
+func (srv *PatrickService) checkAsset(ctx context.Context, cid string) error {
+	ctx, cancel := context.WithTimeout(ctx, assetCheckTimeout)
+	defer cancel()
+
+	f, err := srv.node.GetFile(ctx, cid)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
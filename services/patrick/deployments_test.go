@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/taubyte/tau/core/services/tns"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	"github.com/taubyte/tau/pkg/specs/methods"
+	"gotest.tools/v3/assert"
+)
+
+type deploymentsObject struct {
+	tns.Object
+	value interface{}
+}
+
+func (o deploymentsObject) Interface() interface{} { return o.value }
+
+type deploymentsSimple struct {
+	tns.SimpleIface
+	branch string
+}
+
+func (s deploymentsSimple) Commit(projectId string, branches ...string) (string, string, error) {
+	return "", s.branch, nil
+}
+
+// deploymentsTNS stores values by path, like tns once its objects are merged.
+type deploymentsTNS struct {
+	tns.Client
+	values map[string]interface{}
+}
+
+func (m *deploymentsTNS) Fetch(path tns.Path) (tns.Object, error) {
+	value, ok := m.values[path.String()]
+	if !ok {
+		return nil, fmt.Errorf("nothing at %s", path.String())
+	}
+
+	return deploymentsObject{value: value}, nil
+}
+
+func (m *deploymentsTNS) Push(path []string, value interface{}) error {
+	m.values[strings.Join(path, "/")] = value
+	return nil
+}
+
+func (m *deploymentsTNS) Simple() tns.SimpleIface {
+	return deploymentsSimple{branch: "main"}
+}
+
+func newDeploymentsService(getFileError error) (*PatrickService, *deploymentsTNS) {
+	tnsClient := &deploymentsTNS{values: map[string]interface{}{
+		spec.Deployments("project", "main").String(): map[string]interface{}{
+			"c1": map[string]interface{}{"time": int64(1), "assets": map[string]interface{}{"fn": "cid1"}},
+			"c2": map[string]interface{}{"time": int64(2), "assets": map[string]interface{}{"fn": "cid2"}},
+			"c3": map[string]interface{}{"time": int64(3), "assets": map[string]interface{}{"fn": "cid3"}},
+		},
+		spec.Current("project", "main").String():                "c3",
+		methods.ProjectPrefix("project", "main", "c1").String(): map[string]interface{}{},
+		methods.ProjectPrefix("project", "main", "c2").String(): map[string]interface{}{},
+		methods.ProjectPrefix("project", "main", "c3").String(): map[string]interface{}{},
+	}}
+
+	return &PatrickService{
+		tnsClient: tnsClient,
+		node:      &mockNodeWithGetFile{mockNode: &mockNode{}, getFileError: getFileError},
+	}, tnsClient
+}
+
+func TestDeployments(t *testing.T) {
+	srv, _ := newDeploymentsService(nil)
+
+	deployments, err := srv.deployments("project", "main")
+	assert.NilError(t, err)
+	assert.Equal(t, len(deployments), 3)
+	assert.Equal(t, deployments[0].Commit, "c3")
+	assert.Assert(t, deployments[0].Current)
+	assert.Equal(t, deployments[2].Commit, "c1")
+	assert.Equal(t, deployments[2].Assets["fn"], "cid1")
+	assert.Assert(t, !deployments[2].Current)
+}
+
+func TestRollback(t *testing.T) {
+	ctx := context.Background()
+	srv, tnsClient := newDeploymentsService(nil)
+	assetPath, err := methods.GetTNSAssetPath("project", "fn", "main")
+	assert.NilError(t, err)
+
+	// Without a commit, the deployment before the current one.
+	deployment, err := srv.rollback(ctx, "project", "", "")
+	assert.NilError(t, err)
+	assert.Equal(t, deployment.Commit, "c2")
+	assert.Equal(t, tnsClient.values[assetPath.String()], "cid2")
+	current := tnsClient.values[spec.Current("project", "main").String()].(map[string]string)
+	assert.Equal(t, current[spec.CurrentCommitPathVariable.String()], "c2")
+
+	deployment, err = srv.rollback(ctx, "project", "main", "c1")
+	assert.NilError(t, err)
+	assert.Equal(t, deployment.Commit, "c1")
+	assert.Equal(t, tnsClient.values[assetPath.String()], "cid1")
+
+	_, err = srv.rollback(ctx, "project", "main", "c9")
+	assert.ErrorContains(t, err, "never deployed")
+
+	_, err = srv.rollback(ctx, "project", "main", "c")
+	assert.ErrorContains(t, err, "ambiguous")
+}
+
+func TestRollbackMissingAsset(t *testing.T) {
+	srv, tnsClient := newDeploymentsService(errors.New("not found"))
+
+	_, err := srv.rollback(context.Background(), "project", "main", "c1")
+	assert.ErrorContains(t, err, "asset `cid1`")
+	assert.Equal(t, tnsClient.values[spec.Current("project", "main").String()], "c3")
+}
+
+func TestRollbackMissingConfig(t *testing.T) {
+	srv, tnsClient := newDeploymentsService(nil)
+	delete(tnsClient.values, methods.ProjectPrefix("project", "main", "c1").String())
+
+	_, err := srv.rollback(context.Background(), "project", "main", "c1")
+	assert.ErrorContains(t, err, "config of commit `c1` not found")
+}
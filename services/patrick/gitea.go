@@ -0,0 +1,77 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	goHttp "net/http"
+
+	authIface "github.com/taubyte/tau/core/services/auth"
+	iface "github.com/taubyte/tau/core/services/patrick"
+)
+
+// Gitea and Forgejo webhook handlers
+
+type giteaPushPayload struct {
+	Ref        string `json:"ref"`
+	Before     string `json:"before"`
+	After      string `json:"after"`
+	HeadCommit *struct {
+		ID        string `json:"id"`
+		Timestamp string `json:"timestamp"`
+	} `json:"head_commit"`
+	Repository struct {
+		ID     int    `json:"id"`
+		SSHURL string `json:"ssh_url"`
+	} `json:"repository"`
+}
+
+// parseGiteaPush authenticates a Gitea delivery through the HMAC-SHA256 of its
+// body. Forgejo sends the same payload under X-Forgejo-* headers and keeps the
+// X-Gitea-* ones for compatibility, so both are accepted.
+func parseGiteaPush(header goHttp.Header, body []byte, hook *authIface.GitHook) (*iface.Meta, error) {
+	signature := header.Get("X-Gitea-Signature")
+	if signature == "" {
+		signature = header.Get("X-Forgejo-Signature")
+	}
+
+	if !validHMACSHA256(body, hook.Secret, signature) {
+		return nil, errors.New("invalid X-Gitea-Signature")
+	}
+
+	event := header.Get("X-Gitea-Event")
+	if event == "" {
+		event = header.Get("X-Forgejo-Event")
+	}
+
+	if event != "push" {
+		return nil, errNotPushEvent
+	}
+
+	var payload giteaPushPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decoding push payload failed with %w", err)
+	}
+
+	if err := checkHookRepository(hook, payload.Repository.ID); err != nil {
+		return nil, err
+	}
+
+	meta := &iface.Meta{
+		Ref:        payload.Ref,
+		Before:     payload.Before,
+		After:      payload.After,
+		HeadCommit: iface.HeadCommit{ID: payload.After},
+		Repository: iface.Repository{
+			ID:     payload.Repository.ID,
+			SSHURL: payload.Repository.SSHURL,
+			URI:    payload.Repository.SSHURL,
+		},
+	}
+
+	if payload.HeadCommit != nil {
+		meta.Repository.PushedAt = commitTimestamp(payload.HeadCommit.Timestamp)
+	}
+
+	return meta, nil
+}
@@ -7,8 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"slices"
-	"strings"
 	"time"
 
 	"github.com/fxamacker/cbor/v2"
@@ -17,8 +15,6 @@ import (
 	http "github.com/taubyte/tau/pkg/http"
 	servicesCommon "github.com/taubyte/tau/services/common"
 	"gopkg.in/go-playground/webhooks.v5/github"
-
-	commonSpec "github.com/taubyte/tau/pkg/specs/common"
 )
 
 // GitHub webhook handlers
@@ -48,25 +44,11 @@ func (srv *PatrickService) githubCheckHookAndExtractSecret(ctx http.Context) (in
 }
 
 func (srv *PatrickService) githubHookHandler(ctx http.Context) (interface{}, error) {
-	newJob := &iface.Job{
-		Status:    iface.JobStatusOpen,
-		Timestamp: time.Now().Unix(),
-		Logs:      make(map[string]string),
-		AssetCid:  make(map[string]string),
-		Attempt:   0,
-	}
-
 	secret, err := ctx.GetStringVariable("GithubSecret") // comes from auth
 	if err != nil {
 		return nil, err
 	}
 
-	if servicesCommon.DelayJob {
-		newJob.Delay = &iface.DelayConfig{
-			Time: int(servicesCommon.DelayJobTime),
-		}
-	}
-
 	hook, err := github.New(github.Options.Secret(secret))
 	if err != nil {
 		return nil, fmt.Errorf("creating hook failed with %w", err)
@@ -80,7 +62,7 @@ func (srv *PatrickService) githubHookHandler(ctx http.Context) (interface{}, err
 	if err != nil {
 		if err == github.ErrEventNotFound {
 			// ok event wasn't one of the ones asked to be parsed
-			return nil, errors.New("this is not a push event")
+			return nil, errNotPushEvent
 		}
 		return nil, fmt.Errorf("parsing hook failed with %w", err)
 	}
@@ -95,44 +77,13 @@ func (srv *PatrickService) githubHookHandler(ctx http.Context) (interface{}, err
 		}
 
 		// Unmarshal the needed json fields into the structure (Repository.UnmarshalJSON runs normalize)
-		err = json.Unmarshal(pl, &newJob.Meta)
+		var meta iface.Meta
+		err = json.Unmarshal(pl, &meta)
 		if err != nil {
 			return nil, fmt.Errorf("failed unmarshalling payload into struct with error: %w", err)
 		}
 
-		// Assign fields before id and branch checks
-		newJob.Meta.Repository.Provider = "github"
-		newJob.Meta.Repository.Branch = strings.Replace(newJob.Meta.Ref, "refs/heads/", "", 1)
-
-		if !slices.Contains(commonSpec.DefaultBranches, newJob.Meta.Repository.Branch) && !srv.devMode {
-			return nil, fmt.Errorf("only builds main branches %v got `%s`", commonSpec.DefaultBranches, newJob.Meta.Repository.Branch)
-		}
-
-		newJob.Id = iface.PushEventJobID(&newJob.Meta)
-
-		if existing, err := srv.getJob(ctx.Request().Context(), "/jobs/", newJob.Id); err == nil && existing != nil {
-			return existing, nil
-		}
-
-		// Pushing useful information to tns (ssh key stores effective URI for backward compat)
-		repoInfo := map[string]string{
-			"id":  fmt.Sprintf("%d", newJob.Meta.Repository.ID),
-			"ssh": newJob.Meta.Repository.URI,
-		}
-
-		err = srv.tnsClient.Push([]string{"resolve", "repo", "github", fmt.Sprintf("%d", newJob.Meta.Repository.ID)}, repoInfo)
-		if err != nil {
-			return nil, fmt.Errorf("failed registering new job repo %d into tns with error: %v", newJob.Meta.Repository.ID, err)
-		}
-
-		err = srv.RegisterJob(ctx.Request().Context(), newJob)
-		if err != nil {
-			return nil, fmt.Errorf("failed registering job with error: %w", err)
-		}
-
-		logger.Debugf("Got job: %#v", newJob)
-
-		return newJob, nil
+		return srv.dispatchPush(ctx.Request().Context(), "github", &meta)
 	default:
 		return nil, fmt.Errorf("this is not a push event. but a %T", payload)
 	}
@@ -154,7 +105,12 @@ func (srv *PatrickService) RegisterJob(ctx context.Context, newJob *iface.Job) e
 		return fmt.Errorf("failed putting job into database with error: %w", err)
 	}
 
-	err = srv.connectToProject(ctx, newJob)
+	projectID, err := srv.connectToProject(ctx, newJob)
+	if err != nil {
+		return err
+	}
+
+	queued, err := srv.scheduleJob(ctx, newJob, projectID)
 	if err != nil {
 		return err
 	}
@@ -166,12 +122,31 @@ func (srv *PatrickService) RegisterJob(ctx context.Context, newJob *iface.Job) e
 	if exists {
 		return nil
 	}
-	if err = srv.jobQueue.Push(newJob.Id, nil, 5*time.Second); err != nil {
+	if err = srv.jobQueue.Push(newJob.Id, queued, 5*time.Second); err != nil {
 		return fmt.Errorf("failed to push job onto queue: %w", err)
 	}
+
+	srv.supersedeJobs(ctx, newJob.Id)
+
 	return nil
 }
 
+// supersedeJobs cancels the jobs of the same repository and branch queued
+// before jid and not yet handed out, as jid builds a newer commit.
+func (srv *PatrickService) supersedeJobs(ctx context.Context, jid string) {
+	superseded, err := srv.jobQueue.Supersede(jid, 5*time.Second)
+	if err != nil {
+		logger.Errorf("superseding jobs older than %s failed with: %s", jid, err.Error())
+		return
+	}
+
+	for _, id := range superseded {
+		if err = srv.updateStatus(ctx, "", id, nil, iface.JobStatusCancelled, nil); err != nil {
+			logger.Errorf("cancelling job %s superseded by %s failed with: %s", id, jid, err.Error())
+		}
+	}
+}
+
 // Hook management
 func (srv *PatrickService) getHook(hookid string) (authIface.Hook, error) {
 	return srv.authClient.Hooks().Get(hookid)
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/fxamacker/cbor/v2"
 	"github.com/taubyte/tau/core/services/patrick"
 	"github.com/taubyte/tau/dream/helpers"
 	httpPkg "github.com/taubyte/tau/pkg/http"
@@ -257,6 +258,32 @@ func TestRegisterJob(t *testing.T) {
 	}
 }
 
+func TestRegisterJob_Supersede(t *testing.T) {
+	ts := createTestSetup(false)
+	ctx := context.Background()
+
+	old := createGitHubTestJob("old-job")
+	ts.mockDB.Put(ctx, "/jobs/old-job", marshalJob(old))
+
+	mq := &mockJobQueue{superseded: []string{"old-job"}}
+	ts.service.jobQueue = mq
+
+	assert.NilError(t, ts.service.RegisterJob(ctx, createGitHubTestJob("new-job")))
+	assert.Equal(t, len(mq.pushCalls), 1)
+
+	var queued queuedJob
+	assert.NilError(t, cbor.Unmarshal(mq.pushCalls[0].data, &queued))
+	assert.Equal(t, queued.Project, "project-456")
+	assert.Equal(t, queued.Priority, priorityCode)
+
+	_, err := ts.service.getJob(ctx, "/jobs/", "old-job")
+	assert.Assert(t, err != nil, "superseded job should leave /jobs/")
+
+	archived, err := ts.service.getJob(ctx, "/archive/jobs/", "old-job")
+	assert.NilError(t, err)
+	assert.Equal(t, archived.Status, patrick.JobStatusCancelled)
+}
+
 func TestGetHook(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	goHttp "net/http"
+
+	authIface "github.com/taubyte/tau/core/services/auth"
+	iface "github.com/taubyte/tau/core/services/patrick"
+)
+
+// GitLab webhook handlers
+
+type gitlabPushPayload struct {
+	ObjectKind string `json:"object_kind"`
+	Ref        string `json:"ref"`
+	Before     string `json:"before"`
+	After      string `json:"after"`
+	Project    struct {
+		ID        int    `json:"id"`
+		GitSSHURL string `json:"git_ssh_url"`
+	} `json:"project"`
+	Commits []struct {
+		ID        string `json:"id"`
+		Timestamp string `json:"timestamp"`
+	} `json:"commits"`
+}
+
+// parseGitlabPush authenticates a GitLab delivery through its X-Gitlab-Token
+// header, which carries the hook secret verbatim.
+func parseGitlabPush(header goHttp.Header, body []byte, hook *authIface.GitHook) (*iface.Meta, error) {
+	if subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), []byte(hook.Secret)) != 1 {
+		return nil, errors.New("invalid X-Gitlab-Token")
+	}
+
+	if header.Get("X-Gitlab-Event") != "Push Hook" {
+		return nil, errNotPushEvent
+	}
+
+	var payload gitlabPushPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decoding push payload failed with %w", err)
+	}
+
+	if payload.ObjectKind != "push" {
+		return nil, errNotPushEvent
+	}
+
+	if err := checkHookRepository(hook, payload.Project.ID); err != nil {
+		return nil, err
+	}
+
+	meta := &iface.Meta{
+		Ref:        payload.Ref,
+		Before:     payload.Before,
+		After:      payload.After,
+		HeadCommit: iface.HeadCommit{ID: payload.After},
+		Repository: iface.Repository{
+			ID:     payload.Project.ID,
+			SSHURL: payload.Project.GitSSHURL,
+			URI:    payload.Project.GitSSHURL,
+		},
+	}
+
+	for _, commit := range payload.Commits {
+		if commit.ID == payload.After {
+			meta.Repository.PushedAt = commitTimestamp(commit.Timestamp)
+		}
+	}
+
+	return meta, nil
+}
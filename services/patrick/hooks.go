@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	goHttp "net/http"
+	"strings"
+	"time"
+
+	authIface "github.com/taubyte/tau/core/services/auth"
+	iface "github.com/taubyte/tau/core/services/patrick"
+	http "github.com/taubyte/tau/pkg/http"
+	servicesCommon "github.com/taubyte/tau/services/common"
+
+	commonSpec "github.com/taubyte/tau/pkg/specs/common"
+)
+
+// Provider-neutral push hook pipeline
+
+// pushParser authenticates a push delivery with the hook secret, checks it is
+// for the hook's repository and extracts the job metadata from its payload.
+type pushParser func(header goHttp.Header, body []byte, hook *authIface.GitHook) (*iface.Meta, error)
+
+var errNotPushEvent = errors.New("this is not a push event")
+
+// checkHookAndExtractSecret returns a validator that loads the hook from auth,
+// makes sure it belongs to provider and exposes it as `GitHook` and its secret
+// as `HookSecret`.
+func (srv *PatrickService) checkHookAndExtractSecret(provider string) http.Handler {
+	return func(ctx http.Context) (interface{}, error) {
+		if servicesCommon.FakeSecret && srv.devMode {
+			ctx.SetVariable("HookSecret", "taubyte_secret")
+			ctx.SetVariable("GitHook", &authIface.GitHook{Provider: provider, Secret: "taubyte_secret"})
+			return nil, nil
+		}
+
+		hook_uuid, err := ctx.GetStringVariable("hook")
+		if err != nil {
+			return nil, fmt.Errorf("get string context failed with %w", err)
+		}
+
+		hook, err := srv.getHook(hook_uuid)
+		if err != nil {
+			return nil, fmt.Errorf("get hook failed with %w", err)
+		}
+
+		git_hook, err := hook.Git()
+		if err != nil {
+			return nil, fmt.Errorf("%s hook failed with %w", provider, err)
+		}
+
+		if git_hook.Provider != provider {
+			return nil, fmt.Errorf("hook `%s` belongs to %s not %s", hook_uuid, git_hook.Provider, provider)
+		}
+
+		ctx.SetVariable("HookSecret", git_hook.Secret)
+		ctx.SetVariable("GitHook", git_hook)
+
+		return nil, nil
+	}
+}
+
+// pushHookHandler returns the webhook handler for provider using parse to
+// authenticate and decode the delivery.
+func (srv *PatrickService) pushHookHandler(provider string, parse pushParser) http.Handler {
+	return func(ctx http.Context) (interface{}, error) {
+		hook, ok := ctx.Variables()["GitHook"].(*authIface.GitHook) // comes from auth
+		if !ok {
+			return nil, errors.New("no hook to check the delivery against")
+		}
+
+		meta, err := parse(ctx.Request().Header, ctx.Body(), hook)
+		if err != nil {
+			if errors.Is(err, errNotPushEvent) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("parsing hook failed with %w", err)
+		}
+
+		return srv.dispatchPush(ctx.Request().Context(), provider, meta)
+	}
+}
+
+// dispatchPush builds pushes and tears down previews of deleted branches.
+func (srv *PatrickService) dispatchPush(ctx context.Context, provider string, meta *iface.Meta) (interface{}, error) {
+	if isBranchDeletion(meta) {
+		return srv.handleBranchDeletion(ctx, provider, meta)
+	}
+
+	job, err := srv.handlePush(ctx, provider, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// handlePush turns push metadata into a job, records the repository in tns
+// and registers the job. Redeliveries of the same push return the existing job.
+func (srv *PatrickService) handlePush(ctx context.Context, provider string, meta *iface.Meta) (*iface.Job, error) {
+	newJob := &iface.Job{
+		Status:    iface.JobStatusOpen,
+		Timestamp: time.Now().Unix(),
+		Logs:      make(map[string]string),
+		AssetCid:  make(map[string]string),
+		Attempt:   0,
+		Meta:      *meta,
+	}
+
+	if servicesCommon.DelayJob {
+		newJob.Delay = &iface.DelayConfig{
+			Time: int(servicesCommon.DelayJobTime),
+		}
+	}
+
+	// Assign fields before id and branch checks
+	newJob.Meta.Repository.Provider = provider
+	newJob.Meta.Repository.Branch = strings.Replace(newJob.Meta.Ref, "refs/heads/", "", 1)
+
+	if !srv.buildsBranch(newJob.Meta.Repository.Branch) {
+		return nil, fmt.Errorf("only builds main branches %v or preview branches %v got `%s`", commonSpec.DefaultBranches, srv.previewBranches, newJob.Meta.Repository.Branch)
+	}
+
+	newJob.Id = iface.PushEventJobID(&newJob.Meta)
+
+	if existing, err := srv.getJob(ctx, "/jobs/", newJob.Id); err == nil && existing != nil {
+		return existing, nil
+	}
+
+	// Pushing useful information to tns (ssh key stores effective URI for backward compat)
+	repoInfo := map[string]string{
+		"id":  fmt.Sprintf("%d", newJob.Meta.Repository.ID),
+		"ssh": newJob.Meta.Repository.URI,
+	}
+
+	err := srv.tnsClient.Push([]string{"resolve", "repo", provider, fmt.Sprintf("%d", newJob.Meta.Repository.ID)}, repoInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed registering new job repo %d into tns with error: %v", newJob.Meta.Repository.ID, err)
+	}
+
+	err = srv.RegisterJob(ctx, newJob)
+	if err != nil {
+		return nil, fmt.Errorf("failed registering job with error: %w", err)
+	}
+
+	logger.Debugf("Got job: %#v", newJob)
+
+	return newJob, nil
+}
+
+// buildsBranch reports whether pushes to branch are built.
+func (srv *PatrickService) buildsBranch(branch string) bool {
+	return srv.devMode || commonSpec.IsDefaultBranch(branch) || commonSpec.MatchBranch(srv.previewBranches, branch)
+}
+
+// isBranchDeletion reports whether meta describes a push deleting its ref,
+// which every provider signals with an all-zero after commit.
+func isBranchDeletion(meta *iface.Meta) bool {
+	return meta.After != "" && strings.Trim(meta.After, "0") == ""
+}
+
+// handleBranchDeletion tears down the preview of a deleted preview branch by
+// clearing its current commit in tns, which stops substrate from serving it.
+// Branches with no preview, default ones included, are left alone.
+func (srv *PatrickService) handleBranchDeletion(ctx context.Context, provider string, meta *iface.Meta) (interface{}, error) {
+	branch := strings.Replace(meta.Ref, "refs/heads/", "", 1)
+	if commonSpec.IsDefaultBranch(branch) || !commonSpec.MatchBranch(srv.previewBranches, branch) {
+		return nil, nil
+	}
+
+	job := &iface.Job{Meta: *meta}
+	job.Meta.Repository.Provider = provider
+	projectID, err := srv.getProjectIDFromJob(job)
+	if err != nil {
+		return nil, fmt.Errorf("resolving project of deleted branch `%s` failed with: %w", branch, err)
+	}
+
+	// The label may never have been registered, or belong to another branch
+	// it collides with.
+	label := commonSpec.PreviewLabel(branch)
+	if commonSpec.PreviewBranch(srv.tnsClient.Fetch(commonSpec.Preview(projectID, label))) != branch {
+		logger.Debugf("Branch `%s` of project `%s` deleted, it has no preview", branch, projectID)
+		return nil, nil
+	}
+
+	err = srv.tnsClient.Push(
+		commonSpec.Current(projectID, branch).Slice(),
+		map[string]string{
+			commonSpec.CurrentCommitPathVariable.String(): "",
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("clearing current commit of preview `%s` failed with: %w", branch, err)
+	}
+
+	err = srv.tnsClient.Push(commonSpec.Preview(projectID, label).Slice(), "")
+	if err != nil {
+		return nil, fmt.Errorf("removing preview `%s` failed with: %w", branch, err)
+	}
+
+	logger.Infof("Tore down preview of branch `%s` for project `%s`", branch, projectID)
+
+	return map[string]string{"project": projectID, "branch": branch, "preview": "removed"}, nil
+}
+
+// checkHookRepository makes sure a delivery is for the repository its hook
+// was registered for. Hooks with no repository id, in dev mode, take any.
+func checkHookRepository(hook *authIface.GitHook, id int) error {
+	if hook.RepositoryId != 0 && hook.RepositoryId != id {
+		return fmt.Errorf("delivery for repository %d on a hook of repository %d", id, hook.RepositoryId)
+	}
+
+	return nil
+}
+
+// validHMACSHA256 reports whether signature is the hex encoded HMAC-SHA256 of body.
+func validHMACSHA256(body []byte, secret, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// commitTimestamp parses an RFC 3339 commit date, returning 0 when absent.
+func commitTimestamp(date string) int64 {
+	ts, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return 0
+	}
+	return ts.Unix()
+}
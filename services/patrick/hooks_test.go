@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	goHttp "net/http"
+	"testing"
+
+	"github.com/taubyte/tau/core/services/auth"
+	"github.com/taubyte/tau/core/services/patrick"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	"gotest.tools/v3/assert"
+)
+
+const testAfter = "84cac8e2c33df0ee4400aee496379745be65e8e8"
+
+var (
+	gitlabPushBody = []byte(`{
+		"object_kind": "push",
+		"ref": "refs/heads/main",
+		"before": "5ab4f01f95993cee73c598e19adae4fd32d94646",
+		"after": "` + testAfter + `",
+		"project": {"id": 12345, "git_ssh_url": "git@gitlab.com:test/repo.git"},
+		"commits": [{"id": "` + testAfter + `", "timestamp": "2026-03-24T15:29:54+00:00"}]
+	}`)
+
+	giteaPushBody = []byte(`{
+		"ref": "refs/heads/main",
+		"before": "5ab4f01f95993cee73c598e19adae4fd32d94646",
+		"after": "` + testAfter + `",
+		"head_commit": {"id": "` + testAfter + `", "timestamp": "2026-03-24T15:29:54Z"},
+		"repository": {"id": 12345, "ssh_url": "git@gitea.example.com:test/repo.git"}
+	}`)
+
+	bitbucketPushBody = []byte(`{
+		"repository": {"uuid": "{c7bc2d2b-0b5e-4b36-9a43-1f7b8c3d2e10}", "full_name": "test/repo"},
+		"push": {"changes": [{
+			"old": {"type": "branch", "name": "main", "target": {"hash": "5ab4f01f95993cee73c598e19adae4fd32d94646"}},
+			"new": {"type": "branch", "name": "main", "target": {"hash": "` + testAfter + `", "date": "2026-03-24T15:29:54+00:00"}}
+		}]}
+	}`)
+)
+
+func hmacSHA256(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseGitlabPush(t *testing.T) {
+	header := goHttp.Header{}
+	header.Set("X-Gitlab-Event", "Push Hook")
+	header.Set("X-Gitlab-Token", "secret")
+	hook := &auth.GitHook{Secret: "secret", RepositoryId: 12345}
+
+	meta, err := parseGitlabPush(header, gitlabPushBody, hook)
+	assert.NilError(t, err)
+	assert.Equal(t, meta.Ref, "refs/heads/main")
+	assert.Equal(t, meta.After, testAfter)
+	assert.Equal(t, meta.Repository.ID, 12345)
+	assert.Equal(t, meta.Repository.URI, "git@gitlab.com:test/repo.git")
+	assert.Equal(t, meta.Repository.PushedAt, int64(1774366194))
+
+	_, err = parseGitlabPush(header, gitlabPushBody, &auth.GitHook{Secret: "other"})
+	assert.ErrorContains(t, err, "invalid X-Gitlab-Token")
+
+	_, err = parseGitlabPush(header, gitlabPushBody, &auth.GitHook{Secret: "secret", RepositoryId: 54321})
+	assert.ErrorContains(t, err, "on a hook of repository 54321")
+
+	header.Set("X-Gitlab-Event", "Tag Push Hook")
+	_, err = parseGitlabPush(header, gitlabPushBody, hook)
+	assert.Assert(t, errors.Is(err, errNotPushEvent))
+}
+
+func TestParseGiteaPush(t *testing.T) {
+	for _, prefix := range []string{"X-Gitea-", "X-Forgejo-"} {
+		header := goHttp.Header{}
+		header.Set(prefix+"Event", "push")
+		header.Set(prefix+"Signature", hmacSHA256(giteaPushBody, "secret"))
+
+		meta, err := parseGiteaPush(header, giteaPushBody, &auth.GitHook{Secret: "secret", RepositoryId: 12345})
+		assert.NilError(t, err)
+		assert.Equal(t, meta.After, testAfter)
+		assert.Equal(t, meta.Repository.ID, 12345)
+		assert.Equal(t, meta.Repository.URI, "git@gitea.example.com:test/repo.git")
+		assert.Equal(t, meta.Repository.PushedAt, int64(1774366194))
+
+		_, err = parseGiteaPush(header, giteaPushBody, &auth.GitHook{Secret: "other"})
+		assert.ErrorContains(t, err, "invalid X-Gitea-Signature")
+
+		_, err = parseGiteaPush(header, giteaPushBody, &auth.GitHook{Secret: "secret", RepositoryId: 54321})
+		assert.ErrorContains(t, err, "on a hook of repository 54321")
+	}
+}
+
+func TestParseBitbucketPush(t *testing.T) {
+	header := goHttp.Header{}
+	header.Set("X-Event-Key", "repo:push")
+	header.Set("X-Hub-Signature", "sha256="+hmacSHA256(bitbucketPushBody, "secret"))
+
+	hook := &auth.GitHook{Secret: "secret", Repository: "{c7bc2d2b-0b5e-4b36-9a43-1f7b8c3d2e10}", RepositoryId: 42}
+	meta, err := parseBitbucketPush(header, bitbucketPushBody, hook)
+	assert.NilError(t, err)
+	assert.Equal(t, meta.Ref, "refs/heads/main")
+	assert.Equal(t, meta.Before, "5ab4f01f95993cee73c598e19adae4fd32d94646")
+	assert.Equal(t, meta.After, testAfter)
+	assert.Equal(t, meta.Repository.ID, 42)
+	assert.Equal(t, meta.Repository.URI, "git@bitbucket.org:test/repo.git")
+
+	_, err = parseBitbucketPush(header, bitbucketPushBody, &auth.GitHook{Secret: "other"})
+	assert.ErrorContains(t, err, "invalid X-Hub-Signature")
+
+	_, err = parseBitbucketPush(header, bitbucketPushBody, &auth.GitHook{Secret: "secret", Repository: "{x}", RepositoryId: 43})
+	assert.ErrorContains(t, err, "on a hook of repository `{x}`")
+
+	hook = &auth.GitHook{Secret: "secret", Repository: "{x}", RepositoryId: 43}
+
+	deleted := []byte(`{"repository": {"uuid": "{x}"}, "push": {"changes": [{"old": {"type": "branch", "name": "feature/x", "target": {"hash": "` + testAfter + `"}}, "new": null}]}}`)
+	header.Set("X-Hub-Signature", "sha256="+hmacSHA256(deleted, "secret"))
+	meta, err = parseBitbucketPush(header, deleted, hook)
+	assert.NilError(t, err)
+	assert.Equal(t, meta.Ref, "refs/heads/feature/x")
+	assert.Assert(t, isBranchDeletion(meta))
+
+	tag := []byte(`{"repository": {"uuid": "{x}"}, "push": {"changes": [{"new": {"type": "tag", "name": "v1"}}]}}`)
+	header.Set("X-Hub-Signature", "sha256="+hmacSHA256(tag, "secret"))
+	_, err = parseBitbucketPush(header, tag, hook)
+	assert.Assert(t, errors.Is(err, errNotPushEvent))
+}
+
+func TestBuildsBranch(t *testing.T) {
+	srv := &PatrickService{previewBranches: []string{"feature/*"}}
+	assert.Assert(t, srv.buildsBranch("main"))
+	assert.Assert(t, srv.buildsBranch("feature/login"))
+	assert.Assert(t, !srv.buildsBranch("fix/login"))
+
+	srv.devMode = true
+	assert.Assert(t, srv.buildsBranch("fix/login"))
+}
+
+func TestHandleBranchDeletion(t *testing.T) {
+	ts := createTestSetup(false)
+	ts.service.previewBranches = []string{"feature/*"}
+	ts.tnsClient.values = map[string]interface{}{
+		spec.Preview("project-456", "feature-login").String(): "feature/login",
+	}
+
+	meta := &patrick.Meta{
+		Ref:        "refs/heads/feature/login",
+		After:      "0000000000000000000000000000000000000000",
+		Repository: patrick.Repository{ID: 12345},
+	}
+
+	result, err := ts.service.dispatchPush(context.Background(), "github", meta)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, map[string]string{"project": "project-456", "branch": "feature/login", "preview": "removed"})
+
+	// feature/Login has the label of feature/login, whose preview is kept.
+	for _, ref := range []string{"refs/heads/main", "refs/heads/feature/other", "refs/heads/feature/Login"} {
+		meta.Ref = ref
+		result, err = ts.service.dispatchPush(context.Background(), "github", meta)
+		assert.NilError(t, err)
+		assert.Assert(t, result == nil, ref)
+	}
+}
+
+func TestCheckHookAndExtractSecret(t *testing.T) {
+	ts := createTestSetup(false)
+	ts.ctx.SetVariable("hook", "test-hook-1")
+	ts.authClient.hooks.hooks["test-hook-1"] = mockAuthHook{secret: "test-secret"}
+
+	_, err := ts.service.checkHookAndExtractSecret("gitlab")(ts.ctx)
+	assert.ErrorContains(t, err, "belongs to github not gitlab")
+
+	ts.authClient.hooks.hooks["test-hook-1"] = mockAuthHook{secret: "test-secret", provider: "gitlab"}
+	_, err = ts.service.checkHookAndExtractSecret("gitlab")(ts.ctx)
+	assert.NilError(t, err)
+
+	secret, err := ts.ctx.GetStringVariable("HookSecret")
+	assert.NilError(t, err)
+	assert.Equal(t, secret, "test-secret")
+
+	hook, ok := ts.ctx.Variables()["GitHook"].(*auth.GitHook)
+	assert.Assert(t, ok)
+	assert.Equal(t, hook.Provider, "gitlab")
+}
+
+func TestPushHookHandler(t *testing.T) {
+	for _, tt := range []struct {
+		provider string
+		parse    pushParser
+		headers  map[string]string
+		body     []byte
+	}{
+		{
+			provider: "gitlab",
+			parse:    parseGitlabPush,
+			headers:  map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "secret"},
+			body:     gitlabPushBody,
+		},
+		{
+			provider: "gitea",
+			parse:    parseGiteaPush,
+			headers:  map[string]string{"X-Gitea-Event": "push", "X-Gitea-Signature": hmacSHA256(giteaPushBody, "secret")},
+			body:     giteaPushBody,
+		},
+	} {
+		t.Run(tt.provider, func(t *testing.T) {
+			ts := createTestSetup(false)
+			ts.ctx.SetHeaders(tt.headers)
+			ts.ctx.SetBody(tt.body)
+			ts.ctx.SetVariable("GitHook", &auth.GitHook{Provider: tt.provider, Secret: "secret", RepositoryId: 12345})
+
+			handler := ts.service.pushHookHandler(tt.provider, tt.parse)
+
+			r1, err := handler(ts.ctx)
+			assert.NilError(t, err)
+			job, ok := r1.(*patrick.Job)
+			assert.Assert(t, ok)
+			assert.Equal(t, job.Meta.Repository.Provider, tt.provider)
+			assert.Equal(t, job.Meta.Repository.Branch, "main")
+
+			r2, err := handler(ts.ctx)
+			assert.NilError(t, err)
+			assert.Equal(t, r2.(*patrick.Job).Id, job.Id)
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	iface "github.com/taubyte/tau/core/services/patrick"
+	http "github.com/taubyte/tau/pkg/http"
+	servicesCommon "github.com/taubyte/tau/services/common"
+	"github.com/taubyte/tau/utils/maps"
+)
+
+var (
+	// JobLogFollowWait is how long a follow request waits for new output. It
+	// stays under the default timeout of the http client.
+	JobLogFollowWait = 5 * time.Second
+	// JobLogBufferSize bounds the output kept of a running job.
+	JobLogBufferSize = 4 << 20
+	// JobLogChunkSize bounds the output returned by a follow request.
+	JobLogChunkSize = 1 << 20
+	// JobLogIdle is how long the log of a job is followed with no one asking.
+	JobLogIdle = time.Minute
+	// JobLogMaxFollowers bounds the follow requests of a job waiting at once.
+	JobLogMaxFollowers = 32
+
+	jobLogReplayInterval = time.Second
+)
+
+// jobLog buffers the tail of the log a monkey publishes for a running job.
+type jobLog struct {
+	lock sync.Mutex
+	// start is the offset of data in the log.
+	start int64
+	data  []byte
+	done  bool
+	// updated is closed, then replaced, when output comes in.
+	updated chan struct{}
+
+	lastAccess time.Time
+	lastReplay time.Time
+	// followers counts the follow requests being answered.
+	followers int
+
+	publish func(*iface.JobLogChunk)
+	ctxC    context.CancelFunc
+}
+
+func newJobLog(publish func(*iface.JobLogChunk)) *jobLog {
+	return &jobLog{
+		updated:    make(chan struct{}),
+		lastAccess: time.Now(),
+		publish:    publish,
+	}
+}
+
+func (l *jobLog) end() int64 {
+	return l.start + int64(len(l.data))
+}
+
+// add takes in a chunk from the monkey. Output past what is buffered means
+// chunks were missed, so the monkey is asked to replay them.
+func (l *jobLog) add(chunk *iface.JobLogChunk) {
+	if chunk.Replay {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.done {
+		return
+	}
+
+	end := l.end()
+	if chunk.Offset > end {
+		l.replay(end)
+		return
+	}
+
+	grew := false
+	if tail := chunk.Offset + int64(len(chunk.Data)); tail > end {
+		l.data = append(l.data, chunk.Data[end-chunk.Offset:]...)
+		if over := len(l.data) - JobLogBufferSize; over > 0 {
+			l.data = l.data[over:]
+			l.start += int64(over)
+		}
+		grew = true
+	}
+
+	if chunk.Done {
+		if chunk.Offset > l.end() {
+			l.replay(l.end())
+		} else {
+			l.done = true
+			grew = true
+		}
+	}
+
+	if grew {
+		close(l.updated)
+		l.updated = make(chan struct{})
+	}
+}
+
+// replay asks the monkey for the log from offset, at most once a
+// jobLogReplayInterval. It expects the lock held.
+func (l *jobLog) replay(offset int64) {
+	if time.Since(l.lastReplay) < jobLogReplayInterval {
+		return
+	}
+
+	l.lastReplay = time.Now()
+	go l.publish(&iface.JobLogChunk{Offset: offset, Replay: true})
+}
+
+// read returns the output buffered from offset, waiting for some until ctx is
+// done. Output dropped from the buffer is skipped, so the offset returned can
+// be past the one asked.
+func (l *jobLog) read(ctx context.Context, offset int64) (int64, []byte, bool) {
+	for {
+		l.lock.Lock()
+		l.lastAccess = time.Now()
+		offset = max(offset, l.start)
+		if end := l.end(); offset < end || l.done {
+			data := l.data[min(offset, end)-l.start:]
+			data = data[:min(len(data), JobLogChunkSize)]
+			done := l.done && offset+int64(len(data)) >= end
+			l.lock.Unlock()
+			return offset, append([]byte(nil), data...), done
+		}
+
+		updated := l.updated
+		l.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return offset, nil, false
+		case <-updated:
+		}
+	}
+}
+
+// follow registers a follow request, unless JobLogMaxFollowers are already
+// being answered. Requests that follow call unfollow once answered.
+func (l *jobLog) follow() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.followers >= JobLogMaxFollowers {
+		return false
+	}
+
+	l.followers++
+	return true
+}
+
+func (l *jobLog) unfollow() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.followers--
+}
+
+func (l *jobLog) idle(now time.Time) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return now.Sub(l.lastAccess) > JobLogIdle
+}
+
+// followJobLog returns the buffer of a job, subscribing to its log the first
+// time it is asked for.
+func (srv *PatrickService) followJobLog(jid string) (*jobLog, error) {
+	srv.jobLogsLock.Lock()
+	defer srv.jobLogsLock.Unlock()
+
+	if l, ok := srv.jobLogs[jid]; ok {
+		return l, nil
+	}
+
+	topic := iface.JobLogsTopic(jid)
+	l := newJobLog(func(chunk *iface.JobLogChunk) {
+		data, err := cbor.Marshal(chunk)
+		if err == nil {
+			err = srv.node.PubSubPublish(srv.ctx, topic, data)
+		}
+		if err != nil {
+			logger.Errorf("asking for the log of `%s` failed with: %s", jid, err)
+		}
+	})
+
+	var ctx context.Context
+	ctx, l.ctxC = context.WithCancel(srv.ctx)
+	if err := srv.node.PubSubSubscribeContext(ctx, topic, func(msg *pubsub.Message) {
+		var chunk iface.JobLogChunk
+		if err := cbor.Unmarshal(msg.Data, &chunk); err != nil {
+			logger.Errorf("decoding log of `%s` from `%s` failed with: %s", jid, msg.ReceivedFrom, err)
+			return
+		}
+
+		l.add(&chunk)
+	}, func(err error) {
+		if ctx.Err() == nil {
+			logger.Errorf("log subscription of `%s` ended with: %s", jid, err)
+		}
+	}); err != nil {
+		l.ctxC()
+		return nil, err
+	}
+
+	if srv.jobLogs == nil {
+		srv.jobLogs = make(map[string]*jobLog)
+	}
+	srv.jobLogs[jid] = l
+
+	go srv.dropIdleJobLog(jid, l)
+
+	return l, nil
+}
+
+func (srv *PatrickService) dropIdleJobLog(jid string, l *jobLog) {
+	ticker := time.NewTicker(JobLogIdle / 2)
+	defer ticker.Stop()
+	defer l.ctxC()
+
+	for {
+		select {
+		case <-srv.ctx.Done():
+			return
+		case now := <-ticker.C:
+			if l.idle(now) {
+				srv.jobLogsLock.Lock()
+				delete(srv.jobLogs, jid)
+				srv.jobLogsLock.Unlock()
+				return
+			}
+		}
+	}
+}
+
+func (srv *PatrickService) setupJobLogRoutes() {
+	srv.http.GET(&http.RouteDefinition{
+		Hosts: srv.config.RouteHosts(servicesCommon.Patrick),
+		Path:  "/logs/follow/{jid}",
+		Vars: http.Variables{
+			Required: []string{"jid"},
+			Optional: []string{"offset"},
+		},
+		Auth: http.RouteAuthHandler{
+			Validator: srv.GitHubTokenHTTPAuth,
+			GC:        srv.GitHubTokenHTTPAuthCleanup,
+		},
+		Handler: srv.followJobLogHandler,
+	})
+}
+
+// followJobLogHandler returns the output of a running job from an offset,
+// waiting up to JobLogFollowWait for some. Done is set once the whole log was
+// returned, or when the job is archived: its log is then stored. Only known
+// jobs are followed, by at most JobLogMaxFollowers requests at once.
+func (srv *PatrickService) followJobLogHandler(ctx http.Context) (interface{}, error) {
+	jid, err := maps.String(ctx.Variables(), "jid")
+	if err != nil {
+		return nil, err
+	}
+
+	var offset int64
+	if value, _ := maps.String(ctx.Variables(), "offset"); value != "" {
+		if offset, err = strconv.ParseInt(value, 10, 64); err != nil || offset < 0 {
+			return nil, fmt.Errorf("invalid offset `%s`", value)
+		}
+	}
+
+	requestCtx := ctx.Request().Context()
+	if _, err = srv.db.Get(requestCtx, "/archive/jobs/"+jid); err == nil {
+		return map[string]interface{}{"offset": offset, "done": true}, nil
+	}
+
+	if _, err = srv.getJob(requestCtx, "/jobs/", jid); err != nil {
+		return nil, fmt.Errorf("job `%s` not found", jid)
+	}
+
+	l, err := srv.followJobLog(jid)
+	if err != nil {
+		return nil, fmt.Errorf("following log of `%s` failed with: %w", jid, err)
+	}
+
+	if !l.follow() {
+		return nil, fmt.Errorf("log of `%s` has too many followers", jid)
+	}
+	defer l.unfollow()
+
+	waitCtx, waitCtxC := context.WithTimeout(requestCtx, JobLogFollowWait)
+	defer waitCtxC()
+
+	offset, data, done := l.read(waitCtx, offset)
+
+	return map[string]interface{}{"offset": offset, "data": data, "done": done}, nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	iface "github.com/taubyte/tau/core/services/patrick"
+	"gotest.tools/v3/assert"
+)
+
+func TestJobLog(t *testing.T) {
+	replays := make(chan int64, 4)
+	l := newJobLog(func(chunk *iface.JobLogChunk) {
+		assert.Assert(t, chunk.Replay)
+		replays <- chunk.Offset
+	})
+
+	ctx, ctxC := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer ctxC()
+
+	offset, data, done := l.read(ctx, 0)
+	assert.Equal(t, offset, int64(0))
+	assert.Equal(t, len(data), 0)
+	assert.Assert(t, !done)
+
+	// Output past the buffer asks for a replay and is dropped.
+	l.add(&iface.JobLogChunk{Offset: 4, Data: []byte("more")})
+	assert.Equal(t, <-replays, int64(0))
+
+	l.add(&iface.JobLogChunk{Offset: 0, Data: []byte("some")})
+	l.add(&iface.JobLogChunk{Offset: 2, Data: []byte("mething")})
+
+	offset, data, done = l.read(context.Background(), 0)
+	assert.Equal(t, offset, int64(0))
+	assert.Equal(t, string(data), "something")
+	assert.Assert(t, !done)
+
+	go l.add(&iface.JobLogChunk{Offset: 9, Data: []byte("\n")})
+	offset, data, _ = l.read(context.Background(), 9)
+	assert.Equal(t, offset, int64(9))
+	assert.Equal(t, string(data), "\n")
+
+	l.add(&iface.JobLogChunk{Offset: 10, Done: true})
+	offset, data, done = l.read(context.Background(), 4)
+	assert.Equal(t, offset, int64(4))
+	assert.Equal(t, string(data), "thing\n")
+	assert.Assert(t, done)
+
+	_, data, done = l.read(context.Background(), 10)
+	assert.Equal(t, len(data), 0)
+	assert.Assert(t, done)
+}
+
+func TestJobLogBufferSize(t *testing.T) {
+	size := JobLogBufferSize
+	JobLogBufferSize = 4
+	defer func() { JobLogBufferSize = size }()
+
+	l := newJobLog(func(*iface.JobLogChunk) {})
+	l.add(&iface.JobLogChunk{Offset: 0, Data: []byte("abcdef")})
+
+	// Dropped output is skipped.
+	offset, data, _ := l.read(context.Background(), 0)
+	assert.Equal(t, offset, int64(2))
+	assert.Equal(t, string(data), "cdef")
+}
+
+func TestJobLogFollowers(t *testing.T) {
+	followers := JobLogMaxFollowers
+	JobLogMaxFollowers = 2
+	defer func() { JobLogMaxFollowers = followers }()
+
+	l := newJobLog(func(*iface.JobLogChunk) {})
+	assert.Assert(t, l.follow())
+	assert.Assert(t, l.follow())
+	assert.Assert(t, !l.follow())
+
+	l.unfollow()
+	assert.Assert(t, l.follow())
+}
+
+func TestFollowJobLogHandlerUnknownJob(t *testing.T) {
+	ts := createTestSetup(false)
+	ts.ctx.SetVariable("jid", "unknown")
+
+	_, err := ts.service.followJobLogHandler(ts.ctx)
+	assert.ErrorContains(t, err, "job `unknown` not found")
+	assert.Equal(t, len(ts.service.jobLogs), 0)
+}
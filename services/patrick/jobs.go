@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/fxamacker/cbor/v2"
+	"github.com/taubyte/tau/core/common/repositorytype"
 	"github.com/taubyte/tau/core/services/patrick"
 	ifaceTNS "github.com/taubyte/tau/core/services/tns"
+	"github.com/taubyte/tau/pkg/specs/methods"
 )
 
 // ReannounceJobs scans pending jobs and pushes forgotten or expired ones back onto the queue.
@@ -42,33 +44,136 @@ func (p *PatrickService) ReannounceJobs(ctx context.Context) error {
 		}
 	}
 
+	// Free the slots of jobs no monkey holds anymore.
+	for _, jid := range p.jobQueue.Running() {
+		if _, err := p.db.Get(ctx, "/assigned/"+jid); err != nil {
+			p.finishJob(jid)
+		}
+	}
+
 	return nil
 }
 
 // republishJob pushes a job back onto the queue (idempotent by id).
 func (p *PatrickService) republishJob(ctx context.Context, jid string) error {
-	if err := p.jobQueue.Push(jid, nil, 5*time.Second); err != nil {
+	if err := p.jobQueue.Push(jid, p.queuedJob(ctx, jid), 5*time.Second); err != nil {
 		return fmt.Errorf("failed to re-push job in republishJob: %w", err)
 	}
 	return nil
 }
 
-func (srv *PatrickService) connectToProject(ctx context.Context, job *patrick.Job) error {
+func (srv *PatrickService) connectToProject(ctx context.Context, job *patrick.Job) (string, error) {
 	projectID, err := srv.getProjectIDFromJob(job)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	err = srv.db.Put(ctx, fmt.Sprintf("/by/project/%s/%s", projectID, job.Id), []byte{})
 	if err != nil {
-		return fmt.Errorf("failed putting job into project with error: %w", err)
+		return "", fmt.Errorf("failed putting job into project with error: %w", err)
 	}
 
-	return nil
+	return projectID, nil
+}
+
+// scheduleJob records how the job is queued: by its project, its repository
+// and branch, and the kind of the repository. It returns the data to push it with.
+func (srv *PatrickService) scheduleJob(ctx context.Context, job *patrick.Job, projectID string) ([]byte, error) {
+	repo := job.Meta.Repository
+	data, err := cbor.Marshal(queuedJob{
+		Project:    projectID,
+		Priority:   srv.repositoryPriority(job, projectID),
+		Repository: fmt.Sprintf("%s/%d/%s", strings.ToLower(repo.Provider), repo.ID, repo.Branch),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal queued job %s failed with: %w", job.Id, err)
+	}
+
+	if err = srv.db.Put(ctx, "/queued/"+job.Id, data); err != nil {
+		return nil, fmt.Errorf("failed putting queued job %s with error: %w", job.Id, err)
+	}
+
+	return data, nil
+}
+
+// queuedJob returns the data the job was first queued with, so it keeps its
+// project and priority when pushed again. Jobs of older patricks have none.
+func (srv *PatrickService) queuedJob(ctx context.Context, jid string) []byte {
+	data, err := srv.db.Get(ctx, "/queued/"+jid)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// retriedJob returns the data to push a job retried by hand with, ahead of
+// the jobs of its kind.
+func (srv *PatrickService) retriedJob(ctx context.Context, jid string) []byte {
+	job := queuedJob{Priority: priorityCode}
+	if data := srv.queuedJob(ctx, jid); data != nil {
+		if err := cbor.Unmarshal(data, &job); err != nil {
+			logger.Errorf("unmarshal queued job %s failed with: %s", jid, err.Error())
+		}
+	}
+
+	job.Priority = priorityRetry
+	data, err := cbor.Marshal(job)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// repositoryPriority ranks the job by the kind of repository it builds:
+// config ahead of code and libraries, ahead of websites.
+func (srv *PatrickService) repositoryPriority(job *patrick.Job, projectID string) jobPriority {
+	repo := job.Meta.Repository
+	if project := srv.authClient.Projects().Get(projectID); project != nil {
+		switch repo.ID {
+		case project.Git.Config.Id():
+			return priorityConfig
+		case project.Git.Code.Id():
+			return priorityCode
+		}
+	}
+
+	repoPath, err := methods.GetRepositoryPath(strings.ToLower(repo.Provider), fmt.Sprintf("%d", repo.ID), projectID)
+	if err != nil {
+		return priorityCode
+	}
+
+	obj, err := srv.tnsClient.Fetch(repoPath.Type())
+	if err != nil {
+		return priorityCode
+	}
+
+	var repoType repositorytype.Type
+	switch v := obj.Interface().(type) {
+	case int64:
+		repoType = repositorytype.Type(v)
+	case uint64:
+		repoType = repositorytype.Type(v)
+	}
+
+	if repoType == repositorytype.WebsiteRepository {
+		return priorityWebsite
+	}
+
+	return priorityCode
+}
+
+// finishJob drops a job that will not run again from the queue, freeing its
+// project's slot. A slot left held is freed by ReannounceJobs.
+func (p *PatrickService) finishJob(jid string) {
+	if err := p.jobQueue.Done(jid, 5*time.Second); err != nil {
+		logger.Errorf("releasing job %s from the queue failed with: %s", jid, err.Error())
+	}
 }
 
 func (srv *PatrickService) getProjectIDFromJob(job *patrick.Job) (projectID string, err error) {
-	repo, _ := srv.authClient.Repositories().Github().Get(job.Meta.Repository.ID)
+	repo, _ := srv.authClient.Repositories().Git(job.Meta.Repository.GitProvider()).Get(job.Meta.Repository.ID)
 
 	if repo != nil {
 		projectID = repo.Project()
@@ -76,7 +181,7 @@ func (srv *PatrickService) getProjectIDFromJob(job *patrick.Job) (projectID stri
 
 	if len(projectID) == 0 {
 		repo := job.Meta.Repository
-		queryKey := []string{"repositories", strings.ToLower(repo.Provider), fmt.Sprintf("%d", repo.ID)}
+		queryKey := []string{"repositories", repo.GitProvider(), fmt.Sprintf("%d", repo.ID)}
 
 		var resp interface{}
 		resp, err = srv.tnsClient.Lookup(ifaceTNS.Query{Prefix: queryKey, RegEx: false})
@@ -61,6 +61,18 @@ func (m *mockAuthClient) Hooks() auth.Hooks {
 	return m.hooks
 }
 
+func (m *mockAuthClient) Projects() auth.Projects {
+	return mockProjects{}
+}
+
+type mockProjects struct {
+	auth.Projects
+}
+
+func (mockProjects) Get(id string) *auth.Project {
+	return nil
+}
+
 type mockHooks struct {
 	auth.Hooks
 	hooks map[string]mockAuthHook
@@ -75,7 +87,8 @@ func (m mockHooks) Get(hookid string) (auth.Hook, error) {
 
 type mockAuthHook struct {
 	auth.Hook
-	secret string
+	secret   string
+	provider string
 }
 
 func (m *mockAuthHook) Github() (*auth.GithubHook, error) {
@@ -86,6 +99,14 @@ func (m *mockAuthHook) Bitbucket() (*auth.BitbucketHook, error) {
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockAuthHook) Git() (*auth.GitHook, error) {
+	provider := m.provider
+	if provider == "" {
+		provider = "github"
+	}
+	return &auth.GitHook{Provider: provider, Secret: m.secret}, nil
+}
+
 type mockRepositories struct {
 	auth.Repositories
 	repos map[int]mockRepo
@@ -95,6 +116,10 @@ func (m mockRepositories) Github() auth.GithubRepositories {
 	return mockGithubRepos{repos: m.repos}
 }
 
+func (m mockRepositories) Git(provider string) auth.GitRepositories {
+	return mockGithubRepos{repos: m.repos}
+}
+
 type mockGithubRepos struct {
 	auth.GithubRepositories
 	repos map[int]mockRepo
@@ -125,6 +150,7 @@ type mockTNSClient struct {
 	lookupResponse interface{}
 	lookupError    error
 	pushError      error
+	values         map[string]interface{}
 }
 
 func (m *mockTNSClient) Lookup(query tns.Query) (interface{}, error) {
@@ -135,6 +161,15 @@ func (m *mockTNSClient) Push(path []string, data interface{}) error {
 	return m.pushError
 }
 
+func (m *mockTNSClient) Fetch(path tns.Path) (tns.Object, error) {
+	value, ok := m.values[path.String()]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+
+	return deploymentsObject{value: value}, nil
+}
+
 func createTestJob(id string) *patrick.Job {
 	return &patrick.Job{
 		Id:        id,
@@ -350,7 +385,7 @@ func TestConnectToProject(t *testing.T) {
 
 			tt.setupMocks(mockDB.(*mock.KVDB), mockAuth, mockTNS)
 
-			err := srv.connectToProject(context.Background(), tt.job)
+			_, err := srv.connectToProject(context.Background(), tt.job)
 
 			if tt.expectedError != "" {
 				assert.ErrorContains(t, err, tt.expectedError)
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	iface "github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/p2p/streams"
+	"github.com/taubyte/tau/p2p/streams/command"
+	cr "github.com/taubyte/tau/p2p/streams/command/response"
+	http "github.com/taubyte/tau/pkg/http"
+	servicesCommon "github.com/taubyte/tau/services/common"
+	"github.com/taubyte/tau/utils/maps"
+)
+
+var (
+	// FunctionLogRetention is how long the logs of a call are kept.
+	FunctionLogRetention = 7 * 24 * time.Hour
+	// FunctionLogLimit is the number of calls kept per function.
+	FunctionLogLimit = 1000
+	// FunctionLogPageSize bounds the calls returned by a query.
+	FunctionLogPageSize = 200
+
+	functionLogSweepInterval = time.Minute
+)
+
+const (
+	functionLogsPrefix = "/logs/functions/"
+	// functionLogsActivePrefix indexes the functions with logs, by the start
+	// time of their latest call, so a sweep visits functions and not calls.
+	functionLogsActivePrefix = "/logs/active/"
+
+	// functionLogTimeDigits pads the start time leading a log key so keys sort
+	// by time.
+	functionLogTimeDigits = 20
+)
+
+func functionLogsKey(projectId, functionId string) string {
+	return functionLogsPrefix + projectId + "/" + functionId + "/"
+}
+
+func functionLogsActiveKey(projectId, functionId string) string {
+	return functionLogsActivePrefix + projectId + "/" + functionId
+}
+
+// functionLogsServiceHandler stores a batch of logs pushed by a substrate node.
+// Every patrick node stores the batches it is pushed, the raft leader trims
+// them.
+func (srv *PatrickService) functionLogsServiceHandler(ctx context.Context, conn streams.Connection, body command.Body) (cr.Response, error) {
+	from := conn.RemotePeer()
+	if srv.substratePeers == nil || !srv.substratePeers.Has(from) {
+		return nil, fmt.Errorf("peer `%s` is not a substrate node", from)
+	}
+
+	data, err := maps.ByteArray(body, "logs")
+	if err != nil {
+		return nil, err
+	}
+
+	var batch []*iface.FunctionLog
+	if err = cbor.Unmarshal(data, &batch); err != nil {
+		return nil, fmt.Errorf("decoding logs from `%s` failed with: %w", from, err)
+	}
+
+	for _, entry := range batch {
+		if entry != nil {
+			entry.Node = from.String()
+		}
+	}
+
+	if err = srv.storeFunctionLogs(ctx, batch); err != nil {
+		return nil, fmt.Errorf("storing logs from `%s` failed with: %w", from, err)
+	}
+
+	return nil, nil
+}
+
+func (srv *PatrickService) storeFunctionLogs(ctx context.Context, logs []*iface.FunctionLog) error {
+	batch, err := srv.db.Batch(ctx)
+	if err != nil {
+		return err
+	}
+
+	latest := make(map[string]int64)
+	for i, entry := range logs {
+		if entry == nil || entry.ProjectId == "" || entry.FunctionId == "" {
+			continue
+		}
+
+		active := functionLogsActiveKey(entry.ProjectId, entry.FunctionId)
+		latest[active] = max(latest[active], entry.Started)
+
+		data, err := cbor.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("marshalling log of `%s` failed with: %w", entry.FunctionId, err)
+		}
+
+		key := fmt.Sprintf("%s%0*d-%s-%d", functionLogsKey(entry.ProjectId, entry.FunctionId), functionLogTimeDigits, entry.Started, entry.Node, i)
+		if err = batch.Put(key, data); err != nil {
+			return err
+		}
+	}
+
+	for active, started := range latest {
+		if err = batch.Put(active, []byte(strconv.FormatInt(started, 10))); err != nil {
+			return err
+		}
+	}
+
+	return batch.Commit()
+}
+
+// functionLogStarted returns the start time leading a log key.
+func functionLogStarted(key string) (int64, bool) {
+	name := key[strings.LastIndex(key, "/")+1:]
+	if len(name) < functionLogTimeDigits {
+		return 0, false
+	}
+
+	started, err := strconv.ParseInt(name[:functionLogTimeDigits], 10, 64)
+	return started, err == nil
+}
+
+// functionLogs returns, oldest first, the logs of calls started at or after
+// since, or the latest ones when since is zero. At most FunctionLogPageSize
+// are returned: the oldest after since, so a caller can page forward.
+func (srv *PatrickService) functionLogs(ctx context.Context, projectId, functionId string, since int64) ([]*iface.FunctionLog, error) {
+	keys, err := srv.db.List(ctx, functionLogsKey(projectId, functionId))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(keys)
+	if since > 0 {
+		keys = keys[sort.Search(len(keys), func(i int) bool {
+			started, _ := functionLogStarted(keys[i])
+			return started >= since
+		}):]
+		keys = keys[:min(len(keys), FunctionLogPageSize)]
+	} else {
+		keys = keys[max(0, len(keys)-FunctionLogPageSize):]
+	}
+
+	logs := make([]*iface.FunctionLog, 0, len(keys))
+	for _, key := range keys {
+		data, err := srv.db.Get(ctx, key)
+		if err != nil {
+			// Dropped by a sweep since listed.
+			continue
+		}
+
+		entry := new(iface.FunctionLog)
+		if err = cbor.Unmarshal(data, entry); err != nil {
+			return nil, fmt.Errorf("decoding `%s` failed with: %w", key, err)
+		}
+
+		logs = append(logs, entry)
+	}
+
+	return logs, nil
+}
+
+func (srv *PatrickService) sweepFunctionLogs() {
+	ticker := time.NewTicker(functionLogSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-srv.ctx.Done():
+			return
+		case now := <-ticker.C:
+			if !srv.raftCluster.IsLeader() {
+				continue
+			}
+
+			if err := srv.trimFunctionLogs(srv.ctx, now); err != nil {
+				logger.Errorf("trimming function logs failed with: %s", err)
+			}
+		}
+	}
+}
+
+// functionLogsTrim is what a function's logs were left at by the last trim:
+// the latest call then and the oldest one kept.
+type functionLogsTrim struct {
+	latest string
+	oldest int64
+}
+
+// trimFunctionLogs drops the logs older than FunctionLogRetention, and the
+// oldest of each function past FunctionLogLimit. Functions with no call since
+// their last trim and nothing expired are skipped without listing their logs.
+func (srv *PatrickService) trimFunctionLogs(ctx context.Context, now time.Time) error {
+	active, err := srv.db.List(ctx, functionLogsActivePrefix)
+	if err != nil {
+		return err
+	}
+
+	if srv.functionLogsTrims == nil {
+		srv.functionLogsTrims = make(map[string]functionLogsTrim)
+	}
+
+	cutoff := now.Add(-FunctionLogRetention).UnixNano()
+	for _, key := range active {
+		latest, err := srv.db.Get(ctx, key)
+		if err != nil {
+			continue
+		}
+
+		if trim, ok := srv.functionLogsTrims[key]; ok && trim.latest == string(latest) && trim.oldest >= cutoff {
+			continue
+		}
+
+		function := strings.TrimPrefix(key, functionLogsActivePrefix)
+		oldest, err := srv.trimFunction(ctx, functionLogsPrefix+function+"/", cutoff)
+		if err != nil {
+			return fmt.Errorf("trimming logs of `%s` failed with: %w", function, err)
+		}
+
+		if oldest == 0 {
+			delete(srv.functionLogsTrims, key)
+			if err = srv.db.Delete(ctx, key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		srv.functionLogsTrims[key] = functionLogsTrim{latest: string(latest), oldest: oldest}
+	}
+
+	return nil
+}
+
+// trimFunction drops the logs under prefix started before cutoff or past
+// FunctionLogLimit, and returns the start of the oldest one kept, 0 if none.
+func (srv *PatrickService) trimFunction(ctx context.Context, prefix string, cutoff int64) (int64, error) {
+	keys, err := srv.db.List(ctx, prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	sort.Strings(keys)
+
+	var (
+		oldest int64
+		drop   []string
+	)
+	// Newest first, so the count is of the calls kept after it.
+	for i := len(keys) - 1; i >= 0; i-- {
+		started, ok := functionLogStarted(keys[i])
+		if !ok || started < cutoff || len(keys)-1-i-len(drop) >= FunctionLogLimit {
+			drop = append(drop, keys[i])
+			continue
+		}
+
+		oldest = started
+	}
+
+	if len(drop) == 0 {
+		return oldest, nil
+	}
+
+	batch, err := srv.db.Batch(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range drop {
+		if err = batch.Delete(key); err != nil {
+			return 0, err
+		}
+	}
+
+	return oldest, batch.Commit()
+}
+
+func (srv *PatrickService) setupFunctionLogRoutes() {
+	srv.http.GET(&http.RouteDefinition{
+		Hosts: srv.config.RouteHosts(servicesCommon.Patrick),
+		Path:  "/logs/functions/{projectId}/{functionId}",
+		Vars: http.Variables{
+			Required: []string{"projectId", "functionId"},
+			Optional: []string{"since"},
+		},
+		Auth: http.RouteAuthHandler{
+			Validator: srv.GitHubTokenHTTPAuth,
+			GC:        srv.GitHubTokenHTTPAuthCleanup,
+		},
+		Handler: srv.functionLogsHandler,
+	})
+}
+
+func (srv *PatrickService) functionLogsHandler(ctx http.Context) (interface{}, error) {
+	projectId, err := maps.String(ctx.Variables(), "projectId")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = srv.projectAccess(ctx, projectId); err != nil {
+		return nil, err
+	}
+
+	functionId, err := maps.String(ctx.Variables(), "functionId")
+	if err != nil {
+		return nil, err
+	}
+
+	var since int64
+	if value, _ := maps.String(ctx.Variables(), "since"); value != "" {
+		if since, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid since `%s`", value)
+		}
+	}
+
+	logs, err := srv.functionLogs(ctx.Request().Context(), projectId, functionId, since)
+	if err != nil {
+		return nil, fmt.Errorf("getting logs of `%s` failed with: %w", functionId, err)
+	}
+
+	return map[string]interface{}{"logs": logs}, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	iface "github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/pkg/kvdb/mock"
+	"gotest.tools/v3/assert"
+)
+
+func newLogsTestService(t *testing.T) *PatrickService {
+	db, err := mock.New().New(nil, "test", 0)
+	assert.NilError(t, err)
+
+	return &PatrickService{db: db}
+}
+
+func functionLog(function string, started time.Time) *iface.FunctionLog {
+	return &iface.FunctionLog{
+		ProjectId:  "project",
+		FunctionId: function,
+		Node:       "node",
+		Started:    started.UnixNano(),
+		Stdout:     started.Format(time.TimeOnly),
+	}
+}
+
+func TestFunctionLogs(t *testing.T) {
+	ctx := context.Background()
+	srv := newLogsTestService(t)
+	start := time.Date(2026, time.March, 14, 10, 0, 0, 0, time.UTC)
+
+	batch := []*iface.FunctionLog{nil, {FunctionId: "function"}}
+	for i := range 5 {
+		batch = append(batch, functionLog("function", start.Add(time.Duration(4-i)*time.Second)))
+	}
+	batch = append(batch, functionLog("other", start))
+	assert.NilError(t, srv.storeFunctionLogs(ctx, batch))
+
+	logs, err := srv.functionLogs(ctx, "project", "function", 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(logs), 5)
+	assert.Equal(t, logs[0].Stdout, "10:00:00")
+	assert.Equal(t, logs[4].Stdout, "10:00:04")
+
+	logs, err = srv.functionLogs(ctx, "project", "function", start.Add(3*time.Second).UnixNano())
+	assert.NilError(t, err)
+	assert.Equal(t, len(logs), 2)
+	assert.Equal(t, logs[0].Stdout, "10:00:03")
+
+	pageSize := FunctionLogPageSize
+	FunctionLogPageSize = 2
+	defer func() { FunctionLogPageSize = pageSize }()
+
+	logs, err = srv.functionLogs(ctx, "project", "function", 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(logs), 2)
+	assert.Equal(t, logs[0].Stdout, "10:00:03")
+
+	logs, err = srv.functionLogs(ctx, "project", "function", start.UnixNano())
+	assert.NilError(t, err)
+	assert.Equal(t, len(logs), 2)
+	assert.Equal(t, logs[1].Stdout, "10:00:01")
+}
+
+func TestTrimFunctionLogs(t *testing.T) {
+	ctx := context.Background()
+	srv := newLogsTestService(t)
+	start := time.Date(2026, time.March, 14, 10, 0, 0, 0, time.UTC)
+
+	limit := FunctionLogLimit
+	FunctionLogLimit = 3
+	defer func() { FunctionLogLimit = limit }()
+
+	batch := []*iface.FunctionLog{functionLog("other", start.Add(-FunctionLogRetention))}
+	for i := range 5 {
+		batch = append(batch, functionLog("function", start.Add(time.Duration(i)*time.Second)))
+	}
+	assert.NilError(t, srv.storeFunctionLogs(ctx, batch))
+
+	assert.NilError(t, srv.trimFunctionLogs(ctx, start.Add(time.Second)))
+
+	logs, err := srv.functionLogs(ctx, "project", "function", 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(logs), 3)
+	assert.Equal(t, logs[0].Stdout, "10:00:02")
+
+	logs, err = srv.functionLogs(ctx, "project", "other", 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(logs), 0)
+
+	// Only functions with logs left are visited by the next trims.
+	active, err := srv.db.List(ctx, functionLogsActivePrefix)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, active, []string{functionLogsActiveKey("project", "function")})
+
+	assert.NilError(t, srv.storeFunctionLogs(ctx, []*iface.FunctionLog{functionLog("function", start.Add(5*time.Second))}))
+	assert.NilError(t, srv.trimFunctionLogs(ctx, start.Add(time.Second)))
+
+	logs, err = srv.functionLogs(ctx, "project", "function", 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(logs), 3)
+	assert.Equal(t, logs[0].Stdout, "10:00:03")
+}
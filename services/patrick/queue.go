@@ -0,0 +1,432 @@
+package service
+
+import (
+	"encoding/binary"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taubyte/tau/pkg/raft"
+)
+
+const jobQueueKeyPrefix = "_jobs"
+
+// jobPriority orders queued jobs; higher ones are handed out first.
+type jobPriority int
+
+const (
+	priorityWebsite jobPriority = iota
+	priorityCode
+	priorityConfig
+	// priorityRetry is given to jobs retried by hand.
+	priorityRetry
+)
+
+// queuedJob is what the queue schedules a job by. It is the data pushed with
+// a job id; nil data schedules the job as code of no project. Jobs of no
+// project are left out of the project limit, as they share no project.
+type queuedJob struct {
+	Project  string      `cbor:"1,keyasint"`
+	Priority jobPriority `cbor:"2,keyasint"`
+	// Repository is the repository and branch the job builds. Queued jobs of
+	// it are superseded by a newer push.
+	Repository string `cbor:"3,keyasint"`
+}
+
+// queueItem is a queued job as stored in the cluster.
+type queueItem struct {
+	Id  string    `cbor:"1,keyasint"`
+	Seq uint64    `cbor:"2,keyasint"`
+	Job queuedJob `cbor:"3,keyasint"`
+}
+
+// jobQueue is the queue monkeys take jobs from. On top of raft.Queue it
+// tracks the jobs handed out until they are done.
+type jobQueue interface {
+	raft.Queue
+	// Done drops the job from the queue and frees its project's slot.
+	Done(id string, timeout time.Duration) error
+	// Supersede drops the jobs of id's repository queued before it and
+	// returns their ids.
+	Supersede(id string, timeout time.Duration) ([]string, error)
+	// Running returns the ids of the jobs handed out and not yet done.
+	Running() []string
+}
+
+// fairQueue is a jobQueue backed by the cluster's KV primitives. Pop hands
+// out a job of the project served least recently, so no project starves
+// another with jobs of higher priority; then the one of highest priority,
+// then the oldest. Projects running projectLimit jobs are skipped. A project
+// left with no queued or running job is forgotten, and counts as never
+// served once it queues jobs again.
+type fairQueue struct {
+	cluster       raft.Cluster
+	projectLimit  int
+	counterKey    string
+	servedKey     string
+	itemsPrefix   string
+	indexPrefix   string
+	runningPrefix string
+	servedPrefix  string
+	closed        atomic.Bool
+	mu            sync.Mutex
+}
+
+// newJobQueue returns a jobQueue named name. A projectLimit of 0 leaves the
+// jobs run at once per project unbounded.
+func newJobQueue(cluster raft.Cluster, name string, projectLimit int) jobQueue {
+	prefix := path.Join(jobQueueKeyPrefix, name)
+	return &fairQueue{
+		cluster:       cluster,
+		projectLimit:  projectLimit,
+		counterKey:    path.Join(prefix, "_counter"),
+		servedKey:     path.Join(prefix, "_served"),
+		itemsPrefix:   path.Join(prefix, "items") + "/",
+		indexPrefix:   path.Join(prefix, "idx") + "/",
+		runningPrefix: path.Join(prefix, "running") + "/",
+		servedPrefix:  path.Join(prefix, "served") + "/",
+	}
+}
+
+func (q *fairQueue) itemKey(seq uint64) string {
+	return q.itemsPrefix + fmt.Sprintf("%020d", seq)
+}
+
+func (q *fairQueue) counter(key string) uint64 {
+	data, ok := q.cluster.Get(key)
+	if !ok || len(data) < 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data)
+}
+
+func encodeCounter(n uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, n)
+	return buf
+}
+
+func applyTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 || timeout > raft.MaxApplyTimeout {
+		return raft.MaxApplyTimeout
+	}
+	return timeout
+}
+
+func (q *fairQueue) Push(id string, data []byte, timeout time.Duration) error {
+	if q.closed.Load() {
+		return raft.ErrShutdown
+	}
+	if id == "" {
+		return fmt.Errorf("queue item id must be non-empty")
+	}
+
+	item := queueItem{Id: id, Job: queuedJob{Priority: priorityCode}}
+	if len(data) > 0 {
+		if err := cbor.Unmarshal(data, &item.Job); err != nil {
+			return fmt.Errorf("decoding queued job %s failed with: %w", id, err)
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, exists := q.cluster.Get(q.indexPrefix + id); exists {
+		return nil
+	}
+
+	item.Seq = q.counter(q.counterKey) + 1
+	raw, err := cbor.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("encoding queued job %s failed with: %w", id, err)
+	}
+
+	// A job pushed again is no longer running: it timed out or is retried.
+	return q.cluster.Batch([]raft.BatchOp{
+		{Set: &raft.SetCommand{Key: q.counterKey, Value: encodeCounter(item.Seq)}},
+		{Set: &raft.SetCommand{Key: q.itemKey(item.Seq), Value: raw}},
+		{Set: &raft.SetCommand{Key: q.indexPrefix + id, Value: []byte(fmt.Sprintf("%020d", item.Seq))}},
+		{Delete: &raft.DeleteCommand{Key: q.runningPrefix + id}},
+	}, applyTimeout(timeout))
+}
+
+func (q *fairQueue) Pop(timeout time.Duration) (string, []byte, error) {
+	if q.closed.Load() {
+		return "", nil, raft.ErrShutdown
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	item, err := q.next()
+	if err != nil {
+		return "", nil, err
+	}
+
+	data, err := cbor.Marshal(item.Job)
+	if err != nil {
+		return "", nil, fmt.Errorf("encoding queued job %s failed with: %w", item.Id, err)
+	}
+
+	served := q.counter(q.servedKey) + 1
+	err = q.cluster.Batch([]raft.BatchOp{
+		{Delete: &raft.DeleteCommand{Key: q.itemKey(item.Seq)}},
+		{Delete: &raft.DeleteCommand{Key: q.indexPrefix + item.Id}},
+		{Set: &raft.SetCommand{Key: q.runningPrefix + item.Id, Value: []byte(item.Job.Project)}},
+		{Set: &raft.SetCommand{Key: q.servedKey, Value: encodeCounter(served)}},
+		{Set: &raft.SetCommand{Key: q.servedPrefix + item.Job.Project, Value: encodeCounter(served)}},
+	}, applyTimeout(timeout))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return item.Id, data, nil
+}
+
+func (q *fairQueue) Peek() (string, []byte, bool) {
+	if q.closed.Load() {
+		return "", nil, false
+	}
+
+	item, err := q.next()
+	if err != nil {
+		return "", nil, false
+	}
+
+	data, err := cbor.Marshal(item.Job)
+	if err != nil {
+		return "", nil, false
+	}
+
+	return item.Id, data, true
+}
+
+func (q *fairQueue) Len() int {
+	if q.closed.Load() {
+		return 0
+	}
+	return len(q.cluster.Keys(q.itemsPrefix))
+}
+
+func (q *fairQueue) Close() error {
+	q.closed.Store(true)
+	return nil
+}
+
+func (q *fairQueue) Done(id string, timeout time.Duration) error {
+	if q.closed.Load() {
+		return raft.ErrShutdown
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var project string
+	ops := make([]raft.BatchOp, 0, 4)
+	if seq, ok := q.cluster.Get(q.indexPrefix + id); ok {
+		if raw, ok := q.cluster.Get(q.itemsPrefix + string(seq)); ok {
+			var item queueItem
+			if err := cbor.Unmarshal(raw, &item); err == nil {
+				project = item.Job.Project
+			}
+		}
+
+		ops = append(ops,
+			raft.BatchOp{Delete: &raft.DeleteCommand{Key: q.itemsPrefix + string(seq)}},
+			raft.BatchOp{Delete: &raft.DeleteCommand{Key: q.indexPrefix + id}},
+		)
+	}
+
+	if raw, ok := q.cluster.Get(q.runningPrefix + id); ok {
+		project = string(raw)
+		ops = append(ops, raft.BatchOp{Delete: &raft.DeleteCommand{Key: q.runningPrefix + id}})
+	}
+
+	if len(ops) == 0 {
+		return nil
+	}
+
+	active, err := q.active(project, id)
+	if err != nil {
+		return err
+	}
+
+	if !active {
+		ops = append(ops, raft.BatchOp{Delete: &raft.DeleteCommand{Key: q.servedPrefix + project}})
+	}
+
+	return q.cluster.Batch(ops, applyTimeout(timeout))
+}
+
+func (q *fairQueue) Supersede(id string, timeout time.Duration) ([]string, error) {
+	if q.closed.Load() {
+		return nil, raft.ErrShutdown
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	items, err := q.items()
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *queueItem
+	for i := range items {
+		if items[i].Id == id {
+			latest = &items[i]
+			break
+		}
+	}
+
+	if latest == nil || latest.Job.Repository == "" {
+		return nil, nil
+	}
+
+	var (
+		ids []string
+		ops []raft.BatchOp
+	)
+	for _, item := range items {
+		if item.Job.Repository != latest.Job.Repository || item.Seq >= latest.Seq {
+			continue
+		}
+
+		ids = append(ids, item.Id)
+		ops = append(ops,
+			raft.BatchOp{Delete: &raft.DeleteCommand{Key: q.itemKey(item.Seq)}},
+			raft.BatchOp{Delete: &raft.DeleteCommand{Key: q.indexPrefix + item.Id}},
+		)
+	}
+
+	if len(ops) == 0 {
+		return nil, nil
+	}
+
+	if err = q.cluster.Batch(ops, applyTimeout(timeout)); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (q *fairQueue) Running() []string {
+	keys := q.cluster.Keys(q.runningPrefix)
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, strings.TrimPrefix(key, q.runningPrefix))
+	}
+
+	sort.Strings(ids)
+	return ids
+}
+
+// active reports whether project has jobs other than id queued or running.
+func (q *fairQueue) active(project, id string) (bool, error) {
+	for _, key := range q.cluster.Keys(q.runningPrefix) {
+		if strings.TrimPrefix(key, q.runningPrefix) == id {
+			continue
+		}
+
+		if running, ok := q.cluster.Get(key); ok && string(running) == project {
+			return true, nil
+		}
+	}
+
+	items, err := q.items()
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range items {
+		if item.Id != id && item.Job.Project == project {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// items returns the queued jobs, oldest first.
+func (q *fairQueue) items() ([]queueItem, error) {
+	keys := q.cluster.Keys(q.itemsPrefix)
+	sort.Strings(keys)
+
+	items := make([]queueItem, 0, len(keys))
+	for _, key := range keys {
+		raw, ok := q.cluster.Get(key)
+		if !ok {
+			continue
+		}
+
+		var item queueItem
+		if err := cbor.Unmarshal(raw, &item); err != nil {
+			return nil, fmt.Errorf("corrupt queue entry %s: %w", key, err)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// next returns the job Pop hands out, or raft.ErrQueueEmpty when every
+// queued job belongs to a project at its limit.
+func (q *fairQueue) next() (queueItem, error) {
+	items, err := q.items()
+	if err != nil {
+		return queueItem{}, err
+	}
+
+	running := make(map[string]int)
+	if q.projectLimit > 0 {
+		for _, key := range q.cluster.Keys(q.runningPrefix) {
+			if project, ok := q.cluster.Get(key); ok {
+				running[string(project)]++
+			}
+		}
+	}
+
+	served := make(map[string]uint64)
+	lastServed := func(project string) uint64 {
+		if n, ok := served[project]; ok {
+			return n
+		}
+		served[project] = q.counter(q.servedPrefix + project)
+		return served[project]
+	}
+
+	var (
+		best  queueItem
+		found bool
+	)
+	for _, item := range items {
+		if q.projectLimit > 0 && item.Job.Project != "" && running[item.Job.Project] >= q.projectLimit {
+			continue
+		}
+
+		switch {
+		case !found:
+		case lastServed(item.Job.Project) != lastServed(best.Job.Project):
+			if lastServed(item.Job.Project) > lastServed(best.Job.Project) {
+				continue
+			}
+		case item.Job.Priority <= best.Job.Priority:
+			// items are oldest first, so ties keep the older job.
+			continue
+		}
+
+		best, found = item, true
+	}
+
+	if !found {
+		return queueItem{}, raft.ErrQueueEmpty
+	}
+
+	return best, nil
+}
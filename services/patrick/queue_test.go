@@ -0,0 +1,188 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taubyte/tau/pkg/raft"
+	"gotest.tools/v3/assert"
+)
+
+func pushJob(t *testing.T, q jobQueue, id string, job queuedJob) {
+	data, err := cbor.Marshal(job)
+	assert.NilError(t, err)
+	assert.NilError(t, q.Push(id, data, time.Second))
+}
+
+func popOrder(t *testing.T, q jobQueue) []string {
+	var ids []string
+	for {
+		id, _, err := q.Pop(time.Second)
+		if err == raft.ErrQueueEmpty {
+			return ids
+		}
+		assert.NilError(t, err)
+		ids = append(ids, id)
+	}
+}
+
+func TestJobQueue_Priority(t *testing.T) {
+	q := newJobQueue(raft.NewMockCluster(), "test", 0)
+	defer q.Close()
+
+	pushJob(t, q, "website", queuedJob{Project: "p", Priority: priorityWebsite})
+	pushJob(t, q, "code", queuedJob{Project: "p", Priority: priorityCode})
+	pushJob(t, q, "config", queuedJob{Project: "p", Priority: priorityConfig})
+	pushJob(t, q, "retry", queuedJob{Project: "p", Priority: priorityRetry})
+
+	id, _, ok := q.Peek()
+	assert.Assert(t, ok)
+	assert.Equal(t, id, "retry")
+
+	assert.DeepEqual(t, popOrder(t, q), []string{"retry", "config", "code", "website"})
+}
+
+func TestJobQueue_Fairness(t *testing.T) {
+	q := newJobQueue(raft.NewMockCluster(), "test", 0)
+	defer q.Close()
+
+	for _, id := range []string{"a1", "a2", "a3"} {
+		pushJob(t, q, id, queuedJob{Project: "a", Priority: priorityCode})
+	}
+	pushJob(t, q, "b1", queuedJob{Project: "b", Priority: priorityCode})
+
+	id, data, err := q.Pop(time.Second)
+	assert.NilError(t, err)
+	assert.Equal(t, id, "a1")
+
+	var job queuedJob
+	assert.NilError(t, cbor.Unmarshal(data, &job))
+	assert.Equal(t, job.Project, "a")
+
+	pushJob(t, q, "c1", queuedJob{Project: "c", Priority: priorityCode})
+
+	assert.DeepEqual(t, popOrder(t, q), []string{"b1", "c1", "a2", "a3"})
+}
+
+func TestJobQueue_FairnessBeforePriority(t *testing.T) {
+	q := newJobQueue(raft.NewMockCluster(), "test", 0)
+	defer q.Close()
+
+	for _, id := range []string{"a1", "a2", "a3"} {
+		pushJob(t, q, id, queuedJob{Project: "a", Priority: priorityRetry})
+	}
+	pushJob(t, q, "b1", queuedJob{Project: "b", Priority: priorityWebsite})
+	pushJob(t, q, "b2", queuedJob{Project: "b", Priority: priorityConfig})
+
+	// b's jobs aren't held back by a's retries, but still go by priority.
+	assert.DeepEqual(t, popOrder(t, q), []string{"a1", "b2", "a2", "b1", "a3"})
+}
+
+func TestJobQueue_ProjectLimit(t *testing.T) {
+	q := newJobQueue(raft.NewMockCluster(), "test", 1)
+	defer q.Close()
+
+	pushJob(t, q, "a1", queuedJob{Project: "a", Priority: priorityConfig})
+	pushJob(t, q, "a2", queuedJob{Project: "a", Priority: priorityConfig})
+	pushJob(t, q, "b1", queuedJob{Project: "b", Priority: priorityWebsite})
+
+	assert.DeepEqual(t, popOrder(t, q), []string{"a1", "b1"})
+	assert.Equal(t, q.Len(), 1)
+	assert.DeepEqual(t, q.Running(), []string{"a1", "b1"})
+
+	assert.NilError(t, q.Done("a1", time.Second))
+	assert.DeepEqual(t, popOrder(t, q), []string{"a2"})
+
+	// a timed out job is pushed again, freeing its slot
+	pushJob(t, q, "b1", queuedJob{Project: "b", Priority: priorityWebsite})
+	assert.DeepEqual(t, q.Running(), []string{"a2"})
+	assert.DeepEqual(t, popOrder(t, q), []string{"b1"})
+}
+
+func TestJobQueue_ProjectLimitNoProject(t *testing.T) {
+	q := newJobQueue(raft.NewMockCluster(), "test", 1)
+	defer q.Close()
+
+	assert.NilError(t, q.Push("legacy1", nil, time.Second))
+	assert.NilError(t, q.Push("legacy2", nil, time.Second))
+
+	assert.DeepEqual(t, popOrder(t, q), []string{"legacy1", "legacy2"})
+}
+
+func TestJobQueue_ForgetsIdleProjects(t *testing.T) {
+	cluster := raft.NewMockCluster()
+	q := newJobQueue(cluster, "test", 0)
+	defer q.Close()
+
+	servedPrefix := q.(*fairQueue).servedPrefix
+
+	pushJob(t, q, "a1", queuedJob{Project: "a"})
+	pushJob(t, q, "a2", queuedJob{Project: "a"})
+	pushJob(t, q, "b1", queuedJob{Project: "b"})
+	assert.DeepEqual(t, popOrder(t, q), []string{"a1", "b1", "a2"})
+	assert.Equal(t, len(cluster.Keys(servedPrefix)), 2)
+
+	assert.NilError(t, q.Done("a1", time.Second))
+	assert.Equal(t, len(cluster.Keys(servedPrefix)), 2)
+
+	assert.NilError(t, q.Done("a2", time.Second))
+	assert.NilError(t, q.Done("b1", time.Second))
+	assert.Equal(t, len(cluster.Keys(servedPrefix)), 0)
+}
+
+func TestJobQueue_Done(t *testing.T) {
+	q := newJobQueue(raft.NewMockCluster(), "test", 0)
+	defer q.Close()
+
+	pushJob(t, q, "queued", queuedJob{Project: "p"})
+	assert.NilError(t, q.Done("queued", time.Second))
+	assert.Equal(t, q.Len(), 0)
+
+	assert.NilError(t, q.Done("unknown", time.Second))
+
+	// done jobs can be queued again, e.g. when retried
+	assert.NilError(t, q.Push("queued", nil, time.Second))
+	assert.Equal(t, q.Len(), 1)
+
+	_, data, err := q.Pop(time.Second)
+	assert.NilError(t, err)
+
+	var job queuedJob
+	assert.NilError(t, cbor.Unmarshal(data, &job))
+	assert.Equal(t, job.Priority, priorityCode)
+}
+
+func TestJobQueue_Supersede(t *testing.T) {
+	q := newJobQueue(raft.NewMockCluster(), "test", 0)
+	defer q.Close()
+
+	pushJob(t, q, "old", queuedJob{Project: "p", Repository: "github/1/main"})
+	pushJob(t, q, "other", queuedJob{Project: "p", Repository: "github/1/dev"})
+	pushJob(t, q, "new", queuedJob{Project: "p", Repository: "github/1/main"})
+	pushJob(t, q, "newer", queuedJob{Project: "p", Repository: "github/1/main"})
+
+	ids, err := q.Supersede("new", time.Second)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ids, []string{"old"})
+
+	ids, err = q.Supersede("newer", time.Second)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ids, []string{"new"})
+
+	ids, err = q.Supersede("unknown", time.Second)
+	assert.NilError(t, err)
+	assert.Equal(t, len(ids), 0)
+
+	assert.DeepEqual(t, popOrder(t, q), []string{"other", "newer"})
+}
+
+func TestJobQueue_Closed(t *testing.T) {
+	q := newJobQueue(raft.NewMockCluster(), "test", 0)
+	assert.NilError(t, q.Close())
+
+	assert.ErrorIs(t, q.Push("job", nil, time.Second), raft.ErrShutdown)
+	_, _, err := q.Pop(time.Second)
+	assert.ErrorIs(t, err, raft.ErrShutdown)
+	assert.Equal(t, q.Len(), 0)
+}
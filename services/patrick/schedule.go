@@ -0,0 +1,428 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/fxamacker/cbor/v2"
+	iface "github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/core/services/tns"
+	"github.com/taubyte/tau/p2p/streams/client"
+	"github.com/taubyte/tau/pkg/cron"
+	"github.com/taubyte/tau/pkg/raft"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/utils/maps"
+)
+
+var (
+	// ScheduleRefreshInterval is how often the leader reloads scheduled functions from tns.
+	ScheduleRefreshInterval = time.Minute
+	// ScheduleMisfireGrace is how late a tick may still fire. Older ticks are
+	// recorded as missed instead.
+	ScheduleMisfireGrace = time.Minute
+	// ScheduleHistoryLimit is the number of runs kept per function.
+	ScheduleHistoryLimit = 100
+
+	scheduleCheckInterval = time.Second
+	// scheduleCallGrace is added to a function's timeout to bound a dispatch.
+	scheduleCallGrace = 10 * time.Second
+	// scheduleMaxCoalesce bounds the missed ticks counted after a long outage.
+	scheduleMaxCoalesce = 1 << 16
+)
+
+const (
+	scheduleLastPrefix = "/schedule/last/"
+	scheduleRunsPrefix = "/schedule/runs/"
+
+	scheduleWriteTimeout = 5 * time.Second
+)
+
+type scheduledFunction struct {
+	project     string
+	application string
+	config      *structureSpec.Function
+	schedule    *cron.Schedule
+	location    *time.Location
+}
+
+// scheduler fires the ticks of scheduled functions from the raft leader. The
+// last tick handled is committed to raft before a tick is dispatched, so a new
+// leader picks up where the old one stopped and each tick fires once.
+type scheduler struct {
+	cluster raft.Cluster
+	// dispatch runs a tick on a substrate node, returning the node's id.
+	dispatch func(context.Context, *scheduledFunction, *iface.ScheduledRun) (string, error)
+	list     func() (map[string]*scheduledFunction, error)
+	now      func() time.Time
+
+	functions map[string]*scheduledFunction
+	refreshed time.Time
+
+	wg sync.WaitGroup
+}
+
+func (s *scheduler) run(ctx context.Context) {
+	ticker := time.NewTicker(scheduleCheckInterval)
+	defer ticker.Stop()
+	defer s.wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !s.cluster.IsLeader() {
+			s.refreshed = time.Time{}
+			continue
+		}
+
+		if err := s.check(ctx); err != nil {
+			logger.Errorf("checking schedules failed with: %s", err)
+		}
+	}
+}
+
+// check refreshes the functions when due, then fires every tick that came due.
+func (s *scheduler) check(ctx context.Context) error {
+	now := s.now()
+	if now.Sub(s.refreshed) >= ScheduleRefreshInterval {
+		// A new leader may not have applied every entry of the old one yet.
+		if s.refreshed.IsZero() {
+			if err := s.cluster.Barrier(scheduleWriteTimeout); err != nil {
+				return fmt.Errorf("barrier failed with: %w", err)
+			}
+		}
+
+		functions, err := s.list()
+		if err != nil {
+			return fmt.Errorf("listing scheduled functions failed with: %w", err)
+		}
+
+		s.functions = functions
+		s.refreshed = now
+		s.forget()
+	}
+
+	for id, fn := range s.functions {
+		if err := s.fire(ctx, id, fn, now); err != nil {
+			logger.Errorf("firing `%s` failed with: %s", id, err)
+		}
+	}
+
+	return nil
+}
+
+// forget drops the state of functions that are no longer scheduled, so one
+// that comes back starts from scratch. Run history is kept.
+func (s *scheduler) forget() {
+	for _, key := range s.cluster.Keys(scheduleLastPrefix) {
+		if _, ok := s.functions[strings.TrimPrefix(key, scheduleLastPrefix)]; !ok {
+			if err := s.cluster.Delete(key, scheduleWriteTimeout); err != nil {
+				logger.Errorf("deleting `%s` failed with: %s", key, err)
+			}
+		}
+	}
+}
+
+func (s *scheduler) fire(ctx context.Context, id string, fn *scheduledFunction, now time.Time) error {
+	lastKey := scheduleLastPrefix + id
+
+	data, ok := s.cluster.Get(lastKey)
+	if !ok || len(data) < 8 {
+		// Seen for the first time: start from now rather than from the past.
+		return s.cluster.Set(lastKey, encodeTick(now), scheduleWriteTimeout)
+	}
+
+	last := time.Unix(0, int64(binary.BigEndian.Uint64(data))).In(fn.location)
+
+	tick := fn.schedule.Next(last)
+	if tick.IsZero() || tick.After(now) {
+		return nil
+	}
+
+	// Coalesce every tick missed while no leader was firing into the latest.
+	var missed int
+	for missed < scheduleMaxCoalesce {
+		next := fn.schedule.Next(tick)
+		if next.IsZero() || next.After(now) {
+			break
+		}
+		tick = next
+		missed++
+	}
+
+	run := &iface.ScheduledRun{
+		ProjectId:   fn.project,
+		Application: fn.application,
+		FunctionId:  id,
+		Scheduled:   tick.UnixNano(),
+		Missed:      missed,
+	}
+
+	late := now.Sub(tick) > ScheduleMisfireGrace
+	if late {
+		run.Status = iface.RunMissed
+	} else {
+		run.Status = iface.RunRunning
+		run.Started = now.UnixNano()
+	}
+
+	runData, err := cbor.Marshal(run)
+	if err != nil {
+		return err
+	}
+
+	if err = s.cluster.Batch([]raft.BatchOp{
+		{Set: &raft.SetCommand{Key: lastKey, Value: encodeTick(tick)}},
+		{Set: &raft.SetCommand{Key: runKey(id, tick), Value: runData}},
+	}, scheduleWriteTimeout); err != nil {
+		return err
+	}
+
+	if !late {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.call(ctx, fn, run)
+		}()
+	}
+
+	s.trim(id)
+
+	return nil
+}
+
+// call dispatches a tick, and records how it went.
+func (s *scheduler) call(ctx context.Context, fn *scheduledFunction, run *iface.ScheduledRun) {
+	node, err := s.dispatch(ctx, fn, run)
+
+	run.Node = node
+	run.Finished = s.now().UnixNano()
+	run.Status = iface.RunSucceeded
+	if err != nil {
+		run.Status = iface.RunFailed
+		run.Error = err.Error()
+	}
+
+	data, err := cbor.Marshal(run)
+	if err == nil {
+		err = s.cluster.Set(runKey(run.FunctionId, time.Unix(0, run.Scheduled)), data, scheduleWriteTimeout)
+	}
+	if err != nil {
+		logger.Errorf("recording run of `%s` failed with: %s", run.FunctionId, err)
+	}
+}
+
+// trim deletes the oldest runs of a function past ScheduleHistoryLimit.
+func (s *scheduler) trim(id string) {
+	keys := s.cluster.Keys(runsPrefix(id))
+	if len(keys) <= ScheduleHistoryLimit {
+		return
+	}
+
+	sort.Strings(keys)
+
+	ops := make([]raft.BatchOp, 0, len(keys)-ScheduleHistoryLimit)
+	for _, key := range keys[:len(keys)-ScheduleHistoryLimit] {
+		ops = append(ops, raft.BatchOp{Delete: &raft.DeleteCommand{Key: key}})
+	}
+
+	if err := s.cluster.Batch(ops, scheduleWriteTimeout); err != nil {
+		logger.Errorf("trimming runs of `%s` failed with: %s", id, err)
+	}
+}
+
+// scheduledRuns returns the recorded runs of a function, newest first.
+func scheduledRuns(cluster raft.Cluster, functionId string) ([]*iface.ScheduledRun, error) {
+	keys := cluster.Keys(runsPrefix(functionId))
+	sort.Strings(keys)
+
+	runs := make([]*iface.ScheduledRun, 0, len(keys))
+	for i := len(keys) - 1; i >= 0; i-- {
+		data, ok := cluster.Get(keys[i])
+		if !ok {
+			continue
+		}
+
+		run := new(iface.ScheduledRun)
+		if err := cbor.Unmarshal(data, run); err != nil {
+			return nil, fmt.Errorf("decoding `%s` failed with: %w", keys[i], err)
+		}
+
+		runs = append(runs, run)
+	}
+
+	return runs, nil
+}
+
+func runsPrefix(functionId string) string {
+	return scheduleRunsPrefix + functionId + "/"
+}
+
+// runKey is zero padded so keys sort like their ticks.
+func runKey(functionId string, tick time.Time) string {
+	return fmt.Sprintf("%s%020d", runsPrefix(functionId), tick.UnixNano())
+}
+
+func encodeTick(t time.Time) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(t.UnixNano()))
+	return buf
+}
+
+func newScheduledFunction(project, application string, config *structureSpec.Function) (*scheduledFunction, error) {
+	schedule, err := cron.Parse(config.Cron)
+	if err != nil {
+		return nil, err
+	}
+
+	location := time.UTC
+	if len(config.Timezone) > 0 {
+		if location, err = time.LoadLocation(config.Timezone); err != nil {
+			return nil, err
+		}
+	}
+
+	return &scheduledFunction{
+		project:     project,
+		application: application,
+		config:      config,
+		schedule:    schedule,
+		location:    location,
+	}, nil
+}
+
+// dispatchSchedule has a single substrate node call the function, waiting for
+// it to finish or time out.
+func (srv *PatrickService) dispatchSchedule(ctx context.Context, fn *scheduledFunction, run *iface.ScheduledRun) (string, error) {
+	timeout := time.Duration(fn.config.Timeout) + scheduleCallGrace
+
+	resCh, err := srv.substrateClient.RunSchedule(
+		run.ProjectId, run.Application, run.FunctionId,
+		time.Unix(0, run.Scheduled), run.Missed,
+		client.Timeout(timeout), client.Threshold(1),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	select {
+	case res := <-resCh:
+		if res == nil {
+			return "", fmt.Errorf("timed out after %s", timeout)
+		}
+		defer res.Close()
+
+		return res.PID().String(), res.Error()
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// scheduledFunctions lists the scheduled functions of every project, global
+// and per application, on their default branch. Failing to read a project
+// fails the listing, so the scheduler never takes a partial one for functions
+// being unscheduled.
+func (srv *PatrickService) scheduledFunctions() (map[string]*scheduledFunction, error) {
+	resp, err := srv.tnsClient.Lookup(tns.Query{Prefix: []string{spec.ProjectPathVariable.String()}})
+	if err != nil {
+		return nil, fmt.Errorf("listing projects failed with: %w", err)
+	}
+
+	keys, _ := resp.([]string)
+
+	projects := make(map[string]struct{})
+	for _, key := range keys {
+		parts := strings.Split(strings.TrimPrefix(key, "/"), "/")
+		if len(parts) > 1 && parts[0] == spec.ProjectPathVariable.String() {
+			projects[parts[1]] = struct{}{}
+		}
+	}
+
+	functions := make(map[string]*scheduledFunction)
+	add := func(project, application string, list map[string]*structureSpec.Function) {
+		for id, config := range list {
+			if config.Type != "schedule" {
+				continue
+			}
+
+			fn, err := newScheduledFunction(project, application, config)
+			if err != nil {
+				logger.Errorf("scheduling function `%s` of project `%s` failed with: %s", id, project, err)
+				continue
+			}
+
+			functions[id] = fn
+		}
+	}
+
+	for project := range projects {
+		deployed, err := srv.deployedOnDefaultBranch(project)
+		if err != nil {
+			return nil, err
+		} else if !deployed {
+			continue
+		}
+
+		list, _, _, err := srv.tnsClient.Function().Global(project, spec.DefaultBranches...).List()
+		if err != nil {
+			return nil, fmt.Errorf("listing functions of project `%s` failed with: %w", project, err)
+		}
+		add(project, "", list)
+
+		applications, err := srv.projectApplications(project)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, application := range applications {
+			list, _, _, err := srv.tnsClient.Function().Relative(project, application, spec.DefaultBranches...).List()
+			if err != nil {
+				return nil, fmt.Errorf("listing functions of application `%s` of project `%s` failed with: %w", application, project, err)
+			}
+			add(project, application, list)
+		}
+	}
+
+	return functions, nil
+}
+
+// deployedOnDefaultBranch reports whether a project has a current commit on
+// one of its default branches.
+func (srv *PatrickService) deployedOnDefaultBranch(project string) (bool, error) {
+	for _, branch := range spec.DefaultBranches {
+		obj, err := srv.tnsClient.Fetch(spec.Current(project, branch))
+		if err != nil {
+			return false, fmt.Errorf("fetching current commit of project `%s` failed with: %w", project, err)
+		}
+
+		if commit, _ := obj.Interface().(string); commit != "" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (srv *PatrickService) projectApplications(project string) ([]string, error) {
+	obj, err := srv.tnsClient.Simple().Project(project, spec.DefaultBranches...)
+	if err != nil {
+		return nil, fmt.Errorf("fetching project `%s` failed with: %w", project, err)
+	}
+
+	apps, ok := maps.SafeInterfaceToStringKeys(obj)[spec.ApplicationPathVariable.String()]
+	if !ok {
+		return nil, nil
+	}
+
+	return maps.Keys(maps.SafeInterfaceToStringKeys(apps)), nil
+}
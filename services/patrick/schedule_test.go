@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	iface "github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/pkg/raft"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"gotest.tools/v3/assert"
+)
+
+type fakeDispatcher struct {
+	mu    sync.Mutex
+	ticks []time.Time
+	err   error
+}
+
+func (d *fakeDispatcher) dispatch(_ context.Context, _ *scheduledFunction, run *iface.ScheduledRun) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ticks = append(d.ticks, time.Unix(0, run.Scheduled))
+	return "node", d.err
+}
+
+func (d *fakeDispatcher) fired() []time.Time {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]time.Time{}, d.ticks...)
+}
+
+func newTestScheduler(t *testing.T, cluster raft.Cluster, d *fakeDispatcher, now *time.Time, expr string) *scheduler {
+	fn, err := newScheduledFunction("project", "", &structureSpec.Function{
+		Id:       "function",
+		Type:     "schedule",
+		Cron:     expr,
+		Timezone: "Europe/Paris",
+	})
+	assert.NilError(t, err)
+
+	return &scheduler{
+		cluster:  cluster,
+		dispatch: d.dispatch,
+		list: func() (map[string]*scheduledFunction, error) {
+			return map[string]*scheduledFunction{"function": fn}, nil
+		},
+		now: func() time.Time { return *now },
+	}
+}
+
+func (s *scheduler) checkAndWait(t *testing.T) {
+	assert.NilError(t, s.check(context.Background()))
+	s.wg.Wait()
+}
+
+func TestSchedulerFiresOnce(t *testing.T) {
+	cluster := raft.NewMockCluster()
+	d := new(fakeDispatcher)
+	now := time.Date(2026, time.March, 14, 10, 17, 42, 0, time.UTC)
+	s := newTestScheduler(t, cluster, d, &now, "*/5 * * * *")
+
+	// A new function starts from now.
+	s.checkAndWait(t)
+	assert.Equal(t, len(d.fired()), 0)
+
+	now = time.Date(2026, time.March, 14, 10, 20, 3, 0, time.UTC)
+	s.checkAndWait(t)
+	s.checkAndWait(t)
+	assert.DeepEqual(t, d.fired(), []time.Time{time.Date(2026, time.March, 14, 10, 20, 0, 0, time.UTC)})
+
+	// A new leader carries on from the committed tick.
+	newTestScheduler(t, cluster, d, &now, "*/5 * * * *").checkAndWait(t)
+	assert.Equal(t, len(d.fired()), 1)
+
+	runs, err := scheduledRuns(cluster, "function")
+	assert.NilError(t, err)
+	assert.Equal(t, len(runs), 1)
+	assert.Equal(t, runs[0].Status, iface.RunSucceeded)
+	assert.Equal(t, runs[0].Node, "node")
+	assert.Equal(t, runs[0].ProjectId, "project")
+	assert.Equal(t, runs[0].Finished, now.UnixNano())
+}
+
+func TestSchedulerMisfire(t *testing.T) {
+	cluster := raft.NewMockCluster()
+	d := new(fakeDispatcher)
+	now := time.Date(2026, time.March, 14, 10, 0, 30, 0, time.UTC)
+	s := newTestScheduler(t, cluster, d, &now, "0 * * * *")
+	s.checkAndWait(t)
+
+	// The latest tick is past the grace: it is recorded, not run.
+	now = time.Date(2026, time.March, 14, 12, 30, 0, 0, time.UTC)
+	s.checkAndWait(t)
+	assert.Equal(t, len(d.fired()), 0)
+
+	runs, err := scheduledRuns(cluster, "function")
+	assert.NilError(t, err)
+	assert.Equal(t, len(runs), 1)
+	assert.Equal(t, runs[0].Status, iface.RunMissed)
+	assert.Equal(t, runs[0].Missed, 1)
+	assert.Equal(t, runs[0].Scheduled, time.Date(2026, time.March, 14, 12, 0, 0, 0, time.UTC).UnixNano())
+
+	// Missed ticks are coalesced into a latest one within the grace.
+	now = time.Date(2026, time.March, 14, 15, 0, 20, 0, time.UTC)
+	s.checkAndWait(t)
+	assert.DeepEqual(t, d.fired(), []time.Time{time.Date(2026, time.March, 14, 15, 0, 0, 0, time.UTC)})
+
+	runs, err = scheduledRuns(cluster, "function")
+	assert.NilError(t, err)
+	assert.Equal(t, len(runs), 2)
+	assert.Equal(t, runs[0].Status, iface.RunSucceeded)
+	assert.Equal(t, runs[0].Missed, 2)
+}
+
+func TestSchedulerHistory(t *testing.T) {
+	limit := ScheduleHistoryLimit
+	ScheduleHistoryLimit = 3
+	defer func() { ScheduleHistoryLimit = limit }()
+
+	cluster := raft.NewMockCluster()
+	d := &fakeDispatcher{err: errors.New("boom")}
+	now := time.Date(2026, time.March, 14, 10, 0, 30, 0, time.UTC)
+	s := newTestScheduler(t, cluster, d, &now, "* * * * *")
+	s.checkAndWait(t)
+
+	for range 5 {
+		now = now.Add(time.Minute)
+		s.checkAndWait(t)
+	}
+	assert.Equal(t, len(d.fired()), 5)
+
+	runs, err := scheduledRuns(cluster, "function")
+	assert.NilError(t, err)
+	assert.Equal(t, len(runs), 3)
+	assert.Equal(t, runs[0].Scheduled, time.Date(2026, time.March, 14, 10, 5, 0, 0, time.UTC).UnixNano())
+	assert.Equal(t, runs[2].Scheduled, time.Date(2026, time.March, 14, 10, 3, 0, 0, time.UTC).UnixNano())
+	assert.Equal(t, runs[0].Status, iface.RunFailed)
+	assert.Equal(t, runs[0].Error, "boom")
+}
+
+func TestSchedulerKeepsStateOnListError(t *testing.T) {
+	cluster := raft.NewMockCluster()
+	d := new(fakeDispatcher)
+	now := time.Date(2026, time.March, 14, 10, 0, 30, 0, time.UTC)
+	s := newTestScheduler(t, cluster, d, &now, "* * * * *")
+	s.checkAndWait(t)
+
+	s.list = func() (map[string]*scheduledFunction, error) {
+		return nil, errors.New("tns unreachable")
+	}
+
+	now = now.Add(ScheduleRefreshInterval)
+	assert.ErrorContains(t, s.check(context.Background()), "tns unreachable")
+	s.wg.Wait()
+	assert.Equal(t, len(d.fired()), 0)
+
+	_, ok := cluster.Get(scheduleLastPrefix + "function")
+	assert.Assert(t, ok)
+}
+
+func TestScheduledFunctionsFailsOnTnsError(t *testing.T) {
+	srv := &PatrickService{tnsClient: &mockTNSClient{
+		lookupResponse: []string{"/projects/project/branches/main/current"},
+	}}
+
+	_, err := srv.scheduledFunctions()
+	assert.ErrorContains(t, err, "fetching current commit of project `project` failed")
+}
@@ -10,14 +10,16 @@ import (
 	"github.com/ipfs/go-log/v2"
 	authAPI "github.com/taubyte/tau/clients/p2p/auth"
 	monkeyApi "github.com/taubyte/tau/clients/p2p/monkey"
+	seerApi "github.com/taubyte/tau/clients/p2p/seer"
+	substrateApi "github.com/taubyte/tau/clients/p2p/substrate"
 	tnsApi "github.com/taubyte/tau/clients/p2p/tns"
 	tauConfig "github.com/taubyte/tau/pkg/config"
 	"github.com/taubyte/tau/pkg/kvdb"
-	"github.com/taubyte/tau/pkg/raft"
 	servicesCommon "github.com/taubyte/tau/services/common"
 	"github.com/taubyte/tau/services/common/httpsvc"
 
 	kvdbIface "github.com/taubyte/tau/core/kvdb"
+	seerIface "github.com/taubyte/tau/core/services/seer"
 
 	"github.com/taubyte/tau/p2p/peer"
 	streamClient "github.com/taubyte/tau/p2p/streams/client"
@@ -41,6 +43,7 @@ func New(ctx context.Context, cfg tauConfig.Config) (*PatrickService, error) {
 
 	var err error
 	srv.devMode = cfg.DevMode()
+	srv.previewBranches = cfg.PreviewBranches()
 	srv.reAnnounceJobTime = DefaultReAnnounceJobTime
 	if srv.devMode {
 		srv.reAnnounceJobTime = 5 * time.Second
@@ -50,7 +53,8 @@ func New(ctx context.Context, cfg tauConfig.Config) (*PatrickService, error) {
 		return nil, fmt.Errorf("raft cluster is required")
 	}
 	srv.raftCluster = cfg.RaftCluster()
-	srv.jobQueue = raft.NewQueue(cfg.RaftCluster(), "patrick")
+	// Jobs left in the former FIFO queue are pushed to this one by ReannounceJobs.
+	srv.jobQueue = newJobQueue(cfg.RaftCluster(), "patrick", cfg.ProjectBuilds())
 
 	if srv.node = cfg.Node(); srv.node == nil {
 		srv.node, err = tauConfig.NewNode(srv.ctx, cfg, path.Join(cfg.Root(), servicesCommon.Patrick))
@@ -84,6 +88,21 @@ func New(ctx context.Context, cfg tauConfig.Config) (*PatrickService, error) {
 	if srv.outboundClient, err = streamClient.New(srv.node, servicesCommon.PatrickProtocol); err != nil {
 		return nil, fmt.Errorf("creating outbound patrick client: %w", err)
 	}
+	if srv.substrateClient, err = substrateApi.New(srv.ctx, clientNode, substrateApi.Threshold(1)); err != nil {
+		return nil, fmt.Errorf("creating substrate client: %w", err)
+	}
+	seerClient, err := seerApi.New(srv.ctx, clientNode, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating seer client: %w", err)
+	}
+	srv.substratePeers = servicesCommon.NewServicePeers(seerClient.Usage(), seerIface.ServiceTypeSubstrate)
+	srv.scheduler = &scheduler{
+		cluster:  srv.raftCluster,
+		dispatch: srv.dispatchSchedule,
+		list:     srv.scheduledFunctions,
+		now:      time.Now,
+	}
+	go srv.scheduler.run(srv.ctx)
 	go srv.runClusterHeartbeat()
 	if srv.stream, err = streams.New(srv.node, servicesCommon.Patrick, servicesCommon.PatrickProtocol); err != nil {
 		return nil, fmt.Errorf("failed stream new with error: %w", err)
@@ -91,6 +110,7 @@ func New(ctx context.Context, cfg tauConfig.Config) (*PatrickService, error) {
 
 	srv.config = cfg
 	srv.setupStreamRoutes()
+	go srv.sweepFunctionLogs()
 	srv.stream.Start()
 
 	// HTTP
@@ -174,6 +194,9 @@ func (srv *PatrickService) Close() error {
 	if srv.outboundClient != nil {
 		srv.outboundClient.Close()
 	}
+	if srv.substrateClient != nil {
+		srv.substrateClient.Close()
+	}
 
 	srv.stream.Stop()
 	srv.db.Close()
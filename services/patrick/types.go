@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	iface "github.com/taubyte/tau/core/services/patrick"
@@ -13,11 +14,13 @@ import (
 	auth "github.com/taubyte/tau/core/services/auth"
 
 	monkey "github.com/taubyte/tau/core/services/monkey"
+	substrate "github.com/taubyte/tau/core/services/substrate"
 	tns "github.com/taubyte/tau/core/services/tns"
 
 	"github.com/taubyte/tau/core/kvdb"
 	streamClient "github.com/taubyte/tau/p2p/streams/client"
 	"github.com/taubyte/tau/pkg/raft"
+	servicesCommon "github.com/taubyte/tau/services/common"
 )
 
 var _ iface.Service = &PatrickService{}
@@ -34,6 +37,8 @@ type PatrickService struct {
 	db           kvdb.KVDB
 	dbFactory    kvdb.Factory
 	devMode      bool
+	// previewBranches are glob patterns of non-default branches built as previews.
+	previewBranches []string
 	// reAnnounceJobTime is captured per-service at construction (from the
 	// DefaultReAnnounceJobTime default, or 5s in dev mode). It replaces a former
 	// runtime mutation of the package global, which raced across concurrent
@@ -42,9 +47,20 @@ type PatrickService struct {
 
 	cluster        string
 	raftCluster    raft.Cluster
-	jobQueue       raft.Queue
+	jobQueue       jobQueue
 	outboundClient *streamClient.Client
 
+	substrateClient substrate.ProxyClient
+	scheduler       *scheduler
+
+	// substratePeers are the nodes allowed to push function logs.
+	substratePeers    *servicesCommon.ServicePeers
+	functionLogsTrims map[string]functionLogsTrim
+
+	// jobLogs buffers the output of the running jobs being followed.
+	jobLogs     map[string]*jobLog
+	jobLogsLock sync.Mutex
+
 	config tauConfig.Config
 }
 
@@ -9,11 +9,19 @@ import (
 	"github.com/ipfs/go-datastore/query"
 	"github.com/libp2p/go-libp2p/core/peer"
 	iface "github.com/taubyte/tau/core/services/seer"
+	servicesCommon "github.com/taubyte/tau/services/common"
 	"github.com/taubyte/tau/utils/maps"
 )
 
-func (h *dnsHandler) getServiceIpWithCache(ctx context.Context, proto string, filter func(string, int64, *iface.UsageData) bool) ([]string, error) {
-	it := h.serverIPCache.Get(proto)
+// getServiceAddrWithCache returns the addresses nodes running proto announced
+// under metaKey.
+func (h *dnsHandler) getServiceAddrWithCache(ctx context.Context, proto, metaKey string, filter func(string, int64, *iface.UsageData) bool) ([]string, error) {
+	cacheKey := proto
+	if metaKey != servicesCommon.IPMetaKey {
+		cacheKey += "/" + metaKey
+	}
+
+	it := h.serverIPCache.Get(cacheKey)
 	if it != nil && len(it.Value()) > 0 {
 		return it.Value(), nil
 	}
@@ -30,7 +38,7 @@ func (h *dnsHandler) getServiceIpWithCache(ctx context.Context, proto string, fi
 
 	for entry := range result.Next() {
 		key := datastore.NewKey(entry.Key)
-		if key.Name() == "IP" {
+		if key.Name() == metaKey {
 			id := key.Path().Name()
 			ip := string(entry.Value)
 			tsBytes, err := h.seer.ds.Get(ctx, datastore.NewKey("/hb/ts").Instance(id))
@@ -64,7 +72,7 @@ func (h *dnsHandler) getServiceIpWithCache(ctx context.Context, proto string, fi
 	}
 
 	ips := maps.Keys(unique)
-	h.serverIPCache.Set(proto, ips, ServerIpCacheTTL)
+	h.serverIPCache.Set(cacheKey, ips, ServerIpCacheTTL)
 
 	return ips, nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/jellydator/ttlcache/v3"
 	"github.com/miekg/dns"
 	"github.com/taubyte/tau/pkg/specs/common"
+	domainSpecs "github.com/taubyte/tau/pkg/specs/domain"
 )
 
 type dnsHandler struct {
@@ -61,6 +62,7 @@ func (seer *Service) newDnsServer(devMode bool, port int) error {
 	//Create cache nodes and spam requests
 	seer.positiveCache = ttlcache.New(ttlcache.WithTTL[string, []string](PositiveCacheTTL), ttlcache.WithDisableTouchOnHit[string, []string]())
 	seer.negativeCache = ttlcache.New(ttlcache.WithTTL[string, bool](DefaultBlockTime), ttlcache.WithDisableTouchOnHit[string, bool]())
+	seer.recordsCache = ttlcache.New(ttlcache.WithTTL[string, []dns.RR](PositiveCacheTTL), ttlcache.WithDisableTouchOnHit[string, []dns.RR]())
 
 	// Create TCP and UDP
 	validate.UseResolver(seer.dnsResolver)
@@ -78,6 +80,7 @@ func (seer *Service) newDnsServer(devMode bool, port int) error {
 
 	go seer.positiveCache.Start()
 	go seer.negativeCache.Start()
+	go seer.recordsCache.Start()
 
 	return nil
 }
@@ -96,6 +99,49 @@ func (s *Service) isServiceOrAliasDomain(dom string) bool {
 	return false
 }
 
+// poeCheck scores a node with the proof of engagement script, if any.
+func (h *dnsHandler) poeCheck(id string, ts int64, usage *iface.UsageData) bool {
+	if h.seer.poe == nil {
+		return true
+	}
+
+	usageMap := usage.ToMap()
+	usageMap["timestamp"] = ts
+	ok, err := h.seer.poe.Check(id, usageMap)
+	if err != nil {
+		// Assume the poe script has an issue & return the node anyway
+		logger.Errorf("scoring %s failed with: %s", id, err.Error())
+		return true
+	}
+
+	logger.Infof("scoring %s with: %v, result: %t", id, usageMap, ok)
+	return ok
+}
+
+// addrMetaKey is the announced meta holding the addresses answering qtype.
+func addrMetaKey(qtype uint16) string {
+	if qtype == dns.TypeAAAA {
+		return servicesCommon.IP6MetaKey
+	}
+
+	return servicesCommon.IPMetaKey
+}
+
+// addrRecords returns the A or AAAA records of ips.
+func addrRecords(name string, qtype uint16, ttl uint32, ips []string) []dns.RR {
+	records := make([]dns.RR, 0, len(ips))
+	for _, ip := range ips {
+		hdr := dns.RR_Header{Name: name, Rrtype: qtype, Class: dns.ClassINET, Ttl: ttl}
+		if qtype == dns.TypeAAAA {
+			records = append(records, &dns.AAAA{Hdr: hdr, AAAA: net.ParseIP(ip)})
+		} else {
+			records = append(records, &dns.A{Hdr: hdr, A: net.ParseIP(ip)})
+		}
+	}
+
+	return records
+}
+
 // Real DNS Handler
 func (h *dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	ctx, ctxC := context.WithTimeout(h.seer.Node().Context(), MaxDnsResponseTime)
@@ -126,6 +172,25 @@ func (h *dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 			return
 		}
 
+		// Pending ACME DNS-01 challenges answer first. Names without any are
+		// only blocked briefly, as a challenge may be set at any time.
+		if names := domainSpecs.ChallengeNames(name, h.seer.config.NetworkFqdn()); len(names) > 0 {
+			if values := h.challengeValues(names); len(values) > 0 {
+				h.replyWithChallenges(name, values, w, r, msg)
+				return
+			}
+
+			if records := h.userRecords(name); len(records) > 0 && h.replyWithRecords(name, records, w, r, msg) {
+				return
+			}
+
+			h.seer.negativeCache.Set(name, true, ChallengeBlockTime)
+			if err := w.WriteMsg(errMsg); err != nil {
+				logger.Errorf("writing error message `%s` failed with %s", errMsg, err.Error())
+			}
+			return
+		}
+
 		if len(msg.Question) == 0 {
 			w.Close()
 			return
@@ -146,6 +211,13 @@ func (h *dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 			return
 		}
 
+		// Records published for a project's domains answer first.
+		if !h.seer.isServiceOrAliasDomain(name) && !h.seer.config.GeneratedDomainMatch(name) {
+			if records := h.userRecords(name); len(records) > 0 && h.replyWithRecords(name, records, w, r, msg) {
+				return
+			}
+		}
+
 		// if we didn't see this domain registred before
 		if h.seer.positiveCache.Get(name) == nil {
 			if h.seer.isServiceOrAliasDomain(name) {
@@ -191,23 +263,10 @@ func (h *dnsHandler) tauDnsResolve(ctx context.Context, service, name string, w
 		return
 	}
 
-	switch r.Question[0].Qtype {
-	case dns.TypeA:
-		logger.Debugf("request for %s A", name)
-		ips, err := h.getServiceIpWithCache(ctx, service, func(id string, ts int64, usage *iface.UsageData) bool {
-			if h.seer.poe != nil {
-				usageMap := usage.ToMap()
-				usageMap["timestamp"] = ts
-				ok, err := h.seer.poe.Check(id, usageMap)
-				if err != nil {
-					logger.Errorf("scoring %s failed with: %s", id, err.Error())
-					return true
-				}
-				logger.Infof("scoring %s with: %v, result: %t", id, usageMap, ok)
-				return ok
-			}
-			return true
-		})
+	switch qtype := r.Question[0].Qtype; qtype {
+	case dns.TypeA, dns.TypeAAAA:
+		logger.Debugf("request for %s %s", name, dns.TypeToString[qtype])
+		ips, err := h.getServiceAddrWithCache(ctx, service, addrMetaKey(qtype), h.poeCheck)
 		if err != nil {
 			logger.Errorf("getting ip for %s failed with %s", service, err.Error())
 			if err := w.WriteMsg(errMsg); err != nil {
@@ -216,13 +275,7 @@ func (h *dnsHandler) tauDnsResolve(ctx context.Context, service, name string, w
 			return
 		}
 
-		for _, ip := range ips {
-			msg.Answer = append(msg.Answer, &dns.A{
-				Hdr: dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: uint32(ValidServiceResponseTime.Seconds())},
-				A:   net.ParseIP(ip),
-			})
-
-		}
+		msg.Answer = append(msg.Answer, addrRecords(r.Question[0].Name, qtype, uint32(ValidServiceResponseTime.Seconds()), ips)...)
 	case dns.TypeTXT:
 		txt, err := h.getServiceMultiAddr(ctx, service)
 		if err != nil {
@@ -257,36 +310,12 @@ func (h *dnsHandler) tauDnsResolve(ctx context.Context, service, name string, w
 }
 
 func (h *dnsHandler) replyWithHTTPServicingNodes(ctx context.Context, w dns.ResponseWriter, r *dns.Msg, errMsg *dns.Msg, msg dns.Msg) {
-	nodeIps, err := h.getServiceIpWithCache(ctx, "gateway", func(id string, ts int64, usage *iface.UsageData) bool {
-		if h.seer.poe != nil {
-			usageMap := usage.ToMap()
-			usageMap["timestamp"] = ts
-			ok, err := h.seer.poe.Check(id, usageMap)
-			if err != nil {
-				// Assume the poe script has an issue & return the node anyway
-				logger.Errorf("scoring %s failed with: %s", id, err.Error())
-				return true
-			}
-			logger.Infof("scoring %s with: %v, result: %t", id, usageMap, ok)
-			return ok
-		}
-		return true
-	})
+	qtype := r.Question[0].Qtype
+	metaKey := addrMetaKey(qtype)
+
+	nodeIps, err := h.getServiceAddrWithCache(ctx, "gateway", metaKey, h.poeCheck)
 	if err != nil || len(nodeIps) == 0 {
-		nodeIps, err = h.getServiceIpWithCache(ctx, "substrate", func(id string, ts int64, usage *iface.UsageData) bool {
-			if h.seer.poe != nil {
-				usageMap := usage.ToMap()
-				usageMap["timestamp"] = ts
-				ok, err := h.seer.poe.Check(id, usageMap)
-				if err != nil {
-					logger.Errorf("scoring %s failed with: %s", id, err.Error())
-					return true
-				}
-				logger.Infof("scoring %s with: %v, result: %t", id, usageMap, ok)
-				return ok
-			}
-			return true
-		})
+		nodeIps, err = h.getServiceAddrWithCache(ctx, "substrate", metaKey, h.poeCheck)
 		if err != nil {
 			err = w.WriteMsg(errMsg)
 			if err != nil {
@@ -296,15 +325,9 @@ func (h *dnsHandler) replyWithHTTPServicingNodes(ctx context.Context, w dns.Resp
 		}
 	}
 
-	switch r.Question[0].Qtype {
-	case dns.TypeA:
-		for _, ip := range nodeIps {
-			msg.Answer = append(msg.Answer, &dns.A{
-				Hdr: dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
-				A:   net.ParseIP(ip),
-			})
-
-		}
+	switch qtype {
+	case dns.TypeA, dns.TypeAAAA:
+		msg.Answer = append(msg.Answer, addrRecords(r.Question[0].Name, qtype, 60, nodeIps)...)
 	case dns.TypeCAA:
 		msg.Answer = append(msg.Answer, &dns.CAA{
 			Hdr:   dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeCAA, Class: dns.ClassINET, Ttl: uint32(ValidServiceResponseTime.Seconds())},
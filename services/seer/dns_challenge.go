@@ -0,0 +1,51 @@
+package seer
+
+import (
+	"github.com/miekg/dns"
+)
+
+// challengeValues returns the values of the ACME DNS-01 challenges pending on
+// names.
+func (h *dnsHandler) challengeValues(names []string) []string {
+	if h.seer.auth == nil {
+		return nil
+	}
+
+	values := make([]string, 0)
+	for _, name := range names {
+		_values, err := h.seer.auth.Challenges().Get(name)
+		if err != nil {
+			logger.Debugf("getting challenges of %s failed with: %s", name, err.Error())
+			continue
+		}
+
+		values = append(values, _values...)
+	}
+
+	return values
+}
+
+// challengeRecords returns a TXT record per challenge value.
+func challengeRecords(name string, values []string) []dns.RR {
+	records := make([]dns.RR, 0, len(values))
+	for _, value := range values {
+		records = append(records, &dns.TXT{
+			Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: uint32(ChallengeRecordTTL.Seconds())},
+			Txt: []string{value},
+		})
+	}
+
+	return records
+}
+
+// replyWithChallenges answers TXT questions with the challenge values, and
+// other types with no record.
+func (h *dnsHandler) replyWithChallenges(name string, values []string, w dns.ResponseWriter, r *dns.Msg, msg dns.Msg) {
+	if r.Question[0].Qtype == dns.TypeTXT {
+		msg.Answer = append(msg.Answer, challengeRecords(r.Question[0].Name, values)...)
+	}
+
+	if err := w.WriteMsg(&msg); err != nil {
+		logger.Errorf("writing challenges for `%s` failed with: %s", name, err.Error())
+	}
+}
@@ -2,6 +2,7 @@ package seer
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/taubyte/tau/core/services/tns"
 	domainSpecs "github.com/taubyte/tau/pkg/specs/domain"
@@ -21,8 +22,20 @@ func (h *dnsHandler) fetchDomainTnsPathSlice(name string) ([]string, error) {
 		return nil, errors.New("domain not registred")
 	}
 
-	domPath, ok := tnsInterface.([]string)
-	if !ok || len(domPath) == 0 {
+	keys, ok := tnsInterface.([]string)
+	if !ok {
+		return nil, errors.New("invalid domain entry in tns")
+	}
+
+	// A name with records only, like a mail host, isn't a registered domain.
+	domPath := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if !strings.Contains(key, "/"+domainSpecs.RecordsPathVariable.String()+"/") {
+			domPath = append(domPath, key)
+		}
+	}
+
+	if len(domPath) == 0 {
 		return nil, errors.New("invalid domain entry in tns")
 	}
 
@@ -0,0 +1,165 @@
+package seer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+	dv "github.com/taubyte/domain-validation"
+	domainSpecs "github.com/taubyte/tau/pkg/specs/domain"
+	"github.com/taubyte/tau/utils/maps"
+)
+
+// userRecords returns the records a project published for name, caching the
+// answer, empty or not, for PositiveCacheTTL.
+func (h *dnsHandler) userRecords(name string) []dns.RR {
+	if item := h.seer.recordsCache.Get(name); item != nil {
+		return item.Value()
+	}
+
+	var records []dns.RR
+	if lines, err := h.fetchRecords(name); err != nil {
+		logger.Debugf("fetching records of %s failed with: %s", name, err.Error())
+	} else {
+		records = parseRecords(lines)
+	}
+
+	h.seer.recordsCache.Set(name, records, PositiveCacheTTL)
+
+	return records
+}
+
+// fetchRecords returns the records published for name by the project that
+// proves, like for serving its domains over HTTP, that it owns the domain it
+// published them for. Records of projects that don't are skipped, and a name
+// more than one project proves is served by none.
+func (h *dnsHandler) fetchRecords(name string) ([]string, error) {
+	recordsPath, err := domainSpecs.Tns().RecordsPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := h.seer.tns.Fetch(recordsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		owner   string
+		records []string
+	)
+	for project, domains := range maps.SafeInterfaceToStringKeys(obj.Interface()) {
+		for fqdn, lines := range maps.SafeInterfaceToStringKeys(domains) {
+			if err = h.validateDomain(name, project, fqdn); err != nil {
+				logger.Debugf("skipping records of %s published by `%s` for %s: %s", name, project, fqdn, err.Error())
+				continue
+			}
+
+			if owner != "" && owner != project {
+				return nil, fmt.Errorf("records of %s published by both `%s` and `%s`", name, owner, project)
+			}
+
+			owner = project
+			records = append(records, recordLines(lines)...)
+		}
+	}
+
+	return records, nil
+}
+
+// validateDomain checks the domain validation of fqdn for project. The token
+// can't come from the records the domain publishes itself.
+func (h *dnsHandler) validateDomain(name, project, fqdn string) error {
+	if len(project) >= 8 && name == strings.ToLower(project[:8])+"."+fqdn {
+		return fmt.Errorf("records can't answer the validation of their domain")
+	}
+
+	return domainSpecs.ValidateDNS(
+		h.seer.config.GeneratedDomainRegExp(),
+		project,
+		fqdn,
+		h.seer.devMode,
+		dv.PublicKey(h.seer.config.DomainValidation().PublicKey),
+	)
+}
+
+func recordLines(lines interface{}) []string {
+	switch lines := lines.(type) {
+	case []string:
+		return lines
+	case []interface{}:
+		records := make([]string, 0, len(lines))
+		for _, line := range lines {
+			if _line, ok := line.(string); ok {
+				records = append(records, _line)
+			}
+		}
+		return records
+	default:
+		return nil
+	}
+}
+
+// parseRecords parses the zone lines published by the compiler, skipping the
+// ones that don't parse.
+func parseRecords(lines []string) []dns.RR {
+	records := make([]dns.RR, 0, len(lines))
+	for _, line := range lines {
+		rr, err := dns.NewRR(line)
+		if err != nil || rr == nil {
+			logger.Errorf("parsing record `%s` failed with: %v", line, err)
+			continue
+		}
+
+		records = append(records, rr)
+	}
+
+	return records
+}
+
+// answerRecords returns the records answering qtype: a CNAME stands for the
+// name whatever the type asked.
+func answerRecords(records []dns.RR, qtype uint16) []dns.RR {
+	answers := make([]dns.RR, 0, len(records))
+	for _, rr := range records {
+		if rr.Header().Rrtype == qtype {
+			answers = append(answers, rr)
+		}
+	}
+
+	if len(answers) == 0 {
+		for _, rr := range records {
+			if rr.Header().Rrtype == dns.TypeCNAME {
+				return []dns.RR{rr}
+			}
+		}
+	}
+
+	return answers
+}
+
+// replyWithRecords answers from the records of a name. Without a record of the
+// type asked, a registered domain still gets the nodes serving it, so it
+// returns false; any other name exists only through its records and gets an
+// empty answer.
+func (h *dnsHandler) replyWithRecords(name string, records []dns.RR, w dns.ResponseWriter, r *dns.Msg, msg dns.Msg) bool {
+	answers := answerRecords(records, r.Question[0].Qtype)
+	if len(answers) == 0 {
+		if _, err := h.fetchDomainTnsPathSlice(name); err == nil {
+			return false
+		}
+	}
+
+	for _, rr := range answers {
+		rr = dns.Copy(rr)
+		// Answer with the case the client asked with.
+		rr.Header().Name = r.Question[0].Name
+		msg.Answer = append(msg.Answer, rr)
+	}
+
+	if err := w.WriteMsg(&msg); err != nil {
+		logger.Errorf("writing records for `%s` failed with: %s", name, err.Error())
+	}
+
+	return true
+}
@@ -0,0 +1,110 @@
+package seer
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/taubyte/tau/core/services/tns"
+	"github.com/taubyte/tau/pkg/config"
+	"gotest.tools/v3/assert"
+)
+
+func TestAnswerRecords(t *testing.T) {
+	records := parseRecords([]string{
+		"hal.computers.com. 300 IN MX 10 mail.hal.computers.com.",
+		`hal.computers.com. 3600 IN TXT "v=spf1 -all"`,
+		"hal.computers.com. 300 IN MX 20 backup.hal.computers.com.",
+		"not a record",
+	})
+	assert.Equal(t, len(records), 3)
+
+	mx := answerRecords(records, dns.TypeMX)
+	assert.Equal(t, len(mx), 2)
+	assert.Equal(t, mx[0].(*dns.MX).Mx, "mail.hal.computers.com.")
+
+	txt := answerRecords(records, dns.TypeTXT)
+	assert.DeepEqual(t, txt[0].(*dns.TXT).Txt, []string{"v=spf1 -all"})
+
+	assert.Equal(t, len(answerRecords(records, dns.TypeAAAA)), 0)
+}
+
+func TestAnswerRecordsCNAME(t *testing.T) {
+	records := parseRecords([]string{"www.hal.computers.com. 300 IN CNAME hal.computers.com."})
+
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA, dns.TypeCNAME} {
+		answers := answerRecords(records, qtype)
+		assert.Equal(t, len(answers), 1)
+		assert.Equal(t, answers[0].(*dns.CNAME).Target, "hal.computers.com.")
+	}
+}
+
+func TestAddrRecords(t *testing.T) {
+	a := addrRecords("hal.computers.com.", dns.TypeA, 60, []string{"10.0.0.1"})
+	assert.Equal(t, a[0].(*dns.A).A.String(), "10.0.0.1")
+
+	aaaa := addrRecords("hal.computers.com.", dns.TypeAAAA, 60, []string{"2001:db8::1"})
+	assert.Equal(t, aaaa[0].(*dns.AAAA).AAAA.String(), "2001:db8::1")
+	assert.Equal(t, addrMetaKey(dns.TypeAAAA), "IP6")
+}
+
+func TestChallengeRecords(t *testing.T) {
+	records := challengeRecords("_acme-challenge.g.tau.link.", []string{"a", "b"})
+	assert.Equal(t, len(records), 2)
+	assert.DeepEqual(t, records[1].(*dns.TXT).Txt, []string{"b"})
+	assert.Equal(t, records[0].Header().Ttl, uint32(ChallengeRecordTTL.Seconds()))
+}
+
+type recordsObject struct {
+	tns.Object
+	value interface{}
+}
+
+func (o recordsObject) Interface() interface{} { return o.value }
+
+type recordsTns struct {
+	tns.Client
+	value interface{}
+}
+
+func (c recordsTns) Fetch(tns.Path) (tns.Object, error) {
+	return recordsObject{value: c.value}, nil
+}
+
+func newRecordsHandler(t *testing.T, value interface{}) *dnsHandler {
+	cfg, err := config.New(config.WithGeneratedDomainRegExp(regexp.MustCompile(`\.g\.tau\.link$`)))
+	assert.NilError(t, err)
+
+	return &dnsHandler{seer: &Service{config: cfg, tns: recordsTns{value: value}}}
+}
+
+func TestFetchRecords(t *testing.T) {
+	h := newRecordsHandler(t, map[string]interface{}{
+		"projectaabcd1234": map[string]interface{}{
+			"abcd1234.g.tau.link": []interface{}{"www.abcd1234.g.tau.link. 300 IN A 10.0.0.1"},
+		},
+		// doesn't own the domain
+		"projectbzzzz9999": map[string]interface{}{
+			"abcd1234.g.tau.link": []interface{}{"www.abcd1234.g.tau.link. 300 IN A 10.0.0.2"},
+		},
+	})
+
+	records, err := h.fetchRecords("www.abcd1234.g.tau.link")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, records, []string{"www.abcd1234.g.tau.link. 300 IN A 10.0.0.1"})
+
+	h = newRecordsHandler(t, map[string]interface{}{
+		"projectaabcd1234": map[string]interface{}{
+			"abcd1234.g.tau.link": []interface{}{"www.abcd1234.g.tau.link. 300 IN A 10.0.0.1"},
+		},
+		"projectbabcd1234": map[string]interface{}{
+			"abcd1234.g.tau.link": []interface{}{"www.abcd1234.g.tau.link. 300 IN A 10.0.0.2"},
+		},
+	})
+
+	_, err = h.fetchRecords("www.abcd1234.g.tau.link")
+	assert.ErrorContains(t, err, "published by both")
+
+	err = h.validateDomain("projecta.abcd1234.g.tau.link", "projectaabcd1234", "abcd1234.g.tau.link")
+	assert.ErrorContains(t, err, "validation of their domain")
+}
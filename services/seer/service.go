@@ -12,6 +12,7 @@ import (
 
 	pebbleds "github.com/ipfs/go-ds-pebble"
 	"github.com/ipfs/go-log/v2"
+	authClient "github.com/taubyte/tau/clients/p2p/auth"
 	tnsClient "github.com/taubyte/tau/clients/p2p/tns"
 	streams "github.com/taubyte/tau/p2p/streams/service"
 	tauConfig "github.com/taubyte/tau/pkg/config"
@@ -68,6 +69,9 @@ func New(ctx context.Context, cfg tauConfig.Config, opts ...Options) (*Service,
 	if srv.tns, err = tnsClient.New(ctx, clientNode); err != nil {
 		return nil, fmt.Errorf("new tns api failed with: %s", err)
 	}
+	if srv.auth, err = authClient.New(ctx, clientNode); err != nil {
+		return nil, fmt.Errorf("new auth api failed with: %s", err)
+	}
 	if srv.ds, err = pebbleds.NewDatastore(
 		path.Join(cfg.Root(), "storage", srv.shape, "seer"),
 		nil,
@@ -119,11 +123,13 @@ func (srv *Service) Close() error {
 	time.Sleep(100 * time.Millisecond)
 
 	srv.tns.Close()
+	srv.auth.Close()
 	srv.ds.Close()
 
 	srv.dns.Stop()
 
 	srv.positiveCache.Stop()
 	srv.negativeCache.Stop()
+	srv.recordsCache.Stop()
 	return nil
 }
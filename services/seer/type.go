@@ -10,6 +10,7 @@ import (
 	"github.com/taubyte/tau/p2p/peer"
 	streams "github.com/taubyte/tau/p2p/streams/service"
 
+	authClient "github.com/taubyte/tau/core/services/auth"
 	tnsClient "github.com/taubyte/tau/core/services/tns"
 	"github.com/taubyte/tau/pkg/config"
 	http "github.com/taubyte/tau/pkg/http"
@@ -24,6 +25,8 @@ var (
 	PositiveCacheTTL         = 1 * time.Minute
 	DefaultBlockTime         = 1 * time.Minute
 	ValidServiceResponseTime = 1 * time.Minute
+	ChallengeRecordTTL       = 10 * time.Second
+	ChallengeBlockTime       = 2 * time.Second
 )
 
 type dnsServer struct {
@@ -59,12 +62,14 @@ type Service struct {
 	dns           *dnsServer
 	positiveCache *ttlcache.Cache[string, []string]
 	negativeCache *ttlcache.Cache[string, bool]
+	recordsCache  *ttlcache.Cache[string, []dns.RR]
 
 	config config.Config
 
 	ds datastore.Batching
 
 	tns         tnsClient.Client
+	auth        authClient.Client
 	dnsResolver iface.Resolver
 
 	poe poe.Engine
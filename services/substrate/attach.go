@@ -3,13 +3,19 @@ package substrate
 import (
 	"fmt"
 
+	authClient "github.com/taubyte/tau/clients/p2p/auth"
 	"github.com/taubyte/tau/core/services/substrate"
 	"github.com/taubyte/tau/pkg/config"
+	protocolCommon "github.com/taubyte/tau/services/common"
 	counters "github.com/taubyte/tau/services/substrate/components/counters"
 	database "github.com/taubyte/tau/services/substrate/components/database"
+	events "github.com/taubyte/tau/services/substrate/components/events"
 	http "github.com/taubyte/tau/services/substrate/components/http"
+	logs "github.com/taubyte/tau/services/substrate/components/logs"
 	p2p "github.com/taubyte/tau/services/substrate/components/p2p"
 	pubSub "github.com/taubyte/tau/services/substrate/components/pubsub"
+	s3 "github.com/taubyte/tau/services/substrate/components/s3"
+	schedule "github.com/taubyte/tau/services/substrate/components/schedule"
 	smartOps "github.com/taubyte/tau/services/substrate/components/smartops"
 	storage "github.com/taubyte/tau/services/substrate/components/storage"
 )
@@ -36,7 +42,16 @@ func (srv *Service) attachNodes(cfg config.Config) (err error) {
 		return attachNodesError("smartops", err)
 	}
 
-	if err = srv.attachNodePubSub(); err != nil {
+	if err = srv.attachNodeLogs(); err != nil {
+		return attachNodesError("logs", err)
+	}
+
+	// Needs to happen before database and storage, their changes trigger functions
+	if err = srv.attachNodeEvents(); err != nil {
+		return attachNodesError("events", err)
+	}
+
+	if err = srv.attachNodePubSub(cfg); err != nil {
 		return attachNodesError("pubsub", err)
 	}
 
@@ -48,10 +63,19 @@ func (srv *Service) attachNodes(cfg config.Config) (err error) {
 		return attachNodesError("storage", err)
 	}
 
+	// Needs to happen before http, its catch-all route would shadow the API
+	if err = srv.attachNodeS3(cfg); err != nil {
+		return attachNodesError("s3", err)
+	}
+
 	if err = srv.attachNodeP2P(); err != nil {
 		return attachNodesError("p2p", err)
 	}
 
+	if err = srv.attachNodeSchedule(); err != nil {
+		return attachNodesError("schedule", err)
+	}
+
 	if err = srv.attachNodeHttp(cfg); err != nil {
 		return attachNodesError("http", err)
 	}
@@ -64,8 +88,17 @@ func (srv *Service) attachNodeHttp(cfg config.Config) (err error) {
 	return
 }
 
-func (srv *Service) attachNodePubSub() (err error) {
-	srv.components.pubsub, err = pubSub.New(srv)
+func (srv *Service) attachNodePubSub(cfg config.Config) (err error) {
+	options := []pubSub.Option{pubSub.Hoarder(srv.hoarderClient)}
+	if port := cfg.Ports()["mqtt"]; port > 0 {
+		// MQTT clients authenticate with the key pairs auth issues projects.
+		if err = srv.attachAuthClient(); err != nil {
+			return err
+		}
+		options = append(options, pubSub.MQTT(fmt.Sprintf(":%d", port), srv.authClient.StorageCredentials))
+	}
+
+	srv.components.pubsub, err = pubSub.New(srv, options...)
 	return
 }
 
@@ -79,13 +112,60 @@ func (srv *Service) attachNodeStorage() (err error) {
 	return
 }
 
+// attachNodeS3 serves storages over S3 on the substrate hosts, checking
+// requests against the keys auth issued.
+func (srv *Service) attachNodeS3(cfg config.Config) (err error) {
+	hosts := cfg.RouteHosts(protocolCommon.Substrate)
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	if err = srv.attachAuthClient(); err != nil {
+		return err
+	}
+
+	srv.components.s3, err = s3.New(srv, srv.components.storage, srv.authClient.StorageCredentials, s3.Hosts(hosts...))
+	return
+}
+
+// attachAuthClient creates the auth client once. Auth serves secrets to
+// substrate nodes only, so it asks as this node.
+func (srv *Service) attachAuthClient() (err error) {
+	if srv.authClient != nil {
+		return nil
+	}
+
+	if srv.authClient, err = authClient.New(srv.ctx, srv.node); err != nil {
+		return fmt.Errorf("creating auth client failed with: %w", err)
+	}
+
+	return nil
+}
+
 func (srv *Service) attachNodeP2P() (err error) {
 	srv.components.p2p, err = p2p.New(srv)
 	return
 }
 
+func (srv *Service) attachNodeEvents() (err error) {
+	srv.components.events, err = events.New(srv)
+	return
+}
+
+func (srv *Service) attachNodeSchedule() (err error) {
+	srv.components.schedule, err = schedule.New(srv)
+	return
+}
+
+// attachNodeCounters serves /metrics on the node's loopback hosts only, the
+// route hosts being public.
 func (srv *Service) attachNodeCounters() (err error) {
-	srv.components.counters, err = counters.New(srv)
+	srv.components.counters, err = counters.New(srv, counters.Hosts("localhost", "127.0.0.1"))
+	return
+}
+
+func (srv *Service) attachNodeLogs() (err error) {
+	srv.components.logs, err = logs.New(srv)
 	return
 }
 
@@ -8,6 +8,8 @@ import (
 	storageIface "github.com/taubyte/tau/core/services/substrate/components/storage"
 	tbPlugins "github.com/taubyte/tau/pkg/vm-low-orbit"
 	httpIface "github.com/taubyte/tau/services/substrate/components/http"
+	s3Iface "github.com/taubyte/tau/services/substrate/components/s3"
+	scheduleIface "github.com/taubyte/tau/services/substrate/components/schedule"
 )
 
 // TODO: All of these components interfaces can be removed
@@ -16,9 +18,13 @@ type components struct {
 	pubsub   pubSubIface.Service
 	database databaseIface.Service
 	storage  storageIface.Service
+	s3       *s3Iface.Service
 	p2p      p2pIface.Service
+	schedule *scheduleIface.Service
 	counters iface.CounterService
 	smartops iface.SmartOpsService
+	logs     iface.LogService
+	events   iface.EventService
 }
 
 func (c *components) config() []tbPlugins.Option {
@@ -35,7 +41,13 @@ func (c *components) close() {
 	c.pubsub.Close()
 	c.database.Close()
 	c.storage.Close()
+	if c.s3 != nil {
+		c.s3.Close()
+	}
 	c.p2p.Close()
+	c.schedule.Close()
 	c.counters.Close()
 	c.smartops.Close()
+	c.logs.Close()
+	c.events.Close()
 }
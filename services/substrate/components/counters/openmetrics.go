@@ -0,0 +1,237 @@
+package counters
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// family is an OpenMetrics metric family.
+type family struct {
+	name  string
+	kind  string
+	unit  string
+	help  string
+	nanos bool // values are nanoseconds, exposed as seconds
+}
+
+var (
+	requestsFamily        = family{name: "tau_substrate_requests", kind: "counter", help: "Requests handled by a resource."}
+	requestTimeFamily     = family{name: "tau_substrate_request_seconds", kind: "counter", unit: "seconds", help: "Time spent handling requests, cold starts included.", nanos: true}
+	coldStartsFamily      = family{name: "tau_substrate_cold_starts", kind: "counter", help: "Cold starts of a resource."}
+	coldStartTimeFamily   = family{name: "tau_substrate_cold_start_seconds", kind: "counter", unit: "seconds", help: "Time spent in cold starts.", nanos: true}
+	executionsFamily      = family{name: "tau_substrate_executions", kind: "counter", help: "Executions of a resource after its cold start."}
+	executionTimeFamily   = family{name: "tau_substrate_execution_seconds", kind: "counter", unit: "seconds", help: "Time spent executing, cold starts excluded.", nanos: true}
+	memoryFamily          = family{name: "tau_substrate_memory_max_bytes", kind: "gauge", unit: "bytes", help: "Peak memory used by a resource."}
+	rejectedFamily        = family{name: "tau_substrate_rejected_requests", kind: "counter", help: "Requests turned away by the rate limit or concurrency cap of a resource."}
+	projectRequests       = family{name: "tau_project_requests", kind: "counter", help: "Requests handled for a project across the network."}
+	projectRequestsTime   = family{name: "tau_project_request_seconds", kind: "counter", unit: "seconds", help: "Time spent handling a project's requests across the network.", nanos: true}
+	familiesInRenderOrder = []family{
+		requestsFamily, requestTimeFamily,
+		coldStartsFamily, coldStartTimeFamily,
+		executionsFamily, executionTimeFamily,
+		memoryFamily,
+		rejectedFamily,
+		projectRequests, projectRequestsTime,
+	}
+)
+
+type sample struct {
+	labels [][2]string
+	value  float64
+}
+
+// series identifies one resource metric parsed from a counters path.
+type series struct {
+	family          family
+	project         string
+	resource        string
+	commit          string
+	smartOp         string
+	status          string
+	coldStartStatus string
+}
+
+// parseKey maps a counters path, as built by core/services/substrate/counters,
+// onto its family and labels:
+//
+//	<project>/<resource>/<s|f>[/t]                   requests
+//	<project>/<resource>/<s|f>/cs/<s|f>[/t]          cold starts
+//	<project>/<resource>/<s|f>/e[/t]                 executions
+//	<project>/<resource>/m                           memory
+//	<project>/<resource>/r                           rejected requests
+//
+// A resource served under a traffic policy is <resource>@<commit>. Any of the
+// above may follow <project>/<resource>/<smartop>/<smartop>, the counters of
+// a smartop run for the resource.
+func parseKey(key string) (series, bool) {
+	parts := strings.Split(key, "/")
+	if len(parts) < 3 {
+		return series{}, false
+	}
+
+	s := series{project: parts[0], resource: parts[1]}
+	s.resource, s.commit, _ = strings.Cut(s.resource, "@")
+	rest := parts[2:]
+	if len(rest) > 2 && rest[0] == rest[1] && !reservedToken(rest[0]) {
+		s.smartOp, rest = rest[0], rest[2:]
+	}
+	if len(rest) == 1 && rest[0] == "m" {
+		s.family = memoryFamily
+		return s, true
+	}
+
+	if len(rest) == 1 && rest[0] == "r" {
+		s.family = rejectedFamily
+		return s, true
+	}
+
+	var ok bool
+	if s.status, ok = statusOf(rest[0]); !ok {
+		return series{}, false
+	}
+	rest = rest[1:]
+
+	timed := len(rest) > 0 && rest[len(rest)-1] == "t"
+	if timed {
+		rest = rest[:len(rest)-1]
+	}
+
+	switch {
+	case len(rest) == 0:
+		s.family = pick(timed, requestTimeFamily, requestsFamily)
+	case len(rest) == 2 && rest[0] == "cs":
+		if s.coldStartStatus, ok = statusOf(rest[1]); !ok {
+			return series{}, false
+		}
+		s.family = pick(timed, coldStartTimeFamily, coldStartsFamily)
+	case len(rest) == 1 && rest[0] == "e":
+		s.family = pick(timed, executionTimeFamily, executionsFamily)
+	default:
+		return series{}, false
+	}
+
+	return s, true
+}
+
+func statusOf(token string) (string, bool) {
+	switch token {
+	case "s":
+		return "success", true
+	case "f":
+		return "failure", true
+	default:
+		return "", false
+	}
+}
+
+// reservedToken reports whether token is one of the fixed path tokens, which
+// smartop ids are told apart from.
+func reservedToken(token string) bool {
+	switch token {
+	case "s", "f", "t", "cs", "e", "m", "r":
+		return true
+	default:
+		return false
+	}
+}
+
+func pick(timed bool, timeFamily, countFamily family) family {
+	if timed {
+		return timeFamily
+	}
+	return countFamily
+}
+
+// OpenMetrics renders the node's metrics and the network-wide project rollups
+// in the OpenMetrics text format.
+func (s *Service) OpenMetrics() []byte {
+	samples := make(map[string][]sample)
+	for key, value := range s.snapshot() {
+		ser, ok := parseKey(key)
+		if !ok {
+			logger.Debugf("skipping metric `%s`: not a counters path", key)
+			continue
+		}
+
+		labels := [][2]string{{"project", ser.project}, {"resource", ser.resource}}
+		if ser.commit != "" {
+			labels = append(labels, [2]string{"commit", ser.commit})
+		}
+		if ser.smartOp != "" {
+			labels = append(labels, [2]string{"smartop", ser.smartOp})
+		}
+		if ser.status != "" {
+			labels = append(labels, [2]string{"status", ser.status})
+		}
+		if ser.coldStartStatus != "" {
+			labels = append(labels, [2]string{"cold_start_status", ser.coldStartStatus})
+		}
+		samples[ser.family.name] = append(samples[ser.family.name], sample{labels: labels, value: value})
+	}
+
+	for project, usage := range s.networkUsage() {
+		for status, count := range usage.Requests {
+			labels := [][2]string{{"project", project}, {"status", status}}
+			samples[projectRequests.name] = append(samples[projectRequests.name], sample{labels: labels, value: float64(count)})
+		}
+		for status, nanos := range usage.RequestNanos {
+			labels := [][2]string{{"project", project}, {"status", status}}
+			samples[projectRequestsTime.name] = append(samples[projectRequestsTime.name], sample{labels: labels, value: float64(nanos)})
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, f := range familiesInRenderOrder {
+		writeFamily(&buf, f, samples[f.name])
+	}
+	buf.WriteString("# EOF\n")
+
+	return buf.Bytes()
+}
+
+func writeFamily(buf *bytes.Buffer, f family, samples []sample) {
+	if len(samples) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "# TYPE %s %s\n", f.name, f.kind)
+	if f.unit != "" {
+		fmt.Fprintf(buf, "# UNIT %s %s\n", f.name, f.unit)
+	}
+	fmt.Fprintf(buf, "# HELP %s %s\n", f.name, f.help)
+
+	lines := make([]string, 0, len(samples))
+	for _, smp := range samples {
+		value := smp.value
+		if f.nanos {
+			value /= 1e9
+		}
+
+		name := f.name
+		if f.kind == "counter" {
+			name += "_total"
+		}
+
+		lines = append(lines, name+formatLabels(smp.labels)+" "+strconv.FormatFloat(value, 'g', -1, 64))
+	}
+	sort.Strings(lines)
+
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatLabels(labels [][2]string) string {
+	parts := make([]string, 0, len(labels))
+	for _, l := range labels {
+		parts = append(parts, l[0]+`="`+labelEscaper.Replace(l[1])+`"`)
+	}
+
+	return "{" + strings.Join(parts, ",") + "}"
+}
@@ -0,0 +1,13 @@
+package counters
+
+// Option configures the counters service.
+type Option func(*Service) error
+
+// Hosts scopes the /metrics endpoint to hosts. Without hosts the endpoint is
+// not exposed: substrate serves user routes on every other host.
+func Hosts(hosts ...string) Option {
+	return func(s *Service) error {
+		s.hosts = append(s.hosts, hosts...)
+		return nil
+	}
+}
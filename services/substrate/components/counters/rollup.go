@@ -0,0 +1,180 @@
+package counters
+
+import (
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+// rollup is what a substrate node publishes on RollupTopic every
+// ReportInterval: its cumulative usage per project. Values only grow for the
+// life of the node, so the latest rollup of each peer supersedes the previous.
+type rollup struct {
+	PeerID   string
+	Projects map[string]*projectUsage
+
+	// seen is when we last heard the rollup, on the local clock.
+	seen time.Time
+}
+
+// projectUsage counts requests and the time spent on them, keyed by status.
+type projectUsage struct {
+	Requests     map[string]uint64
+	RequestNanos map[string]uint64
+}
+
+func newProjectUsage() *projectUsage {
+	return &projectUsage{
+		Requests:     make(map[string]uint64),
+		RequestNanos: make(map[string]uint64),
+	}
+}
+
+func (u *projectUsage) add(other *projectUsage) {
+	for status, count := range other.Requests {
+		u.Requests[status] += count
+	}
+	for status, nanos := range other.RequestNanos {
+		u.RequestNanos[status] += nanos
+	}
+}
+
+// startRollups subscribes to peers' rollups and publishes ours on a ticker.
+func (s *Service) startRollups() error {
+	err := s.Node().PubSubSubscribe(
+		RollupTopic,
+		s.handleRollup,
+		func(err error) {
+			if s.ctx.Err() == nil {
+				logger.Error("rollup subscription ended with:", err.Error())
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	go s.publishLoop()
+	return nil
+}
+
+// handleRollup keeps the rollups of substrate peers, under the peer that
+// signed the message: the PeerID it carries is the sender's to set.
+func (s *Service) handleRollup(msg *pubsub.Message) {
+	from := msg.GetFrom()
+	if !s.substratePeers.Has(from) {
+		return
+	}
+
+	r := new(rollup)
+	if cbor.Unmarshal(msg.Data, r) != nil {
+		return
+	}
+
+	r.PeerID = from.String()
+	s.observeRollup(r)
+}
+
+func (s *Service) publishLoop() {
+	ticker := time.NewTicker(ReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.publishRollup()
+		}
+	}
+}
+
+func (s *Service) publishRollup() {
+	r := s.localRollup(s.Node().ID().String())
+	s.observeRollup(r)
+
+	data, err := cbor.Marshal(r)
+	if err != nil {
+		logger.Errorf("marshalling rollup failed with: %s", err.Error())
+		return
+	}
+
+	if err = s.Node().PubSubPublish(s.ctx, RollupTopic, data); err != nil {
+		logger.Errorf("publishing rollup failed with: %s", err.Error())
+	}
+}
+
+// localRollup sums this node's request counters per project.
+func (s *Service) localRollup(peerID string) *rollup {
+	r := &rollup{PeerID: peerID, Projects: make(map[string]*projectUsage)}
+
+	for key, value := range s.snapshot() {
+		ser, ok := parseKey(key)
+		if !ok || ser.smartOp != "" {
+			continue
+		}
+
+		var counts map[string]uint64
+		switch ser.family {
+		case requestsFamily:
+			counts = s.usageOf(r, ser.project).Requests
+		case requestTimeFamily:
+			counts = s.usageOf(r, ser.project).RequestNanos
+		default:
+			continue
+		}
+
+		counts[ser.status] += uint64(value)
+	}
+
+	return r
+}
+
+func (s *Service) usageOf(r *rollup, project string) *projectUsage {
+	usage, ok := r.Projects[project]
+	if !ok {
+		usage = newProjectUsage()
+		r.Projects[project] = usage
+	}
+
+	return usage
+}
+
+func (s *Service) observeRollup(r *rollup) {
+	r.seen = time.Now()
+
+	s.rollupsLock.Lock()
+	defer s.rollupsLock.Unlock()
+	s.rollups[r.PeerID] = r
+}
+
+// networkUsage sums the latest rollup of every peer heard within
+// RollupExpiry, dropping the ones that expired.
+func (s *Service) networkUsage() map[string]*projectUsage {
+	s.rollupsLock.Lock()
+	defer s.rollupsLock.Unlock()
+
+	usage := make(map[string]*projectUsage)
+	for peerID, r := range s.rollups {
+		if time.Since(r.seen) > RollupExpiry {
+			delete(s.rollups, peerID)
+			continue
+		}
+
+		for project, pu := range r.Projects {
+			if pu == nil {
+				continue
+			}
+
+			total, ok := usage[project]
+			if !ok {
+				total = newProjectUsage()
+				usage[project] = total
+			}
+			total.add(pu)
+		}
+	}
+
+	return usage
+}
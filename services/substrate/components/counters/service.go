@@ -0,0 +1,166 @@
+package counters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/ipfs/go-log/v2"
+	seerApi "github.com/taubyte/tau/clients/p2p/seer"
+	seerIface "github.com/taubyte/tau/core/services/seer"
+	"github.com/taubyte/tau/core/services/substrate"
+	"github.com/taubyte/tau/core/services/substrate/counters"
+	http "github.com/taubyte/tau/pkg/http"
+	servicesCommon "github.com/taubyte/tau/services/common"
+)
+
+var logger = log.Logger("tau.substrate.service.counters")
+
+var _ substrate.CounterService = &Service{}
+
+// Service aggregates the metrics pushed by substrate serviceables. Values are
+// cumulative for the life of the node, exposed on a node-local /metrics
+// endpoint and rolled up per project over pubsub.
+type Service struct {
+	substrate.Service
+	ctx   context.Context
+	ctxC  context.CancelFunc
+	hosts []string
+
+	metricsLock sync.RWMutex
+	metrics     map[string]counters.Metric
+
+	rollupsLock    sync.RWMutex
+	rollups        map[string]*rollup
+	substratePeers *servicesCommon.ServicePeers
+}
+
+func New(srv substrate.Service, options ...Option) (substrate.CounterService, error) {
+	s := &Service{
+		Service: srv,
+		metrics: make(map[string]counters.Metric),
+		rollups: make(map[string]*rollup),
+	}
+
+	for _, opt := range options {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+
+	s.ctx, s.ctxC = context.WithCancel(srv.Context())
+
+	if len(s.hosts) > 0 && s.Http() != nil {
+		s.Http().Raw(&http.RawRouteDefinition{
+			Hosts:       s.hosts,
+			Path:        MetricsPath,
+			RawResponse: true,
+			Auth:        http.RouteAuthHandler{Validator: nodeLocal},
+			Handler: func(http.Context) (interface{}, error) {
+				return http.RawData{ContentType: openMetricsContentType, Data: s.OpenMetrics()}, nil
+			},
+		})
+	}
+
+	if s.Node() != nil {
+		seerClient, err := seerApi.New(s.ctx, s.Node(), nil)
+		if err != nil {
+			s.ctxC()
+			return nil, fmt.Errorf("creating seer client failed with: %w", err)
+		}
+
+		s.substratePeers = servicesCommon.NewServicePeers(seerClient.Usage(), seerIface.ServiceTypeSubstrate)
+		if err := s.startRollups(); err != nil {
+			s.ctxC()
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// nodeLocal only lets requests from the node itself through: the Host header
+// is the client's to set, so hosts alone don't keep /metrics private.
+func nodeLocal(ctx http.Context) (interface{}, error) {
+	host, _, err := net.SplitHostPort(ctx.Request().RemoteAddr)
+	if err != nil {
+		host = ctx.Request().RemoteAddr
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return nil, errors.New("metrics are only served to the node")
+	}
+
+	return nil, nil
+}
+
+// Push aggregates each metric into the one already stored under its key.
+func (s *Service) Push(metrics ...*counters.WrappedMetric) {
+	s.metricsLock.Lock()
+	defer s.metricsLock.Unlock()
+
+	for _, wm := range metrics {
+		if wm == nil || wm.Metric == nil {
+			continue
+		}
+
+		existing, ok := s.metrics[wm.Key]
+		if !ok {
+			s.metrics[wm.Key] = wm.Metric
+			continue
+		}
+
+		if err := existing.Aggregate(wm.Metric); err != nil {
+			logger.Errorf("aggregating metric `%s` failed with: %s", wm.Key, err.Error())
+		}
+	}
+}
+
+func (s *Service) Implemented() bool { return true }
+
+func (s *Service) Context() context.Context { return s.ctx }
+
+func (s *Service) Close() error {
+	s.ctxC()
+	return nil
+}
+
+// snapshot copies the current metric values keyed by metric path.
+func (s *Service) snapshot() map[string]float64 {
+	s.metricsLock.RLock()
+	defer s.metricsLock.RUnlock()
+
+	values := make(map[string]float64, len(s.metrics))
+	for key, metric := range s.metrics {
+		if value, ok := toFloat(metric.Interface()); ok {
+			values[key] = value
+		}
+	}
+
+	return values
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,200 @@
+package counters
+
+import (
+	goHttp "net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubPb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	seerIface "github.com/taubyte/tau/core/services/seer"
+	"github.com/taubyte/tau/core/services/substrate/counters"
+	http "github.com/taubyte/tau/pkg/http"
+	servicesCommon "github.com/taubyte/tau/services/common"
+	"github.com/taubyte/tau/services/substrate/components/counters/metrics"
+	"gotest.tools/v3/assert"
+)
+
+// testUsage lists the peers seer knows per service type.
+type testUsage struct {
+	seerIface.Usage
+	ids map[seerIface.ServiceType][]string
+}
+
+func (u testUsage) ListServiceId(name string) ([]string, error) {
+	return u.ids[seerIface.ServiceType(name)], nil
+}
+
+func newTestService(substrates ...peer.ID) *Service {
+	ids := make([]string, 0, len(substrates))
+	for _, pid := range substrates {
+		ids = append(ids, pid.String())
+	}
+
+	return &Service{
+		metrics: make(map[string]counters.Metric),
+		rollups: make(map[string]*rollup),
+		substratePeers: servicesCommon.NewServicePeers(
+			testUsage{ids: map[seerIface.ServiceType][]string{seerIface.ServiceTypeSubstrate: ids}},
+			seerIface.ServiceTypeSubstrate,
+		),
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	for key, expected := range map[string]series{
+		"proj/fn/s":            {family: requestsFamily, project: "proj", resource: "fn", status: "success"},
+		"proj/fn/f/t":          {family: requestTimeFamily, project: "proj", resource: "fn", status: "failure"},
+		"proj/fn/s/cs/f":       {family: coldStartsFamily, project: "proj", resource: "fn", status: "success", coldStartStatus: "failure"},
+		"proj/fn/f/cs/s/t":     {family: coldStartTimeFamily, project: "proj", resource: "fn", status: "failure", coldStartStatus: "success"},
+		"proj/fn/s/e":          {family: executionsFamily, project: "proj", resource: "fn", status: "success"},
+		"proj/fn/f/e/t":        {family: executionTimeFamily, project: "proj", resource: "fn", status: "failure"},
+		"proj/fn/m":            {family: memoryFamily, project: "proj", resource: "fn"},
+		"proj/fn/r":            {family: rejectedFamily, project: "proj", resource: "fn"},
+		"proj/fn@c1/s":         {family: requestsFamily, project: "proj", resource: "fn", commit: "c1", status: "success"},
+		"proj/fn/op/op/f":      {family: requestsFamily, project: "proj", resource: "fn", smartOp: "op", status: "failure"},
+		"proj/fn/op/op/s/cs/s": {family: coldStartsFamily, project: "proj", resource: "fn", smartOp: "op", status: "success", coldStartStatus: "success"},
+	} {
+		got, ok := parseKey(key)
+		assert.Assert(t, ok, key)
+		assert.Equal(t, got, expected, key)
+	}
+
+	for _, key := range []string{"proj", "proj/fn", "proj/fn/x", "proj/fn/s/cs", "proj/fn/s/z/t", "proj/fn/op/op", "proj/fn/s/s/s"} {
+		_, ok := parseKey(key)
+		assert.Assert(t, !ok, key)
+	}
+}
+
+func TestPush(t *testing.T) {
+	s := newTestService()
+
+	s.Push(
+		&counters.WrappedMetric{Key: "proj/fn/s", Metric: metrics.NewSumMetric[uint64](1)},
+		&counters.WrappedMetric{Key: "proj/fn/m", Metric: metrics.NewMaxMetric[uint64](64)},
+		nil,
+	)
+	s.Push(
+		&counters.WrappedMetric{Key: "proj/fn/s", Metric: metrics.NewSumMetric[uint64](2)},
+		&counters.WrappedMetric{Key: "proj/fn/m", Metric: metrics.NewMaxMetric[uint64](32)},
+	)
+
+	snap := s.snapshot()
+	assert.Equal(t, snap["proj/fn/s"], float64(3))
+	assert.Equal(t, snap["proj/fn/m"], float64(64))
+}
+
+func TestOpenMetrics(t *testing.T) {
+	s := newTestService()
+	s.Push(
+		&counters.WrappedMetric{Key: "proj/fn/s", Metric: metrics.NewSumMetric[uint64](2)},
+		&counters.WrappedMetric{Key: "proj/fn/s/t", Metric: metrics.NewSumMetric[int64](3e9)},
+		&counters.WrappedMetric{Key: "proj/fn/m", Metric: metrics.NewMaxMetric[uint64](1024)},
+		&counters.WrappedMetric{Key: "proj/fn@c2/f", Metric: metrics.NewSumMetric[uint64](1)},
+		&counters.WrappedMetric{Key: "proj/fn/r", Metric: metrics.NewSumMetric[uint64](4)},
+		&counters.WrappedMetric{Key: "proj/fn/s/cs/s", Metric: metrics.NewSumMetric[uint64](1)},
+		&counters.WrappedMetric{Key: "proj/fn/f/cs/s", Metric: metrics.NewSumMetric[uint64](1)},
+		&counters.WrappedMetric{Key: "proj/fn/op/op/s", Metric: metrics.NewSumMetric[uint64](6)},
+		&counters.WrappedMetric{Key: "not/a/metric/path", Metric: metrics.NewSumMetric[uint64](1)},
+	)
+
+	s.observeRollup(s.localRollup("self"))
+	s.observeRollup(&rollup{
+		PeerID:   "other",
+		Projects: map[string]*projectUsage{"proj": {Requests: map[string]uint64{"success": 5}}},
+	})
+
+	out := string(s.OpenMetrics())
+	for _, line := range []string{
+		"# TYPE tau_substrate_requests counter",
+		`tau_substrate_requests_total{project="proj",resource="fn",status="success"} 2`,
+		`tau_substrate_requests_total{project="proj",resource="fn",commit="c2",status="failure"} 1`,
+		"# UNIT tau_substrate_request_seconds seconds",
+		`tau_substrate_request_seconds_total{project="proj",resource="fn",status="success"} 3`,
+		"# TYPE tau_substrate_memory_max_bytes gauge",
+		`tau_substrate_memory_max_bytes{project="proj",resource="fn"} 1024`,
+		`tau_substrate_rejected_requests_total{project="proj",resource="fn"} 4`,
+		`tau_substrate_cold_starts_total{project="proj",resource="fn",status="success",cold_start_status="success"} 1`,
+		`tau_substrate_cold_starts_total{project="proj",resource="fn",status="failure",cold_start_status="success"} 1`,
+		`tau_substrate_requests_total{project="proj",resource="fn",smartop="op",status="success"} 6`,
+		`tau_project_requests_total{project="proj",status="success"} 7`,
+		`tau_project_request_seconds_total{project="proj",status="success"} 3`,
+	} {
+		assert.Assert(t, strings.Contains(out, line+"\n"), line)
+	}
+	assert.Assert(t, !strings.Contains(out, "not/a"))
+	assert.Assert(t, strings.HasSuffix(out, "# EOF\n"))
+}
+
+func TestRollupExpiry(t *testing.T) {
+	s := newTestService()
+
+	s.observeRollup(&rollup{
+		PeerID:   "stale",
+		Projects: map[string]*projectUsage{"proj": {Requests: map[string]uint64{"success": 1}}},
+	})
+	s.rollups["stale"].seen = time.Now().Add(-2 * RollupExpiry)
+
+	assert.Equal(t, len(s.networkUsage()), 0)
+	assert.Equal(t, len(s.rollups), 0)
+}
+
+func TestRollupWireFormat(t *testing.T) {
+	data, err := cbor.Marshal(&rollup{
+		PeerID:   "peer",
+		Projects: map[string]*projectUsage{"proj": {RequestNanos: map[string]uint64{"failure": 10}}},
+	})
+	assert.NilError(t, err)
+
+	r := new(rollup)
+	assert.NilError(t, cbor.Unmarshal(data, r))
+	assert.Equal(t, r.PeerID, "peer")
+	assert.Equal(t, r.Projects["proj"].RequestNanos["failure"], uint64(10))
+}
+
+func TestHandleRollup(t *testing.T) {
+	substrate, stranger := peer.ID("substrate"), peer.ID("stranger")
+	s := newTestService(substrate)
+
+	publish := func(from peer.ID, claimed string) {
+		data, err := cbor.Marshal(&rollup{
+			PeerID:   claimed,
+			Projects: map[string]*projectUsage{"proj": {Requests: map[string]uint64{"success": 1}}},
+		})
+		assert.NilError(t, err)
+		s.handleRollup(&pubsub.Message{Message: &pubsubPb.Message{From: []byte(from), Data: data}})
+	}
+
+	publish(substrate, "someone-else")
+	publish(stranger, stranger.String())
+
+	assert.Equal(t, len(s.rollups), 1)
+	assert.Assert(t, s.rollups[substrate.String()] != nil)
+	assert.Equal(t, s.networkUsage()["proj"].Requests["success"], uint64(1))
+}
+
+func TestFormatLabels(t *testing.T) {
+	assert.Equal(t, formatLabels([][2]string{{"a", `x"y\z` + "\n"}}), `{a="x\"y\\z\n"}`)
+}
+
+type requestContext struct {
+	http.Context
+	request *goHttp.Request
+}
+
+func (c requestContext) Request() *goHttp.Request { return c.request }
+
+func TestNodeLocal(t *testing.T) {
+	for addr, allowed := range map[string]bool{
+		"127.0.0.1:4242":   true,
+		"[::1]:4242":       true,
+		"203.0.113.7:4242": false,
+		"garbage":          false,
+	} {
+		_, err := nodeLocal(requestContext{request: &goHttp.Request{RemoteAddr: addr, Host: "localhost"}})
+		assert.Equal(t, err == nil, allowed, addr)
+	}
+}
@@ -0,0 +1,22 @@
+package counters
+
+import "github.com/taubyte/tau/core/services/substrate/counters"
+
+// RollupTopic carries each substrate node's per-project usage, so any node's
+// /metrics can report network-wide usage.
+var RollupTopic = "/substrate/v1/counters"
+
+// Tunables — exported so tests can shrink them.
+var (
+	// ReportInterval is how often a node publishes its rollup.
+	ReportInterval = counters.DefaultReportTime
+
+	// RollupExpiry drops the rollup of a node we stopped hearing from.
+	RollupExpiry = 3 * counters.DefaultReportTime
+)
+
+const (
+	MetricsPath = "/metrics"
+
+	openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
@@ -3,11 +3,14 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 
 	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
 	"github.com/taubyte/tau/core/services/substrate"
 	iface "github.com/taubyte/tau/core/services/substrate/components/database"
+	"github.com/taubyte/tau/core/services/substrate/events"
 	kv "github.com/taubyte/tau/services/substrate/components/database/kv"
+	tcc "github.com/taubyte/tau/utils/tcc"
 )
 
 // New opens a database instance backed by a remote hoarder-hosted kvdb. The
@@ -18,10 +21,29 @@ func New(srv substrate.Service, hoarderClient hoarderIface.Client, dbContext ifa
 		return nil, fmt.Errorf("opening remote kvdb for %s failed with: %w", dbContext.Matcher, err)
 	}
 
+	keystore := kv.New(dbContext.Config.Size, dbContext.Matcher, store, kv.Scope(dbContext.ProjectId, dbContext.ApplicationId, dbContext.Matcher), kv.Notify(func(ctx context.Context, kind events.Kind, key string, size int) {
+		if emitter := srv.Events(); emitter != nil {
+			emitter.Emit(ctx, dbContext.ProjectId, dbContext.ApplicationId, &events.Event{
+				Resource: events.Database,
+				Name:     dbContext.Config.Name,
+				Kind:     kind,
+				Key:      key,
+				Size:     size,
+				Time:     time.Now(),
+			})
+		}
+	}), kv.Previous(func(context.Context) (string, error) {
+		return previousKey(srv, dbContext, branch)
+	}))
+	if err = keystore.UpdateKey(dbContext.Config.Key); err != nil {
+		keystore.Close()
+		return nil, fmt.Errorf("setting key of %s failed with: %w", dbContext.Matcher, err)
+	}
+
 	db := &Database{
 		srv:       srv,
 		dbContext: dbContext,
-		keystore:  kv.New(dbContext.Config.Size, dbContext.Matcher, store),
+		keystore:  keystore,
 	}
 	db.instanceCtx, db.instanceCtxC = context.WithCancel(srv.Node().Context())
 
@@ -36,6 +58,22 @@ func New(srv substrate.Service, hoarderClient hoarderIface.Client, dbContext ifa
 	return db, nil
 }
 
+// previousKey returns the key the database was configured with at the
+// deployment before the current one, empty when there is none.
+func previousKey(srv substrate.Service, dbContext iface.Context, branch string) (string, error) {
+	commit, err := tcc.PreviousCommit(srv.Tns(), dbContext.ProjectId, branch)
+	if err != nil || commit == "" {
+		return "", err
+	}
+
+	config, err := srv.Tns().Database().All(dbContext.ProjectId, dbContext.ApplicationId, branch).GetByIdCommit(dbContext.Config.Id, commit)
+	if err != nil {
+		return "", fmt.Errorf("fetching database %s at commit `%s` failed with: %w", dbContext.Config.Name, commit, err)
+	}
+
+	return config.Key, nil
+}
+
 // Open wraps an already-resolved KV (used by the global path).
 func Open(srv substrate.Service, dbContext iface.Context, kv iface.KV) (iface.Database, error) {
 	db := &Database{
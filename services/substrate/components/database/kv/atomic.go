@@ -0,0 +1,298 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+
+	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
+	iface "github.com/taubyte/tau/core/services/substrate/components/database"
+	"github.com/taubyte/tau/core/services/substrate/events"
+	"github.com/taubyte/tau/pkg/kvdb"
+)
+
+// hidden tells whether an entry is kept by substrate rather than the project.
+func hidden(name string) bool {
+	return reserved(name) || strings.HasPrefix(strings.TrimPrefix(name, "/"), "size/")
+}
+
+// read returns the value of key as stored, nil when it is absent.
+func (kv *kv) read(ctx context.Context, key string) []byte {
+	value, err := kv.database.Get(ctx, key)
+	if err != nil {
+		return nil
+	}
+
+	return value
+}
+
+// swap stores sealed in place of current, the value of key as stored or nil
+// when absent, unless it changed since it was read. Stores that can't compare
+// and swap themselves are only atomic against writes made by this node.
+func (kv *kv) swap(ctx context.Context, key string, current, sealed []byte) (bool, error) {
+	if cas, ok := kv.database.(hoarderIface.CasKVDB); ok {
+		var expected []byte
+		if current != nil {
+			sum := sha256.Sum256(current)
+			expected = sum[:]
+		}
+
+		return cas.CompareAndSwap(ctx, key, expected, sealed)
+	}
+
+	kv.writeLock.Lock()
+	defer kv.writeLock.Unlock()
+
+	if value := kv.read(ctx, key); (value == nil) != (current == nil) || !bytes.Equal(value, current) {
+		return false, nil
+	}
+
+	return true, kv.database.Put(ctx, key, sealed)
+}
+
+func (kv *kv) putSize(ctx context.Context, key string, size int) error {
+	if err := kv.database.Put(ctx, path.Join("size", key), []byte(strconv.Itoa(size))); err != nil {
+		return fmt.Errorf("failed putting size for key %s in database with error: %v", key, err)
+	}
+
+	return nil
+}
+
+// compareAndSwap stores v once update accepts the current value of key, nil
+// when absent, retrying as long as the value changes while being replaced.
+func (kv *kv) compareAndSwap(ctx context.Context, key string, update func(current []byte) ([]byte, error)) (bool, error) {
+	if reserved(key) {
+		return false, fmt.Errorf("key %s is reserved", key)
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		raw := kv.read(ctx, key)
+
+		var current []byte
+		if raw != nil {
+			var err error
+			if current, err = kv.open(ctx, key, raw); err != nil {
+				return false, fmt.Errorf("failed reading key %s using database %s with error: %w", key, kv.name, err)
+			}
+		}
+
+		v, err := update(current)
+		if v == nil || err != nil {
+			return false, err
+		}
+
+		if err = kv.checkValidSize(ctx, len(v)); err != nil {
+			return false, err
+		}
+
+		sealed, err := kv.keys.seal(key, v)
+		if err != nil {
+			return false, fmt.Errorf("failed sealing %s with error: %w", key, err)
+		}
+
+		swapped, err := kv.swap(ctx, key, raw, sealed)
+		if err != nil {
+			return false, fmt.Errorf("failed putting %s in database %s with error: %v", key, kv.name, err)
+		}
+
+		if swapped {
+			if err = kv.putSize(ctx, key, len(v)); err != nil {
+				return true, err
+			}
+
+			kv.changed(ctx, events.Put, key, len(v))
+			return true, nil
+		}
+	}
+}
+
+// A nil value returned by update leaves the key as it is, so empty values are
+// written as empty slices.
+func orEmpty(v []byte) []byte {
+	if v == nil {
+		return []byte{}
+	}
+
+	return v
+}
+
+func (kv *kv) PutNx(ctx context.Context, key string, v []byte) (existed bool, err error) {
+	v = orEmpty(v)
+	swapped, err := kv.compareAndSwap(ctx, key, func(current []byte) ([]byte, error) {
+		if current != nil {
+			return nil, nil
+		}
+
+		return v, nil
+	})
+
+	return !swapped && err == nil, err
+}
+
+func (kv *kv) CompareAndSwap(ctx context.Context, key string, expected, v []byte) (bool, error) {
+	v = orEmpty(v)
+	return kv.compareAndSwap(ctx, key, func(current []byte) ([]byte, error) {
+		if current == nil {
+			if len(expected) == 0 {
+				return v, nil
+			}
+			return nil, nil
+		}
+
+		if sum := sha256.Sum256(current); !bytes.Equal(sum[:], expected) {
+			return nil, nil
+		}
+
+		return v, nil
+	})
+}
+
+func (kv *kv) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	var next int64
+	_, err := kv.compareAndSwap(ctx, key, func(current []byte) ([]byte, error) {
+		var value int64
+		if current != nil {
+			var err error
+			if value, err = strconv.ParseInt(string(current), 10, 64); err != nil {
+				return nil, fmt.Errorf("value of key %s is not an integer", key)
+			}
+		}
+
+		if (delta > 0 && value > math.MaxInt64-delta) || (delta < 0 && value < math.MinInt64-delta) {
+			return nil, fmt.Errorf("incrementing key %s overflows", key)
+		}
+
+		next = value + delta
+		return []byte(strconv.FormatInt(next, 10)), nil
+	})
+
+	return next, err
+}
+
+func (kv *kv) Scan(ctx context.Context, prefix, after string, limit int) ([]string, error) {
+	keys := make([]string, 0, max(limit, 0))
+	for {
+		want := limit - len(keys)
+		page, err := kvdb.Scan(ctx, kv.database, prefix, after, want)
+		if err != nil {
+			return nil, fmt.Errorf("scanning with prefix %s failed with: %w", prefix, err)
+		}
+
+		for _, key := range page {
+			if !hidden(key) {
+				keys = append(keys, key)
+			}
+		}
+
+		if limit <= 0 || len(page) < want || len(keys) == limit {
+			return keys, nil
+		}
+
+		after = page[len(page)-1]
+	}
+}
+
+type batch struct {
+	kv   *kv
+	ctx  context.Context
+	puts map[string][]byte
+	ops  []string
+}
+
+// Batch groups writes so they are applied by one batch of the store.
+func (kv *kv) Batch(ctx context.Context) (iface.Batch, error) {
+	return &batch{kv: kv, ctx: ctx, puts: make(map[string][]byte)}, nil
+}
+
+func (b *batch) Put(key string, v []byte) error {
+	if reserved(key) {
+		return fmt.Errorf("key %s is reserved", key)
+	}
+
+	b.ops = append(b.ops, key)
+	b.puts[key] = v
+	return nil
+}
+
+func (b *batch) Delete(key string) error {
+	if reserved(key) {
+		return fmt.Errorf("key %s is reserved", key)
+	}
+
+	b.ops = append(b.ops, key)
+	delete(b.puts, key)
+	return nil
+}
+
+func (b *batch) Commit() error {
+	if len(b.ops) == 0 {
+		return nil
+	}
+
+	var size int
+	for _, v := range b.puts {
+		size += len(v)
+	}
+
+	if err := b.kv.checkValidSize(b.ctx, size); err != nil {
+		return err
+	}
+
+	store, err := b.kv.database.Batch(b.ctx)
+	if err != nil {
+		return fmt.Errorf("creating batch of database %s failed with: %w", b.kv.name, err)
+	}
+
+	// Only the last write of a key is applied.
+	seen := make(map[string]bool, len(b.ops))
+	for i := len(b.ops) - 1; i >= 0; i-- {
+		key := b.ops[i]
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		v, put := b.puts[key]
+		if !put {
+			err = errors.Join(store.Delete(key), store.Delete(path.Join("size", key)))
+		} else {
+			var sealed []byte
+			if sealed, err = b.kv.keys.seal(key, v); err != nil {
+				return fmt.Errorf("failed sealing %s with error: %w", key, err)
+			}
+
+			err = errors.Join(store.Put(key, sealed), store.Put(path.Join("size", key), []byte(strconv.Itoa(len(v)))))
+		}
+
+		if err != nil {
+			return fmt.Errorf("batching %s failed with: %w", key, err)
+		}
+	}
+
+	b.kv.writeLock.RLock()
+	defer b.kv.writeLock.RUnlock()
+
+	if err = store.Commit(); err != nil {
+		return fmt.Errorf("committing batch of database %s failed with: %w", b.kv.name, err)
+	}
+
+	for key := range seen {
+		if v, put := b.puts[key]; put {
+			b.kv.changed(b.ctx, events.Put, key, len(v))
+		} else {
+			b.kv.changed(b.ctx, events.Delete, key, 0)
+		}
+	}
+
+	return nil
+}
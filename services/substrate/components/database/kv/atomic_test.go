@@ -0,0 +1,143 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/taubyte/tau/core/kvdb"
+	"gotest.tools/v3/assert"
+)
+
+// casStore compares and swaps like a hoarder-backed store does.
+type casStore struct {
+	kvdb.KVDB
+	lock sync.Mutex
+}
+
+func (s *casStore) CompareAndSwap(ctx context.Context, key string, expected, value []byte) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	current, err := s.Get(ctx, key)
+	if err != nil {
+		if len(expected) != 0 {
+			return false, nil
+		}
+	} else if sum := sha256.Sum256(current); !bytes.Equal(sum[:], expected) {
+		return false, nil
+	}
+
+	return true, s.Put(ctx, key, value)
+}
+
+func TestConditionalWrites(t *testing.T) {
+	ctx := context.Background()
+	db, store := newTestKV(t)
+	wrapped := New(1<<20, "test", &casStore{KVDB: store}, Scope("project", "application", "test")).(*kv)
+
+	for _, db := range []*kv{db, wrapped} {
+		updateKey(t, db, "secret")
+		assert.NilError(t, db.Delete(ctx, "value"))
+
+		existed, err := db.PutNx(ctx, "value", []byte("first"))
+		assert.NilError(t, err)
+		assert.Assert(t, !existed)
+
+		existed, err = db.PutNx(ctx, "value", []byte("second"))
+		assert.NilError(t, err)
+		assert.Assert(t, existed)
+
+		sum := sha256.Sum256([]byte("other"))
+		swapped, err := db.CompareAndSwap(ctx, "value", sum[:], []byte("stale"))
+		assert.NilError(t, err)
+		assert.Assert(t, !swapped)
+
+		sum = sha256.Sum256([]byte("first"))
+		swapped, err = db.CompareAndSwap(ctx, "value", sum[:], []byte("second"))
+		assert.NilError(t, err)
+		assert.Assert(t, swapped)
+
+		value, err := db.Get(ctx, "value")
+		assert.NilError(t, err)
+		assert.Equal(t, string(value), "second")
+	}
+}
+
+func TestIncrement(t *testing.T) {
+	ctx := context.Background()
+	db, store := newTestKV(t)
+	db = New(1<<20, "test", &casStore{KVDB: store}).(*kv)
+	updateKey(t, db, "secret")
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := db.Increment(ctx, "counter", 2)
+			assert.Check(t, err)
+		}()
+	}
+	wg.Wait()
+
+	next, err := db.Increment(ctx, "counter", -1)
+	assert.NilError(t, err)
+	assert.Equal(t, next, int64(39))
+
+	value, err := db.Get(ctx, "counter")
+	assert.NilError(t, err)
+	assert.Equal(t, string(value), "39")
+
+	assert.NilError(t, db.Put(ctx, "name", []byte("tau")))
+	_, err = db.Increment(ctx, "name", 1)
+	assert.ErrorContains(t, err, "not an integer")
+}
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	db, _ := newTestKV(t)
+	assert.NilError(t, db.Put(ctx, "old", []byte("old")))
+
+	batch, err := db.Batch(ctx)
+	assert.NilError(t, err)
+	assert.NilError(t, batch.Put("a", []byte("a")))
+	assert.NilError(t, batch.Put("b", []byte("b")))
+	assert.NilError(t, batch.Delete("b"))
+	assert.NilError(t, batch.Delete("old"))
+	assert.ErrorContains(t, batch.Put(reservedPrefix+"x", nil), "reserved")
+	assert.ErrorContains(t, batch.Delete("/"+sealedEntry), "reserved")
+	assert.NilError(t, batch.Commit())
+
+	keys, err := db.Scan(ctx, "", "", 0)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys, []string{"a"})
+
+	size, err := db.Size(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, size, uint64(1<<20-1))
+}
+
+func TestScan(t *testing.T) {
+	ctx := context.Background()
+	db, _ := newTestKV(t)
+	for i := range 5 {
+		assert.NilError(t, db.Put(ctx, fmt.Sprintf("item/%d", i), nil))
+	}
+	updateKey(t, db, "secret")
+
+	keys, err := db.Scan(ctx, "", "", 2)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys, []string{"item/0", "item/1"})
+
+	keys, err = db.Scan(ctx, "item/", keys[1], 2)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys, []string{"item/2", "item/3"})
+
+	keys, err = db.Scan(ctx, "item/", keys[1], 0)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys, []string{"item/4"})
+}
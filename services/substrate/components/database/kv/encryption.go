@@ -0,0 +1,291 @@
+package kv
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/taubyte/tau/services/substrate/components/database/common"
+)
+
+// Entries under reservedPrefix hold what substrate needs to read a database;
+// they are not values of the project.
+const (
+	reservedPrefix = ".tau/"
+	// keysPrefix holds the keys being rotated out, each sealed with the key
+	// that replaced it, so any node with the current key can read values not
+	// sealed again yet.
+	keysPrefix = reservedPrefix + "keys/"
+	// sealedEntry holds the id of the key every value was last sealed with.
+	sealedEntry = reservedPrefix + "sealed"
+)
+
+func reserved(name string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(name, "/"), reservedPrefix)
+}
+
+func sealedMarker(k *key) string {
+	if k == nil {
+		return ""
+	}
+
+	return k.id.String()
+}
+
+// UpdateKey sets the key values are sealed with, an empty one storing them in
+// the clear. Values sealed with the previous key stay readable while a
+// background pass seals them with the new one. The key values were last sealed
+// with must be known, from this node, the keys being rotated out or the
+// Previous lookup; otherwise the key is not rotated.
+func (kv *kv) UpdateKey(secret string) error {
+	kv.keyLock.Lock()
+	defer kv.keyLock.Unlock()
+
+	if kv.resealDone != nil && secret == kv.secret {
+		return nil
+	}
+
+	var next *key
+	if secret != "" {
+		var err error
+		if next, err = kv.keys.derive(secret); err != nil {
+			return err
+		}
+		kv.keys.add(next)
+	}
+
+	sealed, err := kv.sealedKey(kv.ctx, next)
+	if err != nil {
+		return err
+	}
+
+	previous := kv.keys.getCurrent()
+	kv.keys.setCurrent(next)
+	kv.secret = secret
+
+	if kv.resealC != nil {
+		kv.resealC()
+	}
+
+	ctx, ctxC := context.WithCancel(kv.ctx)
+	done := make(chan struct{})
+	kv.resealC, kv.resealDone = ctxC, done
+
+	go func() {
+		defer close(done)
+		if err := kv.reseal(ctx, []*key{previous, sealed}, next); err != nil && ctx.Err() == nil {
+			common.Logger.Errorf("sealing values of database %s with its key failed with: %s", kv.name, err)
+		}
+	}()
+
+	return nil
+}
+
+// sealedKey returns the key values were last sealed with, nil when they are
+// stored in the clear or never were sealed. It is looked for in the keyring,
+// then in the keys being rotated out, which next may unseal, then at the
+// Previous lookup.
+func (kv *kv) sealedKey(ctx context.Context, next *key) (*key, error) {
+	marker, err := kv.database.Get(ctx, sealedEntry)
+	if err != nil || len(marker) == 0 {
+		return nil, nil
+	}
+
+	var id keyId
+	if n, err := hex.Decode(id[:], marker); err != nil || n != keyIdSize {
+		return nil, fmt.Errorf("reading key id of database %s failed", kv.name)
+	}
+
+	if k, ok := kv.keys.get(id); ok {
+		return k, nil
+	}
+
+	if err = kv.loadKeys(ctx); err != nil {
+		return nil, fmt.Errorf("loading previous keys failed with: %w", err)
+	}
+
+	if k, ok := kv.keys.get(id); ok {
+		return k, nil
+	}
+
+	if kv.previous != nil {
+		secret, err := kv.previous(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("looking up previous key of database %s failed with: %w", kv.name, err)
+		}
+
+		if secret != "" {
+			k, err := kv.keys.derive(secret)
+			if err != nil {
+				return nil, err
+			}
+
+			if k.id == id {
+				kv.keys.add(k)
+				return k, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("values of database %s are sealed with unknown key `%s`, not rotating", kv.name, id)
+}
+
+// reseal stores every value the way new ones are, unless a pass already did.
+// The previous keys are kept, sealed with the current one, until it's done.
+func (kv *kv) reseal(ctx context.Context, previous []*key, current *key) error {
+	var hadKey bool
+	for _, k := range previous {
+		if k == nil {
+			continue
+		}
+
+		hadKey = true
+		if current != nil && k.id != current.id {
+			if err := kv.storeKey(ctx, k, current); err != nil {
+				return fmt.Errorf("storing previous key failed with: %w", err)
+			}
+		}
+	}
+
+	if err := kv.loadKeys(ctx); err != nil {
+		return fmt.Errorf("loading previous keys failed with: %w", err)
+	}
+
+	if marker, err := kv.database.Get(ctx, sealedEntry); err == nil && string(marker) == sealedMarker(current) {
+		return nil
+	} else if err != nil && current == nil && !hadKey {
+		// Never sealed.
+		return nil
+	}
+
+	names, err := kv.database.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("listing values failed with: %w", err)
+	}
+
+	var failed int
+	for _, name := range names {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		if reserved(name) || strings.HasPrefix(strings.TrimPrefix(name, "/"), "size/") {
+			continue
+		}
+
+		value, err := kv.database.Get(ctx, name)
+		if err != nil || kv.keys.isCurrent(value) {
+			continue
+		}
+
+		plain, err := kv.keys.open(name, value)
+		if err != nil {
+			common.Logger.Errorf("reading %s of database %s failed with: %s", name, kv.name, err)
+			failed++
+			continue
+		}
+
+		sealed, err := kv.keys.seal(name, plain)
+		if err != nil {
+			return err
+		}
+
+		// A value written since was sealed with the current key.
+		if _, err = kv.swap(ctx, name, value, sealed); err != nil {
+			return fmt.Errorf("storing %s failed with: %w", name, err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d values could not be read", failed)
+	}
+
+	if err = kv.database.Put(ctx, sealedEntry, []byte(sealedMarker(current))); err != nil {
+		return err
+	}
+
+	// Nothing is sealed with the previous keys anymore.
+	keys, err := kv.database.List(ctx, keysPrefix)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range keys {
+		if err = kv.database.Delete(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// storeKey stores a key being rotated out, sealed with the one replacing it.
+func (kv *kv) storeKey(ctx context.Context, previous, current *key) error {
+	name := keysPrefix + previous.id.String()
+	sealed, err := current.seal(kv.keys.aad(name), []byte(previous.secret))
+	if err != nil {
+		return err
+	}
+
+	return kv.database.Put(ctx, name, sealed)
+}
+
+// loadKeys adds to the keyring the keys being rotated out it can unseal.
+func (kv *kv) loadKeys(ctx context.Context) error {
+	names, err := kv.database.List(ctx, keysPrefix)
+	if err != nil {
+		return err
+	}
+
+	pending := make(map[string][]byte, len(names))
+	for _, name := range names {
+		if value, err := kv.database.Get(ctx, name); err == nil {
+			pending[name] = value
+		}
+	}
+
+	// A key can be sealed with one that is itself being rotated out.
+	for progress := true; progress; {
+		progress = false
+		for name, value := range pending {
+			id, _ := sealedWith(value)
+			sealer, ok := kv.keys.get(id)
+			if !ok {
+				continue
+			}
+
+			delete(pending, name)
+
+			secret, err := sealer.open(kv.keys.aad(name), value)
+			if err != nil {
+				common.Logger.Errorf("reading key %s of database %s failed with: %s", name, kv.name, err)
+				continue
+			}
+
+			k, err := kv.keys.derive(string(secret))
+			if err != nil {
+				return err
+			}
+
+			kv.keys.add(k)
+			progress = true
+		}
+	}
+
+	return nil
+}
+
+// open decrypts a value, looking for keys rotated out by other nodes when it
+// is sealed with one it doesn't know.
+func (kv *kv) open(ctx context.Context, name string, value []byte) ([]byte, error) {
+	plain, err := kv.keys.open(name, value)
+	if errors.Is(err, errUnknownKey) {
+		if err = kv.loadKeys(ctx); err == nil {
+			plain, err = kv.keys.open(name, value)
+		}
+	}
+
+	return plain, err
+}
@@ -0,0 +1,175 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/taubyte/tau/core/kvdb"
+	"github.com/taubyte/tau/pkg/kvdb/mock"
+	"gotest.tools/v3/assert"
+)
+
+func newTestKV(t *testing.T) (*kv, kvdb.KVDB) {
+	store, err := mock.New().New(nil, "test", 0)
+	assert.NilError(t, err)
+
+	return New(1<<20, "test", store, Scope("project", "application", "test")).(*kv), store
+}
+
+const testScope = "project/application/test"
+
+func updateKey(t *testing.T, db *kv, secret string) {
+	assert.NilError(t, db.UpdateKey(secret))
+	<-db.resealDone
+}
+
+func sealedBy(t *testing.T, store kvdb.KVDB, name, secret string) bool {
+	value, err := store.Get(context.Background(), name)
+	assert.NilError(t, err)
+
+	id, sealed := sealedWith(value)
+	if secret == "" {
+		return !sealed
+	}
+
+	k, err := deriveKey(secret, testScope)
+	assert.NilError(t, err)
+
+	return sealed && id == k.id
+}
+
+func TestEncryption(t *testing.T) {
+	ctx := context.Background()
+	db, store := newTestKV(t)
+
+	// Stored before the database had a key.
+	assert.NilError(t, db.Put(ctx, "legacy", []byte("clear")))
+	assert.Assert(t, sealedBy(t, store, "legacy", ""))
+
+	updateKey(t, db, "one")
+	assert.Assert(t, sealedBy(t, store, "legacy", "one"))
+
+	assert.NilError(t, db.Put(ctx, "value", []byte("secret")))
+	raw, err := store.Get(ctx, "value")
+	assert.NilError(t, err)
+	assert.Assert(t, !bytes.Contains(raw, []byte("secret")))
+
+	for name, expected := range map[string]string{"legacy": "clear", "value": "secret"} {
+		value, err := db.Get(ctx, name)
+		assert.NilError(t, err)
+		assert.Equal(t, string(value), expected)
+	}
+
+	// Sizes are of the values written.
+	size, err := db.Size(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, size, uint64(1<<20-len("clear")-len("secret")))
+
+	// A sealed value is bound to its name.
+	assert.NilError(t, store.Put(ctx, "moved", raw))
+	_, err = db.Get(ctx, "moved")
+	assert.ErrorContains(t, err, "decrypting value failed")
+
+	assert.ErrorContains(t, db.Put(ctx, reservedPrefix+"x", nil), "reserved")
+	_, err = db.Get(ctx, sealedEntry)
+	assert.ErrorContains(t, err, "reserved")
+	assert.ErrorContains(t, db.Delete(ctx, "/"+sealedEntry), "reserved")
+	_, err = store.Get(ctx, sealedEntry)
+	assert.NilError(t, err)
+
+	// Nor can it be read by another database with the same key.
+	otherStore, err := mock.New().New(nil, "other", 0)
+	assert.NilError(t, err)
+	assert.NilError(t, otherStore.Put(ctx, "value", raw))
+	other := New(1<<20, "other", otherStore, Scope("project", "application", "other")).(*kv)
+	updateKey(t, other, "one")
+	_, err = other.Get(ctx, "value")
+	assert.ErrorContains(t, err, "unknown key")
+}
+
+func TestKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	db, store := newTestKV(t)
+
+	updateKey(t, db, "one")
+	assert.NilError(t, db.Put(ctx, "value", []byte("secret")))
+
+	updateKey(t, db, "two")
+	assert.Assert(t, sealedBy(t, store, "value", "two"))
+
+	keys, err := store.List(ctx, keysPrefix)
+	assert.NilError(t, err)
+	assert.Equal(t, len(keys), 0)
+
+	value, err := db.Get(ctx, "value")
+	assert.NilError(t, err)
+	assert.Equal(t, string(value), "secret")
+
+	updateKey(t, db, "")
+	assert.Assert(t, sealedBy(t, store, "value", ""))
+	value, err = store.Get(ctx, "value")
+	assert.NilError(t, err)
+	assert.Equal(t, string(value), "secret")
+}
+
+func TestKeyRotationOnOtherNode(t *testing.T) {
+	ctx := context.Background()
+	db, store := newTestKV(t)
+
+	updateKey(t, db, "one")
+	assert.NilError(t, db.Put(ctx, "value", []byte("secret")))
+
+	one, err := deriveKey("one", testScope)
+	assert.NilError(t, err)
+	two, err := deriveKey("two", testScope)
+	assert.NilError(t, err)
+
+	// Another node rotated the key but values are still sealed with the
+	// previous one.
+	assert.NilError(t, db.storeKey(ctx, one, two))
+
+	other := New(1<<20, "test", store, Scope("project", "application", "test")).(*kv)
+	other.keys.setCurrent(two)
+
+	value, err := other.Get(ctx, "value")
+	assert.NilError(t, err)
+	assert.Equal(t, string(value), "secret")
+
+	updateKey(t, other, "two")
+	assert.Assert(t, sealedBy(t, store, "value", "two"))
+}
+
+func TestKeyRotationUnknownKey(t *testing.T) {
+	ctx := context.Background()
+	db, store := newTestKV(t)
+
+	updateKey(t, db, "one")
+	assert.NilError(t, db.Put(ctx, "value", []byte("secret")))
+
+	// A node that never had the key values are sealed with can't rotate it.
+	other := New(1<<20, "test", store, Scope("project", "application", "test")).(*kv)
+	assert.ErrorContains(t, other.UpdateKey("two"), "unknown key")
+	assert.Assert(t, other.keys.getCurrent() == nil)
+	assert.Assert(t, sealedBy(t, store, "value", "one"))
+
+	// It can once the key of the previous deployment is looked up.
+	var looked int
+	other = New(1<<20, "test", store, Scope("project", "application", "test"), Previous(func(context.Context) (string, error) {
+		looked++
+		return "one", nil
+	})).(*kv)
+	updateKey(t, other, "two")
+	assert.Equal(t, looked, 1)
+	assert.Assert(t, sealedBy(t, store, "value", "two"))
+
+	value, err := other.Get(ctx, "value")
+	assert.NilError(t, err)
+	assert.Equal(t, string(value), "secret")
+
+	// A wrong previous key is refused too.
+	other = New(1<<20, "test", store, Scope("project", "application", "test"), Previous(func(context.Context) (string, error) {
+		return "one", nil
+	})).(*kv)
+	assert.ErrorContains(t, other.UpdateKey("three"), "unknown key")
+}
@@ -0,0 +1,198 @@
+package kv
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+)
+
+// Sealed values start with sealMagic, then the id of their key and a nonce.
+// Values without it were stored before the database had a key.
+var sealMagic = []byte{0, 't', 'a', 'u', 'e', 1}
+
+const keyIdSize = 8
+
+// keyInfo binds the keys derived to their use.
+const keyInfo = "tau/substrate/database/v1"
+
+type keyId [keyIdSize]byte
+
+func (id keyId) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+type key struct {
+	id     keyId
+	secret string
+	aead   cipher.AEAD
+}
+
+// deriveKey derives the AES-256-GCM key of a database from its configured key,
+// salted with the scope of the database so each gets its own.
+func deriveKey(secret, scope string) (*key, error) {
+	raw, err := hkdf.Key(sha256.New, []byte(secret), []byte(scope), keyInfo, 32)
+	if err != nil {
+		return nil, fmt.Errorf("deriving key failed with: %w", err)
+	}
+
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &key{secret: secret, aead: aead}
+	sum := sha256.Sum256(raw)
+	copy(k.id[:], sum[:])
+
+	return k, nil
+}
+
+// keyring holds the key values are sealed with, and the ones they may still be
+// sealed with while it's being rotated.
+type keyring struct {
+	// scope is the database values are sealed for, see Scope.
+	scope string
+
+	lock sync.RWMutex
+	// current is nil when values are stored in the clear.
+	current *key
+	keys    map[keyId]*key
+}
+
+func newKeyring() *keyring {
+	return &keyring{keys: make(map[keyId]*key)}
+}
+
+// aad binds a sealed value to its database and name, so values can't be
+// swapped within a database or moved to another one.
+func (r *keyring) aad(name string) []byte {
+	if r.scope == "" {
+		return []byte(path.Clean("/" + name))
+	}
+
+	return []byte(r.scope + "\x00" + path.Clean("/"+name))
+}
+
+func (r *keyring) derive(secret string) (*key, error) {
+	return deriveKey(secret, r.scope)
+}
+
+func (r *keyring) add(k *key) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.keys[k.id] = k
+}
+
+func (r *keyring) get(id keyId) (*key, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	k, ok := r.keys[id]
+	return k, ok
+}
+
+func (r *keyring) setCurrent(k *key) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if k != nil {
+		r.keys[k.id] = k
+	}
+	r.current = k
+}
+
+func (r *keyring) getCurrent() *key {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.current
+}
+
+// seal encrypts a value with the current key, if any.
+func (r *keyring) seal(name string, value []byte) ([]byte, error) {
+	k := r.getCurrent()
+	if k == nil {
+		return value, nil
+	}
+
+	return k.seal(r.aad(name), value)
+}
+
+func (k *key) seal(aad, value []byte) ([]byte, error) {
+	header := len(sealMagic) + keyIdSize
+	sealed := make([]byte, header+k.aead.NonceSize(), header+k.aead.NonceSize()+len(value)+k.aead.Overhead())
+	copy(sealed, sealMagic)
+	copy(sealed[len(sealMagic):], k.id[:])
+	if _, err := rand.Read(sealed[header:]); err != nil {
+		return nil, err
+	}
+
+	return k.aead.Seal(sealed, sealed[header:], value, aad), nil
+}
+
+// sealedWith returns the id of the key a value is sealed with.
+func sealedWith(value []byte) (id keyId, sealed bool) {
+	if len(value) < len(sealMagic)+keyIdSize || !bytes.HasPrefix(value, sealMagic) {
+		return id, false
+	}
+
+	copy(id[:], value[len(sealMagic):])
+	return id, true
+}
+
+var errUnknownKey = errors.New("sealed with an unknown key")
+
+// open decrypts a value, passing values stored in the clear through.
+func (r *keyring) open(name string, value []byte) ([]byte, error) {
+	id, sealed := sealedWith(value)
+	if !sealed {
+		return value, nil
+	}
+
+	k, ok := r.get(id)
+	if !ok {
+		return nil, fmt.Errorf("value %s %w `%s`", name, errUnknownKey, id)
+	}
+
+	return k.open(r.aad(name), value)
+}
+
+func (k *key) open(aad, value []byte) ([]byte, error) {
+	header := len(sealMagic) + keyIdSize
+	if len(value) < header+k.aead.NonceSize() {
+		return nil, errors.New("sealed value is truncated")
+	}
+
+	nonce := value[header : header+k.aead.NonceSize()]
+	plain, err := k.aead.Open(nil, nonce, value[header+k.aead.NonceSize():], aad)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting value failed with: %w", err)
+	}
+
+	return plain, nil
+}
+
+// isCurrent tells whether a value is stored the way new values are.
+func (r *keyring) isCurrent(value []byte) bool {
+	id, sealed := sealedWith(value)
+	k := r.getCurrent()
+	if k == nil {
+		return !sealed
+	}
+
+	return sealed && id == k.id
+}
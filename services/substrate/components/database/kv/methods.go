@@ -6,25 +6,47 @@ import (
 	"path"
 	"strconv"
 	"strings"
+
+	"github.com/taubyte/tau/core/services/substrate/events"
 )
 
 func (kv *kv) Get(ctx context.Context, key string) (data []byte, err error) {
+	if reserved(key) {
+		return nil, fmt.Errorf("key %s is reserved", key)
+	}
+
 	data, err = kv.database.Get(ctx, key)
 	if err != nil {
 		return nil, fmt.Errorf("failed getting key %s using database %s with error: %v", key, kv.name, err)
 	}
 
+	if data, err = kv.open(ctx, key, data); err != nil {
+		return nil, fmt.Errorf("failed reading key %s using database %s with error: %w", key, kv.name, err)
+	}
+
 	return
 }
 
 func (kv *kv) Put(ctx context.Context, key string, v []byte) error {
-	err := kv.checkValidSize(ctx, v)
+	if reserved(key) {
+		return fmt.Errorf("key %s is reserved", key)
+	}
+
+	err := kv.checkValidSize(ctx, len(v))
 	if err != nil {
 		return err
 	}
 
+	sealed, err := kv.keys.seal(key, v)
+	if err != nil {
+		return fmt.Errorf("failed sealing %s with error: %w", key, err)
+	}
+
+	kv.writeLock.RLock()
+	defer kv.writeLock.RUnlock()
+
 	// Register actual data into the database
-	err = kv.database.Put(ctx, key, v)
+	err = kv.database.Put(ctx, key, sealed)
 	if err != nil {
 		return fmt.Errorf("failed putting %s in database %s with error: %v", key, kv.name, err)
 	}
@@ -36,10 +58,19 @@ func (kv *kv) Put(ctx context.Context, key string, v []byte) error {
 		return fmt.Errorf("failed putting size for key %s in database with error: %v", key, err)
 	}
 
+	kv.changed(ctx, events.Put, key, len(v))
+
 	return nil
 }
 
 func (kv *kv) Delete(ctx context.Context, key string) error {
+	if reserved(key) {
+		return fmt.Errorf("key %s is reserved", key)
+	}
+
+	kv.writeLock.RLock()
+	defer kv.writeLock.RUnlock()
+
 	// Delete key
 	err := kv.database.Delete(ctx, key)
 	if err != nil {
@@ -52,10 +83,13 @@ func (kv *kv) Delete(ctx context.Context, key string) error {
 		return fmt.Errorf("failed deleting key %s with error: %v", key, err)
 	}
 
+	kv.changed(ctx, events.Delete, key, 0)
+
 	return nil
 }
 
 func (kv *kv) Close() {
+	kv.ctxC()
 	kv.database.Close()
 }
 
@@ -69,7 +103,7 @@ func (kv *kv) List(ctx context.Context, prefix string) ([]string, error) {
 		newList := make([]string, 0)
 
 		for _, entry := range entries {
-			if !strings.HasPrefix(entry, "/size") {
+			if !strings.HasPrefix(entry, "/size") && !strings.HasPrefix(entry, "/"+reservedPrefix) {
 				newList = append(newList, entry)
 			}
 		}
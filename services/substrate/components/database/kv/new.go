@@ -1,12 +1,50 @@
 package kv
 
 import (
+	"context"
+
 	"github.com/taubyte/tau/core/kvdb"
 	iface "github.com/taubyte/tau/core/services/substrate/components/database"
+	"github.com/taubyte/tau/core/services/substrate/events"
 )
 
+type Option func(*kv)
+
+// Notify has fn called after each key the database writes or deletes, with the
+// context of the write and the size of the value put.
+func Notify(fn func(ctx context.Context, kind events.Kind, key string, size int)) Option {
+	return func(kv *kv) {
+		kv.notify = fn
+	}
+}
+
+// Scope binds the values of the database to the project, application and
+// database they belong to: its key is derived for them and each value is
+// authenticated with them.
+func Scope(projectId, applicationId, database string) Option {
+	return func(kv *kv) {
+		kv.keys.scope = projectId + "/" + applicationId + "/" + database
+	}
+}
+
+// Previous has fn look up the key the database was configured with at its
+// previous deployment, for values sealed with a key this node never had.
+func Previous(fn func(ctx context.Context) (string, error)) Option {
+	return func(kv *kv) {
+		kv.previous = fn
+	}
+}
+
 // New wraps a kvdb.KVDB (now hoarder-backed) with the substrate size-tracking
-// layer. The handle is a remote stream client, not a local datastore.
-func New(size uint64, name string, store kvdb.KVDB) iface.KV {
-	return &kv{name: name, database: store, maxSize: size}
+// layer. The handle is a remote stream client, not a local datastore. Values
+// are stored in the clear until a key is set with UpdateKey.
+func New(size uint64, name string, store kvdb.KVDB, options ...Option) iface.KV {
+	kv := &kv{name: name, database: store, maxSize: size, keys: newKeyring()}
+	kv.ctx, kv.ctxC = context.WithCancel(context.Background())
+
+	for _, opt := range options {
+		opt(kv)
+	}
+
+	return kv
 }
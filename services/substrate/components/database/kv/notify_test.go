@@ -0,0 +1,44 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/taubyte/tau/core/services/substrate/events"
+	"github.com/taubyte/tau/pkg/kvdb/mock"
+	"gotest.tools/v3/assert"
+)
+
+func TestNotify(t *testing.T) {
+	ctx := context.Background()
+	store, err := mock.New().New(nil, "test", 0)
+	assert.NilError(t, err)
+
+	var changes []string
+	db := New(1<<20, "test", store, Notify(func(ctx context.Context, kind events.Kind, key string, size int) {
+		changes = append(changes, fmt.Sprintf("%s %s %d", kind, key, size))
+	}))
+
+	assert.NilError(t, db.Put(ctx, "a", []byte("abc")))
+	assert.NilError(t, db.Delete(ctx, "a"))
+
+	_, err = db.PutNx(ctx, "b", []byte("x"))
+	assert.NilError(t, err)
+
+	// A write that doesn't happen isn't reported.
+	_, err = db.PutNx(ctx, "b", []byte("y"))
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, changes, []string{"put a 3", "delete a 0", "put b 1"})
+
+	changes = nil
+	batch, err := db.Batch(ctx)
+	assert.NilError(t, err)
+	assert.NilError(t, batch.Put("c", []byte("cc")))
+	assert.NilError(t, batch.Delete("b"))
+	assert.NilError(t, batch.Commit())
+
+	assert.Assert(t, len(changes) == 2)
+	assert.Assert(t, (changes[0] == "put c 2" && changes[1] == "delete b 0") || (changes[1] == "put c 2" && changes[0] == "delete b 0"))
+}
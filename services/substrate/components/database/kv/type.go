@@ -1,9 +1,39 @@
 package kv
 
-import "github.com/taubyte/tau/core/kvdb"
+import (
+	"context"
+	"sync"
+
+	"github.com/taubyte/tau/core/kvdb"
+	"github.com/taubyte/tau/core/services/substrate/events"
+)
 
 type kv struct {
+	ctx      context.Context
+	ctxC     context.CancelFunc
 	name     string
 	database kvdb.KVDB
 	maxSize  uint64
+
+	keys *keyring
+
+	// writeLock makes conditional writes atomic against the other writes of
+	// this node when the store can't.
+	writeLock sync.RWMutex
+
+	keyLock    sync.Mutex
+	secret     string
+	resealC    context.CancelFunc
+	resealDone chan struct{}
+	// previous looks up the key of the database at its previous deployment.
+	previous func(ctx context.Context) (string, error)
+
+	notify func(ctx context.Context, kind events.Kind, key string, size int)
+}
+
+// changed reports a write of the database to the Notify callback, if any.
+func (kv *kv) changed(ctx context.Context, kind events.Kind, key string, size int) {
+	if kv.notify != nil {
+		kv.notify(ctx, kind, key, size)
+	}
 }
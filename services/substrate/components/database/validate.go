@@ -35,6 +35,9 @@ func (s *Service) updateDatabase(database iface.Database, branches []string) (if
 	}
 
 	database.KV().UpdateSize(newConfig.Size)
+	if err = database.KV().UpdateKey(newConfig.Key); err != nil {
+		return nil, err
+	}
 
 	return database, nil
 }
@@ -0,0 +1,27 @@
+package common
+
+import (
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+)
+
+var Logger = log.Logger("tau.substrate.service.events")
+
+// Topic is the topic of the pubsub event a triggered function receives.
+const Topic = "events"
+
+var (
+	// MaxRuns caps the functions a node runs at once for changes.
+	MaxRuns = 64
+
+	// QueueSize caps the changes waiting for dispatch; more are dropped.
+	QueueSize = 1024
+
+	// Dispatchers is how many changes are dispatched at once.
+	Dispatchers = 4
+
+	// FunctionsRefresh is how long the triggered functions of an application
+	// are kept before they are listed again.
+	FunctionsRefresh = 10 * time.Second
+)
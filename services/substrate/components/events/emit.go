@@ -0,0 +1,147 @@
+package events
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	iface "github.com/taubyte/tau/core/services/substrate/components/events"
+	"github.com/taubyte/tau/core/services/substrate/events"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/events/common"
+	counter "github.com/taubyte/tau/services/substrate/runtime/counter"
+)
+
+// triggered tells whether a function runs on storage or database changes.
+func triggered(config *structureSpec.Function) bool {
+	return config.Type == string(events.Storage) || config.Type == string(events.Database)
+}
+
+// Matches tells whether a change triggers the function: same resource, a key
+// under its prefix and, when it lists some, one of its kinds.
+func Matches(config *structureSpec.Function, change *events.Event) bool {
+	if config.Type != string(change.Resource) || config.Resource != change.Name {
+		return false
+	}
+
+	if !strings.HasPrefix(change.Key, config.Prefix) {
+		return false
+	}
+
+	return len(config.Events) == 0 || slices.Contains(config.Events, string(change.Kind))
+}
+
+// Emit queues a change for the functions of the application it triggers.
+// Changes made by functions run for a change are skipped, so functions can't
+// trigger each other in a loop, and changes are dropped while the queue is
+// full.
+func (s *Service) Emit(ctx context.Context, projectId, applicationId string, change *events.Event) {
+	if events.FromChange(ctx) {
+		common.Logger.Debugf("skipping %s of `%s` in %s `%s` made by a triggered function", change.Kind, change.Key, change.Resource, change.Name)
+		return
+	}
+
+	select {
+	case s.queue <- &emitted{projectId: projectId, applicationId: applicationId, change: change}:
+	default:
+		common.Logger.Errorf("dropping %s of `%s` in %s `%s`: %d changes are waiting", change.Kind, change.Key, change.Resource, change.Name, cap(s.queue))
+	}
+}
+
+// dispatchQueue dispatches the queued changes until the service closes.
+func (s *Service) dispatchQueue() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case e := <-s.queue:
+			s.dispatch(e.projectId, e.applicationId, e.change)
+		}
+	}
+}
+
+// dispatch starts the functions the change triggers, waiting for free run
+// slots so the queue backs up when the node is busy.
+func (s *Service) dispatch(projectId, applicationId string, change *events.Event) {
+	functions, err := s.triggeredFunctions(projectId, applicationId)
+	if err != nil {
+		common.Logger.Errorf("listing functions of project `%s` failed with: %s", projectId, err)
+		return
+	}
+
+	for id, config := range functions {
+		if !Matches(config, change) {
+			continue
+		}
+
+		select {
+		case s.runs <- struct{}{}:
+		case <-s.ctx.Done():
+			return
+		}
+
+		go func() {
+			defer func() { <-s.runs }()
+
+			s.run(&iface.MatchDefinition{
+				Project:     projectId,
+				Application: applicationId,
+				Function:    id,
+			}, change)
+		}()
+	}
+}
+
+// triggeredFunctions returns the functions of an application run on changes,
+// listing them at most every FunctionsRefresh.
+func (s *Service) triggeredFunctions(projectId, applicationId string) (map[string]*structureSpec.Function, error) {
+	key := projectId + "/" + applicationId
+	now := time.Now()
+
+	s.functionsLock.Lock()
+	if cached, ok := s.functions[key]; ok && now.Sub(cached.fetched) < common.FunctionsRefresh {
+		s.functionsLock.Unlock()
+		return cached.configs, nil
+	}
+	s.functionsLock.Unlock()
+
+	functions, _, _, err := s.Tns().Function().All(projectId, applicationId, spec.DefaultBranches...).List()
+	if err != nil {
+		return nil, err
+	}
+
+	configs := make(map[string]*structureSpec.Function)
+	for id, config := range functions {
+		if triggered(config) {
+			configs[id] = config
+		}
+	}
+
+	s.functionsLock.Lock()
+	defer s.functionsLock.Unlock()
+
+	for k, cached := range s.functions {
+		if now.Sub(cached.fetched) >= common.FunctionsRefresh {
+			delete(s.functions, k)
+		}
+	}
+	s.functions[key] = &triggeredFunctions{configs: configs, fetched: now}
+
+	return configs, nil
+}
+
+func (s *Service) run(matcher *iface.MatchDefinition, change *events.Event) {
+	start := time.Now()
+	pick, err := s.Lookup(matcher)
+	if err != nil {
+		common.Logger.Errorf("looking up function `%s` for %s `%s` failed with: %s", matcher.Function, change.Resource, change.Name, err)
+		return
+	}
+
+	coldStartDone, err := pick.Handle(change)
+	if err = counter.ErrorWrapper(pick, start, coldStartDone, err); err != nil {
+		common.Logger.Errorf("handling %s of `%s` in %s `%s` by `%s` failed with: %s", change.Kind, change.Key, change.Resource, change.Name, pick.Name(), err)
+	}
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/taubyte/tau/core/services/substrate/events"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"gotest.tools/v3/assert"
+)
+
+func TestMatches(t *testing.T) {
+	config := &structureSpec.Function{
+		Type:     "storage",
+		Resource: "images",
+		Prefix:   "uploads/",
+		Events:   []string{"put", "new-version"},
+	}
+
+	change := &events.Event{Resource: events.Storage, Name: "images", Kind: events.Put, Key: "uploads/cat.png"}
+	assert.Assert(t, Matches(config, change))
+
+	other := *change
+	other.Kind = events.Delete
+	assert.Assert(t, !Matches(config, &other))
+
+	other = *change
+	other.Key = "thumbs/cat.png"
+	assert.Assert(t, !Matches(config, &other))
+
+	other = *change
+	other.Name = "videos"
+	assert.Assert(t, !Matches(config, &other))
+
+	other = *change
+	other.Resource = events.Database
+	assert.Assert(t, !Matches(config, &other))
+
+	// No prefix nor kinds matches every change of the resource.
+	config.Prefix, config.Events = "", nil
+	other = *change
+	other.Kind = events.Delete
+	other.Key = "thumbs/cat.png"
+	assert.Assert(t, Matches(config, &other))
+}
+
+func TestEmit(t *testing.T) {
+	s := &Service{queue: make(chan *emitted, 1)}
+	change := &events.Event{Resource: events.Storage, Name: "images", Kind: events.Put, Key: "uploads/cat.png"}
+
+	// Changes made by functions run for a change trigger nothing.
+	s.Emit(events.WithChange(context.Background()), "project", "", change)
+	assert.Equal(t, len(s.queue), 0)
+
+	s.Emit(context.Background(), "project", "", change)
+	assert.Equal(t, len(s.queue), 1)
+
+	// A full queue drops changes instead of blocking writes.
+	s.Emit(context.Background(), "project", "", change)
+	assert.Equal(t, len(s.queue), 1)
+
+	queued := <-s.queue
+	assert.Equal(t, queued.projectId, "project")
+	assert.Equal(t, queued.change, change)
+}
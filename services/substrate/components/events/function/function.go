@@ -0,0 +1,32 @@
+package function
+
+import (
+	"fmt"
+	"time"
+
+	iface "github.com/taubyte/tau/core/services/substrate/components/events"
+	"github.com/taubyte/tau/core/services/substrate/events"
+	plugins "github.com/taubyte/tau/pkg/vm-low-orbit"
+	"github.com/taubyte/tau/services/substrate/components/events/common"
+	"github.com/taubyte/tau/services/substrate/components/triggered"
+)
+
+var _ iface.Serviceable = &Function{}
+
+type Function struct {
+	*triggered.Function
+}
+
+// Handle runs the function for a change. The guest gets a change event: a
+// pubsub event whose data is the JSON encoded change, with the change's
+// fields also read through the getChangeEvent host functions.
+func (f *Function) Handle(change *events.Event) (time.Time, error) {
+	msg, err := triggered.NewMessage(f.Id(), common.Topic, change)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("encoding change failed with: %w", err)
+	}
+
+	return f.Trigger(func(sdk plugins.Instance) uint32 {
+		return sdk.CreateChangeEvent(msg, change).Id
+	})
+}
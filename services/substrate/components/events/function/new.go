@@ -0,0 +1,35 @@
+package function
+
+import (
+	"fmt"
+
+	commonIface "github.com/taubyte/tau/core/services/substrate/components"
+	iface "github.com/taubyte/tau/core/services/substrate/components/events"
+	"github.com/taubyte/tau/core/services/substrate/events"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/triggered"
+)
+
+// New creates a function triggered by changes. Its runs are marked, so the
+// changes they make trigger no function.
+func New(srv iface.Service, config structureSpec.Function, commit, branch string, matcher *iface.MatchDefinition) (commonIface.Serviceable, error) {
+	function, err := triggered.New(events.WithChange(srv.Context()), srv, triggered.Definition{
+		Config:      config,
+		Commit:      commit,
+		Branch:      branch,
+		Project:     matcher.Project,
+		Application: matcher.Application,
+		Matcher:     matcher,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Function{Function: function}
+	if _, err = srv.Cache().Add(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("adding triggered function serviceable failed with: %s", err)
+	}
+
+	return f, nil
+}
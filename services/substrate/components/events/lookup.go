@@ -0,0 +1,54 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+
+	commonIface "github.com/taubyte/tau/core/services/substrate/components"
+	iface "github.com/taubyte/tau/core/services/substrate/components/events"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	"github.com/taubyte/tau/services/substrate/components/events/function"
+	"github.com/taubyte/tau/services/substrate/runtime/lookup"
+)
+
+func (s *Service) Lookup(matcher *iface.MatchDefinition) (iface.Serviceable, error) {
+	serviceables, err := lookup.Lookup(s, matcher)
+	if err != nil {
+		return nil, fmt.Errorf("events lookup failed with: %s", err)
+	}
+
+	if len(serviceables) == 0 {
+		return nil, errors.New("events lookup returned no picks")
+	}
+
+	pick, ok := serviceables[0].(iface.Serviceable)
+	if !ok {
+		return nil, errors.New("converting serviceable to events serviceable failed")
+	}
+
+	return pick, nil
+}
+
+func (s *Service) CheckTns(matcherIface commonIface.MatchDefinition) ([]commonIface.Serviceable, error) {
+	matcher, ok := matcherIface.(*iface.MatchDefinition)
+	if !ok {
+		return nil, fmt.Errorf("matcher not correct type expected (%T) got (%T)", new(iface.MatchDefinition), matcherIface)
+	}
+
+	functions, commit, branch, err := s.Tns().Function().All(matcher.Project, matcher.Application, spec.DefaultBranches...).List()
+	if err != nil {
+		return nil, err
+	}
+
+	config, ok := functions[matcher.Function]
+	if !ok || !triggered(config) {
+		return nil, fmt.Errorf("no storage or database triggered function `%s` found in project `%s`", matcher.Function, matcher.Project)
+	}
+
+	serv, err := function.New(s, *config, commit, branch, matcher)
+	if err != nil {
+		return nil, fmt.Errorf("creating triggered function `%s` failed with: %w", matcher.Function, err)
+	}
+
+	return []commonIface.Serviceable{serv}, nil
+}
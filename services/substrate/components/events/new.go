@@ -0,0 +1,26 @@
+package events
+
+import (
+	"context"
+
+	"github.com/taubyte/tau/core/services/substrate"
+	"github.com/taubyte/tau/services/substrate/components/events/common"
+	"github.com/taubyte/tau/services/substrate/runtime/cache"
+)
+
+func New(srv substrate.Service) (*Service, error) {
+	s := &Service{
+		Service:   srv,
+		cache:     cache.New(),
+		queue:     make(chan *emitted, common.QueueSize),
+		runs:      make(chan struct{}, common.MaxRuns),
+		functions: make(map[string]*triggeredFunctions),
+	}
+	s.ctx, s.ctxC = context.WithCancel(srv.Context())
+
+	for range common.Dispatchers {
+		go s.dispatchQueue()
+	}
+
+	return s, nil
+}
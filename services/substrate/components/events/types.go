@@ -0,0 +1,43 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	nodeIface "github.com/taubyte/tau/core/services/substrate"
+	iface "github.com/taubyte/tau/core/services/substrate/components/events"
+	"github.com/taubyte/tau/core/services/substrate/events"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/runtime/cache"
+)
+
+var _ iface.Service = &Service{}
+
+type Service struct {
+	nodeIface.Service
+	cache *cache.Cache
+
+	ctx  context.Context
+	ctxC context.CancelFunc
+
+	// queue holds the changes waiting for dispatch.
+	queue chan *emitted
+	// runs holds a slot per function running for a change.
+	runs chan struct{}
+
+	functionsLock sync.Mutex
+	functions     map[string]*triggeredFunctions
+}
+
+type emitted struct {
+	projectId     string
+	applicationId string
+	change        *events.Event
+}
+
+// triggeredFunctions are the functions of an application run on changes.
+type triggeredFunctions struct {
+	configs map[string]*structureSpec.Function
+	fetched time.Time
+}
@@ -1,21 +1,33 @@
 package http
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	goHttp "net/http"
 
+	commonIface "github.com/taubyte/tau/core/services/substrate/components"
 	iface "github.com/taubyte/tau/core/services/substrate/components/http"
+	"github.com/taubyte/tau/core/services/substrate/smartops"
 	http "github.com/taubyte/tau/pkg/http"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	"github.com/taubyte/tau/pkg/tracing"
 	"github.com/taubyte/tau/services/substrate/components/http/common"
+	"github.com/taubyte/tau/services/substrate/components/http/limits"
 	"github.com/taubyte/tau/services/substrate/runtime/counter"
 	"github.com/taubyte/tau/services/substrate/runtime/helpers"
-	"github.com/taubyte/tau/services/substrate/runtime/lookup"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func (s *Service) Lookup(matcher *common.MatchDefinition) (iface.Serviceable, error) {
-	servs, err := lookup.Lookup(s, matcher)
+// Lookup returns the serviceable matcher matches, looking it up in TNS as part
+// of the trace of ctx, if any, when it isn't cached.
+func (s *Service) Lookup(ctx context.Context, matcher *common.MatchDefinition) (iface.Serviceable, error) {
+	servs, err := s.lookup(ctx, matcher)
 	if err != nil {
 		return nil, fmt.Errorf("http serviceable lookup failed with: %w", err)
 	}
@@ -25,7 +37,7 @@ func (s *Service) Lookup(matcher *common.MatchDefinition) (iface.Serviceable, er
 			s.Cache().Remove(srv)
 		}
 
-		servs, err = lookup.Lookup(s, matcher)
+		servs, err = s.lookup(ctx, matcher)
 		if err != nil {
 			return nil, fmt.Errorf("http serviceable lookup failed with: %w", err)
 		} else if len(servs) != 1 {
@@ -41,17 +53,70 @@ func (s *Service) Lookup(matcher *common.MatchDefinition) (iface.Serviceable, er
 	return pick, nil
 }
 
-func (s *Service) handle(w goHttp.ResponseWriter, r *goHttp.Request) error {
+// lookup is lookup.Lookup, with TNS asked as part of the trace of ctx.
+func (s *Service) lookup(ctx context.Context, matcher *common.MatchDefinition) ([]commonIface.Serviceable, error) {
+	if picks, err := s.Cache().Get(matcher, commonIface.GetOptions{Validation: true}); err == nil {
+		return picks, nil
+	}
+
+	return s.checkTns(ctx, matcher)
+}
+
+func (s *Service) handle(w goHttp.ResponseWriter, r *goHttp.Request) (err error) {
+	// Serves the request as part of the trace the gateway tunneled it in, if any.
+	ctx, span := tracing.Start(tracing.ExtractHeader(r.Context(), r.Header), "substrate.http",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("http.host", r.Host),
+			attribute.String("http.method", r.Method),
+			attribute.String("http.path", r.URL.Path),
+		),
+	)
+	defer func() { tracing.End(span, err) }()
+	r = r.WithContext(ctx)
+
 	startTime := time.Now()
 	matcher := common.New(helpers.ExtractHost(r.Host), r.URL.Path, r.Method)
 
-	pick, err := s.Lookup(matcher)
+	// Resources not cached are looked up in TNS.
+	lookupCtx, lookupSpan := tracing.Start(ctx, "substrate.lookup")
+	pick, err := s.Lookup(lookupCtx, matcher)
+	tracing.End(lookupSpan, err)
 	if err != nil {
 		return fmt.Errorf("looking up serviceable failed with: %w", err)
 	}
 
+	key := spec.TrafficKey(r)
+	if key == "" {
+		key = spec.NewTrafficKey()
+	}
+
+	pick, split, err := s.Split(ctx, matcher, pick, key)
+	if err != nil {
+		return err
+	}
+
+	span.SetAttributes(
+		attribute.String("substrate.project", pick.Project()),
+		attribute.String("substrate.resource", pick.Id()),
+		attribute.String("substrate.commit", pick.Commit()),
+	)
+
+	// Sticks the client to the commit it was assigned.
+	if _, err := r.Cookie(spec.TrafficCookie); split && err != nil {
+		goHttp.SetCookie(w, spec.TrafficKeyCookie(key))
+	}
+
+	release, err := s.limit(r, matcher, pick)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	if !pick.IsProvisioned() {
+		_, provisionSpan := tracing.Start(ctx, "substrate.provision")
 		pick, err = pick.Provision()
+		tracing.End(provisionSpan, err)
 		if err != nil {
 			return fmt.Errorf("provisioning serviceable failed with: %w", err)
 		}
@@ -67,11 +132,30 @@ func (s *Service) handle(w goHttp.ResponseWriter, r *goHttp.Request) error {
 
 func (s *Service) Handler(w goHttp.ResponseWriter, r *goHttp.Request) {
 	if err := s.handle(w, r); err != nil {
-		w.WriteHeader(500)
-		w.Write([]byte(err.Error()))
+		writeError(w, err)
 	}
 }
 
+func writeError(w goHttp.ResponseWriter, err error) {
+	var blocked *smartops.BlockedError
+	if errors.As(err, &blocked) {
+		w.WriteHeader(goHttp.StatusForbidden)
+		w.Write([]byte(blocked.Error()))
+		return
+	}
+
+	var rejected *limits.RejectedError
+	if errors.As(err, &rejected) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rejected.RetryAfter.Seconds()))))
+		w.WriteHeader(goHttp.StatusTooManyRequests)
+		w.Write([]byte(rejected.Error()))
+		return
+	}
+
+	w.WriteHeader(500)
+	w.Write([]byte(err.Error()))
+}
+
 func (s *Service) attach() error {
 	s.Http().LowLevel(&http.LowLevelDefinition{
 		PathPrefix: "/",
@@ -4,7 +4,7 @@ import (
 	commonIface "github.com/taubyte/tau/core/services/substrate/components"
 )
 
-var _ commonIface.MatchDefinition = &MatchDefinition{}
+var _ commonIface.PinnedMatchDefinition = &MatchDefinition{}
 
 func New(host, path, method string) *MatchDefinition {
 	return &MatchDefinition{
@@ -27,16 +27,49 @@ type Request struct {
 type MatchDefinition struct {
 	*Request
 	params map[string]string
+
+	// branch and commit pin the definition, see Pin.
+	branch string
+	commit string
 }
 
 func (m *MatchDefinition) String() string {
 	return m.Host + m.Path + m.Method
 }
 
+// CachePrefix keeps the serviceables of a pinned definition apart from those
+// of the current commit.
 func (m *MatchDefinition) CachePrefix() string {
+	if m.commit != "" {
+		return m.Host + "@" + m.commit
+	}
+
 	return m.Host
 }
 
+// Pin returns a copy of the definition bound to commit of branch, so it is
+// served from that commit rather than the current one. The definition itself
+// is left as is: serviceables of the current commit keep it as their matcher.
+func (m *MatchDefinition) Pin(branch, commit string) *MatchDefinition {
+	request := *m.Request
+	pinned := &MatchDefinition{
+		Request: &request,
+		params:  make(map[string]string, len(m.params)),
+		branch:  branch,
+		commit:  commit,
+	}
+
+	for key, value := range m.params {
+		pinned.params[key] = value
+	}
+
+	return pinned
+}
+
+func (m *MatchDefinition) Pinned() (branch, commit string) {
+	return m.branch, m.commit
+}
+
 func (m *MatchDefinition) Set(key, value string) {
 	m.params[key] = value
 }
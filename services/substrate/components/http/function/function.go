@@ -13,6 +13,7 @@ import (
 	"github.com/taubyte/tau/core/services/substrate/components"
 	httpComp "github.com/taubyte/tau/core/services/substrate/components/http"
 	matcherSpec "github.com/taubyte/tau/pkg/specs/matcher"
+	"github.com/taubyte/tau/pkg/tracing"
 	"github.com/taubyte/tau/services/substrate/components/http/common"
 	"github.com/taubyte/tau/services/substrate/components/metrics"
 	"github.com/taubyte/tau/services/substrate/runtime"
@@ -50,7 +51,13 @@ func (f *Function) Provision() (function httpComp.Serviceable, err error) {
 }
 
 func (f *Function) Handle(w goHttp.ResponseWriter, r *goHttp.Request, matcher components.MatchDefinition) (t time.Time, err error) {
+	if err = f.guard(); err != nil {
+		return t, err
+	}
+
+	_, span := tracing.Start(r.Context(), "substrate.instantiate")
 	instance, err := f.Instantiate(f.instanceCtx)
+	tracing.End(span, err)
 	if err != nil {
 		return t, fmt.Errorf("instantiate failed with: %w", err)
 	}
@@ -58,13 +65,24 @@ func (f *Function) Handle(w goHttp.ResponseWriter, r *goHttp.Request, matcher co
 
 	ev := instance.SDK().CreateHttpEvent(w, r)
 
-	return time.Now(), f.Call(instance, ev.Id)
+	t = time.Now()
+	ctx, span := tracing.Start(r.Context(), "substrate.call")
+	err = f.CallContext(ctx, instance, ev.Id)
+	tracing.End(span, err)
+
+	return t, err
 }
 
 var logger = log.Logger("tau.substrate.components.http.function")
 
+// Rejected counts a request turned away by a rate limit or concurrency cap.
+func (f *Function) Rejected() {
+	f.rejected.Add(1)
+}
+
 func (f *Function) Metrics() *metrics.Function {
 	m := f.metrics
+	m.Rejected = f.rejected.Load()
 
 	maxMemory := f.config.Memory
 	if f.provisioned {
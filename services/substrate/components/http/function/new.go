@@ -1,6 +1,7 @@
 package function
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/ipfs/go-cid"
@@ -12,7 +13,7 @@ import (
 	"github.com/taubyte/tau/services/substrate/runtime/cache"
 )
 
-func New(srv components.ServiceComponent, object tns.Object, matcher *common.MatchDefinition) (http.Serviceable, error) {
+func New(ctx context.Context, srv components.ServiceComponent, object tns.Object, matcher *common.MatchDefinition) (http.Serviceable, error) {
 	parser, err := extract.Tns().BasicPath(object.Path().String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse tns path `%s` with: %s", object.Path().String(), err)
@@ -35,7 +36,7 @@ func New(srv components.ServiceComponent, object tns.Object, matcher *common.Mat
 	f.config.Id = id
 
 	if f.config.Source == "." {
-		f.assetId, err = cache.ResolveAssetCid(f)
+		f.assetId, err = cache.ResolveAssetCid(ctx, f)
 		if err != nil {
 			return nil, fmt.Errorf("getting asset id failed with: %w", err)
 		}
@@ -2,6 +2,7 @@ package function
 
 import (
 	"context"
+	"fmt"
 
 	sdkSmartOpsCommon "github.com/taubyte/go-sdk-smartops/common"
 	"github.com/taubyte/tau/core/services/substrate/smartops"
@@ -22,3 +23,22 @@ func (f *Function) Context() context.Context {
 func (f *Function) SmartOps() (uint32, error) {
 	return f.srv.SmartOps().Run(f, f.config.SmartOps)
 }
+
+// guard runs the function's smartops and refuses the request when one of them
+// exits with a non-zero code.
+func (f *Function) guard() error {
+	if len(f.config.SmartOps) == 0 {
+		return nil
+	}
+
+	val, err := f.SmartOps()
+	if err != nil {
+		return fmt.Errorf("running smartops failed with: %w", err)
+	}
+
+	if val > 0 {
+		return &smartops.BlockedError{Code: val}
+	}
+
+	return nil
+}
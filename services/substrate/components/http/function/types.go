@@ -3,6 +3,7 @@ package function
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 
 	"github.com/taubyte/tau/core/services/substrate/components"
 	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
@@ -35,7 +36,8 @@ type Function struct {
 
 	closeOnce sync.Once
 
-	metrics metrics.Function
+	metrics  metrics.Function
+	rejected atomic.Uint64
 
 	*runtime.Function
 }
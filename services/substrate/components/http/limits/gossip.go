@@ -0,0 +1,117 @@
+package limits
+
+import (
+	"math"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+// startGossip subscribes to the reports of the other substrate nodes and
+// publishes ours on a ticker. Reports are signed by the node sending them, so
+// the ones from nodes not running substrate are dropped.
+func (l *Limiter) startGossip() error {
+	err := l.node.PubSubSubscribe(
+		Topic,
+		l.receive,
+		func(err error) {
+			if l.ctx.Err() == nil {
+				logger.Error("limits subscription ended with:", err.Error())
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	go l.gossipLoop()
+	return nil
+}
+
+// receive observes the report in msg, if a substrate node other than this one
+// sent it.
+func (l *Limiter) receive(msg *pubsub.Message) {
+	from := msg.GetFrom()
+	if from == l.node.ID() || !l.peers.Has(from) {
+		return
+	}
+
+	r := new(report)
+	if cbor.Unmarshal(msg.Data, r) != nil {
+		return
+	}
+
+	l.observe(r, time.Now())
+}
+
+func (l *Limiter) gossipLoop() {
+	ticker := time.NewTicker(ReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case now := <-ticker.C:
+			l.publish(l.collect(now))
+		}
+	}
+}
+
+func (l *Limiter) publish(spent map[string]uint64) {
+	if len(spent) == 0 {
+		return
+	}
+
+	data, err := cbor.Marshal(&report{Spent: spent})
+	if err != nil {
+		logger.Errorf("marshalling limits report failed with: %s", err.Error())
+		return
+	}
+
+	if err = l.node.PubSubPublish(l.ctx, Topic, data); err != nil {
+		logger.Errorf("publishing limits report failed with: %s", err.Error())
+	}
+}
+
+// collect returns the tokens taken on this node since the last call, and
+// drops the buckets unused for BucketExpiry.
+func (l *Limiter) collect(now time.Time) map[string]uint64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	spent := make(map[string]uint64)
+	for key, b := range l.buckets {
+		if b.spent > 0 {
+			spent[key] = b.spent
+			b.spent = 0
+		} else if now.Sub(b.last) > BucketExpiry {
+			delete(l.buckets, key)
+		}
+	}
+
+	return spent
+}
+
+// observe takes from our buckets the tokens a peer took from its own. A
+// bucket can't go further below empty than its burst, so a client flooding
+// the network isn't kept out longer than the time to refill twice.
+func (l *Limiter) observe(r *report, now time.Time) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for key, spent := range r.Spent {
+		b, ok := l.buckets[key]
+		if !ok {
+			b = &bucket{last: now}
+			l.buckets[key] = b
+		}
+
+		b.refill(now)
+		b.tokens -= float64(spent)
+		if b.rate > 0 {
+			b.tokens = math.Max(b.tokens, -b.burst)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package limits
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/taubyte/tau/p2p/peer"
+)
+
+// New returns a limiter gossiping over node, if not nil, with peers until ctx
+// is done.
+func New(ctx context.Context, node peer.Node, peers Peers) (*Limiter, error) {
+	l := &Limiter{
+		ctx:     ctx,
+		node:    node,
+		peers:   peers,
+		buckets: make(map[string]*bucket),
+		running: make(map[string]int),
+	}
+
+	if node != nil {
+		if err := l.startGossip(); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+type tunnelKey struct{}
+
+// WithTunnel marks ctx as the one of a request the gateway tunneled.
+func WithTunnel(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tunnelKey{}, true)
+}
+
+// FromTunnel reports whether ctx is the one of a request the gateway tunneled.
+func FromTunnel(ctx context.Context) bool {
+	tunneled, _ := ctx.Value(tunnelKey{}).(bool)
+	return tunneled
+}
+
+// Client returns what tells the client of r apart under limit: the value of
+// its header, or the IP the request came from. Behind the gateway that is
+// the last address it added to X-Forwarded-For, as earlier ones come from the
+// client and can't be trusted. Requests that didn't come through the gateway
+// can forge the header, so it is only read on tunneled ones.
+func (limit Limit) Client(r *http.Request) string {
+	if limit.Key != "" {
+		if value := r.Header.Get(limit.Key); value != "" {
+			return value
+		}
+	}
+
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 && FromTunnel(r.Context()) {
+		hops := strings.Split(forwarded[len(forwarded)-1], ",")
+		if hop := strings.TrimSpace(hops[len(hops)-1]); hop != "" {
+			return hop
+		}
+	}
+
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+
+	return r.RemoteAddr
+}
+
+// Allow takes a token from the bucket of client on resource. When it is
+// empty, it returns how long the client has to wait for one.
+func (l *Limiter) Allow(resource string, limit Limit, client string) (time.Duration, bool) {
+	if limit.Rate <= 0 {
+		return 0, true
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	b := l.bucket(bucketKey(resource, client), limit, time.Now())
+	if b.tokens < 1 {
+		wait := (1 - b.tokens) / float64(limit.Rate)
+		return time.Duration(math.Ceil(wait * float64(time.Second))), false
+	}
+
+	b.tokens--
+	b.spent++
+
+	return 0, true
+}
+
+// Acquire counts a request served by resource, unless its concurrency cap is
+// reached. The returned function must be called once the request is done.
+func (l *Limiter) Acquire(resource string, limit Limit) (func(), bool) {
+	if limit.Concurrency <= 0 {
+		return func() {}, true
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.running[resource] >= limit.Concurrency {
+		return nil, false
+	}
+
+	l.running[resource]++
+
+	return func() {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+
+		if l.running[resource]--; l.running[resource] <= 0 {
+			delete(l.running, resource)
+		}
+	}, true
+}
+
+// Check applies limit to the request r makes to resource. It returns a
+// *RejectedError if the request is over it, and otherwise the function to
+// call once the request is done.
+func (l *Limiter) Check(resource string, limit Limit, r *http.Request) (func(), error) {
+	if retryAfter, ok := l.Allow(resource, limit, limit.Client(r)); !ok {
+		return nil, &RejectedError{Resource: resource, RetryAfter: retryAfter}
+	}
+
+	release, ok := l.Acquire(resource, limit)
+	if !ok {
+		return nil, &RejectedError{Resource: resource, RetryAfter: ConcurrencyRetryAfter, Concurrent: true}
+	}
+
+	return release, nil
+}
+
+// bucketKey is the key of the bucket of client on resource.
+func bucketKey(resource, client string) string {
+	sum := sha256.Sum256([]byte(resource + "/" + client))
+	return hex.EncodeToString(sum[:])
+}
+
+// bucket returns the bucket under key, refilled up to now. New buckets are
+// full. Callers must hold the lock.
+func (l *Limiter) bucket(key string, limit Limit, now time.Time) *bucket {
+	burst := float64(limit.Burst)
+	if burst <= 0 {
+		burst = float64(limit.Rate)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	} else if b.rate == 0 {
+		b.tokens += burst
+	}
+
+	b.rate, b.burst = float64(limit.Rate), burst
+	b.refill(now)
+
+	return b
+}
+
+// refill adds the tokens earned since the last refill. Buckets not knowing
+// their limit keep the time of their first debt, so it is refilled once
+// their limit is known.
+func (b *bucket) refill(now time.Time) {
+	if b.rate == 0 {
+		return
+	}
+
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
@@ -0,0 +1,172 @@
+package limits
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	peerCore "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taubyte/tau/p2p/peer"
+	"gotest.tools/v3/assert"
+)
+
+func newLimiter(t *testing.T) *Limiter {
+	l, err := New(context.Background(), nil, nil)
+	assert.NilError(t, err)
+	return l
+}
+
+func TestAllow(t *testing.T) {
+	l := newLimiter(t)
+	limit := Limit{Rate: 2, Burst: 3}
+
+	for range 3 {
+		_, ok := l.Allow("fn", limit, "1.2.3.4")
+		assert.Assert(t, ok)
+	}
+
+	retryAfter, ok := l.Allow("fn", limit, "1.2.3.4")
+	assert.Assert(t, !ok)
+	assert.Assert(t, retryAfter > 0 && retryAfter <= 500*time.Millisecond, retryAfter)
+
+	// Other clients and resources have their own buckets.
+	_, ok = l.Allow("fn", limit, "5.6.7.8")
+	assert.Assert(t, ok)
+	_, ok = l.Allow("other", limit, "1.2.3.4")
+	assert.Assert(t, ok)
+
+	// Tokens come back at the rate.
+	l.buckets[bucketKey("fn", "1.2.3.4")].last = time.Now().Add(-time.Second)
+	for range 2 {
+		_, ok = l.Allow("fn", limit, "1.2.3.4")
+		assert.Assert(t, ok)
+	}
+	_, ok = l.Allow("fn", limit, "1.2.3.4")
+	assert.Assert(t, !ok)
+
+	_, ok = l.Allow("fn", Limit{}, "1.2.3.4")
+	assert.Assert(t, ok, "no rate should be unlimited")
+}
+
+func TestAcquire(t *testing.T) {
+	l := newLimiter(t)
+	limit := Limit{Concurrency: 2}
+
+	release1, ok := l.Acquire("fn", limit)
+	assert.Assert(t, ok)
+	_, ok = l.Acquire("fn", limit)
+	assert.Assert(t, ok)
+
+	_, ok = l.Acquire("fn", limit)
+	assert.Assert(t, !ok)
+
+	release1()
+	_, ok = l.Acquire("fn", limit)
+	assert.Assert(t, ok)
+}
+
+func TestCheck(t *testing.T) {
+	l := newLimiter(t)
+	r, _ := http.NewRequest("GET", "http://example.com", nil)
+	r.RemoteAddr = "1.2.3.4:5678"
+
+	release, err := l.Check("fn", Limit{Rate: 1, Concurrency: 1}, r)
+	assert.NilError(t, err)
+
+	var rejected *RejectedError
+	_, err = l.Check("fn", Limit{Concurrency: 1}, r)
+	assert.Assert(t, errors.As(err, &rejected))
+	assert.Assert(t, rejected.Concurrent)
+	assert.Equal(t, rejected.RetryAfter, ConcurrencyRetryAfter)
+	release()
+
+	_, err = l.Check("fn", Limit{Rate: 1}, r)
+	assert.Assert(t, errors.As(err, &rejected))
+	assert.Assert(t, !rejected.Concurrent)
+	assert.ErrorContains(t, err, "rate limit of `fn` exceeded")
+}
+
+func TestClient(t *testing.T) {
+	r, _ := http.NewRequest("GET", "http://example.com", nil)
+	r.RemoteAddr = "[::1]:5678"
+	assert.Equal(t, Limit{}.Client(r), "::1")
+
+	// Only the gateway is trusted with the header.
+	r.Header.Add("X-Forwarded-For", "6.6.6.6, 1.2.3.4")
+	assert.Equal(t, Limit{}.Client(r), "::1")
+
+	// The gateway appends the address it got the request from.
+	r = r.WithContext(WithTunnel(r.Context()))
+	assert.Equal(t, Limit{}.Client(r), "1.2.3.4")
+
+	r.Header.Set("X-Api-Key", "key1")
+	assert.Equal(t, Limit{Key: "X-Api-Key"}.Client(r), "key1")
+	assert.Equal(t, Limit{Key: "X-Other"}.Client(r), "1.2.3.4")
+}
+
+func TestGossip(t *testing.T) {
+	l := newLimiter(t)
+	limit := Limit{Rate: 1, Burst: 5}
+
+	_, ok := l.Allow("fn", limit, "1.2.3.4")
+	assert.Assert(t, ok)
+
+	now := time.Now()
+	assert.DeepEqual(t, l.collect(now), map[string]uint64{bucketKey("fn", "1.2.3.4"): 1})
+	assert.DeepEqual(t, l.collect(now), map[string]uint64{})
+
+	// A peer served the client 3 times: one token is left here.
+	l.observe(&report{Spent: map[string]uint64{bucketKey("fn", "1.2.3.4"): 3}}, now)
+	_, ok = l.Allow("fn", limit, "1.2.3.4")
+	assert.Assert(t, ok)
+	_, ok = l.Allow("fn", limit, "1.2.3.4")
+	assert.Assert(t, !ok)
+
+	// A client only seen by peers starts with their debt.
+	l.observe(&report{Spent: map[string]uint64{bucketKey("fn", "5.6.7.8"): 5}}, now)
+	_, ok = l.Allow("fn", limit, "5.6.7.8")
+	assert.Assert(t, !ok)
+
+	// Flooding doesn't lock a client out for longer than twice the burst.
+	l.observe(&report{Spent: map[string]uint64{bucketKey("fn", "1.2.3.4"): 1000}}, now)
+	assert.Assert(t, l.buckets[bucketKey("fn", "1.2.3.4")].tokens >= -5)
+
+	// Quiet buckets expire.
+	l.collect(now)
+	l.collect(now.Add(2 * BucketExpiry))
+	assert.Equal(t, len(l.buckets), 0)
+}
+
+type mockPeers map[peerCore.ID]bool
+
+func (p mockPeers) Has(pid peerCore.ID) bool {
+	return p[pid]
+}
+
+func TestReceive(t *testing.T) {
+	node := peer.Mock(t.Context())
+	substrate, other := peerCore.ID("substrate"), peerCore.ID("other")
+
+	l, err := New(t.Context(), node, mockPeers{substrate: true, node.ID(): true})
+	assert.NilError(t, err)
+
+	data, err := cbor.Marshal(&report{Spent: map[string]uint64{bucketKey("fn", "1.2.3.4"): 3}})
+	assert.NilError(t, err)
+
+	message := func(from peerCore.ID) *pubsub.Message {
+		return &pubsub.Message{Message: &pb.Message{From: []byte(from), Data: data}}
+	}
+
+	// Reports of nodes not running substrate, and our own, are dropped.
+	l.receive(message(other))
+	l.receive(message(node.ID()))
+	assert.Equal(t, len(l.buckets), 0)
+
+	l.receive(message(substrate))
+	assert.Equal(t, l.buckets[bucketKey("fn", "1.2.3.4")].tokens, float64(-3))
+}
@@ -0,0 +1,80 @@
+package limits
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	peerCore "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taubyte/tau/p2p/peer"
+)
+
+// Peers tells the substrate nodes reports are taken from apart.
+type Peers interface {
+	Has(pid peerCore.ID) bool
+}
+
+// Limit is the rate and concurrency settings of a function or a domain. A
+// zero Rate or Concurrency leaves that side unlimited.
+type Limit struct {
+	// Rate is the requests per second each client may make.
+	Rate int
+
+	// Burst is the requests a client may make at once, Rate when 0.
+	Burst int
+
+	// Key is the header telling clients apart, their IP when empty.
+	Key string
+
+	// Concurrency is the requests a node serves at once.
+	Concurrency int
+}
+
+// Limiter keeps a token bucket per client of every rate limited resource and
+// counts the requests each resource is serving. Tokens clients spend are
+// gossiped, so a client's rate holds across the substrate nodes.
+type Limiter struct {
+	ctx   context.Context
+	node  peer.Node
+	peers Peers
+
+	lock    sync.Mutex
+	buckets map[string]*bucket
+	running map[string]int
+}
+
+// bucket holds the tokens of a client of a resource, under a hash of both so
+// reports don't carry client addresses. Buckets first heard of
+// from peers don't know their limit yet: their tokens are what peers took.
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	// spent is the tokens taken on this node since the last report.
+	spent uint64
+}
+
+// report is what a node publishes on Topic every ReportInterval: the tokens
+// taken from each bucket since its previous report.
+type report struct {
+	Spent map[string]uint64
+}
+
+// RejectedError is returned for requests over the rate limit or concurrency
+// cap of a resource.
+type RejectedError struct {
+	Resource   string
+	RetryAfter time.Duration
+	Concurrent bool
+}
+
+func (e *RejectedError) Error() string {
+	if e.Concurrent {
+		return fmt.Sprintf("too many concurrent requests to `%s`", e.Resource)
+	}
+
+	return fmt.Sprintf("rate limit of `%s` exceeded", e.Resource)
+}
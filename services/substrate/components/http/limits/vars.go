@@ -0,0 +1,26 @@
+package limits
+
+import (
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+)
+
+var logger = log.Logger("tau.substrate.components.http.limits")
+
+// Topic carries the tokens each substrate node took from the buckets of rate
+// limited clients.
+var Topic = "/substrate/v1/limits"
+
+// Tunables — exported so tests can shrink them.
+var (
+	// ReportInterval is how often a node publishes the tokens it took.
+	ReportInterval = time.Second
+
+	// BucketExpiry drops the bucket of a client that went quiet.
+	BucketExpiry = time.Minute
+
+	// ConcurrencyRetryAfter is what clients over a concurrency cap are told
+	// to wait.
+	ConcurrencyRetryAfter = time.Second
+)
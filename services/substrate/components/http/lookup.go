@@ -1,6 +1,8 @@
 package http
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	_ "embed"
@@ -10,6 +12,7 @@ import (
 	"github.com/taubyte/tau/core/services/tns"
 	spec "github.com/taubyte/tau/pkg/specs/common"
 	domainSpec "github.com/taubyte/tau/pkg/specs/domain"
+	"github.com/taubyte/tau/pkg/specs/extract"
 	functionSpec "github.com/taubyte/tau/pkg/specs/function"
 	matcherSpec "github.com/taubyte/tau/pkg/specs/matcher"
 	"github.com/taubyte/tau/pkg/specs/methods"
@@ -32,7 +35,19 @@ func (s *Service) CheckTns(matcherIface commonIface.MatchDefinition) ([]commonIf
 		return nil, fmt.Errorf("%#v is invalid http matcher", matcher)
 	}
 
+	return s.checkTns(s.Context(), matcher)
+}
+
+// checkTns looks matcher up in TNS as part of the trace of ctx, if any.
+func (s *Service) checkTns(ctx context.Context, matcher *common.MatchDefinition) ([]commonIface.Serviceable, error) {
+	tnsClient := s.Tns().WithContext(ctx)
+
 	host := helpers.ExtractHost(matcher.Host)
+	previewLabel, baseHost, preview := spec.SplitPreviewHost(host)
+	if preview {
+		host = baseHost
+	}
+
 	var candidates []commonIface.Serviceable
 	for _, rtype := range ValidResources {
 		servKey, err := methods.HttpPath(host, rtype)
@@ -40,11 +55,18 @@ func (s *Service) CheckTns(matcherIface commonIface.MatchDefinition) ([]commonIf
 			return nil, fmt.Errorf("creating new tns path for serviceable type `%s` on host `%s` failed with: %w", rtype, host, err)
 		}
 
-		indexObject, err := s.Tns().Fetch(servKey.Versioning().Links())
+		indexObject, err := tnsClient.Fetch(servKey.Versioning().Links())
 		if err == nil {
-			pathList, err := indexObject.Current(spec.DefaultBranches)
+			var pathList []tns.Path
+			if branch, commit := matcher.Pinned(); commit != "" {
+				pathList, err = s.pinnedPaths(indexObject, branch, commit)
+			} else if preview {
+				pathList, err = previewPaths(tnsClient, indexObject, previewLabel)
+			} else {
+				pathList, err = indexObject.Current(spec.DefaultBranches)
+			}
 			if err == nil {
-				candidates = append(candidates, s.handleTNSPaths(rtype, matcher, pathList)...)
+				candidates = append(candidates, s.handleTNSPaths(ctx, rtype, matcher, pathList)...)
 			}
 		}
 	}
@@ -57,7 +79,7 @@ func (s *Service) CheckTns(matcherIface commonIface.MatchDefinition) ([]commonIf
 			publicKey = s.dvPublicKey
 		}
 
-		if err := domainSpec.ValidateDNS(s.config.GeneratedDomainRegExp(), pick.Project(), matcher.Host, s.Dev(), dv.PublicKey(publicKey)); err != nil {
+		if err := domainSpec.ValidateDNS(s.config.GeneratedDomainRegExp(), pick.Project(), host, s.Dev(), dv.PublicKey(publicKey)); err != nil {
 			return nil, fmt.Errorf("validating dns failed for match definition `%v` failed with: %w", *matcher, err)
 		}
 
@@ -67,17 +89,114 @@ func (s *Service) CheckTns(matcherIface commonIface.MatchDefinition) ([]commonIf
 	return nil, fmt.Errorf("no HTTP match found for method `%s` on `https://%s%s`", matcher.Method, matcher.Host, matcher.Path)
 }
 
-func (s *Service) handleTNSPaths(stype spec.PathVariable, matcher *common.MatchDefinition, paths []tns.Path) []commonIface.Serviceable {
+// indexedProject returns the links indexed under a host and the project they
+// belong to.
+func indexedProject(indexObject tns.Object) ([]interface{}, string, error) {
+	links, ok := indexObject.Interface().([]interface{})
+	if !ok || len(links) == 0 {
+		return nil, "", errors.New("no links indexed")
+	}
+
+	first, ok := links[0].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("cannot convert path iface `%v` to string", links[0])
+	}
+
+	link, err := extract.Tns().BasicPath(first)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return links, link.Project(), nil
+}
+
+// previewPaths resolves the resources indexed under a host to the current
+// commit of the preview branch registered for label, so `<label>--<host>`
+// serves that branch instead of the default one.
+func previewPaths(tnsClient tns.Client, indexObject tns.Object, label string) ([]tns.Path, error) {
+	links, projectId, err := indexedProject(indexObject)
+	if err != nil {
+		return nil, fmt.Errorf("resolving preview `%s` failed with: %w", label, err)
+	}
+
+	branchObj, err := tnsClient.Fetch(spec.Preview(projectId, label))
+	if err != nil {
+		return nil, fmt.Errorf("fetching preview `%s` of project `%s` failed with: %w", label, projectId, err)
+	}
+
+	branch, ok := branchObj.Interface().(string)
+	if !ok || branch == "" {
+		return nil, fmt.Errorf("preview `%s` of project `%s` does not exist", label, projectId)
+	}
+
+	commit, _, err := tnsClient.Simple().Commit(projectId, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	return commitPaths(links, projectId, branch, commit)
+}
+
+// pinnedPaths resolves the resources indexed under a host to the commit of
+// branch a traffic policy pinned the request to.
+func (s *Service) pinnedPaths(indexObject tns.Object, branch, commit string) ([]tns.Path, error) {
+	links, projectId, err := indexedProject(indexObject)
+	if err != nil {
+		return nil, fmt.Errorf("resolving commit `%s` failed with: %w", commit, err)
+	}
+
+	return commitPaths(links, projectId, branch, commit)
+}
+
+// commitPaths returns the config paths, in commit of branch, of the resources
+// linked from an index.
+func commitPaths(links []interface{}, projectId, branch, commit string) ([]tns.Path, error) {
+	seen := make(map[string]bool, len(links))
+	paths := make([]tns.Path, 0, len(links))
+	for _, linkIface := range links {
+		_path, ok := linkIface.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert path iface `%v` to string", linkIface)
+		}
+
+		link, err := extract.Tns().BasicPath(_path)
+		if err != nil {
+			return nil, err
+		}
+
+		if link.Project() != projectId {
+			return nil, fmt.Errorf("unexpected project ID `%s` in index", link.Project())
+		}
+
+		key := link.Application() + "/" + link.ResourceType() + "/" + link.Resource()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		currentPath, err := methods.GetBasicTNSKey(branch, commit, projectId, link.Application(), link.Resource(), spec.PathVariable(link.ResourceType()))
+		if err != nil {
+			return nil, fmt.Errorf("getting basic tns key for index `%s` failed with: %w", _path, err)
+		}
+
+		paths = append(paths, currentPath)
+	}
+
+	return paths, nil
+}
+
+func (s *Service) handleTNSPaths(ctx context.Context, stype spec.PathVariable, matcher *common.MatchDefinition, paths []tns.Path) []commonIface.Serviceable {
+	tnsClient := s.Tns().WithContext(ctx)
 	candidates := make([]commonIface.Serviceable, 0, len(paths))
 	for _, path := range paths {
-		config, err := s.Tns().Fetch(path)
+		config, err := tnsClient.Fetch(path)
 		if err == nil {
 			var serv commonIface.Serviceable
 			switch stype {
 			case websiteSpec.PathVariable:
-				serv, err = website.New(s, config, matcher)
+				serv, err = website.New(ctx, s, config, matcher)
 			case functionSpec.PathVariable:
-				serv, err = function.New(s, config, matcher)
+				serv, err = function.New(ctx, s, config, matcher)
 			}
 
 			if err == nil && serv != nil {
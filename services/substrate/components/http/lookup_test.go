@@ -8,6 +8,7 @@ import (
 
 	commonIface "github.com/taubyte/tau/core/services/substrate/components"
 	"github.com/taubyte/tau/p2p/peer"
+	functionSpec "github.com/taubyte/tau/pkg/specs/function"
 	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
 	"github.com/taubyte/tau/services/substrate/components/http/common"
 	"github.com/taubyte/tau/services/substrate/components/http/function"
@@ -140,3 +141,38 @@ func checkWebsite(s *Service, id string, matcher *common.MatchDefinition) error
 
 	return nil
 }
+
+func TestCommitPaths(t *testing.T) {
+	link, err := functionSpec.Tns().BasicPath("main", "c1", testProject, "", "fn")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := commitPaths([]interface{}{link.String(), link.String()}, testProject, "main", "c2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := functionSpec.Tns().BasicPath("main", "c2", testProject, "", "fn")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(paths) != 1 || paths[0].String() != expected.String() {
+		t.Fatalf("expected %s, got %v", expected.String(), paths)
+	}
+
+	if _, err = commitPaths([]interface{}{link.String()}, "other", "main", "c2"); err == nil {
+		t.Fatal("expected links of another project to fail")
+	}
+
+	matcher := common.New("example.com", "/", "GET")
+	pinned := matcher.Pin("main", "c2")
+	if pinned.CachePrefix() != "example.com@c2" {
+		t.Fatalf("unexpected cache prefix %s", pinned.CachePrefix())
+	}
+
+	if matcher.CachePrefix() != "example.com" {
+		t.Fatalf("pinning changed the cache prefix of the definition to %s", matcher.CachePrefix())
+	}
+}
@@ -3,7 +3,11 @@ package http
 import (
 	"fmt"
 
+	seerApi "github.com/taubyte/tau/clients/p2p/seer"
+	seerIface "github.com/taubyte/tau/core/services/seer"
 	"github.com/taubyte/tau/pkg/config"
+	servicesCommon "github.com/taubyte/tau/services/common"
+	"github.com/taubyte/tau/services/substrate/components/http/limits"
 	"github.com/taubyte/tau/services/substrate/runtime/cache"
 
 	nodeIface "github.com/taubyte/tau/core/services/substrate"
@@ -11,9 +15,11 @@ import (
 
 func New(srv nodeIface.Service, cfg config.Config, options ...Option) (*Service, error) {
 	s := &Service{
-		Service: srv,
-		config:  cfg,
-		cache:   cache.New(),
+		Service:      srv,
+		config:       cfg,
+		cache:        cache.New(),
+		policies:     make(map[string]policyEntry),
+		domainLimits: make(map[string]domainLimitEntry),
 	}
 
 	var err error
@@ -23,6 +29,21 @@ func New(srv nodeIface.Service, cfg config.Config, options ...Option) (*Service,
 		}
 	}()
 
+	clientNode := srv.Node()
+	if cfg != nil && cfg.ClientNode() != nil {
+		clientNode = cfg.ClientNode()
+	}
+
+	seerClient, err := seerApi.New(srv.Context(), clientNode, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating seer client failed with: %w", err)
+	}
+
+	substratePeers := servicesCommon.NewServicePeers(seerClient.Usage(), seerIface.ServiceTypeSubstrate)
+	if s.limiter, err = limits.New(srv.Context(), srv.Node(), substratePeers); err != nil {
+		return nil, fmt.Errorf("creating limiter failed with: %w", err)
+	}
+
 	for _, opt := range options {
 		if err = opt(s); err != nil {
 			return nil, fmt.Errorf("options failed with: %w", err)
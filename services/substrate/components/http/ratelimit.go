@@ -0,0 +1,110 @@
+package http
+
+import (
+	"time"
+
+	goHttp "net/http"
+
+	iface "github.com/taubyte/tau/core/services/substrate/components/http"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/http/common"
+	"github.com/taubyte/tau/services/substrate/components/http/function"
+	"github.com/taubyte/tau/services/substrate/components/http/limits"
+	"github.com/taubyte/tau/services/substrate/runtime/counter"
+	"github.com/taubyte/tau/services/substrate/runtime/helpers"
+)
+
+// DomainLimitTTL is how long the limit of the domain a host is served under
+// is used before it is fetched again.
+var DomainLimitTTL = time.Minute
+
+type domainLimitEntry struct {
+	limit   limits.Limit
+	expires time.Time
+}
+
+func functionLimit(config *structureSpec.Function) limits.Limit {
+	return limits.Limit{Rate: config.RateLimit, Burst: config.RateBurst, Key: config.RateKey, Concurrency: config.Concurrency}
+}
+
+func domainLimit(config *structureSpec.Domain) limits.Limit {
+	return limits.Limit{Rate: config.RateLimit, Burst: config.RateBurst, Key: config.RateKey, Concurrency: config.Concurrency}
+}
+
+// domainLimit returns the limit of the domain of pick serving host, a zero
+// one if it has none.
+func (s *Service) domainLimit(pick iface.Serviceable, host string) limits.Limit {
+	if _, base, preview := spec.SplitPreviewHost(host); preview {
+		host = base
+	}
+
+	key := pick.Project() + "/" + pick.Commit() + "/" + host
+
+	s.domainLimitsLock.Lock()
+	entry, ok := s.domainLimits[key]
+	s.domainLimitsLock.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.limit
+	}
+
+	var ids []string
+	switch serviceable := pick.(type) {
+	case iface.Function:
+		ids = serviceable.Config().Domains
+	case iface.Website:
+		ids = serviceable.Config().Domains
+	}
+
+	var limit limits.Limit
+	domains := s.Tns().Domain().All(pick.Project(), pick.Application(), pick.Branch())
+	for _, id := range ids {
+		if domain, err := domains.GetByIdCommit(id, pick.Commit()); err == nil && domain.Fqdn == host {
+			limit = domainLimit(domain)
+			break
+		}
+	}
+
+	s.domainLimitsLock.Lock()
+	s.domainLimits[key] = domainLimitEntry{limit: limit, expires: time.Now().Add(DomainLimitTTL)}
+	s.domainLimitsLock.Unlock()
+
+	return limit
+}
+
+// limit applies the limits of the domain the request is made to, then those
+// of the function serving it. It returns a *limits.RejectedError if one is
+// exceeded, and otherwise the function to call once the request is done.
+func (s *Service) limit(r *goHttp.Request, matcher *common.MatchDefinition, pick iface.Serviceable) (func(), error) {
+	host := helpers.ExtractHost(matcher.Host)
+	releaseDomain, err := s.limiter.Check("domains/"+host, s.domainLimit(pick, host), r)
+	if err != nil {
+		s.rejected(pick)
+		return nil, err
+	}
+
+	var limit limits.Limit
+	if fn, ok := pick.(iface.Function); ok {
+		limit = functionLimit(fn.Config())
+	}
+
+	releaseResource, err := s.limiter.Check(pick.Project()+"/"+pick.Id(), limit, r)
+	if err != nil {
+		releaseDomain()
+		s.rejected(pick)
+		return nil, err
+	}
+
+	return func() {
+		releaseResource()
+		releaseDomain()
+	}, nil
+}
+
+func (s *Service) rejected(pick iface.Serviceable) {
+	if fn, ok := pick.(*function.Function); ok {
+		fn.Rejected()
+	}
+
+	counter.Rejected(pick)
+}
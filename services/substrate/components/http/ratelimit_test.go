@@ -0,0 +1,59 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/services/substrate/components"
+	iface "github.com/taubyte/tau/core/services/substrate/components/http"
+	"github.com/taubyte/tau/p2p/peer"
+	"github.com/taubyte/tau/services/substrate/components/http/common"
+	"github.com/taubyte/tau/services/substrate/components/http/limits"
+	"gotest.tools/v3/assert"
+)
+
+type limitedServiceable struct {
+	iface.Serviceable
+	srv *Service
+}
+
+func (l *limitedServiceable) Project() string { return testProject }
+func (l *limitedServiceable) Commit() string  { return testCommit }
+func (l *limitedServiceable) Id() string      { return "websiteId" }
+func (l *limitedServiceable) Matcher() components.MatchDefinition {
+	return common.New(successDomain, "/", getRequest)
+}
+func (l *limitedServiceable) Service() components.ServiceComponent { return l.srv }
+
+func TestDomainLimit(t *testing.T) {
+	s := newTestService(peer.Mock(t.Context()))
+	pick := &limitedServiceable{srv: s}
+	s.domainLimits[testProject+"/"+testCommit+"/"+successDomain] = domainLimitEntry{
+		limit:   limits.Limit{Rate: 1, Burst: 2, Concurrency: 1},
+		expires: time.Now().Add(time.Minute),
+	}
+
+	matcher := common.New(successDomain, "/", getRequest)
+	r := httptest.NewRequest("GET", "http://"+successDomain+"/", nil)
+
+	release, err := s.limit(r, matcher, pick)
+	assert.NilError(t, err)
+
+	var rejected *limits.RejectedError
+	_, err = s.limit(r, matcher, pick)
+	assert.Assert(t, errors.As(err, &rejected))
+	assert.Assert(t, rejected.Concurrent)
+
+	release()
+	_, err = s.limit(r, matcher, pick)
+	assert.Assert(t, errors.As(err, &rejected))
+	assert.Assert(t, !rejected.Concurrent)
+
+	w := httptest.NewRecorder()
+	writeError(w, err)
+	assert.Equal(t, w.Code, http.StatusTooManyRequests)
+	assert.Equal(t, w.Header().Get("Retry-After"), "1")
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	iface "github.com/taubyte/tau/core/services/substrate/components/http"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	"github.com/taubyte/tau/services/substrate/components/http/common"
+)
+
+// PolicyTTL is how long the traffic policy of a branch is used before it is
+// fetched again.
+var PolicyTTL = 5 * time.Second
+
+type policyEntry struct {
+	policy  *spec.TrafficPolicy
+	expires time.Time
+}
+
+// policy returns the traffic policy of a project's branch, nil if it has none.
+func (s *Service) policy(ctx context.Context, projectId, branch string) *spec.TrafficPolicy {
+	key := projectId + "/" + branch
+
+	s.policiesLock.Lock()
+	entry, ok := s.policies[key]
+	s.policiesLock.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.policy
+	}
+
+	var policy *spec.TrafficPolicy
+	if obj, err := s.Tns().WithContext(ctx).Fetch(spec.Traffic(projectId, branch)); err == nil {
+		policy, _ = spec.DecodeTrafficPolicy(obj.Interface())
+	}
+
+	s.policiesLock.Lock()
+	s.policies[key] = policyEntry{policy: policy, expires: time.Now().Add(PolicyTTL)}
+	s.policiesLock.Unlock()
+
+	return policy
+}
+
+// Split serves matcher from the commit the traffic policy of pick's branch
+// assigns to key, looking up the serviceable of that commit. It reports
+// whether a policy applied; without one, pick is returned as is. TNS is asked
+// as part of the trace of ctx, if any.
+func (s *Service) Split(ctx context.Context, matcher *common.MatchDefinition, pick iface.Serviceable, key string) (iface.Serviceable, bool, error) {
+	// Previews serve their branch as is.
+	if !spec.IsDefaultBranch(pick.Branch()) {
+		return pick, false, nil
+	}
+
+	policy := s.policy(ctx, pick.Project(), pick.Branch())
+	if !policy.Active() {
+		return pick, false, nil
+	}
+
+	commit := policy.Pick(key, pick.Commit())
+
+	pinned, err := s.Lookup(ctx, matcher.Pin(pick.Branch(), commit))
+	if err != nil {
+		return nil, true, fmt.Errorf("looking up commit `%s` failed with: %w", commit, err)
+	}
+
+	return pinned, true, nil
+}
@@ -1,8 +1,11 @@
 package http
 
 import (
+	"sync"
+
 	"github.com/taubyte/tau/core/services/substrate"
 	"github.com/taubyte/tau/pkg/config"
+	"github.com/taubyte/tau/services/substrate/components/http/limits"
 	"github.com/taubyte/tau/services/substrate/runtime/cache"
 )
 
@@ -11,4 +14,11 @@ type Service struct {
 	config      config.Config
 	cache       *cache.Cache
 	dvPublicKey []byte
+
+	policiesLock sync.Mutex
+	policies     map[string]policyEntry
+
+	limiter          *limits.Limiter
+	domainLimitsLock sync.Mutex
+	domainLimits     map[string]domainLimitEntry
 }
@@ -13,6 +13,7 @@ import (
 	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
 	websiteSpec "github.com/taubyte/tau/pkg/specs/website"
 	"github.com/taubyte/tau/services/substrate/components/http/common"
+	"github.com/taubyte/tau/services/substrate/components/http/limits"
 	"github.com/taubyte/tau/services/substrate/components/structure"
 	"github.com/taubyte/tau/services/substrate/runtime/cache"
 	slices "github.com/taubyte/tau/utils/slices/string"
@@ -147,10 +148,16 @@ func newTestService(node peer.Node) *Service {
 	if err != nil {
 		panic(err)
 	}
+	limiter, err := limits.New(context.Background(), nil, nil)
+	if err != nil {
+		panic(err)
+	}
 	return &Service{
-		Service: nodeService,
-		cache:   cache.New(),
-		config:  cfg,
+		Service:      nodeService,
+		cache:        cache.New(),
+		config:       cfg,
+		limiter:      limiter,
+		domainLimits: make(map[string]domainLimitEntry),
 	}
 }
 
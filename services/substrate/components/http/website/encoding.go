@@ -0,0 +1,171 @@
+package website
+
+import (
+	"compress/gzip"
+	"fmt"
+	"mime"
+	goHttp "net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	websiteSpec "github.com/taubyte/tau/pkg/specs/builders/website"
+)
+
+// entry is what the zip of a build tells of a file.
+type entry struct {
+	crc  uint32
+	size uint64
+}
+
+// etag is a strong ETag of the file: its content is all the CRC and size
+// stand for.
+func (e entry) etag(encoding string) string {
+	if encoding != "" {
+		return fmt.Sprintf(`"%08x-%x-%s"`, e.crc, e.size, encoding)
+	}
+
+	return fmt.Sprintf(`"%08x-%x"`, e.crc, e.size)
+}
+
+// encodings are the precompressed variants served, by preference.
+var encodings = []struct {
+	name   string
+	suffix string
+}{
+	{"br", websiteSpec.BrotliSuffix},
+	{"gzip", websiteSpec.GzipSuffix},
+}
+
+// accepts tells whether an Accept-Encoding header takes a coding.
+func accepts(header, coding string) bool {
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.TrimSpace(name)
+		if name != coding && name != "*" {
+			continue
+		}
+
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			weight, err := strconv.ParseFloat(q, 64)
+			return err == nil && weight > 0
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// serveAsset serves a file of the website, the path being resolved by the
+// routes. Files of the build come with an ETag, and compressed when the client
+// takes it: from a variant of the build, else on the fly.
+func (w *Website) serveAsset(rw goHttp.ResponseWriter, r *goHttp.Request) error {
+	name := r.URL.Path
+	if strings.HasSuffix(name, "/") {
+		name += "index.html"
+	}
+
+	file, ok := w.entries[name]
+	if !ok {
+		return w.lowLevelAssetHandler(rw, r)
+	}
+
+	compressible := websiteSpec.Compressible(name)
+	if compressible {
+		rw.Header().Add("Vary", "Accept-Encoding")
+
+		acceptEncoding := r.Header.Get("Accept-Encoding")
+		for _, encoding := range encodings {
+			if variant, ok := w.entries[name+encoding.suffix]; ok && accepts(acceptEncoding, encoding.name) {
+				return w.serveVariant(rw, r, name, encoding.suffix, encoding.name, variant)
+			}
+		}
+
+		if file.size >= websiteSpec.MinCompressSize && accepts(acceptEncoding, "gzip") {
+			rw.Header().Set("Etag", file.etag("gzip"))
+			// Ranges are of the compressed output, unknown until written.
+			r.Header.Del("Range")
+
+			gw := &gzipResponseWriter{ResponseWriter: rw}
+			defer gw.Close()
+
+			return w.lowLevelAssetHandler(gw, r)
+		}
+	}
+
+	rw.Header().Set("Etag", file.etag(""))
+
+	return w.lowLevelAssetHandler(rw, r)
+}
+
+// serveVariant serves the precompressed variant of a file.
+func (w *Website) serveVariant(rw goHttp.ResponseWriter, r *goHttp.Request, name, suffix, encoding string, variant entry) error {
+	f, err := w.root.Open(name + suffix)
+	if err != nil {
+		return fmt.Errorf("opening `%s` failed with: %w", name+suffix, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("reading `%s` failed with: %w", name+suffix, err)
+	}
+
+	ctype := mime.TypeByExtension(path.Ext(name))
+	if ctype == "" {
+		ctype = "application/octet-stream"
+	}
+
+	header := rw.Header()
+	header.Set("Content-Type", ctype)
+	header.Set("Content-Encoding", encoding)
+	header.Set("Etag", variant.etag(encoding))
+
+	goHttp.ServeContent(rw, r, name, info.ModTime(), f)
+
+	return nil
+}
+
+// gzipResponseWriter compresses successful responses.
+type gzipResponseWriter struct {
+	goHttp.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+}
+
+func (g *gzipResponseWriter) WriteHeader(status int) {
+	if g.wroteHeader {
+		return
+	}
+	g.wroteHeader = true
+
+	if status == goHttp.StatusOK {
+		header := g.Header()
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", "gzip")
+		g.gz = gzip.NewWriter(g.ResponseWriter)
+	}
+
+	g.ResponseWriter.WriteHeader(status)
+}
+
+func (g *gzipResponseWriter) Write(p []byte) (int, error) {
+	if !g.wroteHeader {
+		g.WriteHeader(goHttp.StatusOK)
+	}
+
+	if g.gz != nil {
+		return g.gz.Write(p)
+	}
+
+	return g.ResponseWriter.Write(p)
+}
+
+func (g *gzipResponseWriter) Close() error {
+	if g.gz != nil {
+		return g.gz.Close()
+	}
+
+	return nil
+}
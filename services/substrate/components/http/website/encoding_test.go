@@ -0,0 +1,127 @@
+package website
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	goHttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/taubyte/tau/services/substrate/components/http/common"
+	"gotest.tools/v3/assert"
+)
+
+func newZipTestWebsite(t *testing.T, files map[string]string) *Website {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range files {
+		f, err := zw.Create(name)
+		assert.NilError(t, err)
+		_, err = f.Write([]byte(data))
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, zw.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NilError(t, err)
+
+	w := &Website{
+		srv:           assetsService{},
+		matcher:       &common.MatchDefinition{Request: &common.Request{Path: "/"}},
+		computedPaths: make(map[string][]string),
+	}
+	w.load(zr)
+
+	return w
+}
+
+func request(t *testing.T, w *Website, target string, header map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("GET", target, nil)
+	for name, value := range header {
+		r.Header.Set(name, value)
+	}
+
+	rec := httptest.NewRecorder()
+	assert.NilError(t, w.serve(rec, r))
+	return rec
+}
+
+func TestAccepts(t *testing.T) {
+	assert.Assert(t, accepts("gzip, deflate, br", "br"))
+	assert.Assert(t, accepts("br;q=0.5", "br"))
+	assert.Assert(t, !accepts("br;q=0, gzip", "br"))
+	assert.Assert(t, accepts("*", "gzip"))
+	assert.Assert(t, !accepts("", "gzip"))
+}
+
+func TestServeETag(t *testing.T) {
+	w := newZipTestWebsite(t, map[string]string{
+		"index.html": "index",
+		"logo.png":   "png",
+	})
+
+	rec := request(t, w, "/", nil)
+	assert.Equal(t, rec.Code, goHttp.StatusOK)
+	assert.Equal(t, rec.Body.String(), "index")
+	etag := rec.Header().Get("Etag")
+	assert.Assert(t, strings.HasPrefix(etag, `"`))
+
+	rec = request(t, w, "/", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, rec.Code, goHttp.StatusNotModified)
+	assert.Equal(t, rec.Body.Len(), 0)
+
+	rec = request(t, w, "/logo.png", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, rec.Code, goHttp.StatusOK)
+	assert.Assert(t, rec.Header().Get("Etag") != etag)
+	assert.Equal(t, rec.Header().Get("Vary"), "")
+}
+
+func TestServePrecompressed(t *testing.T) {
+	w := newZipTestWebsite(t, map[string]string{
+		"app.js":    "plain",
+		"app.js.br": "brotli",
+		"app.js.gz": "gzip",
+	})
+
+	rec := request(t, w, "/app.js", map[string]string{"Accept-Encoding": "gzip, br"})
+	assert.Equal(t, rec.Body.String(), "brotli")
+	assert.Equal(t, rec.Header().Get("Content-Encoding"), "br")
+	assert.Equal(t, rec.Header().Get("Content-Type"), "text/javascript; charset=utf-8")
+	assert.Equal(t, rec.Header().Get("Vary"), "Accept-Encoding")
+
+	etag := rec.Header().Get("Etag")
+	rec = request(t, w, "/app.js", map[string]string{"Accept-Encoding": "br", "If-None-Match": etag})
+	assert.Equal(t, rec.Code, goHttp.StatusNotModified)
+
+	rec = request(t, w, "/app.js", map[string]string{"Accept-Encoding": "gzip"})
+	assert.Equal(t, rec.Body.String(), "gzip")
+	assert.Equal(t, rec.Header().Get("Content-Encoding"), "gzip")
+
+	rec = request(t, w, "/app.js", nil)
+	assert.Equal(t, rec.Body.String(), "plain")
+	assert.Equal(t, rec.Header().Get("Content-Encoding"), "")
+}
+
+func TestServeCompressedOnTheFly(t *testing.T) {
+	style := strings.Repeat("body { margin: 0; }\n", 100)
+	w := newZipTestWebsite(t, map[string]string{"style.css": style})
+
+	rec := request(t, w, "/style.css", map[string]string{"Accept-Encoding": "gzip"})
+	assert.Equal(t, rec.Code, goHttp.StatusOK)
+	assert.Equal(t, rec.Header().Get("Content-Encoding"), "gzip")
+	assert.Equal(t, rec.Header().Get("Content-Length"), "")
+	assert.Assert(t, rec.Body.Len() < len(style))
+
+	gz, err := gzip.NewReader(rec.Body)
+	assert.NilError(t, err)
+	data, err := io.ReadAll(gz)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), style)
+
+	rec = request(t, w, "/style.css", map[string]string{"Accept-Encoding": "gzip", "If-None-Match": rec.Header().Get("Etag")})
+	assert.Equal(t, rec.Code, goHttp.StatusNotModified)
+	assert.Equal(t, rec.Header().Get("Content-Encoding"), "")
+}
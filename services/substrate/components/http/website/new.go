@@ -1,6 +1,7 @@
 package website
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/ipfs/go-cid"
@@ -12,7 +13,7 @@ import (
 	"github.com/taubyte/tau/services/substrate/runtime/cache"
 )
 
-func New(srv components.ServiceComponent, object tns.Object, matcher *common.MatchDefinition) (serviceable http.Serviceable, err error) {
+func New(ctx context.Context, srv components.ServiceComponent, object tns.Object, matcher *common.MatchDefinition) (serviceable http.Serviceable, err error) {
 	parser, err := extract.Tns().BasicPath(object.Path().String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse tns path `%s` with: %w", object.Path().String(), err)
@@ -34,7 +35,7 @@ func New(srv components.ServiceComponent, object tns.Object, matcher *common.Mat
 	}
 	w.config.Id = id
 
-	w.assetId, err = cache.ResolveAssetCid(w)
+	w.assetId, err = cache.ResolveAssetCid(ctx, w)
 	if err != nil {
 		return nil, fmt.Errorf("getting website asset id failed with: %w", err)
 	}
@@ -0,0 +1,345 @@
+package website
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"mime"
+	goHttp "net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ipfs/go-log/v2"
+	"github.com/spf13/afero"
+)
+
+var logger = log.Logger("tau.substrate.components.http.website")
+
+// Files of a build that set how the website is served. They are not served.
+const (
+	// RedirectsFile holds one rule a line: `<from> <to> [status][!]`. From is a
+	// path where `:name` matches a segment and a trailing `*` the rest, both
+	// then usable in to as `:name` and `:splat`. Status is 301 (default), 302,
+	// 303, 307 or 308 to redirect, 200 to serve to instead, or 404 to serve to
+	// as not found. Rules only apply to paths with no file unless forced with a
+	// trailing `!`; the first rule matching wins.
+	RedirectsFile = "_redirects"
+	// HeadersFile lists paths, matched like in RedirectsFile, each followed by
+	// indented `Name: value` headers set on their responses.
+	HeadersFile = "_headers"
+	// NotFoundPage is served, with a 404, for paths nothing answers on
+	// websites that aren't single page applications.
+	NotFoundPage = "404.html"
+)
+
+var placeholder = regexp.MustCompile(`:[A-Za-z_][A-Za-z0-9_]*`)
+
+// pattern matches request paths: segments, `:name` ones matching any, and
+// optionally whatever follows.
+type pattern struct {
+	segments []string
+	splat    bool
+}
+
+func parsePattern(value string) (*pattern, error) {
+	if !strings.HasPrefix(value, "/") {
+		return nil, fmt.Errorf("path `%s` should start with `/`", value)
+	}
+
+	p := &pattern{segments: splitPath(value)}
+	if n := len(p.segments); n > 0 && p.segments[n-1] == "*" {
+		p.segments, p.splat = p.segments[:n-1], true
+	}
+
+	return p, nil
+}
+
+func splitPath(value string) []string {
+	value = strings.Trim(value, "/")
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, "/")
+}
+
+// match returns the values of the placeholders of the pattern in a path.
+func (p *pattern) match(segments []string) (map[string]string, bool) {
+	if len(segments) < len(p.segments) || (!p.splat && len(segments) != len(p.segments)) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, segment := range p.segments {
+		if strings.HasPrefix(segment, ":") {
+			params[segment[1:]] = segments[i]
+		} else if segment != segments[i] {
+			return nil, false
+		}
+	}
+
+	if p.splat {
+		params["splat"] = strings.Join(segments[len(p.segments):], "/")
+	}
+
+	return params, true
+}
+
+type rule struct {
+	from   *pattern
+	to     string
+	status int
+	force  bool
+}
+
+// target fills the placeholders of the rule's destination.
+func (r *rule) target(params map[string]string) string {
+	return placeholder.ReplaceAllStringFunc(r.to, func(name string) string {
+		if value, ok := params[name[1:]]; ok {
+			return value
+		}
+		return name
+	})
+}
+
+func parseRule(line string) (*rule, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, fmt.Errorf("rule `%s` should be `<from> <to> [status]`", line)
+	}
+
+	from, err := parsePattern(fields[0])
+	if err != nil {
+		return nil, err
+	}
+
+	r := &rule{from: from, to: fields[1], status: goHttp.StatusMovedPermanently}
+	if len(fields) == 3 {
+		status := fields[2]
+		status, r.force = strings.CutSuffix(status, "!")
+		if r.status, err = strconv.Atoi(status); err != nil {
+			return nil, fmt.Errorf("invalid status `%s`", fields[2])
+		}
+	}
+
+	switch r.status {
+	case goHttp.StatusOK, goHttp.StatusNotFound:
+		if !strings.HasPrefix(r.to, "/") {
+			return nil, fmt.Errorf("rule `%s` can only serve a path of the website", line)
+		}
+	case goHttp.StatusMovedPermanently, goHttp.StatusFound, goHttp.StatusSeeOther, goHttp.StatusTemporaryRedirect, goHttp.StatusPermanentRedirect:
+	default:
+		return nil, fmt.Errorf("unsupported status `%d`", r.status)
+	}
+
+	return r, nil
+}
+
+type headers struct {
+	path   *pattern
+	header goHttp.Header
+}
+
+// routes is how a website is served, read from its build.
+type routes struct {
+	rules   []*rule
+	headers []*headers
+	// spa serves the index for paths with no file, as set by the website's
+	// config.
+	spa bool
+	// notFound is the page served for paths nothing answers, if any.
+	notFound string
+}
+
+// loadRoutes reads the routing files of a build, served as a single page
+// application if spa is set. Invalid lines are skipped.
+func loadRoutes(fs afero.Fs, spa bool) *routes {
+	rt := &routes{spa: spa}
+
+	readLines(fs, RedirectsFile, func(line string) {
+		r, err := parseRule(line)
+		if err != nil {
+			logger.Errorf("skipping redirect: %s", err)
+			return
+		}
+
+		rt.rules = append(rt.rules, r)
+	})
+
+	var current *headers
+	readLines(fs, HeadersFile, func(line string) {
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			current = nil
+			p, err := parsePattern(strings.TrimSpace(line))
+			if err != nil {
+				logger.Errorf("skipping headers: %s", err)
+				return
+			}
+
+			current = &headers{path: p, header: make(goHttp.Header)}
+			rt.headers = append(rt.headers, current)
+			return
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || current == nil {
+			logger.Errorf("skipping header `%s`", strings.TrimSpace(line))
+			return
+		}
+
+		current.header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	})
+
+	if isFile(fs, "/"+NotFoundPage) {
+		rt.notFound = "/" + NotFoundPage
+	}
+
+	return rt
+}
+
+// readLines calls handle with the lines of a file that aren't blank or
+// comments, returning whether the file exists.
+func readLines(fs afero.Fs, name string, handle func(string)) bool {
+	f, err := fs.Open("/" + name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			handle(line)
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		logger.Errorf("reading `%s` failed with: %s", name, err)
+	}
+
+	return true
+}
+
+func isFile(fs afero.Fs, name string) bool {
+	info, err := fs.Stat(name)
+	return err == nil && !info.IsDir()
+}
+
+// exists tells whether a path is served as is: a file, or a folder with an
+// index.
+func (w *Website) exists(name string) bool {
+	if hidden(name) {
+		return false
+	}
+
+	info, err := w.root.Stat(name)
+	if err != nil {
+		return false
+	}
+
+	return !info.IsDir() || isFile(w.root, path.Join(name, "index.html"))
+}
+
+func hidden(name string) bool {
+	switch strings.TrimPrefix(name, "/") {
+	case RedirectsFile, HeadersFile:
+		return true
+	}
+
+	return false
+}
+
+// serve answers a request for a path of the website, applying its routes.
+func (w *Website) serve(rw goHttp.ResponseWriter, r *goHttp.Request) error {
+	rt := w.routes
+	segments := splitPath(r.URL.Path)
+
+	for _, h := range rt.headers {
+		if _, ok := h.path.match(segments); ok {
+			for name, values := range h.header {
+				rw.Header()[name] = values
+			}
+		}
+	}
+
+	exists := w.exists(r.URL.Path)
+	for _, rule := range rt.rules {
+		if exists && !rule.force {
+			continue
+		}
+
+		params, ok := rule.from.match(segments)
+		if !ok {
+			continue
+		}
+
+		target := rule.target(params)
+		switch rule.status {
+		case goHttp.StatusOK:
+			if !w.exists(target) {
+				return w.notFound(rw, r)
+			}
+
+			// The file server redirects index.html to its folder.
+			r.URL.Path = strings.TrimSuffix(target, "index.html")
+			return w.serveAsset(rw, r)
+		case goHttp.StatusNotFound:
+			return w.serveWithStatus(rw, r, target, goHttp.StatusNotFound)
+		default:
+			if r.URL.RawQuery != "" && !strings.Contains(target, "?") {
+				target += "?" + r.URL.RawQuery
+			}
+
+			goHttp.Redirect(rw, r, target, rule.status)
+			return nil
+		}
+	}
+
+	switch {
+	case exists:
+	case rt.spa:
+		r.URL.Path = "/"
+	default:
+		return w.notFound(rw, r)
+	}
+
+	return w.serveAsset(rw, r)
+}
+
+func (w *Website) notFound(rw goHttp.ResponseWriter, r *goHttp.Request) error {
+	if w.routes.notFound == "" {
+		goHttp.NotFound(rw, r)
+		return nil
+	}
+
+	return w.serveWithStatus(rw, r, w.routes.notFound, goHttp.StatusNotFound)
+}
+
+// serveWithStatus serves a file of the website with a status other than 200.
+func (w *Website) serveWithStatus(rw goHttp.ResponseWriter, r *goHttp.Request, name string, status int) error {
+	if !isFile(w.root, name) || hidden(name) {
+		goHttp.NotFound(rw, r)
+		return nil
+	}
+
+	f, err := w.root.Open(name)
+	if err != nil {
+		return fmt.Errorf("opening `%s` failed with: %w", name, err)
+	}
+	defer f.Close()
+
+	rw.Header().Del("Content-Type")
+	if ctype := mime.TypeByExtension(path.Ext(name)); ctype != "" {
+		rw.Header().Set("Content-Type", ctype)
+	}
+
+	rw.WriteHeader(status)
+	if r.Method != goHttp.MethodHead {
+		_, err = io.Copy(rw, f)
+	}
+
+	return err
+}
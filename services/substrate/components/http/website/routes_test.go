@@ -0,0 +1,167 @@
+package website
+
+import (
+	goHttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	commonIface "github.com/taubyte/tau/core/services/substrate/components"
+	http "github.com/taubyte/tau/pkg/http"
+	"github.com/taubyte/tau/pkg/http/basic"
+	"gotest.tools/v3/assert"
+)
+
+type assetsHttp struct {
+	http.Service
+}
+
+func (assetsHttp) LowLevelAssetHandler(def *http.HeadlessAssetsDefinition, w goHttp.ResponseWriter, r *goHttp.Request) error {
+	return new(basic.Service).LowLevelAssetHandler(def, w, r)
+}
+
+type assetsService struct {
+	commonIface.ServiceComponent
+}
+
+func (assetsService) Http() http.Service {
+	return assetsHttp{}
+}
+
+func newRoutesTestWebsite(t *testing.T, spa bool, files map[string]string) *Website {
+	fs := afero.NewMemMapFs()
+	for name, data := range files {
+		assert.NilError(t, afero.WriteFile(fs, name, []byte(data), 0644))
+	}
+
+	return &Website{srv: assetsService{}, root: fs, routes: loadRoutes(fs, spa)}
+}
+
+func get(t *testing.T, w *Website, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	assert.NilError(t, w.serve(rec, httptest.NewRequest("GET", target, nil)))
+	return rec
+}
+
+func TestPattern(t *testing.T) {
+	p, err := parsePattern("/blog/:year/*")
+	assert.NilError(t, err)
+
+	params, ok := p.match(splitPath("/blog/2026/a/b"))
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, params, map[string]string{"year": "2026", "splat": "a/b"})
+
+	_, ok = p.match(splitPath("/blog"))
+	assert.Assert(t, !ok)
+
+	p, err = parsePattern("/*")
+	assert.NilError(t, err)
+	_, ok = p.match(splitPath("/"))
+	assert.Assert(t, ok)
+
+	_, err = parsePattern("blog")
+	assert.ErrorContains(t, err, "should start with")
+}
+
+func TestParseRule(t *testing.T) {
+	r, err := parseRule("/old/:id  https://example.com/new/:id")
+	assert.NilError(t, err)
+	assert.Equal(t, r.status, goHttp.StatusMovedPermanently)
+	assert.Equal(t, r.target(map[string]string{"id": "7"}), "https://example.com/new/7")
+
+	r, err = parseRule("/app/* /app/index.html 200!")
+	assert.NilError(t, err)
+	assert.Equal(t, r.status, goHttp.StatusOK)
+	assert.Assert(t, r.force)
+
+	_, err = parseRule("/a /b 500")
+	assert.ErrorContains(t, err, "unsupported status")
+
+	_, err = parseRule("/a https://example.com 200")
+	assert.ErrorContains(t, err, "only serve a path")
+
+	_, err = parseRule("/a")
+	assert.ErrorContains(t, err, "should be")
+}
+
+func TestServeSinglePageApplication(t *testing.T) {
+	w := newRoutesTestWebsite(t, true, map[string]string{
+		"/index.html":       "index",
+		"/app.js":           "js",
+		"/404.html":         "not found",
+		"/" + RedirectsFile: "/old /new 302",
+	})
+
+	assert.Equal(t, get(t, w, "/app.js").Body.String(), "js")
+
+	rec := get(t, w, "/some/route")
+	assert.Equal(t, rec.Code, goHttp.StatusOK)
+	assert.Equal(t, rec.Body.String(), "index")
+
+	rec = get(t, w, "/old")
+	assert.Equal(t, rec.Code, goHttp.StatusFound)
+	assert.Equal(t, rec.Header().Get("Location"), "/new")
+}
+
+func TestServeRoutes(t *testing.T) {
+	w := newRoutesTestWebsite(t, false, map[string]string{
+		"/index.html":      "index",
+		"/404.html":        "not found",
+		"/docs/index.html": "docs",
+		"/docs/guide.html": "guide",
+		"/" + RedirectsFile: strings.Join([]string{
+			"# comments and invalid rules are skipped",
+			"/broken",
+			"/old/:page /docs/:page.html 302",
+			"/blog/* https://blog.example.com/:splat",
+			"/app/* /index.html 200",
+			"/gone /404.html 404",
+			"/docs/guide.html /docs/ 301!",
+		}, "\n"),
+		"/" + HeadersFile: strings.Join([]string{
+			"/*",
+			"  X-Frame-Options: DENY",
+			"/docs/*",
+			"  Cache-Control: max-age=60",
+		}, "\n"),
+	})
+
+	rec := get(t, w, "/old/guide?ref=home")
+	assert.Equal(t, rec.Code, goHttp.StatusFound)
+	assert.Equal(t, rec.Header().Get("Location"), "/docs/guide.html?ref=home")
+
+	rec = get(t, w, "/blog/2026/post")
+	assert.Equal(t, rec.Code, goHttp.StatusMovedPermanently)
+	assert.Equal(t, rec.Header().Get("Location"), "https://blog.example.com/2026/post")
+
+	rec = get(t, w, "/app/settings")
+	assert.Equal(t, rec.Code, goHttp.StatusOK)
+	assert.Equal(t, rec.Body.String(), "index")
+
+	// Forced rules apply to existing files.
+	rec = get(t, w, "/docs/guide.html")
+	assert.Equal(t, rec.Code, goHttp.StatusMovedPermanently)
+
+	rec = get(t, w, "/docs/")
+	assert.Equal(t, rec.Code, goHttp.StatusOK)
+	assert.Equal(t, rec.Body.String(), "docs")
+	assert.Equal(t, rec.Header().Get("Cache-Control"), "max-age=60")
+	assert.Equal(t, rec.Header().Get("X-Frame-Options"), "DENY")
+
+	for _, target := range []string{"/gone", "/missing", "/" + RedirectsFile} {
+		rec = get(t, w, target)
+		assert.Equal(t, rec.Code, goHttp.StatusNotFound)
+		assert.Equal(t, rec.Body.String(), "not found")
+		assert.Equal(t, rec.Header().Get("Content-Type"), "text/html; charset=utf-8")
+	}
+}
+
+func TestServeNotFoundWithoutPage(t *testing.T) {
+	w := newRoutesTestWebsite(t, false, map[string]string{
+		"/index.html": "index",
+	})
+
+	rec := get(t, w, "/missing")
+	assert.Equal(t, rec.Code, goHttp.StatusNotFound)
+}
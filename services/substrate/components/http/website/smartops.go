@@ -2,6 +2,7 @@ package website
 
 import (
 	"context"
+	"fmt"
 
 	sdkSmartOpsCommon "github.com/taubyte/go-sdk-smartops/common"
 	"github.com/taubyte/tau/core/services/substrate/smartops"
@@ -30,3 +31,22 @@ func (w *Website) SmartOps() (uint32, error) {
 func (w *Website) Application() string {
 	return w.application
 }
+
+// guard runs the website's smartops and refuses the request when one of them
+// exits with a non-zero code.
+func (w *Website) guard() error {
+	if len(w.config.SmartOps) == 0 {
+		return nil
+	}
+
+	val, err := w.SmartOps()
+	if err != nil {
+		return fmt.Errorf("running smartops failed with: %w", err)
+	}
+
+	if val > 0 {
+		return &smartops.BlockedError{Code: val}
+	}
+
+	return nil
+}
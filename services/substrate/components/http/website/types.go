@@ -16,6 +16,9 @@ type Website struct {
 	config        structureSpec.Website
 	computedPaths map[string][]string
 	root          afero.Fs
+	routes        *routes
+	// entries are the files of the build, by path.
+	entries map[string]entry
 
 	matcher     *common.MatchDefinition
 	project     string
@@ -63,6 +63,10 @@ func (w *Website) Handle(_w goHttp.ResponseWriter, r *goHttp.Request, matcher co
 		return t, errors.New("invalid match definition")
 	}
 
+	if err = w.guard(); err != nil {
+		return t, err
+	}
+
 	pathMatch := _matcher.Get(common.PathMatch)
 	_path := path.Clean("/" + strings.TrimPrefix(r.URL.Path, pathMatch))
 	if strings.HasSuffix(r.URL.Path, "/") {
@@ -70,12 +74,16 @@ func (w *Website) Handle(_w goHttp.ResponseWriter, r *goHttp.Request, matcher co
 	}
 
 	r.URL.Path = _path
-	err = w.srv.Http().LowLevelAssetHandler(&http.HeadlessAssetsDefinition{
+	err = w.serve(_w, r)
+	return time.Now(), err
+}
+
+func (w *Website) lowLevelAssetHandler(_w goHttp.ResponseWriter, r *goHttp.Request) error {
+	return w.srv.Http().LowLevelAssetHandler(&http.HeadlessAssetsDefinition{
 		FileSystem:            w.root,
-		SinglePageApplication: true,
+		SinglePageApplication: w.routes.spa,
 		Directory:             "/",
 	}, _w, r)
-	return time.Now(), err
 }
 
 func (w *Website) Validate(matcher components.MatchDefinition) error {
@@ -105,19 +113,28 @@ func (w *Website) getAsset() error {
 		return fmt.Errorf("reading build zip failed with: %w", err)
 	}
 
+	w.load(zipReader)
+	dagReader.Close()
+
+	return nil
+}
+
+// load serves the files of a build zip.
+func (w *Website) load(zipReader *zip.Reader) {
 	computedPaths := make([]string, 0)
+	w.entries = make(map[string]entry)
 
 	for _, file := range zipReader.File {
 		if !file.FileInfo().IsDir() {
 			computedPaths = append(computedPaths, file.Name)
+			w.entries[path.Join("/", file.Name)] = entry{crc: file.CRC32, size: file.UncompressedSize64}
 		}
 	}
 
 	w.computedPaths[w.matcher.Path] = computedPaths
 	w.root = zipfs.New(zipReader)
-	dagReader.Close()
-
-	return nil
+	// Websites published before spa was a setting carry none: they are SPAs.
+	w.routes = loadRoutes(w.root, w.config.SPA == nil || *w.config.SPA)
 }
 
 func (w *Website) Match(matcher components.MatchDefinition) (currentMatchIndex matcherSpec.Index) {
@@ -0,0 +1,120 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+	patrickClient "github.com/taubyte/tau/clients/p2p/patrick"
+	"github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/core/services/substrate"
+)
+
+var logger = log.Logger("tau.substrate.service.logs")
+
+var _ substrate.LogService = &Service{}
+
+// Service batches the logs of function calls and pushes them to patrick over
+// a stream, patrick taking them from substrate nodes only. A batch goes out
+// every FlushInterval, or as soon as its output reaches BatchSize. Logs that
+// fail to push are dropped.
+type Service struct {
+	substrate.Service
+	ctx     context.Context
+	ctxC    context.CancelFunc
+	node    string
+	patrick patrick.Client
+
+	// publish is nil without a node, in which case logs are dropped.
+	publish func([]*patrick.FunctionLog) error
+
+	lock    sync.Mutex
+	pending []*patrick.FunctionLog
+	size    int
+}
+
+func New(srv substrate.Service) (substrate.LogService, error) {
+	s := &Service{
+		Service: srv,
+	}
+
+	s.ctx, s.ctxC = context.WithCancel(srv.Context())
+
+	// Pushed from the node itself, the peer patrick knows as a substrate.
+	if node := srv.Node(); node != nil {
+		var err error
+		if s.patrick, err = patrickClient.New(s.ctx, node); err != nil {
+			s.ctxC()
+			return nil, fmt.Errorf("creating patrick client failed with: %w", err)
+		}
+
+		s.node = node.ID().String()
+		s.publish = s.patrick.PushFunctionLogs
+
+		go s.flushLoop()
+	}
+
+	return s, nil
+}
+
+// Push queues the log of a call.
+func (s *Service) Push(entry *patrick.FunctionLog) {
+	if entry == nil || s.publish == nil {
+		return
+	}
+
+	entry.Node = s.node
+
+	s.lock.Lock()
+	s.pending = append(s.pending, entry)
+	s.size += len(entry.Stdout) + len(entry.Stderr) + len(entry.Error)
+	full := s.size >= BatchSize
+	s.lock.Unlock()
+
+	if full {
+		go s.flush()
+	}
+}
+
+func (s *Service) flushLoop() {
+	ticker := time.NewTicker(FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.flush()
+		}
+	}
+}
+
+// flush publishes the pending logs as one batch.
+func (s *Service) flush() {
+	s.lock.Lock()
+	batch := s.pending
+	s.pending, s.size = nil, 0
+	s.lock.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+
+	if err := s.publish(batch); err != nil && s.ctx.Err() == nil {
+		logger.Errorf("pushing %d logs failed with: %s", len(batch), err.Error())
+	}
+}
+
+func (s *Service) Context() context.Context { return s.ctx }
+
+func (s *Service) Close() error {
+	s.flush()
+	s.ctxC()
+	if s.patrick != nil {
+		s.patrick.Close()
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package logs
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/services/patrick"
+	"gotest.tools/v3/assert"
+)
+
+type published struct {
+	lock    sync.Mutex
+	batches [][]*patrick.FunctionLog
+}
+
+func (p *published) publish(batch []*patrick.FunctionLog) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.batches = append(p.batches, batch)
+
+	return nil
+}
+
+func (p *published) count() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.batches)
+}
+
+func newTestService(p *published) *Service {
+	s := &Service{node: "node", publish: p.publish}
+	s.ctx, s.ctxC = context.WithCancel(context.Background())
+	return s
+}
+
+func TestFlush(t *testing.T) {
+	p := new(published)
+	s := newTestService(p)
+	defer s.ctxC()
+
+	s.flush()
+	assert.Equal(t, p.count(), 0)
+
+	s.Push(&patrick.FunctionLog{ProjectId: "project", FunctionId: "a", Stdout: "hello"})
+	s.Push(&patrick.FunctionLog{ProjectId: "project", FunctionId: "b", Error: "trapped"})
+	s.Push(nil)
+	s.flush()
+
+	assert.Equal(t, p.count(), 1)
+	batch := p.batches[0]
+	assert.Equal(t, len(batch), 2)
+	assert.Equal(t, batch[0].Stdout, "hello")
+	assert.Equal(t, batch[0].Node, "node")
+	assert.Equal(t, batch[1].Error, "trapped")
+
+	s.flush()
+	assert.Equal(t, p.count(), 1)
+}
+
+func TestFlushWhenFull(t *testing.T) {
+	size := BatchSize
+	BatchSize = 16
+	defer func() { BatchSize = size }()
+
+	p := new(published)
+	s := newTestService(p)
+	defer s.ctxC()
+
+	s.Push(&patrick.FunctionLog{Stdout: "short"})
+	assert.Equal(t, p.count(), 0)
+
+	s.Push(&patrick.FunctionLog{Stdout: strings.Repeat("x", 16)})
+	for deadline := time.Now().Add(time.Second); p.count() == 0 && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.Equal(t, p.count(), 1)
+	assert.Equal(t, len(p.batches[0]), 2)
+}
+
+func TestDroppedWithoutNode(t *testing.T) {
+	s := &Service{}
+	s.Push(&patrick.FunctionLog{Stdout: "dropped"})
+	assert.Equal(t, len(s.pending), 0)
+}
@@ -0,0 +1,14 @@
+package logs
+
+import "time"
+
+// Tunables — exported so tests can shrink them.
+var (
+	// FlushInterval is how often pending logs are published.
+	FlushInterval = 2 * time.Second
+
+	// BatchSize is the output, in bytes, that gets a batch published without
+	// waiting for FlushInterval. It keeps batches well under the pubsub
+	// message limit.
+	BatchSize = 256 << 10
+)
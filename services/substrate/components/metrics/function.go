@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"io"
 )
 
 func (m *Function) Less(comp Iface) bool {
@@ -23,6 +24,7 @@ func (m *Function) Encode() []byte {
 	binary.Write(&buf, binary.LittleEndian, m.ColdStart)
 	binary.Write(&buf, binary.LittleEndian, m.Memory)
 	binary.Write(&buf, binary.LittleEndian, m.AvgRunTime)
+	binary.Write(&buf, binary.LittleEndian, m.Rejected)
 	return buf.Bytes()
 }
 
@@ -55,5 +57,10 @@ func (m *Function) Decode(b []byte) error {
 		return err
 	}
 
+	// Nodes predating it don't send Rejected.
+	if err := binary.Read(buf, binary.LittleEndian, &m.Rejected); err != nil && err != io.EOF {
+		return err
+	}
+
 	return nil
 }
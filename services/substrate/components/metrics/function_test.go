@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFunctionEncoding(t *testing.T) {
+	m := &Function{Cached: 1, ColdStart: 20, Memory: 0.5, AvgRunTime: 300, Rejected: 4}
+
+	decoded := new(Function)
+	assert.NilError(t, decoded.Decode(m.Encode()))
+	assert.Equal(t, *decoded, *m)
+
+	// Metrics of nodes predating Rejected still decode.
+	data := m.Encode()
+	decoded = new(Function)
+	assert.NilError(t, decoded.Decode(data[:len(data)-8]))
+	assert.Equal(t, decoded.AvgRunTime, int64(300))
+	assert.Equal(t, decoded.Rejected, uint64(0))
+}
@@ -15,6 +15,7 @@ type Function struct {
 	ColdStart  int64
 	Memory     float64
 	AvgRunTime int64
+	Rejected   uint64
 }
 
 type Iface interface {
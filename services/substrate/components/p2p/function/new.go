@@ -35,7 +35,7 @@ func New(srv iface.Service, config structureSpec.Function, commit, branch string
 	}
 
 	if f.config.Source == "." {
-		f.assetId, err = cache.ResolveAssetCid(f)
+		f.assetId, err = cache.ResolveAssetCid(srv.Context(), f)
 		if err != nil {
 			return nil, fmt.Errorf("getting asset id failed with: %w", err)
 		}
@@ -1,8 +1,30 @@
 package common
 
-import "github.com/ipfs/go-log/v2"
+import (
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+)
 
 var (
 	WebSocketHttpPath = "/ws-{hash}/{channel:.+}"
 	Logger            = log.Logger("tau.substrate.service.pubsub")
+
+	// LogSweepInterval is how often the logs of persistent channels are
+	// trimmed to their retention.
+	LogSweepInterval = time.Minute
+	// ConsumeInterval is how often consumers of persistent channels poll
+	// their log when no message wakes them up.
+	ConsumeInterval = 10 * time.Second
+	// RetryBackoff is the delay before the first retry of a failed delivery,
+	// doubling with every retry.
+	RetryBackoff = time.Second
+	// ConsumeLease is how long a node delivers a persistent channel to a
+	// function before another node may take over.
+	ConsumeLease = time.Minute
+	// ReadBatch is how many logged messages a consumer reads at once.
+	ReadBatch = 64
 )
+
+// MQTTSourcePrefix marks the source of messages published by MQTT clients.
+const MQTTSourcePrefix = "mqtt:"
@@ -7,6 +7,7 @@ import (
 
 	"github.com/taubyte/tau/core/services/substrate/components"
 	iface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	"github.com/taubyte/tau/core/services/substrate/smartops"
 
 	matcherSpec "github.com/taubyte/tau/pkg/specs/matcher"
 	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
@@ -35,6 +36,14 @@ func (f *Function) HandleMessage(msg iface.Message) (t time.Time, err error) {
 	// Pass the interface message directly to the SDK
 	ev := instance.SDK().CreatePubsubEvent(msg)
 
+	val, err := f.SmartOps(ev)
+	if err != nil {
+		return t, fmt.Errorf("running smartops failed with: %w", err)
+	}
+	if val > 0 {
+		return t, &smartops.BlockedError{Code: val}
+	}
+
 	return time.Now(), f.Call(instance, ev.Id)
 }
 
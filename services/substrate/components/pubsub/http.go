@@ -13,6 +13,9 @@ func (s *Service) attach() {
 			Required: []string{
 				"hash", "channel",
 			},
+			Optional: []string{
+				"offset",
+			},
 		},
 		NewHandler: func(ctx service.Context, conn service.WebSocketConnection) service.WebSocketHandler {
 			return _websocket.Handler(s, ctx, conn)
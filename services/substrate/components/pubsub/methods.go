@@ -7,6 +7,10 @@ import (
 )
 
 func (s *Service) Close() error {
+	if s.mqtt != nil {
+		s.mqtt.Close()
+	}
+	s.stopConsumers()
 	s.cache.Close()
 	return nil
 }
@@ -0,0 +1,52 @@
+package pubsub
+
+import (
+	"fmt"
+	"time"
+
+	iface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/common"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/mqtt"
+)
+
+var _ mqtt.Service = &Service{}
+
+func (s *Service) AuthorizeMQTT(matcher *common.MatchDefinition) error {
+	messagingsMap, _, _, err := s.getMessagingsMap(matcher)
+	if err != nil {
+		return fmt.Errorf("getting messaging channels failed with: %w", err)
+	}
+
+	if messagingsMap.MQTT.Len() == 0 {
+		return fmt.Errorf("no messaging channel bridged to mqtt matches `%s`", matcher.Channel)
+	}
+
+	return nil
+}
+
+// Dispatch runs the functions of an MQTT publish on the node that accepted
+// it, instead of on every node subscribed to the channel. Publishes on
+// persistent channels are logged and delivered from the log instead.
+func (s *Service) Dispatch(matcher *common.MatchDefinition, msg iface.Message) {
+	start := time.Now()
+
+	if l, err := s.log(matcher); err != nil {
+		common.Logger.Errorf("opening log of `%s` failed with: %s", matcher.Channel, err)
+	} else if l != nil {
+		if _, err = l.Append(s.Context(), msg.GetSource(), msg.GetData()); err != nil {
+			common.Logger.Errorf("logging mqtt publish on `%s` failed with: %s", matcher.Channel, err)
+			return
+		}
+
+		wake(s.consume(matcher))
+		return
+	}
+
+	picks, err := s.Lookup(matcher)
+	if err != nil || len(picks) == 0 {
+		// a channel without functions
+		return
+	}
+
+	s.run(start, picks, msg)
+}
@@ -0,0 +1,135 @@
+package mqtt
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Listen starts a broker on addr.
+func Listen(srv Service, addr string, credentials Credentials) (*Broker, error) {
+	if credentials == nil {
+		return nil, errors.New("a credentials lookup is required")
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listening on `%s` failed with: %w", addr, err)
+	}
+
+	return New(srv, listener, credentials), nil
+}
+
+// New starts a broker accepting clients on listener. Clients authenticate
+// with a key pair auth issued for their project, see session.handshake.
+func New(srv Service, listener net.Listener, credentials Credentials) *Broker {
+	b := &Broker{
+		srv:         srv,
+		listener:    listener,
+		credentials: credentials,
+		sessions:    make(map[string]*session),
+	}
+
+	b.ctx, b.ctxC = context.WithCancel(srv.Context())
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.accept()
+	}()
+
+	go func() {
+		<-b.ctx.Done()
+		b.listener.Close()
+	}()
+
+	return b
+}
+
+// Addr is the address the broker listens on.
+func (b *Broker) Addr() net.Addr {
+	return b.listener.Addr()
+}
+
+// Close stops accepting clients and disconnects the connected ones.
+func (b *Broker) Close() error {
+	b.ctxC()
+	err := b.listener.Close()
+
+	b.sessionsLock.Lock()
+	for _, s := range b.sessions {
+		s.close()
+	}
+	b.sessionsLock.Unlock()
+
+	b.wg.Wait()
+
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func (b *Broker) accept() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			if b.ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				logger.Errorf("accepting connection failed with: %s", err.Error())
+			}
+			return
+		}
+
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			newSession(b, conn).serve()
+		}()
+	}
+}
+
+// authenticate checks password is `<access key>:<secret key>` of a key pair
+// auth issued for project.
+func (b *Broker) authenticate(project string, password []byte) error {
+	accessKey, secret, ok := strings.Cut(string(password), ":")
+	if !ok || len(accessKey) == 0 {
+		return errors.New("password is not an access key and secret")
+	}
+
+	keyProject, keySecret, err := b.credentials(accessKey)
+	if err != nil {
+		return fmt.Errorf("looking up access key `%s` failed with: %w", accessKey, err)
+	}
+
+	if keyProject != project || subtle.ConstantTimeCompare([]byte(keySecret), []byte(secret)) != 1 {
+		return fmt.Errorf("access key `%s` is not valid for project `%s`", accessKey, project)
+	}
+
+	return nil
+}
+
+// register makes s the session of its client id, taking over the previous
+// connection of that client as the spec requires.
+func (b *Broker) register(s *session) {
+	b.sessionsLock.Lock()
+	previous := b.sessions[s.clientId]
+	b.sessions[s.clientId] = s
+	b.sessionsLock.Unlock()
+
+	if previous != nil {
+		previous.close()
+	}
+}
+
+func (b *Broker) unregister(s *session) {
+	b.sessionsLock.Lock()
+	defer b.sessionsLock.Unlock()
+
+	if b.sessions[s.clientId] == s {
+		delete(b.sessions, s.clientId)
+	}
+}
@@ -0,0 +1,308 @@
+package mqtt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	"github.com/taubyte/tau/p2p/peer"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/common"
+	"gotest.tools/v3/assert"
+)
+
+type fakeService struct {
+	pubsubIface.ServiceWithLookup
+	ctx     context.Context
+	node    peer.Node
+	project string
+
+	lock       sync.Mutex
+	dispatched []string
+}
+
+func (f *fakeService) Context() context.Context { return f.ctx }
+func (f *fakeService) Node() peer.Node          { return f.node }
+
+func (f *fakeService) AuthorizeMQTT(matcher *common.MatchDefinition) error {
+	if matcher.Channel != "sensors" {
+		return errors.New("not bridged")
+	}
+	return nil
+}
+
+func (f *fakeService) Dispatch(matcher *common.MatchDefinition, msg pubsubIface.Message) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.dispatched = append(f.dispatched, matcher.Channel+":"+string(msg.GetData()))
+}
+
+func (f *fakeService) dispatchedCount() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.dispatched)
+}
+
+// newTestBroker starts a broker on a mock node. Each broker gets its own
+// project: the websocket subscription registry is process-wide.
+func newTestBroker(t *testing.T) (*Broker, *fakeService) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+
+	srv := &fakeService{
+		ctx:     t.Context(),
+		node:    peer.Mock(t.Context()),
+		project: fmt.Sprintf("%s-%d", t.Name(), time.Now().UnixNano()),
+	}
+	b := New(srv, listener, func(accessKey string) (string, string, error) {
+		if accessKey != "key" {
+			return "", "", errors.New("not found")
+		}
+		return srv.project, "secret", nil
+	})
+	t.Cleanup(func() { b.Close() })
+
+	return b, srv
+}
+
+type testClient struct {
+	t       *testing.T
+	version byte
+	conn    net.Conn
+	reader  *bufio.Reader
+}
+
+// dial connects with the key pair of the test project.
+func dial(t *testing.T, b *Broker, version byte, username string) (*testClient, byte) {
+	return dialWithPassword(t, b, version, username, "key:secret")
+}
+
+func dialWithPassword(t *testing.T, b *Broker, version byte, username, password string) (*testClient, byte) {
+	conn, err := net.Dial("tcp", b.Addr().String())
+	assert.NilError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	c := &testClient{t: t, version: version, conn: conn, reader: bufio.NewReader(conn)}
+
+	var body bytes.Buffer
+	writeString(&body, "MQTT")
+	body.WriteByte(version)
+	body.WriteByte(0x02 | 0x80 | 0x40) // clean session, username, password
+	binary.Write(&body, binary.BigEndian, uint16(30))
+	if version == version5 {
+		writeVarint(&body, 0)
+	}
+	writeString(&body, conn.LocalAddr().String())
+	writeString(&body, username)
+	writeString(&body, password)
+	c.send(typeConnect, 0, body.Bytes())
+
+	p := c.expect(typeConnack)
+	return c, p.body[1]
+}
+
+func (c *testClient) send(kind, flags byte, body []byte) {
+	_, err := c.conn.Write((&packet{kind: kind, flags: flags, body: body}).encode())
+	assert.NilError(c.t, err)
+}
+
+func (c *testClient) expect(kind byte) *packet {
+	c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	p, err := readPacket(c.reader, MaxPacketSize)
+	assert.NilError(c.t, err)
+	assert.Equal(c.t, p.kind, kind)
+	return p
+}
+
+func (c *testClient) subscribe(packetId uint16, filter string) byte {
+	var body bytes.Buffer
+	binary.Write(&body, binary.BigEndian, packetId)
+	if c.version == version5 {
+		writeVarint(&body, 0)
+	}
+	writeString(&body, filter)
+	body.WriteByte(0)
+	c.send(typeSubscribe, 0x02, body.Bytes())
+
+	p := c.expect(typeSuback)
+	return p.body[len(p.body)-1]
+}
+
+func (c *testClient) publish(topic string, qos byte, packetId uint16, payload string) {
+	var body bytes.Buffer
+	writeString(&body, topic)
+	if qos > 0 {
+		binary.Write(&body, binary.BigEndian, packetId)
+	}
+	if c.version == version5 {
+		writeVarint(&body, 0)
+	}
+	body.WriteString(payload)
+	c.send(typePublish, qos<<1, body.Bytes())
+}
+
+func TestConnect(t *testing.T) {
+	b, srv := newTestBroker(t)
+
+	_, code := dial(t, b, version311, srv.project)
+	assert.Equal(t, code, codeSuccess)
+
+	_, code = dial(t, b, version5, srv.project+"/app")
+	assert.Equal(t, code, codeSuccess)
+
+	_, code = dial(t, b, version311, "")
+	assert.Equal(t, code, codeV3BadCredentials)
+
+	_, code = dial(t, b, version5, "")
+	assert.Equal(t, code, codeBadCredentials)
+
+	for _, password := range []string{"", "key", "key:other", "other:secret"} {
+		_, code = dialWithPassword(t, b, version5, srv.project, password)
+		assert.Equal(t, code, codeBadCredentials, password)
+	}
+
+	// The key pair is only good for the project it was issued for.
+	_, code = dial(t, b, version311, "other-project")
+	assert.Equal(t, code, codeV3BadCredentials)
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	b, srv := newTestBroker(t)
+
+	for _, version := range []byte{version311, version5} {
+		sub, _ := dial(t, b, version, srv.project)
+		assert.Equal(t, sub.subscribe(1, "sensors"), codeSuccess)
+
+		pub, _ := dial(t, b, version, srv.project)
+		pub.publish("sensors", 1, 7, "21.5")
+
+		ack := pub.expect(typePuback)
+		assert.Equal(t, binary.BigEndian.Uint16(ack.body), uint16(7))
+
+		p := sub.expect(typePublish)
+		got, err := decodePublish(version, p.flags, p.body)
+		assert.NilError(t, err)
+		assert.Equal(t, got.topic, "sensors")
+		assert.Equal(t, string(got.payload), "21.5")
+	}
+
+	assert.Assert(t, srv.dispatchedCount() >= 1)
+}
+
+func TestSubscribeRefusals(t *testing.T) {
+	b, srv := newTestBroker(t)
+
+	c, _ := dial(t, b, version5, srv.project)
+	assert.Equal(t, c.subscribe(1, "sensors/+"), codeWildcardUnsupported)
+	assert.Equal(t, c.subscribe(2, "$share/group/sensors"), codeSharedSubUnsupported)
+	assert.Equal(t, c.subscribe(3, "other"), codeNotAuthorized)
+
+	v3, _ := dial(t, b, version311, srv.project)
+	assert.Equal(t, v3.subscribe(1, "#"), codeV3SubscribeFailure)
+}
+
+func TestUnauthorizedPublish(t *testing.T) {
+	b, srv := newTestBroker(t)
+
+	c, _ := dial(t, b, version5, srv.project)
+	c.publish("other", 1, 3, "x")
+	ack := c.expect(typePuback)
+	assert.Equal(t, ack.body[2], codeNotAuthorized)
+
+	// MQTT 3.1.1 can only refuse by closing the connection.
+	v3, _ := dial(t, b, version311, srv.project)
+	v3.publish("other", 0, 0, "x")
+	v3.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err := readPacket(v3.reader, MaxPacketSize)
+	assert.Assert(t, err != nil)
+
+	assert.Equal(t, srv.dispatchedCount(), 0)
+}
+
+func TestQoS2(t *testing.T) {
+	b, srv := newTestBroker(t)
+
+	c, _ := dial(t, b, version311, srv.project)
+	c.publish("sensors", 2, 9, "once")
+	c.expect(typePubrec)
+
+	// A resend before PUBREL is acknowledged without a second delivery.
+	c.publish("sensors", 2, 9, "once")
+	c.expect(typePubrec)
+
+	var body bytes.Buffer
+	binary.Write(&body, binary.BigEndian, uint16(9))
+	c.send(typePubrel, 0x02, body.Bytes())
+	c.expect(typePubcomp)
+
+	c.send(typePingreq, 0, nil)
+	c.expect(typePingresp)
+
+	assert.Equal(t, srv.dispatchedCount(), 1)
+}
+
+func TestQoS2PendingBound(t *testing.T) {
+	// Restored after the broker closed, as its sessions read the limit.
+	maxPendingRel := MaxPendingRel
+	t.Cleanup(func() { MaxPendingRel = maxPendingRel })
+	MaxPendingRel = 2
+
+	b, srv := newTestBroker(t)
+
+	c, _ := dial(t, b, version5, srv.project)
+	for id := uint16(1); id <= 2; id++ {
+		c.publish("sensors", 2, id, "x")
+		c.expect(typePubrec)
+	}
+
+	// A third publish left unreleased is one too many.
+	c.publish("sensors", 2, 3, "x")
+	c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err := readPacket(c.reader, MaxPacketSize)
+	assert.Assert(t, err != nil)
+
+	assert.Equal(t, srv.dispatchedCount(), 2)
+}
+
+func TestVarint(t *testing.T) {
+	for _, value := range []int{0, 127, 128, 16383, 16384, 268435455} {
+		var buf bytes.Buffer
+		writeVarint(&buf, value)
+
+		got, err := readVarint(&buf)
+		assert.NilError(t, err)
+		assert.Equal(t, got, value)
+	}
+
+	_, err := readVarint(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0x01}))
+	assert.ErrorIs(t, err, errMalformed)
+}
+
+func TestDecodeConnectWill(t *testing.T) {
+	var body bytes.Buffer
+	writeString(&body, "MQTT")
+	body.WriteByte(version5)
+	body.WriteByte(0x02 | 0x04 | 0x80) // clean, will, username
+	binary.Write(&body, binary.BigEndian, uint16(0))
+	writeVarint(&body, 5)
+	body.Write([]byte{0x11, 0, 0, 0, 60}) // session expiry interval, skipped
+	writeString(&body, "id")
+	writeVarint(&body, 0) // will properties
+	writeString(&body, "status")
+	writeString(&body, "offline")
+	writeString(&body, "project")
+
+	c, err := decodeConnect(body.Bytes())
+	assert.NilError(t, err)
+	assert.Equal(t, c.clientId, "id")
+	assert.Equal(t, c.username, "project")
+	assert.Equal(t, c.will.topic, "status")
+	assert.Equal(t, string(c.will.payload), "offline")
+}
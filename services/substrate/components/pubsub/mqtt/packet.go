@@ -0,0 +1,409 @@
+package mqtt
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Control packet types, MQTT 3.1.1 §2.2.1 / MQTT 5 §2.1.2.
+const (
+	typeConnect     byte = 1
+	typeConnack     byte = 2
+	typePublish     byte = 3
+	typePuback      byte = 4
+	typePubrec      byte = 5
+	typePubrel      byte = 6
+	typePubcomp     byte = 7
+	typeSubscribe   byte = 8
+	typeSuback      byte = 9
+	typeUnsubscribe byte = 10
+	typeUnsuback    byte = 11
+	typePingreq     byte = 12
+	typePingresp    byte = 13
+	typeDisconnect  byte = 14
+)
+
+// Protocol levels carried in CONNECT.
+const (
+	version311 byte = 4
+	version5   byte = 5
+)
+
+// Reason codes, MQTT 3.1.1 return codes first.
+const (
+	codeSuccess byte = 0x00
+
+	codeV3UnacceptableVersion byte = 0x01
+	codeV3IdentifierRejected  byte = 0x02
+	codeV3BadCredentials      byte = 0x04
+	codeV3SubscribeFailure    byte = 0x80
+
+	codeNoSubscriptionExisted byte = 0x11
+	codeUnspecifiedError      byte = 0x80
+	codeBadCredentials        byte = 0x86
+	codeNotAuthorized         byte = 0x87
+	codeTopicFilterInvalid    byte = 0x8F
+	codeSharedSubUnsupported  byte = 0x9E
+	codeWildcardUnsupported   byte = 0xA2
+)
+
+// MQTT 5 property identifiers the broker writes.
+const (
+	propMaximumQoS         byte = 0x24
+	propRetainAvailable    byte = 0x25
+	propWildcardAvailable  byte = 0x28
+	propSharedSubAvailable byte = 0x2A
+)
+
+var (
+	errMalformed      = errors.New("malformed packet")
+	errPacketTooLarge = errors.New("packet too large")
+)
+
+// packet is a control packet split into its fixed header and the rest.
+type packet struct {
+	kind  byte
+	flags byte
+	body  []byte
+}
+
+// readPacket reads one control packet, refusing bodies over maxSize.
+func readPacket(r *bufio.Reader, maxSize int) (*packet, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := readVarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxSize {
+		return nil, errPacketTooLarge
+	}
+
+	p := &packet{kind: header >> 4, flags: header & 0x0f, body: make([]byte, size)}
+	if _, err = io.ReadFull(r, p.body); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *packet) encode() []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(p.kind<<4 | p.flags)
+	writeVarint(&buf, len(p.body))
+	buf.Write(p.body)
+	return buf.Bytes()
+}
+
+// readVarint reads a Variable Byte Integer, at most four bytes long.
+func readVarint(r io.ByteReader) (int, error) {
+	var value, shift int
+	for i := 0; i < 4; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		value |= int(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return value, nil
+		}
+		shift += 7
+	}
+
+	return 0, errMalformed
+}
+
+func writeVarint(buf *bytes.Buffer, value int) {
+	for {
+		b := byte(value & 0x7f)
+		value >>= 7
+		if value > 0 {
+			b |= 0x80
+		}
+		buf.WriteByte(b)
+		if value == 0 {
+			return
+		}
+	}
+}
+
+// decoder walks a packet body.
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) byte() byte {
+	if d.err != nil || len(d.data) < 1 {
+		d.err = errMalformed
+		return 0
+	}
+
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b
+}
+
+func (d *decoder) uint16() uint16 {
+	if d.err != nil || len(d.data) < 2 {
+		d.err = errMalformed
+		return 0
+	}
+
+	v := binary.BigEndian.Uint16(d.data)
+	d.data = d.data[2:]
+	return v
+}
+
+func (d *decoder) bytes() []byte {
+	n := int(d.uint16())
+	if d.err != nil || len(d.data) < n {
+		d.err = errMalformed
+		return nil
+	}
+
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *decoder) string() string {
+	return string(d.bytes())
+}
+
+// properties skips an MQTT 5 property block; the broker acts on none of the
+// properties clients send.
+func (d *decoder) properties() {
+	if d.err != nil {
+		return
+	}
+
+	r := bytes.NewReader(d.data)
+	n, err := readVarint(r)
+	if err != nil {
+		d.err = errMalformed
+		return
+	}
+
+	rest := d.data[len(d.data)-r.Len():]
+	if len(rest) < n {
+		d.err = errMalformed
+		return
+	}
+	d.data = rest[n:]
+}
+
+func (d *decoder) rest() []byte {
+	b := d.data
+	d.data = nil
+	return b
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	binary.Write(buf, binary.BigEndian, uint16(len(s)))
+	buf.WriteString(s)
+}
+
+// connect holds the CONNECT fields the broker uses.
+type connect struct {
+	version   byte
+	clientId  string
+	username  string
+	password  []byte
+	keepAlive uint16
+	clean     bool
+
+	will *publish
+}
+
+func decodeConnect(body []byte) (*connect, error) {
+	d := &decoder{data: body}
+
+	if name := d.string(); d.err == nil && name != "MQTT" {
+		return nil, fmt.Errorf("unsupported protocol name `%s`", name)
+	}
+
+	c := &connect{version: d.byte()}
+	flags := d.byte()
+	c.keepAlive = d.uint16()
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	if c.version != version311 && c.version != version5 {
+		return c, fmt.Errorf("unsupported protocol level %d", c.version)
+	}
+
+	if flags&0x01 != 0 {
+		return nil, errMalformed
+	}
+	c.clean = flags&0x02 != 0
+
+	if c.version == version5 {
+		d.properties()
+	}
+
+	c.clientId = d.string()
+
+	if flags&0x04 != 0 {
+		c.will = &publish{qos: (flags >> 3) & 0x03}
+		if c.version == version5 {
+			d.properties()
+		}
+		c.will.topic = d.string()
+		c.will.payload = d.bytes()
+	}
+
+	if flags&0x80 != 0 {
+		c.username = d.string()
+	}
+
+	if flags&0x40 != 0 {
+		c.password = d.bytes()
+	}
+
+	return c, d.err
+}
+
+func encodeConnack(version byte, sessionPresent bool, code byte) []byte {
+	var body bytes.Buffer
+	if sessionPresent {
+		body.WriteByte(1)
+	} else {
+		body.WriteByte(0)
+	}
+	body.WriteByte(code)
+
+	if version == version5 {
+		// Advertise what the broker leaves out so clients don't try it.
+		props := []byte{
+			propMaximumQoS, 1,
+			propRetainAvailable, 0,
+			propWildcardAvailable, 0,
+			propSharedSubAvailable, 0,
+		}
+		writeVarint(&body, len(props))
+		body.Write(props)
+	}
+
+	return (&packet{kind: typeConnack, body: body.Bytes()}).encode()
+}
+
+type publish struct {
+	topic    string
+	payload  []byte
+	qos      byte
+	packetId uint16
+}
+
+func decodePublish(version, flags byte, body []byte) (*publish, error) {
+	d := &decoder{data: body}
+
+	p := &publish{qos: (flags >> 1) & 0x03, topic: d.string()}
+	if p.qos == 3 {
+		return nil, errMalformed
+	}
+	if p.qos > 0 {
+		p.packetId = d.uint16()
+	}
+	if version == version5 {
+		d.properties()
+	}
+	p.payload = d.rest()
+
+	return p, d.err
+}
+
+func encodePublish(version byte, topic string, payload []byte) []byte {
+	var body bytes.Buffer
+	writeString(&body, topic)
+	if version == version5 {
+		writeVarint(&body, 0)
+	}
+	body.Write(payload)
+
+	return (&packet{kind: typePublish, body: body.Bytes()}).encode()
+}
+
+// encodeAck builds PUBACK, PUBREC, PUBREL and PUBCOMP: a packet id, plus a
+// reason code under MQTT 5 when it isn't success.
+func encodeAck(version, kind, flags byte, packetId uint16, code byte) []byte {
+	var body bytes.Buffer
+	binary.Write(&body, binary.BigEndian, packetId)
+	if version == version5 && code != 0 {
+		body.WriteByte(code)
+	}
+
+	return (&packet{kind: kind, flags: flags, body: body.Bytes()}).encode()
+}
+
+type subscription struct {
+	filter  string
+	noLocal bool
+}
+
+func decodeSubscribe(version byte, body []byte) (uint16, []subscription, error) {
+	d := &decoder{data: body}
+
+	packetId := d.uint16()
+	if version == version5 {
+		d.properties()
+	}
+
+	var subs []subscription
+	for d.err == nil && len(d.data) > 0 {
+		filter := d.string()
+		options := d.byte()
+		subs = append(subs, subscription{
+			filter:  filter,
+			noLocal: version == version5 && options&0x04 != 0,
+		})
+	}
+	if d.err == nil && len(subs) == 0 {
+		d.err = errMalformed
+	}
+
+	return packetId, subs, d.err
+}
+
+func decodeUnsubscribe(version byte, body []byte) (uint16, []string, error) {
+	d := &decoder{data: body}
+
+	packetId := d.uint16()
+	if version == version5 {
+		d.properties()
+	}
+
+	var filters []string
+	for d.err == nil && len(d.data) > 0 {
+		filters = append(filters, d.string())
+	}
+	if d.err == nil && len(filters) == 0 {
+		d.err = errMalformed
+	}
+
+	return packetId, filters, d.err
+}
+
+// encodeSubAck builds SUBACK and UNSUBACK; MQTT 3.1.1 UNSUBACK carries no
+// codes.
+func encodeSubAck(version, kind byte, packetId uint16, codes []byte) []byte {
+	var body bytes.Buffer
+	binary.Write(&body, binary.BigEndian, packetId)
+	if version == version5 {
+		writeVarint(&body, 0)
+	}
+	if version == version5 || kind == typeSuback {
+		body.Write(codes)
+	}
+
+	return (&packet{kind: kind, body: body.Bytes()}).encode()
+}
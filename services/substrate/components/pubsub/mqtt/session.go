@@ -0,0 +1,465 @@
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/common"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/websocket"
+	"github.com/taubyte/tau/utils/id"
+)
+
+// session is one client connection. Clients pick their project with the
+// CONNECT username, `<project>` or `<project>/<application>`, authenticate
+// with a key pair auth issued for it as password, `<access key>:<secret>`,
+// and address messaging channels by topic.
+type session struct {
+	broker *Broker
+	conn   net.Conn
+	reader *bufio.Reader
+
+	ctx       context.Context
+	ctxC      context.CancelFunc
+	closeOnce sync.Once
+
+	// id is the source of the messages this session publishes.
+	id string
+
+	version     byte
+	clientId    string
+	project     string
+	application string
+	keepAlive   time.Duration
+	will        *publish
+
+	// channels caches the matchers this session was authorized for, for the
+	// life of the connection.
+	channels map[string]*common.MatchDefinition
+	// pendingRel holds QoS 2 packet ids delivered but not yet released, at
+	// most MaxPendingRel.
+	pendingRel map[uint16]struct{}
+
+	subsLock sync.Mutex
+	subs     map[string]*sessionSub
+
+	writeLock sync.Mutex
+	out       chan []byte
+}
+
+type sessionSub struct {
+	topic string
+	id    int
+}
+
+func newSession(b *Broker, conn net.Conn) *session {
+	s := &session{
+		broker:     b,
+		conn:       conn,
+		reader:     bufio.NewReader(conn),
+		channels:   make(map[string]*common.MatchDefinition),
+		pendingRel: make(map[uint16]struct{}),
+		subs:       make(map[string]*sessionSub),
+		out:        make(chan []byte, OutboundQueue),
+	}
+
+	s.ctx, s.ctxC = context.WithCancel(b.ctx)
+	s.id = common.MQTTSourcePrefix + id.Generate(conn.RemoteAddr())
+
+	return s
+}
+
+func (s *session) close() {
+	s.closeOnce.Do(func() {
+		s.ctxC()
+		s.conn.Close()
+	})
+}
+
+func (s *session) serve() {
+	defer s.close()
+
+	if err := s.handshake(); err != nil {
+		logger.Debugf("mqtt handshake from %s failed with: %s", s.conn.RemoteAddr(), err.Error())
+		return
+	}
+
+	s.broker.register(s)
+	defer s.broker.unregister(s)
+
+	go s.writeLoop()
+
+	graceful, err := s.readLoop()
+	s.unsubscribeAll()
+
+	if !graceful {
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+			logger.Debugf("mqtt session `%s` ended with: %s", s.clientId, err.Error())
+		}
+		s.publishWill()
+	}
+}
+
+// handshake reads CONNECT and answers with CONNACK.
+func (s *session) handshake() error {
+	s.conn.SetReadDeadline(time.Now().Add(ConnectTimeout))
+
+	p, err := readPacket(s.reader, MaxPacketSize)
+	if err != nil {
+		return err
+	}
+	if p.kind != typeConnect {
+		return fmt.Errorf("expected CONNECT got packet type %d", p.kind)
+	}
+
+	c, err := decodeConnect(p.body)
+	if err != nil {
+		if c != nil {
+			s.version = version311
+			s.write(encodeConnack(version311, false, codeV3UnacceptableVersion))
+		}
+		return err
+	}
+	s.version = c.version
+
+	project, application, _ := strings.Cut(c.username, "/")
+	if len(project) == 0 {
+		s.write(encodeConnack(s.version, false, s.code(codeV3BadCredentials, codeBadCredentials)))
+		return errors.New("no project in username")
+	}
+
+	if err := s.broker.authenticate(project, c.password); err != nil {
+		s.write(encodeConnack(s.version, false, s.code(codeV3BadCredentials, codeBadCredentials)))
+		return err
+	}
+
+	if len(c.clientId) == 0 {
+		if !c.clean && s.version == version311 {
+			s.write(encodeConnack(s.version, false, codeV3IdentifierRejected))
+			return errors.New("empty client id without clean session")
+		}
+		c.clientId = s.id
+	}
+
+	s.clientId = c.clientId
+	s.project = project
+	s.application = application
+	s.keepAlive = time.Duration(c.keepAlive) * time.Second
+	s.will = c.will
+
+	return s.write(encodeConnack(s.version, false, codeSuccess))
+}
+
+// code picks the reason code of the session's protocol version.
+func (s *session) code(v311, v5 byte) byte {
+	if s.version == version5 {
+		return v5
+	}
+	return v311
+}
+
+// readLoop serves the client's packets until it disconnects; graceful
+// reports a DISCONNECT, which discards the will.
+func (s *session) readLoop() (graceful bool, err error) {
+	for {
+		if s.keepAlive > 0 {
+			s.conn.SetReadDeadline(time.Now().Add(s.keepAlive * 3 / 2))
+		} else {
+			s.conn.SetReadDeadline(time.Time{})
+		}
+
+		p, err := readPacket(s.reader, MaxPacketSize)
+		if err != nil {
+			return false, err
+		}
+
+		switch p.kind {
+		case typePublish:
+			err = s.handlePublish(p)
+		case typePubrel:
+			err = s.handlePubrel(p)
+		case typeSubscribe:
+			err = s.handleSubscribe(p)
+		case typeUnsubscribe:
+			err = s.handleUnsubscribe(p)
+		case typePingreq:
+			err = s.write((&packet{kind: typePingresp}).encode())
+		case typeDisconnect:
+			return true, nil
+		case typePuback, typePubrec, typePubcomp:
+			// Deliveries are QoS 0; nothing awaits these.
+		default:
+			err = fmt.Errorf("unexpected packet type %d", p.kind)
+		}
+
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// matcher resolves topic to the messaging channel it addresses, checking
+// the channel is bridged to MQTT.
+func (s *session) matcher(topic string) (*common.MatchDefinition, error) {
+	if matcher, ok := s.channels[topic]; ok {
+		return matcher, nil
+	}
+
+	matcher := &common.MatchDefinition{
+		Channel:     strings.TrimPrefix(topic, "/"),
+		Project:     s.project,
+		Application: s.application,
+	}
+	if len(matcher.Channel) == 0 {
+		return nil, errors.New("empty channel")
+	}
+
+	if err := s.broker.srv.AuthorizeMQTT(matcher); err != nil {
+		return nil, err
+	}
+
+	s.channels[topic] = matcher
+	return matcher, nil
+}
+
+func (s *session) handlePublish(p *packet) error {
+	pub, err := decodePublish(s.version, p.flags, p.body)
+	if err != nil {
+		return err
+	}
+	if len(pub.topic) == 0 || strings.ContainsAny(pub.topic, "+#") {
+		return fmt.Errorf("invalid publish topic `%s`", pub.topic)
+	}
+
+	if pub.qos == 2 {
+		if _, ok := s.pendingRel[pub.packetId]; ok {
+			// A resent QoS 2 publish was already delivered.
+			return s.write(encodeAck(s.version, typePubrec, 0, pub.packetId, codeSuccess))
+		}
+		if len(s.pendingRel) >= MaxPendingRel {
+			return fmt.Errorf("more than %d QoS 2 publishes not released", MaxPendingRel)
+		}
+	}
+
+	code := codeSuccess
+	matcher, err := s.matcher(pub.topic)
+	if err == nil {
+		err = s.bridge(s.ctx, matcher, pub.payload)
+		if err != nil {
+			code = codeUnspecifiedError
+		}
+	} else {
+		code = codeNotAuthorized
+	}
+
+	if err != nil {
+		logger.Debugf("mqtt publish on `%s` by `%s` failed with: %s", pub.topic, s.clientId, err.Error())
+		if s.version == version311 {
+			// MQTT 3.1.1 has no way to refuse a publish but closing.
+			return err
+		}
+	}
+
+	switch pub.qos {
+	case 1:
+		return s.write(encodeAck(s.version, typePuback, 0, pub.packetId, code))
+	case 2:
+		if code == codeSuccess {
+			s.pendingRel[pub.packetId] = struct{}{}
+		}
+		return s.write(encodeAck(s.version, typePubrec, 0, pub.packetId, code))
+	}
+
+	return nil
+}
+
+func (s *session) handlePubrel(p *packet) error {
+	d := &decoder{data: p.body}
+	packetId := d.uint16()
+	if d.err != nil {
+		return d.err
+	}
+
+	delete(s.pendingRel, packetId)
+	return s.write(encodeAck(s.version, typePubcomp, 0, packetId, codeSuccess))
+}
+
+// bridge publishes payload on the channel's pubsub topic, the one websocket
+// clients use, then runs the channel's functions here.
+func (s *session) bridge(ctx context.Context, matcher *common.MatchDefinition, payload []byte) error {
+	message, err := common.NewMessage(payload, s.id)
+	if err != nil {
+		return fmt.Errorf("creating message failed with: %w", err)
+	}
+
+	data, err := message.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshalling message failed with: %w", err)
+	}
+
+	if err = s.broker.srv.Node().PubSubPublish(ctx, matcher.String(), data); err != nil {
+		return fmt.Errorf("publishing to `%s` failed with: %w", matcher, err)
+	}
+
+	go s.broker.srv.Dispatch(matcher, message)
+
+	return nil
+}
+
+// publishWill publishes the will of a client that went away without
+// DISCONNECT. The session's context may be done by then, the broker's is not
+// unless it is closing.
+func (s *session) publishWill() {
+	if s.will == nil {
+		return
+	}
+
+	matcher, err := s.matcher(s.will.topic)
+	if err == nil {
+		err = s.bridge(s.broker.ctx, matcher, s.will.payload)
+	}
+	if err != nil {
+		logger.Debugf("publishing will of `%s` failed with: %s", s.clientId, err.Error())
+	}
+}
+
+func (s *session) handleSubscribe(p *packet) error {
+	packetId, subs, err := decodeSubscribe(s.version, p.body)
+	if err != nil {
+		return err
+	}
+
+	codes := make([]byte, len(subs))
+	for i, sub := range subs {
+		codes[i] = s.subscribe(sub)
+	}
+
+	return s.write(encodeSubAck(s.version, typeSuback, packetId, codes))
+}
+
+// subscribe grants QoS 0 on an exact topic; wildcard and shared
+// subscriptions are refused.
+func (s *session) subscribe(sub subscription) byte {
+	switch {
+	case strings.HasPrefix(sub.filter, "$share/"):
+		return s.code(codeV3SubscribeFailure, codeSharedSubUnsupported)
+	case strings.ContainsAny(sub.filter, "+#"):
+		return s.code(codeV3SubscribeFailure, codeWildcardUnsupported)
+	case len(sub.filter) == 0:
+		return s.code(codeV3SubscribeFailure, codeTopicFilterInvalid)
+	}
+
+	matcher, err := s.matcher(sub.filter)
+	if err != nil {
+		logger.Debugf("mqtt subscribe to `%s` by `%s` failed with: %s", sub.filter, s.clientId, err.Error())
+		return s.code(codeV3SubscribeFailure, codeNotAuthorized)
+	}
+
+	topic, filter, noLocal := matcher.String(), sub.filter, sub.noLocal
+	subId, err := websocket.AddSubscription(s.broker.srv, topic, func(msg *pubsub.Message) {
+		message, err := common.NewMessage(msg, "")
+		if err != nil {
+			return
+		}
+		if noLocal && message.GetSource() == s.id {
+			return
+		}
+
+		s.deliver(encodePublish(s.version, filter, message.GetData()))
+	}, func(err error) {
+		logger.Errorf("mqtt subscription to `%s` failed with: %s", topic, err.Error())
+		s.close()
+	})
+	if err != nil {
+		logger.Errorf("mqtt subscribe to `%s` failed with: %s", topic, err.Error())
+		return s.code(codeV3SubscribeFailure, codeNotAuthorized)
+	}
+
+	s.subsLock.Lock()
+	previous := s.subs[filter]
+	s.subs[filter] = &sessionSub{topic: topic, id: subId}
+	s.subsLock.Unlock()
+
+	if previous != nil {
+		websocket.RemoveSubscription(previous.topic, previous.id)
+	}
+
+	return codeSuccess
+}
+
+func (s *session) handleUnsubscribe(p *packet) error {
+	packetId, filters, err := decodeUnsubscribe(s.version, p.body)
+	if err != nil {
+		return err
+	}
+
+	codes := make([]byte, len(filters))
+	for i, filter := range filters {
+		s.subsLock.Lock()
+		sub, ok := s.subs[filter]
+		delete(s.subs, filter)
+		s.subsLock.Unlock()
+
+		if !ok {
+			codes[i] = codeNoSubscriptionExisted
+			continue
+		}
+		websocket.RemoveSubscription(sub.topic, sub.id)
+	}
+
+	return s.write(encodeSubAck(s.version, typeUnsuback, packetId, codes))
+}
+
+func (s *session) unsubscribeAll() {
+	s.subsLock.Lock()
+	subs := s.subs
+	s.subs = make(map[string]*sessionSub)
+	s.subsLock.Unlock()
+
+	for _, sub := range subs {
+		websocket.RemoveSubscription(sub.topic, sub.id)
+	}
+}
+
+// deliver queues a packet for the write loop, dropping it when the client
+// has fallen OutboundQueue packets behind.
+func (s *session) deliver(data []byte) {
+	select {
+	case <-s.ctx.Done():
+	case s.out <- data:
+	default:
+		logger.Debugf("dropping delivery to slow mqtt client `%s`", s.clientId)
+	}
+}
+
+func (s *session) writeLoop() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case data := <-s.out:
+			if err := s.write(data); err != nil {
+				s.close()
+				return
+			}
+		}
+	}
+}
+
+// write sends data to the client; the read and write loops both answer, so
+// writes are serialized per connection.
+func (s *session) write(data []byte) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	s.conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
+	_, err := s.conn.Write(data)
+	return err
+}
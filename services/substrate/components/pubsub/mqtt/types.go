@@ -0,0 +1,40 @@
+package mqtt
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/common"
+)
+
+// Service is what the broker needs from the substrate pubsub service.
+type Service interface {
+	pubsubIface.ServiceWithLookup
+
+	// AuthorizeMQTT fails unless an MQTT-enabled messaging channel of the
+	// matcher's project matches its channel.
+	AuthorizeMQTT(matcher *common.MatchDefinition) error
+
+	// Dispatch runs the pubsub functions of the matcher's channel on msg.
+	Dispatch(matcher *common.MatchDefinition, msg pubsubIface.Message)
+}
+
+// Credentials returns the project an access key was issued for and its
+// secret.
+type Credentials func(accessKey string) (projectId, secretKey string, err error)
+
+// Broker is an MQTT 3.1.1 and 5 front-end to substrate messaging channels.
+type Broker struct {
+	srv         Service
+	listener    net.Listener
+	credentials Credentials
+
+	ctx  context.Context
+	ctxC context.CancelFunc
+	wg   sync.WaitGroup
+
+	sessionsLock sync.Mutex
+	sessions     map[string]*session
+}
@@ -0,0 +1,29 @@
+package mqtt
+
+import (
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+)
+
+var logger = log.Logger("tau.substrate.service.pubsub.mqtt")
+
+// Tunables — exported so tests can shrink them.
+var (
+	// ConnectTimeout bounds how long a client has to send CONNECT.
+	ConnectTimeout = 10 * time.Second
+
+	// WriteTimeout bounds a single write to a client.
+	WriteTimeout = 10 * time.Second
+
+	// MaxPacketSize is the largest packet body a client may send.
+	MaxPacketSize = 1 << 20
+
+	// OutboundQueue is how many deliveries a session buffers before it drops
+	// messages for a slow client; deliveries are QoS 0.
+	OutboundQueue = 256
+
+	// MaxPendingRel is how many QoS 2 publishes a client may leave
+	// unreleased before it is disconnected.
+	MaxPendingRel = 1024
+)
@@ -0,0 +1,25 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/taubyte/tau/p2p/peer"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/common"
+	"github.com/taubyte/tau/services/substrate/components/structure"
+	"gotest.tools/v3/assert"
+)
+
+func TestAuthorizeMQTT(t *testing.T) {
+	s := NewTestService(peer.Mock(t.Context()))
+
+	structure.RefreshTestVariables()
+	fakeFetch(map[string]structureSpec.Messaging{
+		"plainId":  {Name: "plain", Match: "plain"},
+		"sensorId": {Name: "sensors", Match: "sensors/.*", Regex: true, MQTT: true},
+	}, nil)
+
+	assert.NilError(t, s.AuthorizeMQTT(&common.MatchDefinition{Channel: "sensors/kitchen", Project: testProject}))
+	assert.ErrorContains(t, s.AuthorizeMQTT(&common.MatchDefinition{Channel: "plain", Project: testProject}), "bridged to mqtt")
+	assert.ErrorContains(t, s.AuthorizeMQTT(&common.MatchDefinition{Channel: "unknown", Project: testProject}), "bridged to mqtt")
+}
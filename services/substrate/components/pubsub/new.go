@@ -1,17 +1,39 @@
 package pubsub
 
 import (
+	"fmt"
+
 	nodeIface "github.com/taubyte/tau/core/services/substrate"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/mqtt"
 	"github.com/taubyte/tau/services/substrate/runtime/cache"
 )
 
-func New(srv nodeIface.Service) (*Service, error) {
+func New(srv nodeIface.Service, options ...Option) (*Service, error) {
 	s := &Service{
-		Service: srv,
-		cache:   cache.New(),
+		Service:   srv,
+		cache:     cache.New(),
+		logs:      make(map[string]*channelLog),
+		consumers: make(map[string]*consumer),
+	}
+
+	for _, opt := range options {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
 	}
 
 	s.attach()
 
+	if s.hoarderClient != nil {
+		go s.sweepLogs()
+	}
+
+	if len(s.mqttAddr) > 0 {
+		var err error
+		if s.mqtt, err = mqtt.Listen(s, s.mqttAddr, s.mqttCredentials); err != nil {
+			return nil, fmt.Errorf("starting mqtt broker failed with: %w", err)
+		}
+	}
+
 	return s, nil
 }
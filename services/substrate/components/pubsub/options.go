@@ -0,0 +1,28 @@
+package pubsub
+
+import (
+	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/mqtt"
+)
+
+// Option configures the pubsub service.
+type Option func(*Service) error
+
+// MQTT serves an MQTT broker on addr, bridging MQTT-enabled messaging
+// channels. Clients authenticate with the key pairs credentials looks up.
+func MQTT(addr string, credentials mqtt.Credentials) Option {
+	return func(s *Service) error {
+		s.mqttAddr = addr
+		s.mqttCredentials = credentials
+		return nil
+	}
+}
+
+// Hoarder stores the logs of persistent messaging channels with client.
+// Without it, persistent channels behave like plain ones.
+func Hoarder(client hoarderIface.Client) Option {
+	return func(s *Service) error {
+		s.hoarderClient = client
+		return nil
+	}
+}
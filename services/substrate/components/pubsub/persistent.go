@@ -0,0 +1,377 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
+	iface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	messagingSpec "github.com/taubyte/tau/pkg/specs/messaging"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/common"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/function"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/persistent"
+	counter "github.com/taubyte/tau/services/substrate/runtime/counter"
+)
+
+// channelLog is the log of a persistent channel as configured at commit.
+type channelLog struct {
+	*persistent.Log
+	commit string
+}
+
+// log returns the log of matcher's channel, nil when no persistent messaging
+// matches it.
+func (s *Service) log(matcher *common.MatchDefinition) (*persistent.Log, error) {
+	if s.hoarderClient == nil {
+		return nil, nil
+	}
+
+	messagingsMap, commit, branch, err := s.getMessagingsMap(matcher)
+	if err != nil {
+		return nil, fmt.Errorf("getting messaging channels failed with: %w", err)
+	}
+
+	var config *structureSpec.Messaging
+	for _, item := range messagingsMap.Function.Items {
+		if item.Config().Persistent {
+			config = item.Config()
+			break
+		}
+	}
+
+	key := matcher.String()
+
+	s.logsLock.Lock()
+	defer s.logsLock.Unlock()
+
+	cached, ok := s.logs[key]
+	if config == nil {
+		if ok {
+			delete(s.logs, key)
+		}
+		s.stopConsumer(key, nil)
+		return nil, nil
+	}
+
+	if ok && cached.commit == commit {
+		return cached.Log, nil
+	}
+
+	// The consumer delivering the previous config starts over with this one.
+	if c, running := s.consumers[key]; ok && running {
+		s.stopConsumer(key, c)
+		s.startConsumer(key, matcher)
+	}
+
+	kv, err := s.hoarderClient.KVDB(hoarderIface.Messaging, matcher.Project, matcher.Application, messagingSpec.LogMatch(matcher.Channel), branch)
+	if err != nil {
+		return nil, fmt.Errorf("opening log of `%s` failed with: %w", matcher.Channel, err)
+	}
+
+	cached = &channelLog{Log: persistent.New(kv, config), commit: commit}
+	s.logs[key] = cached
+
+	return cached.Log, nil
+}
+
+func (s *Service) Log(_matcher iface.MatchDefinition) (iface.Log, error) {
+	matcher, ok := _matcher.(*common.MatchDefinition)
+	if !ok {
+		return nil, fmt.Errorf("matcher of type %T is not a pubsub matcher", _matcher)
+	}
+
+	l, err := s.log(matcher)
+	if l == nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// persistentLog returns the log of a persistent channel, failing if it isn't one.
+func (s *Service) persistentLog(projectId, appId, channel string) (*persistent.Log, error) {
+	matcher := &common.MatchDefinition{
+		Channel:     channel,
+		Project:     projectId,
+		Application: appId,
+	}
+
+	if len(matcher.Channel) > 0 && matcher.Channel[0] == '/' {
+		matcher.Channel = matcher.Channel[1:]
+	}
+
+	l, err := s.log(matcher)
+	if err != nil {
+		return nil, err
+	}
+
+	if l == nil {
+		return nil, fmt.Errorf("channel `%s` is not persistent", channel)
+	}
+
+	return l, nil
+}
+
+func (s *Service) Read(ctx context.Context, projectId, appId, channel string, offset uint64, limit int) ([]*iface.Entry, error) {
+	l, err := s.persistentLog(projectId, appId, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.Read(ctx, offset, limit)
+}
+
+func (s *Service) Offset(ctx context.Context, projectId, appId, channel, subscriber string) (uint64, error) {
+	l, err := s.persistentLog(projectId, appId, channel)
+	if err != nil {
+		return 0, err
+	}
+
+	return l.Offset(ctx, subscriber)
+}
+
+func (s *Service) Commit(ctx context.Context, projectId, appId, channel, subscriber string, offset uint64) error {
+	l, err := s.persistentLog(projectId, appId, channel)
+	if err != nil {
+		return err
+	}
+
+	return l.Commit(ctx, subscriber, offset)
+}
+
+// sweepLogs trims the logs of the persistent channels used on this node to
+// their retention.
+func (s *Service) sweepLogs() {
+	ticker := time.NewTicker(common.LogSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.Context().Done():
+			return
+		case now := <-ticker.C:
+			s.logsLock.Lock()
+			logs := make([]*persistent.Log, 0, len(s.logs))
+			for _, cached := range s.logs {
+				logs = append(logs, cached.Log)
+			}
+			s.logsLock.Unlock()
+
+			for _, l := range logs {
+				if err := l.Sweep(s.Context(), now); err != nil {
+					common.Logger.Errorf("sweeping log of `%s` failed with: %s", l.Config().Name, err)
+				}
+			}
+		}
+	}
+}
+
+// consume starts delivering the log of matcher's channel to its functions,
+// once per node, and returns the channel waking the consumer up.
+func (s *Service) consume(matcher *common.MatchDefinition) chan struct{} {
+	key := matcher.String()
+
+	s.logsLock.Lock()
+	defer s.logsLock.Unlock()
+
+	if c, ok := s.consumers[key]; ok {
+		return c.nudge
+	}
+
+	return s.startConsumer(key, matcher).nudge
+}
+
+// startConsumer runs a consumer for matcher under key. logsLock must be held.
+func (s *Service) startConsumer(key string, matcher *common.MatchDefinition) *consumer {
+	c := &consumer{nudge: make(chan struct{}, 1)}
+	c.ctx, c.ctxC = context.WithCancel(s.Context())
+	s.consumers[key] = c
+	go s.runConsumer(key, matcher, c)
+
+	return c
+}
+
+// stopConsumer cancels the consumer under key, only if it is c when c is not
+// nil. logsLock must be held.
+func (s *Service) stopConsumer(key string, c *consumer) {
+	running, ok := s.consumers[key]
+	if !ok || (c != nil && running != c) {
+		return
+	}
+
+	running.ctxC()
+	delete(s.consumers, key)
+}
+
+// unconsume stops the consumer of matcher's channel, once nothing on the node
+// listens to the channel anymore.
+func (s *Service) unconsume(matcher *common.MatchDefinition) {
+	s.logsLock.Lock()
+	defer s.logsLock.Unlock()
+
+	s.stopConsumer(matcher.String(), nil)
+}
+
+// stopConsumers cancels every consumer, for Close.
+func (s *Service) stopConsumers() {
+	s.logsLock.Lock()
+	defer s.logsLock.Unlock()
+
+	for key := range s.consumers {
+		s.stopConsumer(key, nil)
+	}
+}
+
+func wake(nudge chan struct{}) {
+	select {
+	case nudge <- struct{}{}:
+	default:
+	}
+}
+
+// runConsumer delivers until c is cancelled, or the channel isn't persistent
+// anymore.
+func (s *Service) runConsumer(key string, matcher *common.MatchDefinition, c *consumer) {
+	ticker := time.NewTicker(common.ConsumeInterval)
+	defer ticker.Stop()
+
+	for {
+		if !s.deliverAll(c.ctx, matcher) {
+			s.logsLock.Lock()
+			s.stopConsumer(key, c)
+			s.logsLock.Unlock()
+			return
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-c.nudge:
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverAll delivers the log of matcher's channel to each of its functions,
+// and reports whether the channel is still persistent.
+func (s *Service) deliverAll(ctx context.Context, matcher *common.MatchDefinition) bool {
+	l, err := s.log(matcher)
+	if err != nil {
+		common.Logger.Errorf("opening log of `%s` failed with: %s", matcher.Channel, err)
+		return true
+	}
+	if l == nil {
+		return false
+	}
+
+	picks, err := s.Lookup(matcher)
+	if err != nil {
+		return true
+	}
+
+	var waitGroup sync.WaitGroup
+	for _, pick := range picks {
+		if _, ok := pick.(*function.Function); !ok {
+			continue
+		}
+
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			if err := s.deliver(ctx, l, pick); err != nil && ctx.Err() == nil {
+				common.Logger.Errorf("delivering `%s` to %s failed with: %s", matcher.Channel, pick.Name(), err)
+			}
+		}()
+	}
+	waitGroup.Wait()
+
+	return true
+}
+
+// deliver runs pick on the messages logged since its offset, committing the
+// offset after each one. Only the node holding the lease of a function
+// delivers to it.
+func (s *Service) deliver(ctx context.Context, l *persistent.Log, pick iface.Serviceable) error {
+	subscriber := pick.Id()
+	holder := s.Node().ID().String()
+
+	for {
+		offset, err := l.Offset(ctx, subscriber)
+		if err != nil {
+			return err
+		}
+
+		entries, err := l.Read(ctx, offset, common.ReadBatch)
+		if err != nil || len(entries) == 0 {
+			return err
+		}
+
+		for _, entry := range entries {
+			leased, err := l.Lease(ctx, subscriber, holder, common.ConsumeLease)
+			if !leased || err != nil {
+				return err
+			}
+
+			// Functions don't get the messages they publish.
+			if entry.Source != subscriber {
+				if err = s.attempt(ctx, l.Config(), pick, entry); err != nil {
+					return err
+				}
+			}
+
+			if err = l.Commit(ctx, subscriber, entry.Offset+1); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// attempt runs pick on entry, retrying with backoff as configured. Messages
+// still failing go to the dead-letter channel, or are dropped without one.
+func (s *Service) attempt(ctx context.Context, config *structureSpec.Messaging, pick iface.Serviceable, entry *iface.Entry) error {
+	msg, err := common.NewMessage(entry.Data, entry.Source)
+	if err != nil {
+		return fmt.Errorf("creating message failed with: %w", err)
+	}
+
+	backoff := common.RetryBackoff
+	for try := 0; ; try++ {
+		start := time.Now()
+		coldStartDone, err := pick.HandleMessage(msg)
+		if err == nil {
+			return nil
+		}
+
+		counter.ErrorWrapper(pick, start, coldStartDone, err)
+		if try >= config.Retries {
+			common.Logger.Errorf("handling message %d of `%s` failed %d times, last with: %s", entry.Offset, config.Name, try+1, err)
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	if config.DeadLetter == "" {
+		return nil
+	}
+
+	deadLetter := &common.MatchDefinition{
+		Channel:     config.DeadLetter,
+		Project:     pick.Project(),
+		Application: pick.Application(),
+	}
+
+	if err = s.publish(ctx, deadLetter, pick.Id(), entry.Data); err != nil {
+		return fmt.Errorf("dead-lettering message %d to `%s` failed with: %w", entry.Offset, config.DeadLetter, err)
+	}
+
+	return nil
+}
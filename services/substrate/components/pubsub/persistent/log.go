@@ -0,0 +1,425 @@
+package persistent
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taubyte/tau/core/kvdb"
+	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
+	iface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	kvdbPkg "github.com/taubyte/tau/pkg/kvdb"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/common"
+)
+
+// Log keys in the channel's kvdb:
+//
+//	m/<offset>      cbor{Source,Data,Time}
+//	next            offset appends start looking for a free one at
+//	floor           offsets below it were dropped by retention
+//	bytes           size of the messages logged, a lower bound after a crash
+//	o/<subscriber>  offset the subscriber reads next
+//	l/<subscriber>  <holder>/<unix nano expiry> of the consumer lease
+const (
+	messagePrefix = "m/"
+	offsetPrefix  = "o/"
+	leasePrefix   = "l/"
+	nextKey       = "next"
+	floorKey      = "floor"
+	bytesKey      = "bytes"
+)
+
+// sweepPage is the number of messages Sweep looks at a time.
+var sweepPage = 256
+
+type Log struct {
+	kv     kvdb.KVDB
+	config *structureSpec.Messaging
+
+	// swapLock guards offsets and leases on stores that can't compare and swap.
+	swapLock sync.Mutex
+}
+
+var _ iface.Log = &Log{}
+
+type record struct {
+	Source string `cbor:"0"`
+	Data   []byte `cbor:"1"`
+	Time   int64  `cbor:"2"`
+}
+
+// New opens the log of a persistent channel stored in kv.
+func New(kv kvdb.KVDB, config *structureSpec.Messaging) *Log {
+	return &Log{kv: kv, config: config}
+}
+
+func (l *Log) Config() *structureSpec.Messaging {
+	return l.config
+}
+
+func messageKey(offset uint64) string {
+	return fmt.Sprintf("%s%020d", messagePrefix, offset)
+}
+
+func parseKey(key string) (uint64, error) {
+	offset, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimPrefix(key, "/"), messagePrefix), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("key %s is not a message: %w", key, err)
+	}
+
+	return offset, nil
+}
+
+// swap replaces the value of key by update's, unless it changed since read.
+// update gets nil when key is absent and returns nil to leave it as it is.
+func (l *Log) swap(ctx context.Context, key string, update func(current []byte) ([]byte, error)) (bool, error) {
+	cas, ok := l.kv.(hoarderIface.CasKVDB)
+	if !ok {
+		l.swapLock.Lock()
+		defer l.swapLock.Unlock()
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		current, err := l.kv.Get(ctx, key)
+		if err != nil {
+			current = nil
+		}
+
+		value, err := update(current)
+		if value == nil || err != nil {
+			return false, err
+		}
+
+		if !ok {
+			return true, l.kv.Put(ctx, key, value)
+		}
+
+		var expected []byte
+		if current != nil {
+			sum := sha256.Sum256(current)
+			expected = sum[:]
+		}
+
+		swapped, err := cas.CompareAndSwap(ctx, key, expected, value)
+		if err != nil {
+			return false, err
+		}
+
+		if swapped {
+			return true, nil
+		}
+	}
+}
+
+// create writes key unless it exists, reporting whether it did.
+func (l *Log) create(ctx context.Context, key string, value []byte) (bool, error) {
+	if nx, ok := l.kv.(hoarderIface.NxKVDB); ok {
+		existed, err := nx.PutNx(ctx, key, value)
+		return !existed, err
+	}
+
+	return l.swap(ctx, key, func(current []byte) ([]byte, error) {
+		if current != nil {
+			return nil, nil
+		}
+		return value, nil
+	})
+}
+
+// counter returns the number stored at key, 0 when it is absent.
+func (l *Log) counter(ctx context.Context, key string) (uint64, error) {
+	raw, err := l.kv.Get(ctx, key)
+	if err != nil || raw == nil {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s failed with: %w", key, err)
+	}
+
+	return n, nil
+}
+
+// add changes the number stored at key by delta, or raises it to at least
+// delta's value when raise is set.
+func (l *Log) add(ctx context.Context, key string, delta int64, raise bool) error {
+	_, err := l.swap(ctx, key, func(current []byte) ([]byte, error) {
+		var n uint64
+		if current != nil {
+			var err error
+			if n, err = strconv.ParseUint(string(current), 10, 64); err != nil {
+				return nil, fmt.Errorf("parsing %s failed with: %w", key, err)
+			}
+		}
+
+		switch {
+		case raise && uint64(delta) <= n:
+			return nil, nil
+		case raise:
+			n = uint64(delta)
+		case delta < 0 && uint64(-delta) > n:
+			n = 0
+		default:
+			n = uint64(int64(n) + delta)
+		}
+
+		return []byte(strconv.FormatUint(n, 10)), nil
+	})
+	if err != nil {
+		return fmt.Errorf("updating %s failed with: %w", key, err)
+	}
+
+	return nil
+}
+
+// Append adds a message at the end of the log and returns its offset. The
+// message is written by the same conditional put that takes its offset, so a
+// message is never visible after a newer one.
+func (l *Log) Append(ctx context.Context, source string, data []byte) (uint64, error) {
+	value, err := cbor.Marshal(&record{Source: source, Data: data, Time: time.Now().UnixNano()})
+	if err != nil {
+		return 0, fmt.Errorf("marshalling message failed with: %w", err)
+	}
+
+	next, err := l.counter(ctx, nextKey)
+	if err != nil {
+		return 0, err
+	}
+
+	floor, err := l.counter(ctx, floorKey)
+	if err != nil {
+		return 0, err
+	}
+
+	offset := max(next, floor)
+	for {
+		if err = ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		created, err := l.create(ctx, messageKey(offset), value)
+		if err != nil {
+			return 0, fmt.Errorf("appending message %d failed with: %w", offset, err)
+		}
+
+		if !created {
+			offset++
+			continue
+		}
+
+		// Sweep raises the floor before dropping messages: an offset below it
+		// was freed by retention, not taken for the first time.
+		if floor, err = l.counter(ctx, floorKey); err == nil && offset < floor {
+			if err = l.kv.Delete(ctx, messageKey(offset)); err != nil {
+				return 0, fmt.Errorf("dropping message %d appended below the floor failed with: %w", offset, err)
+			}
+			offset = floor
+			continue
+		}
+
+		break
+	}
+
+	// Both are hints, the message is logged already.
+	if err = l.add(ctx, nextKey, int64(offset+1), true); err != nil {
+		common.Logger.Errorf("appending message %d: %s", offset, err)
+	}
+
+	if err = l.add(ctx, bytesKey, int64(len(data)), false); err != nil {
+		common.Logger.Errorf("appending message %d: %s", offset, err)
+	}
+
+	return offset, nil
+}
+
+// get returns the message stored at key, nil if it was dropped since listed.
+func (l *Log) get(ctx context.Context, key string) (*record, error) {
+	value, err := l.kv.Get(ctx, key)
+	if err != nil || value == nil {
+		return nil, nil
+	}
+
+	var rec record
+	if err = cbor.Unmarshal(value, &rec); err != nil {
+		return nil, fmt.Errorf("unmarshalling message %s failed with: %w", key, err)
+	}
+
+	return &rec, nil
+}
+
+// Read returns, oldest first, at most limit messages from the given offset on.
+// Messages dropped by retention are skipped.
+func (l *Log) Read(ctx context.Context, offset uint64, limit int) ([]*iface.Entry, error) {
+	var after string
+	if offset > 0 {
+		after = messageKey(offset - 1)
+	}
+
+	keys, err := kvdbPkg.Scan(ctx, l.kv, messagePrefix, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing messages failed with: %w", err)
+	}
+
+	entries := make([]*iface.Entry, 0, len(keys))
+	for _, key := range keys {
+		at, err := parseKey(key)
+		if err != nil {
+			continue
+		}
+
+		rec, err := l.get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if rec != nil {
+			entries = append(entries, &iface.Entry{Offset: at, Time: time.Unix(0, rec.Time), Source: rec.Source, Data: rec.Data})
+		}
+	}
+
+	return entries, nil
+}
+
+// Offset returns the offset a subscriber reads next, 0 if it never committed.
+func (l *Log) Offset(ctx context.Context, subscriber string) (uint64, error) {
+	raw, err := l.kv.Get(ctx, offsetPrefix+subscriber)
+	if err != nil || raw == nil {
+		return 0, nil
+	}
+
+	offset, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing offset of %s failed with: %w", subscriber, err)
+	}
+
+	return offset, nil
+}
+
+// Commit records the offset a subscriber reads next.
+func (l *Log) Commit(ctx context.Context, subscriber string, offset uint64) error {
+	if subscriber == "" {
+		return errors.New("subscriber is required")
+	}
+
+	if err := l.kv.Put(ctx, offsetPrefix+subscriber, []byte(strconv.FormatUint(offset, 10))); err != nil {
+		return fmt.Errorf("committing offset of %s failed with: %w", subscriber, err)
+	}
+
+	return nil
+}
+
+// Lease makes holder the only consumer of subscriber for ttl, unless another
+// holder's lease is still running. Holders renew their lease the same way.
+func (l *Log) Lease(ctx context.Context, subscriber, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	leased, err := l.swap(ctx, leasePrefix+subscriber, func(current []byte) ([]byte, error) {
+		if current != nil {
+			owner, expiry, _ := strings.Cut(string(current), "/")
+			nano, err := strconv.ParseInt(expiry, 10, 64)
+			if owner != holder && err == nil && now.Before(time.Unix(0, nano)) {
+				return nil, nil
+			}
+		}
+
+		return []byte(holder + "/" + strconv.FormatInt(now.Add(ttl).UnixNano(), 10)), nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("leasing %s failed with: %w", subscriber, err)
+	}
+
+	return leased, nil
+}
+
+// Sweep drops the messages older than the retention, then the oldest ones
+// until the log fits in the retention size.
+func (l *Log) Sweep(ctx context.Context, now time.Time) error {
+	if l.config.Retention == 0 && l.config.RetentionSize == 0 {
+		return nil
+	}
+
+	size, err := l.counter(ctx, bytesKey)
+	if err != nil {
+		return err
+	}
+
+	batch, err := l.kv.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("creating batch failed with: %w", err)
+	}
+
+	var (
+		after   string
+		floor   uint64
+		dropped int
+		freed   int64
+	)
+sweep:
+	for {
+		keys, err := kvdbPkg.Scan(ctx, l.kv, messagePrefix, after, sweepPage)
+		if err != nil {
+			return fmt.Errorf("listing messages failed with: %w", err)
+		}
+
+		for _, key := range keys {
+			offset, err := parseKey(key)
+			if err != nil {
+				continue
+			}
+
+			rec, err := l.get(ctx, key)
+			if err != nil || rec == nil {
+				continue
+			}
+
+			expired := l.config.Retention > 0 && now.Sub(time.Unix(0, rec.Time)) > time.Duration(l.config.Retention)
+			oversized := l.config.RetentionSize > 0 && size > l.config.RetentionSize
+			if !expired && !oversized {
+				break sweep
+			}
+
+			if err = batch.Delete(key); err != nil {
+				return fmt.Errorf("dropping message %d failed with: %w", offset, err)
+			}
+
+			size -= min(size, uint64(len(rec.Data)))
+			freed += int64(len(rec.Data))
+			floor = offset + 1
+			dropped++
+		}
+
+		if len(keys) < sweepPage {
+			break
+		}
+		after = keys[len(keys)-1]
+	}
+
+	if dropped == 0 {
+		return nil
+	}
+
+	if err = l.add(ctx, floorKey, int64(floor), true); err != nil {
+		return err
+	}
+
+	if err = batch.Commit(); err != nil {
+		return fmt.Errorf("dropping %d messages failed with: %w", dropped, err)
+	}
+
+	return l.add(ctx, bytesKey, -freed, false)
+}
+
+func (l *Log) Close() {
+	l.kv.Close()
+}
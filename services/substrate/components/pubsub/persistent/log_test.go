@@ -0,0 +1,199 @@
+package persistent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/pkg/kvdb/mock"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"gotest.tools/v3/assert"
+)
+
+func newTestLog(t *testing.T, config *structureSpec.Messaging) *Log {
+	store, err := mock.New().New(nil, "test", 0)
+	assert.NilError(t, err)
+
+	return New(store, config)
+}
+
+func TestAppendRead(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLog(t, &structureSpec.Messaging{Persistent: true})
+
+	for i := range 5 {
+		offset, err := l.Append(ctx, "source", []byte(fmt.Sprintf("message %d", i)))
+		assert.NilError(t, err)
+		assert.Equal(t, offset, uint64(i))
+	}
+
+	entries, err := l.Read(ctx, 0, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 5)
+	for i, entry := range entries {
+		assert.Equal(t, entry.Offset, uint64(i))
+		assert.Equal(t, entry.Source, "source")
+		assert.Equal(t, string(entry.Data), fmt.Sprintf("message %d", i))
+	}
+
+	entries, err = l.Read(ctx, 3, 1)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 1)
+	assert.Equal(t, entries[0].Offset, uint64(3))
+
+	entries, err = l.Read(ctx, 5, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 0)
+}
+
+func TestAppendConcurrent(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLog(t, &structureSpec.Messaging{Persistent: true})
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := l.Append(ctx, "", []byte("x"))
+			assert.Check(t, err)
+		}()
+	}
+	wg.Wait()
+
+	entries, err := l.Read(ctx, 0, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 20)
+	for i, entry := range entries {
+		assert.Equal(t, entry.Offset, uint64(i))
+	}
+}
+
+func TestOffsets(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLog(t, &structureSpec.Messaging{Persistent: true})
+
+	offset, err := l.Offset(ctx, "fn")
+	assert.NilError(t, err)
+	assert.Equal(t, offset, uint64(0))
+
+	assert.NilError(t, l.Commit(ctx, "fn", 7))
+	offset, err = l.Offset(ctx, "fn")
+	assert.NilError(t, err)
+	assert.Equal(t, offset, uint64(7))
+
+	offset, err = l.Offset(ctx, "other")
+	assert.NilError(t, err)
+	assert.Equal(t, offset, uint64(0))
+
+	assert.ErrorContains(t, l.Commit(ctx, "", 1), "subscriber")
+}
+
+func TestSweep(t *testing.T) {
+	ctx := context.Background()
+	config := &structureSpec.Messaging{Persistent: true, RetentionSize: 10}
+	l := newTestLog(t, config)
+
+	for range 4 {
+		_, err := l.Append(ctx, "", []byte("abcd"))
+		assert.NilError(t, err)
+	}
+
+	// 16 bytes logged, only two 4 byte messages fit in 10.
+	assert.NilError(t, l.Sweep(ctx, time.Now()))
+	entries, err := l.Read(ctx, 0, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 2)
+	assert.Equal(t, entries[0].Offset, uint64(2))
+
+	config.RetentionSize = 0
+	config.Retention = uint64(time.Hour)
+	assert.NilError(t, l.Sweep(ctx, time.Now()))
+	entries, err = l.Read(ctx, 0, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 2)
+
+	assert.NilError(t, l.Sweep(ctx, time.Now().Add(2*time.Hour)))
+	entries, err = l.Read(ctx, 0, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 0)
+
+	// Offsets keep growing once the log is emptied.
+	offset, err := l.Append(ctx, "", []byte("abcd"))
+	assert.NilError(t, err)
+	assert.Equal(t, offset, uint64(4))
+}
+
+func TestSweepPages(t *testing.T) {
+	defer func(page int) { sweepPage = page }(sweepPage)
+	sweepPage = 2
+
+	ctx := context.Background()
+	l := newTestLog(t, &structureSpec.Messaging{Persistent: true, RetentionSize: 8})
+
+	for range 7 {
+		_, err := l.Append(ctx, "", []byte("abcd"))
+		assert.NilError(t, err)
+	}
+
+	assert.NilError(t, l.Sweep(ctx, time.Now()))
+	entries, err := l.Read(ctx, 0, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 2)
+	assert.Equal(t, entries[0].Offset, uint64(5))
+
+	entries, err = l.Read(ctx, 6, 1)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 1)
+	assert.Equal(t, entries[0].Offset, uint64(6))
+}
+
+func TestAppendBelowFloor(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLog(t, &structureSpec.Messaging{Persistent: true, Retention: uint64(time.Hour)})
+
+	for range 3 {
+		_, err := l.Append(ctx, "", []byte("abcd"))
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, l.Sweep(ctx, time.Now().Add(2*time.Hour)))
+
+	// Appends never reuse the offsets a sweep freed, even when next lags.
+	assert.NilError(t, l.kv.Put(ctx, nextKey, []byte("0")))
+	offset, err := l.Append(ctx, "", []byte("abcd"))
+	assert.NilError(t, err)
+	assert.Equal(t, offset, uint64(3))
+
+	entries, err := l.Read(ctx, 0, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 1)
+	assert.Equal(t, entries[0].Offset, uint64(3))
+}
+
+func TestLease(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLog(t, &structureSpec.Messaging{Persistent: true})
+
+	leased, err := l.Lease(ctx, "fn", "nodeA", time.Hour)
+	assert.NilError(t, err)
+	assert.Assert(t, leased)
+
+	leased, err = l.Lease(ctx, "fn", "nodeB", time.Hour)
+	assert.NilError(t, err)
+	assert.Assert(t, !leased)
+
+	leased, err = l.Lease(ctx, "fn", "nodeA", -time.Second)
+	assert.NilError(t, err)
+	assert.Assert(t, leased)
+
+	// An expired lease goes to whoever asks.
+	leased, err = l.Lease(ctx, "fn", "nodeB", time.Hour)
+	assert.NilError(t, err)
+	assert.Assert(t, leased)
+
+	leased, err = l.Lease(ctx, "other", "nodeA", time.Hour)
+	assert.NilError(t, err)
+	assert.Assert(t, leased)
+}
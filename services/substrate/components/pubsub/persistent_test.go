@@ -0,0 +1,50 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/services/substrate/components/pubsub/common"
+)
+
+func TestConsumerLifecycle(t *testing.T) {
+	s := NewTestService(nil)
+	s.logs = make(map[string]*channelLog)
+	s.consumers = make(map[string]*consumer)
+
+	consumers := func() int {
+		s.logsLock.Lock()
+		defer s.logsLock.Unlock()
+		return len(s.consumers)
+	}
+
+	// Without a log the channel isn't persistent: the consumer stops and
+	// drops its entry.
+	matcher := &common.MatchDefinition{Project: testProject, Channel: testChannel}
+	s.consume(matcher)
+	for deadline := time.Now().Add(5 * time.Second); consumers() > 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("consumer of a channel that isn't persistent kept running")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ctx, ctxC := context.WithCancel(context.Background())
+	s.consumers[matcher.String()] = &consumer{nudge: make(chan struct{}, 1), ctx: ctx, ctxC: ctxC}
+
+	s.unconsume(matcher)
+	if ctx.Err() == nil || consumers() != 0 {
+		t.Error("unconsume left the consumer running")
+	}
+
+	ctx, ctxC = context.WithCancel(context.Background())
+	s.consumers[matcher.String()] = &consumer{nudge: make(chan struct{}, 1), ctx: ctx, ctxC: ctxC}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Err() == nil || consumers() != 0 {
+		t.Error("close left the consumer running")
+	}
+}
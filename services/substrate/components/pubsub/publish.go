@@ -27,6 +27,23 @@ func (s *Service) Publish(ctx context.Context, projectId, appId, resource, chann
 		matcher.Channel = matcher.Channel[1:]
 	}
 
+	return s.publish(ctx, matcher, resource, data)
+}
+
+// publish logs data when the channel is persistent, then sends it to the
+// channel's subscribers.
+func (s *Service) publish(ctx context.Context, matcher *common.MatchDefinition, resource string, data []byte) error {
+	l, err := s.log(matcher)
+	if err != nil {
+		return err
+	}
+
+	if l != nil {
+		if _, err = l.Append(ctx, resource, data); err != nil {
+			return fmt.Errorf("logging message failed with: %w", err)
+		}
+	}
+
 	message, err := common.NewMessage(
 		data,
 		resource, /* id of function - unique to each function - at least on channel*/
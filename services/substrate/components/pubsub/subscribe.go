@@ -3,6 +3,7 @@ package pubsub
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -24,6 +25,10 @@ func (s *Service) handle(startTime time.Time, matcher *common.MatchDefinition, m
 		return
 	}
 
+	s.run(startTime, picks, msg)
+}
+
+func (s *Service) run(startTime time.Time, picks []iface.Serviceable, msg iface.Message) {
 	var waitGroup sync.WaitGroup
 	waitGroup.Add(len(picks))
 	for _, pick := range picks {
@@ -59,6 +64,18 @@ func (s *Service) Subscribe(projectId, appId, resource, path string) error {
 		return fmt.Errorf("lookup returned no picks")
 	}
 
+	l, err := s.log(matcher)
+	if err != nil {
+		return fmt.Errorf("opening log failed with: %w", err)
+	}
+
+	// Persistent channels are delivered from their log, live messages only
+	// wake the consumer up.
+	var nudge chan struct{}
+	if l != nil {
+		nudge = s.consume(matcher)
+	}
+
 	ctx, ctxC := context.WithCancel(s.Context())
 	workers := make(chan struct{}, 64)
 	for range 64 {
@@ -66,6 +83,11 @@ func (s *Service) Subscribe(projectId, appId, resource, path string) error {
 	}
 
 	_, err = websocket.AddSubscription(s, matcher.String(), func(msg *pubsub.Message) {
+		if nudge != nil {
+			wake(nudge)
+			return
+		}
+
 		// unwarp the message first
 		message, err := common.NewMessage(msg, "")
 		if err != nil {
@@ -76,6 +98,10 @@ func (s *Service) Subscribe(projectId, appId, resource, path string) error {
 			// ignore the message - comes from self
 			return
 		}
+		if strings.HasPrefix(message.GetSource(), common.MQTTSourcePrefix) {
+			// the node that accepted an MQTT publish already dispatched it
+			return
+		}
 
 		go func() {
 			defer func() {
@@ -91,11 +117,17 @@ func (s *Service) Subscribe(projectId, appId, resource, path string) error {
 	}, func(err error) {
 		common.Logger.Error("handle error with:", err.Error())
 		ctxC()
+		if nudge != nil {
+			s.unconsume(matcher)
+		}
 	})
 
 	if err != nil {
 		common.Logger.Error("subscribe failed with:", err.Error())
 		ctxC()
+		if nudge != nil {
+			s.unconsume(matcher)
+		}
 		return err
 	}
 
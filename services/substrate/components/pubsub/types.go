@@ -1,8 +1,13 @@
 package pubsub
 
 import (
+	"context"
+	"sync"
+
+	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
 	nodeIface "github.com/taubyte/tau/core/services/substrate"
 	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/mqtt"
 	"github.com/taubyte/tau/services/substrate/runtime/cache"
 )
 
@@ -11,4 +16,24 @@ var _ pubsubIface.ServiceWithLookup = &Service{}
 type Service struct {
 	nodeIface.Service
 	cache *cache.Cache
+
+	mqttAddr        string
+	mqttCredentials mqtt.Credentials
+	mqtt            *mqtt.Broker
+
+	hoarderClient hoarderIface.Client
+
+	// logs and consumers of the persistent channels used on this node,
+	// keyed by matcher.
+	logsLock  sync.Mutex
+	logs      map[string]*channelLog
+	consumers map[string]*consumer
+}
+
+// consumer delivers the log of a persistent channel until cancelled; nudge
+// wakes it up.
+type consumer struct {
+	nudge chan struct{}
+	ctx   context.Context
+	ctxC  context.CancelFunc
 }
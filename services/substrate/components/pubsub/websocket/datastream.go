@@ -20,6 +20,12 @@ type dataStreamHandler struct {
 	errCh   chan error
 	srv     pubsubIface.ServiceWithLookup
 	matcher components.MatchDefinition
+
+	// log is set on persistent channels, replay is the offset to replay it
+	// from when replaying is set.
+	log       pubsubIface.Log
+	replay    uint64
+	replaying bool
 }
 
 func (h *dataStreamHandler) Close() {
@@ -47,6 +53,13 @@ func (h *dataStreamHandler) In() {
 				return
 			}
 
+			if h.log != nil {
+				if _, err = h.log.Append(h.ctx, h.id, msg); err != nil {
+					h.error(fmt.Errorf("logging data In on `%s` failed with: %w", h.matcher, err))
+					return
+				}
+			}
+
 			message, err := common.NewMessage(msg, h.id)
 			if err != nil {
 				h.error(fmt.Errorf("creating message failed with: %w", err))
@@ -67,8 +80,40 @@ func (h *dataStreamHandler) In() {
 	}
 }
 
+// replayLog writes the messages logged since the replay offset out, before
+// any live one.
+func (h *dataStreamHandler) replayLog() error {
+	for offset := h.replay; ; {
+		entries, err := h.log.Read(h.ctx, offset, common.ReadBatch)
+		if err != nil {
+			return fmt.Errorf("reading log of `%s` failed with: %w", h.matcher, err)
+		}
+
+		if len(entries) == 0 {
+			return nil
+		}
+
+		for _, entry := range entries {
+			if err = h.conn.WriteMessage(websocket.BinaryMessage, entry.Data); err != nil {
+				return fmt.Errorf("writing replayed data out failed with %v closing connection", err)
+			}
+			offset = entry.Offset + 1
+		}
+	}
+}
+
 func (h *dataStreamHandler) Out() {
 	defer h.Close()
+
+	if h.replaying {
+		if err := h.replayLog(); err != nil {
+			h.conn.WriteJSON(WrappedMessage{
+				Error: err.Error(),
+			})
+			return
+		}
+	}
+
 	for {
 		select {
 		case <-h.ctx.Done():
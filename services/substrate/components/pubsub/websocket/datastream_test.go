@@ -999,3 +999,7 @@ func TestDataStreamHandler_ClosedChannelWrites(t *testing.T) {
 		handler2.Close()
 	})
 }
+
+func (m *mockLocalService) Log(matcher pubsubIface.MatchDefinition) (pubsubIface.Log, error) {
+	return nil, nil
+}
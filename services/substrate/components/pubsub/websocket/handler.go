@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 
@@ -84,19 +85,34 @@ func (sv *subViewer) handler(msg *pubsub.Message) {
 }
 
 func (sv *subViewer) err_handler(err error) {
+	// Subscription error handlers remove themselves, so they run unlocked.
 	sv.Lock()
-	defer sv.Unlock()
-	// Process subscriptions sequentially to avoid goroutine explosion
+	handlers := make([]*sub, 0, len(sv.subs))
 	for _, subscription := range sv.subs {
+		handlers = append(handlers, subscription)
+	}
+	sv.Unlock()
+
+	// Process subscriptions sequentially to avoid goroutine explosion
+	for _, subscription := range handlers {
 		subscription.err_handler(err)
 	}
 }
 
+// RemoveSubscription drops a subscription added with AddSubscription.
+func RemoveSubscription(name string, subIdx int) {
+	removeSubscription(name, subIdx)
+}
+
 func removeSubscription(name string, subIdx int) {
 	subs.Lock()
-	defer subs.Unlock()
-	if subset, ok := subs.subscriptions[name]; ok {
+	subset, ok := subs.subscriptions[name]
+	subs.Unlock()
+
+	if ok {
+		subset.Lock()
 		delete(subset.subs, subIdx)
+		subset.Unlock()
 	}
 }
 
@@ -204,6 +220,18 @@ func createWsHandler(srv pubsubIface.ServiceWithLookup, ctx service.Context, con
 	}
 
 	handler := new(dataStreamHandler)
+	if handler.log, err = srv.Log(matcher); err != nil {
+		return nil, fmt.Errorf("opening log of `%s` failed with: %w", channel, err)
+	}
+
+	// Clients of persistent channels may replay the log from an offset.
+	if offset, err := ctx.GetStringVariable("offset"); err == nil && handler.log != nil {
+		if handler.replay, err = strconv.ParseUint(offset, 10, 64); err != nil {
+			return nil, fmt.Errorf("parsing offset `%s` failed with: %w", offset, err)
+		}
+		handler.replaying = true
+	}
+
 	handler.id = id.Generate(srv.Node().ID(), handler)
 	handler.ctx, handler.ctxC = context.WithCancel(srv.Context())
 	handler.conn = conn
@@ -0,0 +1,143 @@
+package s3
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// chunkSigner checks the signature chaining each aws-chunked chunk to the
+// one before it, starting from the request's.
+type chunkSigner struct {
+	key      []byte
+	date     string
+	scope    scope
+	previous string
+}
+
+// chunkedReader decodes an aws-chunked body:
+//
+//	<hex size>[;chunk-signature=<sig>]\r\n<data>\r\n ... 0[;chunk-signature=<sig>]\r\n[trailers]\r\n
+//
+// Trailing checksums are read past, not checked.
+type chunkedReader struct {
+	r      *bufio.Reader
+	signer *chunkSigner
+	chunk  []byte
+	err    error
+}
+
+func newChunkedReader(r io.Reader, signer *chunkSigner) *chunkedReader {
+	return &chunkedReader{r: bufio.NewReader(r), signer: signer}
+}
+
+func (c *chunkedReader) Read(p []byte) (int, error) {
+	for len(c.chunk) == 0 {
+		if c.err != nil {
+			return 0, c.err
+		}
+		c.err = c.next()
+	}
+
+	n := copy(p, c.chunk)
+	c.chunk = c.chunk[n:]
+	return n, nil
+}
+
+func (c *chunkedReader) next() error {
+	header, err := c.line()
+	if err != nil {
+		return err
+	}
+
+	_size, extension, _ := strings.Cut(header, ";")
+	size, err := strconv.ParseInt(_size, 16, 64)
+	if err != nil || size < 0 {
+		return errInvalidArgument.with("malformed chunk header")
+	}
+	if size > int64(MaxChunkSize) {
+		return errInvalidArgument.with("chunk of %d bytes exceeds %d", size, MaxChunkSize)
+	}
+
+	data := make([]byte, size)
+	if _, err = io.ReadFull(c.r, data); err != nil {
+		return errIncompleteBody
+	}
+
+	if c.signer != nil {
+		signature, _ := strings.CutPrefix(extension, "chunk-signature=")
+		if !c.signer.verify(data, signature) {
+			return errSignatureDoesNotMatch
+		}
+	}
+
+	if size == 0 {
+		// Trailers, if any, end with an empty line.
+		for {
+			line, err := c.line()
+			if err != nil {
+				return err
+			}
+			if line == "" {
+				return io.EOF
+			}
+		}
+	}
+
+	if line, err := c.line(); err != nil || line != "" {
+		return errIncompleteBody
+	}
+
+	c.chunk = data
+	return nil
+}
+
+func (c *chunkedReader) line() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return "", errIncompleteBody
+		}
+		return "", err
+	}
+
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+}
+
+func (cs *chunkSigner) verify(data []byte, signature string) bool {
+	hash := sha256.Sum256(data)
+	stringToSign := chunkAlgorithm + "\n" + cs.date + "\n" + cs.scope.String() + "\n" + cs.previous + "\n" + emptySHA256 + "\n" + hex.EncodeToString(hash[:])
+
+	expected := sign(cs.key, stringToSign)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return false
+	}
+
+	cs.previous = expected
+	return true
+}
+
+// hashReader fails the read that reaches EOF when the payload doesn't hash
+// to the signed X-Amz-Content-Sha256.
+type hashReader struct {
+	r    io.Reader
+	hash hash.Hash
+	want []byte
+}
+
+func (h *hashReader) Read(p []byte) (int, error) {
+	n, err := h.r.Read(p)
+	h.hash.Write(p[:n])
+
+	if errors.Is(err, io.EOF) && !hmac.Equal(h.hash.Sum(nil), h.want) {
+		return n, errContentSHA256Mismatch
+	}
+
+	return n, err
+}
@@ -0,0 +1,71 @@
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	errAccessDenied           = &apiError{"AccessDenied", "Access Denied", http.StatusForbidden}
+	errInvalidAccessKeyId     = &apiError{"InvalidAccessKeyId", "The AWS access key Id you provided does not exist in our records", http.StatusForbidden}
+	errSignatureDoesNotMatch  = &apiError{"SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided", http.StatusForbidden}
+	errRequestTimeTooSkewed   = &apiError{"RequestTimeTooSkewed", "The difference between the request time and the server's time is too large", http.StatusForbidden}
+	errAuthorizationMalformed = &apiError{"AuthorizationHeaderMalformed", "The authorization header is malformed", http.StatusBadRequest}
+	errAuthorizationQuery     = &apiError{"AuthorizationQueryParametersError", "The authorization query parameters are malformed", http.StatusBadRequest}
+	errInvalidArgument        = &apiError{"InvalidArgument", "Invalid argument", http.StatusBadRequest}
+	errContentSHA256Mismatch  = &apiError{"XAmzContentSHA256Mismatch", "The provided 'x-amz-content-sha256' header does not match what was computed", http.StatusBadRequest}
+	errIncompleteBody         = &apiError{"IncompleteBody", "You did not provide the number of bytes specified by the Content-Length HTTP header", http.StatusBadRequest}
+	errEntityTooLarge         = &apiError{"EntityTooLarge", "Your proposed upload exceeds the capacity of the storage", http.StatusBadRequest}
+	errMalformedXML           = &apiError{"MalformedXML", "The XML you provided was not well-formed", http.StatusBadRequest}
+	errInvalidPart            = &apiError{"InvalidPart", "One or more of the specified parts could not be found", http.StatusBadRequest}
+	errInvalidPartOrder       = &apiError{"InvalidPartOrder", "The list of parts was not in ascending order", http.StatusBadRequest}
+	errNoSuchBucket           = &apiError{"NoSuchBucket", "The specified bucket does not exist", http.StatusNotFound}
+	errNoSuchKey              = &apiError{"NoSuchKey", "The specified key does not exist", http.StatusNotFound}
+	errNoSuchUpload           = &apiError{"NoSuchUpload", "The specified multipart upload does not exist", http.StatusNotFound}
+	errMethodNotAllowed       = &apiError{"MethodNotAllowed", "The specified method is not allowed against this resource", http.StatusMethodNotAllowed}
+	errNotImplemented         = &apiError{"NotImplemented", "A header or operation you provided is not implemented", http.StatusNotImplemented}
+	errInternal               = &apiError{"InternalError", "We encountered an internal error. Please try again", http.StatusInternalServerError}
+)
+
+func (e *apiError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// Is matches errors by code, whatever their message.
+func (e *apiError) Is(target error) bool {
+	t, ok := target.(*apiError)
+	return ok && t.Code == e.Code
+}
+
+// with returns a copy of e carrying a more specific message.
+func (e *apiError) with(format string, args ...any) *apiError {
+	return &apiError{Code: e.Code, Message: fmt.Sprintf(format, args...), Status: e.Status}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var e *apiError
+	if !errors.As(err, &e) {
+		logger.Errorf("%s %s failed with: %s", r.Method, r.URL.Path, err.Error())
+		e = errInternal.with("%s", err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(e.Status)
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	w.Write([]byte(xml.Header))
+	xml.NewEncoder(w).Encode(&errorResponse{Code: e.Code, Message: e.Message, Resource: r.URL.Path})
+}
+
+func writeXML(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	if err := xml.NewEncoder(w).Encode(v); err != nil {
+		logger.Errorf("encoding response failed with: %s", err.Error())
+	}
+}
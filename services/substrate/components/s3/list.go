@@ -0,0 +1,187 @@
+package s3
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	storageIface "github.com/taubyte/tau/core/services/substrate/components/storage"
+	"github.com/taubyte/tau/services/substrate/components/storage/common"
+)
+
+// listObjects answers ListObjectsV2, and ListObjects when list-type isn't 2.
+func (s *Service) listObjects(w http.ResponseWriter, r *http.Request, storage storageIface.Storage, bucket string) error {
+	ctx := r.Context()
+	query := r.URL.Query()
+	v2 := query.Get("list-type") == "2"
+
+	result := &listBucketResult{
+		Xmlns:        xmlns,
+		Name:         bucket,
+		Prefix:       query.Get("prefix"),
+		Delimiter:    query.Get("delimiter"),
+		EncodingType: query.Get("encoding-type"),
+		MaxKeys:      maxKeys,
+	}
+
+	if _max := query.Get("max-keys"); _max != "" {
+		max, err := strconv.Atoi(_max)
+		if err != nil || max < 0 {
+			return errInvalidArgument.with("invalid max-keys `%s`", _max)
+		}
+		result.MaxKeys = min(max, maxKeys)
+	}
+
+	var after string
+	if v2 {
+		result.StartAfter = query.Get("start-after")
+		after = result.StartAfter
+		if token := query.Get("continuation-token"); token != "" {
+			result.ContinuationToken = token
+			_after, err := base64.RawURLEncoding.DecodeString(token)
+			if err != nil {
+				return errInvalidArgument.with("invalid continuation token")
+			}
+			after = string(_after)
+		}
+	} else {
+		marker := query.Get("marker")
+		result.Marker = &marker
+		after = marker
+	}
+
+	names, err := objectNames(ctx, storage, result.Prefix)
+	if err != nil {
+		return err
+	}
+
+	var last string
+	for _, name := range names {
+		if name <= after || (result.Delimiter != "" && strings.HasSuffix(after, result.Delimiter) && strings.HasPrefix(name, after)) {
+			continue
+		}
+
+		entry := name
+		if result.Delimiter != "" {
+			if i := strings.Index(name[len(result.Prefix):], result.Delimiter); i >= 0 {
+				entry = name[:len(result.Prefix)+i+len(result.Delimiter)]
+			}
+		}
+		if entry == last {
+			continue
+		}
+
+		if len(result.Contents)+len(result.CommonPrefixes) == result.MaxKeys {
+			result.IsTruncated = true
+			break
+		}
+
+		if entry != name {
+			result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: result.encode(entry)})
+		} else {
+			obj, err := describe(ctx, storage, name)
+			if err != nil {
+				continue
+			}
+			obj.Key = result.encode(name)
+			result.Contents = append(result.Contents, *obj)
+		}
+		last = entry
+	}
+
+	if result.IsTruncated {
+		if v2 {
+			result.NextContinuationToken = base64.RawURLEncoding.EncodeToString([]byte(last))
+		} else if result.Delimiter != "" {
+			result.NextMarker = result.encode(last)
+		}
+	}
+
+	if v2 {
+		count := len(result.Contents) + len(result.CommonPrefixes)
+		result.KeyCount = &count
+	}
+
+	result.Prefix = result.encode(result.Prefix)
+	result.Delimiter = result.encode(result.Delimiter)
+	result.StartAfter = result.encode(result.StartAfter)
+	if result.Marker != nil {
+		marker := result.encode(*result.Marker)
+		result.Marker = &marker
+	}
+
+	writeXML(w, result)
+	return nil
+}
+
+// encode applies encoding-type=url, which clients ask for so keys survive
+// XML; like S3 it leaves '/' alone.
+func (l *listBucketResult) encode(value string) string {
+	if l.EncodingType != "url" {
+		return value
+	}
+
+	return strings.ReplaceAll(url.QueryEscape(value), "%2F", "/")
+}
+
+// objectNames lists, sorted, the files under prefix that have a latest
+// version.
+func objectNames(ctx context.Context, storage storageIface.Storage, prefix string) ([]string, error) {
+	keys, err := storage.Kvdb().List(ctx, common.KvVersion+prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		name := strings.TrimPrefix(strings.TrimPrefix(key, "/"), common.KvVersion)
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// describe builds the listing entry of the latest version of name.
+func describe(ctx context.Context, storage storageIface.Storage, name string) (*object, error) {
+	version, err := latestVersion(ctx, storage, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleted(ctx, storage, name, version) {
+		return nil, errNoSuchKey
+	}
+
+	_size, err := storage.Kvdb().Get(ctx, path.Join(common.KvSize, name, strconv.Itoa(version)))
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := strconv.ParseInt(string(_size), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	cid, err := lookupCid(ctx, storage, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	info := lookupInfo(ctx, storage, name, version, cid)
+
+	return &object{
+		LastModified: time.Unix(info.Modified, 0).UTC().Format(time.RFC3339),
+		ETag:         strconv.Quote(info.ETag),
+		Size:         size,
+		StorageClass: "STANDARD",
+	}, nil
+}
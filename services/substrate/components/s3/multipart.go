@@ -0,0 +1,299 @@
+package s3
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	storageIface "github.com/taubyte/tau/core/services/substrate/components/storage"
+)
+
+// Multipart uploads are kept in the storage's database and their parts in the
+// DAG, so every request of an upload may reach a different node.
+
+func (s *Service) createMultipartUpload(w http.ResponseWriter, r *http.Request, bucket, key string, storage storageIface.Storage) error {
+	ctx := r.Context()
+
+	_id := make([]byte, 16)
+	if _, err := rand.Read(_id); err != nil {
+		return err
+	}
+	id := hex.EncodeToString(_id)
+
+	s.expireUploads(ctx, storage)
+
+	data, err := json.Marshal(&upload{Key: key, Created: time.Now().Unix()})
+	if err != nil {
+		return err
+	}
+
+	if err = storage.Kvdb().Put(ctx, uploadsPrefix+id, data); err != nil {
+		return err
+	}
+
+	writeXML(w, &initiateMultipartUploadResult{Xmlns: xmlns, Bucket: bucket, Key: key, UploadId: id})
+	return nil
+}
+
+func (s *Service) uploadPart(w http.ResponseWriter, r *http.Request, req *request, key string, storage storageIface.Storage) error {
+	ctx := r.Context()
+
+	number, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil || number < 1 || number > maxParts {
+		return errInvalidArgument.with("part number must be between 1 and %d", maxParts)
+	}
+
+	id := r.URL.Query().Get("uploadId")
+	if _, err = lookupUpload(ctx, storage, id, key); err != nil {
+		return err
+	}
+
+	file, sum, err := spool(req.body, "", int64(storage.Capacity()))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	cid, err := s.files.AddFile(file)
+	if err != nil {
+		return fmt.Errorf("storing part %d failed with: %w", number, err)
+	}
+
+	data, err := json.Marshal(&part{Cid: cid, MD5: hex.EncodeToString(sum), Size: info.Size()})
+	if err != nil {
+		return err
+	}
+
+	if err = storage.Kvdb().Put(ctx, partKey(id, number), data); err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", strconv.Quote(hex.EncodeToString(sum)))
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (s *Service) completeMultipartUpload(w http.ResponseWriter, r *http.Request, req *request, bucket, key string, storage storageIface.Storage) error {
+	ctx := r.Context()
+
+	id := r.URL.Query().Get("uploadId")
+	if _, err := lookupUpload(ctx, storage, id, key); err != nil {
+		return err
+	}
+
+	var complete completeMultipartUpload
+	if err := xml.NewDecoder(io.LimitReader(req.body, int64(MaxCompleteBodySize))).Decode(&complete); err != nil || len(complete.Parts) == 0 {
+		return errMalformedXML
+	}
+
+	parts, err := pickParts(ctx, storage, id, complete)
+	if err != nil {
+		return err
+	}
+
+	var (
+		total int64
+		sums  []byte
+	)
+	for _, p := range parts {
+		total += p.Size
+		sum, _ := hex.DecodeString(p.MD5)
+		sums = append(sums, sum...)
+	}
+
+	if total > int64(storage.Capacity()) {
+		return errEntityTooLarge
+	}
+
+	file, err := s.assemble(ctx, parts)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	sum := md5.Sum(sums)
+	etag := fmt.Sprintf("%s-%d", hex.EncodeToString(sum[:]), len(parts))
+
+	version, err := s.store(ctx, storage, key, file, etag, "")
+	if err != nil {
+		return err
+	}
+
+	s.dropUpload(ctx, storage, id)
+
+	setVersionHeader(w, storage, version)
+	writeXML(w, &completeMultipartUploadResult{
+		Xmlns:    xmlns,
+		Location: "/" + bucket + "/" + key,
+		Bucket:   bucket,
+		Key:      key,
+		ETag:     strconv.Quote(etag),
+	})
+
+	return nil
+}
+
+func (s *Service) abortMultipartUpload(w http.ResponseWriter, r *http.Request, key string, storage storageIface.Storage) error {
+	ctx := r.Context()
+
+	id := r.URL.Query().Get("uploadId")
+	if _, err := lookupUpload(ctx, storage, id, key); err != nil {
+		return err
+	}
+
+	s.dropUpload(ctx, storage, id)
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// lookupUpload returns the upload id names, if it is an upload of key that
+// hasn't expired.
+func lookupUpload(ctx context.Context, storage storageIface.Storage, id, key string) (*upload, error) {
+	if id == "" || strings.Contains(id, "/") {
+		return nil, errNoSuchUpload
+	}
+
+	data, err := storage.Kvdb().Get(ctx, uploadsPrefix+id)
+	if err != nil {
+		return nil, errNoSuchUpload
+	}
+
+	u := new(upload)
+	if err = json.Unmarshal(data, u); err != nil || u.Key != key || u.expired() {
+		return nil, errNoSuchUpload
+	}
+
+	return u, nil
+}
+
+func (u *upload) expired() bool {
+	return time.Since(time.Unix(u.Created, 0)) > UploadExpiry
+}
+
+// expireUploads drops the uploads of storage left incomplete for longer than
+// UploadExpiry.
+func (s *Service) expireUploads(ctx context.Context, storage storageIface.Storage) {
+	keys, err := storage.Kvdb().List(ctx, uploadsPrefix)
+	if err != nil {
+		return
+	}
+
+	for _, key := range keys {
+		id := key[strings.LastIndex(key, "/")+1:]
+		data, err := storage.Kvdb().Get(ctx, key)
+		if err != nil {
+			continue
+		}
+
+		u := new(upload)
+		if json.Unmarshal(data, u) != nil || u.expired() {
+			logger.Debugf("dropping expired upload %s of %s", id, u.Key)
+			s.dropUpload(ctx, storage, id)
+		}
+	}
+}
+
+// dropUpload deletes an upload and its parts. Part files are only removed
+// from this node; other copies are left to the DAG's garbage collection.
+func (s *Service) dropUpload(ctx context.Context, storage storageIface.Storage, id string) {
+	kv := storage.Kvdb()
+
+	keys, err := kv.List(ctx, partsPrefix+id+"/")
+	if err != nil {
+		logger.Errorf("listing parts of upload `%s` failed with: %s", id, err.Error())
+	}
+
+	for _, key := range keys {
+		if data, err := kv.Get(ctx, key); err == nil {
+			p := new(part)
+			if json.Unmarshal(data, p) == nil {
+				s.files.DeleteFile(p.Cid)
+			}
+		}
+		kv.Delete(ctx, key)
+	}
+
+	if err = kv.Delete(ctx, uploadsPrefix+id); err != nil {
+		logger.Errorf("deleting upload `%s` failed with: %s", id, err.Error())
+	}
+}
+
+func partKey(id string, number int) string {
+	return fmt.Sprintf("%s%s/%05d", partsPrefix, id, number)
+}
+
+// pickParts returns the uploaded parts a CompleteMultipartUpload lists, in
+// order.
+func pickParts(ctx context.Context, storage storageIface.Storage, id string, complete completeMultipartUpload) ([]*part, error) {
+	parts := make([]*part, 0, len(complete.Parts))
+	for i, p := range complete.Parts {
+		if i > 0 && p.PartNumber <= complete.Parts[i-1].PartNumber {
+			return nil, errInvalidPartOrder
+		}
+
+		uploaded := new(part)
+		data, err := storage.Kvdb().Get(ctx, partKey(id, p.PartNumber))
+		if err != nil || json.Unmarshal(data, uploaded) != nil || strings.Trim(p.ETag, `"`) != uploaded.MD5 {
+			return nil, errInvalidPart.with("part %d was not uploaded with ETag %s", p.PartNumber, p.ETag)
+		}
+
+		parts = append(parts, uploaded)
+	}
+
+	return parts, nil
+}
+
+// assemble concatenates parts into a temporary file, rewound.
+func (s *Service) assemble(ctx context.Context, parts []*part) (*os.File, error) {
+	file, err := os.CreateTemp("", "tau-s3-upload-*")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range parts {
+		if err = s.appendPart(ctx, file, p); err != nil {
+			break
+		}
+	}
+
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (s *Service) appendPart(ctx context.Context, dst *os.File, p *part) error {
+	src, err := s.files.GetFile(ctx, p.Cid)
+	if err != nil {
+		return fmt.Errorf("fetching part `%s` failed with: %w", p.Cid, err)
+	}
+	defer src.Close()
+
+	_, err = io.Copy(dst, src)
+	return err
+}
@@ -0,0 +1,274 @@
+package s3
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	storageIface "github.com/taubyte/tau/core/services/substrate/components/storage"
+	storageSpec "github.com/taubyte/tau/pkg/specs/storage"
+	"github.com/taubyte/tau/services/substrate/components/storage/common"
+)
+
+func (s *Service) putObject(w http.ResponseWriter, r *http.Request, req *request, storage storageIface.Storage, key string) error {
+	if r.Header.Get("X-Amz-Copy-Source") != "" {
+		return errNotImplemented.with("copying objects is not supported")
+	}
+
+	file, sum, err := spool(req.body, "", int64(storage.Capacity()))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	etag := hex.EncodeToString(sum)
+	version, err := s.store(r.Context(), storage, key, file, etag, r.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", strconv.Quote(etag))
+	setVersionHeader(w, storage, version)
+	w.WriteHeader(http.StatusOK)
+
+	return nil
+}
+
+// store adds r as the next version of key, or in place of it when the
+// storage isn't versioned, and records what S3 reports about it.
+func (s *Service) store(ctx context.Context, storage storageIface.Storage, key string, r io.ReadSeeker, etag, contentType string) (int, error) {
+	version, err := storage.AddFile(ctx, r, key, !storage.Config().Versioning)
+	if err != nil {
+		return 0, err
+	}
+
+	meta, err := storage.Meta(ctx, key, version)
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := json.Marshal(&objectInfo{
+		Cid:         meta.Cid().String(),
+		ETag:        etag,
+		ContentType: contentType,
+		Modified:    time.Now().Unix(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err = storage.Kvdb().Put(ctx, infoKey(key, version), info); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (s *Service) getObject(w http.ResponseWriter, r *http.Request, storage storageIface.Storage, key string) error {
+	ctx := r.Context()
+
+	version, err := requestedVersion(r)
+	if err != nil {
+		return err
+	}
+
+	if version == 0 {
+		if version, err = latestVersion(ctx, storage, key); err != nil {
+			return errNoSuchKey
+		}
+
+		if deleted(ctx, storage, key, version) {
+			w.Header().Set("X-Amz-Delete-Marker", "true")
+			return errNoSuchKey
+		}
+	}
+
+	meta, err := storage.Meta(ctx, key, version)
+	if err != nil {
+		return errNoSuchKey
+	}
+
+	info := lookupInfo(ctx, storage, key, version, meta.Cid().String())
+
+	file, err := meta.Get()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	header := w.Header()
+	header.Set("ETag", strconv.Quote(info.ETag))
+	if info.ContentType != "" {
+		header.Set("Content-Type", info.ContentType)
+	}
+	setVersionHeader(w, storage, version)
+
+	var modified time.Time
+	if info.Modified > 0 {
+		modified = time.Unix(info.Modified, 0)
+	}
+
+	http.ServeContent(w, r, path.Base(key), modified, file)
+
+	return nil
+}
+
+func (s *Service) deleteObject(w http.ResponseWriter, r *http.Request, storage storageIface.Storage, key string) error {
+	ctx := r.Context()
+
+	version, err := requestedVersion(r)
+	if err != nil {
+		return err
+	}
+
+	if version == 0 && storage.Config().Versioning {
+		return markDeleted(w, r, storage, key)
+	}
+
+	var versions []string
+	if version > 0 {
+		if _, err = lookupCid(ctx, storage, key, version); err != nil {
+			return errNoSuchKey.with("version %d of `%s` does not exist", version, key)
+		}
+		versions = []string{strconv.Itoa(version)}
+	} else {
+		// Without versioning the object is deleted. Deleting a missing key
+		// succeeds.
+		if versions, err = storage.ListVersions(ctx, key); err != nil {
+			w.WriteHeader(http.StatusNoContent)
+			return nil
+		}
+		version = -1
+	}
+
+	if err = storage.DeleteFile(ctx, key, version); err != nil {
+		return err
+	}
+
+	for _, v := range versions {
+		storage.Kvdb().Delete(ctx, path.Join(infoPrefix, key, v))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// markDeleted hides an object of a versioned storage behind a delete marker,
+// as S3 does, keeping its versions. Writing a new version shows it again.
+func markDeleted(w http.ResponseWriter, r *http.Request, storage storageIface.Storage, key string) error {
+	ctx := r.Context()
+
+	version, err := latestVersion(ctx, storage, key)
+	if err != nil {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	if err = storage.Kvdb().Put(ctx, deletedPrefix+key, []byte(strconv.Itoa(version))); err != nil {
+		return err
+	}
+
+	w.Header().Set("X-Amz-Delete-Marker", "true")
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// deleted reports whether a delete marker hides version, the latest of key.
+func deleted(ctx context.Context, storage storageIface.Storage, key string, version int) bool {
+	data, err := storage.Kvdb().Get(ctx, deletedPrefix+key)
+	if err != nil {
+		return false
+	}
+
+	marker, err := strconv.Atoi(string(data))
+	return err == nil && marker >= version
+}
+
+// spool copies body into a temporary file in dir, refusing more than limit
+// bytes, and returns it rewound with its MD5.
+func spool(body io.Reader, dir string, limit int64) (*os.File, []byte, error) {
+	file, err := os.CreateTemp(dir, "tau-s3-*")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sum := md5.New()
+	n, err := io.Copy(io.MultiWriter(file, sum), io.LimitReader(body, limit+1))
+	if err == nil && n > limit {
+		err = errEntityTooLarge
+	}
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, nil, err
+	}
+
+	return file, sum.Sum(nil), nil
+}
+
+func requestedVersion(r *http.Request) (int, error) {
+	_version := r.URL.Query().Get("versionId")
+	if _version == "" || _version == "null" {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(_version)
+	if err != nil || version < 1 {
+		return 0, errInvalidArgument.with("invalid version id `%s`", _version)
+	}
+
+	return version, nil
+}
+
+func setVersionHeader(w http.ResponseWriter, storage storageIface.Storage, version int) {
+	if storage.Config().Versioning {
+		w.Header().Set("X-Amz-Version-Id", strconv.Itoa(version))
+	}
+}
+
+func latestVersion(ctx context.Context, storage storageIface.Storage, key string) (int, error) {
+	data, err := storage.Kvdb().Get(ctx, path.Join(common.KvVersion, key))
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(string(data))
+}
+
+func infoKey(key string, version int) string {
+	return path.Join(infoPrefix, key, strconv.Itoa(version))
+}
+
+// lookupInfo returns the objectInfo of a file version, falling back to its
+// cid as ETag for files written outside S3 or replaced since.
+func lookupInfo(ctx context.Context, storage storageIface.Storage, key string, version int, cid string) *objectInfo {
+	info := new(objectInfo)
+	if data, err := storage.Kvdb().Get(ctx, infoKey(key, version)); err == nil {
+		if json.Unmarshal(data, info) == nil && info.Cid == cid {
+			return info
+		}
+	}
+
+	return &objectInfo{Cid: cid, ETag: cid}
+}
+
+// lookupCid returns the cid stored for a file version.
+func lookupCid(ctx context.Context, storage storageIface.Storage, key string, version int) (string, error) {
+	data, err := storage.Kvdb().Get(ctx, path.Join(storageSpec.FilePath.String(), key, strconv.Itoa(version)))
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
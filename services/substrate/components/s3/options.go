@@ -0,0 +1,13 @@
+package s3
+
+// Option configures the S3 service.
+type Option func(*Service) error
+
+// Hosts scopes the API to hosts. Without hosts the API is not exposed:
+// substrate serves user routes on every other host.
+func Hosts(hosts ...string) Option {
+	return func(s *Service) error {
+		s.hosts = append(s.hosts, hosts...)
+		return nil
+	}
+}
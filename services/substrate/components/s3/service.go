@@ -0,0 +1,122 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/taubyte/tau/core/services/substrate"
+	storageIface "github.com/taubyte/tau/core/services/substrate/components/storage"
+	httpService "github.com/taubyte/tau/pkg/http"
+)
+
+// New serves storages over S3, checking signatures against the secrets
+// credentials returns for the access keys auth issued.
+func New(srv substrate.Service, storages storageIface.Service, credentials Credentials, options ...Option) (*Service, error) {
+	if credentials == nil {
+		return nil, errors.New("a credentials lookup is required")
+	}
+
+	s := &Service{
+		Service:     srv,
+		storages:    storages,
+		credentials: credentials,
+		keys:        make(map[string]*cachedKey),
+	}
+
+	for _, opt := range options {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.files == nil {
+		s.files = srv.Node()
+	}
+
+	s.ctx, s.ctxC = context.WithCancel(srv.Context())
+
+	if len(s.hosts) > 0 && s.Http() != nil {
+		s.Http().LowLevel(&httpService.LowLevelDefinition{
+			Hosts:      s.hosts,
+			PathPrefix: "/",
+			Handler:    s.Handler,
+		})
+	}
+
+	return s, nil
+}
+
+// Handler serves path-style requests: /<bucket> and /<bucket>/<key>.
+func (s *Service) Handler(w http.ResponseWriter, r *http.Request) {
+	if err := s.handle(w, r); err != nil {
+		writeError(w, r, err)
+	}
+}
+
+func (s *Service) handle(w http.ResponseWriter, r *http.Request) error {
+	req, err := s.authenticate(r)
+	if err != nil {
+		return err
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket == "" {
+		return errNotImplemented.with("listing buckets is not supported, storages are listed in the project config")
+	}
+
+	storage, err := s.storages.Storage(storageIface.Context{
+		Context:   s.ctx,
+		ProjectId: req.projectId,
+		Matcher:   bucket,
+	})
+	if err != nil {
+		return errNoSuchBucket.with("%s", err.Error())
+	}
+
+	query := r.URL.Query()
+	if key == "" {
+		switch {
+		case r.Method == http.MethodHead:
+			return nil
+		case r.Method == http.MethodGet && query.Has("location"):
+			writeXML(w, &locationConstraint{Xmlns: xmlns})
+			return nil
+		case r.Method == http.MethodGet:
+			return s.listObjects(w, r, storage, bucket)
+		default:
+			return errMethodNotAllowed
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return s.getObject(w, r, storage, key)
+	case http.MethodPut:
+		if query.Has("uploadId") {
+			return s.uploadPart(w, r, req, key, storage)
+		}
+		return s.putObject(w, r, req, storage, key)
+	case http.MethodPost:
+		if query.Has("uploads") {
+			return s.createMultipartUpload(w, r, bucket, key, storage)
+		}
+		if query.Has("uploadId") {
+			return s.completeMultipartUpload(w, r, req, bucket, key, storage)
+		}
+		return errNotImplemented
+	case http.MethodDelete:
+		if query.Has("uploadId") {
+			return s.abortMultipartUpload(w, r, key, storage)
+		}
+		return s.deleteObject(w, r, storage, key)
+	default:
+		return errMethodNotAllowed
+	}
+}
+
+func (s *Service) Close() error {
+	s.ctxC()
+	return nil
+}
@@ -0,0 +1,678 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"github.com/taubyte/tau/core/kvdb"
+	"github.com/taubyte/tau/core/services/substrate"
+	storageIface "github.com/taubyte/tau/core/services/substrate/components/storage"
+	"github.com/taubyte/tau/p2p/peer"
+	"github.com/taubyte/tau/pkg/kvdb/mock"
+	storageSpec "github.com/taubyte/tau/pkg/specs/storage"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/storage/common"
+	"gotest.tools/v3/assert"
+)
+
+const (
+	testProject = "QmProject"
+	testBucket  = "assets"
+	testRegion  = "us-east-1"
+)
+
+const (
+	testAccessKey = "TAUTESTACCESSKEY"
+	testSecretKey = "test secret key"
+)
+
+// mockCredentials are the keys auth issued.
+type mockCredentials struct {
+	lock sync.Mutex
+	keys map[string][2]string
+}
+
+func newMockCredentials() *mockCredentials {
+	return &mockCredentials{keys: map[string][2]string{
+		testAccessKey:       {testProject, testSecretKey},
+		"TAUOTHERACCESSKEY": {"QmOther", "other secret key"},
+	}}
+}
+
+func (m *mockCredentials) lookup(accessKey string) (string, string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	key, ok := m.keys[accessKey]
+	if !ok {
+		return "", "", fmt.Errorf("access key `%s` not found", accessKey)
+	}
+	return key[0], key[1], nil
+}
+
+func (m *mockCredentials) revoke(accessKey string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.keys, accessKey)
+}
+
+// mockFiles is the DAG the nodes of a test share.
+type mockFiles struct {
+	lock  sync.Mutex
+	files map[string][]byte
+}
+
+func newMockFiles() *mockFiles {
+	return &mockFiles{files: make(map[string][]byte)}
+}
+
+func (m *mockFiles) AddFile(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	_cid, err := cid.V1Builder{Codec: cid.Raw, MhType: multihash.SHA2_256}.Sum(data)
+	if err != nil {
+		return "", err
+	}
+	id := _cid.String()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.files[id] = data
+
+	return id, nil
+}
+
+func (m *mockFiles) GetFile(ctx context.Context, id string) (peer.ReadSeekCloser, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	data, ok := m.files[id]
+	if !ok {
+		return nil, fmt.Errorf("file `%s` not found", id)
+	}
+	return fileReader{bytes.NewReader(data)}, nil
+}
+
+type fileReader struct{ *bytes.Reader }
+
+func (fileReader) Close() error { return nil }
+
+func (m *mockFiles) DeleteFile(id string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.files, id)
+	return nil
+}
+
+type mockNode struct {
+	substrate.Service
+	ctx context.Context
+}
+
+func (m *mockNode) Context() context.Context { return m.ctx }
+
+type mockStorages struct {
+	storageIface.Service
+	storage *mockStorage
+}
+
+func (m *mockStorages) Storage(ctx storageIface.Context) (storageIface.Storage, error) {
+	if ctx.ProjectId != testProject || ctx.Matcher != testBucket {
+		return nil, fmt.Errorf("`%s` did not match with any storages", ctx.Matcher)
+	}
+	return m.storage, nil
+}
+
+type mockMeta struct {
+	storageIface.Meta
+	data []byte
+	cid  cid.Cid
+}
+
+func (m *mockMeta) Get() (io.ReadSeekCloser, error) {
+	return readSeekCloser{bytes.NewReader(m.data)}, nil
+}
+
+func (m *mockMeta) Cid() cid.Cid { return m.cid }
+
+type readSeekCloser struct{ io.ReadSeeker }
+
+func (readSeekCloser) Close() error { return nil }
+
+// mockStorage keeps files in memory under the key layout of the real store.
+type mockStorage struct {
+	storageIface.Storage
+	kv     kvdb.KVDB
+	config *structureSpec.Storage
+	files  map[string][]byte
+}
+
+func newMockStorage(t *testing.T, versioning bool) *mockStorage {
+	kv, err := mock.New().New(nil, t.Name(), 0)
+	assert.NilError(t, err)
+
+	return &mockStorage{
+		kv:     kv,
+		config: &structureSpec.Storage{Size: 1 << 20, Versioning: versioning},
+		files:  make(map[string][]byte),
+	}
+}
+
+func (m *mockStorage) Kvdb() kvdb.KVDB                { return m.kv }
+func (m *mockStorage) Config() *structureSpec.Storage { return m.config }
+func (m *mockStorage) Capacity() int                  { return int(m.config.Size) }
+
+func (m *mockStorage) AddFile(ctx context.Context, r io.ReadSeeker, name string, replace bool) (int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+
+	version := 1
+	if latest, err := latestVersion(ctx, m, name); err == nil && !replace {
+		version = latest + 1
+	}
+
+	_cid, err := cid.V1Builder{Codec: cid.Raw, MhType: multihash.SHA2_256}.Sum(data)
+	if err != nil {
+		return 0, err
+	}
+
+	v := strconv.Itoa(version)
+	m.files[_cid.String()] = data
+	m.kv.Put(ctx, path.Join(storageSpec.FilePath.String(), name, v), []byte(_cid.String()))
+	m.kv.Put(ctx, path.Join(common.KvVersion, name), []byte(v))
+	m.kv.Put(ctx, path.Join(common.KvSize, name, v), []byte(strconv.Itoa(len(data))))
+
+	return version, nil
+}
+
+func (m *mockStorage) Meta(ctx context.Context, name string, version int) (storageIface.Meta, error) {
+	_cid, err := lookupCid(ctx, m, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := cid.Decode(_cid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mockMeta{data: m.files[_cid], cid: c}, nil
+}
+
+func (m *mockStorage) ListVersions(ctx context.Context, name string) ([]string, error) {
+	keys, err := m.kv.List(ctx, path.Join(storageSpec.FilePath.String(), name)+"/")
+	if err != nil || len(keys) == 0 {
+		return nil, errors.New("no available versions")
+	}
+
+	versions := make([]string, 0, len(keys))
+	for _, key := range keys {
+		versions = append(versions, path.Base(key))
+	}
+	return versions, nil
+}
+
+func (m *mockStorage) DeleteFile(ctx context.Context, name string, version int) error {
+	versions, err := m.ListVersions(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if version > 0 {
+		versions = []string{strconv.Itoa(version)}
+	}
+
+	for _, v := range versions {
+		m.kv.Delete(ctx, path.Join(storageSpec.FilePath.String(), name, v))
+		m.kv.Delete(ctx, path.Join(common.KvSize, name, v))
+	}
+
+	if _, err := m.ListVersions(ctx, name); err != nil {
+		m.kv.Delete(ctx, path.Join(common.KvVersion, name))
+	}
+
+	return nil
+}
+
+type client struct {
+	t         *testing.T
+	url       string
+	accessKey string
+	secretKey string
+}
+
+func newTestService(t *testing.T, versioning bool) (*client, *mockStorage) {
+	storage := newMockStorage(t, versioning)
+	return newTestNode(t, storage, newMockCredentials(), newMockFiles()), storage
+}
+
+// newTestNode serves storage from one more node.
+func newTestNode(t *testing.T, storage *mockStorage, credentials *mockCredentials, files *mockFiles) *client {
+	withFiles := func(s *Service) error {
+		s.files = files
+		return nil
+	}
+
+	s, err := New(&mockNode{ctx: t.Context()}, &mockStorages{storage: storage}, credentials.lookup, withFiles)
+	assert.NilError(t, err)
+	t.Cleanup(func() { s.Close() })
+
+	server := httptest.NewServer(http.HandlerFunc(s.Handler))
+	t.Cleanup(server.Close)
+
+	return &client{t: t, url: server.URL, accessKey: testAccessKey, secretKey: testSecretKey}
+}
+
+// do sends a request signed with a hashed payload.
+func (c *client) do(method, target string, body []byte, headers ...string) *http.Response {
+	r, err := http.NewRequest(method, c.url+target, bytes.NewReader(body))
+	assert.NilError(c.t, err)
+
+	for i := 0; i+1 < len(headers); i += 2 {
+		r.Header.Set(headers[i], headers[i+1])
+	}
+
+	hash := sha256.Sum256(body)
+	c.sign(r, hex.EncodeToString(hash[:]))
+
+	resp, err := http.DefaultClient.Do(r)
+	assert.NilError(c.t, err)
+	c.t.Cleanup(func() { resp.Body.Close() })
+
+	return resp
+}
+
+func (c *client) sign(r *http.Request, payloadHash string) (scope, []byte, string) {
+	now := time.Now().UTC()
+	sc := scope{date: now.Format(scopeDateFormat), region: testRegion, service: signingService}
+	amzDate := now.Format(amzDateFormat)
+
+	r.Header.Set("X-Amz-Date", amzDate)
+	r.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signed := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	key := deriveKey(c.secretKey, sc)
+	signature := sign(key, stringToSign(amzDate, sc, canonicalRequest(r, signed, canonicalQuery(r.URL.Query(), ""), payloadHash)))
+
+	r.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, c.accessKey, sc, strings.Join(signed, ";"), signature))
+
+	return sc, key, signature
+}
+
+func (c *client) presign(method, target string, expires time.Duration) string {
+	now := time.Now().UTC()
+	sc := scope{date: now.Format(scopeDateFormat), region: testRegion, service: signingService}
+	amzDate := now.Format(amzDateFormat)
+
+	u, err := url.Parse(c.url + target)
+	assert.NilError(c.t, err)
+
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", signingAlgorithm)
+	query.Set("X-Amz-Credential", c.accessKey+"/"+sc.String())
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	u.RawQuery = query.Encode()
+
+	r := &http.Request{Method: method, URL: u, Host: u.Host, Header: make(http.Header)}
+	canonical := canonicalRequest(r, []string{"host"}, canonicalQuery(query, ""), unsignedPayload)
+	query.Set("X-Amz-Signature", sign(deriveKey(c.secretKey, sc), stringToSign(amzDate, sc, canonical)))
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+func readAll(t *testing.T, resp *http.Response) string {
+	data, err := io.ReadAll(resp.Body)
+	assert.NilError(t, err)
+	return string(data)
+}
+
+func decode(t *testing.T, resp *http.Response, v any) {
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.NilError(t, xml.NewDecoder(resp.Body).Decode(v))
+}
+
+func errorCode(t *testing.T, resp *http.Response) string {
+	var e errorResponse
+	assert.NilError(t, xml.NewDecoder(resp.Body).Decode(&e))
+	return e.Code
+}
+
+func TestPutGetDelete(t *testing.T) {
+	c, _ := newTestService(t, false)
+
+	resp := c.do(http.MethodPut, "/assets/docs/hello world.txt", []byte("hello world"), "Content-Type", "text/plain")
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, resp.Header.Get("ETag"), `"5eb63bbbe01eeed093cb22bb8f5acdc3"`)
+
+	resp = c.do(http.MethodGet, "/assets/docs/hello world.txt", nil)
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, readAll(t, resp), "hello world")
+	assert.Equal(t, resp.Header.Get("Content-Type"), "text/plain")
+	assert.Equal(t, resp.Header.Get("ETag"), `"5eb63bbbe01eeed093cb22bb8f5acdc3"`)
+
+	resp = c.do(http.MethodGet, "/assets/docs/hello world.txt", nil, "Range", "bytes=6-")
+	assert.Equal(t, resp.StatusCode, http.StatusPartialContent)
+	assert.Equal(t, readAll(t, resp), "world")
+
+	resp = c.do(http.MethodHead, "/assets/docs/hello world.txt", nil)
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, resp.ContentLength, int64(11))
+
+	// Unversioned storages replace in place.
+	c.do(http.MethodPut, "/assets/docs/hello world.txt", []byte("bye"))
+	resp = c.do(http.MethodGet, "/assets/docs/hello world.txt", nil)
+	assert.Equal(t, readAll(t, resp), "bye")
+	assert.Equal(t, resp.Header.Get("X-Amz-Version-Id"), "")
+
+	resp = c.do(http.MethodDelete, "/assets/docs/hello world.txt", nil)
+	assert.Equal(t, resp.StatusCode, http.StatusNoContent)
+
+	resp = c.do(http.MethodGet, "/assets/docs/hello world.txt", nil)
+	assert.Equal(t, resp.StatusCode, http.StatusNotFound)
+	assert.Equal(t, errorCode(t, resp), "NoSuchKey")
+
+	// Deleting a missing key succeeds, as on S3.
+	resp = c.do(http.MethodDelete, "/assets/missing", nil)
+	assert.Equal(t, resp.StatusCode, http.StatusNoContent)
+}
+
+func TestVersions(t *testing.T) {
+	c, _ := newTestService(t, true)
+
+	resp := c.do(http.MethodPut, "/assets/file", []byte("one"))
+	assert.Equal(t, resp.Header.Get("X-Amz-Version-Id"), "1")
+
+	resp = c.do(http.MethodPut, "/assets/file", []byte("two"))
+	assert.Equal(t, resp.Header.Get("X-Amz-Version-Id"), "2")
+
+	resp = c.do(http.MethodGet, "/assets/file", nil)
+	assert.Equal(t, readAll(t, resp), "two")
+
+	resp = c.do(http.MethodGet, "/assets/file?versionId=1", nil)
+	assert.Equal(t, readAll(t, resp), "one")
+	assert.Equal(t, resp.Header.Get("X-Amz-Version-Id"), "1")
+
+	resp = c.do(http.MethodDelete, "/assets/file?versionId=3", nil)
+	assert.Equal(t, resp.StatusCode, http.StatusNotFound)
+
+	// Without a version, a delete marker hides the object and keeps its
+	// versions.
+	resp = c.do(http.MethodDelete, "/assets/file", nil)
+	assert.Equal(t, resp.StatusCode, http.StatusNoContent)
+	assert.Equal(t, resp.Header.Get("X-Amz-Delete-Marker"), "true")
+
+	resp = c.do(http.MethodGet, "/assets/file", nil)
+	assert.Equal(t, errorCode(t, resp), "NoSuchKey")
+	assert.Equal(t, resp.Header.Get("X-Amz-Delete-Marker"), "true")
+
+	var listed listBucketResult
+	decode(t, c.do(http.MethodGet, "/assets?list-type=2", nil), &listed)
+	assert.Equal(t, len(listed.Contents), 0)
+
+	resp = c.do(http.MethodGet, "/assets/file?versionId=2", nil)
+	assert.Equal(t, readAll(t, resp), "two")
+
+	resp = c.do(http.MethodPut, "/assets/file", []byte("three"))
+	assert.Equal(t, resp.Header.Get("X-Amz-Version-Id"), "3")
+
+	resp = c.do(http.MethodGet, "/assets/file", nil)
+	assert.Equal(t, readAll(t, resp), "three")
+}
+
+func TestAuthentication(t *testing.T) {
+	c, _ := newTestService(t, false)
+	c.do(http.MethodPut, "/assets/file", []byte("data"))
+
+	resp, err := http.Get(c.url + "/assets/file")
+	assert.NilError(t, err)
+	assert.Equal(t, resp.StatusCode, http.StatusForbidden)
+	assert.Equal(t, errorCode(t, resp), "AccessDenied")
+
+	// Credentials of another project don't open this one.
+	other := *c
+	other.accessKey, other.secretKey = "TAUOTHERACCESSKEY", "other secret key"
+	resp = other.do(http.MethodGet, "/assets/file", nil)
+	assert.Equal(t, resp.StatusCode, http.StatusNotFound)
+	assert.Equal(t, errorCode(t, resp), "NoSuchBucket")
+
+	forged := *c
+	forged.secretKey = "forged"
+	resp = forged.do(http.MethodGet, "/assets/file", nil)
+	assert.Equal(t, resp.StatusCode, http.StatusForbidden)
+	assert.Equal(t, errorCode(t, resp), "SignatureDoesNotMatch")
+
+	unknown := *c
+	unknown.accessKey = "TAUUNKNOWNACCESSKEY"
+	resp = unknown.do(http.MethodGet, "/assets/file", nil)
+	assert.Equal(t, resp.StatusCode, http.StatusForbidden)
+	assert.Equal(t, errorCode(t, resp), "InvalidAccessKeyId")
+
+	resp = c.do(http.MethodGet, "/missing/file", nil)
+	assert.Equal(t, errorCode(t, resp), "NoSuchBucket")
+
+	// The signed payload hash must match the body.
+	r, err := http.NewRequest(http.MethodPut, c.url+"/assets/file", strings.NewReader("tampered"))
+	assert.NilError(t, err)
+	hash := sha256.Sum256([]byte("data"))
+	c.sign(r, hex.EncodeToString(hash[:]))
+	resp, err = http.DefaultClient.Do(r)
+	assert.NilError(t, err)
+	assert.Equal(t, errorCode(t, resp), "XAmzContentSHA256Mismatch")
+}
+
+func TestPresigned(t *testing.T) {
+	c, _ := newTestService(t, false)
+	c.do(http.MethodPut, "/assets/file", []byte("data"))
+
+	resp, err := http.Get(c.presign(http.MethodGet, "/assets/file", time.Minute))
+	assert.NilError(t, err)
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, readAll(t, resp), "data")
+
+	tampered := strings.Replace(c.presign(http.MethodGet, "/assets/file", time.Minute), "/assets/file", "/assets/other", 1)
+	resp, err = http.Get(tampered)
+	assert.NilError(t, err)
+	assert.Equal(t, errorCode(t, resp), "SignatureDoesNotMatch")
+}
+
+func TestStreamingPut(t *testing.T) {
+	c, _ := newTestService(t, false)
+
+	r, err := http.NewRequest(http.MethodPut, c.url+"/assets/streamed", nil)
+	assert.NilError(t, err)
+	r.Header.Set("Content-Encoding", "aws-chunked")
+	sc, key, seed := c.sign(r, streamingPayload)
+
+	signer := &chunkSigner{key: key, date: r.Header.Get("X-Amz-Date"), scope: sc, previous: seed}
+	var body bytes.Buffer
+	for _, chunk := range []string{"hello ", "streamed world", ""} {
+		hash := sha256.Sum256([]byte(chunk))
+		signature := sign(key, chunkAlgorithm+"\n"+signer.date+"\n"+sc.String()+"\n"+signer.previous+"\n"+emptySHA256+"\n"+hex.EncodeToString(hash[:]))
+		signer.previous = signature
+		fmt.Fprintf(&body, "%x;chunk-signature=%s\r\n%s\r\n", len(chunk), signature, chunk)
+	}
+	r.Body = io.NopCloser(&body)
+
+	resp, err := http.DefaultClient.Do(r)
+	assert.NilError(t, err)
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+
+	resp = c.do(http.MethodGet, "/assets/streamed", nil)
+	assert.Equal(t, readAll(t, resp), "hello streamed world")
+}
+
+func TestListObjects(t *testing.T) {
+	c, _ := newTestService(t, false)
+	for _, key := range []string{"a.txt", "dir/b.txt", "dir/c.txt", "dir/sub/d.txt", "e.txt"} {
+		c.do(http.MethodPut, "/assets/"+key, []byte(key))
+	}
+
+	var result listBucketResult
+	decode(t, c.do(http.MethodGet, "/assets?list-type=2&delimiter=/", nil), &result)
+	assert.Equal(t, *result.KeyCount, 3)
+	assert.Equal(t, result.Contents[0].Key, "a.txt")
+	assert.Equal(t, result.Contents[0].Size, int64(5))
+	assert.Equal(t, result.Contents[1].Key, "e.txt")
+	assert.Equal(t, result.CommonPrefixes[0].Prefix, "dir/")
+
+	result = listBucketResult{}
+	decode(t, c.do(http.MethodGet, "/assets?list-type=2&prefix=dir/&delimiter=/", nil), &result)
+	assert.Equal(t, len(result.Contents), 2)
+	assert.Equal(t, result.CommonPrefixes[0].Prefix, "dir/sub/")
+
+	// Page through everything two keys at a time.
+	var keys []string
+	token := ""
+	for {
+		result = listBucketResult{}
+		decode(t, c.do(http.MethodGet, "/assets?list-type=2&max-keys=2&continuation-token="+token, nil), &result)
+		for _, obj := range result.Contents {
+			keys = append(keys, obj.Key)
+		}
+		if !result.IsTruncated {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+	assert.DeepEqual(t, keys, []string{"a.txt", "dir/b.txt", "dir/c.txt", "dir/sub/d.txt", "e.txt"})
+}
+
+func TestMultipartUpload(t *testing.T) {
+	c, _ := newTestService(t, false)
+
+	var initiated initiateMultipartUploadResult
+	decode(t, c.do(http.MethodPost, "/assets/big?uploads", nil), &initiated)
+	id := initiated.UploadId
+
+	parts := []string{"first part, ", "second part"}
+	etags := make([]string, len(parts))
+	for i, p := range parts {
+		resp := c.do(http.MethodPut, fmt.Sprintf("/assets/big?partNumber=%d&uploadId=%s", i+1, id), []byte(p))
+		assert.Equal(t, resp.StatusCode, http.StatusOK)
+		etags[i] = resp.Header.Get("ETag")
+	}
+
+	wrong := `<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>"bad"</ETag></Part></CompleteMultipartUpload>`
+	resp := c.do(http.MethodPost, "/assets/big?uploadId="+id, []byte(wrong))
+	assert.Equal(t, errorCode(t, resp), "InvalidPart")
+
+	var complete bytes.Buffer
+	complete.WriteString("<CompleteMultipartUpload>")
+	for i, etag := range etags {
+		fmt.Fprintf(&complete, "<Part><PartNumber>%d</PartNumber><ETag>%s</ETag></Part>", i+1, etag)
+	}
+	complete.WriteString("</CompleteMultipartUpload>")
+
+	var completed completeMultipartUploadResult
+	decode(t, c.do(http.MethodPost, "/assets/big?uploadId="+id, complete.Bytes()), &completed)
+	assert.Assert(t, strings.HasSuffix(completed.ETag, `-2"`))
+
+	resp = c.do(http.MethodGet, "/assets/big", nil)
+	assert.Equal(t, readAll(t, resp), "first part, second part")
+	assert.Equal(t, resp.Header.Get("ETag"), completed.ETag)
+
+	// The upload is gone once completed.
+	resp = c.do(http.MethodPut, "/assets/big?partNumber=1&uploadId="+id, []byte("late"))
+	assert.Equal(t, errorCode(t, resp), "NoSuchUpload")
+
+	decode(t, c.do(http.MethodPost, "/assets/aborted?uploads", nil), &initiated)
+	resp = c.do(http.MethodDelete, "/assets/aborted?uploadId="+initiated.UploadId, nil)
+	assert.Equal(t, resp.StatusCode, http.StatusNoContent)
+}
+
+func TestRevokedKey(t *testing.T) {
+	defer func(ttl time.Duration) { CredentialsCacheTTL = ttl }(CredentialsCacheTTL)
+	CredentialsCacheTTL = 0
+
+	credentials := newMockCredentials()
+	c := newTestNode(t, newMockStorage(t, false), credentials, newMockFiles())
+
+	resp := c.do(http.MethodPut, "/assets/file", []byte("data"))
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+
+	credentials.revoke(testAccessKey)
+
+	resp = c.do(http.MethodGet, "/assets/file", nil)
+	assert.Equal(t, resp.StatusCode, http.StatusForbidden)
+	assert.Equal(t, errorCode(t, resp), "InvalidAccessKeyId")
+}
+
+func TestMultipartAcrossNodes(t *testing.T) {
+	storage := newMockStorage(t, false)
+	credentials, files := newMockCredentials(), newMockFiles()
+	a := newTestNode(t, storage, credentials, files)
+	b := newTestNode(t, storage, credentials, files)
+
+	var initiated initiateMultipartUploadResult
+	decode(t, a.do(http.MethodPost, "/assets/big?uploads", nil), &initiated)
+	id := initiated.UploadId
+
+	first := a.do(http.MethodPut, "/assets/big?partNumber=1&uploadId="+id, []byte("from a, "))
+	assert.Equal(t, first.StatusCode, http.StatusOK)
+	second := b.do(http.MethodPut, "/assets/big?partNumber=2&uploadId="+id, []byte("from b"))
+	assert.Equal(t, second.StatusCode, http.StatusOK)
+
+	complete := fmt.Sprintf(
+		"<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>%s</ETag></Part><Part><PartNumber>2</PartNumber><ETag>%s</ETag></Part></CompleteMultipartUpload>",
+		first.Header.Get("ETag"), second.Header.Get("ETag"),
+	)
+	resp := a.do(http.MethodPost, "/assets/big?uploadId="+id, []byte(complete))
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+
+	resp = b.do(http.MethodGet, "/assets/big", nil)
+	assert.Equal(t, readAll(t, resp), "from a, from b")
+
+	// Parts are dropped with the upload.
+	assert.Equal(t, len(files.files), 0)
+
+	resp = b.do(http.MethodDelete, "/assets/big?uploadId="+id, nil)
+	assert.Equal(t, errorCode(t, resp), "NoSuchUpload")
+}
+
+func TestMultipartExpiry(t *testing.T) {
+	c, _ := newTestService(t, false)
+
+	var initiated initiateMultipartUploadResult
+	decode(t, c.do(http.MethodPost, "/assets/big?uploads", nil), &initiated)
+
+	defer func(expiry time.Duration) { UploadExpiry = expiry }(UploadExpiry)
+	UploadExpiry = -time.Second
+
+	resp := c.do(http.MethodPut, "/assets/big?partNumber=1&uploadId="+initiated.UploadId, []byte("late"))
+	assert.Equal(t, errorCode(t, resp), "NoSuchUpload")
+}
+
+func TestEntityTooLarge(t *testing.T) {
+	c, storage := newTestService(t, false)
+	storage.config.Size = 4
+
+	resp := c.do(http.MethodPut, "/assets/file", []byte("too large"))
+	assert.Equal(t, resp.StatusCode, http.StatusBadRequest)
+	assert.Equal(t, errorCode(t, resp), "EntityTooLarge")
+}
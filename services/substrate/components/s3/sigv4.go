@@ -0,0 +1,380 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// authenticate checks the SigV4 signature of r, from its Authorization header
+// or, for presigned URLs, its query.
+func (s *Service) authenticate(r *http.Request) (*request, error) {
+	if r.URL.Query().Has("X-Amz-Algorithm") {
+		return s.authenticatePresigned(r)
+	}
+
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return nil, errAccessDenied.with("anonymous requests are not allowed")
+	}
+
+	auth, err := parseAuthorization(header)
+	if err != nil {
+		return nil, err
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	date, err := time.Parse(amzDateFormat, amzDate)
+	if err != nil {
+		return nil, errAccessDenied.with("missing or malformed X-Amz-Date")
+	}
+
+	if skew := time.Since(date); skew > MaxClockSkew || skew < -MaxClockSkew {
+		return nil, errRequestTimeTooSkewed
+	}
+
+	if auth.scope.date != date.Format(scopeDateFormat) {
+		return nil, errAuthorizationMalformed.with("credential date `%s` does not match X-Amz-Date", auth.scope.date)
+	}
+
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash == "" {
+		return nil, errInvalidArgument.with("missing X-Amz-Content-Sha256")
+	}
+
+	key, projectId, err := s.signingKey(auth.accessKey, auth.scope)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical := canonicalRequest(r, auth.signedHeaders, canonicalQuery(r.URL.Query(), ""), payloadHash)
+	signature := sign(key, stringToSign(amzDate, auth.scope, canonical))
+	if !hmac.Equal([]byte(signature), []byte(auth.signature)) {
+		return nil, errSignatureDoesNotMatch
+	}
+
+	req := &request{projectId: projectId}
+	switch payloadHash {
+	case unsignedPayload:
+		req.body = r.Body
+	case streamingPayload:
+		req.body = newChunkedReader(r.Body, &chunkSigner{key: key, date: amzDate, scope: auth.scope, previous: signature})
+	case streamingUnsignedTrailer:
+		req.body = newChunkedReader(r.Body, nil)
+	default:
+		want, err := hex.DecodeString(payloadHash)
+		if err != nil || len(want) != sha256.Size {
+			return nil, errInvalidArgument.with("unsupported X-Amz-Content-Sha256 `%s`", payloadHash)
+		}
+		req.body = &hashReader{r: r.Body, hash: sha256.New(), want: want}
+	}
+
+	return req, nil
+}
+
+func (s *Service) authenticatePresigned(r *http.Request) (*request, error) {
+	query := r.URL.Query()
+	if query.Get("X-Amz-Algorithm") != signingAlgorithm {
+		return nil, errAuthorizationQuery.with("unsupported X-Amz-Algorithm `%s`", query.Get("X-Amz-Algorithm"))
+	}
+
+	auth := &authorization{
+		signedHeaders: strings.Split(query.Get("X-Amz-SignedHeaders"), ";"),
+		signature:     query.Get("X-Amz-Signature"),
+	}
+
+	var err error
+	if auth.accessKey, auth.scope, err = parseCredential(query.Get("X-Amz-Credential")); err != nil {
+		return nil, errAuthorizationQuery.with("%s", err.(*apiError).Message)
+	}
+
+	amzDate := query.Get("X-Amz-Date")
+	date, err := time.Parse(amzDateFormat, amzDate)
+	if err != nil || auth.scope.date != date.Format(scopeDateFormat) {
+		return nil, errAuthorizationQuery.with("missing or malformed X-Amz-Date")
+	}
+
+	expires, err := strconv.Atoi(query.Get("X-Amz-Expires"))
+	if err != nil || expires < 1 || time.Duration(expires)*time.Second > MaxPresignExpiry {
+		return nil, errAuthorizationQuery.with("X-Amz-Expires must be between 1 and %d seconds", int(MaxPresignExpiry.Seconds()))
+	}
+
+	now := time.Now()
+	if now.Before(date.Add(-MaxClockSkew)) {
+		return nil, errRequestTimeTooSkewed
+	}
+	if now.After(date.Add(time.Duration(expires) * time.Second)) {
+		return nil, errAccessDenied.with("request has expired")
+	}
+
+	if err = checkSignedHeaders(auth.signedHeaders); err != nil {
+		return nil, err
+	}
+
+	key, projectId, err := s.signingKey(auth.accessKey, auth.scope)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical := canonicalRequest(r, auth.signedHeaders, canonicalQuery(query, "X-Amz-Signature"), unsignedPayload)
+	if !hmac.Equal([]byte(sign(key, stringToSign(amzDate, auth.scope, canonical))), []byte(auth.signature)) {
+		return nil, errSignatureDoesNotMatch
+	}
+
+	return &request{projectId: projectId, body: r.Body}, nil
+}
+
+// parseAuthorization parses
+//
+//	AWS4-HMAC-SHA256 Credential=<key>/<scope>, SignedHeaders=<a;b>, Signature=<hex>
+func parseAuthorization(header string) (*authorization, error) {
+	fields, ok := strings.CutPrefix(header, signingAlgorithm+" ")
+	if !ok {
+		return nil, errAuthorizationMalformed.with("unsupported authorization algorithm")
+	}
+
+	auth := new(authorization)
+	for _, field := range strings.Split(fields, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		switch name {
+		case "Credential":
+			var err error
+			if auth.accessKey, auth.scope, err = parseCredential(value); err != nil {
+				return nil, err
+			}
+		case "SignedHeaders":
+			auth.signedHeaders = strings.Split(value, ";")
+		case "Signature":
+			auth.signature = value
+		}
+	}
+
+	if auth.accessKey == "" || auth.signature == "" {
+		return nil, errAuthorizationMalformed
+	}
+
+	if err := checkSignedHeaders(auth.signedHeaders); err != nil {
+		return nil, err
+	}
+
+	return auth, nil
+}
+
+// parseCredential parses <key>/<date>/<region>/s3/aws4_request.
+func parseCredential(credential string) (string, scope, error) {
+	parts := strings.Split(credential, "/")
+	if len(parts) != 5 || parts[0] == "" || parts[4] != scopeTerminator {
+		return "", scope{}, errAuthorizationMalformed.with("malformed credential `%s`", credential)
+	}
+
+	if parts[3] != signingService {
+		return "", scope{}, errAuthorizationMalformed.with("credential is scoped to service `%s`, not s3", parts[3])
+	}
+
+	return parts[0], scope{date: parts[1], region: parts[2], service: parts[3]}, nil
+}
+
+func checkSignedHeaders(headers []string) error {
+	for _, h := range headers {
+		if h == "host" {
+			return nil
+		}
+	}
+
+	return errAuthorizationMalformed.with("the host header must be signed")
+}
+
+func (sc scope) String() string {
+	return sc.date + "/" + sc.region + "/" + sc.service + "/" + scopeTerminator
+}
+
+// signingKey derives the SigV4 key of a scope from the secret of accessKey,
+// and returns the project the key was issued for.
+func (s *Service) signingKey(accessKey string, sc scope) ([]byte, string, error) {
+	projectId, secret, err := s.lookupKey(accessKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return deriveKey(secret, sc), projectId, nil
+}
+
+// lookupKey returns the project and secret of accessKey. Answers are kept for
+// CredentialsCacheTTL, so a revoked key stops working within that long, and
+// unknown keys for InvalidKeyCacheTTL. Concurrent lookups of a key share one
+// call to auth.
+func (s *Service) lookupKey(accessKey string) (string, string, error) {
+	cached, ok := s.cachedKey(accessKey)
+	if !ok {
+		v, _, _ := s.keyLookups.Do(accessKey, func() (interface{}, error) {
+			return s.fetchKey(accessKey), nil
+		})
+		cached = v.(*cachedKey)
+	}
+
+	if cached.invalid {
+		return "", "", errInvalidAccessKeyId
+	}
+
+	return cached.projectId, cached.secret, nil
+}
+
+func (s *Service) cachedKey(accessKey string) (*cachedKey, bool) {
+	s.keysLock.Lock()
+	defer s.keysLock.Unlock()
+
+	cached, ok := s.keys[accessKey]
+	if !ok || cached.expired() {
+		return nil, false
+	}
+
+	return cached, true
+}
+
+// fetchKey asks auth for accessKey and caches the answer, dropping the
+// entries that expired meanwhile.
+func (s *Service) fetchKey(accessKey string) *cachedKey {
+	cached := &cachedKey{fetched: time.Now()}
+
+	var err error
+	if cached.projectId, cached.secret, err = s.credentials(accessKey); err != nil {
+		logger.Debugf("looking up access key `%s` failed with: %s", accessKey, err.Error())
+		cached = &cachedKey{invalid: true, fetched: cached.fetched}
+	}
+
+	s.keysLock.Lock()
+	defer s.keysLock.Unlock()
+
+	for key, other := range s.keys {
+		if other.expired() {
+			delete(s.keys, key)
+		}
+	}
+	s.keys[accessKey] = cached
+
+	return cached
+}
+
+func (k *cachedKey) expired() bool {
+	if k.invalid {
+		return time.Since(k.fetched) >= InvalidKeyCacheTTL
+	}
+
+	return time.Since(k.fetched) >= CredentialsCacheTTL
+}
+
+func deriveKey(secret string, sc scope) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), sc.date)
+	key = hmacSHA256(key, sc.region)
+	key = hmacSHA256(key, sc.service)
+	return hmacSHA256(key, scopeTerminator)
+}
+
+func stringToSign(amzDate string, sc scope, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	return signingAlgorithm + "\n" + amzDate + "\n" + sc.String() + "\n" + hex.EncodeToString(hash[:])
+}
+
+func sign(key []byte, stringToSign string) string {
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func canonicalRequest(r *http.Request, signedHeaders []string, query, payloadHash string) string {
+	var b strings.Builder
+	b.WriteString(r.Method + "\n")
+	b.WriteString(uriEncode(r.URL.Path, false) + "\n")
+	b.WriteString(query + "\n")
+
+	for _, name := range signedHeaders {
+		b.WriteString(name + ":" + canonicalHeader(r, name) + "\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString(strings.Join(signedHeaders, ";") + "\n")
+	b.WriteString(payloadHash)
+
+	return b.String()
+}
+
+// canonicalHeader is the trimmed, comma joined value of a header; net/http
+// moves Host and Content-Length out of the header map.
+func canonicalHeader(r *http.Request, name string) string {
+	switch name {
+	case "host":
+		return r.Host
+	case "content-length":
+		if r.Header.Get("Content-Length") == "" && r.ContentLength >= 0 {
+			return strconv.FormatInt(r.ContentLength, 10)
+		}
+	}
+
+	values := r.Header.Values(name)
+	for i, v := range values {
+		values[i] = strings.Join(strings.Fields(v), " ")
+	}
+
+	return strings.Join(values, ",")
+}
+
+// canonicalQuery encodes query sorted by name then value, leaving out skip.
+func canonicalQuery(query url.Values, skip string) string {
+	type pair struct{ name, value string }
+
+	pairs := make([]pair, 0, len(query))
+	for name, values := range query {
+		if name == skip {
+			continue
+		}
+		for _, v := range values {
+			pairs = append(pairs, pair{uriEncode(name, true), uriEncode(v, true)})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].name != pairs[j].name {
+			return pairs[i].name < pairs[j].name
+		}
+		return pairs[i].value < pairs[j].value
+	})
+
+	encoded := make([]string, len(pairs))
+	for i, p := range pairs {
+		encoded[i] = p.name + "=" + p.value
+	}
+
+	return strings.Join(encoded, "&")
+}
+
+// uriEncode escapes everything but RFC 3986 unreserved characters, and '/'
+// unless encodeSlash.
+func uriEncode(s string, encodeSlash bool) string {
+	const hexDigits = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&0x0f])
+		}
+	}
+
+	return b.String()
+}
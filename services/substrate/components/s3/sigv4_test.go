@@ -0,0 +1,165 @@
+package s3
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+// Vectors from the AWS Signature Version 4 examples for S3.
+const (
+	exampleSecret = "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"
+	exampleDate   = "20130524T000000Z"
+)
+
+var exampleScope = scope{date: "20130524", region: "us-east-1", service: "s3"}
+
+func TestSignatureGetObject(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/test.txt", nil)
+	r.Host = "examplebucket.s3.amazonaws.com"
+	r.Header.Set("Range", "bytes=0-9")
+	r.Header.Set("X-Amz-Content-Sha256", emptySHA256)
+	r.Header.Set("X-Amz-Date", exampleDate)
+
+	signed := []string{"host", "range", "x-amz-content-sha256", "x-amz-date"}
+	canonical := canonicalRequest(r, signed, canonicalQuery(r.URL.Query(), ""), emptySHA256)
+
+	assert.Equal(t, sign(deriveKey(exampleSecret, exampleScope), stringToSign(exampleDate, exampleScope, canonical)),
+		"f0e8bdb87c964420e857bd35b5d6ed310bd44f0170aba48dd91039c6036bdb41")
+}
+
+func TestSignatureChunks(t *testing.T) {
+	signer := &chunkSigner{
+		key:      deriveKey(exampleSecret, exampleScope),
+		date:     exampleDate,
+		scope:    exampleScope,
+		previous: "4f232c4386841ef735655705268965c44a0e4690baa4adea153f7db9fa80a0a9",
+	}
+
+	var body bytes.Buffer
+	for _, chunk := range []struct {
+		size      int
+		signature string
+	}{
+		{65536, "ad80c730a21e5b8d04586a2213dd63b9a0e99e0e2307b0ade35a65485a288648"},
+		{1024, "0055627c9e194cb4542bae2aa5492e3c1575bbb81b612b7d234b86a503ef5497"},
+		{0, "b6c6ea8a5354eaf15b3cb7646744f4275b71ea724fed81ceb9323e279d449df9"},
+	} {
+		fmt.Fprintf(&body, "%x;chunk-signature=%s\r\n%s\r\n", chunk.size, chunk.signature, strings.Repeat("a", chunk.size))
+	}
+
+	data, err := io.ReadAll(newChunkedReader(bytes.NewReader(body.Bytes()), signer))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), strings.Repeat("a", 66560))
+
+	// A tampered chunk breaks the chain.
+	tampered := bytes.Replace(body.Bytes(), []byte("aaaa"), []byte("aaab"), 1)
+	signer.previous = "4f232c4386841ef735655705268965c44a0e4690baa4adea153f7db9fa80a0a9"
+	_, err = io.ReadAll(newChunkedReader(bytes.NewReader(tampered), signer))
+	assert.ErrorIs(t, err, errSignatureDoesNotMatch)
+}
+
+func TestUnsignedTrailer(t *testing.T) {
+	body := "5\r\nhello\r\n0\r\nx-amz-checksum-crc32:NhCmhg==\r\n\r\n"
+
+	data, err := io.ReadAll(newChunkedReader(strings.NewReader(body), nil))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "hello")
+
+	_, err = io.ReadAll(newChunkedReader(strings.NewReader("5\r\nhel"), nil))
+	assert.ErrorIs(t, err, errIncompleteBody)
+}
+
+func TestCanonicalQuery(t *testing.T) {
+	query, err := url.ParseQuery("prefix=a b&list-type=2&list=x&uploads=")
+	assert.NilError(t, err)
+
+	assert.Equal(t, canonicalQuery(query, ""), "list=x&list-type=2&prefix=a%20b&uploads=")
+}
+
+func TestParseAuthorization(t *testing.T) {
+	auth, err := parseAuthorization("AWS4-HMAC-SHA256 Credential=project/20130524/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-date, Signature=abc")
+	assert.NilError(t, err)
+	assert.Equal(t, auth.accessKey, "project")
+	assert.Equal(t, auth.scope, exampleScope)
+	assert.DeepEqual(t, auth.signedHeaders, []string{"host", "x-amz-date"})
+
+	_, err = parseAuthorization("AWS4-HMAC-SHA256 Credential=project/20130524/us-east-1/s3/aws4_request, SignedHeaders=x-amz-date, Signature=abc")
+	assert.ErrorIs(t, err, errAuthorizationMalformed)
+
+	_, err = parseAuthorization("AWS4-HMAC-SHA256 Credential=project/20130524/us-east-1/sqs/aws4_request, SignedHeaders=host, Signature=abc")
+	assert.ErrorContains(t, err, "not s3")
+}
+
+func TestLookupKeyCache(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	s := &Service{
+		keys: make(map[string]*cachedKey),
+		credentials: func(accessKey string) (string, string, error) {
+			calls.Add(1)
+			<-release
+			if accessKey != testAccessKey {
+				return "", "", fmt.Errorf("access key `%s` not found", accessKey)
+			}
+			return testProject, testSecretKey, nil
+		},
+	}
+
+	// Concurrent lookups of a key share one call to auth, and don't hold up
+	// the lookups of other keys.
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			projectId, secret, err := s.lookupKey(testAccessKey)
+			assert.NilError(t, err)
+			assert.Equal(t, projectId, testProject)
+			assert.Equal(t, secret, testSecretKey)
+		}()
+	}
+
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	s.keysLock.Lock()
+	s.keys["TAUCACHEDKEY"] = &cachedKey{projectId: "QmCached", secret: "cached", fetched: time.Now()}
+	s.keysLock.Unlock()
+	projectId, _, err := s.lookupKey("TAUCACHEDKEY")
+	assert.NilError(t, err)
+	assert.Equal(t, projectId, "QmCached")
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, calls.Load(), int32(1))
+	calls.Store(0)
+
+	_, _, err = s.lookupKey(testAccessKey)
+	assert.NilError(t, err)
+	assert.Equal(t, calls.Load(), int32(0))
+
+	// Unknown keys are turned away from the cache until InvalidKeyCacheTTL.
+	_, _, err = s.lookupKey("TAUUNKNOWNKEY")
+	assert.Equal(t, err, errInvalidAccessKeyId)
+	_, _, err = s.lookupKey("TAUUNKNOWNKEY")
+	assert.Equal(t, err, errInvalidAccessKeyId)
+	assert.Equal(t, calls.Load(), int32(1))
+
+	s.keys["TAUUNKNOWNKEY"].fetched = time.Now().Add(-InvalidKeyCacheTTL)
+	_, _, err = s.lookupKey("TAUUNKNOWNKEY")
+	assert.Equal(t, err, errInvalidAccessKeyId)
+	assert.Equal(t, calls.Load(), int32(2))
+}
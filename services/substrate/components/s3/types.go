@@ -0,0 +1,171 @@
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/taubyte/tau/core/services/substrate"
+	storageIface "github.com/taubyte/tau/core/services/substrate/components/storage"
+	"github.com/taubyte/tau/p2p/peer"
+)
+
+// Service serves project storages over a path-style S3 API: the bucket is the
+// storage matcher and the access key one auth issued for the project.
+type Service struct {
+	substrate.Service
+	storages    storageIface.Service
+	credentials Credentials
+	files       files
+	hosts       []string
+
+	ctx  context.Context
+	ctxC context.CancelFunc
+
+	keysLock   sync.Mutex
+	keys       map[string]*cachedKey
+	keyLookups singleflight.Group
+}
+
+// Credentials returns the project an access key was issued for and its
+// secret.
+type Credentials func(accessKey string) (projectId, secretKey string, err error)
+
+// files holds the parts of multipart uploads, so any node can complete them.
+type files interface {
+	AddFile(r io.Reader) (string, error)
+	GetFile(ctx context.Context, id string) (peer.ReadSeekCloser, error)
+	DeleteFile(id string) error
+}
+
+// cachedKey is auth's answer for an access key; invalid if auth didn't know
+// it.
+type cachedKey struct {
+	projectId string
+	secret    string
+	invalid   bool
+	fetched   time.Time
+}
+
+// request is an authenticated S3 request.
+type request struct {
+	projectId string
+	// body is the payload, decoded and checked against the signature as it
+	// is read.
+	body io.Reader
+}
+
+type scope struct {
+	date    string
+	region  string
+	service string
+}
+
+// authorization is the parsed Authorization header or presigned query.
+type authorization struct {
+	accessKey     string
+	scope         scope
+	signedHeaders []string
+	signature     string
+}
+
+// objectInfo is what S3 reports about a file version that the store doesn't
+// keep. It's only trusted while Cid still names the version.
+type objectInfo struct {
+	Cid         string `json:"cid"`
+	ETag        string `json:"etag"`
+	ContentType string `json:"type,omitempty"`
+	Modified    int64  `json:"modified"`
+}
+
+// upload is a multipart upload, kept in the storage's database.
+type upload struct {
+	Key     string `json:"key"`
+	Created int64  `json:"created"`
+}
+
+// part is an uploaded part, its bytes stored as a file of the node's DAG.
+type part struct {
+	Cid  string `json:"cid"`
+	MD5  string `json:"md5"`
+	Size int64  `json:"size"`
+}
+
+type apiError struct {
+	Code    string
+	Message string
+	Status  int
+}
+
+type errorResponse struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string   `xml:"Code"`
+	Message  string   `xml:"Message"`
+	Resource string   `xml:"Resource"`
+}
+
+type object struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type commonPrefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+// listBucketResult answers both ListObjects and ListObjectsV2; each leaves
+// the other's fields empty.
+type listBucketResult struct {
+	XMLName               xml.Name       `xml:"ListBucketResult"`
+	Xmlns                 string         `xml:"xmlns,attr"`
+	Name                  string         `xml:"Name"`
+	Prefix                string         `xml:"Prefix"`
+	Delimiter             string         `xml:"Delimiter,omitempty"`
+	EncodingType          string         `xml:"EncodingType,omitempty"`
+	MaxKeys               int            `xml:"MaxKeys"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+	Marker                *string        `xml:"Marker"`
+	NextMarker            string         `xml:"NextMarker,omitempty"`
+	StartAfter            string         `xml:"StartAfter,omitempty"`
+	ContinuationToken     string         `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string         `xml:"NextContinuationToken,omitempty"`
+	KeyCount              *int           `xml:"KeyCount"`
+	Contents              []object       `xml:"Contents"`
+	CommonPrefixes        []commonPrefix `xml:"CommonPrefixes"`
+}
+
+type initiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadId string   `xml:"UploadId"`
+}
+
+type completeMultipartUpload struct {
+	Parts []struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	} `xml:"Part"`
+}
+
+type completeMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"CompleteMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Location string   `xml:"Location"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	ETag     string   `xml:"ETag"`
+}
+
+type locationConstraint struct {
+	XMLName xml.Name `xml:"LocationConstraint"`
+	Xmlns   string   `xml:"xmlns,attr"`
+}
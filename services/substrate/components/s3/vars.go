@@ -0,0 +1,68 @@
+package s3
+
+import (
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+)
+
+var logger = log.Logger("tau.substrate.service.s3")
+
+// Tunables — exported so tests can shrink them.
+var (
+	// MaxClockSkew is how far X-Amz-Date may drift from the node's clock.
+	MaxClockSkew = 15 * time.Minute
+
+	// MaxPresignExpiry caps X-Amz-Expires, as S3 does.
+	MaxPresignExpiry = 7 * 24 * time.Hour
+
+	// MaxChunkSize caps one chunk of an aws-chunked body.
+	MaxChunkSize = 16 << 20
+
+	// MaxCompleteBodySize caps the part list of CompleteMultipartUpload.
+	MaxCompleteBodySize = 1 << 20
+
+	// UploadExpiry drops multipart uploads left incomplete for this long.
+	UploadExpiry = 24 * time.Hour
+
+	// CredentialsCacheTTL is how long the secret of an access key is kept
+	// before auth is asked again, so how long a revoked key keeps working.
+	CredentialsCacheTTL = 30 * time.Second
+
+	// InvalidKeyCacheTTL is how long an access key auth didn't know is
+	// turned away without asking auth again.
+	InvalidKeyCacheTTL = 5 * time.Second
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	chunkAlgorithm   = "AWS4-HMAC-SHA256-PAYLOAD"
+	signingService   = "s3"
+	scopeTerminator  = "aws4_request"
+	amzDateFormat    = "20060102T150405Z"
+	scopeDateFormat  = "20060102"
+
+	unsignedPayload          = "UNSIGNED-PAYLOAD"
+	streamingPayload         = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	streamingUnsignedTrailer = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
+
+	// emptySHA256 is the hash of an empty payload.
+	emptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	// infoPrefix holds the objectInfo of each file version S3 wrote.
+	infoPrefix = "s3/"
+
+	// deletedPrefix holds the delete marker of each object deleted from a
+	// versioned storage: the latest version when it was deleted.
+	deletedPrefix = "s3-deleted/"
+
+	// uploadsPrefix holds the incomplete multipart uploads, partsPrefix
+	// their parts.
+	uploadsPrefix = "s3-uploads/"
+	partsPrefix   = "s3-parts/"
+
+	maxKeys     = 1000
+	maxParts    = 10000
+	xmlns       = "http://s3.amazonaws.com/doc/2006-03-01/"
+	defaultType = "application/octet-stream"
+)
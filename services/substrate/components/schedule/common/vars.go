@@ -0,0 +1,8 @@
+package common
+
+import "github.com/ipfs/go-log/v2"
+
+var Logger = log.Logger("tau.substrate.service.schedule")
+
+// Topic is the topic of the pubsub event a scheduled function receives.
+const Topic = "schedule"
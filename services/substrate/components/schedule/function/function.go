@@ -0,0 +1,30 @@
+package function
+
+import (
+	"fmt"
+	"time"
+
+	iface "github.com/taubyte/tau/core/services/substrate/components/schedule"
+	plugins "github.com/taubyte/tau/pkg/vm-low-orbit"
+	"github.com/taubyte/tau/services/substrate/components/schedule/common"
+	"github.com/taubyte/tau/services/substrate/components/triggered"
+)
+
+var _ iface.Serviceable = &Function{}
+
+type Function struct {
+	*triggered.Function
+}
+
+// Handle runs the function for a tick. The SDK having no schedule event, the
+// guest gets a pubsub event whose data is the JSON encoded tick.
+func (f *Function) Handle(tick iface.Tick) (time.Time, error) {
+	msg, err := triggered.NewMessage(f.Id(), common.Topic, tick)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("encoding tick failed with: %w", err)
+	}
+
+	return f.Trigger(func(sdk plugins.Instance) uint32 {
+		return sdk.CreatePubsubEvent(msg).Id
+	})
+}
@@ -0,0 +1,32 @@
+package function
+
+import (
+	"fmt"
+
+	commonIface "github.com/taubyte/tau/core/services/substrate/components"
+	iface "github.com/taubyte/tau/core/services/substrate/components/schedule"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/triggered"
+)
+
+func New(srv iface.Service, config structureSpec.Function, commit, branch string, matcher *iface.MatchDefinition) (commonIface.Serviceable, error) {
+	function, err := triggered.New(srv.Context(), srv, triggered.Definition{
+		Config:      config,
+		Commit:      commit,
+		Branch:      branch,
+		Project:     matcher.Project,
+		Application: matcher.Application,
+		Matcher:     matcher,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Function{Function: function}
+	if _, err = srv.Cache().Add(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("adding scheduled function serviceable failed with: %s", err)
+	}
+
+	return f, nil
+}
@@ -0,0 +1,13 @@
+package schedule
+
+import (
+	"github.com/taubyte/tau/core/services/substrate"
+	"github.com/taubyte/tau/services/substrate/runtime/cache"
+)
+
+func New(srv substrate.Service) (*Service, error) {
+	return &Service{
+		Service: srv,
+		cache:   cache.New(),
+	}, nil
+}
@@ -0,0 +1,33 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	iface "github.com/taubyte/tau/core/services/substrate/components/schedule"
+	counter "github.com/taubyte/tau/services/substrate/runtime/counter"
+)
+
+// Run calls the function the matcher points to for a tick, and returns once
+// the call is done.
+func (s *Service) Run(ctx context.Context, matcher *iface.MatchDefinition, tick iface.Tick) error {
+	start := time.Now()
+
+	pick, err := s.Lookup(matcher)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		coldStartDone, err := pick.Handle(tick)
+		done <- counter.ErrorWrapper(pick, start, coldStartDone, err)
+	}()
+
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
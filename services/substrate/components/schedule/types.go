@@ -0,0 +1,14 @@
+package schedule
+
+import (
+	nodeIface "github.com/taubyte/tau/core/services/substrate"
+	iface "github.com/taubyte/tau/core/services/substrate/components/schedule"
+	"github.com/taubyte/tau/services/substrate/runtime/cache"
+)
+
+var _ iface.Service = &Service{}
+
+type Service struct {
+	nodeIface.Service
+	cache *cache.Cache
+}
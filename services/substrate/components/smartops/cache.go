@@ -0,0 +1,160 @@
+package smartOps
+
+import (
+	"container/list"
+	"context"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/taubyte/tau/core/services/substrate/smartops"
+)
+
+var _ smartops.SmartOpsCache = &cache{}
+
+// cache is an LRU of compiled smartops, capped at MaxCachedInstances and
+// dropping the ones idle for InstanceIdleTimeout. Dropped instances are shut
+// down.
+type cache struct {
+	lock  sync.Mutex
+	items map[string]*list.Element
+	order *list.List
+
+	done chan struct{}
+}
+
+type cacheEntry struct {
+	key      string
+	instance smartops.Instance
+	used     time.Time
+}
+
+func newCache() *cache {
+	c := &cache{
+		items: make(map[string]*list.Element),
+		order: list.New(),
+		done:  make(chan struct{}),
+	}
+
+	go c.sweepLoop()
+
+	return c
+}
+
+func cacheKey(project, application, smartOpId string) string {
+	return path.Join(project, application, smartOpId)
+}
+
+// Get returns the cached instance unless it has been shut down.
+func (c *cache) Get(project, application, smartOpId string, _ context.Context) (smartops.Instance, bool) {
+	key := cacheKey(project, application, smartOpId)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if entry.instance.Context().Err() != nil {
+		c.remove(elem)
+		return nil, false
+	}
+
+	entry.used = time.Now()
+	c.order.MoveToFront(elem)
+
+	return entry.instance, true
+}
+
+// Put caches instance, shutting down the one it replaces and the least
+// recently used ones past MaxCachedInstances.
+func (c *cache) Put(project, application, smartOpId string, _ context.Context, instance smartops.Instance) error {
+	key := cacheKey(project, application, smartOpId)
+
+	var evicted []smartops.Instance
+
+	c.lock.Lock()
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		if entry.instance != instance {
+			evicted = append(evicted, entry.instance)
+		}
+		entry.instance, entry.used = instance, time.Now()
+		c.order.MoveToFront(elem)
+	} else {
+		c.items[key] = c.order.PushFront(&cacheEntry{key: key, instance: instance, used: time.Now()})
+	}
+
+	for c.order.Len() > MaxCachedInstances {
+		evicted = append(evicted, c.remove(c.order.Back()))
+	}
+	c.lock.Unlock()
+
+	for _, instance := range evicted {
+		instance.ContextCancel()
+	}
+
+	return nil
+}
+
+// remove drops elem from the cache and returns its instance. lock must be
+// held.
+func (c *cache) remove(elem *list.Element) smartops.Instance {
+	entry := elem.Value.(*cacheEntry)
+	c.order.Remove(elem)
+	delete(c.items, entry.key)
+
+	return entry.instance
+}
+
+func (c *cache) sweepLoop() {
+	ticker := time.NewTicker(IdleSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.sweep()
+		}
+	}
+}
+
+// sweep shuts down the instances idle for InstanceIdleTimeout.
+func (c *cache) sweep() {
+	var idle []smartops.Instance
+
+	c.lock.Lock()
+	for elem := c.order.Back(); elem != nil; elem = c.order.Back() {
+		if time.Since(elem.Value.(*cacheEntry).used) < InstanceIdleTimeout {
+			break
+		}
+		idle = append(idle, c.remove(elem))
+	}
+	c.lock.Unlock()
+
+	for _, instance := range idle {
+		instance.ContextCancel()
+	}
+}
+
+func (c *cache) Close() {
+	c.lock.Lock()
+	items := c.items
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
+	select {
+	case <-c.done:
+	default:
+		close(c.done)
+	}
+	c.lock.Unlock()
+
+	for _, elem := range items {
+		elem.Value.(*cacheEntry).instance.ContextCancel()
+	}
+}
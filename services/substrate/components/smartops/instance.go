@@ -0,0 +1,209 @@
+package smartOps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/taubyte/tau/core/services/substrate/smartops"
+	"github.com/taubyte/tau/core/vm"
+	librarySpec "github.com/taubyte/tau/pkg/specs/library"
+	smartOpSpec "github.com/taubyte/tau/pkg/specs/smartops"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	tbPlugins "github.com/taubyte/tau/pkg/vm-low-orbit"
+	smartopsPlugins "github.com/taubyte/tau/pkg/vm-ops-orbit"
+	vmContext "github.com/taubyte/tau/pkg/vm/context"
+)
+
+var _ smartops.Instance = &instance{}
+
+// instance is a compiled smartop. A wasm runtime is not safe for concurrent
+// calls, so runs on the same instance are serialized.
+type instance struct {
+	ctx    context.Context
+	ctxC   context.CancelFunc
+	config *structureSpec.SmartOp
+	commit string
+
+	lock    sync.Mutex
+	runtime vm.Runtime
+	sdk     smartopsPlugins.Instance
+	fx      vm.FunctionInstance
+}
+
+func (s *Service) newInstance(config *structureSpec.SmartOp, project, application, branch, commit string) (inst *instance, err error) {
+	inst = &instance{
+		config: config,
+		commit: commit,
+	}
+	inst.ctx, inst.ctxC = context.WithCancel(s.Context())
+
+	var closers []io.Closer
+	defer func() {
+		if err != nil {
+			for _, toClose := range closers {
+				toClose.Close()
+			}
+			inst.ctxC()
+		}
+	}()
+
+	vmCtx, err := vmContext.New(
+		inst.ctx,
+		vmContext.Project(project),
+		vmContext.Application(application),
+		vmContext.Resource(config.Id),
+		vmContext.Commit(commit),
+		vmContext.Branch(branch),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating vm context failed with: %w", err)
+	}
+
+	vmConfig := vm.Config{MemoryLimitPages: memoryLimitPages(config.Memory)}
+	if s.Verbose() {
+		vmConfig.Output = vm.Buffer
+	}
+
+	vmInstance, err := s.Vm().New(vmCtx, vmConfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating new instance failed with: %w", err)
+	}
+	closers = append(closers, vmInstance)
+
+	if inst.runtime, err = vmInstance.Runtime(); err != nil {
+		return nil, fmt.Errorf("creating new runtime failed with: %w", err)
+	}
+	closers = append(closers, inst.runtime)
+
+	for _, plugIn := range s.Orbitals() {
+		if _, _, err = inst.runtime.Attach(plugIn); err != nil {
+			return nil, fmt.Errorf("attaching satellite plugin `%s` to runtime failed with: %w", plugIn.Name(), err)
+		}
+	}
+
+	if _, _, err = inst.runtime.Attach(tbPlugins.Plugin()); err != nil {
+		return nil, fmt.Errorf("attaching core plugins to runtime failed with: %w", err)
+	}
+
+	smartOpsPi, _, err := inst.runtime.Attach(smartopsPlugins.Plugin())
+	if err != nil {
+		return nil, fmt.Errorf("attaching smartops plugin to runtime failed with: %w", err)
+	}
+
+	if inst.sdk, err = smartopsPlugins.With(smartOpsPi); err != nil {
+		return nil, fmt.Errorf("loading smartops plugin api failed with: %w", err)
+	}
+
+	moduleName, err := s.moduleName(config)
+	if err != nil {
+		return nil, err
+	}
+
+	module, err := inst.runtime.Module(moduleName)
+	if err != nil {
+		return nil, fmt.Errorf("loading module `%s` failed with: %w", moduleName, err)
+	}
+
+	if inst.fx, err = module.Function(config.Call); err != nil {
+		return nil, fmt.Errorf("getting function `%s` of module `%s` failed with: %w", config.Call, moduleName, err)
+	}
+
+	return inst, nil
+}
+
+func (i *instance) Context() context.Context {
+	return i.ctx
+}
+
+// ContextCancel shuts the instance down once any in-flight run is done.
+func (i *instance) ContextCancel() {
+	i.ctxC()
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.runtime != nil {
+		i.runtime.Close()
+		i.runtime = nil
+	}
+}
+
+// Run calls the smartop with a resource wrapping caller and returns the
+// value it exits with. A failed call leaves the runtime in an unknown state,
+// so the instance is shut down and rebuilt on the next run.
+func (i *instance) Run(caller smartops.EventCaller) (uint32, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.runtime == nil || i.ctx.Err() != nil {
+		return 0, errors.New("smartop instance is closed")
+	}
+
+	resource := i.sdk.CreateSmartOp(caller)
+	defer i.sdk.ReleaseSmartOp(resource.Id)
+
+	ctx := caller.Context()
+	if ctx == nil {
+		ctx = i.ctx
+	}
+	if i.config.Timeout > 0 {
+		var ctxC context.CancelFunc
+		ctx, ctxC = context.WithTimeout(ctx, time.Duration(i.config.Timeout))
+		defer ctxC()
+	}
+
+	ret, err := i.fx.RawCall(ctx, uint64(resource.Id))
+	if err != nil {
+		i.ctxC()
+		i.runtime.Close()
+		i.runtime = nil
+		return 0, fmt.Errorf("calling smartop `%s` failed with: %w", i.config.Name, err)
+	}
+
+	if len(ret) < 1 {
+		return 0, nil
+	}
+
+	return uint32(ret[0]), nil
+}
+
+func (s *Service) moduleName(config *structureSpec.SmartOp) (string, error) {
+	switch source := config.Source; {
+	case source == "." || source == "":
+		return smartOpSpec.ModuleName(config.Name), nil
+	case strings.HasPrefix(source, librarySpec.PathVariable.String()):
+		libId := strings.TrimPrefix(source, librarySpec.PathVariable.String()+"/")
+		_library, err := s.Tns().Fetch(librarySpec.Tns().NameIndex(libId))
+		if err != nil {
+			return "", fmt.Errorf("fetching library name for resource: `%s` failed with: %w", libId, err)
+		}
+
+		library, ok := _library.Interface().(string)
+		if !ok {
+			return "", fmt.Errorf("got tns object for library index %#v, expected string value ", _library.Interface())
+		}
+
+		return librarySpec.ModuleName(library), nil
+	default:
+		return source, nil
+	}
+}
+
+// memoryLimitPages converts a memory size in bytes to wasm pages, rounding
+// up. Zero lets the vm apply its default limit.
+func memoryLimitPages(memory uint64) uint32 {
+	pages := memory / uint64(vm.MemoryPageSize)
+	if memory%uint64(vm.MemoryPageSize) != 0 {
+		pages++
+	}
+
+	if pages > uint64(vm.MemoryLimitPages) {
+		pages = uint64(vm.MemoryLimitPages)
+	}
+
+	return uint32(pages)
+}
@@ -0,0 +1,99 @@
+package smartOps
+
+import (
+	"fmt"
+
+	"github.com/ipfs/go-log/v2"
+	"github.com/taubyte/tau/core/services/substrate"
+	"github.com/taubyte/tau/core/services/substrate/smartops"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+)
+
+var logger = log.Logger("tau.substrate.service.smartops")
+
+var _ substrate.SmartOpsService = &Service{}
+
+// Service runs the smartops guarding substrate resources. Compiled smartop
+// instances are cached per project, application and smartop, replaced once a
+// new commit of the project is published and shut down once idle.
+type Service struct {
+	substrate.Service
+	cache smartops.SmartOpsCache
+}
+
+func New(srv substrate.Service) (*Service, error) {
+	return &Service{
+		Service: srv,
+		cache:   newCache(),
+	}, nil
+}
+
+// Run executes smartOpIds in order against caller and stops at the first one
+// returning a non-zero value, which is returned so the caller can refuse the
+// event.
+func (s *Service) Run(caller smartops.EventCaller, smartOpIds []string) (uint32, error) {
+	for _, smartOpId := range smartOpIds {
+		instance, err := s.instance(caller, smartOpId)
+		if err != nil {
+			return 0, fmt.Errorf("getting smartop `%s` failed with: %w", smartOpId, err)
+		}
+
+		val, err := instance.Run(caller)
+		if err != nil {
+			return 0, fmt.Errorf("running smartop `%s` failed with: %w", smartOpId, err)
+		}
+
+		if val != 0 {
+			return val, nil
+		}
+	}
+
+	return 0, nil
+}
+
+func (s *Service) Close() error {
+	s.cache.Close()
+	return nil
+}
+
+func (s *Service) instance(caller smartops.EventCaller, smartOpId string) (smartops.Instance, error) {
+	project, application := caller.Project(), caller.Application()
+	getter := s.Tns().SmartOp().All(project, application, callerBranches(caller)...)
+
+	commit, branch, err := getter.Commit(project)
+	if err != nil {
+		return nil, fmt.Errorf("getting current commit of project `%s` failed with: %w", project, err)
+	}
+
+	if cached, ok := s.cache.Get(project, application, smartOpId, caller.Context()); ok {
+		if inst, ok := cached.(*instance); !ok || inst.commit == commit {
+			return cached, nil
+		}
+	}
+
+	config, err := getter.GetByIdCommit(smartOpId, commit)
+	if err != nil {
+		return nil, fmt.Errorf("fetching config failed with: %w", err)
+	}
+
+	inst, err := s.newInstance(config, project, application, branch, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.cache.Put(project, application, smartOpId, caller.Context(), inst); err != nil {
+		logger.Errorf("caching smartop `%s` failed with: %s", smartOpId, err.Error())
+	}
+
+	return inst, nil
+}
+
+// callerBranches returns the branch of the guarded resource when it exposes
+// one, so preview deployments run their own smartops.
+func callerBranches(caller smartops.EventCaller) []string {
+	if b, ok := caller.(interface{ Branch() string }); ok && len(b.Branch()) > 0 {
+		return []string{b.Branch()}
+	}
+
+	return spec.DefaultBranches
+}
@@ -0,0 +1,139 @@
+package smartOps
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/services/substrate/smartops"
+	"github.com/taubyte/tau/core/services/tns"
+	"github.com/taubyte/tau/p2p/peer"
+	"github.com/taubyte/tau/services/substrate/components/structure"
+	"gotest.tools/v3/assert"
+)
+
+type fakeCaller struct {
+	ctx context.Context
+}
+
+func (c fakeCaller) Context() context.Context { return c.ctx }
+func (fakeCaller) Type() uint32               { return 0 }
+func (fakeCaller) Application() string        { return "app" }
+func (fakeCaller) Project() string            { return "project" }
+
+type fakeInstance struct {
+	ctx  context.Context
+	ctxC context.CancelFunc
+	val  uint32
+	err  error
+	runs int
+}
+
+func newFakeInstance(val uint32, err error) *fakeInstance {
+	i := &fakeInstance{val: val, err: err}
+	i.ctx, i.ctxC = context.WithCancel(context.Background())
+	return i
+}
+
+func (i *fakeInstance) Context() context.Context { return i.ctx }
+func (i *fakeInstance) ContextCancel()           { i.ctxC() }
+func (i *fakeInstance) Run(smartops.EventCaller) (uint32, error) {
+	i.runs++
+	return i.val, i.err
+}
+
+func TestCache(t *testing.T) {
+	c := newCache()
+
+	_, ok := c.Get("project", "app", "op", context.Background())
+	assert.Assert(t, !ok)
+
+	first := newFakeInstance(0, nil)
+	assert.NilError(t, c.Put("project", "app", "op", context.Background(), first))
+
+	got, ok := c.Get("project", "app", "op", context.Background())
+	assert.Assert(t, ok)
+	assert.Equal(t, got, smartops.Instance(first))
+
+	_, ok = c.Get("project", "", "op", context.Background())
+	assert.Assert(t, !ok)
+
+	second := newFakeInstance(0, nil)
+	assert.NilError(t, c.Put("project", "app", "op", context.Background(), second))
+	assert.ErrorIs(t, first.ctx.Err(), context.Canceled)
+
+	second.ContextCancel()
+	_, ok = c.Get("project", "app", "op", context.Background())
+	assert.Assert(t, !ok)
+
+	third := newFakeInstance(0, nil)
+	assert.NilError(t, c.Put("project", "app", "other", context.Background(), third))
+	c.Close()
+	assert.ErrorIs(t, third.ctx.Err(), context.Canceled)
+}
+
+func TestCacheBounds(t *testing.T) {
+	defer func(max int) { MaxCachedInstances = max }(MaxCachedInstances)
+	MaxCachedInstances = 2
+
+	c := newCache()
+	defer c.Close()
+
+	first, second, third := newFakeInstance(0, nil), newFakeInstance(0, nil), newFakeInstance(0, nil)
+	assert.NilError(t, c.Put("project", "app", "first", context.Background(), first))
+	assert.NilError(t, c.Put("project", "app", "second", context.Background(), second))
+
+	// Running first makes second the least recently used.
+	_, ok := c.Get("project", "app", "first", context.Background())
+	assert.Assert(t, ok)
+
+	assert.NilError(t, c.Put("project", "app", "third", context.Background(), third))
+	assert.ErrorIs(t, second.ctx.Err(), context.Canceled)
+	_, ok = c.Get("project", "app", "second", context.Background())
+	assert.Assert(t, !ok)
+	assert.NilError(t, first.ctx.Err())
+
+	// Idle instances are shut down by the sweep.
+	c.items[cacheKey("project", "app", "first")].Value.(*cacheEntry).used = time.Now().Add(-InstanceIdleTimeout)
+	c.sweep()
+	assert.ErrorIs(t, first.ctx.Err(), context.Canceled)
+	assert.NilError(t, third.ctx.Err())
+	assert.Equal(t, c.order.Len(), 1)
+}
+
+func TestRun(t *testing.T) {
+	structure.FakeFetchMethod = func(tns.Path) (tns.Object, error) {
+		return structure.ResponseObject{Object: "commit"}, nil
+	}
+	t.Cleanup(func() {
+		structure.FakeFetchMethod = func(tns.Path) (tns.Object, error) { return nil, nil }
+	})
+
+	srv, err := New(structure.MockNodeService(peer.Mock(t.Context()), t.Context()))
+	assert.NilError(t, err)
+	defer srv.Close()
+
+	allow, deny, broken := newFakeInstance(0, nil), newFakeInstance(3, nil), newFakeInstance(0, errors.New("trap"))
+	assert.NilError(t, srv.cache.Put("project", "app", "allow", context.Background(), allow))
+	assert.NilError(t, srv.cache.Put("project", "app", "deny", context.Background(), deny))
+	assert.NilError(t, srv.cache.Put("project", "app", "broken", context.Background(), broken))
+
+	caller := fakeCaller{ctx: t.Context()}
+
+	val, err := srv.Run(caller, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, val, uint32(0))
+
+	val, err = srv.Run(caller, []string{"allow"})
+	assert.NilError(t, err)
+	assert.Equal(t, val, uint32(0))
+
+	val, err = srv.Run(caller, []string{"allow", "deny", "broken"})
+	assert.NilError(t, err)
+	assert.Equal(t, val, uint32(3))
+	assert.Equal(t, broken.runs, 0)
+
+	_, err = srv.Run(caller, []string{"broken"})
+	assert.ErrorContains(t, err, "trap")
+}
@@ -0,0 +1,15 @@
+package smartOps
+
+import "time"
+
+var (
+	// MaxCachedInstances caps the compiled smartops a node keeps; past it the
+	// least recently run one is shut down.
+	MaxCachedInstances = 256
+
+	// InstanceIdleTimeout shuts down a compiled smartop not run for this long.
+	InstanceIdleTimeout = 10 * time.Minute
+
+	// IdleSweepInterval is how often idle instances are looked for.
+	IdleSweepInterval = time.Minute
+)
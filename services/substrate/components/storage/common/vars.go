@@ -1,13 +1,27 @@
 package common
 
-import "github.com/ipfs/go-log/v2"
+import (
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+)
 
 var (
 	BroadcastInterval = 5
-	Logger            = log.Logger("tau.substrate.service.storage")
+	// LifecycleInterval is how often a storage with lifecycle rules sweeps its
+	// expired objects and versions.
+	LifecycleInterval = time.Minute
+	// LifecycleLease is how long the node sweeping a storage keeps the job
+	// after each sweep before another node may take it over.
+	LifecycleLease = 3 * time.Minute
+	Logger         = log.Logger("tau.substrate.service.storage")
 )
 
 const (
 	KvVersion = "v/"
 	KvSize    = "s/"
+	// KvTime holds when each version of a file was added, in unix nanoseconds.
+	KvTime = "t/"
+	// KvSweeper holds the lifecycle sweep lease: "<node id> <unix nano expiry>".
+	KvSweeper = "lifecycle/sweeper"
 )
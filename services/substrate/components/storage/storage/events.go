@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/taubyte/tau/core/services/substrate/events"
+)
+
+// notify dispatches a change of the storage, made under ctx, to the functions
+// it triggers.
+func (s *Store) notify(ctx context.Context, kind events.Kind, name string, version, size int) {
+	emitter := s.srv.Events()
+	if emitter == nil {
+		return
+	}
+
+	emitter.Emit(ctx, s.context.ProjectId, s.context.ApplicationId, &events.Event{
+		Resource: events.Storage,
+		Name:     s.Config().Name,
+		Kind:     kind,
+		Key:      name,
+		Version:  version,
+		Size:     size,
+		Time:     time.Now(),
+	})
+}
@@ -0,0 +1,226 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
+	storageSpec "github.com/taubyte/tau/pkg/specs/storage"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/storage/common"
+)
+
+type fileVersion struct {
+	version int
+	added   time.Time
+}
+
+// hasLifecycle tells whether the storage has rules expiring its files.
+func hasLifecycle(config *structureSpec.Storage) bool {
+	return config.Ttl > 0 || config.KeepVersions > 0 || config.ExpireVersions > 0
+}
+
+// expiredVersions returns the versions of a file the lifecycle rules expire:
+// any version older than the TTL, those past the newest KeepVersions, and
+// non-current ones replaced for longer than ExpireVersions.
+func expiredVersions(config *structureSpec.Storage, versions []fileVersion, now time.Time) []int {
+	sort.Slice(versions, func(i, j int) bool { return versions[i].version > versions[j].version })
+
+	expired := make([]int, 0)
+	for i, v := range versions {
+		switch {
+		case config.Ttl > 0 && now.Sub(v.added) > time.Duration(config.Ttl):
+		case config.KeepVersions > 0 && i >= config.KeepVersions:
+		case config.ExpireVersions > 0 && i > 0 && now.Sub(versions[i-1].added) > time.Duration(config.ExpireVersions):
+		default:
+			continue
+		}
+
+		expired = append(expired, v.version)
+	}
+
+	return expired
+}
+
+// scheduleLifecycle starts the lifecycle sweep when the storage has rules and
+// stops it when it has none left.
+func (s *Store) scheduleLifecycle() {
+	s.lifecycleLock.Lock()
+	defer s.lifecycleLock.Unlock()
+
+	switch active := hasLifecycle(s.Config()); {
+	case active && s.lifecycleC == nil:
+		var ctx context.Context
+		ctx, s.lifecycleC = context.WithCancel(s.instanceCtx)
+		go s.runLifecycle(ctx, s.srv.Node().ID().String())
+	case !active && s.lifecycleC != nil:
+		s.lifecycleC()
+		s.lifecycleC = nil
+	}
+}
+
+func (s *Store) runLifecycle(ctx context.Context, self string) {
+	ticker := time.NewTicker(common.LifecycleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.claimSweep(ctx, self) {
+				continue
+			}
+
+			if err := s.sweep(ctx); err != nil {
+				common.Logger.Errorf("lifecycle sweep of %s failed with: %s", storageError(s.ContextConfig()), err)
+			}
+		}
+	}
+}
+
+// claimSweep reports whether self sweeps the storage this round. The nodes
+// serving a storage elect one sweeper through a lease in its database, which
+// the sweeper renews every round and others take over once it expires.
+func (s *Store) claimSweep(ctx context.Context, self string) bool {
+	cas, ok := s.KVDB.(hoarderIface.CasKVDB)
+	if !ok {
+		return true
+	}
+
+	now := time.Now()
+	current, err := s.Get(ctx, common.KvSweeper)
+	if err != nil {
+		current = nil
+	}
+
+	if holder, expiry, ok := strings.Cut(string(current), " "); ok && holder != self {
+		if nano, err := strconv.ParseInt(expiry, 10, 64); err == nil && now.Before(time.Unix(0, nano)) {
+			return false
+		}
+	}
+
+	var expected []byte
+	if current != nil {
+		sum := sha256.Sum256(current)
+		expected = sum[:]
+	}
+
+	lease := self + " " + strconv.FormatInt(now.Add(common.LifecycleLease).UnixNano(), 10)
+	swapped, err := cas.CompareAndSwap(ctx, common.KvSweeper, expected, []byte(lease))
+	if err != nil {
+		common.Logger.Errorf("claiming lifecycle sweep of %s failed with: %s", storageError(s.ContextConfig()), err)
+		return false
+	}
+
+	return swapped
+}
+
+// sweep deletes the file versions expired by the lifecycle rules and releases
+// the stashes no file refers to anymore.
+func (s *Store) sweep(ctx context.Context) error {
+	prefix := storageSpec.FilePath.String() + "/"
+	keys, err := s.KVDB.List(ctx, prefix)
+	if err != nil {
+		return fmt.Errorf("listing files failed with: %w", err)
+	}
+
+	now := time.Now()
+	files := make(map[string][]fileVersion)
+	for _, key := range keys {
+		entry := strings.TrimPrefix(strings.TrimPrefix(key, "/"), prefix)
+		name, versionString := path.Dir(entry), path.Base(entry)
+		version, err := strconv.Atoi(versionString)
+		if err != nil {
+			continue
+		}
+
+		files[name] = append(files[name], fileVersion{version: version, added: s.addedAt(ctx, name, versionString, now)})
+	}
+
+	cids := make([]string, 0)
+	for name, versions := range files {
+		expired := expiredVersions(s.Config(), versions, now)
+		if len(expired) == 0 {
+			continue
+		}
+
+		for _, version := range expired {
+			cid, err := s.remove(ctx, name, strconv.Itoa(version))
+			if err != nil {
+				common.Logger.Errorf("expiring version %d of file %s failed with: %s", version, name, err)
+				continue
+			}
+
+			cids = append(cids, cid)
+		}
+
+		if len(expired) < len(versions) {
+			if _, err = s.GetLatestVersion(ctx, name); err != nil {
+				common.Logger.Errorf("updating latest version of file %s failed with: %s", name, err)
+			}
+		} else if err = s.Delete(ctx, path.Join(common.KvVersion, name)); err != nil {
+			common.Logger.Errorf("deleting latest version index of file %s failed with: %s", name, err)
+		}
+	}
+
+	s.release(ctx, cids...)
+
+	return nil
+}
+
+// addedAt returns when a file version was added. Versions added before times
+// were recorded start aging from now.
+func (s *Store) addedAt(ctx context.Context, name, version string, now time.Time) time.Time {
+	key := path.Join(common.KvTime, name, version)
+	if data, err := s.Get(ctx, key); err == nil {
+		if nano, err := strconv.ParseInt(string(data), 10, 64); err == nil {
+			return time.Unix(0, nano)
+		}
+	}
+
+	if err := s.put(ctx, key, []byte(strconv.FormatInt(now.UnixNano(), 10))); err != nil {
+		common.Logger.Errorf("recording time of version %s of file %s failed with: %s", version, name, err)
+	}
+
+	return now
+}
+
+// release drops the stashes of the given cids that no file of the storage
+// refers to anymore, so hoarders can free them once no storage does.
+func (s *Store) release(ctx context.Context, cids ...string) {
+	if len(cids) == 0 {
+		return
+	}
+
+	keys, err := s.KVDB.List(ctx, storageSpec.FilePath.String()+"/")
+	if err != nil {
+		common.Logger.Errorf("listing files to release stashes failed with: %s", err)
+		return
+	}
+
+	referenced := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if cid, err := s.Get(ctx, key); err == nil {
+			referenced[string(cid)] = true
+		}
+	}
+
+	for _, cid := range cids {
+		if referenced[cid] {
+			continue
+		}
+		referenced[cid] = true
+
+		s.srv.Node().DeleteFile(cid) //nolint:errcheck // only cached blocks are held locally
+		if _, err := s.hoarderClient.Release(cid, s.id); err != nil {
+			common.Logger.Errorf("releasing stash %s failed with: %s", cid, err)
+		}
+	}
+}
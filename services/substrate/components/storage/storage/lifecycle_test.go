@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/kvdb"
+	storageIface "github.com/taubyte/tau/core/services/substrate/components/storage"
+	"github.com/taubyte/tau/p2p/peer"
+	"github.com/taubyte/tau/pkg/kvdb/mock"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/storage/common"
+	"gotest.tools/v3/assert"
+)
+
+type mockService struct {
+	storageIface.Service
+	node peer.Node
+}
+
+func (m *mockService) Node() peer.Node { return m.node }
+
+// casStore compares and swaps like a hoarder-backed store does.
+type casStore struct {
+	kvdb.KVDB
+	lock sync.Mutex
+}
+
+func (s *casStore) CompareAndSwap(ctx context.Context, key string, expected, value []byte) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	current, err := s.Get(ctx, key)
+	if err != nil {
+		if len(expected) != 0 {
+			return false, nil
+		}
+	} else if sum := sha256.Sum256(current); !bytes.Equal(sum[:], expected) {
+		return false, nil
+	}
+
+	return true, s.Put(ctx, key, value)
+}
+
+func newTestStore(t *testing.T, config *structureSpec.Storage) *Store {
+	kv, err := mock.New().New(nil, "test", 0)
+	assert.NilError(t, err)
+
+	s := &Store{
+		KVDB:    &casStore{KVDB: kv},
+		srv:     &mockService{node: peer.Mock(t.Context())},
+		context: storageIface.Context{Config: config},
+	}
+	s.instanceCtx, s.instanceCtxC = context.WithCancel(t.Context())
+	t.Cleanup(s.instanceCtxC)
+
+	return s
+}
+
+func TestScheduleLifecycle(t *testing.T) {
+	s := newTestStore(t, &structureSpec.Storage{})
+
+	s.scheduleLifecycle()
+	assert.Assert(t, s.lifecycleC == nil)
+
+	// Rules added after the storage opened start the sweep.
+	s.UpdateConfig(&structureSpec.Storage{Ttl: uint64(time.Hour)})
+	assert.Assert(t, s.lifecycleC != nil)
+	assert.Equal(t, s.Config().Ttl, uint64(time.Hour))
+
+	s.UpdateConfig(&structureSpec.Storage{KeepVersions: 2})
+	assert.Assert(t, s.lifecycleC != nil)
+
+	s.UpdateConfig(&structureSpec.Storage{Size: 10})
+	assert.Assert(t, s.lifecycleC == nil)
+	assert.Equal(t, s.Capacity(), 10)
+}
+
+func TestClaimSweep(t *testing.T) {
+	ctx := t.Context()
+	s := newTestStore(t, &structureSpec.Storage{Ttl: uint64(time.Hour)})
+
+	assert.Assert(t, s.claimSweep(ctx, "a"))
+	assert.Assert(t, !s.claimSweep(ctx, "b"))
+
+	// The sweeper renews its lease.
+	assert.Assert(t, s.claimSweep(ctx, "a"))
+	assert.Assert(t, !s.claimSweep(ctx, "b"))
+
+	defer func(lease time.Duration) { common.LifecycleLease = lease }(common.LifecycleLease)
+	common.LifecycleLease = -time.Second
+
+	// An expired lease is taken over.
+	assert.Assert(t, s.claimSweep(ctx, "a"))
+	assert.Assert(t, s.claimSweep(ctx, "b"))
+}
+
+func TestExpiredVersions(t *testing.T) {
+	now := time.Now()
+	versions := func() []fileVersion {
+		return []fileVersion{
+			{version: 1, added: now.Add(-10 * time.Hour)},
+			{version: 3, added: now.Add(-1 * time.Hour)},
+			{version: 2, added: now.Add(-5 * time.Hour)},
+		}
+	}
+
+	assert.DeepEqual(t, expiredVersions(&structureSpec.Storage{}, versions(), now), []int{})
+
+	ttl := &structureSpec.Storage{Ttl: uint64(4 * time.Hour)}
+	assert.DeepEqual(t, expiredVersions(ttl, versions(), now), []int{2, 1})
+
+	ttl.Ttl = uint64(30 * time.Minute)
+	assert.DeepEqual(t, expiredVersions(ttl, versions(), now), []int{3, 2, 1})
+
+	keep := &structureSpec.Storage{Versioning: true, KeepVersions: 2}
+	assert.DeepEqual(t, expiredVersions(keep, versions(), now), []int{1})
+
+	// Version 2 was replaced an hour ago, version 1 five hours ago.
+	expire := &structureSpec.Storage{Versioning: true, ExpireVersions: uint64(2 * time.Hour)}
+	assert.DeepEqual(t, expiredVersions(expire, versions(), now), []int{1})
+
+	expire.ExpireVersions = uint64(30 * time.Minute)
+	assert.DeepEqual(t, expiredVersions(expire, versions(), now), []int{2, 1})
+}
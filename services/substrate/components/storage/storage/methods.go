@@ -8,6 +8,7 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
@@ -16,7 +17,9 @@ import (
 
 	"github.com/alecthomas/units"
 	storageIface "github.com/taubyte/tau/core/services/substrate/components/storage"
+	"github.com/taubyte/tau/core/services/substrate/events"
 	storageSpec "github.com/taubyte/tau/pkg/specs/storage"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
 	readerUtils "github.com/taubyte/tau/utils/readerutil"
 )
 
@@ -53,7 +56,7 @@ func (s *Store) List(ctx context.Context, prefix string) ([]string, error) {
 		newList := make([]string, 0)
 
 		for _, entry := range entries {
-			if !strings.HasPrefix(entry, "/s/") {
+			if !strings.HasPrefix(entry, "/s/") && !strings.HasPrefix(entry, "/t/") {
 				newList = append(newList, entry)
 			}
 		}
@@ -65,7 +68,7 @@ func (s *Store) List(ctx context.Context, prefix string) ([]string, error) {
 }
 
 func (s *Store) Capacity() (capacity int) {
-	return int(s.context.Config.Size)
+	return int(s.Config().Size)
 }
 
 func (s *Store) put(ctx context.Context, name string, v []byte) error {
@@ -86,7 +89,7 @@ func (s *Store) AddFile(ctx context.Context, r io.ReadSeeker, name string, repla
 	size := int(_size)
 
 	version = 1
-	if s.context.Config.Versioning {
+	if s.Config().Versioning {
 		version, err = s.getNewVersion(ctx, name, replace)
 		if err != nil {
 			return
@@ -95,6 +98,7 @@ func (s *Store) AddFile(ctx context.Context, r io.ReadSeeker, name string, repla
 
 	versionString := strconv.Itoa(version)
 	oldSize := 0
+	kind := events.Put
 	oldSizeByte, err := s.Get(ctx, path.Join(common.KvSize, name, versionString))
 	if err == nil || len(oldSizeByte) != 0 {
 		if !replace {
@@ -107,6 +111,8 @@ func (s *Store) AddFile(ctx context.Context, r io.ReadSeeker, name string, repla
 			err = fmt.Errorf("file with same name previously added, but file could not be read with %w", err)
 			return
 		}
+	} else if version > 1 {
+		kind = events.NewVersion
 	}
 
 	used, err := s.Used(ctx)
@@ -145,7 +151,9 @@ func (s *Store) AddFile(ctx context.Context, r io.ReadSeeker, name string, repla
 	}
 	s.srv.Node().DeleteFile(cid) //nolint:errcheck // best-effort local drop
 
-	if err = s.put(ctx, path.Join(storageSpec.FilePath.String(), name, versionString), []byte(cid)); err != nil {
+	filePath := path.Join(storageSpec.FilePath.String(), name, versionString)
+	oldCid, _ := s.Get(ctx, filePath)
+	if err = s.put(ctx, filePath, []byte(cid)); err != nil {
 		err = fmt.Errorf("adding file cid to database failed with: %w", err)
 		return
 	}
@@ -161,6 +169,17 @@ func (s *Store) AddFile(ctx context.Context, r io.ReadSeeker, name string, repla
 		return
 	}
 
+	if err = s.put(ctx, path.Join(common.KvTime, name, versionString), []byte(strconv.FormatInt(time.Now().UnixNano(), 10))); err != nil {
+		err = fmt.Errorf("adding file time to database failed with: %w", err)
+		return
+	}
+
+	if len(oldCid) > 0 && string(oldCid) != cid {
+		s.release(ctx, string(oldCid))
+	}
+
+	s.notify(ctx, kind, name, version, size)
+
 	return version, nil
 }
 
@@ -196,11 +215,15 @@ func (s *Store) DeleteFile(ctx context.Context, name string, version int) error
 		if err = s.Delete(ctx, path.Join(common.KvVersion, name)); err != nil {
 			return fmt.Errorf("deleting latest version index of file %s failed with %w", name, err)
 		}
+
+		version = 0
 	case 0:
 		if err = s.delete(ctx, name, string(latestVersion)); err != nil {
 			return fmt.Errorf("deleting latest versions of file %s failed with: %v", name, err)
 		}
 
+		version, _ = strconv.Atoi(string(latestVersion))
+
 		if _, err := s.GetLatestVersion(ctx, name); err != nil {
 			return fmt.Errorf("updating latest version of file %s failed with: %v", name, err)
 		}
@@ -214,34 +237,48 @@ func (s *Store) DeleteFile(ctx context.Context, name string, version int) error
 		}
 	}
 
+	s.notify(ctx, events.Delete, name, version, 0)
+
 	return nil
 
 }
 
 func (s *Store) delete(ctx context.Context, name string, version string) error {
+	cid, err := s.remove(ctx, name, version)
+	if err != nil {
+		return err
+	}
+
+	s.release(ctx, cid)
+
+	return nil
+}
+
+// remove drops the entries of a file version and returns the cid it pointed to.
+func (s *Store) remove(ctx context.Context, name string, version string) (string, error) {
 	if _, err := s.Get(ctx, path.Join(common.KvSize, name, version)); err != nil {
-		return errors.New("cannot delete file:" + name + ", file size not found")
+		return "", errors.New("cannot delete file:" + name + ", file size not found")
 	}
 
 	cid, err := s.Get(ctx, path.Join(storageSpec.FilePath.String(), name, version))
 	if err != nil {
-		return errors.New("cannot delete file:" + name + ", not found")
-	}
-
-	if err = s.srv.Node().DeleteFile(string(cid)); err != nil {
-		return fmt.Errorf("failed to delete file: %s, with: %w", name, err)
+		return "", errors.New("cannot delete file:" + name + ", not found")
 	}
 
 	if err = s.Delete(ctx, path.Join(storageSpec.FilePath.String(), name, version)); err != nil {
-		return fmt.Errorf("failed to delete cid from key value database with %w", err)
+		return "", fmt.Errorf("failed to delete cid from key value database with %w", err)
 	}
 
 	if err = s.Delete(ctx, path.Join(common.KvSize, name, version)); err != nil {
-		return fmt.Errorf("failed to delete file size from key value database with %w", err)
+		return "", fmt.Errorf("failed to delete file size from key value database with %w", err)
 
 	}
 
-	return nil
+	if err = s.Delete(ctx, path.Join(common.KvTime, name, version)); err != nil {
+		return "", fmt.Errorf("failed to delete file time from key value database with %w", err)
+	}
+
+	return string(cid), nil
 }
 
 // version 0 for latest storage
@@ -346,8 +383,14 @@ func (s *Store) GetLatestVersion(ctx context.Context, name string) (int, error)
 	return latest, nil
 }
 
-func (s *Store) UpdateCapacity(size uint64) {
-	s.context.Config.Size = size
+// UpdateConfig applies a new config of the storage, starting or stopping the
+// lifecycle sweep as its rules require.
+func (s *Store) UpdateConfig(config *structureSpec.Storage) {
+	s.configLock.Lock()
+	s.context.Config = config
+	s.configLock.Unlock()
+
+	s.scheduleLifecycle()
 }
 
 func (s *Store) Close() {
@@ -46,5 +46,7 @@ func New(srv storageIface.Service, hoarderClient hoarderIface.Client, storageCon
 		return nil, fmt.Errorf("exited: %d", val)
 	}
 
+	_store.scheduleLifecycle()
+
 	return _store, nil
 }
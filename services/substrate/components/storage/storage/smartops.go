@@ -24,7 +24,7 @@ func (s *Store) Event() interface{} {
 }
 
 func (s *Store) SmartOps() (uint32, error) {
-	return s.srv.SmartOps().Run(s, s.context.Config.SmartOps)
+	return s.srv.SmartOps().Run(s, s.Config().SmartOps)
 }
 
 func (s *Store) Application() string {
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"sync"
 
 	"github.com/ipfs/go-cid"
 	"github.com/taubyte/tau/core/kvdb"
@@ -22,6 +23,13 @@ type Store struct {
 
 	instanceCtx  context.Context
 	instanceCtxC context.CancelFunc
+
+	// configLock guards context.Config, replaced when the project changes.
+	configLock sync.RWMutex
+
+	lifecycleLock sync.Mutex
+	// lifecycleC stops the lifecycle sweep, nil when it isn't running.
+	lifecycleC context.CancelFunc
 }
 
 type Meta struct {
@@ -35,9 +43,15 @@ func (s *Store) Kvdb() kvdb.KVDB {
 }
 
 func (s *Store) Config() *structureSpec.Storage {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+
 	return s.context.Config
 }
 
 func (s *Store) ContextConfig() storageIface.Context {
+	s.configLock.RLock()
+	defer s.configLock.RUnlock()
+
 	return s.context
 }
@@ -35,7 +35,7 @@ func (s *Service) updateStorage(storage storageIface.Storage, branch string) (st
 		return nil, err
 	}
 
-	storage.UpdateCapacity(newConfig.Size)
+	storage.UpdateConfig(newConfig)
 
 	return storage, nil
 }
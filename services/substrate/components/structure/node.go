@@ -73,6 +73,14 @@ func (s *NodeService) Context() context.Context {
 	return s.ctx
 }
 
+func (s *NodeService) Logs() substrate.LogService {
+	return nil
+}
+
+func (s *NodeService) Events() substrate.EventService {
+	return nil
+}
+
 func (s *NodeService) Counter() substrate.CounterService {
 	return s.nodeCounters
 }
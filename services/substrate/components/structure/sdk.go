@@ -5,6 +5,7 @@ import (
 
 	"github.com/pterm/pterm"
 	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	"github.com/taubyte/tau/core/services/substrate/events"
 	"github.com/taubyte/tau/core/vm"
 	"github.com/taubyte/tau/p2p/streams/command"
 	"github.com/taubyte/tau/p2p/streams/command/response"
@@ -37,4 +38,9 @@ func (ts *TestSdk) CreateP2PEvent(cmd *command.Command, response response.Respon
 	return &event.Event{}
 }
 
+func (ts *TestSdk) CreateChangeEvent(msg pubsubIface.Message, change *events.Event) *event.Event {
+	CalledTestFunctionsPubsub = append(CalledTestFunctionsPubsub, msg)
+	return &event.Event{}
+}
+
 func (ts *TestSdk) AttachEvent(*event.Event) {}
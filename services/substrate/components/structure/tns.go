@@ -1,6 +1,8 @@
 package structure
 
 import (
+	"context"
+
 	"github.com/taubyte/tau/clients/p2p/tns/structure"
 	"github.com/taubyte/tau/core/services/tns"
 	databaseSpec "github.com/taubyte/tau/pkg/specs/database"
@@ -32,6 +34,10 @@ func (tc *TestClient) Fetch(path tns.Path) (tns.Object, error) {
 	return FakeFetchMethod(path)
 }
 
+func (tc *TestClient) WithContext(context.Context) tns.Client {
+	return tc
+}
+
 type ResponseObject struct {
 	Object    interface{}
 	InnerPath tns.Path
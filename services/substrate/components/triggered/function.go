@@ -0,0 +1,108 @@
+package triggered
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	commonIface "github.com/taubyte/tau/core/services/substrate/components"
+	"github.com/taubyte/tau/core/services/substrate/smartops"
+	matcherSpec "github.com/taubyte/tau/pkg/specs/matcher"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	plugins "github.com/taubyte/tau/pkg/vm-low-orbit"
+)
+
+func (f *Function) Commit() string {
+	return f.commit
+}
+
+func (f *Function) Branch() string {
+	return f.branch
+}
+
+func (f *Function) Project() string {
+	return f.project
+}
+
+func (f *Function) Close() {
+	f.closeOnce.Do(func() {
+		go func() {
+			f.Shutdown()
+			f.instanceCtxC()
+		}()
+	})
+}
+
+// Trigger calls the function on the event newEvent creates in a fresh
+// instance, and returns when its cold start ended.
+func (f *Function) Trigger(newEvent func(sdk plugins.Instance) uint32) (t time.Time, err error) {
+	instance, err := f.Instantiate(f.instanceCtx)
+	if err != nil {
+		return t, fmt.Errorf("instantiating function `%s` on project `%s` on application `%s` failed with: %s", f.config.Name, f.project, f.application, err)
+	}
+	defer instance.Free()
+
+	id := newEvent(instance.SDK())
+
+	val, err := f.SmartOps()
+	if err != nil {
+		return t, fmt.Errorf("running smartops failed with: %w", err)
+	}
+	if val > 0 {
+		return t, &smartops.BlockedError{Code: val}
+	}
+
+	return time.Now(), f.Call(instance, id)
+}
+
+func (f *Function) Match(matcher commonIface.MatchDefinition) matcherSpec.Index {
+	if matcher == nil || matcher.String() != f.matcher.String() {
+		return matcherSpec.NoMatch
+	}
+
+	return matcherSpec.HighMatch
+}
+
+func (f *Function) Validate(matcher commonIface.MatchDefinition) error {
+	if f.Match(matcher) != matcherSpec.HighMatch {
+		return errors.New("function id does not match")
+	}
+
+	return nil
+}
+
+func (f *Function) Matcher() commonIface.MatchDefinition {
+	return f.matcher
+}
+
+func (f *Function) Name() string {
+	return f.config.Name
+}
+
+func (f *Function) Id() string {
+	return f.config.Id
+}
+
+func (f *Function) Ready() error {
+	if !f.readyDone {
+		<-f.readyCtx.Done()
+	}
+
+	return f.readyError
+}
+
+func (f *Function) Application() string {
+	return f.application
+}
+
+func (f *Function) Config() *structureSpec.Function {
+	return &f.config
+}
+
+func (f *Function) Service() commonIface.ServiceComponent {
+	return f.srv
+}
+
+func (f *Function) AssetId() string {
+	return f.assetId
+}
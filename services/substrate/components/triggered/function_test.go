@@ -0,0 +1,20 @@
+package triggered
+
+import (
+	"testing"
+
+	eventsIface "github.com/taubyte/tau/core/services/substrate/components/events"
+	scheduleIface "github.com/taubyte/tau/core/services/substrate/components/schedule"
+	matcherSpec "github.com/taubyte/tau/pkg/specs/matcher"
+	"gotest.tools/v3/assert"
+)
+
+func TestMatch(t *testing.T) {
+	f := &Function{matcher: &eventsIface.MatchDefinition{Project: "project", Function: "function"}}
+
+	assert.Equal(t, f.Match(&eventsIface.MatchDefinition{Project: "project", Function: "function"}), matcherSpec.HighMatch)
+	assert.Equal(t, f.Match(&scheduleIface.MatchDefinition{Project: "project", Function: "function"}), matcherSpec.HighMatch)
+	assert.Equal(t, f.Match(&eventsIface.MatchDefinition{Project: "project", Function: "other"}), matcherSpec.NoMatch)
+	assert.Equal(t, f.Match(&eventsIface.MatchDefinition{Project: "other", Function: "function"}), matcherSpec.NoMatch)
+	assert.ErrorContains(t, f.Validate(&eventsIface.MatchDefinition{Project: "project", Function: "other"}), "does not match")
+}
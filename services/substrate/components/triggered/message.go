@@ -0,0 +1,29 @@
+package triggered
+
+import "encoding/json"
+
+// NewMessage returns the message of trigger, sent by source on topic.
+func NewMessage(source, topic string, trigger any) (*Message, error) {
+	data, err := json.Marshal(trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{source: source, topic: topic, data: data}, nil
+}
+
+func (m *Message) GetSource() string {
+	return m.source
+}
+
+func (m *Message) GetData() []byte {
+	return m.data
+}
+
+func (m *Message) GetTopic() string {
+	return m.topic
+}
+
+func (m *Message) Marshal() ([]byte, error) {
+	return m.data, nil
+}
@@ -0,0 +1,54 @@
+package triggered
+
+import (
+	"context"
+	"fmt"
+
+	commonIface "github.com/taubyte/tau/core/services/substrate/components"
+	"github.com/taubyte/tau/services/substrate/runtime"
+	"github.com/taubyte/tau/services/substrate/runtime/cache"
+)
+
+// New creates the function def points to. Its runs happen under ctx, a
+// context of srv. The component adds it to its cache once wrapped.
+func New(ctx context.Context, srv commonIface.ServiceComponent, def Definition) (*Function, error) {
+	f := &Function{
+		srv:         srv,
+		config:      def.Config,
+		project:     def.Project,
+		application: def.Application,
+		matcher:     def.Matcher,
+		commit:      def.Commit,
+		branch:      def.Branch,
+	}
+
+	f.instanceCtx, f.instanceCtxC = context.WithCancel(ctx)
+	f.readyCtx, f.readyCtxC = context.WithCancel(ctx)
+
+	var err error
+	defer func() {
+		f.readyError = err
+		f.readyDone = true
+		f.readyCtxC()
+		if err != nil {
+			f.instanceCtxC()
+		}
+	}()
+
+	if f.Function, err = runtime.New(f.instanceCtx, f); err != nil {
+		return nil, fmt.Errorf("initializing vm module failed with: %w", err)
+	}
+
+	if f.config.Source == "." {
+		f.assetId, err = cache.ResolveAssetCid(ctx, f)
+		if err != nil {
+			return nil, fmt.Errorf("getting asset id failed with: %w", err)
+		}
+	}
+
+	if err = f.Validate(def.Matcher); err != nil {
+		return nil, fmt.Errorf("validating function with id: `%s` failed with: %s", f.config.Id, err)
+	}
+
+	return f, nil
+}
@@ -0,0 +1,26 @@
+package triggered
+
+import (
+	"context"
+
+	sdkSmartOpsCommon "github.com/taubyte/go-sdk-smartops/common"
+	"github.com/taubyte/tau/core/services/substrate/smartops"
+)
+
+var _ smartops.EventCaller = &Function{}
+
+// The smartops SDK has no schedule nor change resource type; triggered
+// functions receive a pubsub event, so their smartops see a pubsub function.
+const resourceType = sdkSmartOpsCommon.ResourceTypeFunctionPubSub
+
+func (f *Function) Type() uint32 {
+	return uint32(resourceType)
+}
+
+func (f *Function) Context() context.Context {
+	return f.instanceCtx
+}
+
+func (f *Function) SmartOps() (uint32, error) {
+	return f.srv.SmartOps().Run(f, f.config.SmartOps)
+}
@@ -0,0 +1,58 @@
+package triggered
+
+import (
+	"context"
+	"sync"
+
+	commonIface "github.com/taubyte/tau/core/services/substrate/components"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/runtime"
+)
+
+var _ commonIface.FunctionServiceable = &Function{}
+
+// Function is a function the node runs on a trigger rather than a request: a
+// schedule tick or a storage or database change. Components wrap it with the
+// Handle of their trigger.
+type Function struct {
+	srv    commonIface.ServiceComponent
+	config structureSpec.Function
+
+	assetId string
+
+	project     string
+	application string
+	matcher     commonIface.MatchDefinition
+	commit      string
+	branch      string
+
+	readyCtx   context.Context
+	readyCtxC  context.CancelFunc
+	readyError error
+	readyDone  bool
+
+	instanceCtx  context.Context
+	instanceCtxC context.CancelFunc
+
+	closeOnce sync.Once
+
+	*runtime.Function
+}
+
+// Definition is the function a component triggers.
+type Definition struct {
+	Config      structureSpec.Function
+	Commit      string
+	Branch      string
+	Project     string
+	Application string
+	Matcher     commonIface.MatchDefinition
+}
+
+// Message is the pubsub message of a triggered event: guests see a pubsub
+// event on its topic whose data is the JSON encoded trigger.
+type Message struct {
+	source string
+	topic  string
+	data   []byte
+}
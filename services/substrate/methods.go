@@ -29,6 +29,9 @@ func (srv *Service) Close() error {
 
 	srv.tns.Close()
 	srv.components.close()
+	if srv.authClient != nil {
+		srv.authClient.Close()
+	}
 
 	srv.vm.Close()
 
@@ -131,6 +131,14 @@ func (f *fakeHoarder) StashStatus(cids ...string) (map[string]int, int, error) {
 	return out, f.target, nil
 }
 
+func (f *fakeHoarder) Release(cid, _ string, _ ...hoarderIface.StashOption) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.stashed, cid)
+	delete(f.claims, cid)
+	return true, nil
+}
+
 func (f *fakeHoarder) Metas(hashes ...string) ([]hoarderIface.InstanceInfo, error) {
 	if f.metasErr != nil {
 		return nil, f.metasErr
@@ -194,6 +194,8 @@ func kindName(k hoarderIface.ResourceKind) string {
 		return "storage"
 	case hoarderIface.Global:
 		return "global"
+	case hoarderIface.Messaging:
+		return "messaging"
 	default:
 		return fmt.Sprintf("kind-%d", int(k))
 	}
@@ -45,6 +45,16 @@ func (m *mockedSubstrate) Counter() substrate.CounterService {
 	return m.counters
 }
 
+// Logs returns nil: function logs are dropped.
+func (m *mockedSubstrate) Logs() substrate.LogService {
+	return nil
+}
+
+// Events returns nil: storage and database changes trigger nothing.
+func (m *mockedSubstrate) Events() substrate.EventService {
+	return nil
+}
+
 func (m *mockedSubstrate) SmartOps() substrate.SmartOpsService {
 	return m.smartOps
 }
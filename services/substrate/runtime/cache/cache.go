@@ -83,15 +83,10 @@ func (c *Cache) Get(matcher iface.MatchDefinition, ops iface.GetOptions) ([]ifac
 	if ops.MatchIndex != nil {
 		matchIndex = *ops.MatchIndex
 	}
-	branch := ops.Branches
-	if len(branch) < 1 {
-		branch = spec.DefaultBranches
-	}
-
 	for _, serviceable := range servList {
 		if serviceable.Match(matcher) == matchIndex {
 			if ops.Validation {
-				if err := c.validate(serviceable, branch); err != nil {
+				if err := c.validate(serviceable, validationBranches(serviceable, ops.Branches)); err != nil {
 					// remove serviceable from cache & continue
 					c.Remove(serviceable)
 					continue
@@ -116,13 +111,34 @@ func (c *Cache) Remove(serviceable iface.Serviceable) {
 	serviceable.Close()
 }
 
-// validate method checks to see if the serviceable commit matches the current commit.
+// validationBranches returns the branches a cached serviceable is validated
+// against; preview serviceables track their own branch rather than the
+// default ones.
+func validationBranches(serviceable iface.Serviceable, branches []string) []string {
+	if len(branches) > 0 {
+		return branches
+	}
+
+	if branch := serviceable.Branch(); branch != "" && !spec.IsDefaultBranch(branch) {
+		return []string{branch}
+	}
+
+	return spec.DefaultBranches
+}
+
+// validate method checks to see if the serviceable commit matches the current commit,
+// or the one its matcher is pinned to while the traffic policy still serves it.
 func (c *Cache) validate(serviceable iface.Serviceable, branches []string) error {
-	tnsClient := serviceable.Service().Tns()
-	projectId := serviceable.Project()
-	commit, _, err := tnsClient.Simple().Commit(projectId, branches...)
-	if err != nil {
-		return fmt.Errorf("getting serviceable `%s` commit failed with: %w", serviceable.Id(), err)
+	branch, commit := pinned(serviceable)
+	if commit != "" {
+		if err := servedByPolicy(serviceable, branch, commit); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		if commit, _, err = serviceable.Service().Tns().Simple().Commit(serviceable.Project(), branches...); err != nil {
+			return fmt.Errorf("getting serviceable `%s` commit failed with: %w", serviceable.Id(), err)
+		}
 	}
 
 	if serviceable.Commit() != commit {
@@ -130,7 +146,7 @@ func (c *Cache) validate(serviceable iface.Serviceable, branches []string) error
 	}
 
 	// if we have an asset ID, check if it is up to date
-	assetId, err := ResolveAssetCid(serviceable)
+	assetId, err := ResolveAssetCid(serviceable.Service().Context(), serviceable)
 	if err == nil && serviceable.AssetId() != assetId {
 		return fmt.Errorf("cached pick assetId `%s` is outdated, latest assetId is `%s`", serviceable.AssetId(), assetId)
 	}
@@ -16,8 +16,10 @@ import (
 	"github.com/taubyte/tau/core/vm"
 	"github.com/taubyte/tau/p2p/peer"
 	http "github.com/taubyte/tau/pkg/http"
+	spec "github.com/taubyte/tau/pkg/specs/common"
 	matcherSpec "github.com/taubyte/tau/pkg/specs/matcher"
 	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	tcc "github.com/taubyte/tau/utils/tcc"
 )
 
 // Mock implementations for testing
@@ -56,6 +58,8 @@ func (m *mockServiceComponent) CheckTns(matcher components.MatchDefinition) ([]c
 func (m *mockServiceComponent) Vm() vm.Service                      { return nil }
 func (m *mockServiceComponent) Counter() substrate.CounterService   { return nil }
 func (m *mockServiceComponent) SmartOps() substrate.SmartOpsService { return nil }
+func (m *mockServiceComponent) Logs() substrate.LogService          { return nil }
+func (m *mockServiceComponent) Events() substrate.EventService      { return nil }
 func (m *mockServiceComponent) Orbitals() []vm.Plugin               { return nil }
 func (m *mockServiceComponent) Dev() bool                           { return false }
 func (m *mockServiceComponent) Verbose() bool                       { return false }
@@ -72,6 +76,9 @@ type mockTnsClient struct {
 	commit string
 	cid    string
 	err    error
+
+	// objects are fetched by path instead of cid.
+	objects map[string]interface{}
 }
 
 func (m *mockTnsClient) Simple() tns.SimpleIface {
@@ -82,11 +89,22 @@ func (m *mockTnsClient) Fetch(path tns.Path) (tns.Object, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
-	return &mockTnsObject{cid: m.cid}, nil
+	if object, ok := m.objects[path.String()]; ok {
+		return &mockTnsObject{object: object}, nil
+	}
+	return &mockTnsObject{cid: m.cid, object: m.cid}, nil
+}
+
+func (m *mockTnsClient) Lookup(query tns.Query) (interface{}, error) { return nil, nil }
+
+func (m *mockTnsClient) Push(path []string, data interface{}) error {
+	if m.objects == nil {
+		m.objects = make(map[string]interface{})
+	}
+	m.objects[spec.NewTnsPath(path).String()] = data
+	return nil
 }
 
-func (m *mockTnsClient) Lookup(query tns.Query) (interface{}, error)             { return nil, nil }
-func (m *mockTnsClient) Push(path []string, data interface{}) error              { return nil }
 func (m *mockTnsClient) List(depth int) ([][]string, error)                      { return nil, nil }
 func (m *mockTnsClient) Close()                                                  {}
 func (m *mockTnsClient) Database() tns.StructureIface[*structureSpec.Database]   { return nil }
@@ -100,6 +118,7 @@ func (m *mockTnsClient) Storage() tns.StructureIface[*structureSpec.Storage]
 func (m *mockTnsClient) Website() tns.StructureIface[*structureSpec.Website]     { return nil }
 func (m *mockTnsClient) Stats() tns.Stats                                        { return nil }
 func (m *mockTnsClient) Peers(...libp2pPeer.ID) tns.Client                       { return m }
+func (m *mockTnsClient) WithContext(context.Context) tns.Client                  { return m }
 
 type mockTnsSimpleClient struct {
 	client *mockTnsClient
@@ -117,13 +136,14 @@ func (m *mockTnsSimpleClient) GetRepositoryProjectId(gitProvider, repoId string)
 }
 
 type mockTnsObject struct {
-	cid string
+	cid    string
+	object interface{}
 }
 
 func (m *mockTnsObject) Path() tns.Path         { return nil }
 func (m *mockTnsObject) Bind(interface{}) error { return nil }
 func (m *mockTnsObject) Interface() interface{} {
-	return m.cid
+	return m.object
 }
 func (m *mockTnsObject) Current(branch []string) ([]tns.Path, error) { return nil, nil }
 
@@ -1487,6 +1507,9 @@ func (m *mockTnsClientWithNonStringCid) Website() tns.StructureIface[*structureS
 }
 func (m *mockTnsClientWithNonStringCid) Stats() tns.Stats                  { return nil }
 func (m *mockTnsClientWithNonStringCid) Peers(...libp2pPeer.ID) tns.Client { return m }
+func (m *mockTnsClientWithNonStringCid) WithContext(context.Context) tns.Client {
+	return m
+}
 
 type mockTnsObjectWithNonStringCid struct {
 	cid interface{}
@@ -1504,7 +1527,7 @@ func TestResolveAssetCid(t *testing.T) {
 	serviceable := createMockServiceable("test-id", "test-prefix", matcherSpec.HighMatch)
 
 	// Test successful case
-	cid, err := ResolveAssetCid(serviceable)
+	cid, err := ResolveAssetCid(context.Background(), serviceable)
 	if err != nil {
 		t.Fatalf("ResolveAssetCid() failed: %v", err)
 	}
@@ -1523,7 +1546,7 @@ func TestResolveAssetCidWithTnsPathError(t *testing.T) {
 	// Test with empty project ID to trigger error
 	serviceable.project = ""
 
-	_, err := ResolveAssetCid(serviceable)
+	_, err := ResolveAssetCid(context.Background(), serviceable)
 	if err == nil {
 		t.Fatal("Expected error for empty project ID")
 	}
@@ -1540,7 +1563,7 @@ func TestResolveAssetCidWithTnsFetchError(t *testing.T) {
 	mockTnsClient := mockService.tnsClient.(*mockTnsClient)
 	mockTnsClient.err = errors.New("TNS fetch error")
 
-	_, err := ResolveAssetCid(serviceable)
+	_, err := ResolveAssetCid(context.Background(), serviceable)
 	if err == nil {
 		t.Fatal("Expected error for TNS fetch error")
 	}
@@ -1560,7 +1583,7 @@ func TestResolveAssetCidWithNonStringCid(t *testing.T) {
 		cid:    123, // Non-string CID
 	}
 
-	_, err := ResolveAssetCid(serviceable)
+	_, err := ResolveAssetCid(context.Background(), serviceable)
 	if err == nil {
 		t.Fatal("Expected error for non-string CID")
 	}
@@ -2197,3 +2220,124 @@ func TestCacheWithMultipleMatchIndices(t *testing.T) {
 		t.Fatal("Expected high match serviceable")
 	}
 }
+
+func TestValidationBranches(t *testing.T) {
+	serviceable := createMockServiceable("test-id", "test-prefix", matcherSpec.HighMatch)
+
+	if got := validationBranches(serviceable, []string{"custom"}); len(got) != 1 || got[0] != "custom" {
+		t.Fatalf("Expected explicit branches, got %v", got)
+	}
+
+	if got := validationBranches(serviceable, nil); len(got) != 2 || got[0] != "main" {
+		t.Fatalf("Expected default branches, got %v", got)
+	}
+
+	serviceable.branch = "feature/login"
+	if got := validationBranches(serviceable, nil); len(got) != 1 || got[0] != "feature/login" {
+		t.Fatalf("Expected preview branch, got %v", got)
+	}
+}
+
+type mockPinnedMatchDefinition struct {
+	mockMatchDefinition
+	branch string
+	commit string
+}
+
+func (m *mockPinnedMatchDefinition) Pinned() (string, string) {
+	return m.branch, m.commit
+}
+
+func TestValidatePinned(t *testing.T) {
+	cache := New()
+	serviceable := createMockServiceable("test-id", "test-prefix@stable-commit", matcherSpec.HighMatch)
+	serviceable.commit = "stable-commit"
+	serviceable.matcher = &mockPinnedMatchDefinition{
+		mockMatchDefinition: mockMatchDefinition{cachePrefix: "test-prefix@stable-commit"},
+		branch:              "main",
+		commit:              "stable-commit",
+	}
+
+	tnsClient := serviceable.service.(*mockServiceComponent).tnsClient.(*mockTnsClient)
+	tnsClient.objects = map[string]interface{}{
+		spec.Traffic("test-project", "main").String(): map[string]interface{}{"stable": "stable-commit", "weight": 10},
+	}
+
+	if _, err := cache.Add(serviceable); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+
+	// The current commit differs, the pinned serviceable stays valid.
+	if _, err := cache.Get(serviceable.matcher, components.GetOptions{Validation: true}); err != nil {
+		t.Fatalf("Expected pinned serviceable to be valid, got %v", err)
+	}
+
+	serviceable.commit = "other-commit"
+	if _, err := cache.Get(serviceable.matcher, components.GetOptions{Validation: true}); err == nil {
+		t.Fatal("Expected serviceable of another commit to be invalid")
+	}
+
+	// Once the policy stops naming the commit, the serviceable is evicted.
+	serviceable.commit = "stable-commit"
+	if _, err := cache.Add(serviceable); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+
+	tnsClient.objects[spec.Traffic("test-project", "main").String()] = map[string]interface{}{"stable": "new-stable", "weight": 10}
+	if _, err := cache.Get(serviceable.matcher, components.GetOptions{Validation: true}); err == nil {
+		t.Fatal("Expected serviceable of a commit out of the policy to be invalid")
+	}
+}
+
+func TestResolveAssetCidPinned(t *testing.T) {
+	serviceable := createMockServiceable("test-id", "test-prefix@c1", matcherSpec.HighMatch)
+	serviceable.matcher = &mockPinnedMatchDefinition{branch: "main", commit: "c1"}
+
+	tnsClient := serviceable.service.(*mockServiceComponent).tnsClient.(*mockTnsClient)
+	deployed := append(spec.Deployment("test-project", "main", "c1").Slice(), "assets", "test-id")
+	tnsClient.objects = map[string]interface{}{spec.NewTnsPath(deployed).String(): "pinned-asset"}
+
+	if cid, err := ResolveAssetCid(context.Background(), serviceable); err != nil || cid != "pinned-asset" {
+		t.Fatalf("Expected asset of the pinned commit, got %q, %v", cid, err)
+	}
+
+	// Without an asset recorded, it does not fall back to the branch's.
+	tnsClient.objects = map[string]interface{}{spec.NewTnsPath(deployed).String(): nil}
+	if _, err := ResolveAssetCid(context.Background(), serviceable); err == nil {
+		t.Fatal("Expected a pinned commit without an asset to fail")
+	}
+}
+
+func TestResolveAssetCidBuiltSeparately(t *testing.T) {
+	serviceable := createMockServiceable("test-id", "test-prefix@config-1", matcherSpec.HighMatch)
+	pinned := &mockPinnedMatchDefinition{branch: "main", commit: "config-1"}
+	serviceable.matcher = pinned
+
+	tnsClient := serviceable.service.(*mockServiceComponent).tnsClient.(*mockTnsClient)
+	current := spec.Current("test-project", "main").String()
+	tnsClient.objects = map[string]interface{}{current: "config-1"}
+
+	// The code is built after config-1 was deployed, from a commit of its own.
+	if err := tcc.PublishBuiltAsset(tnsClient, "test-project", "main", "test-id", "built-asset"); err != nil {
+		t.Fatalf("PublishBuiltAsset() failed: %v", err)
+	}
+
+	if cid, err := ResolveAssetCid(context.Background(), serviceable); err != nil || cid != "built-asset" {
+		t.Fatalf("Expected asset built for config-1, got %q, %v", cid, err)
+	}
+
+	// Building again once config-2 is deployed leaves config-1 as it was.
+	tnsClient.objects[current] = "config-2"
+	if err := tcc.PublishBuiltAsset(tnsClient, "test-project", "main", "test-id", "rebuilt-asset"); err != nil {
+		t.Fatalf("PublishBuiltAsset() failed: %v", err)
+	}
+
+	if cid, err := ResolveAssetCid(context.Background(), serviceable); err != nil || cid != "built-asset" {
+		t.Fatalf("Expected config-1 to keep its asset, got %q, %v", cid, err)
+	}
+
+	pinned.commit = "config-2"
+	if cid, err := ResolveAssetCid(context.Background(), serviceable); err != nil || cid != "rebuilt-asset" {
+		t.Fatalf("Expected asset built for config-2, got %q, %v", cid, err)
+	}
+}
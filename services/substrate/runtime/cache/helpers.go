@@ -1,20 +1,53 @@
 package cache
 
 import (
+	"context"
 	"fmt"
 
 	iface "github.com/taubyte/tau/core/services/substrate/components"
+	spec "github.com/taubyte/tau/pkg/specs/common"
 	"github.com/taubyte/tau/pkg/specs/methods"
 )
 
+// pinned returns the branch and commit the matcher of serviceable is pinned to,
+// if any.
+func pinned(serviceable iface.Serviceable) (branch, commit string) {
+	if matcher, ok := serviceable.Matcher().(iface.PinnedMatchDefinition); ok {
+		return matcher.Pinned()
+	}
+
+	return "", ""
+}
+
 // TODO: This should return a cid.Cid
-func ResolveAssetCid(serviceable iface.Serviceable) (string, error) {
+//
+// Serviceables pinned to a config commit get the asset recorded in its
+// deployment, builds of the code recording theirs under the config commit
+// deployed then. The branch's asset is the current commit's. TNS is asked as
+// part of the trace of ctx, if any.
+func ResolveAssetCid(ctx context.Context, serviceable iface.Serviceable) (string, error) {
+	tns := serviceable.Service().Tns().WithContext(ctx)
+	if branch, commit := pinned(serviceable); commit != "" {
+		deployed := append(spec.Deployment(serviceable.Project(), branch, commit).Slice(), "assets", serviceable.Id())
+		cidObj, err := tns.Fetch(spec.NewTnsPath(deployed))
+		if err != nil {
+			return "", fmt.Errorf("fetching asset of commit `%s` failed with: %w", commit, err)
+		}
+
+		cid, ok := cidObj.Interface().(string)
+		if !ok || cid == "" {
+			return "", fmt.Errorf("commit `%s` has no asset recorded for `%s`", commit, serviceable.Id())
+		}
+
+		return cid, nil
+	}
+
 	assetPath, err := methods.GetTNSAssetPath(serviceable.Project(), serviceable.Id(), serviceable.Branch())
 	if err != nil {
 		return "", fmt.Errorf("getting tns asset path failed with: %w", err)
 	}
 
-	cidObj, err := serviceable.Service().Tns().Fetch(assetPath)
+	cidObj, err := tns.Fetch(assetPath)
 	if err != nil || cidObj.Interface() == nil {
 		return "", fmt.Errorf("fetching cid object failed with: %w", err)
 	}
@@ -26,3 +59,29 @@ func ResolveAssetCid(serviceable iface.Serviceable) (string, error) {
 
 	return cid, nil
 }
+
+// servedByPolicy checks the traffic policy of branch still sends requests to
+// commit, the one a serviceable is pinned to.
+func servedByPolicy(serviceable iface.Serviceable, branch, commit string) error {
+	tns := serviceable.Service().Tns()
+	current, _, err := tns.Simple().Commit(serviceable.Project(), branch)
+	if err != nil {
+		return fmt.Errorf("getting commit of `%s` failed with: %w", branch, err)
+	}
+
+	policyObj, err := tns.Fetch(spec.Traffic(serviceable.Project(), branch))
+	if err != nil {
+		return fmt.Errorf("fetching traffic policy of `%s` failed with: %w", branch, err)
+	}
+
+	policy, err := spec.DecodeTrafficPolicy(policyObj.Interface())
+	if err != nil {
+		return err
+	}
+
+	if !policy.Active() || (commit != policy.Stable && commit != policy.CanaryOf(current)) {
+		return fmt.Errorf("commit `%s` is no longer in the traffic policy of `%s`", commit, branch)
+	}
+
+	return nil
+}
@@ -2,46 +2,31 @@ package runtime
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"time"
 
 	"github.com/taubyte/tau/core/vm"
+	"github.com/taubyte/tau/pkg/tracing"
 )
 
-var DebugFunctionCallsLogger vm.Logger
-
 // Call takes instance and id, then calls the moduled function. Returns an error.
-func (f *Function) Call(inst Instance, id uint32) (err error) {
+func (f *Function) Call(inst Instance, id uint32) error {
+	return f.CallContext(context.Background(), inst, id)
+}
+
+// CallContext is Call as part of the span of ctx, which the host calls of the
+// function, like its outbound HTTP requests, are traced under. Cancelling ctx
+// does not stop the call.
+func (f *Function) CallContext(traceCtx context.Context, inst Instance, id uint32) (err error) {
 	startTime := time.Now()
+	var memory uint64
 	defer func() {
 		if err == nil {
 			f.calls.Add(1)
 			f.totalCallTime.Add(int64(time.Since(startTime)))
 		}
 
-		if DebugFunctionCallsLogger != nil {
-			logWriter, lgErr := DebugFunctionCallsLogger.New(f.vmContext)
-			if lgErr != nil {
-				return
-			}
-
-			meta := map[string]interface{}{
-				"start_time": startTime.UnixNano(),
-				"end_time":   time.Now().UnixNano(),
-				"duration":   time.Since(startTime).Nanoseconds(),
-			}
-
-			if err != nil {
-				meta["error"] = err.Error()
-			}
-
-			json.NewEncoder(logWriter).Encode(meta)
-			io.Copy(logWriter, inst.Stdout())
-			io.Copy(logWriter, inst.Stderr())
-			logWriter.Close()
-		}
+		f.log(inst, startTime, memory, err)
 	}()
 
 	moduleName, err := f.moduleName()
@@ -77,7 +62,7 @@ func (f *Function) Call(inst Instance, id uint32) (err error) {
 		}
 	}
 
-	ctx, ctxC := context.WithTimeout(f.ctx, time.Duration(time.Nanosecond*time.Duration(f.config.Timeout)))
+	ctx, ctxC := context.WithTimeout(tracing.WithSpan(f.ctx, traceCtx), time.Duration(time.Nanosecond*time.Duration(f.config.Timeout)))
 	defer ctxC()
 
 	_, err = fx.RawCall(ctx, uint64(id))
@@ -88,8 +73,8 @@ func (f *Function) Call(inst Instance, id uint32) (err error) {
 			}
 		}()
 	}
-	if mem := uint64(module.Memory().Size()); mem > f.maxMemory.Load() {
-		f.maxMemory.Store(mem)
+	if memory = uint64(module.Memory().Size()); memory > f.maxMemory.Load() {
+		f.maxMemory.Store(memory)
 	}
 
 	if err != nil {
@@ -9,6 +9,24 @@ import (
 	"github.com/taubyte/tau/services/substrate/components/counters/metrics"
 )
 
+// memoryReporter is implemented by serviceables backed by a wasm runtime.
+type memoryReporter interface {
+	MemoryMax() uint64
+}
+
+// resourceOf returns the resource a serviceable's counters are kept under:
+// those serving a commit pinned by a traffic policy are counted apart, as
+// <id>@<commit>, so the commits can be compared.
+func resourceOf(serviceable components.Serviceable) string {
+	if matcher, ok := serviceable.Matcher().(components.PinnedMatchDefinition); ok {
+		if _, commit := matcher.Pinned(); commit != "" {
+			return serviceable.Id() + "@" + commit
+		}
+	}
+
+	return serviceable.Id()
+}
+
 // ErrorWrapper is an wraps an error in the cold start and execution of a serviceable.
 // It handles the counter reporting for a serviceable based on its success and failures.
 //
@@ -25,7 +43,7 @@ func ErrorWrapper(serviceable components.Serviceable, startTime time.Time, coldS
 			if serviceable != nil {
 				doneTime := time.Now()
 				var skipExecution bool
-				basePath := counters.NewPath(path.Join(serviceable.Project(), serviceable.Id()))
+				basePath := counters.NewPath(path.Join(serviceable.Project(), resourceOf(serviceable)))
 				totalTime := doneTime.Sub(startTime).Nanoseconds()
 
 				if gerr != nil {
@@ -76,6 +94,14 @@ func ErrorWrapper(serviceable components.Serviceable, startTime time.Time, coldS
 						},
 					)
 				}
+				if m, ok := serviceable.(memoryReporter); ok && !skipExecution {
+					if mem := m.MemoryMax(); mem > 0 {
+						ws = append(ws, &counters.WrappedMetric{
+							Key:    counters.NewPath(path.Join(serviceable.Project(), resourceOf(serviceable))).Memory().String(),
+							Metric: metrics.NewMaxMetric(mem),
+						})
+					}
+				}
 				serviceable.Service().Counter().Push(ws...)
 			}
 		}()
@@ -83,3 +109,16 @@ func ErrorWrapper(serviceable components.Serviceable, startTime time.Time, coldS
 
 	return gerr
 }
+
+// Rejected counts a request to a serviceable turned away by its rate limit or
+// concurrency cap.
+func Rejected(serviceable components.Serviceable) {
+	if !serviceable.Service().Counter().Implemented() {
+		return
+	}
+
+	go serviceable.Service().Counter().Push(&counters.WrappedMetric{
+		Key:    counters.NewPath(path.Join(serviceable.Project(), resourceOf(serviceable))).Rejected().String(),
+		Metric: metrics.NewSumMetric[uint64](1),
+	})
+}
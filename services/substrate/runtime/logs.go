@@ -0,0 +1,80 @@
+package runtime
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/core/vm"
+)
+
+// DebugFunctionCallsLogger, when set, gets every call: a JSON line of metadata
+// followed by the call's output.
+var DebugFunctionCallsLogger vm.Logger
+
+// log drains the output a call left in its instance, so it doesn't pile up in
+// pooled instances, and hands it with the outcome of the call to the substrate
+// log service and to DebugFunctionCallsLogger.
+func (f *Function) log(inst Instance, started time.Time, memory uint64, err error) {
+	duration := time.Since(started)
+	stdout, stdoutTruncated := readOutput(inst.Stdout())
+	stderr, stderrTruncated := readOutput(inst.Stderr())
+
+	if logs := f.serviceable.Service().Logs(); logs != nil {
+		entry := &patrick.FunctionLog{
+			ProjectId:   f.serviceable.Project(),
+			Application: f.serviceable.Application(),
+			FunctionId:  f.serviceable.Id(),
+			Branch:      f.branch,
+			Commit:      f.commit,
+			Started:     started.UnixNano(),
+			Duration:    duration.Nanoseconds(),
+			Memory:      memory,
+			Stdout:      string(stdout),
+			Stderr:      string(stderr),
+			Truncated:   stdoutTruncated || stderrTruncated,
+		}
+
+		if err != nil {
+			entry.Error = err.Error()
+		}
+
+		logs.Push(entry)
+	}
+
+	if DebugFunctionCallsLogger != nil {
+		logWriter, lgErr := DebugFunctionCallsLogger.New(f.vmContext)
+		if lgErr != nil {
+			return
+		}
+
+		meta := map[string]interface{}{
+			"start_time": started.UnixNano(),
+			"end_time":   started.Add(duration).UnixNano(),
+			"duration":   duration.Nanoseconds(),
+		}
+
+		if err != nil {
+			meta["error"] = err.Error()
+		}
+
+		json.NewEncoder(logWriter).Encode(meta)
+		logWriter.Write(stdout)
+		logWriter.Write(stderr)
+		logWriter.Close()
+	}
+}
+
+// readOutput reads up to MaxLogOutput of r and discards the rest, reporting
+// whether there was any.
+func readOutput(r io.Reader) ([]byte, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	data, _ := io.ReadAll(io.LimitReader(r, MaxLogOutput))
+	rest, _ := io.Copy(io.Discard, r)
+
+	return data, rest > 0
+}
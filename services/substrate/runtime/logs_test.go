@@ -0,0 +1,69 @@
+package runtime
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/core/services/substrate"
+	"gotest.tools/v3/assert"
+)
+
+type outputInstance struct {
+	Instance
+	stdout, stderr io.Reader
+}
+
+func (i *outputInstance) Stdout() io.Reader { return i.stdout }
+func (i *outputInstance) Stderr() io.Reader { return i.stderr }
+
+type pushedLogs struct {
+	substrate.LogService
+	entries []*patrick.FunctionLog
+}
+
+func (l *pushedLogs) Push(entry *patrick.FunctionLog) {
+	l.entries = append(l.entries, entry)
+}
+
+func TestLog(t *testing.T) {
+	max := MaxLogOutput
+	MaxLogOutput = 8
+	defer func() { MaxLogOutput = max }()
+
+	logs := new(pushedLogs)
+	serviceable := newMockServiceable()
+	serviceable.service.logs = logs
+	f := &Function{serviceable: serviceable, branch: "main", commit: "commit"}
+
+	stdout := bytes.NewBufferString("hello")
+	inst := &outputInstance{stdout: stdout, stderr: strings.NewReader("too much output")}
+	started := time.Now().Add(-time.Second)
+	f.log(inst, started, 1024, errors.New("trapped"))
+
+	assert.Equal(t, len(logs.entries), 1)
+	entry := logs.entries[0]
+	assert.Equal(t, entry.Branch, "main")
+	assert.Equal(t, entry.Commit, "commit")
+	assert.Equal(t, entry.Started, started.UnixNano())
+	assert.Assert(t, entry.Duration >= time.Second.Nanoseconds())
+	assert.Equal(t, entry.Memory, uint64(1024))
+	assert.Equal(t, entry.Error, "trapped")
+	assert.Equal(t, entry.Stdout, "hello")
+	assert.Equal(t, entry.Stderr, "too much")
+	assert.Assert(t, entry.Truncated)
+
+	// The output of a call doesn't carry over to the next one.
+	stdout.WriteString("again")
+	inst.stderr = strings.NewReader("")
+	f.log(inst, started, 1024, nil)
+
+	assert.Equal(t, len(logs.entries), 2)
+	assert.Equal(t, logs.entries[1].Stdout, "again")
+	assert.Equal(t, logs.entries[1].Error, "")
+	assert.Assert(t, !logs.entries[1].Truncated)
+}
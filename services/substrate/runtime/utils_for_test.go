@@ -5,6 +5,7 @@ import (
 	"time"
 
 	wazy "github.com/samyfodil/wazy"
+	"github.com/taubyte/tau/core/services/substrate"
 	"github.com/taubyte/tau/core/services/substrate/components"
 	"github.com/taubyte/tau/core/vm"
 	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
@@ -32,7 +33,8 @@ func newMockService() *mockService {
 
 type mockService struct {
 	components.ServiceComponent
-	vm *mockVm
+	vm   *mockVm
+	logs substrate.LogService
 }
 
 func newMockVm() *mockVm {
@@ -71,10 +73,12 @@ func (*mockServiceable) AssetId() string {
 	return "baguqeerasords4njcts6vs7qvdjfcvgnume4hqohf65zsfguprqphs3icwea"
 }
 
-func (*mockService) Verbose() bool           { return false }
-func (m *mockService) Vm() vm.Service        { return m.vm }
-func (*mockService) Orbitals() []vm.Plugin   { return nil }
-func (*mockService) Cache() components.Cache { return &mockCache{} }
+func (*mockService) Verbose() bool                  { return false }
+func (m *mockService) Vm() vm.Service               { return m.vm }
+func (*mockService) Orbitals() []vm.Plugin          { return nil }
+func (m *mockService) Logs() substrate.LogService   { return m.logs }
+func (*mockService) Events() substrate.EventService { return nil }
+func (*mockService) Cache() components.Cache        { return &mockCache{} }
 
 func (*mockCache) Remove(components.Serviceable) {}
 
@@ -17,4 +17,7 @@ var (
 	MemoryThreshold    uint64 = 80
 
 	DefaultWasmMemory uint64 = 4 * 1024 * 1024 * 1024
+
+	// MaxLogOutput is how much of each of stdout and stderr is logged per call.
+	MaxLogOutput int64 = 32 * 1024
 )
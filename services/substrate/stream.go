@@ -7,7 +7,7 @@ import (
 	"time"
 
 	"github.com/taubyte/tau/clients/p2p/substrate"
-	compIface "github.com/taubyte/tau/core/services/substrate/components"
+	"github.com/taubyte/tau/core/services/substrate/components/schedule"
 	con "github.com/taubyte/tau/p2p/streams"
 	"github.com/taubyte/tau/p2p/streams/command"
 	"github.com/taubyte/tau/p2p/streams/command/response"
@@ -15,9 +15,11 @@ import (
 	httptun "github.com/taubyte/tau/p2p/streams/tunnels/http"
 	functionSpec "github.com/taubyte/tau/pkg/specs/function"
 	websiteSpec "github.com/taubyte/tau/pkg/specs/website"
+	"github.com/taubyte/tau/pkg/tracing"
 	protocolCommon "github.com/taubyte/tau/services/common"
 	http "github.com/taubyte/tau/services/substrate/components/http/common"
 	"github.com/taubyte/tau/services/substrate/components/http/function"
+	"github.com/taubyte/tau/services/substrate/components/http/limits"
 	"github.com/taubyte/tau/services/substrate/components/http/website"
 	"github.com/taubyte/tau/utils/maps"
 )
@@ -35,11 +37,54 @@ func (s *Service) startStream() (err error) {
 		return fmt.Errorf("defining command `%s` failed with: %w", substrate.CommandHTTP, err)
 	}
 
+	if err := s.stream.Define(substrate.CommandSchedule, s.runSchedule); err != nil {
+		return fmt.Errorf("defining command `%s` failed with: %w", substrate.CommandSchedule, err)
+	}
+
 	s.stream.Start()
 
 	return
 }
 
+// runSchedule calls a scheduled function for the tick the scheduler fired,
+// replying once the call is over.
+func (s *Service) runSchedule(ctx context.Context, con con.Connection, body command.Body) (response.Response, error) {
+	var (
+		matcher schedule.MatchDefinition
+		err     error
+	)
+
+	if matcher.Project, err = maps.String(body, substrate.BodyProject); err != nil {
+		return nil, err
+	}
+
+	matcher.Application = maps.TryString(body, substrate.BodyApplication)
+
+	if matcher.Function, err = maps.String(body, substrate.BodyFunction); err != nil {
+		return nil, err
+	}
+
+	scheduled, err := maps.Int(body, substrate.BodyScheduled)
+	if err != nil {
+		return nil, err
+	}
+
+	missed, _ := maps.Int(body, substrate.BodyMissed)
+
+	started := time.Now()
+	if err = s.components.schedule.Run(ctx, &matcher, schedule.Tick{
+		Scheduled: time.Unix(0, int64(scheduled)),
+		Missed:    missed,
+	}); err != nil {
+		return nil, fmt.Errorf("running scheduled function `%s` failed with: %w", matcher.Function, err)
+	}
+
+	return response.Response{
+		substrate.ResponseStarted:  started.UnixNano(),
+		substrate.ResponseFinished: time.Now().UnixNano(),
+	}, nil
+}
+
 func (s *Service) tunnelHttp(ctx context.Context, rw io.ReadWriter) {
 	w, r, err := httptun.Backend(rw)
 	if err != nil {
@@ -47,7 +92,7 @@ func (s *Service) tunnelHttp(ctx context.Context, rw io.ReadWriter) {
 		return
 	}
 
-	s.components.http.Handler(w, r)
+	s.components.http.Handler(w, r.WithContext(limits.WithTunnel(r.Context())))
 }
 
 func (s *Service) parseHttpRequest(body command.Body) (*http.Request, error) {
@@ -85,28 +130,37 @@ func (s *Service) proxyHttp(ctx context.Context, con con.Connection, body comman
 
 	matcher := http.New(request.Host, request.Path, request.Method)
 
-	var pick compIface.Serviceable
-	if serviceables, _ := httpComponent.Cache().Get(
-		matcher,
-		compIface.GetOptions{Validation: true},
-	); len(serviceables) < 1 {
-		pick, err = s.components.http.Lookup(matcher)
-		if err != nil {
-			return nil, fmt.Errorf("lookup failed with: %w", err)
-		}
-	} else {
-		pick = serviceables[0]
+	lookupCtx, span := tracing.Child(ctx, "substrate.lookup")
+	pick, err := httpComponent.Lookup(lookupCtx, matcher)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("lookup failed with: %w", err)
+	}
+
+	// Answers with the metrics of the commit the request will be served from.
+	if pick, _, err = httpComponent.Split(ctx, matcher, pick, maps.TryString(body, substrate.BodyTrafficKey)); err != nil {
+		return nil, err
 	}
 
+	// The route lets the gateway reuse the pick for other paths it serves.
 	switch serviceable := pick.(type) {
 	case *function.Function:
 		response[functionSpec.PathVariable.String()] = serviceable.Metrics().Encode()
+		response[substrate.ResponseRoute] = request.Path
 	case *website.Website:
 		response[websiteSpec.PathVariable.String()] = serviceable.Metrics().Encode()
+		serviceable.Match(matcher)
+		response[substrate.ResponseRoute] = matcher.Get(http.PathMatch)
 	default:
 		return nil, fmt.Errorf("unknown serviceable type")
 	}
 
+	// Lets the gateway drop the routes it caches when the project is deployed.
+	response[substrate.ResponseProject] = pick.Project()
+	response[substrate.ResponseBranch] = pick.Branch()
+	response[substrate.ResponseResource] = pick.Id()
+	response[substrate.ResponseCommit] = pick.Commit()
+
 	if err != nil {
 		return nil, fmt.Errorf("getting serviceable metrics failed with: %w", err)
 	}
@@ -3,6 +3,7 @@ package substrate
 import (
 	"context"
 
+	authIface "github.com/taubyte/tau/core/services/auth"
 	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
 	iface "github.com/taubyte/tau/core/services/substrate"
 	p2pIface "github.com/taubyte/tau/core/services/substrate/components/p2p"
@@ -31,6 +32,7 @@ type Service struct {
 	stream  streams.CommandService
 
 	hoarderClient hoarderIface.Client
+	authClient    authIface.Client
 	tns           tns.Client
 	migrator      *migration.Migrator
 	orbitals      []vm.Plugin
@@ -65,6 +67,14 @@ func (s *Service) SmartOps() iface.SmartOpsService {
 	return s.components.smartops
 }
 
+func (s *Service) Logs() iface.LogService {
+	return s.components.logs
+}
+
+func (s *Service) Events() iface.EventService {
+	return s.components.events
+}
+
 func (s *Service) Tns() tns.Client {
 	return s.tns
 }
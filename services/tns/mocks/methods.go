@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path"
@@ -24,6 +25,10 @@ func (m *mockTns) Push(_path []string, data interface{}) error {
 	return nil
 }
 
+func (m *mockTns) WithContext(context.Context) tns.Client {
+	return m
+}
+
 func (m *mockTns) Fetch(_path tns.Path) (tns.Object, error) {
 	m.lock.RLock()
 	value, exists := m.mapDef[_path.String()]
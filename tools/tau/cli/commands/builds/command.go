@@ -0,0 +1,37 @@
+package builds
+
+import (
+	"time"
+
+	"github.com/taubyte/tau/tools/tau/cli/common/options"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// StoredLogWait bounds how long watch waits for the log of a finished job
+	// to be stored.
+	StoredLogWait = 30 * time.Second
+	// StoredLogInterval is how often watch checks for the stored log.
+	StoredLogInterval = time.Second
+)
+
+var jidFlag = &cli.StringFlag{
+	Name:    "jid",
+	Aliases: []string{"id"},
+	Usage:   "Job id of the build to watch",
+}
+
+var Command = &cli.Command{
+	Name:  "builds",
+	Usage: "Follow the builds of the project",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "watch",
+			Usage:     "Print the log of a build as it runs, then the stored log once it is done",
+			ArgsUsage: "<jid>",
+			Flags:     []cli.Flag{jidFlag},
+			Before:    options.SetFlagAsArgs0(jidFlag.Name),
+			Action:    runWatch,
+		},
+	},
+}
@@ -0,0 +1,127 @@
+package builds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/taubyte/tau/core/services/patrick"
+	patrickClient "github.com/taubyte/tau/tools/tau/clients/patrick_client"
+	"github.com/urfave/cli/v2"
+)
+
+func runWatch(ctx *cli.Context) error {
+	jobId := ctx.String(jidFlag.Name)
+	if len(jobId) < 1 {
+		return errors.New("job id not set")
+	}
+
+	patrickC, err := patrickClient.Load()
+	if err != nil {
+		return err
+	}
+
+	w := &watcher{
+		jobId: jobId,
+		follow: func(offset int64) (*patrick.JobLogChunk, error) {
+			return patrickC.FollowLog(jobId, offset)
+		},
+		job: func() (*patrick.Job, error) {
+			return patrickC.Job(jobId)
+		},
+		stored: func(cid string) (io.ReadCloser, error) {
+			return patrickC.LogFile(jobId, cid)
+		},
+		out: os.Stdout,
+	}
+
+	return w.run(ctx.Context)
+}
+
+// watcher prints the log of a job live, then what the stored log has past it.
+type watcher struct {
+	jobId  string
+	follow func(offset int64) (*patrick.JobLogChunk, error)
+	job    func() (*patrick.Job, error)
+	stored func(cid string) (io.ReadCloser, error)
+	out    io.Writer
+
+	// offset is where the log printed so far ends.
+	offset int64
+}
+
+func (w *watcher) run(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+
+		chunk, err := w.follow(w.offset)
+		if err != nil {
+			return err
+		}
+
+		if chunk.Offset > w.offset {
+			fmt.Fprintf(w.out, "\n[%d bytes skipped]\n", chunk.Offset-w.offset)
+			w.offset = chunk.Offset
+		}
+
+		if _, err = w.out.Write(chunk.Data); err != nil {
+			return err
+		}
+		w.offset += int64(len(chunk.Data))
+
+		if chunk.Done {
+			return w.finish(ctx)
+		}
+	}
+}
+
+// finish prints the stored log of the job from where the live one stopped.
+func (w *watcher) finish(ctx context.Context) error {
+	cid, err := w.storedCid(ctx)
+	if err != nil || cid == "" {
+		return err
+	}
+
+	log, err := w.stored(cid)
+	if err != nil {
+		return err
+	}
+	defer log.Close()
+
+	if _, err = io.CopyN(io.Discard, log, w.offset); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	}
+
+	_, err = io.Copy(w.out, log)
+	return err
+}
+
+// storedCid waits up to StoredLogWait for the log of the job to be stored, as
+// the monkey reports it after the end of the live log.
+func (w *watcher) storedCid(ctx context.Context) (string, error) {
+	deadline := time.Now().Add(StoredLogWait)
+	for {
+		job, err := w.job()
+		if err != nil {
+			return "", err
+		}
+
+		if cid := job.Logs[w.jobId]; cid != "" || time.Now().After(deadline) {
+			return cid, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", nil
+		case <-time.After(StoredLogInterval):
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package builds
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/taubyte/tau/core/services/patrick"
+	"gotest.tools/v3/assert"
+)
+
+func TestWatch(t *testing.T) {
+	stored := "building\ncompiling\nCI/CD Errors:\n\nfailed\n"
+
+	chunks := []*patrick.JobLogChunk{
+		{},
+		{Offset: 0, Data: []byte("building\n")},
+		// The live log skipped some.
+		{Offset: 13, Data: []byte("iling\n")},
+		{Offset: 19, Done: true},
+	}
+
+	var (
+		offsets []int64
+		jobs    int
+		out     bytes.Buffer
+	)
+
+	w := &watcher{
+		jobId: "job",
+		follow: func(offset int64) (*patrick.JobLogChunk, error) {
+			offsets = append(offsets, offset)
+			chunk := chunks[0]
+			chunks = chunks[1:]
+			return chunk, nil
+		},
+		job: func() (*patrick.Job, error) {
+			// Stored on the second look.
+			jobs++
+			if jobs == 1 {
+				return &patrick.Job{}, nil
+			}
+			return &patrick.Job{Logs: map[string]string{"job": "cid"}}, nil
+		},
+		stored: func(cid string) (io.ReadCloser, error) {
+			assert.Equal(t, cid, "cid")
+			return io.NopCloser(strings.NewReader(stored)), nil
+		},
+		out: &out,
+	}
+
+	interval := StoredLogInterval
+	StoredLogInterval = 0
+	defer func() { StoredLogInterval = interval }()
+
+	assert.NilError(t, w.run(context.Background()))
+	assert.DeepEqual(t, offsets, []int64{0, 0, 9, 19})
+	assert.Equal(t, jobs, 2)
+	assert.Equal(t, out.String(), "building\n\n[4 bytes skipped]\niling\nCI/CD Errors:\n\nfailed\n")
+}
+
+func TestWatchFinishedJob(t *testing.T) {
+	var out bytes.Buffer
+	w := &watcher{
+		jobId: "job",
+		follow: func(offset int64) (*patrick.JobLogChunk, error) {
+			return &patrick.JobLogChunk{Offset: offset, Done: true}, nil
+		},
+		job: func() (*patrick.Job, error) {
+			return &patrick.Job{Logs: map[string]string{"job": "cid"}}, nil
+		},
+		stored: func(cid string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("done\n")), nil
+		},
+		out: &out,
+	}
+
+	assert.NilError(t, w.run(context.Background()))
+	assert.Equal(t, out.String(), "done\n")
+}
@@ -0,0 +1,38 @@
+package logs
+
+import (
+	"time"
+
+	"github.com/taubyte/tau/tools/tau/cli/common/options"
+	"github.com/taubyte/tau/tools/tau/flags"
+	"github.com/urfave/cli/v2"
+)
+
+// FollowInterval is how often --follow asks for new logs.
+var FollowInterval = 2 * time.Second
+
+var followFlag = &cli.BoolFlag{
+	Name:    "follow",
+	Aliases: []string{"f"},
+	Usage:   "Keep printing logs as calls come in",
+}
+
+var sinceFlag = &cli.StringFlag{
+	Name:  "since",
+	Usage: "Only show calls started after a time (RFC3339) or a duration ago (e.g. 15m); defaults to the latest calls",
+}
+
+var Command = &cli.Command{
+	Name:  "logs",
+	Usage: "Show the runtime logs of a resource",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "function",
+			Usage:     "Show the output of the selected function's calls",
+			ArgsUsage: "<name>",
+			Flags:     []cli.Flag{flags.Name, followFlag, sinceFlag},
+			Before:    options.SetNameAsArgs0,
+			Action:    runFunction,
+		},
+	},
+}
@@ -0,0 +1,185 @@
+package logs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/units"
+	"github.com/taubyte/tau/core/services/patrick"
+	patrickClient "github.com/taubyte/tau/tools/tau/clients/patrick_client"
+	"github.com/taubyte/tau/tools/tau/output"
+	"github.com/taubyte/tau/tools/tau/tcc"
+	"github.com/urfave/cli/v2"
+)
+
+// followOverlap is how far back --follow looks again: nodes publish logs in
+// batches, so a call can show up after later ones.
+const followOverlap = 10 * time.Second
+
+func runFunction(ctx *cli.Context) error {
+	since, err := parseSince(ctx.String(sinceFlag.Name), time.Now())
+	if err != nil {
+		return err
+	}
+
+	store, err := tcc.Open()
+	if err != nil {
+		return err
+	}
+
+	projectID, err := store.ProjectID()
+	if err != nil {
+		return err
+	}
+
+	g, err := tcc.GroupFor("functions")
+	if err != nil {
+		return err
+	}
+
+	_, doc, err := store.Select(ctx, g)
+	if err != nil {
+		return err
+	}
+
+	functionID, _ := tcc.Get(doc, []string{"id"}).(string)
+
+	patrickC, err := patrickClient.Load()
+	if err != nil {
+		return err
+	}
+
+	t := &tail{
+		fetch: func(since int64) ([]*patrick.FunctionLog, error) {
+			return patrickC.FunctionLogs(projectID, functionID, since)
+		},
+		print: printLog,
+		seen:  make(map[string]time.Time),
+	}
+
+	if err = t.catchUp(since); err != nil || !ctx.Bool(followFlag.Name) {
+		return err
+	}
+
+	// Following only adds calls started after the ones printed.
+	if t.floor = t.last; t.floor == 0 {
+		t.floor = max(since, time.Now().UnixNano())
+	}
+
+	ticker := time.NewTicker(FollowInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Context.Done():
+			return nil
+		case <-ticker.C:
+			if err = t.catchUp(max(t.floor, t.last-followOverlap.Nanoseconds())); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// parseSince reads --since as a time or a duration before now. Empty is zero.
+func parseSince(value string, now time.Time) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d).UnixNano(), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid since `%s`: expected a duration or an RFC3339 time", value)
+	}
+
+	return t.UnixNano(), nil
+}
+
+// tail prints the logs of a function once each, in the order calls started.
+type tail struct {
+	fetch func(since int64) ([]*patrick.FunctionLog, error)
+	print func(*patrick.FunctionLog)
+
+	// floor is the earliest start of the calls printed, zero for any.
+	floor int64
+	// last is when the latest call printed started.
+	last int64
+	// seen holds the calls printed within followOverlap of last.
+	seen map[string]time.Time
+}
+
+// catchUp prints the calls started after since, page by page. Zero since
+// gets the latest page.
+func (t *tail) catchUp(since int64) error {
+	since = max(since, 0)
+	for {
+		logs, err := t.fetch(since)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range logs {
+			key := fmt.Sprintf("%s/%d", entry.Node, entry.Started)
+			if _, ok := t.seen[key]; !ok && entry.Started >= t.floor {
+				t.seen[key] = time.Unix(0, entry.Started)
+				t.last = max(t.last, entry.Started)
+				t.print(entry)
+			}
+		}
+
+		t.forget()
+
+		if len(logs) == 0 || since == 0 {
+			return nil
+		}
+
+		since = logs[len(logs)-1].Started + 1
+	}
+}
+
+func (t *tail) forget() {
+	cutoff := time.Unix(0, t.last-followOverlap.Nanoseconds())
+	for key, started := range t.seen {
+		if started.Before(cutoff) {
+			delete(t.seen, key)
+		}
+	}
+}
+
+func printLog(entry *patrick.FunctionLog) {
+	if output.Render(entry) {
+		return
+	}
+
+	writeLog(os.Stdout, entry)
+}
+
+func writeLog(w io.Writer, entry *patrick.FunctionLog) {
+	started := time.Unix(0, entry.Started).Local()
+	fmt.Fprintf(w, "%s  %s  %s",
+		started.Format(time.RFC3339Nano),
+		time.Duration(entry.Duration).Round(time.Microsecond),
+		units.Base2Bytes(entry.Memory),
+	)
+	if entry.Error != "" {
+		fmt.Fprintf(w, "  error: %s", entry.Error)
+	}
+	fmt.Fprintln(w)
+
+	for _, out := range []string{entry.Stdout, entry.Stderr} {
+		if out = strings.TrimRight(out, "\n"); out != "" {
+			fmt.Fprintln(w, out)
+		}
+	}
+
+	if entry.Truncated {
+		fmt.Fprintln(w, "[output truncated]")
+	}
+}
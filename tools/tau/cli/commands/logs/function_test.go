@@ -0,0 +1,96 @@
+package logs
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/services/patrick"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, time.March, 14, 10, 0, 0, 0, time.UTC)
+
+	since, err := parseSince("", now)
+	assert.NilError(t, err)
+	assert.Equal(t, since, int64(0))
+
+	since, err = parseSince("15m", now)
+	assert.NilError(t, err)
+	assert.Equal(t, since, now.Add(-15*time.Minute).UnixNano())
+
+	since, err = parseSince("2026-03-14T09:00:00Z", now)
+	assert.NilError(t, err)
+	assert.Equal(t, since, now.Add(-time.Hour).UnixNano())
+
+	_, err = parseSince("yesterday", now)
+	assert.ErrorContains(t, err, "invalid since")
+}
+
+func TestTail(t *testing.T) {
+	second := int64(time.Second)
+	base := 100 * second
+
+	var stored []*patrick.FunctionLog
+	for i := range 5 {
+		stored = append(stored, &patrick.FunctionLog{Node: "node", Started: base + int64(i)*second})
+	}
+
+	// Pages of two, the latest ones when since is zero.
+	fetch := func(since int64) ([]*patrick.FunctionLog, error) {
+		if since == 0 {
+			return stored[len(stored)-2:], nil
+		}
+
+		for i, entry := range stored {
+			if entry.Started >= since {
+				return stored[i:min(i+2, len(stored))], nil
+			}
+		}
+
+		return nil, nil
+	}
+
+	var printed []int64
+	tl := &tail{
+		fetch: fetch,
+		print: func(entry *patrick.FunctionLog) { printed = append(printed, (entry.Started-base)/second) },
+		seen:  make(map[string]time.Time),
+	}
+
+	assert.NilError(t, tl.catchUp(0))
+	assert.DeepEqual(t, printed, []int64{3, 4})
+
+	// Following looks back for calls published late, and prints each once.
+	tl.floor = tl.last
+	stored = append(stored[:4], &patrick.FunctionLog{Node: "other", Started: base + 4*second}, stored[4])
+	stored = append(stored, &patrick.FunctionLog{Node: "node", Started: base + 5*second})
+	assert.NilError(t, tl.catchUp(max(tl.floor, tl.last-followOverlap.Nanoseconds())))
+	assert.DeepEqual(t, printed, []int64{3, 4, 4, 5})
+
+	printed = nil
+	tl = &tail{fetch: fetch, print: tl.print, seen: make(map[string]time.Time)}
+	assert.NilError(t, tl.catchUp(base+2*second))
+	assert.DeepEqual(t, printed, []int64{2, 3, 4, 4, 5})
+}
+
+func TestWriteLog(t *testing.T) {
+	var buf bytes.Buffer
+	writeLog(&buf, &patrick.FunctionLog{
+		Started:   time.Date(2026, time.March, 14, 10, 0, 0, 0, time.UTC).UnixNano(),
+		Duration:  int64(1500 * time.Microsecond),
+		Memory:    2 << 20,
+		Error:     "trapped",
+		Stdout:    "hello\n",
+		Stderr:    "oops\n",
+		Truncated: true,
+	})
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Equal(t, len(lines), 4)
+	assert.Assert(t, bytes.HasSuffix(lines[0], []byte("1.5ms  2MiB  error: trapped")), string(lines[0]))
+	assert.Equal(t, string(lines[1]), "hello")
+	assert.Equal(t, string(lines[2]), "oops")
+	assert.Equal(t, string(lines[3]), "[output truncated]")
+}
@@ -0,0 +1,24 @@
+package canary
+
+import (
+	"github.com/taubyte/tau/tools/tau/cli/common"
+	"github.com/taubyte/tau/tools/tau/tcc"
+	"github.com/urfave/cli/v2"
+)
+
+func (link) Base() (*cli.Command, []common.Option) {
+	return common.Base(&cli.Command{
+		Name:  "canary",
+		Usage: "splits the traffic of a branch of the project between its stable commit and a canary",
+	})
+}
+
+// projectID returns the id of the project in the working directory.
+func projectID() (string, error) {
+	store, err := tcc.Open()
+	if err != nil {
+		return "", err
+	}
+
+	return store.ProjectID()
+}
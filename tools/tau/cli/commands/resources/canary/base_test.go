@@ -0,0 +1,20 @@
+package canary
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLink(t *testing.T) {
+	var l link
+	cmd, _ := l.Base()
+	assert.Equal(t, cmd.Name, "canary")
+
+	assert.Assert(t, l.New() != nil)
+	assert.Assert(t, l.Edit() != nil)
+	assert.Assert(t, l.Query() != nil)
+	assert.Assert(t, l.Promote() != nil)
+	assert.Assert(t, l.Cancel() != nil)
+	assert.Assert(t, l.Delete() == nil)
+}
@@ -0,0 +1,74 @@
+package canary
+
+import (
+	"github.com/taubyte/tau/tools/tau/cli/common"
+	patrickClient "github.com/taubyte/tau/tools/tau/clients/patrick_client"
+	projectFlags "github.com/taubyte/tau/tools/tau/flags/project"
+	projectI18n "github.com/taubyte/tau/tools/tau/i18n/project"
+	"github.com/taubyte/tau/tools/tau/prompts"
+	"github.com/urfave/cli/v2"
+)
+
+func (link) Promote() common.Command {
+	return common.Create(
+		&cli.Command{
+			Usage: "serves all of the branch from the canary commit",
+			Flags: []cli.Flag{
+				projectFlags.DeployedBranch,
+			},
+			Action: func(ctx *cli.Context) error {
+				return end(ctx, true)
+			},
+		},
+	)
+}
+
+func (link) Cancel() common.Command {
+	return common.Create(
+		&cli.Command{
+			Usage: "aborts the canary, serving all of the branch from the stable commit",
+			Flags: []cli.Flag{
+				projectFlags.DeployedBranch,
+			},
+			Action: func(ctx *cli.Context) error {
+				return end(ctx, false)
+			},
+		},
+	)
+}
+
+func end(ctx *cli.Context, promote bool) error {
+	projectID, err := projectID()
+	if err != nil {
+		return err
+	}
+
+	question := "Serve all traffic from the stable commit?"
+	if promote {
+		question = "Serve all traffic from the canary commit?"
+	}
+
+	if !prompts.ConfirmPrompt(ctx, question) {
+		return nil
+	}
+
+	patrickC, err := patrickClient.Load()
+	if err != nil {
+		return err
+	}
+
+	branch := ctx.String(projectFlags.DeployedBranch.Name)
+	end := patrickC.AbortCanary
+	if promote {
+		end = patrickC.PromoteCanary
+	}
+
+	deployment, err := end(projectID, branch)
+	if err != nil {
+		return err
+	}
+
+	projectI18n.EndedCanary(deployment.Branch, deployment.Commit)
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package canary
+
+import (
+	"fmt"
+
+	"github.com/taubyte/tau/tools/tau/cli/common"
+	patrickClient "github.com/taubyte/tau/tools/tau/clients/patrick_client"
+	projectFlags "github.com/taubyte/tau/tools/tau/flags/project"
+	projectI18n "github.com/taubyte/tau/tools/tau/i18n/project"
+	"github.com/urfave/cli/v2"
+)
+
+func (link) New() common.Command {
+	return common.Create(
+		&cli.Command{
+			Usage: "serves a share of the branch from a canary commit, the commit deployed next by default",
+			Flags: []cli.Flag{
+				projectFlags.CanaryWeight,
+				projectFlags.CanaryCommit,
+				projectFlags.DeployedBranch,
+			},
+			Action: set,
+		},
+	)
+}
+
+func (link) Edit() common.Command {
+	return common.Create(
+		&cli.Command{
+			Usage: "changes the share of the branch served from the canary commit",
+			Flags: []cli.Flag{
+				projectFlags.CanaryWeight,
+				projectFlags.CanaryCommit,
+				projectFlags.DeployedBranch,
+			},
+			Action: set,
+		},
+	)
+}
+
+func set(ctx *cli.Context) error {
+	if !ctx.IsSet(projectFlags.CanaryWeight.Name) {
+		return fmt.Errorf("flag `%s` is required", projectFlags.CanaryWeight.Name)
+	}
+
+	projectID, err := projectID()
+	if err != nil {
+		return err
+	}
+
+	patrickC, err := patrickClient.Load()
+	if err != nil {
+		return err
+	}
+
+	canary, err := patrickC.StartCanary(
+		projectID,
+		ctx.String(projectFlags.DeployedBranch.Name),
+		ctx.String(projectFlags.CanaryCommit.Name),
+		ctx.Int(projectFlags.CanaryWeight.Name),
+	)
+	if err != nil {
+		return err
+	}
+
+	commit := canary.Canary
+	if commit == canary.Stable {
+		// Nothing deployed since the canary started.
+		commit = ""
+	}
+
+	projectI18n.StartedCanary(canary.Branch, commit, canary.Weight)
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package canary
+
+import (
+	"github.com/taubyte/tau/tools/tau/cli/common"
+	patrickClient "github.com/taubyte/tau/tools/tau/clients/patrick_client"
+	projectFlags "github.com/taubyte/tau/tools/tau/flags/project"
+	canaryTable "github.com/taubyte/tau/tools/tau/table/canary"
+	"github.com/urfave/cli/v2"
+)
+
+func (link) Query() common.Command {
+	return common.Create(
+		&cli.Command{
+			Flags: []cli.Flag{
+				projectFlags.DeployedBranch,
+			},
+			Action: query,
+		},
+	)
+}
+
+func query(ctx *cli.Context) error {
+	projectID, err := projectID()
+	if err != nil {
+		return err
+	}
+
+	patrickC, err := patrickClient.Load()
+	if err != nil {
+		return err
+	}
+
+	canary, err := patrickC.Canary(projectID, ctx.String(projectFlags.DeployedBranch.Name))
+	if err != nil {
+		return err
+	}
+
+	canaryTable.Query(canary)
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package deployments
+
+import (
+	"github.com/taubyte/tau/tools/tau/cli/common"
+	"github.com/urfave/cli/v2"
+)
+
+func (link) Base() (*cli.Command, []common.Option) {
+	return common.Base(&cli.Command{
+		Name:    "deployments",
+		Usage:   "lists the commits deployed on a branch of the project",
+		Aliases: []string{"deployment"},
+	})
+}
@@ -0,0 +1,59 @@
+package deployments
+
+import (
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/taubyte/tau/tools/tau/cli/common"
+	patrickClient "github.com/taubyte/tau/tools/tau/clients/patrick_client"
+	projectFlags "github.com/taubyte/tau/tools/tau/flags/project"
+	"github.com/taubyte/tau/tools/tau/output"
+	deploymentsTable "github.com/taubyte/tau/tools/tau/table/deployments"
+	"github.com/taubyte/tau/tools/tau/tcc"
+	"github.com/urfave/cli/v2"
+)
+
+func (link) Query() common.Command {
+	return common.Create(
+		&cli.Command{
+			Flags: []cli.Flag{
+				projectFlags.DeployedBranch,
+			},
+			Action: query,
+		},
+	)
+}
+
+func (l link) List() common.Command {
+	return l.Query()
+}
+
+func query(ctx *cli.Context) error {
+	store, err := tcc.Open()
+	if err != nil {
+		return err
+	}
+
+	projectID, err := store.ProjectID()
+	if err != nil {
+		return err
+	}
+
+	patrickC, err := patrickClient.Load()
+	if err != nil {
+		return err
+	}
+
+	deployments, err := patrickC.Deployments(projectID, ctx.String(projectFlags.DeployedBranch.Name))
+	if err != nil {
+		return err
+	}
+
+	if output.Render(deployments) {
+		return nil
+	}
+
+	t := deploymentsTable.ListNoRender(deployments)
+	t.SetStyle(table.StyleLight)
+	t.Render()
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/taubyte/tau/tools/tau/cli/common"
+	patrickClient "github.com/taubyte/tau/tools/tau/clients/patrick_client"
+	projectFlags "github.com/taubyte/tau/tools/tau/flags/project"
+	projectI18n "github.com/taubyte/tau/tools/tau/i18n/project"
+	"github.com/taubyte/tau/tools/tau/prompts"
+	projectPrompts "github.com/taubyte/tau/tools/tau/prompts/project"
+	"github.com/urfave/cli/v2"
+)
+
+func (link) Rollback() common.Command {
+	return common.Create(
+		&cli.Command{
+			Usage: "points the project back at an earlier deployed commit",
+			Flags: []cli.Flag{
+				projectFlags.RollbackTo,
+				projectFlags.DeployedBranch,
+			},
+			Action: rollback,
+		},
+	)
+}
+
+func rollback(ctx *cli.Context) error {
+	project, err := projectPrompts.GetOrSelect(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	to := ctx.String(projectFlags.RollbackTo.Name)
+	target := "the previous deployment"
+	if len(to) > 0 {
+		target = fmt.Sprintf("commit `%s`", to)
+	}
+
+	if !prompts.ConfirmPrompt(ctx, fmt.Sprintf("Roll back project `%s` to %s?", project.Name, target)) {
+		return nil
+	}
+
+	patrickC, err := patrickClient.Load()
+	if err != nil {
+		return err
+	}
+
+	deployment, err := patrickC.Rollback(project.Id, ctx.String(projectFlags.DeployedBranch.Name), to)
+	if err != nil {
+		return err
+	}
+
+	projectI18n.RolledBackProject(project.Name, deployment.Branch, deployment.Commit)
+
+	return nil
+}
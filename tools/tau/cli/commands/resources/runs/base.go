@@ -0,0 +1,15 @@
+package runs
+
+import (
+	"github.com/taubyte/tau/tools/tau/cli/common"
+	"github.com/taubyte/tau/tools/tau/cli/common/options"
+	"github.com/urfave/cli/v2"
+)
+
+func (link) Base() (*cli.Command, []common.Option) {
+	return common.Base(&cli.Command{
+		Name:    "runs",
+		Usage:   "lists the recent runs of a scheduled function",
+		Aliases: []string{"run"},
+	}, options.NameFlagArg0())
+}
@@ -0,0 +1,73 @@
+package runs
+
+import (
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/taubyte/tau/tools/tau/cli/common"
+	patrickClient "github.com/taubyte/tau/tools/tau/clients/patrick_client"
+	"github.com/taubyte/tau/tools/tau/output"
+	runsTable "github.com/taubyte/tau/tools/tau/table/runs"
+	"github.com/taubyte/tau/tools/tau/tcc"
+	"github.com/urfave/cli/v2"
+)
+
+func (link) Query() common.Command {
+	return common.Create(
+		&cli.Command{
+			Action: query,
+		},
+	)
+}
+
+func (l link) List() common.Command {
+	return l.Query()
+}
+
+func query(ctx *cli.Context) error {
+	store, err := tcc.Open()
+	if err != nil {
+		return err
+	}
+
+	projectID, err := store.ProjectID()
+	if err != nil {
+		return err
+	}
+
+	g, err := tcc.GroupFor("functions")
+	if err != nil {
+		return err
+	}
+
+	name, doc, err := store.Select(ctx, g)
+	if err != nil {
+		return err
+	}
+
+	if _type, _ := tcc.Get(doc, []string{"trigger", "type"}).(string); _type != "schedule" {
+		return fmt.Errorf("function `%s` is not scheduled", name)
+	}
+
+	functionID, _ := tcc.Get(doc, []string{"id"}).(string)
+
+	patrickC, err := patrickClient.Load()
+	if err != nil {
+		return err
+	}
+
+	runs, err := patrickC.Runs(projectID, functionID)
+	if err != nil {
+		return err
+	}
+
+	if output.Render(runs) {
+		return nil
+	}
+
+	t := runsTable.ListNoRender(runs)
+	t.SetStyle(table.StyleLight)
+	t.Render()
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package runs
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNew(t *testing.T) {
+	b := New()
+	assert.Assert(t, b != nil)
+}
+
+func TestLink_Base(t *testing.T) {
+	var l link
+	cmd, _ := l.Base()
+	assert.Assert(t, cmd != nil)
+	assert.Equal(t, cmd.Name, "runs")
+}
+
+func TestLink_Query(t *testing.T) {
+	var l link
+	assert.Assert(t, l.Query() != nil)
+	assert.Assert(t, l.List() != nil)
+}
@@ -29,6 +29,8 @@ func Attach(app *cli.App, commands ...BasicFunction) {
 		_retry,
 		_run,
 		_clear,
+		_rollback,
+		_promote,
 	} {
 		if len(cmd.Subcommands) > 0 {
 			app.Commands = append(app.Commands, cmd)
@@ -55,6 +57,8 @@ func attachCommand(cmd Basic) {
 		_retry:    cmd.Retry,
 		_run:      cmd.Run,
 		_clear:    cmd.Clear,
+		_rollback: cmd.Rollback,
+		_promote:  cmd.Promote,
 	} {
 		_method := method()
 		if _method != NotImplemented {
@@ -27,4 +27,6 @@ var (
 	_retry    = newBaseCommand("retry")
 	_run      = newBaseCommand("run")
 	_clear    = newBaseCommand("clear")
+	_rollback = newBaseCommand("rollback")
+	_promote  = newBaseCommand("promote")
 )
@@ -29,6 +29,8 @@ type Basic interface {
 	Retry() Command
 	Run() Command
 	Clear() Command
+	Rollback() Command
+	Promote() Command
 
 	// Sets the following in the command if not already set:
 	// Name
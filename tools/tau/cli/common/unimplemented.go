@@ -22,4 +22,6 @@ func (UnimplementedBasic) Cancel() Command                { return NotImplemente
 func (UnimplementedBasic) Retry() Command                 { return NotImplemented }
 func (UnimplementedBasic) Run() Command                   { return NotImplemented }
 func (UnimplementedBasic) Clear() Command                 { return NotImplemented }
+func (UnimplementedBasic) Rollback() Command              { return NotImplemented }
+func (UnimplementedBasic) Promote() Command               { return NotImplemented }
 func (UnimplementedBasic) Base() (*cli.Command, []Option) { return nil, nil }
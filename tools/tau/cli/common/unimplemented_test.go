@@ -22,6 +22,8 @@ func TestUnimplementedBasic_AllCommandsReturnNil(t *testing.T) {
 	assert.Assert(t, u.Pull() == nil)
 	assert.Assert(t, u.Checkout() == nil)
 	assert.Assert(t, u.Import() == nil)
+	assert.Assert(t, u.Rollback() == nil)
+	assert.Assert(t, u.Promote() == nil)
 }
 
 func TestNotImplementedIsNil(t *testing.T) {
@@ -5,14 +5,19 @@ import (
 	accountsCmd "github.com/taubyte/tau/tools/tau/cli/commands/accounts"
 	"github.com/taubyte/tau/tools/tau/cli/commands/autocomplete"
 	buildCmd "github.com/taubyte/tau/tools/tau/cli/commands/build"
+	buildsCmd "github.com/taubyte/tau/tools/tau/cli/commands/builds"
 	"github.com/taubyte/tau/tools/tau/cli/commands/current"
 	"github.com/taubyte/tau/tools/tau/cli/commands/login"
+	logsCmd "github.com/taubyte/tau/tools/tau/cli/commands/logs"
 	"github.com/taubyte/tau/tools/tau/cli/commands/resources/builds"
 	"github.com/taubyte/tau/tools/tau/cli/commands/resources/builds/build"
+	"github.com/taubyte/tau/tools/tau/cli/commands/resources/canary"
 	"github.com/taubyte/tau/tools/tau/cli/commands/resources/cloud"
+	"github.com/taubyte/tau/tools/tau/cli/commands/resources/deployments"
 	"github.com/taubyte/tau/tools/tau/cli/commands/resources/generic"
 	"github.com/taubyte/tau/tools/tau/cli/commands/resources/logs"
 	"github.com/taubyte/tau/tools/tau/cli/commands/resources/project"
+	"github.com/taubyte/tau/tools/tau/cli/commands/resources/runs"
 	"github.com/taubyte/tau/tools/tau/cli/commands/validate"
 	"github.com/taubyte/tau/tools/tau/cli/commands/version"
 	"github.com/taubyte/tau/tools/tau/cli/common"
@@ -56,6 +61,8 @@ func New() (*cli.App, error) {
 			login.Command,
 			current.Command,
 			buildCmd.Command,
+			buildsCmd.Command,
+			logsCmd.Command,
 			validate.Command,
 			accountsCmd.Command,
 		},
@@ -74,6 +81,9 @@ func New() (*cli.App, error) {
 		builds.New,
 		build.New,
 		logs.New,
+		runs.New,
+		deployments.New,
+		canary.New,
 	}, resources...)...)
 
 	app.Commands = append(app.Commands, []*cli.Command{
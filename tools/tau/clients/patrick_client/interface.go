@@ -6,7 +6,7 @@ import (
 	patrickIface "github.com/taubyte/tau/core/services/patrick"
 )
 
-// Client is the interface for the Patrick API used by the tau CLI (jobs, logs, cancel, retry).
+// Client is the interface for the Patrick API used by the tau CLI (jobs, logs, cancel, retry, scheduled runs, function logs, live job logs, deployments, rollback, canaries).
 // Implementations can be the real HTTP client or a mock for tests.
 type Client interface {
 	Jobs(projectId string) ([]string, error)
@@ -14,4 +14,13 @@ type Client interface {
 	LogFile(jobId, resourceId string) (io.ReadCloser, error)
 	Cancel(jid string) (any, error)
 	Retry(jid string) (any, error)
+	Runs(projectId, functionId string) ([]*patrickIface.ScheduledRun, error)
+	FunctionLogs(projectId, functionId string, since int64) ([]*patrickIface.FunctionLog, error)
+	FollowLog(jid string, offset int64) (*patrickIface.JobLogChunk, error)
+	Deployments(projectId, branch string) ([]*patrickIface.Deployment, error)
+	Rollback(projectId, branch, to string) (*patrickIface.Deployment, error)
+	Canary(projectId, branch string) (*patrickIface.Canary, error)
+	StartCanary(projectId, branch, canary string, weight int) (*patrickIface.Canary, error)
+	PromoteCanary(projectId, branch string) (*patrickIface.Deployment, error)
+	AbortCanary(projectId, branch string) (*patrickIface.Deployment, error)
 }
@@ -15,6 +15,14 @@ type mockClient struct {
 	logFileFunc func(jobId, resourceId string) (io.ReadCloser, error)
 	cancelFunc  func(jid string) (any, error)
 	retryFunc   func(jid string) (any, error)
+	runsFunc    func(projectId, functionId string) ([]*patrickIface.ScheduledRun, error)
+	logsFunc    func(projectId, functionId string, since int64) ([]*patrickIface.FunctionLog, error)
+	followFunc  func(jid string, offset int64) (*patrickIface.JobLogChunk, error)
+	deploysFunc func(projectId, branch string) ([]*patrickIface.Deployment, error)
+	backFunc    func(projectId, branch, to string) (*patrickIface.Deployment, error)
+	canaryFunc  func(projectId, branch string) (*patrickIface.Canary, error)
+	startFunc   func(projectId, branch, canary string, weight int) (*patrickIface.Canary, error)
+	endFunc     func(projectId, branch string, promote bool) (*patrickIface.Deployment, error)
 }
 
 func (m *mockClient) Jobs(projectId string) ([]string, error) {
@@ -52,6 +60,69 @@ func (m *mockClient) Retry(jid string) (any, error) {
 	return nil, nil
 }
 
+func (m *mockClient) Runs(projectId, functionId string) ([]*patrickIface.ScheduledRun, error) {
+	if m.runsFunc != nil {
+		return m.runsFunc(projectId, functionId)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) FunctionLogs(projectId, functionId string, since int64) ([]*patrickIface.FunctionLog, error) {
+	if m.logsFunc != nil {
+		return m.logsFunc(projectId, functionId, since)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) FollowLog(jid string, offset int64) (*patrickIface.JobLogChunk, error) {
+	if m.followFunc != nil {
+		return m.followFunc(jid, offset)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) Deployments(projectId, branch string) ([]*patrickIface.Deployment, error) {
+	if m.deploysFunc != nil {
+		return m.deploysFunc(projectId, branch)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) Rollback(projectId, branch, to string) (*patrickIface.Deployment, error) {
+	if m.backFunc != nil {
+		return m.backFunc(projectId, branch, to)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) Canary(projectId, branch string) (*patrickIface.Canary, error) {
+	if m.canaryFunc != nil {
+		return m.canaryFunc(projectId, branch)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) StartCanary(projectId, branch, canary string, weight int) (*patrickIface.Canary, error) {
+	if m.startFunc != nil {
+		return m.startFunc(projectId, branch, canary, weight)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) PromoteCanary(projectId, branch string) (*patrickIface.Deployment, error) {
+	if m.endFunc != nil {
+		return m.endFunc(projectId, branch, true)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) AbortCanary(projectId, branch string) (*patrickIface.Deployment, error) {
+	if m.endFunc != nil {
+		return m.endFunc(projectId, branch, false)
+	}
+	return nil, nil
+}
+
 // Ensure mockClient implements Client at compile time.
 var _ Client = (*mockClient)(nil)
 
@@ -18,6 +18,7 @@ const (
 	FunctionTypeHttps          = "https"
 	FunctionTypeP2P            = "p2p"
 	FunctionTypePubSub         = "pubsub"
+	FunctionTypeSchedule       = "schedule"
 	DefaultGeneratedDomainName = "generated"
 	DefaultNewProjectBranch    = "main"
 
@@ -38,6 +39,6 @@ const (
 )
 
 var (
-	FunctionTypes = []string{FunctionTypeHttp, FunctionTypeHttps, FunctionTypeP2P, FunctionTypePubSub}
+	FunctionTypes = []string{FunctionTypeHttp, FunctionTypeHttps, FunctionTypeP2P, FunctionTypePubSub, FunctionTypeSchedule}
 	BucketTypes   = []string{"Object", "Streaming"}
 )
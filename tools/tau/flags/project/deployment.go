@@ -0,0 +1,24 @@
+package projectFlags
+
+import "github.com/urfave/cli/v2"
+
+var RollbackTo = &cli.StringFlag{
+	Name:  "to",
+	Usage: "commit to roll back to, or a prefix of it; defaults to the deployment before the current one",
+}
+
+var DeployedBranch = &cli.StringFlag{
+	Name:    "branch",
+	Aliases: []string{"b"},
+	Usage:   "branch of the deployments; defaults to the project's default branch",
+}
+
+var CanaryWeight = &cli.IntFlag{
+	Name:  "weight",
+	Usage: "percent of the clients served the canary commit",
+}
+
+var CanaryCommit = &cli.StringFlag{
+	Name:  "canary",
+	Usage: "deployed commit, or a prefix of it, to serve as the canary; defaults to the commit deployed next",
+}
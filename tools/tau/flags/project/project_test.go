@@ -76,3 +76,14 @@ func TestParseConfigCodeFlags(t *testing.T) {
 		assert.NilError(t, err)
 	})
 }
+
+func TestRollbackFlags(t *testing.T) {
+	assert.Equal(t, RollbackTo.Name, "to")
+	assert.Equal(t, DeployedBranch.Name, "branch")
+	assert.Equal(t, DeployedBranch.Value, "")
+}
+
+func TestCanaryFlags(t *testing.T) {
+	assert.Equal(t, CanaryWeight.Name, "weight")
+	assert.Equal(t, CanaryCommit.Name, "canary")
+}
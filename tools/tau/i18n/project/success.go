@@ -1,6 +1,8 @@
 package projectI18n
 
 import (
+	"fmt"
+
 	"github.com/taubyte/tau/tools/tau/i18n/printer"
 )
 
@@ -47,3 +49,21 @@ func RemovedProject(name, cloudFQDN string) {
 func ClearedProjectSelection() {
 	printer.Out.SuccessPrintfln("Cleared project selection")
 }
+
+func RolledBackProject(name, branch, commit string) {
+	printer.Out.SuccessPrintfln("Rolled back branch `%s` of project `%s` to commit `%s`", branch, printer.Out.SprintCyan(name), commit)
+}
+
+func StartedCanary(branch, canary string, weight int) {
+	if canary == "" {
+		canary = "the commit deployed next"
+	} else {
+		canary = fmt.Sprintf("commit `%s`", canary)
+	}
+
+	printer.Out.SuccessPrintfln("Serving %d%% of branch `%s` from %s", weight, branch, canary)
+}
+
+func EndedCanary(branch, commit string) {
+	printer.Out.SuccessPrintfln("Serving all of branch `%s` from commit `%s`", branch, commit)
+}
@@ -84,3 +84,26 @@ func TestRemovedProject(t *testing.T) {
 	assert.Assert(t, strings.Contains(buf.String(), "myproject"))
 	assert.Assert(t, strings.Contains(buf.String(), "sandbox.taubyte.com"))
 }
+
+func TestRolledBackProject(t *testing.T) {
+	var buf bytes.Buffer
+	restore := printer.SetOutput(printer.WriterOutput(&buf))
+	defer restore()
+	projectI18n.RolledBackProject("myproject", "main", "abc1234")
+	assert.Assert(t, strings.Contains(buf.String(), "Rolled back"))
+	assert.Assert(t, strings.Contains(buf.String(), "myproject"))
+	assert.Assert(t, strings.Contains(buf.String(), "abc1234"))
+}
+
+func TestCanaryMessages(t *testing.T) {
+	var buf bytes.Buffer
+	restore := printer.SetOutput(printer.WriterOutput(&buf))
+	defer restore()
+
+	projectI18n.StartedCanary("main", "", 5)
+	assert.Assert(t, strings.Contains(buf.String(), "5%"))
+	assert.Assert(t, strings.Contains(buf.String(), "deployed next"))
+
+	projectI18n.EndedCanary("main", "abc1234")
+	assert.Assert(t, strings.Contains(buf.String(), "abc1234"))
+}
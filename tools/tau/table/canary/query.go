@@ -0,0 +1,33 @@
+package canaryTable
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/tools/tau/output"
+)
+
+// Query shows the traffic split of a branch between its stable and canary commits.
+func Query(canary *patrick.Canary) {
+	if output.Render(canary) {
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+
+	t.AppendRow(table.Row{"Branch", canary.Branch})
+	t.AppendSeparator()
+	if !canary.Active {
+		t.AppendRow(table.Row{"Canary", "none"})
+	} else {
+		t.AppendRow(table.Row{"Stable", canary.Stable, strconv.Itoa(100-canary.Weight) + "%"})
+		t.AppendSeparator()
+		t.AppendRow(table.Row{"Canary", canary.Canary, strconv.Itoa(canary.Weight) + "%"})
+	}
+
+	t.SetStyle(table.StyleLight)
+	t.Render()
+}
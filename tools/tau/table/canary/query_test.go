@@ -0,0 +1,25 @@
+package canaryTable_test
+
+import (
+	"github.com/taubyte/tau/core/services/patrick"
+	canaryTable "github.com/taubyte/tau/tools/tau/table/canary"
+)
+
+func ExampleQuery() {
+	canaryTable.Query(&patrick.Canary{
+		Branch: "main",
+		Stable: "2f1c9e0",
+		Canary: "8ab33d1",
+		Weight: 5,
+		Active: true,
+	})
+
+	// Output:
+	// ┌────────┬─────────┬─────┐
+	// │ Branch │ main    │     │
+	// ├────────┼─────────┼─────┤
+	// │ Stable │ 2f1c9e0 │ 95% │
+	// ├────────┼─────────┼─────┤
+	// │ Canary │ 8ab33d1 │ 5%  │
+	// └────────┴─────────┴─────┘
+}
@@ -0,0 +1,51 @@
+package deploymentsTable
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/taubyte/tau/core/services/patrick"
+)
+
+// ListNoRender lays out deployments in the order given, newest first from patrick.
+func ListNoRender(deployments []*patrick.Deployment) table.Writer {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetAllowedRowLength(79)
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Align: text.AlignCenter},
+		{Name: "Commit"},
+		{Name: "Deployed"},
+		{Name: "Assets"},
+	})
+
+	t.AppendHeader(table.Row{"", "Commit", "Deployed", "Assets"})
+
+	timeZone, _ := time.LoadLocation("Local")
+	for _, deployment := range deployments {
+		t.AppendRow(row(deployment, timeZone))
+		t.AppendSeparator()
+	}
+
+	return t
+}
+
+func row(deployment *patrick.Deployment, timeZone *time.Location) table.Row {
+	var current string
+	if deployment.Current {
+		current = "●"
+	}
+
+	deployed := time.Unix(0, deployment.Time).In(timeZone)
+
+	return table.Row{
+		current,
+		deployment.Commit,
+		deployed.Format("01/02/06") + "\n" + deployed.Format("3:04 PM"),
+		strconv.Itoa(len(deployment.Assets)),
+	}
+}
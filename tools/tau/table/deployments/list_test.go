@@ -0,0 +1,36 @@
+package deploymentsTable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/services/patrick"
+	"gotest.tools/v3/assert"
+)
+
+func TestRow(t *testing.T) {
+	deployed := time.Date(2026, time.March, 14, 22, 5, 0, 0, time.UTC)
+
+	r := row(&patrick.Deployment{
+		Commit:  "abc123",
+		Branch:  "main",
+		Time:    deployed.UnixNano(),
+		Assets:  map[string]string{"fn1": "cid1", "fn2": "cid2"},
+		Current: true,
+	}, time.UTC)
+
+	assert.Equal(t, r[0], "●")
+	assert.Equal(t, r[1], "abc123")
+	assert.Equal(t, r[2], "03/14/26\n10:05 PM")
+	assert.Equal(t, r[3], "2")
+
+	r = row(&patrick.Deployment{Commit: "def456", Time: deployed.UnixNano()}, time.UTC)
+	assert.Equal(t, r[0], "")
+	assert.Equal(t, r[3], "0")
+}
+
+func TestListNoRender(t *testing.T) {
+	tw := ListNoRender([]*patrick.Deployment{{Commit: "abc123"}})
+	assert.Assert(t, tw != nil)
+	assert.Equal(t, tw.Length(), 1)
+}
@@ -0,0 +1,62 @@
+package runsTable
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/taubyte/tau/core/services/patrick"
+)
+
+// ListNoRender lays out runs in the order given, newest first from patrick.
+func ListNoRender(runs []*patrick.ScheduledRun) table.Writer {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetAllowedRowLength(79)
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Align: text.AlignCenter},
+		{Name: "Scheduled"},
+		{Name: "Duration"},
+		{Name: "Details"},
+	})
+
+	t.AppendHeader(table.Row{"", "Scheduled", "Duration", "Details"})
+
+	timeZone, _ := time.LoadLocation("Local")
+	for _, run := range runs {
+		t.AppendRow(row(run, timeZone))
+		t.AppendSeparator()
+	}
+
+	return t
+}
+
+func row(run *patrick.ScheduledRun, timeZone *time.Location) table.Row {
+	scheduled := time.Unix(0, run.Scheduled).In(timeZone)
+
+	var duration string
+	if run.Started > 0 && run.Finished > 0 {
+		duration = time.Duration(run.Finished - run.Started).Round(time.Millisecond).String()
+	}
+
+	details := run.Error
+	if run.Status == patrick.RunMissed {
+		details = "missed"
+	}
+	if run.Missed > 0 {
+		if len(details) > 0 {
+			details += "\n"
+		}
+		details += fmt.Sprintf("%d earlier tick(s) skipped", run.Missed)
+	}
+
+	return table.Row{
+		run.Status.Unicode(),
+		scheduled.Format("01/02/06") + "\n" + scheduled.Format("3:04 PM"),
+		duration,
+		details,
+	}
+}
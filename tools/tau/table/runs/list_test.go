@@ -0,0 +1,37 @@
+package runsTable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/services/patrick"
+	"gotest.tools/v3/assert"
+)
+
+func TestRow(t *testing.T) {
+	scheduled := time.Date(2026, time.March, 14, 22, 5, 0, 0, time.UTC)
+
+	r := row(&patrick.ScheduledRun{
+		Scheduled: scheduled.UnixNano(),
+		Started:   scheduled.Add(time.Second).UnixNano(),
+		Finished:  scheduled.Add(3500 * time.Millisecond).UnixNano(),
+		Status:    patrick.RunFailed,
+		Missed:    2,
+		Error:     "boom",
+	}, time.UTC)
+
+	assert.Equal(t, r[0], "×")
+	assert.Equal(t, r[1], "03/14/26\n10:05 PM")
+	assert.Equal(t, r[2], "2.5s")
+	assert.Equal(t, r[3], "boom\n2 earlier tick(s) skipped")
+
+	r = row(&patrick.ScheduledRun{Scheduled: scheduled.UnixNano(), Status: patrick.RunMissed}, time.UTC)
+	assert.Equal(t, r[2], "")
+	assert.Equal(t, r[3], "missed")
+}
+
+func TestListNoRender(t *testing.T) {
+	tw := ListNoRender([]*patrick.ScheduledRun{{Status: patrick.RunSucceeded}})
+	assert.Assert(t, tw != nil)
+	assert.Equal(t, tw.Length(), 1)
+}
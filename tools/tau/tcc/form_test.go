@@ -49,7 +49,7 @@ func TestFormForFunction(t *testing.T) {
 	// enum -> select, its members come from the DSL
 	typ := byPath["trigger/type"]
 	assert.Equal(t, typ.Widget, WidgetSelect)
-	assert.DeepEqual(t, typ.Enum, []string{"http", "https", "pubsub", "p2p"})
+	assert.DeepEqual(t, typ.Enum, []string{"http", "https", "pubsub", "p2p", "schedule", "storage", "database"})
 
 	// a reference list, a scalar, and a bool switch
 	assert.Equal(t, byPath["trigger/domains"].Widget, WidgetRefList)
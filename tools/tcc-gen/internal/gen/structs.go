@@ -12,8 +12,9 @@ import (
 // fields (e.g. Function.Secure), transform fields (network-access -> Local/Public
 // bool), and hand-tuned mapstructure tags/names on git/cert fields. tcc-gen emits
 // the DSL-derivable field block as a reviewable proposal; the rest is hand-merged
-// at adoption. Two things ARE derived: uint64 duration/size types and the
-// mapstructure tag for compat-aliased fields.
+// at adoption. Three things ARE derived: uint64 duration/size types, the
+// mapstructure tag for compat-aliased fields, and *bool for bools defaulting to
+// true.
 
 // StructModel is the template model for one pkg/specs/structure/<res>.go file.
 type StructModel struct {
@@ -162,6 +163,12 @@ func Structs(root []*engine.Node) ([]*StructModel, error) {
 			if sc, ok := a.Meta["scalar"].(engine.ScalarSpec); ok && sc.GoType != "" {
 				gt = sc.GoType
 			}
+			// A bool defaulting to true decodes to a pointer: an object compiled
+			// before the attribute existed carries no key, which must read as
+			// the default rather than false.
+			if gt == "bool" && a.Default == true {
+				gt = "*bool"
+			}
 			f := Field{Name: nm, Type: gt, Tag: structTag(nm, a), Required: a.Required}
 			if enum, ok := a.Meta["enum"].([]string); ok {
 				f.Enum = enum
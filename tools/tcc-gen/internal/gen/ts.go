@@ -21,7 +21,7 @@ func tsType(goType string) string {
 		return "string"
 	case "[]string":
 		return "string[]"
-	case "bool":
+	case "bool", "*bool":
 		return "boolean"
 	case "int", "uint64":
 		return "number"
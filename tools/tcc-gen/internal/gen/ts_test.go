@@ -54,7 +54,7 @@ func TestGenerateTSAccessors(t *testing.T) {
 	ts := string(out)
 
 	for _, want := range []string{
-		`export type FunctionType = "http" | "https" | "pubsub" | "p2p";`,
+		`export type FunctionType = "http" | "https" | "pubsub" | "p2p" | "schedule" | "storage" | "database";`,
 		`function(name: string, app?: string): FunctionConfig {`, // Session factory (app-scoped)
 		`: ["functions", name];`,                                                      // resource path (ternary fallback)
 		`functionNames(app?: string): Promise<string[]> {`,                            // list
@@ -0,0 +1,172 @@
+package tccUtils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+	patrickIface "github.com/taubyte/tau/core/services/patrick"
+	tnsIface "github.com/taubyte/tau/core/services/tns"
+	specsCommon "github.com/taubyte/tau/pkg/specs/common"
+	"github.com/taubyte/tau/pkg/specs/methods"
+	"github.com/taubyte/tau/utils/mapstructure"
+)
+
+var logger = log.Logger("tau.utils.tcc")
+
+// assetGroups are the resources built from code, whose assets a deployment
+// records.
+var assetGroups = []string{"functions", "websites", "libraries"}
+
+// PublishCurrent points the branch of a project at commit, the one substrate
+// serves.
+func PublishCurrent(tns tnsIface.Client, projectID, branch, commit string) error {
+	err := tns.Push(
+		specsCommon.Current(projectID, branch).Slice(),
+		map[string]string{
+			specsCommon.CurrentCommitPathVariable.String(): commit,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("publishing current commit for project `%s` on branch `%s` failed with: %w", projectID, branch, err)
+	}
+
+	return nil
+}
+
+// resourceIds returns the ids of the resources of object built from code.
+func resourceIds(object map[string]interface{}) []string {
+	ids := make([]string, 0)
+	collect := func(scope map[string]interface{}) {
+		for _, group := range assetGroups {
+			if resources, ok := scope[group].(map[string]interface{}); ok {
+				for id := range resources {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+
+	collect(object)
+	if apps, ok := object[specsCommon.ApplicationPathVariable.String()].(map[string]interface{}); ok {
+		for _, app := range apps {
+			if scope, ok := app.(map[string]interface{}); ok {
+				collect(scope)
+			}
+		}
+	}
+
+	return ids
+}
+
+// publishDeployment records commit as deployed on the branch, along with the
+// assets its resources use now. Resources not built yet are left out, their
+// builds add them later.
+func publishDeployment(tns tnsIface.Client, object map[string]interface{}, projectID, branch, commit string) error {
+	assets := make(map[string]interface{})
+	for _, id := range resourceIds(object) {
+		assetPath, err := methods.GetTNSAssetPath(projectID, id, branch)
+		if err != nil {
+			continue
+		}
+
+		if obj, err := tns.Fetch(assetPath); err == nil {
+			if cid, ok := obj.Interface().(string); ok && cid != "" {
+				assets[id] = cid
+			}
+		}
+	}
+
+	err := tns.Push(
+		specsCommon.Deployment(projectID, branch, commit).Slice(),
+		map[string]interface{}{
+			"time":   time.Now().UnixNano(),
+			"assets": assets,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("recording deployment of commit `%s` for project `%s` on branch `%s` failed with: %w", commit, projectID, branch, err)
+	}
+
+	return nil
+}
+
+// CurrentCommit returns the commit the branch of a project is pointed at, empty
+// when nothing is deployed on it.
+func CurrentCommit(tns tnsIface.Client, projectID, branch string) (string, error) {
+	obj, err := tns.Fetch(specsCommon.Current(projectID, branch))
+	if err != nil {
+		return "", fmt.Errorf("fetching current commit for project `%s` on branch `%s` failed with: %w", projectID, branch, err)
+	}
+
+	commit, _ := obj.Interface().(string)
+	return commit, nil
+}
+
+// PreviousCommit returns the commit deployed on the branch of a project before
+// the current one, empty when there is none.
+func PreviousCommit(tns tnsIface.Client, projectID, branch string) (string, error) {
+	current, err := CurrentCommit(tns, projectID, branch)
+	if err != nil {
+		return "", err
+	}
+
+	obj, err := tns.Fetch(specsCommon.Deployments(projectID, branch))
+	if err != nil {
+		return "", fmt.Errorf("fetching deployments of project `%s` on branch `%s` failed with: %w", projectID, branch, err)
+	}
+
+	var records map[string]*patrickIface.Deployment
+	if err = mapstructure.Decode(obj.Interface(), &records); err != nil {
+		return "", fmt.Errorf("decoding deployments of project `%s` failed with: %w", projectID, err)
+	}
+
+	currentRecord, ok := records[current]
+	if !ok || currentRecord == nil {
+		return "", nil
+	}
+
+	var previous string
+	var previousTime int64
+	for commit, deployment := range records {
+		if deployment == nil || commit == current || deployment.Time >= currentRecord.Time {
+			continue
+		}
+
+		if previous == "" || deployment.Time > previousTime {
+			previous, previousTime = commit, deployment.Time
+		}
+	}
+
+	return previous, nil
+}
+
+// PublishBuiltAsset records the asset a resource was just built into under
+// the deployment of the config commit the branch is served at, the one using
+// it from now on. Deployments of other config commits keep their assets, so
+// rolling back to one serves what it was deployed with.
+func PublishBuiltAsset(tns tnsIface.Client, projectID, branch, resourceID, cid string) error {
+	commit, err := CurrentCommit(tns, projectID, branch)
+	if err != nil {
+		return err
+	}
+
+	// Nothing deployed yet: the first deployment records the asset.
+	if commit == "" {
+		return nil
+	}
+
+	return PublishDeploymentAsset(tns, projectID, branch, commit, resourceID, cid)
+}
+
+// PublishDeploymentAsset records the asset a resource built from code uses
+// under the deployment of a config commit, so rolling back to that commit
+// serves it, whatever the branch is pointed at now.
+func PublishDeploymentAsset(tns tnsIface.Client, projectID, branch, commit, resourceID, cid string) error {
+	err := tns.Push(append(specsCommon.Deployment(projectID, branch, commit).Slice(), "assets", resourceID), cid)
+	if err != nil {
+		return fmt.Errorf("recording asset of `%s` in deployment `%s` failed with: %w", resourceID, commit, err)
+	}
+
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	tnsIface "github.com/taubyte/tau/core/services/tns"
-	specsCommon "github.com/taubyte/tau/pkg/specs/common"
 	"github.com/taubyte/tau/pkg/specs/methods"
 )
 
@@ -35,14 +34,14 @@ func Publish(
 	}
 
 	// Publish current commit
-	err = tns.Push(
-		specsCommon.Current(projectID, branch).Slice(),
-		map[string]string{
-			specsCommon.CurrentCommitPathVariable.String(): commit,
-		},
-	)
-	if err != nil {
-		return fmt.Errorf("publishing current commit for project `%s` on branch `%s` failed with: %w", projectID, branch, err)
+	if err = PublishCurrent(tns, projectID, branch, commit); err != nil {
+		return err
+	}
+
+	// Record the deployment, so it can be rolled back to. The commit is live
+	// already, so failing to only costs rolling back to it.
+	if err = publishDeployment(tns, object, projectID, branch, commit); err != nil {
+		logger.Errorf("commit `%s` of project `%s` is live but can't be rolled back to: %s", commit, projectID, err)
 	}
 
 	return nil
@@ -1,7 +1,10 @@
 package tccUtils
 
 import (
+	"context"
 	"errors"
+	"sort"
+	"strings"
 	"testing"
 
 	peerCore "github.com/libp2p/go-libp2p/core/peer"
@@ -59,6 +62,10 @@ func (m *mockTNSClient) Peers(...peerCore.ID) tnsIface.Client {
 	return m
 }
 
+func (m *mockTNSClient) WithContext(context.Context) tnsIface.Client {
+	return m
+}
+
 func TestPublish_Success(t *testing.T) {
 	mockTNS := &mockTNSClient{}
 	object := map[string]interface{}{
@@ -72,8 +79,8 @@ func TestPublish_Success(t *testing.T) {
 	err := Publish(mockTNS, object, indexes, "QmTestProject123", "main", "abc123")
 	assert.NilError(t, err)
 
-	// Verify Push was called 3 times (indexes, object, current commit)
-	assert.Equal(t, len(mockTNS.pushCalls), 3)
+	// Verify Push was called 4 times (indexes, object, current commit, deployment)
+	assert.Equal(t, len(mockTNS.pushCalls), 4)
 
 	// First call should be indexes (empty path)
 	assert.Equal(t, len(mockTNS.pushCalls[0].path), 0)
@@ -98,6 +105,12 @@ func TestPublish_Success(t *testing.T) {
 	commitValue, exists := commitMap["current"]
 	assert.Assert(t, exists, "current key should exist")
 	assert.Equal(t, commitValue, "abc123")
+
+	// Fourth call records the deployment of the commit
+	assert.Equal(t, strings.Join(mockTNS.pushCalls[3].path, "/"), "projects/QmTestProject123/branches/main/deployments/abc123")
+	deployment, ok := mockTNS.pushCalls[3].value.(map[string]interface{})
+	assert.Assert(t, ok)
+	assert.Assert(t, deployment["time"].(int64) > 0)
 }
 
 func TestPublish_NilObject(t *testing.T) {
@@ -161,3 +174,56 @@ func TestPublish_CurrentCommitPushError(t *testing.T) {
 	err := Publish(mockTNS, object, indexes, "QmTestProject123", "main", "abc123")
 	assert.ErrorContains(t, err, "publishing current commit")
 }
+
+func TestPublish_DeploymentPushError(t *testing.T) {
+	mockTNS := &mockTNSClient{
+		pushErr:    errors.New("deployment push failed"),
+		failOnCall: 4,
+	}
+	object := map[string]interface{}{
+		"id": "QmTestProject123",
+	}
+	indexes := map[string]interface{}{}
+
+	// The commit is published even if it can't be rolled back to.
+	err := Publish(mockTNS, object, indexes, "QmTestProject123", "main", "abc123")
+	assert.NilError(t, err)
+	assert.Equal(t, len(mockTNS.pushCalls), 3)
+}
+
+func TestPublishDeploymentAsset(t *testing.T) {
+	mockTNS := &mockTNSClient{}
+
+	err := PublishDeploymentAsset(mockTNS, "QmTestProject123", "main", "abc123", "QmFunction", "QmAsset")
+	assert.NilError(t, err)
+	assert.Equal(t, len(mockTNS.pushCalls), 1)
+	assert.Assert(t, strings.Contains(strings.Join(mockTNS.pushCalls[0].path, "/"), "abc123"))
+	assert.Equal(t, mockTNS.pushCalls[0].path[len(mockTNS.pushCalls[0].path)-1], "QmFunction")
+	assert.Equal(t, mockTNS.pushCalls[0].value, "QmAsset")
+}
+
+func TestPublishBuiltAssetNotDeployed(t *testing.T) {
+	mockTNS := &mockTNSClient{}
+
+	// Without a current commit to fetch, nothing is recorded.
+	err := PublishBuiltAsset(mockTNS, "QmTestProject123", "main", "QmFunction", "QmAsset")
+	assert.ErrorContains(t, err, "fetching current commit")
+	assert.Equal(t, len(mockTNS.pushCalls), 0)
+}
+
+func TestResourceIds(t *testing.T) {
+	object := map[string]interface{}{
+		"functions": map[string]interface{}{"fn1": map[string]interface{}{}},
+		"databases": map[string]interface{}{"db1": map[string]interface{}{}},
+		"applications": map[string]interface{}{
+			"app1": map[string]interface{}{
+				"websites":  map[string]interface{}{"web1": map[string]interface{}{}},
+				"libraries": map[string]interface{}{"lib1": map[string]interface{}{}},
+			},
+		},
+	}
+
+	ids := resourceIds(object)
+	sort.Strings(ids)
+	assert.DeepEqual(t, ids, []string{"fn1", "lib1", "web1"})
+}